@@ -0,0 +1,86 @@
+// Answer statistics: how complete the candidate's answers are, stated to the evaluator as facts
+package ai
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/zidane0000/ai-interview-platform/utils"
+)
+
+// DefaultMinAnswerWords is the length in words below which an answer counts as trivially short
+const DefaultMinAnswerWords = 5
+
+// MetadataAnswerStats is set in EvaluationResponse.Metadata to the AnswerStats of the evaluated answers
+const MetadataAnswerStats = "answer_stats"
+
+// AnswerStats describes the completeness of a set of answers
+// Words are counted with utils.CountWords, so each CJK character is one word.
+type AnswerStats struct {
+	WordCounts []int `json:"word_counts"` // Words in each answer, in question order
+	MinWords   int   `json:"min_words"`   // Answers with fewer words count as too short
+	Empty      int   `json:"empty"`       // Answers skipped or without a single word
+	TooShort   int   `json:"too_short"`   // Answers given but shorter than MinWords
+}
+
+// Unanswered is the number of questions effectively left unanswered: empty or too short answers
+func (s AnswerStats) Unanswered() int {
+	return s.Empty + s.TooShort
+}
+
+// ComputeAnswerStats counts the words of each answer against minWords; 0 uses DefaultMinAnswerWords
+func ComputeAnswerStats(answers []string, minWords int) AnswerStats {
+	if minWords <= 0 {
+		minWords = DefaultMinAnswerWords
+	}
+	stats := AnswerStats{WordCounts: make([]int, len(answers)), MinWords: minWords}
+	for i, answer := range answers {
+		words := utils.CountWords(answer)
+		stats.WordCounts[i] = words
+		switch {
+		case words == 0:
+			stats.Empty++
+		case words < minWords:
+			stats.TooShort++
+		}
+	}
+	return stats
+}
+
+// AnswerStats returns the answer statistics recorded in the response's metadata
+func (r *EvaluationResponse) AnswerStats() (AnswerStats, bool) {
+	stats, ok := r.Metadata[MetadataAnswerStats].(AnswerStats)
+	return stats, ok
+}
+
+// answerStatsSection states stats in the evaluation prompt and asks for incomplete answers to
+// weigh on the scores, since models otherwise tend to grade one-word answers on what they imply
+func answerStatsSection(stats AnswerStats) string {
+	var b strings.Builder
+	b.WriteString("Answer Statistics (counted from the answers below):\n")
+	b.WriteString(fmt.Sprintf("- %d of %d questions effectively unanswered: %d skipped or empty, %d shorter than %d words\n",
+		stats.Unanswered(), len(stats.WordCounts), stats.Empty, stats.TooShort, stats.MinWords))
+	counts := make([]string, len(stats.WordCounts))
+	for i, words := range stats.WordCounts {
+		counts[i] = fmt.Sprintf("A%d: %d", i+1, words)
+		switch {
+		case words == 0:
+			counts[i] += " (empty)"
+		case words < stats.MinWords:
+			counts[i] += " (too short)"
+		}
+	}
+	b.WriteString("- Words per answer: " + strings.Join(counts, ", ") + "\n")
+	b.WriteString("Weigh answer completeness: an unanswered or trivially short answer shows no evidence of what its " +
+		"question asks about, so it must lower the scores. Do not credit the candidate for what they did not say.\n")
+	return b.String()
+}
+
+// withAnswerStats records stats in metadata
+func withAnswerStats(metadata map[string]interface{}, stats AnswerStats) map[string]interface{} {
+	if metadata == nil {
+		metadata = make(map[string]interface{})
+	}
+	metadata[MetadataAnswerStats] = stats
+	return metadata
+}
@@ -0,0 +1,99 @@
+package ai
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestComputeAnswerStats(t *testing.T) {
+	tests := []struct {
+		name     string
+		answers  []string
+		minWords int
+		expected AnswerStats
+	}{
+		{
+			name:     "english answers",
+			answers:  []string{"I would profile the service first, then cache hot reads.", "Yes.", "", "   "},
+			minWords: 5,
+			expected: AnswerStats{WordCounts: []int{10, 1, 0, 0}, MinWords: 5, Empty: 2, TooShort: 1},
+		},
+		{
+			// Each CJK character is a word, so a short phrase is still several words
+			name:     "traditional chinese answers",
+			answers:  []string{"我會先分析效能瓶頸，再快取熱門資料。", "不知道", "……"},
+			minWords: 5,
+			expected: AnswerStats{WordCounts: []int{16, 3, 0}, MinWords: 5, Empty: 1, TooShort: 1},
+		},
+		{
+			name:     "mixed script answer",
+			answers:  []string{"用 Go 寫 table tests"},
+			minWords: 0,
+			expected: AnswerStats{WordCounts: []int{5}, MinWords: DefaultMinAnswerWords},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ComputeAnswerStats(tt.answers, tt.minWords)
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("ComputeAnswerStats() = %+v, expected %+v", got, tt.expected)
+			}
+		})
+	}
+	if unanswered := (AnswerStats{Empty: 2, TooShort: 1}).Unanswered(); unanswered != 3 {
+		t.Errorf("Expected 3 effectively unanswered, got %d", unanswered)
+	}
+}
+
+func TestBuildEvaluationPrompt_AnswerStats(t *testing.T) {
+	req := &EvaluationRequest{
+		Questions: []string{"Q1", "Q2", "Q3"},
+		Answers:   []string{"A thorough answer about caching and invalidation", "", "Yes"},
+		Language:  "en",
+	}
+	if prompt := BuildEvaluationPrompt(req); strings.Contains(prompt, "Answer Statistics") {
+		t.Error("Expected no answer statistics without stats")
+	}
+
+	stats := ComputeAnswerStats(req.Answers, 5)
+	req.AnswerStats = &stats
+	prompt := BuildEvaluationPrompt(req)
+	for _, expected := range []string{
+		"Answer Statistics (counted from the answers below):",
+		"- 2 of 3 questions effectively unanswered: 1 skipped or empty, 1 shorter than 5 words",
+		"- Words per answer: A1: 7, A2: 0 (empty), A3: 1 (too short)",
+		"unanswered or trivially short answer shows no evidence",
+	} {
+		if !strings.Contains(prompt, expected) {
+			t.Errorf("Expected prompt to contain %q, got:\n%s", expected, prompt)
+		}
+	}
+}
+
+func TestEvaluateAnswersDetailed_AnswerStats(t *testing.T) {
+	provider := NewMockProvider()
+	client := NewAIClientWithProvider(provider, &AIConfig{DefaultModel: "mock-model"})
+
+	resp, err := client.EvaluateAnswersDetailed(context.Background(), []string{"Q1", "Q2"}, []string{"OK", "I would shard by tenant"}, EvaluationContext{
+		Language:       "en",
+		MinAnswerWords: 3,
+	})
+	if err != nil {
+		t.Fatalf("EvaluateAnswersDetailed failed: %v", err)
+	}
+	stats, ok := resp.AnswerStats()
+	if !ok || !reflect.DeepEqual(stats, AnswerStats{WordCounts: []int{1, 5}, MinWords: 3, TooShort: 1}) {
+		t.Errorf("Expected the answer stats in the metadata, got %+v", resp.Metadata[MetadataAnswerStats])
+	}
+	if req := provider.EvaluationRequests()[0]; req.AnswerStats == nil || req.AnswerStats.TooShort != 1 {
+		t.Errorf("Expected the stats sent with the request, got %+v", req.AnswerStats)
+	}
+
+	// An empty answer set still skips the provider
+	resp, err = client.EvaluateAnswersDetailed(context.Background(), nil, nil, EvaluationContext{})
+	if err != nil || resp.Feedback != "No answers provided." || len(provider.EvaluationRequests()) != 1 {
+		t.Errorf("Expected the empty answer set short-circuited, got %+v, %v", resp, err)
+	}
+}
@@ -5,9 +5,11 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
 )
@@ -21,6 +23,12 @@ type ProviderAdapter interface {
 	GetEndpointURL(endpoint string) string
 }
 
+// DefaultMaxResponseBytes is the largest provider response body read when AIConfig.MaxResponseBytes is unset
+const DefaultMaxResponseBytes = 10 << 20
+
+// ErrResponseTooLarge is returned when a provider's response body exceeds AIConfig.MaxResponseBytes
+var ErrResponseTooLarge = errors.New("AI provider response too large")
+
 // BaseProvider contains shared logic and configuration for all AI providers
 type BaseProvider struct {
 	config     *AIConfig
@@ -61,7 +69,7 @@ func (b *BaseProvider) MakeRequest(ctx context.Context, adapter ProviderAdapter,
 	}
 	defer resp.Body.Close()
 
-	body, err := io.ReadAll(resp.Body)
+	body, err := readResponseBody(resp.Body, b.maxResponseBytes())
 	if err != nil {
 		return nil, fmt.Errorf("failed to read response: %w", err)
 	}
@@ -73,15 +81,46 @@ func (b *BaseProvider) MakeRequest(ctx context.Context, adapter ProviderAdapter,
 	return body, nil
 }
 
-// GetModelName returns the model name, using the default if not specified
-func (b *BaseProvider) GetModelName(model, defaultModel string) string {
-	if model == "" {
-		if defaultModel != "" {
-			return defaultModel
-		}
-		return b.config.DefaultModel
+// maxResponseBytes is the configured cap on response bodies, DefaultMaxResponseBytes when unset
+func (b *BaseProvider) maxResponseBytes() int {
+	if b.config != nil && b.config.MaxResponseBytes > 0 {
+		return b.config.MaxResponseBytes
 	}
-	return model
+	return DefaultMaxResponseBytes
+}
+
+// readResponseBody reads body, failing with ErrResponseTooLarge once it passes limit bytes
+// Only limit+1 bytes are ever buffered; the caller closes the body, dropping the connection of an
+// oversized response rather than reading the rest of it.
+func readResponseBody(body io.Reader, limit int) ([]byte, error) {
+	data, err := io.ReadAll(io.LimitReader(body, int64(limit)+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > limit {
+		return nil, fmt.Errorf("%w: more than %d bytes", ErrResponseTooLarge, limit)
+	}
+	return data, nil
+}
+
+// GetModelName returns the model name when specified, otherwise the provider's entry in
+// ProviderDefaultModels, then defaultModel, then the global DefaultModel
+// Retired names are rewritten to their replacement (see ResolveModel), with a warning logged once.
+func (b *BaseProvider) GetModelName(model, provider, defaultModel string) string {
+	switch {
+	case model != "":
+	case b.config.ProviderDefaultModels[provider] != "":
+		model = b.config.ProviderDefaultModels[provider]
+	case defaultModel != "":
+		model = defaultModel
+	default:
+		model = b.config.DefaultModel
+	}
+	resolved, alias := ResolveModel(model, b.config.ModelAliases)
+	if alias != nil {
+		warnDeprecatedModel(model, *alias)
+	}
+	return resolved
 }
 
 // --- Shared Prompt Builders ---
@@ -100,6 +139,8 @@ Job Description:
 Candidate Resume:
 %s
 
+%s
+
 Generate %d relevant interview questions that:
 1. Assess the candidate's skills and experience based on the job description
 2. Are appropriate for the %s level
@@ -112,21 +153,156 @@ Category: [technical/behavioral/situational]
 Difficulty: [easy/medium/hard]
 Expected Time: [minutes]
 
-Provide diverse questions that thoroughly evaluate the candidate for this role.`,
+Provide diverse questions that thoroughly evaluate the candidate for this role.
+%s`,
+		req.ExperienceLevel, req.InterviewType, req.Difficulty,
+		quoteDocument(req.JobDescription), quoteDocument(req.ResumeContent), documentGuardInstruction, req.NumQuestions,
 		req.ExperienceLevel, req.InterviewType, req.Difficulty,
-		req.JobDescription, req.ResumeContent, req.NumQuestions,
-		req.ExperienceLevel, req.InterviewType, req.Difficulty)
+		questionLanguageInstruction(req.Language))
+}
+
+// questionLanguageInstruction asks for the question texts in the interview language while keeping
+// the English field markers the parser relies on
+func questionLanguageInstruction(language string) string {
+	name := languages["en"].Name
+	if info, ok := lookupLanguage(language); ok {
+		name = info.Name
+	} else if language != "" {
+		name = language
+	}
+	return fmt.Sprintf("Write every question text in %s. Keep the field names (Question, Category, Difficulty, Expected Time) "+
+		"and the category and difficulty values in English.", name)
+}
+
+// Prompt truncation limits (in characters) for optional evaluation context
+const (
+	maxPromptResumeLength  = 3000
+	maxPromptCompanyLength = 1000
+)
+
+// interviewTypeFocus describes what the evaluator should weigh for each interview type
+var interviewTypeFocus = map[string]string{
+	"general":    "Focus on overall communication, motivation, and relevant experience.",
+	"technical":  "Focus on technical accuracy, problem-solving approach, and depth of knowledge.",
+	"behavioral": "Focus on soft skills, concrete examples (situation, action, result), leadership, and cultural fit. Do not penalize a lack of technical detail.",
+}
+
+// ValidateDetailLevel reports whether level is a supported evaluation detail level
+func ValidateDetailLevel(level string) bool {
+	switch level {
+	case DetailLevelBrief, DetailLevelStandard, DetailLevelDetailed:
+		return true
+	}
+	return false
+}
+
+// normalizeDetailLevel maps empty or unsupported detail levels to the standard level
+func normalizeDetailLevel(level string) string {
+	if ValidateDetailLevel(level) {
+		return level
+	}
+	return DetailLevelStandard
+}
+
+// evaluationFormats is the response format requested at each detail level
+// ParseEvaluationResponse understands every section used here
+var evaluationFormats = map[string]string{
+	DetailLevelBrief: `Feedback: [concise feedback]
+
+Strengths:
+- [strength 1]
+
+Areas for Improvement:
+- [area 1]
+
+Keep the evaluation brief and do not include recommendations.`,
+	DetailLevelStandard: `Feedback: [comprehensive feedback paragraph]
+
+Strengths:
+- [strength 1]
+- [strength 2]
+
+Areas for Improvement:
+- [area 1]
+- [area 2]
+
+Recommendations:
+- [specific recommendation 1]
+- [specific recommendation 2]
+
+Be specific, constructive, and fair in your evaluation.`,
+	DetailLevelDetailed: `Feedback: [comprehensive feedback paragraph]
+
+Strengths:
+- [strength 1]
+- [strength 2]
+
+Areas for Improvement:
+- [area 1]
+- [area 2]
+
+Recommendations:
+- [specific recommendation 1]
+- [specific recommendation 2]
+
+Per-Question Feedback:
+- Q1: [commentary on the answer to question 1]
+- Q2: [commentary on the answer to question 2]
+
+Comment on every question in the Per-Question Feedback section, using its Q number.
+Be specific, constructive, and fair in your evaluation.`,
 }
 
 // BuildEvaluationPrompt creates the prompt for evaluating interview answers
+// Interview type, company context, resume, session notes, conversation summary, language note and
+// answer statistics sections are only included when present; the job description, company context and resume are
+// delimited as data (see quoteDocument). The response format follows req.DetailLevel and always
+// ends with a recommendation decision and next steps; the content is written in req.Language under
+// the English section markers
 func BuildEvaluationPrompt(req *EvaluationRequest) string {
 	criteriaText := strings.Join(req.Criteria, ", ")
 
-	return fmt.Sprintf(`You are an expert interview evaluator. Evaluate the candidate's answers objectively and provide detailed feedback.
+	var contextText strings.Builder
+	if req.InterviewType != "" {
+		contextText.WriteString(fmt.Sprintf("Interview Type: %s\n", req.InterviewType))
+		if focus, ok := interviewTypeFocus[req.InterviewType]; ok {
+			contextText.WriteString(focus + "\n")
+		}
+	}
+	if req.CompanyContext != "" {
+		contextText.WriteString(fmt.Sprintf("\nCompany Context:\n%s\n", quoteDocument(truncateForPrompt(req.CompanyContext, maxPromptCompanyLength))))
+	}
+	if req.ResumeContent != "" {
+		contextText.WriteString(fmt.Sprintf("\nCandidate Resume:\n%s\n", quoteDocument(truncateForPrompt(req.ResumeContent, maxPromptResumeLength))))
+	}
+	if len(req.SessionNotes) > 0 {
+		contextText.WriteString("\nSession Notes:\n")
+		for _, note := range req.SessionNotes {
+			contextText.WriteString(fmt.Sprintf("- %s\n", note))
+		}
+	}
+	if req.ConversationSummary != "" {
+		contextText.WriteString(fmt.Sprintf("\nConversation Summary:\n%s\n", req.ConversationSummary))
+	}
+	if req.LanguageMismatch {
+		contextText.WriteString("\n" + evaluationLanguageNote(req.Language) + "\n")
+	}
+	if req.AnswerStats != nil {
+		contextText.WriteString("\n" + answerStatsSection(*req.AnswerStats))
+	}
+	if contextText.Len() > 0 {
+		contextText.WriteString("\n")
+	}
+
+	detailLevel := normalizeDetailLevel(req.DetailLevel)
+	return fmt.Sprintf(`You are an expert interview evaluator. Evaluate the candidate's answers objectively and provide feedback at the requested detail level.
 
-Job Description: %s
-Evaluation Criteria: %s
+Job Description:
+%s
+%s
+%sEvaluation Criteria: %s
 Detail Level: %s
+Feedback Length: at most %d words, written as plain paragraphs without markdown headings
 
 Provide evaluation in this format:
 Overall Score: [0.0-1.0]
@@ -136,22 +312,58 @@ Category Scores:
 - Problem Solving: [0.0-1.0]
 - Experience: [0.0-1.0]
 
-Feedback: [comprehensive feedback paragraph]
+%s
 
-Strengths:
-- [strength 1]
-- [strength 2]
+%s
 
-Areas for Improvement:
-- [area 1]
-- [area 2]
+%s`,
+		quoteDocument(req.JobDesc), documentGuardInstruction, contextText.String(), criteriaText, detailLevel, feedbackWordLimit(detailLevel, req.MaxFeedbackWords),
+		evaluationFormats[detailLevel], decisionFormat, evaluationLanguageInstruction(req.Language))
+}
 
-Recommendations:
-- [specific recommendation 1]
-- [specific recommendation 2]
+// evaluationLanguageInstruction asks for the evaluation content in the interview language while
+// keeping the English section markers the parser relies on
+func evaluationLanguageInstruction(language string) string {
+	name := languages["en"].Name
+	if info, ok := lookupLanguage(language); ok {
+		name = info.Name
+	} else if language != "" {
+		name = language
+	}
+	return fmt.Sprintf("Write the feedback, strengths, areas for improvement, recommendations and next steps in %s. "+
+		"Keep the section markers (Overall Score, Category Scores, Feedback, Strengths, Areas for Improvement, Recommendations, "+
+		"Per-Question Feedback, Recommendation Decision, Next Steps) and the decision value in English, exactly as shown.", name)
+}
 
-Be specific, constructive, and fair in your evaluation.`,
-		req.JobDesc, criteriaText, req.DetailLevel)
+// decisionFormat ends every evaluation with a recommendation recruiters can act on
+var decisionFormat = fmt.Sprintf(`Finish with your recommendation and up to %d concrete next steps for the recruiter
+(for example "Advance to the onsite round" or "Schedule a follow-up on system design"):
+Recommendation Decision: [exactly one of: %s]
+
+Next Steps:
+- [next step 1]`, MaxNextSteps, strings.Join(Decisions, ", "))
+
+// evaluationLanguageNote asks for answers given in another language than the interview to be judged
+// on their content, with the evaluation still written in the interview language
+func evaluationLanguageNote(language string) string {
+	name := language
+	if info, ok := lookupLanguage(language); ok {
+		name = info.Name
+	} else if language == "" {
+		name = languages["en"].Name
+	}
+	return fmt.Sprintf("Language Note: The candidate mostly answered in a different language than the interview language (%s). "+
+		"Assess the content of the answers regardless of the language they are written in, without penalizing the choice of language, "+
+		"and still write the whole evaluation in %s.", name, name)
+}
+
+// truncateForPrompt shortens text to maxChars characters, marking the cut
+func truncateForPrompt(text string, maxChars int) string {
+	runes := []rune(strings.TrimSpace(text))
+	if len(runes) <= maxChars {
+		return string(runes)
+	}
+	return string(runes[:maxChars]) + "... [truncated]"
 }
 
 // FormatAnswersForEvaluation formats questions and answers for evaluation
@@ -176,7 +388,8 @@ func ParseQuestionResponse(content string) []InterviewQuestion {
 
 	var currentQuestion InterviewQuestion
 	for _, line := range lines {
-		line = strings.TrimSpace(line)
+		// CJK output sometimes uses full-width colons for the field markers
+		line = strings.Replace(strings.TrimSpace(line), "：", ":", 1)
 		if strings.HasPrefix(line, "Question:") {
 			currentQuestion.Question = strings.TrimSpace(line[9:])
 		} else if strings.HasPrefix(line, "Category:") {
@@ -196,7 +409,125 @@ func ParseQuestionResponse(content string) []InterviewQuestion {
 	return questions
 }
 
+// ParseQuestionAlternatives parses the questions of a response and of its alternatives, in order,
+// dropping repeats (compared ignoring case and surrounding whitespace); with numQuestions > 0 at
+// most that many are kept, so alternatives fill in for questions the first output left out
+func ParseQuestionAlternatives(response *ChatResponse, numQuestions int) []InterviewQuestion {
+	var questions []InterviewQuestion
+	seen := make(map[string]bool)
+	for _, content := range append([]string{response.Content}, response.Alternatives...) {
+		for _, question := range ParseQuestionResponse(content) {
+			key := strings.ToLower(strings.TrimSpace(question.Question))
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			questions = append(questions, question)
+			if numQuestions > 0 && len(questions) == numQuestions {
+				return questions
+			}
+		}
+	}
+	return questions
+}
+
+// parseQuestionFeedback parses a per-question feedback item such as "Q2: Clear example"
+func parseQuestionFeedback(item string) (QuestionFeedback, bool) {
+	label, text, found := strings.Cut(strings.Replace(item, "：", ":", 1), ":")
+	if !found || !strings.HasPrefix(label, "Q") {
+		return QuestionFeedback{}, false
+	}
+	number, err := strconv.Atoi(strings.TrimSpace(label[1:]))
+	if err != nil || number < 1 {
+		return QuestionFeedback{}, false
+	}
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return QuestionFeedback{}, false
+	}
+	return QuestionFeedback{Question: number, Feedback: text}, true
+}
+
+// cutPrefixFold is strings.CutPrefix ignoring case, with the rest trimmed
+func cutPrefixFold(line, prefix string) (string, bool) {
+	if len(line) < len(prefix) || !strings.EqualFold(line[:len(prefix)], prefix) {
+		return "", false
+	}
+	return strings.TrimSpace(line[len(prefix):]), true
+}
+
+// parseDecision normalizes a recommendation decision such as "Strong Hire" or "**no-hire**"
+// Anything that isn't one of Decisions is invalid; a decision is never guessed
+func parseDecision(value string) (string, bool) {
+	decision := strings.ToLower(strings.Trim(value, " *_`'\"[]."))
+	decision = strings.NewReplacer(" ", "_", "-", "_").Replace(decision)
+	for _, valid := range Decisions {
+		if decision == valid {
+			return decision, true
+		}
+	}
+	return "", false
+}
+
+// Sections of an evaluation response
+const (
+	sectionFeedback        = "feedback"
+	sectionStrengths       = "strengths"
+	sectionWeaknesses      = "weaknesses"
+	sectionRecommendations = "recommendations"
+	sectionPerQuestion     = "per_question"
+	sectionDecision        = "decision"
+	sectionNextSteps       = "next_steps"
+)
+
+// evaluationSectionMarkers maps the markers opening each section of an evaluation response to the
+// section. The prompt asks for the English markers; the Traditional Chinese ones are tolerated
+// because models sometimes translate the markers along with the content.
+var evaluationSectionMarkers = []struct {
+	marker  string
+	section string
+}{
+	{"Feedback", sectionFeedback},
+	{"Strengths", sectionStrengths},
+	{"Areas for Improvement", sectionWeaknesses},
+	{"Recommendations", sectionRecommendations},
+	{"Per-Question Feedback", sectionPerQuestion},
+	{"Recommendation Decision", sectionDecision},
+	{"Next Steps", sectionNextSteps},
+	{"回饋", sectionFeedback},
+	{"評語", sectionFeedback},
+	{"整體回饋", sectionFeedback},
+	{"優點", sectionStrengths},
+	{"優勢", sectionStrengths},
+	{"待改進", sectionWeaknesses},
+	{"待改進之處", sectionWeaknesses},
+	{"需改進", sectionWeaknesses},
+	{"建議", sectionRecommendations},
+	{"改進建議", sectionRecommendations},
+	{"逐題回饋", sectionPerQuestion},
+	{"各題回饋", sectionPerQuestion},
+	{"建議決定", sectionDecision},
+	{"錄取建議", sectionDecision},
+	{"後續步驟", sectionNextSteps},
+	{"下一步", sectionNextSteps},
+}
+
+// cutSectionMarker reports the section a line of an evaluation response opens, with the text
+// after its marker. Markers are matched regardless of casing and may end in a full-width colon,
+// as CJK output often does.
+func cutSectionMarker(line string) (section, rest string, ok bool) {
+	for _, m := range evaluationSectionMarkers {
+		for _, colon := range []string{":", "："} {
+			if rest, ok := cutPrefixFold(line, m.marker+colon); ok {
+				return m.section, rest, true
+			}
+		}
+	}
+	return "", "", false
+}
+
 // ParseEvaluationResponse parses the AI response to extract evaluation data
+// Sections are recognized by their English or Traditional Chinese markers (see evaluationSectionMarkers)
 func ParseEvaluationResponse(content string) *EvaluationResponse {
 	evaluation := &EvaluationResponse{
 		OverallScore:    0.7,
@@ -215,30 +546,27 @@ func ParseEvaluationResponse(content string) *EvaluationResponse {
 		line = strings.TrimSpace(line)
 
 		// Handle section headers
-		if strings.HasPrefix(line, "Feedback:") {
-			inFeedback = true
+		if section, rest, ok := cutSectionMarker(line); ok {
+			inFeedback = section == sectionFeedback
 			currentSection = ""
-			feedbackText := strings.TrimSpace(line[9:])
-			if feedbackText != "" {
-				feedbackLines = append(feedbackLines, feedbackText)
+			switch section {
+			case sectionFeedback:
+				if rest != "" {
+					feedbackLines = append(feedbackLines, rest)
+				}
+			case sectionDecision:
+				if decision, valid := parseDecision(rest); valid {
+					evaluation.Decision = decision
+				} else if rest != "" {
+					evaluation.Metadata = map[string]interface{}{
+						MetadataDecisionWarning: fmt.Sprintf("dropped unrecognized recommendation decision %q", rest),
+					}
+				}
+			default:
+				currentSection = section
 			}
 			continue
 		}
-		if strings.HasPrefix(line, "Strengths:") {
-			inFeedback = false
-			currentSection = "strengths"
-			continue
-		}
-		if strings.HasPrefix(line, "Areas for Improvement:") {
-			inFeedback = false
-			currentSection = "weaknesses"
-			continue
-		}
-		if strings.HasPrefix(line, "Recommendations:") {
-			inFeedback = false
-			currentSection = "recommendations"
-			continue
-		}
 
 		// Handle feedback content
 		if inFeedback && line != "" {
@@ -249,12 +577,20 @@ func ParseEvaluationResponse(content string) *EvaluationResponse {
 		if strings.HasPrefix(line, "- ") && len(line) > 2 {
 			item := strings.TrimSpace(line[2:])
 			switch currentSection {
-			case "strengths":
+			case sectionStrengths:
 				evaluation.Strengths = append(evaluation.Strengths, item)
-			case "weaknesses":
+			case sectionWeaknesses:
 				evaluation.Weaknesses = append(evaluation.Weaknesses, item)
-			case "recommendations":
+			case sectionRecommendations:
 				evaluation.Recommendations = append(evaluation.Recommendations, item)
+			case sectionPerQuestion:
+				if feedback, ok := parseQuestionFeedback(item); ok {
+					evaluation.PerQuestionFeedback = append(evaluation.PerQuestionFeedback, feedback)
+				}
+			case sectionNextSteps:
+				if len(evaluation.NextSteps) < MaxNextSteps {
+					evaluation.NextSteps = append(evaluation.NextSteps, item)
+				}
 			}
 		}
 	}
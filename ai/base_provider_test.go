@@ -1,11 +1,15 @@
 package ai
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
+	"reflect"
 	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 )
@@ -36,18 +40,28 @@ func TestNewBaseProvider(t *testing.T) {
 // TestGetModelName tests the model fallback/precedence logic
 func TestGetModelName(t *testing.T) {
 	testCases := []struct {
-		name          string
-		model         string
-		defaultModel  string
-		configDefault string
-		expected      string
+		name            string
+		model           string
+		defaultModel    string
+		configDefault   string
+		providerDefault string
+		expected        string
 	}{
 		{
-			name:          "use provided model",
-			model:         "model-a",
-			defaultModel:  "model-b",
-			configDefault: "config-default",
-			expected:      "model-a",
+			name:            "use provided model",
+			model:           "model-a",
+			defaultModel:    "model-b",
+			configDefault:   "config-default",
+			providerDefault: "provider-default",
+			expected:        "model-a",
+		},
+		{
+			name:            "use provider default before other defaults",
+			model:           "",
+			defaultModel:    "model-b",
+			configDefault:   "config-default",
+			providerDefault: "provider-default",
+			expected:        "provider-default",
 		},
 		{
 			name:          "use default when model empty",
@@ -76,10 +90,14 @@ func TestGetModelName(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			config := &AIConfig{
 				DefaultModel: tc.configDefault,
+				ProviderDefaultModels: map[string]string{
+					"test-provider":  tc.providerDefault,
+					"other-provider": "other-default",
+				},
 			}
 			bp := NewBaseProvider(config, "https://api.example.com", 10*time.Second)
 
-			result := bp.GetModelName(tc.model, tc.defaultModel)
+			result := bp.GetModelName(tc.model, "test-provider", tc.defaultModel)
 
 			if result != tc.expected {
 				t.Errorf("Expected '%s', got '%s'", tc.expected, result)
@@ -634,6 +652,104 @@ Recommendations:
 	}
 }
 
+// TestParseEvaluationResponse_LocalizedMarkers tests that Traditional Chinese section markers separate sections
+func TestParseEvaluationResponse_LocalizedMarkers(t *testing.T) {
+	input := `回饋：候選人整體表現良好，回答條理清晰。
+
+優點：
+- 扎實的技術基礎
+- 溝通清楚
+
+待改進：
+- 時間管理
+
+建議：
+- 多練習系統設計
+
+逐題回饋：
+- Q1：舉例具體
+
+建議決定：hire
+
+後續步驟：
+- 安排第二輪面試`
+
+	evaluation := ParseEvaluationResponse(input)
+	if evaluation.Feedback != "候選人整體表現良好，回答條理清晰。" {
+		t.Errorf("unexpected feedback: %q", evaluation.Feedback)
+	}
+	if !reflect.DeepEqual(evaluation.Strengths, []string{"扎實的技術基礎", "溝通清楚"}) {
+		t.Errorf("unexpected strengths: %v", evaluation.Strengths)
+	}
+	if !reflect.DeepEqual(evaluation.Weaknesses, []string{"時間管理"}) {
+		t.Errorf("unexpected weaknesses: %v", evaluation.Weaknesses)
+	}
+	if !reflect.DeepEqual(evaluation.Recommendations, []string{"多練習系統設計"}) {
+		t.Errorf("unexpected recommendations: %v", evaluation.Recommendations)
+	}
+	if !reflect.DeepEqual(evaluation.PerQuestionFeedback, []QuestionFeedback{{Question: 1, Feedback: "舉例具體"}}) {
+		t.Errorf("unexpected per-question feedback: %v", evaluation.PerQuestionFeedback)
+	}
+	if evaluation.Decision != "hire" {
+		t.Errorf("expected the hire decision, got %q", evaluation.Decision)
+	}
+	if !reflect.DeepEqual(evaluation.NextSteps, []string{"安排第二輪面試"}) {
+		t.Errorf("unexpected next steps: %v", evaluation.NextSteps)
+	}
+}
+
+// TestParseEvaluationResponse_MixedMarkers tests English markers with Chinese content mixed with localized markers
+func TestParseEvaluationResponse_MixedMarkers(t *testing.T) {
+	input := `Feedback: 回答完整，但細節不足。
+整體而言表現穩定。
+
+Strengths:
+- 經驗豐富
+
+待改進之處：
+- 缺乏量化成果
+
+Recommendations:
+- 補充具體數據
+
+Recommendation Decision: strong_hire`
+
+	evaluation := ParseEvaluationResponse(input)
+	if evaluation.Feedback != "回答完整，但細節不足。 整體而言表現穩定。" {
+		t.Errorf("unexpected feedback: %q", evaluation.Feedback)
+	}
+	if !reflect.DeepEqual(evaluation.Strengths, []string{"經驗豐富"}) {
+		t.Errorf("unexpected strengths: %v", evaluation.Strengths)
+	}
+	if !reflect.DeepEqual(evaluation.Weaknesses, []string{"缺乏量化成果"}) {
+		t.Errorf("unexpected weaknesses: %v", evaluation.Weaknesses)
+	}
+	if !reflect.DeepEqual(evaluation.Recommendations, []string{"補充具體數據"}) {
+		t.Errorf("unexpected recommendations: %v", evaluation.Recommendations)
+	}
+	if evaluation.Decision != "strong_hire" {
+		t.Errorf("expected the strong_hire decision, got %q", evaluation.Decision)
+	}
+}
+
+// TestBuildEvaluationPrompt_LanguageInstruction verifies content is requested in the interview language under English markers
+func TestBuildEvaluationPrompt_LanguageInstruction(t *testing.T) {
+	prompt := BuildEvaluationPrompt(&EvaluationRequest{JobDesc: "Engineer", Language: "zh-TW"})
+	for _, expected := range []string{
+		"Write the feedback, strengths, areas for improvement, recommendations and next steps in Traditional Chinese (繁體中文)",
+		"Keep the section markers",
+		"in English, exactly as shown",
+	} {
+		if !strings.Contains(prompt, expected) {
+			t.Errorf("Expected prompt to contain '%s'", expected)
+		}
+	}
+
+	if prompt := BuildEvaluationPrompt(&EvaluationRequest{JobDesc: "Engineer"}); !strings.Contains(prompt, "next steps in English.") {
+		t.Error("Expected the evaluation to be requested in English by default")
+	}
+}
+
 // TestMakeRequest_MarshalError tests handling of unmarshalable payloads
 func TestMakeRequest_MarshalError(t *testing.T) {
 	config := &AIConfig{}
@@ -657,6 +773,43 @@ func TestMakeRequest_MarshalError(t *testing.T) {
 	}
 }
 
+// TestBuildQuestionGenerationPrompt_Language verifies questions are requested in the interview language
+func TestBuildQuestionGenerationPrompt_Language(t *testing.T) {
+	prompt := BuildQuestionGenerationPrompt(&QuestionGenerationRequest{InterviewType: "technical", NumQuestions: 3, Language: "zh-TW"})
+	if !strings.Contains(prompt, "Write every question text in Traditional Chinese (繁體中文)") {
+		t.Errorf("Expected a Traditional Chinese instruction, got: %s", prompt)
+	}
+
+	prompt = BuildQuestionGenerationPrompt(&QuestionGenerationRequest{InterviewType: "technical", NumQuestions: 3})
+	if !strings.Contains(prompt, "Write every question text in English") {
+		t.Errorf("Expected English by default, got: %s", prompt)
+	}
+}
+
+// TestParseQuestionResponse_FullWidthColons verifies markers written with full-width colons are parsed
+func TestParseQuestionResponse_FullWidthColons(t *testing.T) {
+	input := `Question：請描述你設計過最複雜的系統。
+Category：technical
+Difficulty：hard
+Expected Time：10
+
+Question: 你如何處理團隊中的意見分歧？
+Category：behavioral
+Difficulty: medium
+Expected Time：5`
+
+	questions := ParseQuestionResponse(input)
+	if len(questions) != 2 {
+		t.Fatalf("Expected 2 questions, got %d", len(questions))
+	}
+	if questions[0].Question != "請描述你設計過最複雜的系統。" || questions[0].Category != "technical" || questions[0].Difficulty != "hard" {
+		t.Errorf("Unexpected first question: %+v", questions[0])
+	}
+	if questions[1].Question != "你如何處理團隊中的意見分歧？" || questions[1].Category != "behavioral" {
+		t.Errorf("Unexpected second question: %+v", questions[1])
+	}
+}
+
 // TestParseQuestionResponse_EdgeCases tests edge cases in question parsing
 func TestParseQuestionResponse_EdgeCases(t *testing.T) {
 	testCases := []struct {
@@ -791,7 +944,7 @@ func TestBuildEvaluationPrompt_JobDescIncluded(t *testing.T) {
 	req := &EvaluationRequest{
 		JobDesc:     "Senior Software Engineer at Tech Company",
 		Criteria:    []string{"coding", "design"},
-		DetailLevel: "comprehensive",
+		DetailLevel: DetailLevelDetailed,
 	}
 
 	prompt := BuildEvaluationPrompt(req)
@@ -799,11 +952,181 @@ func TestBuildEvaluationPrompt_JobDescIncluded(t *testing.T) {
 	if !strings.Contains(prompt, "Senior Software Engineer at Tech Company") {
 		t.Error("Expected job description to be in prompt")
 	}
-	if !strings.Contains(prompt, "comprehensive") {
+	if !strings.Contains(prompt, "Detail Level: detailed") {
 		t.Error("Expected detail level to be in prompt")
 	}
 }
 
+// TestBuildEvaluationPrompt_OptionalContext verifies context sections appear only when present
+func TestBuildEvaluationPrompt_OptionalContext(t *testing.T) {
+	base := &EvaluationRequest{
+		JobDesc:     "Software Engineer",
+		Criteria:    []string{"communication"},
+		DetailLevel: "detailed",
+	}
+
+	prompt := BuildEvaluationPrompt(base)
+	for _, section := range []string{"Interview Type:", "Company Context:", "Candidate Resume:", "Session Notes:", "Conversation Summary:", "Language Note:"} {
+		if strings.Contains(prompt, section) {
+			t.Errorf("Expected prompt not to contain '%s' when field is empty", section)
+		}
+	}
+
+	withContext := *base
+	withContext.InterviewType = "behavioral"
+	withContext.CompanyContext = "Series B fintech, remote-first"
+	withContext.ResumeContent = "Led a team of five engineers"
+	withContext.SessionNotes = []string{"Session language changed from en to zh-TW"}
+	withContext.ConversationSummary = "Discussed caching strategy"
+	withContext.Language = "zh-TW"
+	withContext.LanguageMismatch = true
+
+	prompt = BuildEvaluationPrompt(&withContext)
+	expected := []string{
+		"Interview Type: behavioral",
+		"soft skills",
+		"Company Context:\n" + documentStartMarker + "\nSeries B fintech, remote-first\n" + documentEndMarker,
+		"Candidate Resume:\n" + documentStartMarker + "\nLed a team of five engineers\n" + documentEndMarker,
+		"Session Notes:\n- Session language changed from en to zh-TW",
+		"Conversation Summary:\nDiscussed caching strategy",
+		"Language Note: The candidate mostly answered in a different language than the interview language (Traditional Chinese (繁體中文))",
+		"regardless of the language",
+		"Evaluation Criteria: communication",
+	}
+	for _, e := range expected {
+		if !strings.Contains(prompt, e) {
+			t.Errorf("Expected prompt to contain '%s'", e)
+		}
+	}
+}
+
+// TestBuildEvaluationPrompt_TruncatesLongContext verifies resume and company context are truncated
+func TestBuildEvaluationPrompt_TruncatesLongContext(t *testing.T) {
+	req := &EvaluationRequest{
+		JobDesc:        "Engineer",
+		ResumeContent:  strings.Repeat("r", maxPromptResumeLength+500),
+		CompanyContext: strings.Repeat("c", maxPromptCompanyLength+500),
+	}
+
+	prompt := BuildEvaluationPrompt(req)
+
+	if strings.Contains(prompt, strings.Repeat("r", maxPromptResumeLength+1)) {
+		t.Error("Expected resume to be truncated")
+	}
+	if strings.Contains(prompt, strings.Repeat("c", maxPromptCompanyLength+1)) {
+		t.Error("Expected company context to be truncated")
+	}
+	if strings.Count(prompt, "... [truncated]") != 2 {
+		t.Errorf("Expected two truncation markers, got %d", strings.Count(prompt, "... [truncated]"))
+	}
+}
+
+// TestBuildEvaluationPrompt_DetailLevels verifies each detail level requests its own format
+func TestBuildEvaluationPrompt_DetailLevels(t *testing.T) {
+	testCases := []struct {
+		name        string
+		detailLevel string
+		expected    []string
+		notExpected []string
+		promptLevel string
+	}{
+		{
+			name:        "brief",
+			detailLevel: DetailLevelBrief,
+			expected:    []string{"at most 100 words", "do not include recommendations", "Strengths:"},
+			notExpected: []string{"Recommendations:", "Per-Question Feedback:"},
+		},
+		{
+			name:        "standard",
+			detailLevel: DetailLevelStandard,
+			expected:    []string{"comprehensive feedback paragraph", "Recommendations:"},
+			notExpected: []string{"at most 100 words", "Per-Question Feedback:"},
+		},
+		{
+			name:        "detailed",
+			detailLevel: DetailLevelDetailed,
+			expected:    []string{"Recommendations:", "Per-Question Feedback:", "- Q1: [commentary"},
+			notExpected: []string{"at most 100 words"},
+		},
+		{
+			name:        "empty defaults to standard",
+			detailLevel: "",
+			expected:    []string{"Recommendations:"},
+			notExpected: []string{"Per-Question Feedback:"},
+			promptLevel: DetailLevelStandard,
+		},
+		{
+			name:        "unknown falls back to standard",
+			detailLevel: "comprehensive",
+			expected:    []string{"Recommendations:"},
+			notExpected: []string{"comprehensive\n", "Per-Question Feedback:"},
+			promptLevel: DetailLevelStandard,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			prompt := BuildEvaluationPrompt(&EvaluationRequest{JobDesc: "Engineer", DetailLevel: tc.detailLevel})
+
+			level := tc.promptLevel
+			if level == "" {
+				level = tc.detailLevel
+			}
+			if !strings.Contains(prompt, "Detail Level: "+level) {
+				t.Errorf("Expected prompt to state detail level %q", level)
+			}
+			for _, e := range tc.expected {
+				if !strings.Contains(prompt, e) {
+					t.Errorf("Expected prompt to contain %q", e)
+				}
+			}
+			for _, e := range tc.notExpected {
+				if strings.Contains(prompt, e) {
+					t.Errorf("Expected prompt not to contain %q", e)
+				}
+			}
+		})
+	}
+}
+
+// TestParseEvaluationResponse_PerQuestionFeedback verifies the detailed per-question section is parsed
+func TestParseEvaluationResponse_PerQuestionFeedback(t *testing.T) {
+	content := `Overall Score: 0.8
+Feedback: Solid interview overall.
+
+Recommendations:
+- Practice system design
+
+Per-Question Feedback:
+- Q1: Clear STAR structure with measurable results.
+- Q2: Missed the trade-offs of caching.
+- Not a question item
+- Q3:
+`
+
+	evaluation := ParseEvaluationResponse(content)
+
+	expected := []QuestionFeedback{
+		{Question: 1, Feedback: "Clear STAR structure with measurable results."},
+		{Question: 2, Feedback: "Missed the trade-offs of caching."},
+	}
+	if len(evaluation.PerQuestionFeedback) != len(expected) {
+		t.Fatalf("Expected %d per-question items, got %+v", len(expected), evaluation.PerQuestionFeedback)
+	}
+	for i, e := range expected {
+		if evaluation.PerQuestionFeedback[i] != e {
+			t.Errorf("Item %d: expected %+v, got %+v", i, e, evaluation.PerQuestionFeedback[i])
+		}
+	}
+	if len(evaluation.Recommendations) != 1 {
+		t.Errorf("Expected per-question items not to leak into recommendations, got %v", evaluation.Recommendations)
+	}
+
+	if standard := ParseEvaluationResponse("Feedback: Fine.\nRecommendations:\n- Keep going"); standard.PerQuestionFeedback != nil {
+		t.Errorf("Expected no per-question feedback without the section, got %+v", standard.PerQuestionFeedback)
+	}
+}
+
 // TestFormatAnswersForEvaluation_Numbering verifies Q&A numbering is correct
 func TestFormatAnswersForEvaluation_Numbering(t *testing.T) {
 	questions := []string{"Q1", "Q2", "Q3"}
@@ -881,3 +1204,133 @@ func BenchmarkFormatAnswersForEvaluation(b *testing.B) {
 	}
 }
 
+func TestParseEvaluationResponse_Decision(t *testing.T) {
+	tests := []struct {
+		line     string
+		expected string
+	}{
+		{"Recommendation Decision: strong_hire", DecisionStrongHire},
+		{"Recommendation Decision: hire", DecisionHire},
+		{"Recommendation Decision: no_hire", DecisionNoHire},
+		{"Recommendation Decision: more_data_needed", DecisionMoreDataNeeded},
+		// Casing and formatting variants
+		{"RECOMMENDATION DECISION: Strong Hire", DecisionStrongHire},
+		{"recommendation decision: **No-Hire**", DecisionNoHire},
+		{"Recommendation Decision: [more_data_needed]", DecisionMoreDataNeeded},
+	}
+	for _, tt := range tests {
+		evaluation := ParseEvaluationResponse("Feedback: Good.\n" + tt.line)
+		if evaluation.Decision != tt.expected {
+			t.Errorf("%q: expected decision %q, got %q", tt.line, tt.expected, evaluation.Decision)
+		}
+		if evaluation.Metadata[MetadataDecisionWarning] != nil {
+			t.Errorf("%q: expected no warning, got %v", tt.line, evaluation.Metadata)
+		}
+	}
+}
+
+func TestParseEvaluationResponse_DecisionMissing(t *testing.T) {
+	evaluation := ParseEvaluationResponse("Feedback: Good.\nRecommendations:\n- Keep going")
+	if evaluation.Decision != "" || evaluation.NextSteps != nil {
+		t.Errorf("Expected no decision or next steps without the sections, got %q / %v", evaluation.Decision, evaluation.NextSteps)
+	}
+	if evaluation.Metadata != nil {
+		t.Errorf("Expected no warning for a missing decision, got %v", evaluation.Metadata)
+	}
+}
+
+func TestParseEvaluationResponse_DecisionOutOfEnum(t *testing.T) {
+	evaluation := ParseEvaluationResponse("Feedback: Good.\nRecommendation Decision: maybe later")
+	if evaluation.Decision != "" {
+		t.Errorf("Expected an unrecognized decision to be dropped, got %q", evaluation.Decision)
+	}
+	warning, _ := evaluation.Metadata[MetadataDecisionWarning].(string)
+	if !strings.Contains(warning, "maybe later") {
+		t.Errorf("Expected a warning naming the dropped decision, got %v", evaluation.Metadata)
+	}
+}
+
+func TestParseEvaluationResponse_NextSteps(t *testing.T) {
+	content := `Feedback: Strong systems knowledge.
+
+Recommendations:
+- Practice behavioral answers
+
+Recommendation Decision: hire
+
+next steps:
+- Advance to the onsite round
+- Schedule a follow-up on system design
+- Send the take-home exercise
+- Ask for references
+`
+	evaluation := ParseEvaluationResponse(content)
+
+	expected := []string{"Advance to the onsite round", "Schedule a follow-up on system design", "Send the take-home exercise"}
+	if !reflect.DeepEqual(evaluation.NextSteps, expected) {
+		t.Errorf("Expected the first %d next steps %v, got %v", MaxNextSteps, expected, evaluation.NextSteps)
+	}
+	if len(evaluation.Recommendations) != 1 {
+		t.Errorf("Expected next steps not to leak into recommendations, got %v", evaluation.Recommendations)
+	}
+	if evaluation.Feedback != "Strong systems knowledge." {
+		t.Errorf("Expected the decision not to leak into feedback, got %q", evaluation.Feedback)
+	}
+}
+
+func TestBuildEvaluationPrompt_DecisionFormat(t *testing.T) {
+	for _, level := range []string{DetailLevelBrief, DetailLevelStandard, DetailLevelDetailed} {
+		prompt := BuildEvaluationPrompt(&EvaluationRequest{JobDesc: "Engineer", DetailLevel: level})
+		for _, expected := range []string{"Recommendation Decision:", "strong_hire, hire, no_hire, more_data_needed", "Next Steps:"} {
+			if !strings.Contains(prompt, expected) {
+				t.Errorf("%s prompt: expected %q", level, expected)
+			}
+		}
+	}
+}
+
+// TestMakeRequest_ResponseTooLarge streams far more than the cap and checks the body is abandoned
+// after the cap instead of being read in full
+func TestMakeRequest_ResponseTooLarge(t *testing.T) {
+	const limit = 1 << 20
+	const streamed = 256 << 20
+	var written atomic.Int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		chunk := bytes.Repeat([]byte("x"), 32<<10)
+		for written.Load() < streamed {
+			n, err := w.Write(chunk)
+			written.Add(int64(n))
+			if err != nil {
+				return // The client hung up
+			}
+		}
+	}))
+	defer server.Close()
+
+	bp := NewBaseProvider(&AIConfig{MaxResponseBytes: limit}, server.URL, 10*time.Second)
+	body, err := bp.MakeRequest(context.Background(), &mockAdapter{baseURL: server.URL}, "/test", map[string]string{})
+	if !errors.Is(err, ErrResponseTooLarge) {
+		t.Fatalf("expected ErrResponseTooLarge, got %v", err)
+	}
+	if body != nil {
+		t.Errorf("expected no body, got %d bytes", len(body))
+	}
+	// The server only gets as far as the cap plus what the connection's buffers hold
+	server.CloseClientConnections()
+	if sent := written.Load(); sent >= streamed/4 {
+		t.Errorf("expected the body abandoned soon after %d bytes, server wrote %d", limit, sent)
+	}
+
+	// A body at the cap is still read, and the default cap applies when none is configured
+	exact := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(bytes.Repeat([]byte("x"), limit))
+	}))
+	defer exact.Close()
+	if body, err := bp.MakeRequest(context.Background(), &mockAdapter{baseURL: exact.URL}, "/test", map[string]string{}); err != nil || len(body) != limit {
+		t.Errorf("expected %d bytes, got %d (%v)", limit, len(body), err)
+	}
+	unset := NewBaseProvider(&AIConfig{}, exact.URL, time.Second)
+	if got := unset.maxResponseBytes(); got != DefaultMaxResponseBytes {
+		t.Errorf("expected the default cap %d, got %d", DefaultMaxResponseBytes, got)
+	}
+}
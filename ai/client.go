@@ -3,14 +3,70 @@ package ai
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"github.com/zidane0000/ai-interview-platform/utils"
+)
+
+// Response language checks
+const (
+	// minCJKRatio is the minimum share of CJK letters in a reply for a CJK session language
+	// Replies may legitimately mix in English technical terms, so this is well below 1
+	minCJKRatio = 0.3
+
+	// minLatinRatio is the minimum share of Latin letters for answers to count as Latin script
+	minLatinRatio = 0.5
+
+	// minDetectableLetters is the fewest letters across all answers worth judging the language of
+	minDetectableLetters = 10
+
+	// MetadataLanguageMismatch is set in ChatResponse.Metadata when the reply is still
+	// in the wrong language after a retry
+	MetadataLanguageMismatch = "language_mismatch"
 )
 
+// credentialCheckTimeout bounds each provider's credential check when ValidateOnStartup is set
+const credentialCheckTimeout = 5 * time.Second
+
+// defaultPerRequestTimeout bounds AI calls when neither PerRequestTimeout nor RequestTimeout is configured
+const defaultPerRequestTimeout = 60 * time.Second
+
+// ErrAttemptBudgetExhausted is returned without calling the provider when the client's attempt
+// hook refuses a call (see SetAttemptHook)
+var ErrAttemptBudgetExhausted = errors.New("AI attempt budget exhausted")
+
 // AIClient provides a simple interface for AI operations
-// Wraps a single AIProvider without enterprise features (metrics, caching, health checks)
+// Wraps a single AIProvider without enterprise features (metrics, caching); credentials are
+// only checked at construction, and only when AIConfig.ValidateOnStartup is set
 type AIClient struct {
 	provider AIProvider
 	config   *AIConfig
+
+	// redactor is created on first use when config.RedactPII is set, and shared by every call
+	// of the client so placeholders stay consistent
+	redactorOnce sync.Once
+	redactor     *Redactor
+
+	// attemptHook runs before every provider call; see SetAttemptHook
+	attemptHook func() error
+
+	// limiter bounds concurrent provider calls; nil means no cap (see AIConfig.Limiter)
+	limiter *ConcurrencyLimiter
+}
+
+// newClient builds a client around provider, sharing cfg's limiter or creating one from
+// cfg.MaxConcurrentRequests
+func newClient(provider AIProvider, cfg *AIConfig) *AIClient {
+	limiter := cfg.Limiter
+	if limiter == nil && cfg.MaxConcurrentRequests > 0 {
+		limiter = NewConcurrencyLimiter(cfg.MaxConcurrentRequests, cfg.QueueTimeout)
+	}
+	return &AIClient{provider: provider, config: cfg, limiter: limiter}
 }
 
 // NewAIClient creates a new AI client with the specified configuration
@@ -20,126 +76,683 @@ func NewAIClient(cfg *AIConfig) (*AIClient, error) {
 	}
 
 	// Create the provider based on default provider setting
-	var provider AIProvider
+	provider, err := newConfiguredProvider(cfg.DefaultProvider, cfg)
+	if err != nil {
+		return nil, err
+	}
 
-	switch cfg.DefaultProvider {
+	if cfg.ValidateOnStartup {
+		return newValidatedAIClient(provider, cfg)
+	}
+
+	return newClient(provider, cfg), nil
+}
+
+// newConfiguredProvider creates the named provider using the credentials in cfg
+func newConfiguredProvider(name string, cfg *AIConfig) (AIProvider, error) {
+	switch name {
 	case ProviderOpenAI:
 		if cfg.OpenAIAPIKey == "" {
 			return nil, fmt.Errorf("OpenAI API key required")
 		}
-		provider = NewOpenAIProvider(cfg.OpenAIAPIKey, cfg)
+		return NewOpenAIProvider(cfg.OpenAIAPIKey, cfg), nil
 	case ProviderGemini:
 		if cfg.GeminiAPIKey == "" {
 			return nil, fmt.Errorf("Gemini API key required")
 		}
-		provider = NewGeminiProvider(cfg.GeminiAPIKey, cfg)
+		return NewGeminiProvider(cfg.GeminiAPIKey, cfg), nil
 	case ProviderMock:
-		provider = NewMockProvider()
+		return NewMockProvider(), nil
+	default:
+		return nil, fmt.Errorf("unsupported provider: %s", name)
+	}
+}
+
+// newValidatedAIClient checks the credentials of every configured provider, starting with the
+// default, and builds a client around the first healthy one the config allows
+func newValidatedAIClient(defaultProvider AIProvider, cfg *AIConfig) (*AIClient, error) {
+	candidates := []AIProvider{defaultProvider}
+	for _, name := range []string{ProviderOpenAI, ProviderGemini} {
+		if name == cfg.DefaultProvider {
+			continue
+		}
+		if provider, err := newConfiguredProvider(name, cfg); err == nil {
+			candidates = append(candidates, provider)
+		}
+	}
+
+	// Check every provider so the logs show the full picture, not just the first failure
+	var failures []string
+	healthy := -1
+	for i, provider := range candidates {
+		name := provider.GetProviderName()
+		ctx, cancel := context.WithTimeout(context.Background(), credentialCheckTimeout)
+		err := provider.ValidateCredentials(ctx)
+		cancel()
+		if err != nil {
+			utils.Warningf("AI provider %s failed credential check: %v", name, err)
+			failures = append(failures, fmt.Sprintf("%s: %v", name, err))
+			continue
+		}
+		utils.Infof("AI provider %s passed credential check", name)
+		if healthy < 0 {
+			healthy = i
+		}
+	}
+
+	switch {
+	case healthy == 0:
+		return newClient(defaultProvider, cfg), nil
+	case !cfg.EnableFallback:
+		return nil, fmt.Errorf("default AI provider %s failed credential check (%s)", cfg.DefaultProvider, failures[0])
+	case healthy > 0:
+		provider := candidates[healthy]
+		utils.Warningf("Default AI provider %s is unhealthy, falling back to %s", cfg.DefaultProvider, provider.GetProviderName())
+		return newClient(provider, withDefaultProvider(cfg, provider)), nil
+	case cfg.AllowMockFallback:
+		utils.Warningf("No healthy AI provider, falling back to mock provider")
+		mock := NewMockProvider()
+		return newClient(mock, withDefaultProvider(cfg, mock)), nil
 	default:
-		return nil, fmt.Errorf("unsupported provider: %s", cfg.DefaultProvider)
+		return nil, fmt.Errorf("no healthy AI provider (%s)", strings.Join(failures, "; "))
 	}
+}
 
-	return &AIClient{
-		provider: provider,
-		config:   cfg,
-	}, nil
+// withDefaultProvider returns a copy of cfg whose default provider and model point at provider
+// The configured default model is kept only if it belongs to that provider
+func withDefaultProvider(cfg *AIConfig, provider AIProvider) *AIConfig {
+	effective := *cfg
+	if provider.GetProviderName() == cfg.DefaultProvider {
+		return &effective
+	}
+	effective.DefaultProvider = provider.GetProviderName()
+	effective.DefaultModel = ""
+	if models := provider.GetSupportedModels(); len(models) > 0 {
+		effective.DefaultModel = models[0]
+	}
+	return &effective
+}
+
+// NewAIClientWithProvider wraps an already constructed provider (e.g. a scripted mock in tests)
+func NewAIClientWithProvider(provider AIProvider, cfg *AIConfig) *AIClient {
+	if cfg == nil {
+		cfg = &AIConfig{DefaultProvider: provider.GetProviderName()}
+		if models := provider.GetSupportedModels(); len(models) > 0 {
+			cfg.DefaultModel = models[0]
+		}
+	}
+	return newClient(provider, cfg)
+}
+
+// SetAttemptHook installs a hook that runs before every provider call the client makes, including
+// retries. When it returns an error the provider is not called and the error is returned instead,
+// so callers can enforce a budget (returning ErrAttemptBudgetExhausted) across a client's calls.
+func (c *AIClient) SetAttemptHook(hook func() error) {
+	c.attemptHook = hook
+}
+
+// beforeProviderCall waits for a concurrency slot, then runs the attempt hook, if any
+// The returned release function must be called once the provider call is done. Calls refused by
+// the limiter don't count toward the attempt budget, and neither do calls whose context is already
+// done: a caller's deadline spent on earlier attempts refuses further ones with the context's error.
+func (c *AIClient) beforeProviderCall(ctx context.Context) (release func(), err error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	release = func() {}
+	if c.limiter != nil {
+		if release, err = c.limiter.Acquire(ctx); err != nil {
+			return nil, err
+		}
+	}
+	if c.attemptHook != nil {
+		if err := c.attemptHook(); err != nil {
+			release()
+			return nil, err
+		}
+	}
+	return release, nil
 }
 
 // GenerateChatResponse generates AI response for conversational interviews
-func (c *AIClient) GenerateChatResponse(sessionID string, conversationHistory []map[string]string, userMessage string) (string, error) {
-	return c.GenerateChatResponseWithLanguage(sessionID, conversationHistory, userMessage, "en")
+//
+// Deprecated: use GenerateInterviewTurn.
+func (c *AIClient) GenerateChatResponse(ctx context.Context, sessionID string, conversationHistory []map[string]string, userMessage string) (string, error) {
+	return c.GenerateChatResponseWithLanguage(ctx, sessionID, conversationHistory, userMessage, "en")
 }
 
 // GenerateChatResponseWithLanguage generates AI response with language support
-func (c *AIClient) GenerateChatResponseWithLanguage(sessionID string, conversationHistory []map[string]string, userMessage string, language string) (string, error) {
-	ctx := context.Background()
+//
+// Deprecated: use GenerateInterviewTurn.
+func (c *AIClient) GenerateChatResponseWithLanguage(ctx context.Context, sessionID string, conversationHistory []map[string]string, userMessage string, language string) (string, error) {
+	resp, err := c.GenerateChatReplyWithOptions(ctx, sessionID, conversationHistory, userMessage, ChatReplyOptions{Language: language})
+	if err != nil {
+		return "", err
+	}
+	return resp.Content, nil
+}
 
-	// Build messages for the AI provider
-	messages := buildChatMessages(conversationHistory, userMessage, language, false)
+// GenerateChatReply generates the next interviewer turn and returns the full provider response
+// When closing is true the reply wraps up the interview.
+//
+// Deprecated: use GenerateInterviewTurn.
+func (c *AIClient) GenerateChatReply(ctx context.Context, sessionID string, conversationHistory []map[string]string, userMessage string, language string, closing bool) (*ChatResponse, error) {
+	return c.GenerateChatReplyWithOptions(ctx, sessionID, conversationHistory, userMessage, ChatReplyOptions{Language: language, Closing: closing})
+}
 
-	// Generate response using provider
-	req := &ChatRequest{
-		Messages:    messages,
-		MaxTokens:   500,
-		Temperature: 0.7,
-		SessionID:   sessionID,
+// GenerateChatReplyWithOptions is GenerateChatReply with the interview type and explicit model parameters
+// History entries are maps with "role" and "content" keys; see TurnsFromMaps for how they convert.
+//
+// Deprecated: use GenerateInterviewTurn.
+func (c *AIClient) GenerateChatReplyWithOptions(ctx context.Context, sessionID string, conversationHistory []map[string]string, userMessage string, opts ChatReplyOptions) (*ChatResponse, error) {
+	return c.GenerateInterviewTurn(ctx, InterviewTurnRequest{
+		SessionID:     sessionID,
+		History:       TurnsFromMaps(conversationHistory),
+		UserMessage:   userMessage,
+		InterviewType: opts.InterviewType,
+		Language:      opts.Language,
+		Closing:       opts.Closing,
+		Params:        opts.Params,
+	})
+}
+
+// generate sends a chat request to the provider, filling in timing and attribution
+// for providers that don't report their own
+// With RedactPII the provider only sees redacted messages, and placeholders it echoes are restored
+func (c *AIClient) generate(ctx context.Context, req *ChatRequest) (*ChatResponse, error) {
+	release, err := c.beforeProviderCall(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	checkSessionScope(req)
+	redactor := c.Redactor()
+	if redactor != nil {
+		redacted := *req
+		redacted.Messages = redactor.redactMessages(req.Messages)
+		redacted.SystemPrompt = redactor.Redact(req.SystemPrompt)
+		req = &redacted
 	}
 
+	startTime := time.Now()
 	resp, err := c.provider.GenerateResponse(ctx, req)
+	if c.config.DebugCapture != nil {
+		c.captureDebug(DebugOperationChat, req, resp, err)
+	}
 	if err != nil {
-		return "", fmt.Errorf("AI generation failed: %w", err)
+		return nil, err
+	}
+	if redactor != nil {
+		resp.Content = redactor.Restore(resp.Content)
+		for i := range resp.Alternatives {
+			resp.Alternatives[i] = redactor.Restore(resp.Alternatives[i])
+		}
+	}
+	if resp.ResponseTime <= 0 {
+		resp.ResponseTime = time.Since(startTime)
+	}
+	c.fillAttribution(&resp.Provider, &resp.Model)
+	var known bool
+	resp.EstimatedCostUSD, known = c.estimateCost(resp.Model, resp.TokensUsed)
+	if !known {
+		resp.Metadata = withPricingNote(resp.Metadata)
 	}
+	recordUsage(ctx, resp.Provider, resp.Model, resp.TokensUsed, resp.EstimatedCostUSD)
+	resp.Metadata = c.withDeprecationNote(resp.Metadata, req.Model)
+	return resp, nil
+}
 
-	return resp.Content, nil
+// Redactor returns the client's PII redactor, or nil when AIConfig.RedactPII is off
+// Its Mapping records what was redacted in the client's provider calls so far
+func (c *AIClient) Redactor() *Redactor {
+	if !c.config.RedactPII {
+		return nil
+	}
+	c.redactorOnce.Do(func() {
+		c.redactor = NewRedactor(c.config.RedactPatterns)
+	})
+	return c.redactor
+}
+
+// redactEvaluationRequest returns a copy of req with every free-text field redacted
+func redactEvaluationRequest(redactor *Redactor, req *EvaluationRequest) *EvaluationRequest {
+	redacted := *req
+	redacted.Questions = redactor.redactAll(req.Questions)
+	redacted.Answers = redactor.redactAll(req.Answers)
+	redacted.JobDesc = redactor.Redact(req.JobDesc)
+	redacted.ResumeContent = redactor.Redact(req.ResumeContent)
+	redacted.CompanyContext = redactor.Redact(req.CompanyContext)
+	redacted.SessionNotes = redactor.redactAll(req.SessionNotes)
+	redacted.ConversationSummary = redactor.Redact(req.ConversationSummary)
+	return &redacted
+}
+
+// restoreEvaluationResponse restores placeholders the provider echoed in its evaluation
+func restoreEvaluationResponse(redactor *Redactor, resp *EvaluationResponse) {
+	resp.Feedback = redactor.Restore(resp.Feedback)
+	redactor.restoreAll(resp.Strengths)
+	redactor.restoreAll(resp.Weaknesses)
+	redactor.restoreAll(resp.Recommendations)
+	for i := range resp.PerQuestionFeedback {
+		resp.PerQuestionFeedback[i].Feedback = redactor.Restore(resp.PerQuestionFeedback[i].Feedback)
+	}
+}
+
+// withPricingNote records that a cost was estimated at the default rate
+func withPricingNote(metadata map[string]interface{}) map[string]interface{} {
+	if metadata == nil {
+		metadata = make(map[string]interface{})
+	}
+	metadata[MetadataPricing] = PricingDefaultRate
+	return metadata
+}
+
+// withDeprecationNote records in metadata that model (the client's default when empty) is retired
+// and the call went to its replacement; metadata is returned unchanged for current models
+func (c *AIClient) withDeprecationNote(metadata map[string]interface{}, model string) map[string]interface{} {
+	if model == "" {
+		model = c.config.DefaultModelFor(c.provider.GetProviderName())
+	}
+	_, alias := ResolveModel(model, c.config.ModelAliases)
+	if alias == nil {
+		return metadata
+	}
+	warnDeprecatedModel(model, *alias)
+	if metadata == nil {
+		metadata = make(map[string]interface{})
+	}
+	metadata[MetadataModelDeprecated] = DeprecationNote(model, *alias)
+	return metadata
+}
+
+// languageInfo describes a language the AI can be asked to reply in
+type languageInfo struct {
+	Name string // Name used in prompts
+	CJK  bool   // Replies are expected to be written in CJK script
+}
+
+// languages is the registry of known reply languages, keyed by lowercase code
+var languages = map[string]languageInfo{
+	"en":    {Name: "English"},
+	"zh":    {Name: "Chinese (中文)", CJK: true},
+	"zh-tw": {Name: "Traditional Chinese (繁體中文)", CJK: true},
+	"zh-cn": {Name: "Simplified Chinese (简体中文)", CJK: true},
+	"ja":    {Name: "Japanese (日本語)", CJK: true},
+	"ko":    {Name: "Korean (한국어)", CJK: true},
+}
+
+// lookupLanguage finds language by its full code, falling back to the primary subtag ("ja-JP" -> "ja")
+func lookupLanguage(language string) (languageInfo, bool) {
+	code := strings.ToLower(language)
+	if info, ok := languages[code]; ok {
+		return info, true
+	}
+	if i := strings.IndexByte(code, '-'); i > 0 {
+		info, ok := languages[code[:i]]
+		return info, ok
+	}
+	return languageInfo{}, false
+}
+
+// isCJKLanguage reports whether replies in language are expected to be written in CJK script
+func isCJKLanguage(language string) bool {
+	info, _ := lookupLanguage(language)
+	return info.CJK
+}
+
+// answersLanguageMismatch reports whether the candidate mostly answered in another script than the
+// interview language: Latin-script answers in a CJK interview, or CJK answers in any other interview
+func answersLanguageMismatch(answers []string, language string) bool {
+	text := strings.Join(answers, "\n")
+	letters := 0
+	for _, r := range text {
+		if unicode.IsLetter(r) {
+			letters++
+		}
+	}
+	if letters < minDetectableLetters {
+		return false
+	}
+	cjk := utils.CJKRatio(text)
+	if isCJKLanguage(language) {
+		return cjk < minCJKRatio && utils.LatinRatio(text) >= minLatinRatio
+	}
+	return cjk >= minCJKRatio
+}
+
+// languageRetryInstruction is appended to the conversation when a reply came back in the wrong language
+func languageRetryInstruction(language string) string {
+	name := language
+	if info, ok := lookupLanguage(language); ok {
+		name = info.Name
+	}
+	return "IMPORTANT: Your previous reply was not written in " + name + ". " +
+		"Write your entire reply in " + name + " only. Do not reply in English."
+}
+
+// addTokenUsage sums token consumption across provider calls
+func addTokenUsage(a, b TokenUsage) TokenUsage {
+	return TokenUsage{
+		PromptTokens:     a.PromptTokens + b.PromptTokens,
+		CompletionTokens: a.CompletionTokens + b.CompletionTokens,
+		TotalTokens:      a.TotalTokens + b.TotalTokens,
+	}
 }
 
 // GenerateClosingMessage generates a closing AI response for ending interviews
-func (c *AIClient) GenerateClosingMessage(sessionID string, conversationHistory []map[string]string, userMessage string) (string, error) {
-	return c.GenerateClosingMessageWithLanguage(sessionID, conversationHistory, userMessage, "en")
+//
+// Deprecated: use GenerateInterviewTurn with Closing set.
+func (c *AIClient) GenerateClosingMessage(ctx context.Context, sessionID string, conversationHistory []map[string]string, userMessage string) (string, error) {
+	return c.GenerateClosingMessageWithLanguage(ctx, sessionID, conversationHistory, userMessage, "en")
 }
 
 // GenerateClosingMessageWithLanguage generates a closing AI response with language support
-func (c *AIClient) GenerateClosingMessageWithLanguage(sessionID string, conversationHistory []map[string]string, userMessage string, language string) (string, error) {
-	ctx := context.Background()
+//
+// Deprecated: use GenerateInterviewTurn with Closing set.
+func (c *AIClient) GenerateClosingMessageWithLanguage(ctx context.Context, sessionID string, conversationHistory []map[string]string, userMessage string, language string) (string, error) {
+	resp, err := c.GenerateChatReplyWithOptions(ctx, sessionID, conversationHistory, userMessage, ChatReplyOptions{Language: language, Closing: true})
+	if err != nil {
+		return "", err
+	}
+	return resp.Content, nil
+}
 
-	// Build messages with closing context
-	messages := buildChatMessages(conversationHistory, userMessage, language, true)
+// SummarizeForContext condenses a long candidate message so it can stand in for the full text
+// in the conversation history sent to the provider. Uses the cheapest model of the provider.
+func (c *AIClient) SummarizeForContext(ctx context.Context, text, language string) (string, error) {
+	resp, err := c.SummarizeForContextDetailed(ctx, text, language)
+	if err != nil {
+		return "", err
+	}
+	return resp.Content, nil
+}
+
+// SummarizeForContextDetailed is SummarizeForContext returning the full provider response,
+// including token usage and estimated cost
+func (c *AIClient) SummarizeForContextDetailed(ctx context.Context, text, language string) (*ChatResponse, error) {
+	ctx, cancel := c.withCallTimeout(ctx)
+	defer cancel()
+
+	systemPrompt := "You are assisting an interviewer. Summarize the candidate's message below so it can replace " +
+		"the original in the interview transcript. Preserve key technical details, decisions, trade-offs and " +
+		"any questions the candidate asked. Keep it under 200 words and do not add commentary." +
+		summaryLanguageInstruction(language)
 
-	// Generate closing response
 	req := &ChatRequest{
-		Messages:    messages,
-		MaxTokens:   300,
-		Temperature: 0.7,
-		SessionID:   sessionID,
+		Messages: []Message{
+			{Role: "system", Content: systemPrompt},
+			{Role: "user", Content: text},
+		},
+		Model:       c.summarizationModel(),
+		MaxTokens:   400,
+		Temperature: 0.2,
+		Context:     map[string]interface{}{"task": TaskSummarization},
 	}
 
-	resp, err := c.provider.GenerateResponse(ctx, req)
+	resp, err := c.generate(ctx, req)
 	if err != nil {
-		return "", fmt.Errorf("AI generation failed: %w", err)
+		return nil, fmt.Errorf("AI summarization failed: %w", err)
 	}
+	return resp, nil
+}
 
-	return resp.Content, nil
+// SummarizeConversation folds earlier conversation turns into a running summary, so long
+// sessions can send the summary plus only the most recent turns to the provider.
+// previousSummary may be empty; turns are chat history entries.
+func (c *AIClient) SummarizeConversation(ctx context.Context, previousSummary string, turns []Turn, language string) (*ChatResponse, error) {
+	ctx, cancel := c.withCallTimeout(ctx)
+	defer cancel()
+
+	systemPrompt := "You are assisting an interviewer. Maintain a running summary of an interview so the " +
+		"interviewer can continue without the full transcript. Update the existing summary with the new " +
+		"exchanges: list the topics and questions already covered and the candidate's key answers, " +
+		"including technical details and notable strengths or gaps. Keep it under 300 words and do not add commentary." +
+		summaryLanguageInstruction(language)
+
+	var transcript strings.Builder
+	if previousSummary != "" {
+		transcript.WriteString("Existing summary:\n" + previousSummary + "\n\n")
+	}
+	transcript.WriteString("New exchanges:\n")
+	for _, turn := range turns {
+		speaker := "Candidate"
+		if turn.Role == TurnRoleInterviewer || turn.Role == "assistant" {
+			speaker = "Interviewer"
+		}
+		transcript.WriteString(speaker + ": " + turn.Content + "\n")
+	}
+
+	req := &ChatRequest{
+		Messages: []Message{
+			{Role: "system", Content: systemPrompt},
+			{Role: "user", Content: transcript.String()},
+		},
+		Model:       c.summarizationModel(),
+		MaxTokens:   600,
+		Temperature: 0.2,
+		Context:     map[string]interface{}{"task": TaskSummarization},
+	}
+
+	resp, err := c.generate(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("AI conversation summarization failed: %w", err)
+	}
+	return resp, nil
+}
+
+// ConversationSummaryNote wraps a running conversation summary as a chat history entry that
+// stands in for the turns it covers
+func ConversationSummaryNote(summary string) Turn {
+	return Turn{
+		Role: TurnRoleNote,
+		Content: "Summary of the earlier part of this interview (older messages are omitted):\n" + summary +
+			"\nDo not repeat questions that were already covered.",
+	}
+}
+
+// summaryLanguageInstruction tells summarization prompts which language to write in
+func summaryLanguageInstruction(language string) string {
+	if language == "zh-TW" || language == "zh-tw" {
+		return " Respond in Traditional Chinese (繁體中文)."
+	}
+	return " Respond in English."
+}
+
+// summarizationModel picks a cheap model for summarization
+// Custom OpenAI-compatible endpoints keep the configured model since they may not serve OpenAI model names
+func (c *AIClient) summarizationModel() string {
+	if c.config.OpenAIBaseURL != "" {
+		return ""
+	}
+	return GetModelRecommendation(c.provider.GetProviderName(), TaskSummarization)
+}
+
+// InterviewMessageLimit is the number of candidate messages after which an interview ends
+const InterviewMessageLimit = 8
+
+// ReopenMessageAllowance is the number of extra candidate messages each reopen of an interview allows
+const ReopenMessageAllowance = 4
+
+// MessageLimit returns the number of candidate messages after which an interview reopened
+// reopens times ends, so a reopened interview doesn't end again on its next message
+func MessageLimit(reopens int) int {
+	return InterviewMessageLimit + reopens*ReopenMessageAllowance
+}
+
+// ReachedMessageLimit reports whether an interview reopened reopens times with messageCount
+// candidate messages should end
+func ReachedMessageLimit(messageCount, reopens int) bool {
+	return messageCount >= MessageLimit(reopens)
 }
 
 // ShouldEndInterview determines if the interview should end
-func (c *AIClient) ShouldEndInterview(messageCount int) bool {
-	return messageCount >= 8 // End after 8 user messages
+func (c *AIClient) ShouldEndInterview(messageCount, reopens int) bool {
+	return ReachedMessageLimit(messageCount, reopens)
 }
 
 // EvaluateAnswers evaluates chat conversation and generates score and feedback
-func (c *AIClient) EvaluateAnswers(questions []string, answers []string, language string) (float64, string, error) {
-	return c.EvaluateAnswersWithContext(questions, answers, "General interview evaluation", language)
+func (c *AIClient) EvaluateAnswers(ctx context.Context, questions []string, answers []string, language string) (float64, string, error) {
+	return c.EvaluateAnswersWithContext(ctx, questions, answers, EvaluationContext{
+		JobDescription: "General interview evaluation",
+		Language:       language,
+	})
 }
 
 // EvaluateAnswersWithContext evaluates chat conversation with interview context
-func (c *AIClient) EvaluateAnswersWithContext(questions []string, answers []string, jobDesc, language string) (float64, string, error) {
+func (c *AIClient) EvaluateAnswersWithContext(ctx context.Context, questions []string, answers []string, evalCtx EvaluationContext) (float64, string, error) {
+	resp, err := c.EvaluateAnswersDetailed(ctx, questions, answers, evalCtx)
+	if err != nil {
+		return 0.0, "Evaluation failed", err
+	}
+	return resp.OverallScore, resp.Feedback, nil
+}
+
+// EvaluateAnswersDetailed evaluates answers and returns the full provider response,
+// including which provider and model performed the scoring
+func (c *AIClient) EvaluateAnswersDetailed(ctx context.Context, questions []string, answers []string, evalCtx EvaluationContext) (*EvaluationResponse, error) {
 	if len(answers) == 0 {
-		return 0.0, "No answers provided.", nil
+		return &EvaluationResponse{
+			OverallScore: 0.0,
+			Feedback:     "No answers provided.",
+			Provider:     c.GetCurrentProvider(),
+			Model:        c.GetCurrentModel(),
+			Timestamp:    time.Now(),
+		}, nil
 	}
 
-	ctx := context.Background()
+	ctx = withDefaultInterviewType(ctx, evalCtx.InterviewType)
+	ctx, cancel := c.withCallTimeout(ctx)
+	defer cancel()
 
 	// Create evaluation request using existing types
+	// Answer statistics are counted before redaction, so placeholders don't change word counts
+	answerStats := ComputeAnswerStats(answers, evalCtx.MinAnswerWords)
 	req := &EvaluationRequest{
-		Questions:   questions,
-		Answers:     answers,
-		JobDesc:     jobDesc,
-		Criteria:    []string{"communication", "technical_knowledge", "problem_solving", "clarity", "cultural_fit"},
-		DetailLevel: "detailed",
-		Language:    language,
+		Questions:           questions,
+		Answers:             answers,
+		JobDesc:             evalCtx.JobDescription,
+		InterviewType:       evalCtx.InterviewType,
+		ResumeContent:       evalCtx.ResumeContent,
+		CompanyContext:      evalCtx.CompanyContext,
+		SessionNotes:        evalCtx.SessionNotes,
+		ConversationSummary: evalCtx.ConversationSummary,
+		Criteria:            []string{"communication", "technical_knowledge", "problem_solving", "clarity", "cultural_fit"},
+		DetailLevel:         normalizeDetailLevel(evalCtx.DetailLevel),
+		Language:            evalCtx.Language,
+		LanguageMismatch:    answersLanguageMismatch(answers, evalCtx.Language),
+		MaxFeedbackWords:    feedbackWordLimit(evalCtx.DetailLevel, evalCtx.MaxFeedbackWords),
+		AnswerStats:         &answerStats,
+		Params:              c.evaluationParams(evalCtx),
 		Context: map[string]interface{}{
-			"interview_type":  "conversational",
 			"evaluation_type": "chat_based",
 		},
 	}
 
+	release, err := c.beforeProviderCall(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("AI evaluation failed: %w", err)
+	}
+	defer release()
+	redactor := c.Redactor()
+	if redactor != nil {
+		req = redactEvaluationRequest(redactor, req)
+	}
+
 	// Use provider's EvaluateAnswers method
 	resp, err := c.provider.EvaluateAnswers(ctx, req)
+	if c.config.DebugCapture != nil {
+		c.captureDebug(DebugOperationEvaluation, req, resp, err)
+	}
 	if err != nil {
-		return 0.0, "Evaluation failed", fmt.Errorf("AI evaluation failed: %w", err)
+		return nil, fmt.Errorf("AI evaluation failed: %w", err)
+	}
+	if redactor != nil {
+		restoreEvaluationResponse(redactor, resp)
+	}
+	c.fillAttribution(&resp.Provider, &resp.Model)
+	resp.LanguageMismatch = req.LanguageMismatch
+	limitFeedback(resp, req.MaxFeedbackWords)
+	var known bool
+	resp.EstimatedCostUSD, known = c.estimateCost(resp.Model, resp.TokensUsed)
+	if !known {
+		resp.Metadata = withPricingNote(resp.Metadata)
 	}
+	recordUsage(ctx, resp.Provider, resp.Model, resp.TokensUsed, resp.EstimatedCostUSD)
+	resp.Metadata = c.withDeprecationNote(resp.Metadata, "")
+	resp.Metadata = withPromptTrace(resp.Metadata, req)
+	resp.Metadata = withAnswerStats(resp.Metadata, answerStats)
+	return resp, nil
+}
 
-	return resp.OverallScore, resp.Feedback, nil
+// GenerateInterviewQuestions generates questions along with their category, difficulty and expected time
+func (c *AIClient) GenerateInterviewQuestions(ctx context.Context, req *QuestionGenerationRequest) (*QuestionGenerationResponse, error) {
+	ctx = withDefaultInterviewType(ctx, req.InterviewType)
+	ctx, cancel := c.withCallTimeout(ctx)
+	defer cancel()
+
+	release, err := c.beforeProviderCall(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("AI question generation failed: %w", err)
+	}
+	defer release()
+	redactor := c.Redactor()
+	if redactor != nil {
+		redacted := *req
+		redacted.JobDescription = redactor.Redact(req.JobDescription)
+		redacted.ResumeContent = redactor.Redact(req.ResumeContent)
+		req = &redacted
+	}
+
+	resp, err := c.provider.GenerateInterviewQuestions(ctx, req)
+	if c.config.DebugCapture != nil {
+		c.captureDebug(DebugOperationQuestions, req, resp, err)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("AI question generation failed: %w", err)
+	}
+	if redactor != nil {
+		for i := range resp.Questions {
+			resp.Questions[i].Question = redactor.Restore(resp.Questions[i].Question)
+		}
+	}
+	c.fillAttribution(&resp.Provider, &resp.Model)
+	cost, _ := c.estimateCost(resp.Model, resp.TokensUsed)
+	recordUsage(ctx, resp.Provider, resp.Model, resp.TokensUsed, cost)
+	return resp, nil
+}
+
+// hasTimeFor reports whether ctx leaves at least d before its deadline; contexts without one always do
+func hasTimeFor(ctx context.Context, d time.Duration) bool {
+	deadline, ok := ctx.Deadline()
+	return !ok || time.Until(deadline) >= d
+}
+
+// withCallTimeout bounds a single AI operation, so a hung provider can't outlive its caller
+// even when the caller passes a context without a deadline. An earlier caller deadline still wins.
+func (c *AIClient) withCallTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(ctx, c.perRequestTimeout())
+}
+
+// perRequestTimeout returns the configured per-call timeout, defaulting to the HTTP RequestTimeout
+func (c *AIClient) perRequestTimeout() time.Duration {
+	switch {
+	case c.config.PerRequestTimeout > 0:
+		return c.config.PerRequestTimeout
+	case c.config.RequestTimeout > 0:
+		return c.config.RequestTimeout
+	default:
+		return defaultPerRequestTimeout
+	}
+}
+
+// fillAttribution sets provider/model to the client's configuration when a provider leaves them empty
+func (c *AIClient) fillAttribution(provider, model *string) {
+	if *provider == "" {
+		*provider = c.GetCurrentProvider()
+	}
+	if *model == "" {
+		*model = c.GetCurrentModel()
+	}
 }
 
 // GetCurrentProvider returns the currently configured AI provider
@@ -147,14 +760,15 @@ func (c *AIClient) GetCurrentProvider() string {
 	return c.provider.GetProviderName()
 }
 
-// GetCurrentModel returns the currently configured AI model
+// GetCurrentModel returns the currently configured AI model, after replacing a retired name
 func (c *AIClient) GetCurrentModel() string {
-	return c.config.DefaultModel
+	model, _ := ResolveModel(c.config.DefaultModelFor(c.provider.GetProviderName()), c.config.ModelAliases)
+	return model
 }
 
 // buildChatMessages builds message array for chat generation
 // Helper function (not a method to avoid parameter issues)
-func buildChatMessages(history []map[string]string, userMessage, language string, isClosing bool) []Message {
+func buildChatMessages(history []Turn, userMessage, language string, isClosing bool) []Message {
 	systemPrompt := buildSystemPrompt(language, isClosing)
 
 	messages := []Message{
@@ -162,22 +776,18 @@ func buildChatMessages(history []map[string]string, userMessage, language string
 	}
 
 	// Add conversation history
-	for _, msg := range history {
-		if role, ok := msg["role"]; ok {
-			if content, ok := msg["content"]; ok {
-				// Convert "ai" role to "assistant" for OpenAI API compatibility
-				// Database stores as "ai"/"user", but OpenAI API expects "assistant"/"user"
-				apiRole := role
-				if role == "ai" {
-					apiRole = "assistant"
-				}
-
-				messages = append(messages, Message{
-					Role:    apiRole,
-					Content: content,
-				})
-			}
+	for _, turn := range history {
+		// Convert "ai" role to "assistant" for OpenAI API compatibility
+		// Database stores as "ai"/"user", but OpenAI API expects "assistant"/"user"
+		apiRole := turn.Role
+		if turn.Role == TurnRoleInterviewer {
+			apiRole = "assistant"
 		}
+
+		messages = append(messages, Message{
+			Role:    apiRole,
+			Content: turn.Content,
+		})
 	}
 
 	// Add current user message if provided
@@ -1,8 +1,15 @@
 package ai
 
 import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
+
+	"go.uber.org/goleak"
 )
 
 // Test NewAIClient with various configurations
@@ -198,14 +205,18 @@ func TestShouldEndInterview(t *testing.T) {
 	tests := []struct {
 		name         string
 		messageCount int
+		reopens      int
 		expected     bool
 	}{
-		{"less than threshold", 5, false},
-		{"at threshold", 8, true},
-		{"above threshold", 10, true},
-		{"zero messages", 0, false},
-		{"one message", 1, false},
-		{"seven messages", 7, false},
+		{"less than threshold", 5, 0, false},
+		{"at threshold", 8, 0, true},
+		{"above threshold", 10, 0, true},
+		{"zero messages", 0, 0, false},
+		{"one message", 1, 0, false},
+		{"seven messages", 7, 0, false},
+		{"reopened at old threshold", 8, 1, false},
+		{"reopened at raised threshold", 12, 1, true},
+		{"reopened twice", 12, 2, false},
 	}
 
 	client, err := NewAIClient(createTestConfig(ProviderMock))
@@ -215,9 +226,9 @@ func TestShouldEndInterview(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := client.ShouldEndInterview(tt.messageCount)
+			result := client.ShouldEndInterview(tt.messageCount, tt.reopens)
 			if result != tt.expected {
-				t.Errorf("ShouldEndInterview(%d) = %v, expected %v", tt.messageCount, result, tt.expected)
+				t.Errorf("ShouldEndInterview(%d, %d) = %v, expected %v", tt.messageCount, tt.reopens, result, tt.expected)
 			}
 		})
 	}
@@ -335,7 +346,7 @@ func TestBuildSystemPrompt(t *testing.T) {
 func TestBuildChatMessages(t *testing.T) {
 	tests := []struct {
 		name            string
-		history         []map[string]string
+		history         []Turn
 		userMessage     string
 		language        string
 		isClosing       bool
@@ -344,7 +355,7 @@ func TestBuildChatMessages(t *testing.T) {
 	}{
 		{
 			name:             "empty history",
-			history:          []map[string]string{},
+			history:          []Turn{},
 			userMessage:      "Hello",
 			language:         "en",
 			isClosing:        false,
@@ -353,9 +364,9 @@ func TestBuildChatMessages(t *testing.T) {
 		},
 		{
 			name: "history with ai role conversion",
-			history: []map[string]string{
-				{"role": "ai", "content": "Hi there!"},  // Should convert to "assistant"
-				{"role": "user", "content": "Hello"},
+			history: []Turn{
+				{Role: TurnRoleInterviewer, Content: "Hi there!"},  // Should convert to "assistant"
+				{Role: TurnRoleCandidate, Content: "Hello"},
 			},
 			userMessage:      "How are you?",
 			language:         "en",
@@ -365,9 +376,9 @@ func TestBuildChatMessages(t *testing.T) {
 		},
 		{
 			name: "history without new message",
-			history: []map[string]string{
-				{"role": "user", "content": "Question"},
-				{"role": "ai", "content": "Answer"},  // Should convert to "assistant"
+			history: []Turn{
+				{Role: TurnRoleCandidate, Content: "Question"},
+				{Role: TurnRoleInterviewer, Content: "Answer"},  // Should convert to "assistant"
 			},
 			userMessage:      "",  // Empty
 			language:         "zh-TW",
@@ -377,7 +388,7 @@ func TestBuildChatMessages(t *testing.T) {
 		},
 		{
 			name:             "closing message in Chinese",
-			history:          []map[string]string{},
+			history:          []Turn{},
 			userMessage:      "謝謝",
 			language:         "zh-TW",
 			isClosing:        true,
@@ -428,7 +439,7 @@ func TestGenerateChatResponse(t *testing.T) {
 		t.Fatalf("Failed to create client: %v", err)
 	}
 
-	response, err := client.GenerateChatResponse("session1", []map[string]string{}, "Hello")
+	response, err := client.GenerateChatResponse(context.Background(), "session1", []map[string]string{}, "Hello")
 	if err != nil {
 		t.Fatalf("GenerateChatResponse failed: %v", err)
 	}
@@ -477,7 +488,7 @@ func TestGenerateChatResponseWithLanguage(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			response, err := client.GenerateChatResponseWithLanguage("session1", tt.history, tt.message, tt.lang)
+			response, err := client.GenerateChatResponseWithLanguage(context.Background(), "session1", tt.history, tt.message, tt.lang)
 
 			if err != nil {
 				t.Errorf("GenerateChatResponseWithLanguage failed: %v", err)
@@ -508,7 +519,7 @@ func TestGenerateClosingMessage(t *testing.T) {
 		{"role": "ai", "content": "Hi there"},
 	}
 
-	response, err := client.GenerateClosingMessage("session1", history, "Thank you")
+	response, err := client.GenerateClosingMessage(context.Background(), "session1", history, "Thank you")
 	if err != nil {
 		t.Fatalf("GenerateClosingMessage failed: %v", err)
 	}
@@ -540,7 +551,7 @@ func TestGenerateClosingMessageWithLanguage(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			response, err := client.GenerateClosingMessageWithLanguage("session1", history, "Goodbye", tt.lang)
+			response, err := client.GenerateClosingMessageWithLanguage(context.Background(), "session1", history, "Goodbye", tt.lang)
 
 			if err != nil {
 				t.Errorf("GenerateClosingMessageWithLanguage failed: %v", err)
@@ -554,6 +565,265 @@ func TestGenerateClosingMessageWithLanguage(t *testing.T) {
 	}
 }
 
+// Test GenerateChatReply returns the full response with provider timing
+func TestGenerateChatReply(t *testing.T) {
+	provider := NewMockProvider()
+	provider.SetDelay(20 * time.Millisecond)
+	client := NewAIClientWithProvider(provider, nil)
+
+	for _, closing := range []bool{false, true} {
+		resp, err := client.GenerateChatReply(context.Background(), "session1", nil, "Hello", "en", closing)
+		if err != nil {
+			t.Fatalf("GenerateChatReply failed: %v", err)
+		}
+		if resp.Content == "" {
+			t.Error("Expected non-empty content")
+		}
+		if resp.ResponseTime < 20*time.Millisecond {
+			t.Errorf("Expected response time to include the mock delay, got %v", resp.ResponseTime)
+		}
+	}
+
+	requests := provider.ChatRequests()
+	if requests[0].MaxTokens != 500 || requests[1].MaxTokens != 300 {
+		t.Errorf("Expected 500/300 max tokens for chat/closing, got %d/%d", requests[0].MaxTokens, requests[1].MaxTokens)
+	}
+
+	// A cancelled context interrupts the artificial delay
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := client.GenerateChatReply(ctx, "session1", nil, "Hello", "en", false); err == nil {
+		t.Error("Expected error for cancelled context")
+	}
+}
+
+func TestGenerateChatReply_LanguageCheck(t *testing.T) {
+	tests := []struct {
+		name            string
+		language        string
+		script          []string
+		wantContent     string
+		wantRequests    int
+		wantMismatch    bool
+		wantInstruction string
+	}{
+		{"chinese reply accepted", "zh-TW", []string{"請介紹一下你自己。"}, "請介紹一下你自己。", 1, false, ""},
+		{"mixed technical terms accepted", "zh-TW", []string{"請說明你如何使用 Go 的 goroutine 和 channel。"}, "請說明你如何使用 Go 的 goroutine 和 channel。", 1, false, ""},
+		{"english retried in chinese", "zh-TW", []string{"Tell me about yourself.", "請介紹一下你自己。"}, "請介紹一下你自己。", 2, false, "Traditional Chinese (繁體中文)"},
+		{"english twice flagged", "zh-TW", []string{"Tell me about yourself.", "Please introduce yourself."}, "Please introduce yourself.", 2, true, "Traditional Chinese (繁體中文)"},
+		{"english retried in japanese", "ja", []string{"Tell me about yourself.", "自己紹介をお願いします。"}, "自己紹介をお願いします。", 2, false, "Japanese (日本語)"},
+		{"regional korean retried in korean", "ko-KR", []string{"Tell me about yourself.", "자기소개를 해주세요."}, "자기소개를 해주세요.", 2, false, "Korean (한국어)"},
+		{"english session not checked", "en", []string{"Tell me about yourself."}, "Tell me about yourself.", 1, false, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := NewScriptedMockProvider(tt.script...)
+			client := NewAIClientWithProvider(provider, nil)
+
+			resp, err := client.GenerateChatReply(context.Background(), "session1", nil, "Hello", tt.language, false)
+			if err != nil {
+				t.Fatalf("GenerateChatReply failed: %v", err)
+			}
+			if resp.Content != tt.wantContent {
+				t.Errorf("Expected content %q, got %q", tt.wantContent, resp.Content)
+			}
+			if mismatch, _ := resp.Metadata[MetadataLanguageMismatch].(bool); mismatch != tt.wantMismatch {
+				t.Errorf("Expected language mismatch %v, got %v", tt.wantMismatch, mismatch)
+			}
+
+			requests := provider.ChatRequests()
+			if len(requests) != tt.wantRequests {
+				t.Fatalf("Expected %d provider requests, got %d", tt.wantRequests, len(requests))
+			}
+			if tt.wantRequests == 2 {
+				// The retry repeats the conversation plus a stronger language instruction
+				retry := requests[1].Messages
+				if len(retry) != len(requests[0].Messages)+1 || !strings.Contains(retry[len(retry)-1].Content, "written in "+tt.wantInstruction+".") {
+					t.Errorf("Expected retry to append a %s instruction, got %+v", tt.wantInstruction, retry)
+				}
+				if resp.TokensUsed.TotalTokens != 60 {
+					t.Errorf("Expected token usage of both attempts (60), got %d", resp.TokensUsed.TotalTokens)
+				}
+			}
+		})
+	}
+}
+
+func TestNewAIClient_ValidateOnStartup(t *testing.T) {
+	newServer := func(status int, hits *int) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			*hits++
+			w.WriteHeader(status)
+			_, _ = w.Write([]byte(`{}`))
+		}))
+	}
+
+	tests := []struct {
+		name          string
+		openAIStatus  int
+		geminiStatus  int
+		validate      bool
+		fallback      bool
+		allowMock     bool
+		wantProvider  string
+		wantErr       bool
+		wantCheckHits bool
+	}{
+		{name: "validation disabled", openAIStatus: http.StatusUnauthorized, geminiStatus: http.StatusUnauthorized, wantProvider: ProviderOpenAI},
+		{name: "default healthy", openAIStatus: http.StatusOK, geminiStatus: http.StatusUnauthorized, validate: true, fallback: true, wantProvider: ProviderOpenAI, wantCheckHits: true},
+		{name: "fallback to healthy provider", openAIStatus: http.StatusUnauthorized, geminiStatus: http.StatusOK, validate: true, fallback: true, wantProvider: ProviderGemini, wantCheckHits: true},
+		{name: "default unhealthy without fallback", openAIStatus: http.StatusUnauthorized, geminiStatus: http.StatusOK, validate: true, wantErr: true, wantCheckHits: true},
+		{name: "none healthy", openAIStatus: http.StatusUnauthorized, geminiStatus: http.StatusUnauthorized, validate: true, fallback: true, wantErr: true, wantCheckHits: true},
+		{name: "none healthy with mock allowed", openAIStatus: http.StatusUnauthorized, geminiStatus: http.StatusUnauthorized, validate: true, fallback: true, allowMock: true, wantProvider: ProviderMock, wantCheckHits: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var openAIHits, geminiHits int
+			openAIServer := newServer(tt.openAIStatus, &openAIHits)
+			defer openAIServer.Close()
+			geminiServer := newServer(tt.geminiStatus, &geminiHits)
+			defer geminiServer.Close()
+
+			cfg := createTestConfig(ProviderOpenAI)
+			cfg.DefaultModel = "gpt-4"
+			cfg.GeminiAPIKey = "test-gemini-key-for-testing"
+			cfg.OpenAIBaseURL = openAIServer.URL
+			cfg.GeminiBaseURL = geminiServer.URL
+			cfg.ValidateOnStartup = tt.validate
+			cfg.EnableFallback = tt.fallback
+			cfg.AllowMockFallback = tt.allowMock
+
+			client, err := NewAIClient(cfg)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("Expected error, got client with provider %s", client.GetCurrentProvider())
+				}
+			} else {
+				if err != nil {
+					t.Fatalf("NewAIClient failed: %v", err)
+				}
+				if got := client.GetCurrentProvider(); got != tt.wantProvider {
+					t.Errorf("Expected provider %s, got %s", tt.wantProvider, got)
+				}
+				// A switched provider must not keep the original provider's model
+				if tt.wantProvider != ProviderOpenAI && client.GetCurrentModel() == "gpt-4" {
+					t.Errorf("Expected default model to change with the provider, got %s", client.GetCurrentModel())
+				}
+			}
+
+			// Every configured provider is checked, not just the default
+			if checked := openAIHits > 0 && geminiHits > 0; checked != tt.wantCheckHits {
+				t.Errorf("Expected credential checks on both providers: %v, got openai=%d gemini=%d", tt.wantCheckHits, openAIHits, geminiHits)
+			}
+			if cfg.DefaultProvider != ProviderOpenAI {
+				t.Errorf("Expected caller's config to be left unchanged, got default provider %s", cfg.DefaultProvider)
+			}
+		})
+	}
+}
+
+func TestAIClient_CallTimeouts(t *testing.T) {
+	defer goleak.VerifyNone(t, goleak.IgnoreCurrent())
+
+	// The provider hangs until the client gives up
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-release:
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	newClient := func(perRequestTimeout time.Duration) *AIClient {
+		cfg := createTestConfig(ProviderOpenAI)
+		cfg.OpenAIBaseURL = server.URL
+		cfg.PerRequestTimeout = perRequestTimeout
+		client, err := NewAIClient(cfg)
+		if err != nil {
+			t.Fatalf("NewAIClient failed: %v", err)
+		}
+		return client
+	}
+
+	tests := []struct {
+		name string
+		call func(ctx context.Context, client *AIClient) error
+	}{
+		{"chat reply", func(ctx context.Context, client *AIClient) error {
+			_, err := client.GenerateChatReply(ctx, "session1", nil, "Hello", "en", false)
+			return err
+		}},
+		{"summarization", func(ctx context.Context, client *AIClient) error {
+			_, err := client.SummarizeForContextDetailed(ctx, "long text", "en")
+			return err
+		}},
+		{"evaluation", func(ctx context.Context, client *AIClient) error {
+			_, err := client.EvaluateAnswersDetailed(ctx, []string{"Q1"}, []string{"A1"}, EvaluationContext{Language: "en"})
+			return err
+		}},
+		{"legacy chat response", func(ctx context.Context, client *AIClient) error {
+			_, err := client.GenerateChatResponseWithLanguage(ctx, "session1", nil, "Hello", "en")
+			return err
+		}},
+		{"legacy closing message", func(ctx context.Context, client *AIClient) error {
+			_, err := client.GenerateClosingMessageWithLanguage(ctx, "session1", nil, "Thanks", "en")
+			return err
+		}},
+		{"legacy evaluation", func(ctx context.Context, client *AIClient) error {
+			_, _, err := client.EvaluateAnswersWithContext(ctx, []string{"Q1"}, []string{"A1"}, EvaluationContext{Language: "en"})
+			return err
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name+" bounded without caller deadline", func(t *testing.T) {
+			start := time.Now()
+			if err := tt.call(context.Background(), newClient(50*time.Millisecond)); err == nil {
+				t.Fatal("Expected timeout error")
+			}
+			if elapsed := time.Since(start); elapsed > time.Second {
+				t.Errorf("Expected call to stop at the per-request timeout, took %v", elapsed)
+			}
+		})
+
+		t.Run(tt.name+" aborted by caller cancellation", func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			time.AfterFunc(50*time.Millisecond, cancel)
+			start := time.Now()
+			if err := tt.call(ctx, newClient(time.Minute)); err == nil {
+				t.Fatal("Expected cancellation error")
+			}
+			if elapsed := time.Since(start); elapsed > time.Second {
+				t.Errorf("Expected call to stop when the caller cancels, took %v", elapsed)
+			}
+		})
+	}
+}
+
+func TestGenerateChatReply_RetrySharesTimeout(t *testing.T) {
+	// Each attempt takes 60ms; a 100ms budget covers the first attempt but not a full retry
+	provider := NewScriptedMockProvider("English reply", "請介紹一下你自己。")
+	provider.SetDelay(60 * time.Millisecond)
+	client := NewAIClientWithProvider(provider, &AIConfig{DefaultModel: "mock-model", PerRequestTimeout: 100 * time.Millisecond})
+
+	start := time.Now()
+	resp, err := client.GenerateChatReply(context.Background(), "session1", nil, "Hello", "zh-TW", false)
+	if err != nil {
+		t.Fatalf("GenerateChatReply failed: %v", err)
+	}
+	// The retry runs out of budget, so the first reply comes back flagged
+	if mismatch, _ := resp.Metadata[MetadataLanguageMismatch].(bool); !mismatch || resp.Content != "English reply" {
+		t.Errorf("Expected the flagged first reply, got %q (mismatch %v)", resp.Content, mismatch)
+	}
+	if elapsed := time.Since(start); elapsed > 150*time.Millisecond {
+		t.Errorf("Expected the retry to stop at the remaining budget, took %v", elapsed)
+	}
+}
+
 // Test EvaluateAnswers (calls EvaluateAnswersWithContext)
 func TestEvaluateAnswers(t *testing.T) {
 	client, err := NewAIClient(createTestConfig(ProviderMock))
@@ -564,7 +834,7 @@ func TestEvaluateAnswers(t *testing.T) {
 	questions := []string{"Tell me about yourself", "What are your strengths?"}
 	answers := []string{"I am a developer", "Problem solving"}
 
-	score, feedback, err := client.EvaluateAnswers(questions, answers, "en")
+	score, feedback, err := client.EvaluateAnswers(context.Background(), questions, answers, "en")
 	if err != nil {
 		t.Fatalf("EvaluateAnswers failed: %v", err)
 	}
@@ -632,7 +902,10 @@ func TestEvaluateAnswersWithContext(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			score, feedback, err := client.EvaluateAnswersWithContext(tt.questions, tt.answers, tt.jobDesc, tt.lang)
+			score, feedback, err := client.EvaluateAnswersWithContext(context.Background(), tt.questions, tt.answers, EvaluationContext{
+				JobDescription: tt.jobDesc,
+				Language:       tt.lang,
+			})
 
 			if tt.wantErr {
 				if err == nil {
@@ -669,6 +942,219 @@ func TestEvaluateAnswersWithContext(t *testing.T) {
 	}
 }
 
+// Test EvaluateAnswersWithContext forwards interview context to the provider
+func TestEvaluateAnswersWithContext_PopulatesRequest(t *testing.T) {
+	provider := NewMockProvider()
+	client := NewAIClientWithProvider(provider, nil)
+
+	_, _, err := client.EvaluateAnswersWithContext(context.Background(), []string{"Q1"}, []string{"A1"}, EvaluationContext{
+		JobDescription: "Backend Engineer",
+		InterviewType:  "behavioral",
+		ResumeContent:  "5 years of Go",
+		CompanyContext: "Fintech startup",
+		Language:       "zh-TW",
+	})
+	if err != nil {
+		t.Fatalf("EvaluateAnswersWithContext failed: %v", err)
+	}
+
+	requests := provider.EvaluationRequests()
+	if len(requests) != 1 {
+		t.Fatalf("Expected 1 evaluation request, got %d", len(requests))
+	}
+	req := requests[0]
+	if req.JobDesc != "Backend Engineer" || req.InterviewType != "behavioral" ||
+		req.ResumeContent != "5 years of Go" || req.CompanyContext != "Fintech startup" || req.Language != "zh-TW" {
+		t.Errorf("Evaluation request missing context: %+v", req)
+	}
+}
+
+func TestEvaluateAnswersWithContext_DetailLevel(t *testing.T) {
+	testCases := []struct {
+		detailLevel string
+		expected    string
+	}{
+		{"", DetailLevelStandard},
+		{DetailLevelBrief, DetailLevelBrief},
+		{DetailLevelDetailed, DetailLevelDetailed},
+	}
+
+	for _, tc := range testCases {
+		provider := NewMockProvider()
+		client := NewAIClientWithProvider(provider, nil)
+
+		if _, _, err := client.EvaluateAnswersWithContext(context.Background(), []string{"Q1"}, []string{"A1"}, EvaluationContext{DetailLevel: tc.detailLevel}); err != nil {
+			t.Fatalf("EvaluateAnswersWithContext failed: %v", err)
+		}
+		if got := provider.EvaluationRequests()[0].DetailLevel; got != tc.expected {
+			t.Errorf("Detail level %q: expected request level %q, got %q", tc.detailLevel, tc.expected, got)
+		}
+	}
+}
+
+// Test SummarizeForContext
+func TestSummarizeForContext(t *testing.T) {
+	t.Run("canned mock summary", func(t *testing.T) {
+		client, err := NewAIClient(createTestConfig(ProviderMock))
+		if err != nil {
+			t.Fatalf("Failed to create client: %v", err)
+		}
+
+		summary, err := client.SummarizeForContext(context.Background(), "A very long take-home solution", "en")
+		if err != nil {
+			t.Fatalf("SummarizeForContext failed: %v", err)
+		}
+		if !contains(summary, "[MOCK] Summary:") {
+			t.Errorf("Expected mock summary, got: %s", summary)
+		}
+	})
+
+	t.Run("request shape", func(t *testing.T) {
+		provider := NewScriptedMockProvider("condensed")
+		client := NewAIClientWithProvider(provider, nil)
+
+		summary, err := client.SummarizeForContext(context.Background(), "full text", "zh-TW")
+		if err != nil {
+			t.Fatalf("SummarizeForContext failed: %v", err)
+		}
+		if summary != "condensed" {
+			t.Errorf("Expected scripted summary, got: %s", summary)
+		}
+
+		requests := provider.ChatRequests()
+		if len(requests) != 1 {
+			t.Fatalf("Expected 1 request, got %d", len(requests))
+		}
+		req := requests[0]
+		if req.Context["task"] != TaskSummarization {
+			t.Errorf("Expected summarization task tag, got %v", req.Context["task"])
+		}
+		if req.Model != "mock-model" {
+			t.Errorf("Expected cheap model for mock provider, got %s", req.Model)
+		}
+		if !contains(req.Messages[0].Content, "繁體中文") {
+			t.Errorf("Expected Traditional Chinese instruction in system prompt")
+		}
+		if req.Messages[1].Content != "full text" {
+			t.Errorf("Expected full text as user message, got %s", req.Messages[1].Content)
+		}
+	})
+
+	t.Run("custom endpoint keeps configured model", func(t *testing.T) {
+		cfg := createTestConfig(ProviderMock)
+		cfg.OpenAIBaseURL = "https://api.groq.com/openai/v1"
+		client := NewAIClientWithProvider(NewScriptedMockProvider("ok"), cfg)
+		if model := client.summarizationModel(); model != "" {
+			t.Errorf("Expected empty model override for custom endpoint, got %s", model)
+		}
+	})
+}
+
+// Test scripted mock provider ordering and fallback
+func TestScriptedMockProvider(t *testing.T) {
+	provider := NewScriptedMockProvider("first", "second")
+	ctx := context.Background()
+
+	for _, want := range []string{"first", "second"} {
+		resp, err := provider.GenerateResponse(ctx, &ChatRequest{})
+		if err != nil {
+			t.Fatalf("GenerateResponse failed: %v", err)
+		}
+		if resp.Content != want {
+			t.Errorf("Expected %q, got %q", want, resp.Content)
+		}
+	}
+
+	// Script exhausted - falls back to canned response
+	resp, _ := provider.GenerateResponse(ctx, &ChatRequest{})
+	if !contains(resp.Content, "[MOCK]") {
+		t.Errorf("Expected canned response after script is exhausted, got %q", resp.Content)
+	}
+	if len(provider.ChatRequests()) != 3 {
+		t.Errorf("Expected 3 recorded requests, got %d", len(provider.ChatRequests()))
+	}
+}
+
+func TestAIClient_AttemptHook(t *testing.T) {
+	provider := NewScriptedMockProvider("first", "second")
+	client := NewAIClientWithProvider(provider, nil)
+	attempts := 0
+	client.SetAttemptHook(func() error {
+		if attempts == 1 {
+			return ErrAttemptBudgetExhausted
+		}
+		attempts++
+		return nil
+	})
+	ctx := context.Background()
+
+	if _, err := client.GenerateChatReply(ctx, "session-1", nil, "", "en", false); err != nil {
+		t.Fatalf("GenerateChatReply failed: %v", err)
+	}
+	if _, err := client.GenerateChatReply(ctx, "session-1", nil, "", "en", false); !errors.Is(err, ErrAttemptBudgetExhausted) {
+		t.Errorf("Expected ErrAttemptBudgetExhausted, got %v", err)
+	}
+	if _, err := client.EvaluateAnswersDetailed(ctx, []string{"Q1"}, []string{"A1"}, EvaluationContext{}); !errors.Is(err, ErrAttemptBudgetExhausted) {
+		t.Errorf("Expected ErrAttemptBudgetExhausted from evaluation, got %v", err)
+	}
+	// Refused calls never reach the provider
+	if len(provider.ChatRequests()) != 1 {
+		t.Errorf("Expected 1 provider call, got %d", len(provider.ChatRequests()))
+	}
+}
+
+func TestAnswersLanguageMismatch(t *testing.T) {
+	tests := []struct {
+		name     string
+		answers  []string
+		language string
+		expected bool
+	}{
+		{"english answers in english", []string{"Goroutines are lightweight threads."}, "en", false},
+		{"chinese answers in chinese", []string{"協程是輕量級的執行緒，由執行環境排程。"}, "zh-TW", false},
+		{"chinese answers with english terms", []string{"我會用 Redis cache 來處理這個問題，並設定過期時間。"}, "zh-TW", false},
+		{"english answers in chinese", []string{"Goroutines are lightweight threads.", "I would use a cache."}, "zh-TW", true},
+		{"chinese answers in english", []string{"協程是輕量級的執行緒，由執行環境排程。"}, "en", true},
+		{"too short to judge", []string{"OK"}, "zh-TW", false},
+		{"no letters", []string{"42", "..."}, "zh-TW", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := answersLanguageMismatch(tt.answers, tt.language); got != tt.expected {
+				t.Errorf("answersLanguageMismatch(%q, %q) = %v, expected %v", tt.answers, tt.language, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestEvaluateAnswersDetailed_LanguageMismatch(t *testing.T) {
+	provider := NewMockProvider()
+	client := NewAIClientWithProvider(provider, nil)
+	ctx := context.Background()
+
+	resp, err := client.EvaluateAnswersDetailed(ctx, []string{"什麼是協程？"}, []string{"A goroutine is a lightweight thread managed by the Go runtime."}, EvaluationContext{Language: "zh-TW"})
+	if err != nil {
+		t.Fatalf("EvaluateAnswersDetailed failed: %v", err)
+	}
+	if !resp.LanguageMismatch {
+		t.Error("Expected English answers to a zh-TW interview to be flagged")
+	}
+	requests := provider.EvaluationRequests()
+	if len(requests) != 1 || !requests[0].LanguageMismatch || !strings.Contains(BuildEvaluationPrompt(requests[0]), "Language Note:") {
+		t.Errorf("Expected the evaluation request to carry the language note, got %+v", requests)
+	}
+
+	// Matching languages change nothing
+	resp, err = client.EvaluateAnswersDetailed(ctx, []string{"What is a goroutine?"}, []string{"A lightweight thread managed by the Go runtime."}, EvaluationContext{Language: "en"})
+	if err != nil {
+		t.Fatalf("EvaluateAnswersDetailed failed: %v", err)
+	}
+	if resp.LanguageMismatch || provider.EvaluationRequests()[1].LanguageMismatch {
+		t.Error("Expected no mismatch when the answers match the interview language")
+	}
+}
+
 // Helper function for string contains check
 func contains(s, substr string) bool {
 	return len(s) >= len(substr) && (s == substr || len(substr) == 0 ||
@@ -683,3 +1169,118 @@ func findSubstring(s, substr string) bool {
 	}
 	return false
 }
+
+func TestNextDifficultyLevel(t *testing.T) {
+	tests := []struct {
+		level   int
+		quality string
+		want    int
+	}{
+		{3, AnswerQualityStrong, 4},
+		{3, AnswerQualityWeak, 2},
+		{3, AnswerQualityAdequate, 3},
+		{MaxDifficultyLevel, AnswerQualityStrong, MaxDifficultyLevel},
+		{MinDifficultyLevel, AnswerQualityWeak, MinDifficultyLevel},
+	}
+	for _, tt := range tests {
+		if got := NextDifficultyLevel(tt.level, tt.quality); got != tt.want {
+			t.Errorf("NextDifficultyLevel(%d, %q) = %d, want %d", tt.level, tt.quality, got, tt.want)
+		}
+	}
+}
+
+func TestAssessAnswer(t *testing.T) {
+	t.Run("scripted judgment", func(t *testing.T) {
+		provider := NewScriptedMockProvider("unused chat reply")
+		provider.SetAssessments("weak")
+		client := NewAIClientWithProvider(provider, nil)
+
+		assessment := client.AssessAnswer(context.Background(), "What is Go?", "A long and detailed answer", "en")
+		if assessment.Quality != AnswerQualityWeak || assessment.Heuristic {
+			t.Errorf("Expected the provider's weak judgment, got %+v", assessment)
+		}
+		if len(provider.ChatRequests()) != 0 {
+			t.Error("Expected assessments not to consume the chat script")
+		}
+	})
+
+	t.Run("unreadable reply falls back to length", func(t *testing.T) {
+		if quality, ok := parseAnswerQuality("Strong."); !ok || quality != AnswerQualityStrong {
+			t.Errorf("Expected STRONG with punctuation to parse, got %q %v", quality, ok)
+		}
+		if _, ok := parseAnswerQuality("The answer is fine"); ok {
+			t.Error("Expected free text not to parse")
+		}
+		if got := heuristicAnswerQuality("Not sure"); got != AnswerQualityWeak {
+			t.Errorf("Expected a two-word answer to be weak, got %s", got)
+		}
+		if got := heuristicAnswerQuality(strings.Repeat("detail ", 60)); got != AnswerQualityStrong {
+			t.Errorf("Expected a 60-word answer to be strong, got %s", got)
+		}
+		if got := heuristicAnswerQuality("Goroutines are lightweight threads managed by the runtime"); got != AnswerQualityAdequate {
+			t.Errorf("Expected a medium answer to be adequate, got %s", got)
+		}
+	})
+}
+
+func TestDifficultyNote(t *testing.T) {
+	if note := DifficultyNote(3, 4); note.Role != TurnRoleNote || !contains(note.Content, "harder") || !contains(note.Content, "target difficulty: hard") {
+		t.Errorf("Expected a harder note targeting hard, got %v", note)
+	}
+	if note := DifficultyNote(2, 1); !contains(note.Content, "easier") || !contains(note.Content, "very easy") {
+		t.Errorf("Expected an easier note targeting very easy, got %v", note)
+	}
+}
+
+func TestJobDescriptionLimits(t *testing.T) {
+	limits := JobDescriptionLimits{SoftLimit: 5, HardLimit: 10}
+	tests := []struct {
+		jobDesc      string
+		needsSummary bool
+		exceeds      bool
+	}{
+		{"short", false, false},
+		{"longer one", true, false},
+		{"far too long", true, true},
+		// Limits count characters, not bytes
+		{"工程師職位", false, false},
+	}
+	for _, tt := range tests {
+		if got := limits.NeedsSummary(tt.jobDesc); got != tt.needsSummary {
+			t.Errorf("NeedsSummary(%q) = %v, want %v", tt.jobDesc, got, tt.needsSummary)
+		}
+		if got := limits.Exceeds(tt.jobDesc); got != tt.exceeds {
+			t.Errorf("Exceeds(%q) = %v, want %v", tt.jobDesc, got, tt.exceeds)
+		}
+	}
+	if got := limits.Truncate("short"); got != "short" {
+		t.Errorf("expected a short description to be kept, got %q", got)
+	}
+	if got := limits.Truncate("longer one"); !strings.HasPrefix(got, "longe") || strings.Contains(got, "longer") {
+		t.Errorf("expected truncation to the soft limit, got %q", got)
+	}
+	// Zero disables a limit
+	if (JobDescriptionLimits{}).NeedsSummary("longer one") || (JobDescriptionLimits{}).Exceeds("far too long") {
+		t.Error("expected zero limits to be disabled")
+	}
+}
+
+func TestSummarizeJobDescription(t *testing.T) {
+	provider := NewScriptedMockProvider("Senior Go engineer")
+	client := NewAIClientWithProvider(provider, nil)
+
+	resp, err := client.SummarizeJobDescription(context.Background(), "We are hiring a senior Go engineer...")
+	if err != nil {
+		t.Fatalf("SummarizeJobDescription failed: %v", err)
+	}
+	if resp.Content != "Senior Go engineer" {
+		t.Errorf("Expected the summary, got %q", resp.Content)
+	}
+	requests := provider.ChatRequests()
+	if len(requests) != 1 || requests[0].Context["task"] != TaskSummarization {
+		t.Fatalf("Expected 1 summarization request, got %v", requests)
+	}
+	if last := requests[0].Messages[len(requests[0].Messages)-1]; last.Content != "We are hiring a senior Go engineer..." {
+		t.Errorf("Expected the job description as the user message, got %q", last.Content)
+	}
+}
@@ -0,0 +1,123 @@
+// Global cap on concurrent AI provider calls
+package ai
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// DefaultMaxConcurrentRequests is the number of provider calls allowed in flight at once
+const DefaultMaxConcurrentRequests = 16
+
+// DefaultQueueTimeout is how long a provider call waits for a free slot before failing with ErrOverloaded
+const DefaultQueueTimeout = 10 * time.Second
+
+// ErrOverloaded is returned without calling the provider when no concurrency slot frees up within
+// the limiter's queue timeout
+var ErrOverloaded = errors.New("AI provider overloaded: too many concurrent requests")
+
+// aiRequestsInFlight and aiRequestsQueued report the limiter's current load
+var (
+	aiRequestsInFlight = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: "ai_interview",
+		Name:      "ai_requests_in_flight",
+		Help:      "AI provider calls currently running.",
+	})
+	aiRequestsQueued = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: "ai_interview",
+		Name:      "ai_requests_queued",
+		Help:      "AI provider calls waiting for a concurrency slot.",
+	})
+	aiRequestsOverloaded = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: "ai_interview",
+		Name:      "ai_requests_overloaded_total",
+		Help:      "AI provider calls refused after waiting longer than the queue timeout for a slot.",
+	})
+)
+
+// ConcurrencyLimiter caps the provider calls in flight across every AI client sharing it
+// It is safe for concurrent use; see AIConfig.Limiter.
+type ConcurrencyLimiter struct {
+	slots        chan struct{}
+	queueTimeout time.Duration
+	queued       atomic.Int64
+}
+
+// ConcurrencyStats is a snapshot of a limiter's load
+type ConcurrencyStats struct {
+	MaxConcurrent int
+	InFlight      int
+	Queued        int
+}
+
+// NewConcurrencyLimiter creates a limiter allowing maxConcurrent calls at once, each waiting at most
+// queueTimeout for a slot; zero values use DefaultMaxConcurrentRequests and DefaultQueueTimeout
+func NewConcurrencyLimiter(maxConcurrent int, queueTimeout time.Duration) *ConcurrencyLimiter {
+	if maxConcurrent <= 0 {
+		maxConcurrent = DefaultMaxConcurrentRequests
+	}
+	if queueTimeout <= 0 {
+		queueTimeout = DefaultQueueTimeout
+	}
+	return &ConcurrencyLimiter{slots: make(chan struct{}, maxConcurrent), queueTimeout: queueTimeout}
+}
+
+// Acquire waits for a free slot and returns the function releasing it
+// It fails with ErrOverloaded after the queue timeout, or with the context's error once ctx is done.
+func (l *ConcurrencyLimiter) Acquire(ctx context.Context) (release func(), err error) {
+	select {
+	case l.slots <- struct{}{}:
+		return l.acquired(), nil
+	default:
+	}
+
+	l.queued.Add(1)
+	aiRequestsQueued.Inc()
+	defer func() {
+		l.queued.Add(-1)
+		aiRequestsQueued.Dec()
+	}()
+	timer := time.NewTimer(l.queueTimeout)
+	defer timer.Stop()
+	select {
+	case l.slots <- struct{}{}:
+		return l.acquired(), nil
+	case <-timer.C:
+		aiRequestsOverloaded.Inc()
+		return nil, ErrOverloaded
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// acquired records a taken slot and returns its release function, which is safe to call twice
+func (l *ConcurrencyLimiter) acquired() func() {
+	aiRequestsInFlight.Inc()
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			<-l.slots
+			aiRequestsInFlight.Dec()
+		})
+	}
+}
+
+// Stats returns the limiter's current load
+func (l *ConcurrencyLimiter) Stats() ConcurrencyStats {
+	return ConcurrencyStats{
+		MaxConcurrent: cap(l.slots),
+		InFlight:      len(l.slots),
+		Queued:        int(l.queued.Load()),
+	}
+}
+
+// QueueTimeout returns how long calls wait for a slot before failing with ErrOverloaded
+func (l *ConcurrencyLimiter) QueueTimeout() time.Duration {
+	return l.queueTimeout
+}
@@ -0,0 +1,115 @@
+package ai
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// concurrencyProbe is a slow mock provider recording the most chat calls it saw running at once
+type concurrencyProbe struct {
+	*MockProvider
+	running atomic.Int64
+	peak    atomic.Int64
+}
+
+func (p *concurrencyProbe) GenerateResponse(ctx context.Context, req *ChatRequest) (*ChatResponse, error) {
+	running := p.running.Add(1)
+	defer p.running.Add(-1)
+	for {
+		peak := p.peak.Load()
+		if running <= peak || p.peak.CompareAndSwap(peak, running) {
+			break
+		}
+	}
+	return p.MockProvider.GenerateResponse(ctx, req)
+}
+
+func newConcurrencyProbe(delay time.Duration) *concurrencyProbe {
+	mock := NewMockProvider()
+	mock.SetDelay(delay)
+	return &concurrencyProbe{MockProvider: mock}
+}
+
+func TestConcurrencyLimiter_CapsConcurrentCalls(t *testing.T) {
+	provider := newConcurrencyProbe(20 * time.Millisecond)
+	limiter := NewConcurrencyLimiter(3, 5*time.Second)
+
+	var wg sync.WaitGroup
+	var failures atomic.Int64
+	for range 20 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			// Each request builds its own client around the shared limiter, like the API handlers
+			client := NewAIClientWithProvider(provider, &AIConfig{Limiter: limiter})
+			if _, err := client.GenerateChatReply(context.Background(), "session", nil, "Hello", "en", false); err != nil {
+				failures.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if failures.Load() != 0 {
+		t.Errorf("expected every call to get a slot, %d failed", failures.Load())
+	}
+	if peak := provider.peak.Load(); peak != 3 {
+		t.Errorf("expected at most 3 concurrent provider calls, saw %d", peak)
+	}
+	if stats := limiter.Stats(); stats.InFlight != 0 || stats.Queued != 0 || stats.MaxConcurrent != 3 {
+		t.Errorf("expected every slot to be released, got %+v", stats)
+	}
+}
+
+func TestConcurrencyLimiter_QueueTimeout(t *testing.T) {
+	provider := newConcurrencyProbe(200 * time.Millisecond)
+	limiter := NewConcurrencyLimiter(1, 20*time.Millisecond)
+	busy := NewAIClientWithProvider(provider, &AIConfig{Limiter: limiter})
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_, _ = busy.GenerateChatReply(context.Background(), "busy", nil, "Hello", "en", false)
+	}()
+	for provider.running.Load() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+
+	// The attempt hook doesn't run for refused calls
+	attempts := 0
+	client := NewAIClientWithProvider(provider, &AIConfig{Limiter: limiter})
+	client.SetAttemptHook(func() error {
+		attempts++
+		return nil
+	})
+	if _, err := client.GenerateChatReply(context.Background(), "queued", nil, "Hello", "en", false); !errors.Is(err, ErrOverloaded) {
+		t.Errorf("expected ErrOverloaded, got %v", err)
+	}
+	if _, err := client.EvaluateAnswersDetailed(context.Background(), []string{"Q1"}, []string{"A1"}, EvaluationContext{}); !errors.Is(err, ErrOverloaded) {
+		t.Errorf("expected ErrOverloaded for evaluations, got %v", err)
+	}
+	if attempts != 0 {
+		t.Errorf("expected refused calls not to count as attempts, got %d", attempts)
+	}
+
+	// A cancelled caller stops waiting with its context's error
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := limiter.Acquire(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+	<-done
+}
+
+func TestAIClient_MaxConcurrentRequestsWithoutLimiter(t *testing.T) {
+	client := NewAIClientWithProvider(NewMockProvider(), &AIConfig{MaxConcurrentRequests: 2, QueueTimeout: time.Second})
+	if client.limiter == nil || client.limiter.Stats().MaxConcurrent != 2 {
+		t.Fatalf("expected the client to get its own limiter of 2")
+	}
+	if NewAIClientWithProvider(NewMockProvider(), nil).limiter != nil {
+		t.Error("expected no cap by default")
+	}
+}
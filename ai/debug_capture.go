@@ -0,0 +1,115 @@
+// Opt-in capture of provider request/response pairs for debugging AI output
+package ai
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/zidane0000/ai-interview-platform/utils"
+)
+
+// DefaultDebugCaptureSize is the number of exchanges kept per provider
+const DefaultDebugCaptureSize = 20
+
+// debugPayloadLimit caps each captured payload, in characters
+const debugPayloadLimit = 4000
+
+// Captured operations
+const (
+	DebugOperationChat       = "chat"
+	DebugOperationEvaluation = "evaluation"
+	DebugOperationQuestions  = "questions"
+)
+
+// DebugExchange is one captured provider call; payloads are redacted and truncated
+type DebugExchange struct {
+	Provider  string    `json:"provider"`
+	Model     string    `json:"model,omitempty"`
+	Operation string    `json:"operation"` // "chat" or "evaluation"
+	Request   string    `json:"request"`
+	Response  string    `json:"response,omitempty"`
+	Error     string    `json:"error,omitempty"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// DebugCapture keeps the most recent exchanges of each provider in fixed-size ring buffers
+// It is safe for concurrent use and shared by the per-request AI clients
+type DebugCapture struct {
+	mu    sync.Mutex
+	size  int
+	rings map[string]*debugRing
+}
+
+// debugRing is a fixed-size ring buffer of exchanges
+type debugRing struct {
+	entries []DebugExchange
+	next    int
+}
+
+// NewDebugCapture creates a capture keeping size exchanges per provider
+func NewDebugCapture(size int) *DebugCapture {
+	if size <= 0 {
+		size = DefaultDebugCaptureSize
+	}
+	return &DebugCapture{size: size, rings: make(map[string]*debugRing)}
+}
+
+// Record stores an exchange, overwriting the provider's oldest one when its buffer is full
+func (d *DebugCapture) Record(exchange DebugExchange) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	ring, ok := d.rings[exchange.Provider]
+	if !ok {
+		ring = &debugRing{entries: make([]DebugExchange, 0, d.size)}
+		d.rings[exchange.Provider] = ring
+	}
+	if len(ring.entries) < d.size {
+		ring.entries = append(ring.entries, exchange)
+		return
+	}
+	ring.entries[ring.next] = exchange
+	ring.next = (ring.next + 1) % d.size
+}
+
+// Snapshot returns the captured exchanges per provider, oldest first
+func (d *DebugCapture) Snapshot() map[string][]DebugExchange {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	snapshot := make(map[string][]DebugExchange, len(d.rings))
+	for provider, ring := range d.rings {
+		entries := make([]DebugExchange, 0, len(ring.entries))
+		entries = append(entries, ring.entries[ring.next:]...)
+		entries = append(entries, ring.entries[:ring.next]...)
+		snapshot[provider] = entries
+	}
+	return snapshot
+}
+
+// captureDebug records a provider call in the configured debug capture
+// Callers check c.config.DebugCapture first so disabled capture never marshals payloads
+func (c *AIClient) captureDebug(operation string, request, response interface{}, callErr error) {
+	exchange := DebugExchange{
+		Provider:  c.GetCurrentProvider(),
+		Model:     c.GetCurrentModel(),
+		Operation: operation,
+		Request:   debugPayload(request),
+		Timestamp: time.Now(),
+	}
+	if callErr != nil {
+		exchange.Error = truncateForPrompt(utils.Redact(callErr.Error()), debugPayloadLimit)
+	} else {
+		exchange.Response = debugPayload(response)
+	}
+	c.config.DebugCapture.Record(exchange)
+}
+
+// debugPayload marshals a payload for capture, redacting it before truncation so a cut
+// cannot expose part of a secret
+func debugPayload(payload interface{}) string {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return "unserializable payload: " + err.Error()
+	}
+	return truncateForPrompt(utils.Redact(string(body)), debugPayloadLimit)
+}
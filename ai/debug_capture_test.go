@@ -0,0 +1,112 @@
+package ai
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/zidane0000/ai-interview-platform/utils"
+)
+
+func TestDebugCapture_RecordsChatExchange(t *testing.T) {
+	capture := NewDebugCapture(5)
+	client := NewAIClientWithProvider(NewScriptedMockProvider("Tell me about yourself?"), &AIConfig{DefaultModel: "mock-model", DebugCapture: capture})
+
+	if _, err := client.GenerateChatReply(context.Background(), "session1", nil, "Hello there", "en", false); err != nil {
+		t.Fatalf("GenerateChatReply failed: %v", err)
+	}
+
+	exchanges := capture.Snapshot()["mock"]
+	if len(exchanges) != 1 {
+		t.Fatalf("Expected 1 captured exchange, got %d", len(exchanges))
+	}
+	exchange := exchanges[0]
+	if exchange.Operation != DebugOperationChat || exchange.Model != "mock-model" {
+		t.Errorf("Unexpected exchange metadata: %+v", exchange)
+	}
+	if !strings.Contains(exchange.Request, "Hello there") {
+		t.Errorf("Expected request payload to contain the user message, got %q", exchange.Request)
+	}
+	if !strings.Contains(exchange.Response, "Tell me about yourself?") {
+		t.Errorf("Expected response payload to contain the reply, got %q", exchange.Response)
+	}
+}
+
+func TestDebugCapture_DisabledRecordsNothing(t *testing.T) {
+	capture := NewDebugCapture(5)
+	enabled := NewAIClientWithProvider(NewMockProvider(), &AIConfig{DefaultModel: "mock-model", DebugCapture: capture})
+	disabled := NewAIClientWithProvider(NewMockProvider(), &AIConfig{DefaultModel: "mock-model"})
+
+	if _, err := disabled.GenerateChatReply(context.Background(), "session1", nil, "Hello", "en", false); err != nil {
+		t.Fatalf("GenerateChatReply failed: %v", err)
+	}
+	if _, _, err := disabled.EvaluateAnswers(context.Background(), []string{"Q1"}, []string{"A1"}, "en"); err != nil {
+		t.Fatalf("EvaluateAnswers failed: %v", err)
+	}
+	if got := len(capture.Snapshot()["mock"]); got != 0 {
+		t.Fatalf("Expected no exchanges from a client without capture, got %d", got)
+	}
+
+	if _, _, err := enabled.EvaluateAnswers(context.Background(), []string{"Q1"}, []string{"A1"}, "en"); err != nil {
+		t.Fatalf("EvaluateAnswers failed: %v", err)
+	}
+	exchanges := capture.Snapshot()["mock"]
+	if len(exchanges) != 1 || exchanges[0].Operation != DebugOperationEvaluation {
+		t.Errorf("Expected only the enabled client's evaluation to be captured, got %+v", exchanges)
+	}
+}
+
+func TestDebugCapture_RedactsAPIKey(t *testing.T) {
+	capture := NewDebugCapture(5)
+	client := NewAIClientWithProvider(NewMockProvider(), &AIConfig{DefaultModel: "mock-model", DebugCapture: capture})
+	apiKey := "sk-abcdefghijklmnopqrstuvwxyz123456"
+
+	if _, err := client.GenerateChatReply(context.Background(), "session1", nil, "My key is "+apiKey, "en", false); err != nil {
+		t.Fatalf("GenerateChatReply failed: %v", err)
+	}
+
+	exchange := capture.Snapshot()["mock"][0]
+	if strings.Contains(exchange.Request, apiKey) {
+		t.Errorf("Expected API key to be redacted, got %q", exchange.Request)
+	}
+	if !strings.Contains(exchange.Request, utils.RedactedPlaceholder) {
+		t.Errorf("Expected redaction placeholder in request payload, got %q", exchange.Request)
+	}
+}
+
+func TestDebugCapture_TruncatesPayload(t *testing.T) {
+	capture := NewDebugCapture(5)
+	client := NewAIClientWithProvider(NewMockProvider(), &AIConfig{DefaultModel: "mock-model", DebugCapture: capture})
+
+	if _, err := client.GenerateChatReply(context.Background(), "session1", nil, strings.Repeat("a", 2*debugPayloadLimit), "en", false); err != nil {
+		t.Fatalf("GenerateChatReply failed: %v", err)
+	}
+
+	request := capture.Snapshot()["mock"][0].Request
+	if !strings.HasSuffix(request, "... [truncated]") || len([]rune(request)) > debugPayloadLimit+len("... [truncated]") {
+		t.Errorf("Expected request payload truncated to %d characters, got %d", debugPayloadLimit, len([]rune(request)))
+	}
+}
+
+func TestDebugCapture_RingBufferRollover(t *testing.T) {
+	capture := NewDebugCapture(3)
+	for i := 0; i < 5; i++ {
+		capture.Record(DebugExchange{Provider: "mock", Request: fmt.Sprintf("request %d", i)})
+	}
+	capture.Record(DebugExchange{Provider: "openai", Request: "other provider"})
+
+	snapshot := capture.Snapshot()
+	mock := snapshot["mock"]
+	if len(mock) != 3 {
+		t.Fatalf("Expected 3 exchanges kept, got %d", len(mock))
+	}
+	for i, want := range []string{"request 2", "request 3", "request 4"} {
+		if mock[i].Request != want {
+			t.Errorf("Exchange %d: expected %q, got %q", i, want, mock[i].Request)
+		}
+	}
+	if len(snapshot["openai"]) != 1 {
+		t.Errorf("Expected providers to have separate buffers, got %d openai exchanges", len(snapshot["openai"]))
+	}
+}
@@ -0,0 +1,148 @@
+// Adaptive interview difficulty: per-answer quality signals and the prompt note they steer
+package ai
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/zidane0000/ai-interview-platform/utils"
+)
+
+// Answer quality signals
+const (
+	AnswerQualityStrong   = "strong"
+	AnswerQualityAdequate = "adequate"
+	AnswerQualityWeak     = "weak"
+)
+
+// Difficulty levels run from very easy (1) to very hard (5); interviews start at medium
+const (
+	MinDifficultyLevel     = 1
+	MaxDifficultyLevel     = 5
+	DefaultDifficultyLevel = 3
+)
+
+// difficultyNames names each level for prompts, indexed by level
+var difficultyNames = []string{"", "very easy", "easy", "medium", "hard", "very hard"}
+
+// Heuristic thresholds, in words (CJK characters count as half a word)
+const (
+	weakAnswerWords   = 8
+	strongAnswerWords = 60
+)
+
+// AnswerAssessment is the quality signal for one candidate answer
+type AnswerAssessment struct {
+	Quality          string  // "strong", "adequate" or "weak"
+	Heuristic        bool    // The provider's judgment was unavailable and answer length decided
+	Provider         string  // Provider that judged the answer, empty for the heuristic
+	Model            string  // Model that judged the answer, empty for the heuristic
+	EstimatedCostUSD float64 // AI cost of the judgment
+}
+
+// DifficultyName returns the prompt name of a difficulty level, clamping out-of-range levels
+func DifficultyName(level int) string {
+	return difficultyNames[ClampDifficultyLevel(level)]
+}
+
+// ClampDifficultyLevel keeps level within MinDifficultyLevel and MaxDifficultyLevel
+func ClampDifficultyLevel(level int) int {
+	if level < MinDifficultyLevel {
+		return MinDifficultyLevel
+	}
+	if level > MaxDifficultyLevel {
+		return MaxDifficultyLevel
+	}
+	return level
+}
+
+// NextDifficultyLevel moves one level up after a strong answer and one down after a weak one
+func NextDifficultyLevel(level int, quality string) int {
+	switch quality {
+	case AnswerQualityStrong:
+		level++
+	case AnswerQualityWeak:
+		level--
+	}
+	return ClampDifficultyLevel(level)
+}
+
+// AssessAnswer judges how well answer responds to question with a short scripted prompt on the
+// cheapest model. Adaptation is best effort: when the provider fails or its reply can't be read,
+// the answer's length decides instead and no error is returned.
+func (c *AIClient) AssessAnswer(ctx context.Context, question, answer, language string) *AnswerAssessment {
+	ctx, cancel := c.withCallTimeout(ctx)
+	defer cancel()
+
+	systemPrompt := "You are assisting an interviewer. Judge the candidate's answer to the interview question " +
+		"for correctness, depth and relevance. Reply with exactly one word: STRONG, ADEQUATE or WEAK."
+	req := &ChatRequest{
+		Messages: []Message{
+			{Role: "system", Content: systemPrompt},
+			{Role: "user", Content: fmt.Sprintf("Question: %s\n\nAnswer: %s", question, answer)},
+		},
+		Model:       c.summarizationModel(),
+		MaxTokens:   5,
+		Temperature: 0,
+		Context:     map[string]interface{}{"task": TaskAnswerAssessment, "language": language},
+	}
+
+	resp, err := c.generate(ctx, req)
+	if err != nil {
+		utils.Warningf("Answer assessment failed, using the length heuristic: %v", err)
+		return &AnswerAssessment{Quality: heuristicAnswerQuality(answer), Heuristic: true}
+	}
+	assessment := &AnswerAssessment{Provider: resp.Provider, Model: resp.Model, EstimatedCostUSD: resp.EstimatedCostUSD}
+	if quality, ok := parseAnswerQuality(resp.Content); ok {
+		assessment.Quality = quality
+	} else {
+		assessment.Quality = heuristicAnswerQuality(answer)
+		assessment.Heuristic = true
+	}
+	return assessment
+}
+
+// parseAnswerQuality reads the quality word from a judgment reply
+func parseAnswerQuality(content string) (string, bool) {
+	switch strings.Trim(strings.ToUpper(strings.TrimSpace(content)), ".!\"'") {
+	case "STRONG":
+		return AnswerQualityStrong, true
+	case "ADEQUATE":
+		return AnswerQualityAdequate, true
+	case "WEAK":
+		return AnswerQualityWeak, true
+	}
+	return "", false
+}
+
+// heuristicAnswerQuality rates an answer by length alone: very short answers are weak, long ones strong
+func heuristicAnswerQuality(answer string) string {
+	cjk := utils.CountCJKCharacters(answer)
+	words := len(strings.Fields(answer)) + cjk/2
+	switch {
+	case words < weakAnswerWords:
+		return AnswerQualityWeak
+	case words >= strongAnswerWords:
+		return AnswerQualityStrong
+	}
+	return AnswerQualityAdequate
+}
+
+// DifficultyNote is a chat history entry telling the interviewer how to pitch the next question
+// after the difficulty moved from previous to level
+func DifficultyNote(previous, level int) Turn {
+	var direction string
+	switch {
+	case level > previous:
+		direction = "The candidate handled the previous question well. Ask a harder question than the previous one"
+	case level < previous:
+		direction = "The candidate struggled with the previous question. Ask an easier question than the previous one"
+	default:
+		direction = "Keep the next question at about the same difficulty as the previous one"
+	}
+	return Turn{
+		Role:    TurnRoleNote,
+		Content: fmt.Sprintf("%s (target difficulty: %s, on a scale from very easy to very hard).", direction, DifficultyName(level)),
+	}
+}
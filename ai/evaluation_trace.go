@@ -0,0 +1,49 @@
+// What an evaluation was generated from, recorded so disputed scores can be reproduced
+package ai
+
+// Keys of EvaluationResponse.Metadata recording what the provider was sent and answered
+// Set on every evaluation the provider generated; the texts are as sent, after PII redaction.
+const (
+	MetadataEvaluationPrompt  = "evaluation_prompt"  // Rendered evaluation prompt
+	MetadataEvaluationAnswers = "evaluation_answers" // Formatted question and answer block
+	MetadataTemperature       = "temperature"        // Sampling temperature of the call
+	MetadataRawOutput         = "raw_output"         // Model output before parsing; not set by the mock provider
+)
+
+// PromptTrace is what an evaluation was generated from and the output it was parsed from
+type PromptTrace struct {
+	Prompt      string
+	Answers     string
+	Temperature float64
+	RawOutput   string
+}
+
+// PromptTrace returns the trace recorded in the response's metadata; fields not recorded are empty
+func (r *EvaluationResponse) PromptTrace() PromptTrace {
+	prompt, _ := r.Metadata[MetadataEvaluationPrompt].(string)
+	answers, _ := r.Metadata[MetadataEvaluationAnswers].(string)
+	temperature, _ := r.Metadata[MetadataTemperature].(float64)
+	rawOutput, _ := r.Metadata[MetadataRawOutput].(string)
+	return PromptTrace{Prompt: prompt, Answers: answers, Temperature: temperature, RawOutput: rawOutput}
+}
+
+// withPromptTrace records in metadata the prompt, answer block and temperature req is sent with
+// req is the request as the provider received it, so redacted text stays redacted.
+func withPromptTrace(metadata map[string]interface{}, req *EvaluationRequest) map[string]interface{} {
+	if metadata == nil {
+		metadata = make(map[string]interface{})
+	}
+	metadata[MetadataEvaluationPrompt] = BuildEvaluationPrompt(req)
+	metadata[MetadataEvaluationAnswers] = FormatAnswersForEvaluation(req.Questions, req.Answers)
+	metadata[MetadataTemperature] = req.temperature()
+	return metadata
+}
+
+// withRawOutput records in metadata the model output an evaluation was parsed from
+func withRawOutput(metadata map[string]interface{}, content string) map[string]interface{} {
+	if metadata == nil {
+		metadata = make(map[string]interface{})
+	}
+	metadata[MetadataRawOutput] = content
+	return metadata
+}
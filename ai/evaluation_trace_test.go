@@ -0,0 +1,36 @@
+package ai
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestEvaluateAnswersDetailed_PromptTrace(t *testing.T) {
+	provider := NewMockProvider()
+	client := NewAIClientWithProvider(provider, &AIConfig{DefaultModel: "mock-model", RedactPII: true})
+
+	resp, err := client.EvaluateAnswersDetailed(context.Background(), []string{"How can we reach you?"}, []string{"jane@example.com"}, EvaluationContext{
+		JobDescription: "Backend engineer",
+		Language:       "en",
+	})
+	if err != nil {
+		t.Fatalf("EvaluateAnswersDetailed failed: %v", err)
+	}
+
+	// The trace is what the provider was sent, so the email stays redacted
+	trace := resp.PromptTrace()
+	req := provider.EvaluationRequests()[0]
+	if trace.Prompt != BuildEvaluationPrompt(req) || !strings.Contains(trace.Prompt, "Backend engineer") {
+		t.Errorf("Expected the rendered prompt, got %q", trace.Prompt)
+	}
+	if trace.Answers != "Interview Questions and Candidate Answers:\n\nQ1: How can we reach you?\nA1: [EMAIL_1]\n\n" {
+		t.Errorf("Expected the redacted answer block, got %q", trace.Answers)
+	}
+	if trace.Temperature != evaluationTemperature {
+		t.Errorf("Expected temperature %v, got %v", evaluationTemperature, trace.Temperature)
+	}
+	if trace.RawOutput != "" {
+		t.Errorf("Expected no raw output from the mock provider, got %q", trace.RawOutput)
+	}
+}
@@ -0,0 +1,77 @@
+// Evaluation translation: the prose of an evaluation rendered in languages besides the interview's
+package ai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// EvaluationTranslation is the prose of an evaluation in another language
+// Scores, decision and recommendations are not part of it; they stay as the evaluator gave them.
+type EvaluationTranslation struct {
+	Feedback         string
+	Strengths        []string
+	Weaknesses       []string
+	TokensUsed       TokenUsage
+	EstimatedCostUSD float64
+}
+
+// TranslateEvaluation translates the feedback, strengths and areas for improvement of evaluation into
+// language. The model is told to translate, not re-evaluate, so the prose keeps matching the scores.
+func (c *AIClient) TranslateEvaluation(ctx context.Context, evaluation *EvaluationResponse, language string) (*EvaluationTranslation, error) {
+	ctx, cancel := c.withCallTimeout(ctx)
+	defer cancel()
+
+	name := language
+	if info, ok := lookupLanguage(language); ok {
+		name = info.Name
+	}
+	systemPrompt := "You are translating the written feedback of an interview evaluation into " + name + ". " +
+		"Translate faithfully: do not re-evaluate the candidate, add or drop points, or soften or strengthen them. " +
+		"Keep the section markers \"Feedback:\", \"Strengths:\" and \"Areas for Improvement:\" in English " +
+		"and keep each point on its own line starting with \"- \"."
+
+	req := &ChatRequest{
+		Messages: []Message{
+			{Role: "system", Content: systemPrompt},
+			{Role: "user", Content: formatEvaluationProse(evaluation)},
+		},
+		Model:       c.summarizationModel(),
+		MaxTokens:   1500,
+		Temperature: 0.2,
+		Context:     map[string]interface{}{"task": TaskTranslation, "language": language},
+	}
+
+	resp, err := c.generate(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("AI evaluation translation to %s failed: %w", language, err)
+	}
+	parsed := ParseEvaluationResponse(resp.Content)
+	if parsed.Feedback == "" {
+		return nil, errors.New("AI evaluation translation to " + language + " returned no feedback")
+	}
+	return &EvaluationTranslation{
+		Feedback:         parsed.Feedback,
+		Strengths:        parsed.Strengths,
+		Weaknesses:       parsed.Weaknesses,
+		TokensUsed:       resp.TokensUsed,
+		EstimatedCostUSD: resp.EstimatedCostUSD,
+	}, nil
+}
+
+// formatEvaluationProse renders the translatable sections of evaluation with their English markers
+func formatEvaluationProse(evaluation *EvaluationResponse) string {
+	var b strings.Builder
+	b.WriteString("Feedback: " + evaluation.Feedback + "\n")
+	b.WriteString("Strengths:\n")
+	for _, strength := range evaluation.Strengths {
+		b.WriteString("- " + strength + "\n")
+	}
+	b.WriteString("Areas for Improvement:\n")
+	for _, weakness := range evaluation.Weaknesses {
+		b.WriteString("- " + weakness + "\n")
+	}
+	return b.String()
+}
@@ -0,0 +1,54 @@
+package ai
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestTranslateEvaluation(t *testing.T) {
+	provider := NewMockProvider()
+	client := NewAIClientWithProvider(provider, &AIConfig{DefaultModel: "mock-model"})
+	evaluation := &EvaluationResponse{
+		OverallScore: 0.8,
+		Feedback:     "Solid answers overall.",
+		Strengths:    []string{"Clear structure"},
+		Weaknesses:   []string{"Few metrics", "Vague on testing"},
+	}
+
+	translation, err := client.TranslateEvaluation(context.Background(), evaluation, "zh-TW")
+	if err != nil {
+		t.Fatalf("TranslateEvaluation failed: %v", err)
+	}
+	if translation.Feedback != "[MOCK zh-TW] Solid answers overall." {
+		t.Errorf("Expected the translated feedback, got %q", translation.Feedback)
+	}
+	if !reflect.DeepEqual(translation.Strengths, []string{"[MOCK zh-TW] Clear structure"}) ||
+		!reflect.DeepEqual(translation.Weaknesses, []string{"[MOCK zh-TW] Few metrics", "[MOCK zh-TW] Vague on testing"}) {
+		t.Errorf("Expected every point translated, got %v and %v", translation.Strengths, translation.Weaknesses)
+	}
+
+	req := provider.ChatRequests()[0]
+	if req.Context["task"] != TaskTranslation || !strings.Contains(req.Messages[0].Content, "Traditional Chinese") {
+		t.Errorf("Expected a translation request into Traditional Chinese, got %v: %q", req.Context, req.Messages[0].Content)
+	}
+}
+
+func TestTranslateEvaluation_Failure(t *testing.T) {
+	provider := NewMockProvider()
+	provider.PlanFaults(MockFault{Op: MockOpChat, Call: 1, Err: ErrOverloaded})
+	client := NewAIClientWithProvider(provider, &AIConfig{DefaultModel: "mock-model"})
+
+	_, err := client.TranslateEvaluation(context.Background(), &EvaluationResponse{Feedback: "Good"}, "en")
+	if !errors.Is(err, ErrOverloaded) {
+		t.Errorf("Expected the provider error, got %v", err)
+	}
+
+	// A reply without the feedback section is no translation
+	provider.AppendScript("Sorry, I cannot help with that.")
+	if _, err := client.TranslateEvaluation(context.Background(), &EvaluationResponse{Feedback: "Good"}, "en"); err == nil {
+		t.Error("Expected an error for a reply without feedback")
+	}
+}
@@ -1,6 +1,7 @@
 package ai
 
 import (
+	"strings"
 	"testing"
 	"time"
 )
@@ -148,3 +149,23 @@ func TestProviderInterface(t *testing.T) {
 		})
 	}
 }
+
+func TestCheckProviderDefaultModels(t *testing.T) {
+	if warnings := CheckProviderDefaultModels(map[string]string{ProviderGemini: "gemini-1.5-pro"}, nil); len(warnings) != 0 {
+		t.Errorf("expected no warnings for a known model, got %v", warnings)
+	}
+
+	warnings := CheckProviderDefaultModels(map[string]string{ProviderOpenAI: "gpt-9", ProviderGemini: "gemini-1.5-flash"}, nil)
+	if len(warnings) != 1 {
+		t.Fatalf("expected 1 warning, got %v", warnings)
+	}
+	if !strings.Contains(warnings[0], `"gpt-9"`) || !strings.Contains(warnings[0], ProviderOpenAI) {
+		t.Errorf("expected the warning to name the model and provider, got %q", warnings[0])
+	}
+
+	// A retired default is reported once and its replacement checked instead
+	warnings = CheckProviderDefaultModels(map[string]string{ProviderGemini: "gemini-pro"}, nil)
+	if len(warnings) != 1 || !strings.Contains(warnings[0], `"gemini-1.5-pro"`) {
+		t.Errorf("expected a single deprecation warning naming the replacement, got %v", warnings)
+	}
+}
@@ -0,0 +1,77 @@
+// Evaluation feedback post-processing: length limits and markdown cleanup
+package ai
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/zidane0000/ai-interview-platform/utils"
+)
+
+// defaultFeedbackWords is the feedback length requested at each detail level, in words
+var defaultFeedbackWords = map[string]int{
+	DetailLevelBrief:    100,
+	DetailLevelStandard: 300,
+	DetailLevelDetailed: 600,
+}
+
+// MetadataFeedbackTruncated is set in EvaluationResponse.Metadata when the feedback ran over its
+// word limit and was cut
+const MetadataFeedbackTruncated = "feedback_truncated"
+
+// feedbackWordLimit returns the feedback length limit: the configured one, or the detail level's default
+func feedbackWordLimit(detailLevel string, configured int) int {
+	if configured > 0 {
+		return configured
+	}
+	return defaultFeedbackWords[normalizeDetailLevel(detailLevel)]
+}
+
+var (
+	// markdownHeadingLine matches a heading on its own line, capturing its text
+	markdownHeadingLine = regexp.MustCompile(`^#{1,6}(?:\s+(.*?))?\s*#*$`)
+	// markdownHeadingInline matches heading markers left mid-text once lines were joined
+	markdownHeadingInline = regexp.MustCompile(`\s+#{1,6}\s+`)
+)
+
+// normalizeFeedback turns the markdown headings models sometimes emit into plain paragraphs
+func normalizeFeedback(feedback string) string {
+	var paragraphs []string
+	var current []string
+	flush := func() {
+		if len(current) > 0 {
+			paragraphs = append(paragraphs, strings.Join(current, " "))
+			current = nil
+		}
+	}
+	for _, line := range strings.Split(feedback, "\n") {
+		line = strings.TrimSpace(line)
+		switch match := markdownHeadingLine.FindStringSubmatch(line); {
+		case match != nil:
+			flush()
+			if match[1] != "" {
+				paragraphs = append(paragraphs, match[1])
+			}
+		case line == "":
+			flush()
+		default:
+			current = append(current, markdownHeadingInline.ReplaceAllString(line, "\n\n"))
+		}
+	}
+	flush()
+	return strings.Join(paragraphs, "\n\n")
+}
+
+// limitFeedback normalizes resp.Feedback and cuts it to maxWords, flagging a cut in the metadata
+func limitFeedback(resp *EvaluationResponse, maxWords int) {
+	resp.Feedback = normalizeFeedback(resp.Feedback)
+	feedback, truncated := utils.TruncateWords(resp.Feedback, maxWords)
+	if !truncated {
+		return
+	}
+	resp.Feedback = feedback
+	if resp.Metadata == nil {
+		resp.Metadata = make(map[string]interface{})
+	}
+	resp.Metadata[MetadataFeedbackTruncated] = true
+}
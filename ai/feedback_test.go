@@ -0,0 +1,90 @@
+package ai
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestNormalizeFeedback(t *testing.T) {
+	tests := []struct {
+		name     string
+		feedback string
+		expected string
+	}{
+		{"plain feedback untouched", "Clear answers with good examples.", "Clear answers with good examples."},
+		{
+			name:     "heading lines become paragraphs",
+			feedback: "## Overview\nClear answers.\nGood examples.\n\n### Technical Depth ###\nSolid on Go.",
+			expected: "Overview\n\nClear answers. Good examples.\n\nTechnical Depth\n\nSolid on Go.",
+		},
+		{
+			name:     "markers left mid-text by joined lines",
+			feedback: "Clear answers. ## Technical Depth Solid on Go.",
+			expected: "Clear answers.\n\nTechnical Depth Solid on Go.",
+		},
+		{"empty heading dropped", "#\nClear answers.", "Clear answers."},
+		{"hashtags are not headings", "Strong C# and #golang skills.", "Strong C# and #golang skills."},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := normalizeFeedback(tt.feedback); got != tt.expected {
+				t.Errorf("normalizeFeedback(%q) = %q, expected %q", tt.feedback, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestLimitFeedback(t *testing.T) {
+	// Short feedback is left alone and not flagged
+	resp := &EvaluationResponse{Feedback: "Clear answers. Good examples."}
+	limitFeedback(resp, 10)
+	if resp.Feedback != "Clear answers. Good examples." || resp.Metadata != nil {
+		t.Errorf("Expected short feedback untouched, got %q (%v)", resp.Feedback, resp.Metadata)
+	}
+
+	// Long feedback is cut at the last sentence that fits
+	resp = &EvaluationResponse{Feedback: "## Summary\nClear answers. Good examples. " + strings.Repeat("More words ", 50)}
+	limitFeedback(resp, 8)
+	if resp.Feedback != "Summary\n\nClear answers. Good examples. …" {
+		t.Errorf("Expected feedback cut at a sentence boundary, got %q", resp.Feedback)
+	}
+	if truncated, _ := resp.Metadata[MetadataFeedbackTruncated].(bool); !truncated {
+		t.Errorf("Expected the truncation to be flagged, got %v", resp.Metadata)
+	}
+}
+
+func TestFeedbackWordLimit(t *testing.T) {
+	tests := []struct {
+		detailLevel string
+		configured  int
+		expected    int
+	}{
+		{DetailLevelBrief, 0, 100},
+		{DetailLevelStandard, 0, 300},
+		{"", 0, 300},
+		{DetailLevelDetailed, 0, 600},
+		{DetailLevelDetailed, 50, 50},
+	}
+	for _, tt := range tests {
+		if got := feedbackWordLimit(tt.detailLevel, tt.configured); got != tt.expected {
+			t.Errorf("feedbackWordLimit(%q, %d) = %d, expected %d", tt.detailLevel, tt.configured, got, tt.expected)
+		}
+	}
+}
+
+func TestEvaluateAnswersDetailed_FeedbackLimit(t *testing.T) {
+	provider := NewMockProvider()
+	client := NewAIClientWithProvider(provider, nil)
+
+	resp, err := client.EvaluateAnswersDetailed(context.Background(), []string{"Q1"}, []string{"A1"}, EvaluationContext{MaxFeedbackWords: 2})
+	if err != nil {
+		t.Fatalf("EvaluateAnswersDetailed failed: %v", err)
+	}
+	if resp.Feedback != "[MOCK] Test …" || resp.Metadata[MetadataFeedbackTruncated] != true {
+		t.Errorf("Expected feedback cut to 2 words and flagged, got %q (%v)", resp.Feedback, resp.Metadata)
+	}
+	if prompt := BuildEvaluationPrompt(provider.EvaluationRequests()[0]); !strings.Contains(prompt, "Feedback Length: at most 2 words") {
+		t.Error("Expected the prompt to state the configured limit")
+	}
+}
@@ -41,6 +41,7 @@ type geminiGenConfig struct {
 	TopK            int      `json:"topK,omitempty"`
 	MaxOutputTokens int      `json:"maxOutputTokens,omitempty"`
 	StopSequences   []string `json:"stopSequences,omitempty"`
+	CandidateCount  int      `json:"candidateCount,omitempty"`
 }
 
 type geminiSafety struct {
@@ -162,8 +163,11 @@ func (p *GeminiProvider) GenerateResponse(ctx context.Context, req *ChatRequest)
 		},
 		SafetySettings: p.getDefaultSafetySettings(),
 	}
+	if req.N > 1 {
+		geminiReq.GenerationConfig.CandidateCount = req.N
+	}
 
-	model := p.GetModelName(req.Model, defaultGeminiModel)
+	model := p.GetModelName(req.Model, ProviderGemini, defaultGeminiModel)
 	endpoint := fmt.Sprintf("/models/%s:generateContent", model)
 
 	respData, err := p.MakeRequest(ctx, p, endpoint, geminiReq)
@@ -184,13 +188,11 @@ func (p *GeminiProvider) GenerateResponse(ctx context.Context, req *ChatRequest)
 		return nil, fmt.Errorf("no candidates returned from Gemini")
 	}
 
-	candidate := geminiResp.Candidates[0]
-	if len(candidate.Content.Parts) == 0 {
+	candidate, alternatives, ok := selectGeminiCandidate(geminiResp.Candidates)
+	if !ok {
 		return nil, fmt.Errorf("no content parts in Gemini response")
 	}
 
-	content := candidate.Content.Parts[0].Text
-
 	var tokensUsed TokenUsage
 	if geminiResp.UsageMetadata != nil {
 		tokensUsed = TokenUsage{
@@ -201,7 +203,8 @@ func (p *GeminiProvider) GenerateResponse(ctx context.Context, req *ChatRequest)
 	}
 
 	return &ChatResponse{
-		Content:      content,
+		Content:      candidate.text(),
+		Alternatives: alternatives,
 		FinishReason: candidate.FinishReason,
 		TokensUsed:   tokensUsed,
 		Model:        model,
@@ -215,6 +218,52 @@ func (p *GeminiProvider) GenerateResponse(ctx context.Context, req *ChatRequest)
 	}, nil
 }
 
+// text returns the candidate's content, its text parts joined
+func (c geminiCandidate) text() string {
+	var text strings.Builder
+	for _, part := range c.Content.Parts {
+		text.WriteString(part.Text)
+	}
+	return text.String()
+}
+
+// geminiFinishedNormally reports whether a candidate ended on its own or at the token limit,
+// rather than being stopped by a safety or recitation filter
+func geminiFinishedNormally(finishReason string) bool {
+	switch finishReason {
+	case "", "STOP", "MAX_TOKENS", "FINISH_REASON_UNSPECIFIED":
+		return true
+	}
+	return false
+}
+
+// selectGeminiCandidate returns the first candidate with text that finished normally, and the text
+// of the other such candidates. A filtered candidate with text is used only when no other has any;
+// ok is false when no candidate has text at all.
+func selectGeminiCandidate(candidates []geminiCandidate) (selected geminiCandidate, alternatives []string, ok bool) {
+	var fallback *geminiCandidate
+	for i, candidate := range candidates {
+		if strings.TrimSpace(candidate.text()) == "" {
+			continue
+		}
+		if !geminiFinishedNormally(candidate.FinishReason) {
+			if fallback == nil {
+				fallback = &candidates[i]
+			}
+			continue
+		}
+		if !ok {
+			selected, ok = candidate, true
+			continue
+		}
+		alternatives = append(alternatives, candidate.text())
+	}
+	if !ok && fallback != nil {
+		return *fallback, nil, true
+	}
+	return selected, alternatives, ok
+}
+
 // GenerateStreamResponse generates a streaming response (placeholder for now)
 func (p *GeminiProvider) GenerateStreamResponse(ctx context.Context, req *ChatRequest) (<-chan *ChatResponse, error) {
 	return nil, fmt.Errorf("streaming not yet implemented for Gemini provider")
@@ -229,12 +278,13 @@ func (p *GeminiProvider) GenerateInterviewQuestions(ctx context.Context, req *Qu
 		Messages: []Message{
 			{
 				Role:    "user",
-				Content: systemPrompt + fmt.Sprintf("\n\nGenerate %d interview questions based on this job description: %s", req.NumQuestions, req.JobDescription),
+				Content: systemPrompt + fmt.Sprintf("\n\nGenerate %d interview questions based on the job description above.", req.NumQuestions),
 			},
 		},
-		Model:       p.GetModelName("", defaultGeminiModel),
+		Model:       p.GetModelName("", ProviderGemini, defaultGeminiModel),
 		MaxTokens:   2000,
 		Temperature: 0.7,
+		N:           req.Alternatives,
 	}
 
 	response, err := p.GenerateResponse(ctx, chatReq)
@@ -242,7 +292,7 @@ func (p *GeminiProvider) GenerateInterviewQuestions(ctx context.Context, req *Qu
 		return nil, fmt.Errorf("failed to generate questions: %w", err)
 	}
 
-	questions := ParseQuestionResponse(response.Content)
+	questions := ParseQuestionAlternatives(response, req.NumQuestions)
 
 	return &QuestionGenerationResponse{
 		Questions:  questions,
@@ -267,9 +317,9 @@ func (p *GeminiProvider) EvaluateAnswers(ctx context.Context, req *EvaluationReq
 				Content: systemPrompt + "\n\n" + userContent,
 			},
 		},
-		Model:       p.GetModelName("", defaultGeminiModel),
-		MaxTokens:   3000,
-		Temperature: 0.3,
+		Model:       p.GetModelName(req.Params.Model, ProviderGemini, defaultGeminiModel),
+		MaxTokens:   req.maxTokens(),
+		Temperature: req.temperature(),
 	}
 
 	response, err := p.GenerateResponse(ctx, chatReq)
@@ -278,6 +328,7 @@ func (p *GeminiProvider) EvaluateAnswers(ctx context.Context, req *EvaluationReq
 	}
 
 	evaluation := ParseEvaluationResponse(response.Content)
+	evaluation.Metadata = withRawOutput(evaluation.Metadata, response.Content)
 	evaluation.TokensUsed = response.TokensUsed
 	evaluation.Provider = ProviderGemini
 	evaluation.Model = response.Model
@@ -296,8 +347,6 @@ func (p *GeminiProvider) GetSupportedModels() []string {
 	return []string{
 		"gemini-1.5-pro",
 		"gemini-1.5-flash",
-		"gemini-pro",
-		"gemini-pro-vision",
 	}
 }
 
@@ -314,7 +363,7 @@ func (p *GeminiProvider) ValidateCredentials(ctx context.Context) error {
 		},
 	}
 
-	model := p.GetModelName("", defaultGeminiModel)
+	model := p.GetModelName("", ProviderGemini, defaultGeminiModel)
 	endpoint := fmt.Sprintf("/models/%s:generateContent", model)
 	_, err := p.MakeRequest(ctx, p, endpoint, testReq)
 	return err
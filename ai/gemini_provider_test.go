@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"reflect"
 	"strings"
 	"testing"
 	"time"
@@ -294,6 +295,49 @@ func TestGeminiProvider_GenerateResponse(t *testing.T) {
 			expectError:   true,
 			errorContains: "no content parts",
 		},
+		{
+			name: "safety-filtered first candidate",
+			serverResponse: `{
+				"candidates": [
+					{"content": {"parts": [], "role": "model"}, "finishReason": "SAFETY", "index": 0},
+					{"content": {"parts": [{"text": "Usable answer"}], "role": "model"}, "finishReason": "STOP", "index": 1}
+				]
+			}`,
+			serverStatus: http.StatusOK,
+			checkResponse: func(t *testing.T, resp *ChatResponse) {
+				if resp.Content != "Usable answer" || resp.Metadata["index"] != 1 || len(resp.Alternatives) != 0 {
+					t.Errorf("Expected the second candidate without alternatives, got %q (index %v), %v", resp.Content, resp.Metadata["index"], resp.Alternatives)
+				}
+			},
+		},
+		{
+			name: "multiple candidates",
+			serverResponse: `{
+				"candidates": [
+					{"content": {"parts": [{"text": "First"}], "role": "model"}, "finishReason": "STOP", "index": 0},
+					{"content": {"parts": [{"text": "Blocked"}], "role": "model"}, "finishReason": "RECITATION", "index": 1},
+					{"content": {"parts": [{"text": "Third, "}, {"text": "in two parts"}], "role": "model"}, "finishReason": "MAX_TOKENS", "index": 2}
+				]
+			}`,
+			serverStatus: http.StatusOK,
+			checkResponse: func(t *testing.T, resp *ChatResponse) {
+				if resp.Content != "First" || len(resp.Alternatives) != 1 || resp.Alternatives[0] != "Third, in two parts" {
+					t.Errorf("Expected the first candidate with the third as alternative, got %q, %v", resp.Content, resp.Alternatives)
+				}
+			},
+		},
+		{
+			name: "only filtered candidates with text",
+			serverResponse: `{
+				"candidates": [{"content": {"parts": [{"text": "Partial"}], "role": "model"}, "finishReason": "SAFETY"}]
+			}`,
+			serverStatus: http.StatusOK,
+			checkResponse: func(t *testing.T, resp *ChatResponse) {
+				if resp.Content != "Partial" || resp.FinishReason != "SAFETY" {
+					t.Errorf("Expected the filtered candidate as a last resort, got %q (%s)", resp.Content, resp.FinishReason)
+				}
+			},
+		},
 		{
 			name:           "invalid JSON response",
 			serverResponse: `{invalid json`,
@@ -544,6 +588,9 @@ func TestGeminiProvider_EvaluateAnswers(t *testing.T) {
 	if resp.Provider != ProviderGemini {
 		t.Errorf("Expected provider '%s', got '%s'", ProviderGemini, resp.Provider)
 	}
+	if raw := resp.PromptTrace().RawOutput; !strings.HasPrefix(raw, "Overall Score: 0.75") {
+		t.Errorf("Expected the raw model output recorded, got %q", raw)
+	}
 }
 
 // TestGeminiProvider_ValidateCredentials tests credential validation
@@ -670,26 +717,40 @@ func TestGeminiProvider_ModelFallback(t *testing.T) {
 	}))
 	defer server.Close()
 
-	config := &AIConfig{
-		GeminiBaseURL:  server.URL,
-		RequestTimeout: 10 * time.Second,
-		DefaultModel:   "custom-model",
+	tests := []struct {
+		name           string
+		providerModels map[string]string
+		expected       string
+	}{
+		// Without its own entry Gemini uses "gemini-1.5-flash", never the global default
+		{"built-in default", nil, "/models/gemini-1.5-flash:"},
+		{"openai default ignored", map[string]string{ProviderOpenAI: "gpt-4"}, "/models/gemini-1.5-flash:"},
+		{"gemini default wins", map[string]string{ProviderOpenAI: "gpt-4", ProviderGemini: "gemini-1.5-pro"}, "/models/gemini-1.5-pro:"},
 	}
-	provider := NewGeminiProvider("test-key", config)
 
-	// Request without model should use "gemini-1.5-flash" as the Gemini default
-	req := &ChatRequest{
-		Messages: []Message{{Role: "user", Content: "test"}},
-	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := &AIConfig{
+				GeminiBaseURL:         server.URL,
+				RequestTimeout:        10 * time.Second,
+				DefaultModel:          "custom-model",
+				ProviderDefaultModels: tt.providerModels,
+			}
+			provider := NewGeminiProvider("test-key", config)
 
-	_, err := provider.GenerateResponse(context.Background(), req)
-	if err != nil {
-		t.Fatalf("Unexpected error: %v", err)
-	}
+			req := &ChatRequest{
+				Messages: []Message{{Role: "user", Content: "test"}},
+			}
 
-	// Gemini provider uses "gemini-1.5-flash" as its default
-	if !strings.Contains(receivedEndpoint, "gemini-1.5-flash") {
-		t.Errorf("Expected endpoint to contain 'gemini-1.5-flash', got '%s'", receivedEndpoint)
+			_, err := provider.GenerateResponse(context.Background(), req)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if !strings.Contains(receivedEndpoint, tt.expected) {
+				t.Errorf("Expected endpoint to contain '%s', got '%s'", tt.expected, receivedEndpoint)
+			}
+		})
 	}
 }
 
@@ -798,3 +859,42 @@ func TestGeminiProvider_APIKeyInURL(t *testing.T) {
 		t.Errorf("Expected API key in query string, got '%s'", receivedQuery)
 	}
 }
+
+func TestGeminiProvider_GenerateInterviewQuestions_Alternatives(t *testing.T) {
+	var candidateCount float64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&body)
+		candidateCount, _ = body["generationConfig"]["candidateCount"].(float64)
+		w.Write([]byte(`{
+			"candidates": [
+				{"content": {"parts": [], "role": "model"}, "finishReason": "SAFETY"},
+				{"content": {"parts": [{"text": "Question: What is Go?\nExpected Time: 5\nQuestion: What is a goroutine?\nExpected Time: 5"}]}, "finishReason": "STOP"},
+				{"content": {"parts": [{"text": "Question: what is go?\nExpected Time: 5\nQuestion: What is a channel?\nExpected Time: 5\nQuestion: What is a mutex?\nExpected Time: 5"}]}, "finishReason": "STOP"}
+			]
+		}`))
+	}))
+	defer server.Close()
+	provider := NewGeminiProvider("test-key", &AIConfig{GeminiBaseURL: server.URL, RequestTimeout: 10 * time.Second})
+
+	resp, err := provider.GenerateInterviewQuestions(context.Background(), &QuestionGenerationRequest{
+		JobDescription: "Backend Engineer",
+		NumQuestions:   3,
+		Alternatives:   3,
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if candidateCount != 3 {
+		t.Errorf("Expected candidateCount 3 in the request, got %v", candidateCount)
+	}
+
+	// The repeat is dropped and the merged list capped at the questions asked for
+	var texts []string
+	for _, question := range resp.Questions {
+		texts = append(texts, question.Question)
+	}
+	if expected := []string{"What is Go?", "What is a goroutine?", "What is a channel?"}; !reflect.DeepEqual(texts, expected) {
+		t.Errorf("Expected %v, got %v", expected, texts)
+	}
+}
@@ -0,0 +1,65 @@
+// Canned greetings: opening messages rendered from templates instead of generated by the AI
+package ai
+
+import "strings"
+
+// Variables of a greeting template, written {{name}} in its text
+const (
+	greetingVarCandidateName = "candidate_name"
+	greetingVarInterviewType = "interview_type"
+)
+
+// greetingTemplates are the canned opening messages, keyed by lowercase language code
+// Each ends with an opening question, as AI greetings do, so the first answer responds to it.
+// Metadata "anonymous" stands in for the candidate's name when the interview has none, and
+// "type:<interview type>" is the interview type as the template's language writes it.
+var greetingTemplates = map[string]PromptTemplate{
+	"en": {
+		Name:      "greeting_en",
+		Template:  "Hi {{candidate_name}}, welcome to your {{interview_type}} interview. To start, could you briefly introduce yourself and your background?",
+		Variables: []string{greetingVarCandidateName, greetingVarInterviewType},
+		Category:  "greeting",
+		Metadata: map[string]string{
+			"anonymous":       "there",
+			"type:general":    "general",
+			"type:technical":  "technical",
+			"type:behavioral": "behavioral",
+		},
+	},
+	"zh-tw": {
+		Name:      "greeting_zh-TW",
+		Template:  "{{candidate_name}}您好，歡迎參加這場{{interview_type}}面試。首先，請簡單介紹一下您自己和您的背景。",
+		Variables: []string{greetingVarCandidateName, greetingVarInterviewType},
+		Category:  "greeting",
+		Metadata: map[string]string{
+			"anonymous":       "",
+			"type:general":    "一般",
+			"type:technical":  "技術",
+			"type:behavioral": "行為",
+		},
+	},
+}
+
+// RenderGreeting renders the canned opening message for a session in language
+// Languages without a template use English; interview types the template doesn't name are written as given.
+func RenderGreeting(language, candidateName, interviewType string) string {
+	tmpl, ok := greetingTemplates[strings.ToLower(language)]
+	if !ok {
+		tmpl = greetingTemplates["en"]
+	}
+	if candidateName == "" {
+		candidateName = tmpl.Metadata["anonymous"]
+	}
+	if label, ok := tmpl.Metadata["type:"+interviewType]; ok {
+		interviewType = label
+	}
+	values := map[string]string{
+		greetingVarCandidateName: candidateName,
+		greetingVarInterviewType: interviewType,
+	}
+	greeting := tmpl.Template
+	for _, variable := range tmpl.Variables {
+		greeting = strings.ReplaceAll(greeting, "{{"+variable+"}}", values[variable])
+	}
+	return greeting
+}
@@ -0,0 +1,54 @@
+package ai
+
+import "testing"
+
+func TestRenderGreeting(t *testing.T) {
+	tests := []struct {
+		name          string
+		language      string
+		candidateName string
+		interviewType string
+		expected      string
+	}{
+		{
+			name:          "english",
+			language:      "en",
+			candidateName: "Jane Doe",
+			interviewType: "behavioral",
+			expected:      "Hi Jane Doe, welcome to your behavioral interview. To start, could you briefly introduce yourself and your background?",
+		},
+		{
+			name:          "english without a name",
+			language:      "en",
+			interviewType: "general",
+			expected:      "Hi there, welcome to your general interview. To start, could you briefly introduce yourself and your background?",
+		},
+		{
+			name:          "traditional chinese",
+			language:      "zh-TW",
+			candidateName: "王小明",
+			interviewType: "technical",
+			expected:      "王小明您好，歡迎參加這場技術面試。首先，請簡單介紹一下您自己和您的背景。",
+		},
+		{
+			name:          "traditional chinese without a name",
+			language:      "zh-tw",
+			interviewType: "general",
+			expected:      "您好，歡迎參加這場一般面試。首先，請簡單介紹一下您自己和您的背景。",
+		},
+		{
+			name:          "unknown language and type",
+			language:      "fr",
+			candidateName: "Jane",
+			interviewType: "system design",
+			expected:      "Hi Jane, welcome to your system design interview. To start, could you briefly introduce yourself and your background?",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := RenderGreeting(tt.language, tt.candidateName, tt.interviewType); got != tt.expected {
+				t.Errorf("RenderGreeting() = %q, expected %q", got, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,108 @@
+// Typed requests for interviewer turns
+package ai
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/zidane0000/ai-interview-platform/utils"
+)
+
+// Roles of a Turn, as chat messages store them
+const (
+	TurnRoleCandidate   = "user"
+	TurnRoleInterviewer = "ai"
+	TurnRoleNote        = "system" // Guidance for the interviewer, e.g. a summary or difficulty note
+)
+
+// Turn is one entry of the conversation history an interviewer turn is generated from
+type Turn struct {
+	Role    string // See TurnRole* constants
+	Content string
+}
+
+// InterviewTurnRequest is everything an interviewer turn is generated from
+type InterviewTurnRequest struct {
+	SessionID     string
+	History       []Turn           // Earlier turns, oldest first, without UserMessage
+	UserMessage   string           // The candidate's latest message; empty for a greeting or sign-off
+	InterviewType string           // Selects the AIConfig.InterviewTypeParams entry and labels the usage metrics
+	Language      string           // Language of the reply ("en", "zh-TW")
+	Closing       bool             // The reply wraps up the interview
+	Params        GenerationParams // Explicit overrides, winning over the interview type's parameters
+}
+
+// GenerateInterviewTurn generates the next interviewer turn and returns the full provider response,
+// including token usage and response time. The temperature, length and model come from chatParams.
+// For CJK languages, a reply without enough CJK characters is retried once with a stronger
+// language instruction; if it still fails, the reply is returned flagged with MetadataLanguageMismatch.
+// The retry shares the call's timeout budget rather than getting a fresh one, and is skipped when
+// what is left of it is shorter than the first attempt took.
+func (c *AIClient) GenerateInterviewTurn(ctx context.Context, turn InterviewTurnRequest) (*ChatResponse, error) {
+	ctx = withDefaultInterviewType(ctx, turn.InterviewType)
+	ctx, cancel := c.withCallTimeout(ctx)
+	defer cancel()
+	language := turn.Language
+
+	// Build messages for the AI provider
+	messages := buildChatMessages(turn.History, turn.UserMessage, language, turn.Closing)
+
+	params := c.chatParams(turn)
+	req := &ChatRequest{
+		Messages:    messages,
+		Model:       params.Model,
+		MaxTokens:   params.MaxTokens,
+		Temperature: *params.Temperature,
+		SessionID:   turn.SessionID,
+		Context:     chatTurnContext(),
+	}
+
+	resp, err := c.generate(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("AI generation failed: %w", err)
+	}
+	if !isCJKLanguage(language) || utils.CJKRatio(resp.Content) >= minCJKRatio {
+		return resp, nil
+	}
+
+	// Providers occasionally ignore the language instruction; retry once with a stronger one
+	if !hasTimeFor(ctx, resp.ResponseTime) {
+		utils.Warningf("Skipping language retry for session %s: not enough time left for another attempt", turn.SessionID)
+	} else {
+		retryReq := *req
+		retryReq.Messages = append(append([]Message(nil), messages...), Message{Role: "system", Content: languageRetryInstruction(language)})
+		retry, err := c.generate(ctx, &retryReq)
+		if err != nil {
+			utils.Warningf("Language retry failed for session %s: %v", turn.SessionID, err)
+		} else {
+			retry.ResponseTime += resp.ResponseTime
+			retry.TokensUsed = addTokenUsage(retry.TokensUsed, resp.TokensUsed)
+			retry.EstimatedCostUSD += resp.EstimatedCostUSD
+			resp = retry
+			if utils.CJKRatio(resp.Content) >= minCJKRatio {
+				return resp, nil
+			}
+		}
+	}
+
+	// Give up and let callers surface the mismatch
+	if resp.Metadata == nil {
+		resp.Metadata = make(map[string]interface{})
+	}
+	resp.Metadata[MetadataLanguageMismatch] = true
+	return resp, nil
+}
+
+// TurnsFromMaps converts history in the deprecated map form, with "role" and "content" keys,
+// to turns. Entries missing either key are left out, as the map-based methods always did.
+func TurnsFromMaps(history []map[string]string) []Turn {
+	turns := make([]Turn, 0, len(history))
+	for _, entry := range history {
+		role, hasRole := entry["role"]
+		content, hasContent := entry["content"]
+		if hasRole && hasContent {
+			turns = append(turns, Turn{Role: role, Content: content})
+		}
+	}
+	return turns
+}
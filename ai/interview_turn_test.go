@@ -0,0 +1,121 @@
+package ai
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	dto "github.com/prometheus/client_model/go"
+)
+
+func TestTurnsFromMaps(t *testing.T) {
+	turns := TurnsFromMaps([]map[string]string{
+		{"role": "ai", "content": "Hi there!"},
+		{"role": "user"},
+		{"content": "orphaned"},
+		{"role": "user", "content": "Hello"},
+	})
+	expected := []Turn{{Role: TurnRoleInterviewer, Content: "Hi there!"}, {Role: TurnRoleCandidate, Content: "Hello"}}
+	if !reflect.DeepEqual(turns, expected) {
+		t.Errorf("expected incomplete entries left out, got %+v", turns)
+	}
+}
+
+// The deprecated map-based methods send the same provider request as GenerateInterviewTurn
+func TestGenerateChatReplyWithOptions_MatchesInterviewTurn(t *testing.T) {
+	provider := NewMockProvider()
+	client := NewAIClientWithProvider(provider, &AIConfig{DefaultProvider: ProviderMock})
+	history := []map[string]string{
+		{"role": "ai", "content": "Tell me about yourself."},
+		{"role": "user", "content": "I build backends."},
+	}
+
+	if _, err := client.GenerateChatReplyWithOptions(context.Background(), "session1", history, "Next?",
+		ChatReplyOptions{Language: "en", Closing: true, InterviewType: "technical"}); err != nil {
+		t.Fatalf("GenerateChatReplyWithOptions failed: %v", err)
+	}
+	if _, err := client.GenerateInterviewTurn(context.Background(), InterviewTurnRequest{
+		SessionID:     "session1",
+		History:       TurnsFromMaps(history),
+		UserMessage:   "Next?",
+		InterviewType: "technical",
+		Language:      "en",
+		Closing:       true,
+	}); err != nil {
+		t.Fatalf("GenerateInterviewTurn failed: %v", err)
+	}
+
+	requests := provider.ChatRequests()
+	if len(requests) != 2 {
+		t.Fatalf("expected 2 provider requests, got %d", len(requests))
+	}
+	if !reflect.DeepEqual(requests[0], requests[1]) {
+		t.Errorf("expected identical requests, got %+v and %+v", requests[0], requests[1])
+	}
+	if roles := []string{requests[1].Messages[1].Role, requests[1].Messages[2].Role}; roles[0] != "assistant" || roles[1] != "user" {
+		t.Errorf("expected the interviewer turn sent as assistant, got %v", roles)
+	}
+}
+
+func TestGenerateInterviewTurn_SessionScope(t *testing.T) {
+	provider := NewMockProvider()
+	client := NewAIClientWithProvider(provider, &AIConfig{DefaultProvider: ProviderMock})
+	missing := func() float64 {
+		var m dto.Metric
+		if err := aiChatTurnsWithoutSession.Write(&m); err != nil {
+			t.Fatalf("failed to read the counter: %v", err)
+		}
+		return m.GetCounter().GetValue()
+	}
+
+	before := missing()
+	if _, err := client.GenerateInterviewTurn(context.Background(), InterviewTurnRequest{SessionID: "session1", UserMessage: "Hello", Language: "en"}); err != nil {
+		t.Fatalf("GenerateInterviewTurn failed: %v", err)
+	}
+	req := provider.ChatRequests()[0]
+	if req.Context["task"] != TaskChatTurn || req.Context[ContextNoStore] != true {
+		t.Errorf("expected the turn tagged as a chat turn not to store, got %v", req.Context)
+	}
+	if got := missing(); got != before {
+		t.Errorf("expected no missing session reported, got %v more", got-before)
+	}
+
+	// A turn without its session still goes ahead, but is counted
+	if _, err := client.GenerateInterviewTurn(context.Background(), InterviewTurnRequest{UserMessage: "Hello", Language: "en"}); err != nil {
+		t.Fatalf("GenerateInterviewTurn failed: %v", err)
+	}
+	if got := missing(); got != before+1 {
+		t.Errorf("expected the missing session counted once, got %v", got-before)
+	}
+}
+
+func TestGenerateInterviewTurn_ChecksTimeLeft(t *testing.T) {
+	// Each attempt takes 60ms; after the first only 40ms of the 100ms budget are left
+	provider := NewScriptedMockProvider("English reply", "請介紹一下你自己。")
+	provider.SetDelay(60 * time.Millisecond)
+	client := NewAIClientWithProvider(provider, &AIConfig{DefaultModel: "mock-model", PerRequestTimeout: 100 * time.Millisecond})
+	attempts := 0
+	client.SetAttemptHook(func() error {
+		attempts++
+		return nil
+	})
+
+	// The language retry is not attempted, so the first reply comes back flagged
+	resp, err := client.GenerateInterviewTurn(context.Background(), InterviewTurnRequest{SessionID: "session1", UserMessage: "Hello", Language: "zh-TW"})
+	if err != nil {
+		t.Fatalf("GenerateInterviewTurn failed: %v", err)
+	}
+	if mismatch, _ := resp.Metadata[MetadataLanguageMismatch].(bool); !mismatch || attempts != 1 {
+		t.Errorf("Expected the flagged first reply after 1 attempt, got mismatch %v after %d", mismatch, attempts)
+	}
+
+	// A caller whose deadline has passed gets no attempt at all
+	ctx, cancel := context.WithTimeout(context.Background(), time.Nanosecond)
+	defer cancel()
+	<-ctx.Done()
+	if _, err := client.GenerateInterviewTurn(ctx, InterviewTurnRequest{SessionID: "session1", UserMessage: "Hello", Language: "en"}); !errors.Is(err, context.DeadlineExceeded) || attempts != 1 {
+		t.Errorf("Expected the expired call refused without an attempt, got %v after %d attempts", err, attempts)
+	}
+}
@@ -0,0 +1,119 @@
+// Generation parameters per interview type, with explicit per-call overrides
+package ai
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// Default generation parameters of chat turns and evaluations
+const (
+	defaultChatTemperature   = 0.7
+	defaultChatMaxTokens     = 500
+	closingChatMaxTokens     = 300 // Closing messages are shorter wrap-ups
+	evaluationTemperature    = 0.3 // Scoring wants consistent, low-variance output
+	evaluationMaxTokens      = 3000
+	maxGenerationTemperature = 2.0
+)
+
+// GenerationParams are the model parameters of a provider call; zero fields are left to the defaults
+// Temperature is a pointer because 0 is a meaningful (deterministic) temperature.
+type GenerationParams struct {
+	Temperature *float64 `json:"temperature,omitempty"`
+	MaxTokens   int      `json:"max_tokens,omitempty"`
+	Model       string   `json:"model,omitempty"`
+}
+
+// withDefaults returns p with its zero fields taken from defaults
+func (p GenerationParams) withDefaults(defaults GenerationParams) GenerationParams {
+	if p.Temperature == nil {
+		p.Temperature = defaults.Temperature
+	}
+	if p.MaxTokens == 0 {
+		p.MaxTokens = defaults.MaxTokens
+	}
+	if p.Model == "" {
+		p.Model = defaults.Model
+	}
+	return p
+}
+
+// Float64 returns a pointer to v, for GenerationParams.Temperature
+func Float64(v float64) *float64 {
+	return &v
+}
+
+// ChatReplyOptions shape an interviewer turn beyond its conversation
+//
+// Deprecated: set the fields of InterviewTurnRequest instead.
+type ChatReplyOptions struct {
+	Language      string           // Language of the reply ("en", "zh-TW")
+	Closing       bool             // The reply wraps up the interview
+	InterviewType string           // Selects the AIConfig.InterviewTypeParams entry
+	Params        GenerationParams // Explicit overrides, winning over the interview type's parameters
+}
+
+// chatParams resolves the parameters of a chat turn: explicit overrides, then the interview type's
+// parameters, then the defaults. Closing messages keep their shorter length unless overridden.
+func (c *AIClient) chatParams(turn InterviewTurnRequest) GenerationParams {
+	defaults := GenerationParams{Temperature: Float64(defaultChatTemperature), MaxTokens: defaultChatMaxTokens}
+	typed := c.config.InterviewTypeParams[turn.InterviewType]
+	if turn.Closing {
+		defaults.MaxTokens = closingChatMaxTokens
+		typed.MaxTokens = 0
+	}
+	return turn.Params.withDefaults(typed.withDefaults(defaults))
+}
+
+// evaluationParams resolves the parameters of an evaluation: explicit overrides, then the interview
+// type's model. The type's temperature and length are for chat turns; scoring keeps its own.
+func (c *AIClient) evaluationParams(evalCtx EvaluationContext) GenerationParams {
+	defaults := GenerationParams{
+		Temperature: Float64(evaluationTemperature),
+		MaxTokens:   evaluationMaxTokens,
+		Model:       c.config.InterviewTypeParams[evalCtx.InterviewType].Model,
+	}
+	return evalCtx.Params.withDefaults(defaults)
+}
+
+// temperature is the evaluation's temperature, the fixed evaluation temperature unless overridden
+func (r *EvaluationRequest) temperature() float64 {
+	if r.Params.Temperature != nil {
+		return *r.Params.Temperature
+	}
+	return evaluationTemperature
+}
+
+// maxTokens is the evaluation's response length, the fixed evaluation length unless overridden
+func (r *EvaluationRequest) maxTokens() int {
+	if r.Params.MaxTokens > 0 {
+		return r.Params.MaxTokens
+	}
+	return evaluationMaxTokens
+}
+
+// ValidateInterviewTypeParams checks the parameter table of AIConfig.InterviewTypeParams, reporting
+// every unknown interview type and out-of-range value at once
+func ValidateInterviewTypeParams(params map[string]GenerationParams) error {
+	types := make([]string, 0, len(params))
+	for interviewType := range params {
+		types = append(types, interviewType)
+	}
+	sort.Strings(types)
+
+	var problems []error
+	for _, interviewType := range types {
+		p := params[interviewType]
+		if _, ok := interviewTypeFocus[interviewType]; !ok {
+			problems = append(problems, fmt.Errorf("unknown interview type %q", interviewType))
+		}
+		if p.Temperature != nil && (*p.Temperature < 0 || *p.Temperature > maxGenerationTemperature) {
+			problems = append(problems, fmt.Errorf("%s: temperature must be between 0 and %g, got %g", interviewType, maxGenerationTemperature, *p.Temperature))
+		}
+		if p.MaxTokens < 0 {
+			problems = append(problems, fmt.Errorf("%s: max_tokens must not be negative, got %d", interviewType, p.MaxTokens))
+		}
+	}
+	return errors.Join(problems...)
+}
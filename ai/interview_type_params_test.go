@@ -0,0 +1,93 @@
+package ai
+
+import (
+	"context"
+	"testing"
+)
+
+func TestAIClient_InterviewTypeParams(t *testing.T) {
+	provider := NewMockProvider()
+	client := NewAIClientWithProvider(provider, &AIConfig{
+		DefaultProvider: ProviderMock,
+		InterviewTypeParams: map[string]GenerationParams{
+			"behavioral": {Temperature: Float64(0.9), MaxTokens: 800},
+			"technical":  {Temperature: Float64(0.2), Model: "mock-technical"},
+		},
+	})
+	reply := func(turn InterviewTurnRequest) *ChatRequest {
+		t.Helper()
+		turn.SessionID, turn.UserMessage = "session1", "Hello"
+		if _, err := client.GenerateInterviewTurn(context.Background(), turn); err != nil {
+			t.Fatalf("GenerateInterviewTurn failed: %v", err)
+		}
+		requests := provider.ChatRequests()
+		return requests[len(requests)-1]
+	}
+
+	tests := []struct {
+		name        string
+		turn        InterviewTurnRequest
+		temperature float64
+		maxTokens   int
+		model       string
+	}{
+		{"behavioral", InterviewTurnRequest{Language: "en", InterviewType: "behavioral"}, 0.9, 800, ""},
+		{"technical", InterviewTurnRequest{Language: "en", InterviewType: "technical"}, 0.2, defaultChatMaxTokens, "mock-technical"},
+		{"type without parameters", InterviewTurnRequest{Language: "en", InterviewType: "general"}, defaultChatTemperature, defaultChatMaxTokens, ""},
+		{"closing keeps its length", InterviewTurnRequest{Language: "en", InterviewType: "behavioral", Closing: true}, 0.9, closingChatMaxTokens, ""},
+		{"explicit overrides win", InterviewTurnRequest{Language: "en", InterviewType: "behavioral", Params: GenerationParams{Temperature: Float64(0), MaxTokens: 100}}, 0, 100, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := reply(tt.turn)
+			if req.Temperature != tt.temperature || req.MaxTokens != tt.maxTokens || req.Model != tt.model {
+				t.Errorf("expected temperature %v, %d tokens and model %q, got %v, %d and %q",
+					tt.temperature, tt.maxTokens, tt.model, req.Temperature, req.MaxTokens, req.Model)
+			}
+		})
+	}
+
+	// Evaluations take the type's model but keep their own low temperature unless overridden
+	evaluate := func(evalCtx EvaluationContext) *EvaluationRequest {
+		t.Helper()
+		if _, err := client.EvaluateAnswersDetailed(context.Background(), []string{"Q1"}, []string{"A1"}, evalCtx); err != nil {
+			t.Fatalf("EvaluateAnswersDetailed failed: %v", err)
+		}
+		requests := provider.EvaluationRequests()
+		return requests[len(requests)-1]
+	}
+	req := evaluate(EvaluationContext{InterviewType: "technical", Language: "en"})
+	if req.temperature() != evaluationTemperature || req.maxTokens() != evaluationMaxTokens || req.Params.Model != "mock-technical" {
+		t.Errorf("expected the fixed evaluation temperature on the technical model, got %v, %d and %q", req.temperature(), req.maxTokens(), req.Params.Model)
+	}
+	if req := evaluate(EvaluationContext{InterviewType: "behavioral", Language: "en"}); req.temperature() != evaluationTemperature {
+		t.Errorf("expected behavioral evaluations at %v, got %v", evaluationTemperature, req.temperature())
+	}
+	req = evaluate(EvaluationContext{InterviewType: "technical", Language: "en", Params: GenerationParams{Temperature: Float64(0.1), Model: "mock-judge"}})
+	if req.temperature() != 0.1 || req.Params.Model != "mock-judge" {
+		t.Errorf("expected the overrides to win, got %v and %q", req.temperature(), req.Params.Model)
+	}
+}
+
+func TestValidateInterviewTypeParams(t *testing.T) {
+	valid := map[string]GenerationParams{
+		"behavioral": {Temperature: Float64(1.2), MaxTokens: 800},
+		"technical":  {Temperature: Float64(0)},
+	}
+	if err := ValidateInterviewTypeParams(valid); err != nil {
+		t.Errorf("expected valid parameters, got %v", err)
+	}
+	invalid := map[string]GenerationParams{
+		"panel":     {},
+		"technical": {Temperature: Float64(-0.1), MaxTokens: -5},
+	}
+	err := ValidateInterviewTypeParams(invalid)
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	for _, want := range []string{`"panel"`, "temperature", "max_tokens"} {
+		if !contains(err.Error(), want) {
+			t.Errorf("expected %s reported, got %v", want, err)
+		}
+	}
+}
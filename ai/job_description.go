@@ -0,0 +1,63 @@
+// Job description handling: long postings are summarized once for prompts, oversized ones rejected
+package ai
+
+import (
+	"context"
+	"fmt"
+	"unicode/utf8"
+)
+
+// JobDescriptionLimits is the job description policy, in characters
+// Descriptions up to SoftLimit go into prompts as they are; longer ones up to HardLimit are
+// summarized for prompts (see SummarizeJobDescription); longer ones are rejected.
+type JobDescriptionLimits struct {
+	SoftLimit int
+	HardLimit int
+}
+
+// Exceeds reports whether jobDescription is over the hard limit and must be rejected
+func (l JobDescriptionLimits) Exceeds(jobDescription string) bool {
+	return l.HardLimit > 0 && utf8.RuneCountInString(jobDescription) > l.HardLimit
+}
+
+// NeedsSummary reports whether jobDescription is over the soft limit and should be summarized for prompts
+func (l JobDescriptionLimits) NeedsSummary(jobDescription string) bool {
+	return l.SoftLimit > 0 && utf8.RuneCountInString(jobDescription) > l.SoftLimit
+}
+
+// Truncate cuts jobDescription to the soft limit; the fallback when it can't be summarized
+func (l JobDescriptionLimits) Truncate(jobDescription string) string {
+	if !l.NeedsSummary(jobDescription) {
+		return jobDescription
+	}
+	return truncateForPrompt(jobDescription, l.SoftLimit)
+}
+
+// SummarizeJobDescription condenses a long job posting into the requirements an interviewer needs,
+// in the posting's own language, so prompts stay proportionate to the conversation
+func (c *AIClient) SummarizeJobDescription(ctx context.Context, jobDescription string) (*ChatResponse, error) {
+	ctx, cancel := c.withCallTimeout(ctx)
+	defer cancel()
+
+	systemPrompt := "You are assisting an interviewer. Summarize the job posting below for use in interview prompts. " +
+		"Keep the job title, seniority, responsibilities, required and preferred skills, and technologies; " +
+		"drop benefits, boilerplate and application instructions. Keep it under 300 words, write it in the " +
+		"language of the posting, and do not add commentary."
+
+	req := &ChatRequest{
+		Messages: []Message{
+			{Role: "system", Content: systemPrompt},
+			{Role: "user", Content: jobDescription},
+		},
+		Model:       c.summarizationModel(),
+		MaxTokens:   600,
+		Temperature: 0.2,
+		Context:     map[string]interface{}{"task": TaskSummarization},
+	}
+
+	resp, err := c.generate(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("AI job description summarization failed: %w", err)
+	}
+	return resp, nil
+}
@@ -3,18 +3,224 @@ package ai
 
 import (
 	"context"
+	"errors"
 	"strings"
+	"sync"
 	"time"
 )
 
 // MockProvider implements the AIProvider interface with canned responses
-type MockProvider struct{}
+// A scripted mock returns its queued responses in order before falling back to canned ones,
+// and records every request it receives so tests can assert on what was sent to the provider.
+// Answer assessments are answered separately (see SetAssessments) so they don't consume the chat script.
+type MockProvider struct {
+	mu                 sync.Mutex
+	script             []string
+	assessments        []string
+	scores             []float64
+	delay              time.Duration
+	chatRequests       []*ChatRequest
+	evaluationRequests []*EvaluationRequest
+	questionRequests   []*QuestionGenerationRequest
+	faults             []MockFault
+	calls              map[string]int
+}
+
+// Mock operations a failure plan targets
+const (
+	MockOpChat       = "chat"       // GenerateResponse, answer assessments excluded
+	MockOpEvaluation = "evaluation" // EvaluateAnswers
+	MockOpQuestions  = "questions"  // GenerateInterviewQuestions
+)
+
+// ErrMockFailure is returned by a planned failure that names no error
+var ErrMockFailure = errors.New("mock provider: planned failure")
+
+// MockFault makes the Call-th call of the mock operation Op wait Delay, then fail with Err
+// A Call of 0 targets every call. A fault with only a Delay slows the call without failing it;
+// one with neither fails with ErrMockFailure.
+type MockFault struct {
+	Op    string
+	Call  int
+	Err   error
+	Delay time.Duration
+}
 
 func NewMockProvider() *MockProvider {
 	return &MockProvider{}
 }
 
+// NewScriptedMockProvider creates a mock provider that replies with the given chat responses in order
+func NewScriptedMockProvider(responses ...string) *MockProvider {
+	return &MockProvider{script: responses}
+}
+
+// AppendScript queues further chat responses after those already scripted
+func (m *MockProvider) AppendScript(responses ...string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.script = append(m.script, responses...)
+}
+
+// SetAssessments queues the qualities ("strong", "adequate", "weak") returned for answer assessments
+// in order; once they run out, answers are judged adequate
+func (m *MockProvider) SetAssessments(qualities ...string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.assessments = append(m.assessments, qualities...)
+}
+
+// nextAssessment pops the next scripted answer quality
+func (m *MockProvider) nextAssessment() string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if len(m.assessments) == 0 {
+		return AnswerQualityAdequate
+	}
+	next := m.assessments[0]
+	m.assessments = m.assessments[1:]
+	return next
+}
+
+// SetScores queues the overall scores returned by evaluations in order; once they run out,
+// evaluations score 0.8
+func (m *MockProvider) SetScores(scores ...float64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.scores = append(m.scores, scores...)
+}
+
+// nextScore pops the next scripted overall score
+func (m *MockProvider) nextScore() float64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if len(m.scores) == 0 {
+		return 0.8
+	}
+	next := m.scores[0]
+	m.scores = m.scores[1:]
+	return next
+}
+
+// PlanFaults adds faults to the mock's failure plan; calls are counted from the mock's creation,
+// so see Calls to target one relative to the calls already made
+func (m *MockProvider) PlanFaults(faults ...MockFault) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.faults = append(m.faults, faults...)
+}
+
+// Calls returns how many calls of the mock operation op were received, failed ones included
+func (m *MockProvider) Calls(op string) int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.calls[op]
+}
+
+// fault counts a call of op and applies the planned faults matching it: their delays are waited
+// out, returning early if the context is cancelled, and the first failure is returned
+func (m *MockProvider) fault(ctx context.Context, op string) error {
+	m.mu.Lock()
+	if m.calls == nil {
+		m.calls = make(map[string]int)
+	}
+	m.calls[op]++
+	var delay time.Duration
+	var failure error
+	for _, fault := range m.faults {
+		if fault.Op != op || (fault.Call != 0 && fault.Call != m.calls[op]) {
+			continue
+		}
+		delay += fault.Delay
+		if failure == nil && fault.Err != nil {
+			failure = fault.Err
+		} else if failure == nil && fault.Delay == 0 {
+			failure = ErrMockFailure
+		}
+	}
+	m.mu.Unlock()
+	if delay > 0 {
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return failure
+}
+
+// SetDelay adds an artificial latency to every chat response and evaluation, for timing tests
+func (m *MockProvider) SetDelay(delay time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.delay = delay
+}
+
+// wait sleeps for the configured delay, returning early if the context is cancelled
+func (m *MockProvider) wait(ctx context.Context) error {
+	m.mu.Lock()
+	delay := m.delay
+	m.mu.Unlock()
+	if delay <= 0 {
+		return nil
+	}
+	select {
+	case <-time.After(delay):
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// ChatRequests returns the chat requests received so far
+func (m *MockProvider) ChatRequests() []*ChatRequest {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]*ChatRequest(nil), m.chatRequests...)
+}
+
+// EvaluationRequests returns the evaluation requests received so far
+func (m *MockProvider) EvaluationRequests() []*EvaluationRequest {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]*EvaluationRequest(nil), m.evaluationRequests...)
+}
+
+// QuestionRequests returns the question generation requests received so far
+func (m *MockProvider) QuestionRequests() []*QuestionGenerationRequest {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]*QuestionGenerationRequest(nil), m.questionRequests...)
+}
+
+// nextScripted records the request and pops the next scripted response, if any
+func (m *MockProvider) nextScripted(req *ChatRequest) (string, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.chatRequests = append(m.chatRequests, req)
+	if len(m.script) == 0 {
+		return "", false
+	}
+	next := m.script[0]
+	m.script = m.script[1:]
+	return next, true
+}
+
 func (m *MockProvider) GenerateResponse(ctx context.Context, req *ChatRequest) (*ChatResponse, error) {
+	startTime := time.Now()
+	if req.Context["task"] == TaskAnswerAssessment {
+		return m.newChatResponse(strings.ToUpper(m.nextAssessment()), startTime), nil
+	}
+	if err := m.wait(ctx); err != nil {
+		return nil, err
+	}
+	if err := m.fault(ctx, MockOpChat); err != nil {
+		return nil, err
+	}
+	if scripted, ok := m.nextScripted(req); ok {
+		return m.newChatResponse(scripted, startTime), nil
+	}
+
 	// Detect language from system prompt
 	var isTraditionalChinese bool
 	for _, msg := range req.Messages {
@@ -27,21 +233,67 @@ func (m *MockProvider) GenerateResponse(ctx context.Context, req *ChatRequest) (
 
 	// Simple language-appropriate mock response
 	var mockResponse string
-	if isTraditionalChinese {
+	switch {
+	case req.Context["task"] == TaskSummarization:
+		mockResponse = mockSummary(req)
+	case req.Context["task"] == TaskTranslation:
+		mockResponse = mockTranslation(req)
+	case isTraditionalChinese:
 		mockResponse = "[模擬] 面試問題回應 - 這是測試用的模擬回應"
-	} else {
+	default:
 		mockResponse = "[MOCK] Interview response - This is a test mock response"
 	}
 
+	return m.newChatResponse(mockResponse, startTime), nil
+}
+
+// mockSummary returns a short deterministic "summary" of the last user message
+func mockSummary(req *ChatRequest) string {
+	text := ""
+	for _, msg := range req.Messages {
+		if msg.Role == "user" {
+			text = msg.Content
+		}
+	}
+	runes := []rune(text)
+	if len(runes) > 100 {
+		runes = runes[:100]
+	}
+	return "[MOCK] Summary: " + string(runes)
+}
+
+// mockTranslation returns the evaluation sections of the last user message with each text tagged
+// with the target language, e.g. "Feedback: [MOCK zh-TW] Solid answers"
+func mockTranslation(req *ChatRequest) string {
+	language, _ := req.Context["language"].(string)
+	tag := "[MOCK " + language + "] "
+	text := ""
+	for _, msg := range req.Messages {
+		if msg.Role == "user" {
+			text = msg.Content
+		}
+	}
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		if rest, ok := strings.CutPrefix(line, "Feedback: "); ok {
+			lines[i] = "Feedback: " + tag + rest
+		} else if rest, ok := strings.CutPrefix(line, "- "); ok {
+			lines[i] = "- " + tag + rest
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (m *MockProvider) newChatResponse(content string, startTime time.Time) *ChatResponse {
 	return &ChatResponse{
-		Content:      mockResponse,
+		Content:      content,
 		FinishReason: "stop",
 		TokensUsed:   TokenUsage{PromptTokens: 10, CompletionTokens: 20, TotalTokens: 30},
 		Model:        "mock-model",
 		Provider:     "mock",
-		ResponseTime: 10 * time.Millisecond,
+		ResponseTime: time.Since(startTime),
 		Timestamp:    time.Now(),
-	}, nil
+	}
 }
 
 func (m *MockProvider) GenerateStreamResponse(ctx context.Context, req *ChatRequest) (<-chan *ChatResponse, error) {
@@ -60,6 +312,13 @@ func (m *MockProvider) GenerateStreamResponse(ctx context.Context, req *ChatRequ
 }
 
 func (m *MockProvider) GenerateInterviewQuestions(ctx context.Context, req *QuestionGenerationRequest) (*QuestionGenerationResponse, error) {
+	m.mu.Lock()
+	m.questionRequests = append(m.questionRequests, req)
+	m.mu.Unlock()
+	if err := m.fault(ctx, MockOpQuestions); err != nil {
+		return nil, err
+	}
+
 	// Simple mock questions
 	questions := []InterviewQuestion{
 		{
@@ -89,29 +348,43 @@ func (m *MockProvider) GenerateInterviewQuestions(ctx context.Context, req *Ques
 }
 
 func (m *MockProvider) EvaluateAnswers(ctx context.Context, req *EvaluationRequest) (*EvaluationResponse, error) {
+	if err := m.wait(ctx); err != nil {
+		return nil, err
+	}
+	m.mu.Lock()
+	m.evaluationRequests = append(m.evaluationRequests, req)
+	m.mu.Unlock()
+	if err := m.fault(ctx, MockOpEvaluation); err != nil {
+		return nil, err
+	}
+
 	// Simple language-appropriate mock evaluation
 	var feedback string
-	var strengths, weaknesses, recommendations []string
+	var strengths, weaknesses, recommendations, nextSteps []string
 
 	if req.Language == "zh-TW" {
 		feedback = "[模擬] 測試用評估回饋"
 		strengths = []string{"[模擬] 測試優勢1", "[模擬] 測試優勢2"}
 		weaknesses = []string{"[模擬] 測試弱點1", "[模擬] 測試弱點2"}
 		recommendations = []string{"[模擬] 測試建議1", "[模擬] 測試建議2"}
+		nextSteps = []string{"[模擬] 測試下一步"}
 	} else {
 		feedback = "[MOCK] Test evaluation feedback"
 		strengths = []string{"[MOCK] Test strength 1", "[MOCK] Test strength 2"}
 		weaknesses = []string{"[MOCK] Test weakness 1", "[MOCK] Test weakness 2"}
 		recommendations = []string{"[MOCK] Test recommendation 1", "[MOCK] Test recommendation 2"}
+		nextSteps = []string{"[MOCK] Test next step"}
 	}
 
 	return &EvaluationResponse{
-		OverallScore:    0.8,
+		OverallScore:    m.nextScore(),
 		CategoryScores:  map[string]float64{"technical": 0.8, "communication": 0.85, "problem_solving": 0.75},
 		Feedback:        feedback,
 		Strengths:       strengths,
 		Weaknesses:      weaknesses,
 		Recommendations: recommendations,
+		Decision:        DecisionHire,
+		NextSteps:       nextSteps,
 		TokensUsed:      TokenUsage{PromptTokens: 50, CompletionTokens: 150, TotalTokens: 200},
 		Provider:        "mock",
 		Model:           "mock-model",
@@ -0,0 +1,62 @@
+package ai
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestMockProvider_FailurePlan(t *testing.T) {
+	provider := NewScriptedMockProvider("first", "second")
+	provider.PlanFaults(
+		MockFault{Op: MockOpChat, Call: 2, Err: ErrOverloaded},
+		MockFault{Op: MockOpEvaluation},
+	)
+	ctx := context.Background()
+
+	if resp, err := provider.GenerateResponse(ctx, &ChatRequest{}); err != nil || resp.Content != "first" {
+		t.Fatalf("expected the first call to succeed, got %v %v", resp, err)
+	}
+	if _, err := provider.GenerateResponse(ctx, &ChatRequest{}); !errors.Is(err, ErrOverloaded) {
+		t.Errorf("expected the second call to fail with ErrOverloaded, got %v", err)
+	}
+	// A failed call does not consume the script
+	if resp, err := provider.GenerateResponse(ctx, &ChatRequest{}); err != nil || resp.Content != "second" {
+		t.Errorf("expected the third call to return the next scripted reply, got %v %v", resp, err)
+	}
+	// Answer assessments are not chat calls
+	if _, err := provider.GenerateResponse(ctx, &ChatRequest{Context: map[string]interface{}{"task": TaskAnswerAssessment}}); err != nil {
+		t.Errorf("expected the assessment to succeed, got %v", err)
+	}
+	if calls := provider.Calls(MockOpChat); calls != 3 {
+		t.Errorf("expected 3 chat calls, got %d", calls)
+	}
+
+	for range 2 {
+		if _, err := provider.EvaluateAnswers(ctx, &EvaluationRequest{}); !errors.Is(err, ErrMockFailure) {
+			t.Errorf("expected every evaluation to fail with ErrMockFailure, got %v", err)
+		}
+	}
+}
+
+func TestMockProvider_PlannedLatency(t *testing.T) {
+	provider := NewMockProvider()
+	provider.PlanFaults(MockFault{Op: MockOpQuestions, Call: 1, Delay: 50 * time.Millisecond})
+
+	start := time.Now()
+	if _, err := provider.GenerateInterviewQuestions(context.Background(), &QuestionGenerationRequest{}); err != nil {
+		t.Fatalf("expected a delayed call to succeed, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < 50*time.Millisecond {
+		t.Errorf("expected the call to take at least 50ms, took %v", elapsed)
+	}
+
+	// The delay gives up once the context is done
+	provider.PlanFaults(MockFault{Op: MockOpQuestions, Call: 2, Delay: time.Minute})
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := provider.GenerateInterviewQuestions(ctx, &QuestionGenerationRequest{}); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected the delay to end with the context, got %v", err)
+	}
+}
@@ -0,0 +1,125 @@
+// Retired model names and their replacements
+package ai
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/zidane0000/ai-interview-platform/utils"
+)
+
+// MetadataModelDeprecated is set in response metadata when the requested model was retired and
+// the call went to its replacement
+const MetadataModelDeprecated = "model_deprecated"
+
+// maxAliasHops bounds how many aliases are followed, in case overrides form a cycle
+const maxAliasHops = 8
+
+// ModelAlias points a retired model name at its replacement
+type ModelAlias struct {
+	Replacement  string    `json:"replacement"`
+	DeprecatedOn time.Time `json:"deprecated_on,omitempty"` // When the provider retired the name; zero when unknown
+}
+
+// DefaultModelAliases maps model names providers have retired to their replacements
+// Extend or override it through AIConfig.ModelAliases as providers retire more models.
+var DefaultModelAliases = map[string]ModelAlias{
+	"gpt-4-turbo-preview": {Replacement: "gpt-4-turbo", DeprecatedOn: time.Date(2024, 4, 9, 0, 0, 0, 0, time.UTC)},
+	"gpt-3.5-turbo-16k":   {Replacement: "gpt-3.5-turbo", DeprecatedOn: time.Date(2024, 9, 13, 0, 0, 0, 0, time.UTC)},
+	"gemini-pro":          {Replacement: "gemini-1.5-pro", DeprecatedOn: time.Date(2025, 2, 15, 0, 0, 0, 0, time.UTC)},
+	"gemini-pro-vision":   {Replacement: "gemini-1.5-flash", DeprecatedOn: time.Date(2024, 7, 12, 0, 0, 0, 0, time.UTC)},
+}
+
+// lookupModelAlias finds model's alias, preferring overrides over the defaults
+func lookupModelAlias(model string, overrides map[string]ModelAlias) (ModelAlias, bool) {
+	for _, aliases := range []map[string]ModelAlias{overrides, DefaultModelAliases} {
+		if alias, ok := aliases[model]; ok {
+			return alias, true
+		}
+	}
+	return ModelAlias{}, false
+}
+
+// ResolveModel returns the name to request for model, following the alias table through any
+// chain of replacements. The returned alias is nil when model is current; otherwise it holds the
+// final replacement and model's own deprecation date.
+func ResolveModel(model string, overrides map[string]ModelAlias) (string, *ModelAlias) {
+	first, ok := lookupModelAlias(model, overrides)
+	if !ok {
+		return model, nil
+	}
+	resolved := first.Replacement
+	for range maxAliasHops {
+		next, ok := lookupModelAlias(resolved, overrides)
+		if !ok || next.Replacement == model {
+			break
+		}
+		resolved = next.Replacement
+	}
+	return resolved, &ModelAlias{Replacement: resolved, DeprecatedOn: first.DeprecatedOn}
+}
+
+// DeprecationNote describes the replacement of a retired model, for logs and response metadata
+func DeprecationNote(model string, alias ModelAlias) string {
+	if alias.DeprecatedOn.IsZero() {
+		return fmt.Sprintf("model %q is deprecated; using %q instead", model, alias.Replacement)
+	}
+	return fmt.Sprintf("model %q was deprecated on %s; using %q instead", model, alias.DeprecatedOn.Format(time.DateOnly), alias.Replacement)
+}
+
+// warnedDeprecatedModels records the retired models already logged, so each is warned about once
+var warnedDeprecatedModels sync.Map
+
+// warnDeprecatedModel logs the first rewrite of each retired model
+func warnDeprecatedModel(model string, alias ModelAlias) {
+	if _, warned := warnedDeprecatedModels.LoadOrStore(model, true); !warned {
+		utils.Warningf("AI model deprecation: %s", DeprecationNote(model, alias))
+	}
+}
+
+// supportedModels returns the models a provider lists in GetSupportedModels
+func supportedModels(provider string) []string {
+	switch provider {
+	case ProviderOpenAI:
+		return NewOpenAIProvider("", &AIConfig{}).GetSupportedModels()
+	case ProviderGemini:
+		return NewGeminiProvider("", &AIConfig{}).GetSupportedModels()
+	case ProviderMock:
+		return NewMockProvider().GetSupportedModels()
+	default:
+		return nil
+	}
+}
+
+// ValidateModel checks a model named by a client, as "provider/model" or a bare model name
+// Names that no provider supports and the alias table doesn't know are rejected up front rather
+// than failing at the provider with a 404; retired names pass and are rewritten when used.
+func ValidateModel(model string, overrides map[string]ModelAlias) error {
+	providers := []string{ProviderOpenAI, ProviderGemini, ProviderMock}
+	name := model
+	if strings.Contains(model, "/") {
+		provider, modelName, err := parseModel(model)
+		if err != nil {
+			return err
+		}
+		if !slices.Contains(providers, provider) {
+			return fmt.Errorf("unsupported provider %q (supported: %s)", provider, strings.Join(providers, ", "))
+		}
+		providers, name = []string{provider}, modelName
+	}
+	if _, ok := lookupModelAlias(name, overrides); ok {
+		return nil
+	}
+	var known []string
+	for _, provider := range providers {
+		models := supportedModels(provider)
+		if slices.Contains(models, name) {
+			return nil
+		}
+		known = append(known, models...)
+	}
+	return fmt.Errorf("unknown model %q (known: %s)", name, strings.Join(known, ", "))
+}
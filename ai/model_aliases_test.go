@@ -0,0 +1,132 @@
+package ai
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestResolveModel(t *testing.T) {
+	overrides := map[string]ModelAlias{
+		"gpt-4-turbo": {Replacement: "gpt-4o"},
+		"legacy":      {Replacement: "gpt-4-turbo-preview", DeprecatedOn: time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC)},
+		"loop-a":      {Replacement: "loop-b"},
+		"loop-b":      {Replacement: "loop-a"},
+	}
+
+	tests := []struct {
+		model     string
+		overrides map[string]ModelAlias
+		want      string
+		wantAlias bool
+	}{
+		{model: "gpt-4o", want: "gpt-4o"},
+		{model: "gemini-pro", want: "gemini-1.5-pro", wantAlias: true},
+		{model: "gpt-4-turbo-preview", want: "gpt-4-turbo", wantAlias: true},
+		// Overrides chain through the defaults and may retire current models
+		{model: "legacy", overrides: overrides, want: "gpt-4o", wantAlias: true},
+		{model: "gpt-4-turbo", overrides: overrides, want: "gpt-4o", wantAlias: true},
+		{model: "loop-a", overrides: overrides, want: "loop-b", wantAlias: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.model, func(t *testing.T) {
+			got, alias := ResolveModel(tt.model, tt.overrides)
+			if got != tt.want || (alias != nil) != tt.wantAlias {
+				t.Errorf("ResolveModel(%q) = %q, %v; expected %q (alias %v)", tt.model, got, alias, tt.want, tt.wantAlias)
+			}
+		})
+	}
+
+	// The deprecation date is the requested model's, not the end of the chain's
+	if _, alias := ResolveModel("legacy", overrides); alias == nil || !alias.DeprecatedOn.Equal(overrides["legacy"].DeprecatedOn) {
+		t.Errorf("expected the requested model's deprecation date, got %v", alias)
+	}
+}
+
+func TestGetModelName_RewritesDeprecatedModel(t *testing.T) {
+	base := NewBaseProvider(&AIConfig{ProviderDefaultModels: map[string]string{ProviderGemini: "gemini-pro"}}, "", time.Second)
+	if got := base.GetModelName("", ProviderGemini, "gemini-1.5-flash"); got != "gemini-1.5-pro" {
+		t.Errorf("expected the retired default to be replaced, got %q", got)
+	}
+	if got := base.GetModelName("gpt-3.5-turbo-16k", ProviderOpenAI, ""); got != "gpt-3.5-turbo" {
+		t.Errorf("expected the retired request model to be replaced, got %q", got)
+	}
+	if got := base.GetModelName("gpt-4o", ProviderOpenAI, ""); got != "gpt-4o" {
+		t.Errorf("expected a current model to be kept, got %q", got)
+	}
+}
+
+func TestAIClient_DeprecatedModelNote(t *testing.T) {
+	config := &AIConfig{
+		DefaultModel: "mock-v1",
+		ModelAliases: map[string]ModelAlias{"mock-v1": {Replacement: "mock-model", DeprecatedOn: time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC)}},
+	}
+	client := NewAIClientWithProvider(NewMockProvider(), config)
+
+	if got := client.GetCurrentModel(); got != "mock-model" {
+		t.Errorf("expected the current model to be the replacement, got %q", got)
+	}
+
+	resp, err := client.GenerateChatReply(context.Background(), "session1", nil, "Hello", "en", false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	note, _ := resp.Metadata[MetadataModelDeprecated].(string)
+	if !strings.Contains(note, `"mock-v1" was deprecated on 2025-01-31`) || !strings.Contains(note, `"mock-model"`) {
+		t.Errorf("expected a deprecation note in the metadata, got %v", resp.Metadata)
+	}
+
+	evaluation, err := client.EvaluateAnswersDetailed(context.Background(), []string{"Q1"}, []string{"A1"}, EvaluationContext{Language: "en"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if evaluation.Metadata[MetadataModelDeprecated] == nil {
+		t.Errorf("expected a deprecation note in the evaluation metadata, got %v", evaluation.Metadata)
+	}
+
+	// Current models get no note
+	resp, err = NewAIClientWithProvider(NewMockProvider(), &AIConfig{DefaultModel: "mock-model"}).GenerateChatReply(context.Background(), "session1", nil, "Hello", "en", false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.Metadata[MetadataModelDeprecated] != nil {
+		t.Errorf("expected no deprecation note, got %v", resp.Metadata)
+	}
+}
+
+func TestValidateModel(t *testing.T) {
+	overrides := map[string]ModelAlias{"in-house-v1": {Replacement: "gpt-4o"}}
+
+	tests := []struct {
+		model   string
+		wantErr string
+	}{
+		{model: "gpt-4o"},
+		{model: "openai/gpt-4o-mini"},
+		{model: "gemini/gemini-1.5-flash"},
+		{model: "openai/gpt-4-turbo-preview"},
+		{model: "gemini-pro"},
+		{model: "in-house-v1"},
+		{model: "openai/gpt-99", wantErr: `unknown model "gpt-99"`},
+		{model: "gemini/gpt-4o", wantErr: `unknown model "gpt-4o"`},
+		{model: "anthropic/claude-3", wantErr: "unsupported provider"},
+		{model: "llama-3", wantErr: "known: gpt-4o"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.model, func(t *testing.T) {
+			err := ValidateModel(tt.model, overrides)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("expected %q to be valid, got %v", tt.model, err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected an error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"strings"
 	"time"
 )
 
@@ -24,6 +25,7 @@ type openAIRequest struct {
 	TopP        float64         `json:"top_p,omitempty"`
 	Stream      bool            `json:"stream,omitempty"`
 	Stop        []string        `json:"stop,omitempty"`
+	N           int             `json:"n,omitempty"`
 }
 
 type openAIMessage struct {
@@ -105,13 +107,16 @@ func (p *OpenAIProvider) GenerateResponse(ctx context.Context, req *ChatRequest)
 	startTime := time.Now()
 
 	openAIReq := &openAIRequest{
-		Model:       p.GetModelName(req.Model, ""),
+		Model:       p.GetModelName(req.Model, ProviderOpenAI, ""),
 		Messages:    p.convertMessages(req.Messages),
 		MaxTokens:   req.MaxTokens,
 		Temperature: req.Temperature,
 		TopP:        req.TopP,
 		Stream:      req.Stream,
 	}
+	if req.N > 1 {
+		openAIReq.N = req.N
+	}
 
 	respData, err := p.MakeRequest(ctx, p, "/chat/completions", openAIReq)
 	if err != nil {
@@ -131,9 +136,10 @@ func (p *OpenAIProvider) GenerateResponse(ctx context.Context, req *ChatRequest)
 		return nil, fmt.Errorf("no choices returned from OpenAI")
 	}
 
-	choice := openAIResp.Choices[0]
+	choice, alternatives := selectOpenAIChoice(openAIResp.Choices)
 	return &ChatResponse{
 		Content:      choice.Message.Content,
+		Alternatives: alternatives,
 		FinishReason: choice.FinishReason,
 		TokensUsed: TokenUsage{
 			PromptTokens:     openAIResp.Usage.PromptTokens,
@@ -151,6 +157,27 @@ func (p *OpenAIProvider) GenerateResponse(ctx context.Context, req *ChatRequest)
 	}, nil
 }
 
+// selectOpenAIChoice returns the first usable choice, one with content that was not filtered, and
+// the content of the other usable choices. Without any usable choice the first is returned as is.
+func selectOpenAIChoice(choices []openAIChoice) (openAIChoice, []string) {
+	selected := -1
+	var alternatives []string
+	for i, choice := range choices {
+		if strings.TrimSpace(choice.Message.Content) == "" || choice.FinishReason == "content_filter" {
+			continue
+		}
+		if selected < 0 {
+			selected = i
+			continue
+		}
+		alternatives = append(alternatives, choice.Message.Content)
+	}
+	if selected < 0 {
+		return choices[0], nil
+	}
+	return choices[selected], alternatives
+}
+
 // GenerateStreamResponse generates a streaming response (placeholder for now)
 func (p *OpenAIProvider) GenerateStreamResponse(ctx context.Context, req *ChatRequest) (<-chan *ChatResponse, error) {
 	return nil, fmt.Errorf("streaming not yet implemented for OpenAI provider")
@@ -163,11 +190,12 @@ func (p *OpenAIProvider) GenerateInterviewQuestions(ctx context.Context, req *Qu
 	chatReq := &ChatRequest{
 		Messages: []Message{
 			{Role: "system", Content: systemPrompt},
-			{Role: "user", Content: fmt.Sprintf("Generate %d interview questions based on this job description: %s", req.NumQuestions, req.JobDescription)},
+			{Role: "user", Content: fmt.Sprintf("Generate %d interview questions based on the job description above.", req.NumQuestions)},
 		},
-		Model:       p.GetModelName("", ""),
+		Model:       p.GetModelName("", ProviderOpenAI, ""),
 		MaxTokens:   2000,
 		Temperature: 0.7,
+		N:           req.Alternatives,
 	}
 
 	response, err := p.GenerateResponse(ctx, chatReq)
@@ -175,7 +203,7 @@ func (p *OpenAIProvider) GenerateInterviewQuestions(ctx context.Context, req *Qu
 		return nil, fmt.Errorf("failed to generate questions: %w", err)
 	}
 
-	questions := ParseQuestionResponse(response.Content)
+	questions := ParseQuestionAlternatives(response, req.NumQuestions)
 
 	return &QuestionGenerationResponse{
 		Questions:  questions,
@@ -197,9 +225,9 @@ func (p *OpenAIProvider) EvaluateAnswers(ctx context.Context, req *EvaluationReq
 			{Role: "system", Content: systemPrompt},
 			{Role: "user", Content: userContent},
 		},
-		Model:       p.GetModelName("", ""),
-		MaxTokens:   3000,
-		Temperature: 0.3,
+		Model:       p.GetModelName(req.Params.Model, ProviderOpenAI, ""),
+		MaxTokens:   req.maxTokens(),
+		Temperature: req.temperature(),
 	}
 
 	response, err := p.GenerateResponse(ctx, chatReq)
@@ -208,6 +236,7 @@ func (p *OpenAIProvider) EvaluateAnswers(ctx context.Context, req *EvaluationReq
 	}
 
 	evaluation := ParseEvaluationResponse(response.Content)
+	evaluation.Metadata = withRawOutput(evaluation.Metadata, response.Content)
 	evaluation.TokensUsed = response.TokensUsed
 	evaluation.Provider = ProviderOpenAI
 	evaluation.Model = response.Model
@@ -224,11 +253,11 @@ func (p *OpenAIProvider) GetProviderName() string {
 // GetSupportedModels returns list of supported OpenAI models
 func (p *OpenAIProvider) GetSupportedModels() []string {
 	return []string{
+		"gpt-4o",
+		"gpt-4o-mini",
 		"gpt-4",
 		"gpt-4-turbo",
-		"gpt-4-turbo-preview",
 		"gpt-3.5-turbo",
-		"gpt-3.5-turbo-16k",
 	}
 }
 
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"reflect"
 	"strings"
 	"testing"
 	"time"
@@ -499,6 +500,9 @@ func TestOpenAIProvider_EvaluateAnswers(t *testing.T) {
 	if resp.Feedback == "" {
 		t.Error("Expected feedback to be present")
 	}
+	if raw := resp.PromptTrace().RawOutput; !strings.HasPrefix(raw, "Overall Score: 0.8\n\nFeedback: Good answers overall.") {
+		t.Errorf("Expected the raw model output recorded, got %q", raw)
+	}
 }
 
 // TestOpenAIProvider_ValidateCredentials tests credential validation
@@ -627,24 +631,98 @@ func TestOpenAIProvider_ModelFallback(t *testing.T) {
 	}))
 	defer server.Close()
 
-	config := &AIConfig{
-		OpenAIBaseURL:  server.URL,
-		RequestTimeout: 10 * time.Second,
-		DefaultModel:   "default-model",
+	tests := []struct {
+		name           string
+		providerModels map[string]string
+		expected       string
+	}{
+		{"global default", nil, "default-model"},
+		{"gemini default ignored", map[string]string{ProviderGemini: "gemini-1.5-pro"}, "default-model"},
+		{"openai default wins", map[string]string{ProviderOpenAI: "gpt-4-turbo", ProviderGemini: "gemini-1.5-pro"}, "gpt-4-turbo"},
 	}
-	provider := NewOpenAIProvider("test-key", config)
 
-	// Request without model should use config default
-	req := &ChatRequest{
-		Messages: []Message{{Role: "user", Content: "test"}},
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := &AIConfig{
+				OpenAIBaseURL:         server.URL,
+				RequestTimeout:        10 * time.Second,
+				DefaultModel:          "default-model",
+				ProviderDefaultModels: tt.providerModels,
+			}
+			provider := NewOpenAIProvider("test-key", config)
+
+			// Request without model should use the configured default
+			req := &ChatRequest{
+				Messages: []Message{{Role: "user", Content: "test"}},
+			}
+
+			_, err := provider.GenerateResponse(context.Background(), req)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if receivedModel != tt.expected {
+				t.Errorf("Expected default model '%s', got '%s'", tt.expected, receivedModel)
+			}
+		})
 	}
+}
 
-	_, err := provider.GenerateResponse(context.Background(), req)
+func TestOpenAIProvider_GenerateResponse_MultipleChoices(t *testing.T) {
+	var n float64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&body)
+		n, _ = body["n"].(float64)
+		w.Write([]byte(`{
+			"model": "gpt-4",
+			"choices": [
+				{"index": 0, "message": {"role": "assistant", "content": ""}, "finish_reason": "content_filter"},
+				{"index": 1, "message": {"role": "assistant", "content": "Second"}, "finish_reason": "stop"},
+				{"index": 2, "message": {"role": "assistant", "content": "Third"}, "finish_reason": "length"}
+			]
+		}`))
+	}))
+	defer server.Close()
+	provider := NewOpenAIProvider("test-key", &AIConfig{OpenAIBaseURL: server.URL, RequestTimeout: 10 * time.Second})
+
+	resp, err := provider.GenerateResponse(context.Background(), &ChatRequest{
+		Messages: []Message{{Role: "user", Content: "Hello"}},
+		N:        3,
+	})
 	if err != nil {
 		t.Fatalf("Unexpected error: %v", err)
 	}
+	if n != 3 {
+		t.Errorf("Expected n=3 in the request, got %v", n)
+	}
+	if resp.Content != "Second" || !reflect.DeepEqual(resp.Alternatives, []string{"Third"}) {
+		t.Errorf("Expected the first usable choice with the rest as alternatives, got %q, %v", resp.Content, resp.Alternatives)
+	}
+}
 
-	if receivedModel != "default-model" {
-		t.Errorf("Expected default model 'default-model', got '%s'", receivedModel)
+func TestOpenAIProvider_GenerateInterviewQuestions_Alternatives(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{
+			"model": "gpt-4",
+			"choices": [
+				{"index": 0, "message": {"content": "Question: What is Go?\nExpected Time: 5"}, "finish_reason": "stop"},
+				{"index": 1, "message": {"content": "Question: What is Go? \nExpected Time: 5\nQuestion: What is a channel?\nExpected Time: 5"}, "finish_reason": "stop"}
+			]
+		}`))
+	}))
+	defer server.Close()
+	provider := NewOpenAIProvider("test-key", &AIConfig{OpenAIBaseURL: server.URL, RequestTimeout: 10 * time.Second})
+
+	resp, err := provider.GenerateInterviewQuestions(context.Background(), &QuestionGenerationRequest{
+		JobDescription: "Backend Engineer",
+		NumQuestions:   5,
+		Alternatives:   2,
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(resp.Questions) != 2 || resp.Questions[0].Question != "What is Go?" || resp.Questions[1].Question != "What is a channel?" {
+		t.Errorf("Expected the questions merged without the repeat, got %+v", resp.Questions)
 	}
 }
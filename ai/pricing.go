@@ -0,0 +1,67 @@
+// Token pricing used to estimate the cost of AI calls
+package ai
+
+import "strings"
+
+// ModelPrice is a model's token price in USD per million tokens
+type ModelPrice struct {
+	PromptPerMillion     float64 `json:"prompt_per_million"`
+	CompletionPerMillion float64 `json:"completion_per_million"`
+}
+
+// DefaultModelPrices holds list prices for the supported models, and for retired ones so past
+// calls stay priced. Estimates only: override them through AIConfig.ModelPrices when providers change pricing
+var DefaultModelPrices = map[string]ModelPrice{
+	"gpt-4o":              {PromptPerMillion: 2.5, CompletionPerMillion: 10},
+	"gpt-4o-mini":         {PromptPerMillion: 0.15, CompletionPerMillion: 0.6},
+	"gpt-4":               {PromptPerMillion: 30, CompletionPerMillion: 60},
+	"gpt-4-turbo":         {PromptPerMillion: 10, CompletionPerMillion: 30},
+	"gpt-4-turbo-preview": {PromptPerMillion: 10, CompletionPerMillion: 30},
+	"gpt-3.5-turbo":       {PromptPerMillion: 0.5, CompletionPerMillion: 1.5},
+	"gpt-3.5-turbo-16k":   {PromptPerMillion: 3, CompletionPerMillion: 4},
+	"gemini-1.5-pro":      {PromptPerMillion: 1.25, CompletionPerMillion: 5},
+	"gemini-1.5-flash":    {PromptPerMillion: 0.075, CompletionPerMillion: 0.3},
+	"gemini-pro":          {PromptPerMillion: 0.5, CompletionPerMillion: 1.5},
+	"gemini-pro-vision":   {PromptPerMillion: 0.5, CompletionPerMillion: 1.5},
+	"mock-model":          {},
+}
+
+// Metadata note added when a cost was estimated without a price for the model
+const (
+	MetadataPricing    = "pricing"
+	PricingDefaultRate = "default_rate" // Priced at AIConfig.CostPerToken
+)
+
+// Cost returns the estimated USD cost of usage at this price
+func (p ModelPrice) Cost(usage TokenUsage) float64 {
+	return (float64(usage.PromptTokens)*p.PromptPerMillion + float64(usage.CompletionTokens)*p.CompletionPerMillion) / 1e6
+}
+
+// lookupModelPrice finds the price for model, preferring overrides over the defaults
+// Versioned names such as "gpt-4-0613" match their base model
+func lookupModelPrice(model string, overrides map[string]ModelPrice) (ModelPrice, bool) {
+	for _, prices := range []map[string]ModelPrice{overrides, DefaultModelPrices} {
+		if price, ok := prices[model]; ok {
+			return price, true
+		}
+	}
+
+	best, bestLen := ModelPrice{}, 0
+	for _, prices := range []map[string]ModelPrice{overrides, DefaultModelPrices} {
+		for name, price := range prices {
+			if len(name) > bestLen && strings.HasPrefix(model, name+"-") {
+				best, bestLen = price, len(name)
+			}
+		}
+	}
+	return best, bestLen > 0
+}
+
+// estimateCost prices token usage for model, falling back to the configured per-token
+// default rate when the model has no price; known is false in that case
+func (c *AIClient) estimateCost(model string, usage TokenUsage) (cost float64, known bool) {
+	if price, ok := lookupModelPrice(model, c.config.ModelPrices); ok {
+		return price.Cost(usage), true
+	}
+	return float64(usage.TotalTokens) * c.config.CostPerToken, false
+}
@@ -0,0 +1,57 @@
+package ai
+
+import (
+	"context"
+	"math"
+	"testing"
+)
+
+func TestEstimateCost(t *testing.T) {
+	usage := TokenUsage{PromptTokens: 1000, CompletionTokens: 500, TotalTokens: 1500}
+
+	tests := []struct {
+		name         string
+		model        string
+		overrides    map[string]ModelPrice
+		costPerToken float64
+		wantCost     float64
+		wantKnown    bool
+	}{
+		{name: "known model", model: "gpt-4", wantCost: 0.06, wantKnown: true},
+		{name: "versioned model uses base price", model: "gpt-4-0613", wantCost: 0.06, wantKnown: true},
+		{name: "longest base name wins", model: "gpt-4-turbo-2024-04-09", wantCost: 0.025, wantKnown: true},
+		{name: "override replaces default", model: "gpt-4", overrides: map[string]ModelPrice{"gpt-4": {PromptPerMillion: 1, CompletionPerMillion: 2}}, wantCost: 0.002, wantKnown: true},
+		{name: "override adds model", model: "llama-3-70b", overrides: map[string]ModelPrice{"llama-3-70b": {PromptPerMillion: 0.9, CompletionPerMillion: 0.9}}, wantCost: 0.00135, wantKnown: true},
+		{name: "unknown model uses default rate", model: "claude-3-opus", costPerToken: 0.00001, wantCost: 0.015, wantKnown: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := NewAIClientWithProvider(NewMockProvider(), &AIConfig{ModelPrices: tt.overrides, CostPerToken: tt.costPerToken})
+			cost, known := client.estimateCost(tt.model, usage)
+			if math.Abs(cost-tt.wantCost) > 1e-12 {
+				t.Errorf("Expected cost %v, got %v", tt.wantCost, cost)
+			}
+			if known != tt.wantKnown {
+				t.Errorf("Expected known %v, got %v", tt.wantKnown, known)
+			}
+		})
+	}
+}
+
+func TestGenerateChatReply_EstimatedCost(t *testing.T) {
+	// The mock reports 10 prompt and 20 completion tokens per call
+	cfg := &AIConfig{DefaultModel: "mock-model", ModelPrices: map[string]ModelPrice{"mock-model": {PromptPerMillion: 1000, CompletionPerMillion: 2000}}}
+	client := NewAIClientWithProvider(NewScriptedMockProvider("Tell me about yourself?"), cfg)
+
+	resp, err := client.GenerateChatReply(context.Background(), "session1", nil, "Hello", "en", false)
+	if err != nil {
+		t.Fatalf("GenerateChatReply failed: %v", err)
+	}
+	if math.Abs(resp.EstimatedCostUSD-0.05) > 1e-12 {
+		t.Errorf("Expected estimated cost 0.05, got %v", resp.EstimatedCostUSD)
+	}
+	if _, noted := resp.Metadata[MetadataPricing]; noted {
+		t.Error("Expected no pricing note for a priced model")
+	}
+}
@@ -3,6 +3,9 @@ package ai
 
 import (
 	"fmt"
+	"os"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/joho/godotenv"
@@ -16,22 +19,23 @@ func NewDefaultAIConfig() *AIConfig {
 	_ = godotenv.Load()
 
 	return &AIConfig{
-		OpenAIAPIKey:     utils.GetEnvString("OPENAI_API_KEY", ""),
-		GeminiAPIKey:     utils.GetEnvString("GEMINI_API_KEY", ""),
-		DefaultProvider:  utils.GetEnvString("AI_DEFAULT_PROVIDER", ProviderMock),
-		DefaultModel:     utils.GetEnvString("AI_DEFAULT_MODEL", "mock-model"),
-		MaxRetries:       utils.GetEnvInt("AI_MAX_RETRIES", 3),
-		RequestTimeout:   utils.GetEnvDuration("AI_REQUEST_TIMEOUT", 60*time.Second),
-		DefaultMaxTokens: utils.GetEnvInt("AI_DEFAULT_MAX_TOKENS", 1000),
-		DefaultTemp:      utils.GetEnvFloat64("AI_DEFAULT_TEMPERATURE", 0.7),
-		EnableCaching:    utils.GetEnvBool("AI_ENABLE_CACHING", true),
-		EnableMetrics:    utils.GetEnvBool("AI_ENABLE_METRICS", true),
-		EnableStreaming:  utils.GetEnvBool("AI_ENABLE_STREAMING", false),
-		RateLimitRPM:     utils.GetEnvInt("AI_RATE_LIMIT_RPM", 60),
-		RateLimitTPM:     utils.GetEnvInt("AI_RATE_LIMIT_TPM", 60000),
-		DailyTokenLimit:  utils.GetEnvInt("AI_DAILY_TOKEN_LIMIT", 100000),
-		CostPerToken:     utils.GetEnvFloat64("AI_COST_PER_TOKEN", 0.000002),
-		MaxCostPerDay:    utils.GetEnvFloat64("AI_MAX_COST_PER_DAY", 10.0),
+		OpenAIAPIKey:          utils.GetEnvString("OPENAI_API_KEY", ""),
+		GeminiAPIKey:          utils.GetEnvString("GEMINI_API_KEY", ""),
+		DefaultProvider:       utils.GetEnvString("AI_DEFAULT_PROVIDER", ProviderMock),
+		DefaultModel:          utils.GetEnvString("AI_DEFAULT_MODEL", "mock-model"),
+		ProviderDefaultModels: ProviderDefaultModelsFromEnv(),
+		MaxRetries:            utils.GetEnvInt("AI_MAX_RETRIES", 3),
+		RequestTimeout:        utils.GetEnvDuration("AI_REQUEST_TIMEOUT", 60*time.Second),
+		DefaultMaxTokens:      utils.GetEnvInt("AI_DEFAULT_MAX_TOKENS", 1000),
+		DefaultTemp:           utils.GetEnvFloat64("AI_DEFAULT_TEMPERATURE", 0.7),
+		EnableCaching:         utils.GetEnvBool("AI_ENABLE_CACHING", true),
+		EnableMetrics:         utils.GetEnvBool("AI_ENABLE_METRICS", true),
+		EnableStreaming:       utils.GetEnvBool("AI_ENABLE_STREAMING", false),
+		RateLimitRPM:          utils.GetEnvInt("AI_RATE_LIMIT_RPM", 60),
+		RateLimitTPM:          utils.GetEnvInt("AI_RATE_LIMIT_TPM", 60000),
+		DailyTokenLimit:       utils.GetEnvInt("AI_DAILY_TOKEN_LIMIT", 100000),
+		CostPerToken:          utils.GetEnvFloat64("AI_COST_PER_TOKEN", 0.000002),
+		MaxCostPerDay:         utils.GetEnvFloat64("AI_MAX_COST_PER_DAY", 10.0),
 	}
 }
 
@@ -74,6 +78,48 @@ func ValidateConfig(config *AIConfig) error {
 	return nil
 }
 
+// DefaultModelFor returns the default model of provider: its ProviderDefaultModels entry, else DefaultModel
+func (config *AIConfig) DefaultModelFor(provider string) string {
+	if model := config.ProviderDefaultModels[provider]; model != "" {
+		return model
+	}
+	return config.DefaultModel
+}
+
+// ProviderDefaultModelsFromEnv reads AI_<PROVIDER>_DEFAULT_MODEL (e.g. AI_OPENAI_DEFAULT_MODEL)
+// for every provider; providers without the variable are left out
+func ProviderDefaultModelsFromEnv() map[string]string {
+	models := make(map[string]string)
+	for _, provider := range []string{ProviderOpenAI, ProviderGemini, ProviderMock} {
+		if model := strings.TrimSpace(os.Getenv("AI_" + strings.ToUpper(provider) + "_DEFAULT_MODEL")); model != "" {
+			models[provider] = model
+		}
+	}
+	return models
+}
+
+// CheckProviderDefaultModels describes every provider default model that is retired (see aliases and
+// DefaultModelAliases) or that the provider does not list in GetSupportedModels. Unknown models are
+// reported rather than rejected so new releases can be used before they are added to the list.
+func CheckProviderDefaultModels(models map[string]string, aliases map[string]ModelAlias) []string {
+	var warnings []string
+	for _, provider := range []string{ProviderOpenAI, ProviderGemini, ProviderMock} {
+		model, ok := models[provider]
+		if !ok {
+			continue
+		}
+		if resolved, alias := ResolveModel(model, aliases); alias != nil {
+			warnings = append(warnings, fmt.Sprintf("default %s model %s", provider, strings.TrimPrefix(DeprecationNote(model, *alias), "model ")))
+			model = resolved
+		}
+		supported := supportedModels(provider)
+		if !slices.Contains(supported, model) {
+			warnings = append(warnings, fmt.Sprintf("default model %q is not a known %s model (known: %s)", model, provider, strings.Join(supported, ", ")))
+		}
+	}
+	return warnings
+}
+
 // GetAvailableProviders returns list of providers with valid API keys
 func GetAvailableProviders(config *AIConfig) []string {
 	var providers []string
@@ -98,7 +144,7 @@ func GetProviderInfo(provider string) map[string]interface{} {
 	case ProviderOpenAI:
 		return map[string]interface{}{
 			"name":               "OpenAI",
-			"models":             []string{"gpt-4", "gpt-4-turbo", "gpt-3.5-turbo"},
+			"models":             []string{"gpt-4o", "gpt-4-turbo", "gpt-3.5-turbo"},
 			"supports_vision":    true,
 			"supports_functions": true,
 			"max_tokens":         4096,
@@ -107,7 +153,7 @@ func GetProviderInfo(provider string) map[string]interface{} {
 	case ProviderGemini:
 		return map[string]interface{}{
 			"name":               "Google Gemini",
-			"models":             []string{"gemini-1.5-pro", "gemini-1.5-flash", "gemini-pro"},
+			"models":             []string{"gemini-1.5-pro", "gemini-1.5-flash"},
 			"supports_vision":    true,
 			"supports_functions": true,
 			"max_tokens":         8192,
@@ -193,6 +239,8 @@ func GetModelRecommendation(provider, taskType string) string {
 			return "gpt-4" // More accurate for complex analysis
 		case "question_generation":
 			return "gpt-3.5-turbo" // Good balance for question generation
+		case TaskSummarization:
+			return "gpt-3.5-turbo" // Cheapest option for condensing text
 		default:
 			return "gpt-3.5-turbo"
 		}
@@ -204,6 +252,8 @@ func GetModelRecommendation(provider, taskType string) string {
 			return "gemini-1.5-pro" // Better for complex reasoning
 		case "question_generation":
 			return "gemini-1.5-flash" // Good for generation tasks
+		case TaskSummarization:
+			return "gemini-1.5-flash" // Cheapest option for condensing text
 		default:
 			return "gemini-1.5-flash"
 		}
@@ -0,0 +1,121 @@
+// PII redaction of provider payloads so personal data does not leave our infrastructure
+package ai
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/zidane0000/ai-interview-platform/utils"
+)
+
+// Built-in PII types, used in placeholders such as [EMAIL_1]
+const (
+	PIITypeEmail      = utils.RedactTypeEmail
+	PIITypePhone      = utils.RedactTypePhone
+	PIITypeNationalID = utils.RedactTypeNationalID
+)
+
+// RedactPattern detects one type of PII; Type names the placeholder and should be upper case
+type RedactPattern = utils.RedactPattern
+
+// Redactor replaces PII with typed placeholders such as [EMAIL_1]
+// The same value always gets the same placeholder, so references stay coherent across every
+// text the Redactor sees. It is safe for concurrent use.
+type Redactor struct {
+	mu           sync.Mutex
+	custom       []RedactPattern
+	placeholders map[string]string // original value -> placeholder
+	originals    map[string]string // placeholder -> original value
+	counts       map[string]int    // placeholders issued per type
+}
+
+// NewRedactor creates a Redactor for the built-in PII types plus the custom patterns
+// Custom patterns are applied first so organization-specific formats win over the built-ins
+func NewRedactor(custom []RedactPattern) *Redactor {
+	return &Redactor{
+		custom:       custom,
+		placeholders: make(map[string]string),
+		originals:    make(map[string]string),
+		counts:       make(map[string]int),
+	}
+}
+
+// Redact replaces every detected PII value in text with its placeholder
+func (r *Redactor) Redact(text string) string {
+	if text == "" {
+		return text
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return utils.RedactTyped(text, r.custom, r.placeholderFor)
+}
+
+// placeholderFor returns the placeholder of value, issuing the next one of its type if needed
+// Callers must hold r.mu
+func (r *Redactor) placeholderFor(piiType, value string) string {
+	if placeholder, ok := r.placeholders[value]; ok {
+		return placeholder
+	}
+	r.counts[piiType]++
+	placeholder := fmt.Sprintf("[%s_%d]", piiType, r.counts[piiType])
+	r.placeholders[value] = placeholder
+	r.originals[placeholder] = value
+	return placeholder
+}
+
+// Restore puts the original values back in place of placeholders this Redactor issued
+// Used on provider output so replies that quote a placeholder read naturally
+func (r *Redactor) Restore(text string) string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if len(r.originals) == 0 {
+		return text
+	}
+	return utils.TypedPlaceholderPattern.ReplaceAllStringFunc(text, func(placeholder string) string {
+		if original, ok := r.originals[placeholder]; ok {
+			return original
+		}
+		return placeholder
+	})
+}
+
+// Mapping returns the placeholders issued so far and the values they replaced
+// The mapping holds the PII itself and must never be sent to clients or providers
+func (r *Redactor) Mapping() map[string]string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	mapping := make(map[string]string, len(r.originals))
+	for placeholder, original := range r.originals {
+		mapping[placeholder] = original
+	}
+	return mapping
+}
+
+// redactMessages returns a copy of messages with their content redacted
+func (r *Redactor) redactMessages(messages []Message) []Message {
+	redacted := make([]Message, len(messages))
+	for i, msg := range messages {
+		redacted[i] = msg
+		redacted[i].Content = r.Redact(msg.Content)
+	}
+	return redacted
+}
+
+// redactAll returns a copy of texts with each entry redacted
+func (r *Redactor) redactAll(texts []string) []string {
+	if texts == nil {
+		return nil
+	}
+	redacted := make([]string, len(texts))
+	for i, text := range texts {
+		redacted[i] = r.Redact(text)
+	}
+	return redacted
+}
+
+// restoreAll restores placeholders in each entry of texts in place
+func (r *Redactor) restoreAll(texts []string) {
+	for i, text := range texts {
+		texts[i] = r.Restore(text)
+	}
+}
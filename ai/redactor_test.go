@@ -0,0 +1,144 @@
+package ai
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestRedactor_DetectsPatterns(t *testing.T) {
+	custom := []RedactPattern{{Type: "EMPLOYEE_ID", Pattern: regexp.MustCompile(`EMP-\d{6}`)}}
+
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"email", "Mail me at jane.doe@example.com.", "Mail me at [EMAIL_1]."},
+		{"international phone", "Call +1 555-123-4567 today", "Call [PHONE_1] today"},
+		{"local phone", "My number is (02) 2345-6789", "My number is [PHONE_1]"},
+		{"taiwan mobile", "手機 0912345678", "手機 [PHONE_1]"},
+		{"us ssn", "SSN 123-45-6789 on file", "SSN [NATIONAL_ID_1] on file"},
+		{"taiwan national id", "ID A123456789", "ID [NATIONAL_ID_1]"},
+		{"custom pattern", "Badge EMP-004211 expired", "Badge [EMPLOYEE_ID_1] expired"},
+		{"dates and numbers untouched", "From 2019-03-01 I led 12 engineers for 3 years", "From 2019-03-01 I led 12 engineers for 3 years"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewRedactor(custom).Redact(tt.input); got != tt.expected {
+				t.Errorf("Redact(%q) = %q, want %q", tt.input, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestRedactor_ConsistentPlaceholders(t *testing.T) {
+	redactor := NewRedactor(nil)
+
+	first := redactor.Redact("Reach me at a@example.com or b@example.com")
+	second := redactor.Redact("Again, a@example.com is best")
+	if first != "Reach me at [EMAIL_1] or [EMAIL_2]" {
+		t.Errorf("Unexpected first redaction %q", first)
+	}
+	if second != "Again, [EMAIL_1] is best" {
+		t.Errorf("Expected the same placeholder for a repeated value, got %q", second)
+	}
+
+	mapping := redactor.Mapping()
+	if len(mapping) != 2 || mapping["[EMAIL_1]"] != "a@example.com" || mapping["[EMAIL_2]"] != "b@example.com" {
+		t.Errorf("Unexpected mapping %v", mapping)
+	}
+	if restored := redactor.Restore("I will write to [EMAIL_2] and [EMAIL_9]"); restored != "I will write to b@example.com and [EMAIL_9]" {
+		t.Errorf("Unexpected restore %q", restored)
+	}
+}
+
+func TestGenerateChatReply_RedactsPII(t *testing.T) {
+	provider := NewScriptedMockProvider("Thanks, I noted [EMAIL_1].")
+	client := NewAIClientWithProvider(provider, &AIConfig{DefaultModel: "mock-model", RedactPII: true})
+	history := []map[string]string{
+		{"role": "user", "content": "My email is jane@example.com"},
+		{"role": "assistant", "content": "Thanks. What is your phone number?"},
+	}
+
+	resp, err := client.GenerateChatReply(context.Background(), "session1", history, "It's +1 555-123-4567, or email jane@example.com", "en", false)
+	if err != nil {
+		t.Fatalf("GenerateChatReply failed: %v", err)
+	}
+
+	requests := provider.ChatRequests()
+	if len(requests) != 1 {
+		t.Fatalf("Expected 1 chat request, got %d", len(requests))
+	}
+	var payload strings.Builder
+	for _, msg := range requests[0].Messages {
+		payload.WriteString(msg.Content + "\n")
+	}
+	for _, leaked := range []string{"jane@example.com", "555-123-4567"} {
+		if strings.Contains(payload.String(), leaked) {
+			t.Errorf("Expected %q to be redacted from the provider payload", leaked)
+		}
+	}
+	if strings.Count(payload.String(), "[EMAIL_1]") != 2 || !strings.Contains(payload.String(), "[PHONE_1]") {
+		t.Errorf("Expected consistent placeholders across turns, got %q", payload.String())
+	}
+	if strings.Contains(payload.String(), "[EMAIL_2]") {
+		t.Errorf("Expected a repeated email to reuse its placeholder, got %q", payload.String())
+	}
+	if history[0]["content"] != "My email is jane@example.com" {
+		t.Errorf("Expected the caller's history to be left untouched, got %q", history[0]["content"])
+	}
+	if resp.Content != "Thanks, I noted jane@example.com." {
+		t.Errorf("Expected placeholders in the reply to be restored, got %q", resp.Content)
+	}
+	if mapping := client.Redactor().Mapping(); mapping["[PHONE_1]"] != "+1 555-123-4567" {
+		t.Errorf("Expected the mapping to record the phone number, got %v", mapping)
+	}
+}
+
+func TestEvaluateAnswersDetailed_RedactsPII(t *testing.T) {
+	provider := NewMockProvider()
+	client := NewAIClientWithProvider(provider, &AIConfig{DefaultModel: "mock-model", RedactPII: true})
+
+	_, err := client.EvaluateAnswersDetailed(context.Background(), []string{"How can we reach you?"}, []string{"jane@example.com"}, EvaluationContext{
+		ResumeContent:       "Jane Doe, 0912345678",
+		ConversationSummary: "Candidate shared jane@example.com",
+	})
+	if err != nil {
+		t.Fatalf("EvaluateAnswersDetailed failed: %v", err)
+	}
+
+	req := provider.EvaluationRequests()[0]
+	if req.Answers[0] != "[EMAIL_1]" || req.ConversationSummary != "Candidate shared [EMAIL_1]" {
+		t.Errorf("Expected the email to be redacted consistently, got answers %v and summary %q", req.Answers, req.ConversationSummary)
+	}
+	if req.ResumeContent != "Jane Doe, [PHONE_1]" {
+		t.Errorf("Expected the resume phone number to be redacted, got %q", req.ResumeContent)
+	}
+}
+
+func TestRedaction_DisabledPassesThrough(t *testing.T) {
+	provider := NewMockProvider()
+	client := NewAIClientWithProvider(provider, &AIConfig{DefaultModel: "mock-model"})
+	message := "Email jane@example.com, phone +1 555-123-4567, SSN 123-45-6789"
+
+	if _, err := client.GenerateChatReply(context.Background(), "session1", nil, message, "en", false); err != nil {
+		t.Fatalf("GenerateChatReply failed: %v", err)
+	}
+	if _, err := client.EvaluateAnswersDetailed(context.Background(), []string{"Q1"}, []string{message}, EvaluationContext{}); err != nil {
+		t.Fatalf("EvaluateAnswersDetailed failed: %v", err)
+	}
+
+	if client.Redactor() != nil {
+		t.Error("Expected no redactor when RedactPII is off")
+	}
+	messages := provider.ChatRequests()[0].Messages
+	if last := messages[len(messages)-1].Content; last != message {
+		t.Errorf("Expected the message to pass through untouched, got %q", last)
+	}
+	if answer := provider.EvaluationRequests()[0].Answers[0]; answer != message {
+		t.Errorf("Expected the answer to pass through untouched, got %q", answer)
+	}
+}
@@ -0,0 +1,35 @@
+// Session scoping of interviewer turns, so one session's conversation never reaches another's
+package ai
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/zidane0000/ai-interview-platform/utils"
+)
+
+// ContextNoStore flags a ChatRequest whose payload and response must never be cached or reused
+// for another request. Interviewer turns always carry it: their content belongs to one session.
+const ContextNoStore = "no_store"
+
+// aiChatTurnsWithoutSession counts interviewer turns that reached generate without a session ID
+var aiChatTurnsWithoutSession = promauto.NewCounter(prometheus.CounterOpts{
+	Namespace: "ai_interview",
+	Name:      "ai_chat_turns_without_session_total",
+	Help:      "Interviewer turns sent to the AI provider without the session ID they belong to.",
+})
+
+// chatTurnContext is the ChatRequest.Context of an interviewer turn
+func chatTurnContext() map[string]interface{} {
+	return map[string]interface{}{"task": TaskChatTurn, ContextNoStore: true}
+}
+
+// checkSessionScope reports an interviewer turn sent without its session ID
+// The call still goes ahead; the warning and counter point at the caller that lost the session.
+func checkSessionScope(req *ChatRequest) {
+	if req.Context["task"] != TaskChatTurn || req.SessionID != "" {
+		return
+	}
+	aiChatTurnsWithoutSession.Inc()
+	utils.Warningf("Interviewer turn sent to the AI provider without a session ID")
+}
@@ -0,0 +1,138 @@
+// Token usage and cost of provider calls, by provider, model and interview type
+package ai
+
+import (
+	"context"
+	"sort"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	dto "github.com/prometheus/client_model/go"
+)
+
+// Token kinds of the ai_tokens_total counter
+const (
+	tokenKindPrompt     = "prompt"
+	tokenKindCompletion = "completion"
+)
+
+// unknownInterviewType labels calls not made on behalf of an interview
+const unknownInterviewType = "unknown"
+
+// aiTokens and aiCost accumulate every provider call's usage since the process started
+// GET /api/admin/stats reads them too (see TokenUsageByInterviewType), so both views agree.
+var (
+	aiTokens = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "ai_interview",
+		Name:      "ai_tokens_total",
+		Help:      "Tokens used by AI provider calls, by provider, model, interview type and kind (prompt or completion).",
+	}, []string{"provider", "model", "interview_type", "kind"})
+	aiCost = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "ai_interview",
+		Name:      "ai_cost_usd_total",
+		Help:      "Estimated cost in USD of AI provider calls, by provider, model and interview type.",
+	}, []string{"provider", "model", "interview_type"})
+)
+
+type interviewTypeContextKey struct{}
+
+// WithInterviewType tags the provider calls made with ctx as made for an interview of this type
+func WithInterviewType(ctx context.Context, interviewType string) context.Context {
+	return context.WithValue(ctx, interviewTypeContextKey{}, interviewType)
+}
+
+// withDefaultInterviewType tags ctx with interviewType unless it is already tagged
+func withDefaultInterviewType(ctx context.Context, interviewType string) context.Context {
+	if interviewType == "" || interviewTypeFrom(ctx) != "" {
+		return ctx
+	}
+	return WithInterviewType(ctx, interviewType)
+}
+
+// interviewTypeFrom returns the interview type ctx is tagged with, or ""
+func interviewTypeFrom(ctx context.Context) string {
+	interviewType, _ := ctx.Value(interviewTypeContextKey{}).(string)
+	return interviewType
+}
+
+// recordUsage adds a provider call's tokens and estimated cost to the usage counters
+func recordUsage(ctx context.Context, provider, model string, usage TokenUsage, cost float64) {
+	interviewType := interviewTypeFrom(ctx)
+	if interviewType == "" {
+		interviewType = unknownInterviewType
+	}
+	aiTokens.WithLabelValues(provider, model, interviewType, tokenKindPrompt).Add(float64(max(usage.PromptTokens, 0)))
+	aiTokens.WithLabelValues(provider, model, interviewType, tokenKindCompletion).Add(float64(max(usage.CompletionTokens, 0)))
+	aiCost.WithLabelValues(provider, model, interviewType).Add(max(cost, 0))
+}
+
+// TokenUsageStats is the usage of one provider, model and interview type since the process started
+type TokenUsageStats struct {
+	Provider         string
+	Model            string
+	InterviewType    string // "unknown" for calls not made on behalf of an interview
+	PromptTokens     int64
+	CompletionTokens int64
+	EstimatedCostUSD float64
+}
+
+// TokenUsageByInterviewType reads the usage counters exported on /metrics, sorted by provider,
+// model and interview type
+func TokenUsageByInterviewType() []TokenUsageStats {
+	type key struct{ provider, model, interviewType string }
+	rows := make(map[key]*TokenUsageStats)
+	row := func(labels map[string]string) *TokenUsageStats {
+		k := key{labels["provider"], labels["model"], labels["interview_type"]}
+		if rows[k] == nil {
+			rows[k] = &TokenUsageStats{Provider: k.provider, Model: k.model, InterviewType: k.interviewType}
+		}
+		return rows[k]
+	}
+	collectCounters(aiTokens, func(labels map[string]string, value float64) {
+		switch labels["kind"] {
+		case tokenKindPrompt:
+			row(labels).PromptTokens += int64(value)
+		case tokenKindCompletion:
+			row(labels).CompletionTokens += int64(value)
+		}
+	})
+	collectCounters(aiCost, func(labels map[string]string, value float64) {
+		row(labels).EstimatedCostUSD += value
+	})
+
+	stats := make([]TokenUsageStats, 0, len(rows))
+	for _, s := range rows {
+		stats = append(stats, *s)
+	}
+	sort.Slice(stats, func(i, j int) bool {
+		a, b := stats[i], stats[j]
+		if a.Provider != b.Provider {
+			return a.Provider < b.Provider
+		}
+		if a.Model != b.Model {
+			return a.Model < b.Model
+		}
+		return a.InterviewType < b.InterviewType
+	})
+	return stats
+}
+
+// collectCounters calls fn with the labels and value of every counter of vec
+func collectCounters(vec *prometheus.CounterVec, fn func(labels map[string]string, value float64)) {
+	metrics := make(chan prometheus.Metric)
+	go func() {
+		vec.Collect(metrics)
+		close(metrics)
+	}()
+	for metric := range metrics {
+		var m dto.Metric
+		if err := metric.Write(&m); err != nil {
+			continue
+		}
+		labels := make(map[string]string, len(m.GetLabel()))
+		for _, label := range m.GetLabel() {
+			labels[label.GetName()] = label.GetValue()
+		}
+		fn(labels, m.GetCounter().GetValue())
+	}
+}
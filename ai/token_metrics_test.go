@@ -0,0 +1,85 @@
+package ai
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// tokenUsage returns the usage counted for the mock provider under interviewType
+func tokenUsage(interviewType string) TokenUsageStats {
+	for _, stats := range TokenUsageByInterviewType() {
+		if stats.Provider == ProviderMock && stats.Model == "mock-model" && stats.InterviewType == interviewType {
+			return stats
+		}
+	}
+	return TokenUsageStats{}
+}
+
+func TestTokenUsageMetrics(t *testing.T) {
+	client := NewAIClientWithProvider(NewMockProvider(), &AIConfig{
+		DefaultProvider: ProviderMock,
+		ModelPrices:     map[string]ModelPrice{"mock-model": {PromptPerMillion: 1000, CompletionPerMillion: 2000}},
+	})
+	ctx := context.Background()
+	technical, behavioral, unknown := tokenUsage("technical"), tokenUsage("behavioral"), tokenUsage(unknownInterviewType)
+
+	// Chat turns and evaluations count under the type they were made for
+	for range 2 {
+		if _, err := client.GenerateInterviewTurn(ctx, InterviewTurnRequest{SessionID: "s1", UserMessage: "Hello", InterviewType: "technical", Language: "en"}); err != nil {
+			t.Fatalf("GenerateInterviewTurn failed: %v", err)
+		}
+	}
+	if _, err := client.EvaluateAnswersDetailed(ctx, []string{"Q1"}, []string{"A1"}, EvaluationContext{InterviewType: "behavioral", Language: "en"}); err != nil {
+		t.Fatalf("EvaluateAnswersDetailed failed: %v", err)
+	}
+	// Calls on a tagged context count under its type; untagged calls under "unknown"
+	if _, err := client.SummarizeForContextDetailed(WithInterviewType(ctx, "behavioral"), "A long answer", "en"); err != nil {
+		t.Fatalf("SummarizeForContextDetailed failed: %v", err)
+	}
+	if _, err := client.SummarizeForContextDetailed(ctx, "A long answer", "en"); err != nil {
+		t.Fatalf("SummarizeForContextDetailed failed: %v", err)
+	}
+
+	delta := func(after, before TokenUsageStats) TokenUsageStats {
+		return TokenUsageStats{
+			PromptTokens:     after.PromptTokens - before.PromptTokens,
+			CompletionTokens: after.CompletionTokens - before.CompletionTokens,
+			EstimatedCostUSD: after.EstimatedCostUSD - before.EstimatedCostUSD,
+		}
+	}
+	if got := delta(tokenUsage("technical"), technical); got.PromptTokens != 20 || got.CompletionTokens != 40 || got.EstimatedCostUSD <= 0 {
+		t.Errorf("expected two chat turns of 10+20 tokens under technical, got %+v", got)
+	}
+	if got := delta(tokenUsage("behavioral"), behavioral); got.PromptTokens != 60 || got.CompletionTokens != 170 {
+		t.Errorf("expected an evaluation and a summary under behavioral, got %+v", got)
+	}
+	if got := delta(tokenUsage(unknownInterviewType), unknown); got.PromptTokens != 10 || got.CompletionTokens != 20 {
+		t.Errorf("expected the untagged summary under unknown, got %+v", got)
+	}
+
+	// The counters are exported with the same label split
+	families, err := prometheus.DefaultGatherer.Gather()
+	if err != nil {
+		t.Fatalf("Gather failed: %v", err)
+	}
+	kinds := make(map[string]bool)
+	for _, family := range families {
+		if family.GetName() != "ai_interview_ai_tokens_total" {
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			labels := make(map[string]string)
+			for _, label := range metric.GetLabel() {
+				labels[label.GetName()] = label.GetValue()
+			}
+			if labels["provider"] == ProviderMock && labels["interview_type"] == "technical" {
+				kinds[labels["kind"]] = true
+			}
+		}
+	}
+	if !kinds[tokenKindPrompt] || !kinds[tokenKindCompletion] {
+		t.Errorf("expected prompt and completion series for technical interviews, got %v", kinds)
+	}
+}
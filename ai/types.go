@@ -13,6 +13,21 @@ const (
 	ProviderMock   = "mock"
 )
 
+// Task types used for model selection and request tagging
+const (
+	TaskSummarization    = "summarization"
+	TaskAnswerAssessment = "answer_assessment" // Judging one answer for adaptive difficulty
+	TaskChatTurn         = "chat_turn"         // An interviewer turn; always carries its session ID
+	TaskTranslation      = "translation"       // Translating evaluation prose; carries the target language
+)
+
+// Evaluation detail levels
+const (
+	DetailLevelBrief    = "brief"    // Short feedback without recommendations
+	DetailLevelStandard = "standard" // Default evaluation format
+	DetailLevelDetailed = "detailed" // Adds commentary on each question
+)
+
 // Message represents a chat message in the conversation
 type Message struct {
 	Role      string                 `json:"role"`      // "system", "user", "assistant"
@@ -32,18 +47,21 @@ type ChatRequest struct {
 	SystemPrompt string                 `json:"system_prompt"` // System instruction
 	Context      map[string]interface{} `json:"context"`       // Additional context
 	SessionID    string                 `json:"session_id"`    // Session identifier
+	N            int                    `json:"n,omitempty"`   // Outputs to generate (OpenAI n, Gemini candidateCount); 0 or 1 means one
 }
 
 // ChatResponse represents a response from the AI
 type ChatResponse struct {
-	Content      string                 `json:"content"`       // Generated content
-	FinishReason string                 `json:"finish_reason"` // Why generation stopped
-	TokensUsed   TokenUsage             `json:"tokens_used"`   // Token consumption
-	Model        string                 `json:"model"`         // Model used
-	Provider     string                 `json:"provider"`      // Provider used
-	Metadata     map[string]interface{} `json:"metadata"`      // Additional response data
-	ResponseTime time.Duration          `json:"response_time"` // Time taken to generate
-	Timestamp    time.Time              `json:"timestamp"`     // When response was generated
+	Content          string                 `json:"content"`                // Generated content
+	Alternatives     []string               `json:"alternatives,omitempty"` // Further usable outputs when ChatRequest.N asked for several
+	FinishReason     string                 `json:"finish_reason"`          // Why generation stopped
+	TokensUsed       TokenUsage             `json:"tokens_used"`            // Token consumption
+	Model            string                 `json:"model"`                  // Model used
+	Provider         string                 `json:"provider"`               // Provider used
+	Metadata         map[string]interface{} `json:"metadata"`               // Additional response data
+	EstimatedCostUSD float64                `json:"estimated_cost_usd"`     // Estimated from TokensUsed and the model's price
+	ResponseTime     time.Duration          `json:"response_time"`          // Time taken to generate
+	Timestamp        time.Time              `json:"timestamp"`              // When response was generated
 }
 
 // TokenUsage represents token consumption metrics
@@ -55,27 +73,81 @@ type TokenUsage struct {
 
 // EvaluationRequest represents a request to evaluate interview answers
 type EvaluationRequest struct {
-	Questions   []string               `json:"questions"`    // Interview questions
-	Answers     []string               `json:"answers"`      // Candidate answers
-	JobDesc     string                 `json:"job_desc"`     // Job description (AI will extract job title from this)
-	Criteria    []string               `json:"criteria"`     // Evaluation criteria
-	Context     map[string]interface{} `json:"context"`      // Additional context
-	DetailLevel string                 `json:"detail_level"` // "brief", "detailed", "comprehensive"
-	Language    string                 `json:"language"`     // Language for evaluation ("en", "zh-TW")
+	Questions           []string               `json:"questions"`                      // Interview questions
+	Answers             []string               `json:"answers"`                        // Candidate answers
+	JobDesc             string                 `json:"job_desc"`                       // Job description (AI will extract job title from this)
+	InterviewType       string                 `json:"interview_type,omitempty"`       // "general", "technical", "behavioral"
+	ResumeContent       string                 `json:"resume_content,omitempty"`       // Candidate resume text
+	CompanyContext      string                 `json:"company_context,omitempty"`      // Company/persona context for the role
+	SessionNotes        []string               `json:"session_notes,omitempty"`        // Notable transcript events, e.g. language switches
+	ConversationSummary string                 `json:"conversation_summary,omitempty"` // Running summary of a long chat session
+	Criteria            []string               `json:"criteria"`                       // Evaluation criteria
+	Context             map[string]interface{} `json:"context"`                        // Additional context
+	DetailLevel         string                 `json:"detail_level"`                   // "brief", "standard", "detailed"; empty means standard
+	Language            string                 `json:"language"`                       // Language for evaluation ("en", "zh-TW")
+	LanguageMismatch    bool                   `json:"language_mismatch,omitempty"`    // Answers are mostly in another language than Language
+	MaxFeedbackWords    int                    `json:"max_feedback_words,omitempty"`   // Feedback length limit stated in the prompt; 0 uses the detail level's default
+	AnswerStats         *AnswerStats           `json:"answer_stats,omitempty"`         // Completeness of Answers, stated in the prompt when set
+	Params              GenerationParams       `json:"params,omitempty"`               // Model parameters; zero fields use the fixed evaluation settings
+}
+
+// EvaluationContext carries the interview details that shape an evaluation
+type EvaluationContext struct {
+	JobDescription      string           // Job description text
+	InterviewType       string           // "general", "technical", "behavioral"
+	ResumeContent       string           // Candidate resume text (optional)
+	CompanyContext      string           // Company/persona context (optional)
+	Language            string           // Language for evaluation ("en", "zh-TW")
+	SessionNotes        []string         // Notable transcript events such as language switches (optional)
+	ConversationSummary string           // Running summary of the earlier part of a long chat session (optional)
+	DetailLevel         string           // "brief", "standard", "detailed"; empty means standard
+	MaxFeedbackWords    int              // Feedback length limit in words; 0 uses the detail level's default
+	MinAnswerWords      int              // Answers shorter than this count as trivially short; 0 uses DefaultMinAnswerWords
+	Params              GenerationParams // Explicit model parameters, winning over the interview type's (optional)
 }
 
 // EvaluationResponse represents an AI evaluation result
 type EvaluationResponse struct {
-	OverallScore    float64            `json:"overall_score"`   // 0.0-1.0
-	CategoryScores  map[string]float64 `json:"category_scores"` // Scores by category
-	Feedback        string             `json:"feedback"`        // General feedback
-	Strengths       []string           `json:"strengths"`       // Identified strengths
-	Weaknesses      []string           `json:"weaknesses"`      // Areas for improvement
-	Recommendations []string           `json:"recommendations"` // Specific recommendations
-	TokensUsed      TokenUsage         `json:"tokens_used"`     // Token consumption
-	Provider        string             `json:"provider"`        // Provider used
-	Model           string             `json:"model"`           // Model used
-	Timestamp       time.Time          `json:"timestamp"`       // When evaluation was done
+	OverallScore        float64                `json:"overall_score"`                   // 0.0-1.0
+	CategoryScores      map[string]float64     `json:"category_scores"`                 // Scores by category
+	Feedback            string                 `json:"feedback"`                        // General feedback
+	Strengths           []string               `json:"strengths"`                       // Identified strengths
+	Weaknesses          []string               `json:"weaknesses"`                      // Areas for improvement
+	Recommendations     []string               `json:"recommendations"`                 // Specific recommendations
+	PerQuestionFeedback []QuestionFeedback     `json:"per_question_feedback,omitempty"` // Only at the detailed level
+	TokensUsed          TokenUsage             `json:"tokens_used"`                     // Token consumption
+	EstimatedCostUSD    float64                `json:"estimated_cost_usd"`              // Estimated from TokensUsed and the model's price
+	Metadata            map[string]interface{} `json:"metadata,omitempty"`              // Additional response data
+	Provider            string                 `json:"provider"`                        // Provider used
+	Model               string                 `json:"model"`                           // Model used
+	Timestamp           time.Time              `json:"timestamp"`                       // When evaluation was done
+	LanguageMismatch    bool                   `json:"language_mismatch,omitempty"`     // Answers were mostly in another language than the interview
+	Decision            string                 `json:"decision,omitempty"`              // One of the Decision* values; empty when the evaluator gave none
+	NextSteps           []string               `json:"next_steps,omitempty"`            // At most MaxNextSteps concrete actions for recruiters
+}
+
+// Recommendation decisions an evaluation ends with
+const (
+	DecisionStrongHire     = "strong_hire"
+	DecisionHire           = "hire"
+	DecisionNoHire         = "no_hire"
+	DecisionMoreDataNeeded = "more_data_needed"
+)
+
+// Decisions lists every recommendation decision, from strongest to weakest
+var Decisions = []string{DecisionStrongHire, DecisionHire, DecisionNoHire, DecisionMoreDataNeeded}
+
+// MaxNextSteps is the most next steps kept from an evaluation
+const MaxNextSteps = 3
+
+// MetadataDecisionWarning is set in EvaluationResponse.Metadata when the evaluator's
+// recommendation decision was not one of Decisions and was dropped
+const MetadataDecisionWarning = "decision_warning"
+
+// QuestionFeedback is the evaluator's commentary on one answered question
+type QuestionFeedback struct {
+	Question int    `json:"question"` // 1-based, matching the Q1, Q2... numbering of the prompt
+	Feedback string `json:"feedback"`
 }
 
 // QuestionGenerationRequest represents a request to generate interview questions
@@ -86,6 +158,8 @@ type QuestionGenerationRequest struct {
 	InterviewType   string                 `json:"interview_type"`   // "technical", "behavioral", "mixed"
 	NumQuestions    int                    `json:"num_questions"`    // Number of questions to generate
 	Difficulty      string                 `json:"difficulty"`       // "easy", "medium", "hard"
+	Language        string                 `json:"language"`         // Interview language the questions are written in, defaults to English
+	Alternatives    int                    `json:"alternatives"`     // Outputs asked of the provider, their questions merged without repeats; 0 or 1 means one
 	Context         map[string]interface{} `json:"context"`          // Additional context
 }
 
@@ -154,26 +228,63 @@ type AIConfig struct {
 	// Provider settings
 	DefaultProvider string `json:"default_provider"`
 	DefaultModel    string `json:"default_model"`
+	// ProviderDefaultModels maps a provider name to its own default model, consulted before DefaultModel
+	ProviderDefaultModels map[string]string `json:"provider_default_models,omitempty"`
 
 	// Request settings
-	MaxRetries       int           `json:"max_retries"`
-	RequestTimeout   time.Duration `json:"request_timeout"`
-	DefaultMaxTokens int           `json:"default_max_tokens"`
-	DefaultTemp      float64       `json:"default_temperature"`
+	MaxRetries     int           `json:"max_retries"`
+	RequestTimeout time.Duration `json:"request_timeout"`
+	// PerRequestTimeout bounds each AIClient operation, retries included; defaults to RequestTimeout
+	PerRequestTimeout time.Duration `json:"per_request_timeout,omitempty"`
+	// MaxResponseBytes caps the provider response bodies read; 0 uses DefaultMaxResponseBytes
+	MaxResponseBytes int     `json:"max_response_bytes,omitempty"`
+	DefaultMaxTokens int     `json:"default_max_tokens"`
+	DefaultTemp      float64 `json:"default_temperature"`
+	// InterviewTypeParams tunes chat turns per interview type ("behavioral", "technical", ...);
+	// evaluations take only the type's model. Explicit per-call parameters still win.
+	InterviewTypeParams map[string]GenerationParams `json:"interview_type_params,omitempty"`
 
 	// Feature flags
 	EnableCaching   bool `json:"enable_caching"`
 	EnableMetrics   bool `json:"enable_metrics"`
 	EnableStreaming bool `json:"enable_streaming"`
 
+	// Startup validation: when ValidateOnStartup is set, NewAIClient checks the credentials of
+	// every configured provider. If the default fails, EnableFallback switches to the first healthy
+	// provider, and AllowMockFallback permits the mock provider when none are healthy.
+	ValidateOnStartup bool `json:"validate_on_startup"`
+	EnableFallback    bool `json:"enable_fallback"`
+	AllowMockFallback bool `json:"allow_mock_fallback"`
+
+	// DebugCapture, when set, keeps redacted and truncated provider request/response pairs for
+	// debugging. Captured payloads are never written to the logs.
+	DebugCapture *DebugCapture `json:"-"`
+
+	// RedactPII replaces emails, phone numbers, national IDs and RedactPatterns matches with
+	// placeholders in everything sent to the provider. Stored transcripts are not affected.
+	RedactPII      bool            `json:"redact_pii"`
+	RedactPatterns []RedactPattern `json:"-"`
+
+	// Concurrency: Limiter caps the provider calls in flight across every client sharing it. Without
+	// one, a client given MaxConcurrentRequests gets its own limiter. Calls waiting longer than
+	// QueueTimeout for a slot fail with ErrOverloaded.
+	MaxConcurrentRequests int                 `json:"max_concurrent_requests,omitempty"`
+	QueueTimeout          time.Duration       `json:"queue_timeout,omitempty"`
+	Limiter               *ConcurrencyLimiter `json:"-"`
+
 	// Rate limiting
 	RateLimitRPM int `json:"rate_limit_rpm"` // Requests per minute
 	RateLimitTPM int `json:"rate_limit_tpm"` // Tokens per minute
 
 	// Costs and quotas
-	DailyTokenLimit int     `json:"daily_token_limit"`
-	CostPerToken    float64 `json:"cost_per_token"`
-	MaxCostPerDay   float64 `json:"max_cost_per_day"`
+	DailyTokenLimit int                   `json:"daily_token_limit"`
+	CostPerToken    float64               `json:"cost_per_token"` // USD per token for models without a known price
+	MaxCostPerDay   float64               `json:"max_cost_per_day"`
+	ModelPrices     map[string]ModelPrice `json:"model_prices,omitempty"` // Overrides DefaultModelPrices per model
+
+	// ModelAliases extends and overrides DefaultModelAliases: retired model names are rewritten to
+	// their replacement before calling the provider
+	ModelAliases map[string]ModelAlias `json:"model_aliases,omitempty"`
 }
 
 // InterviewContext contains context for interview-related AI operations
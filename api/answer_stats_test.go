@@ -0,0 +1,65 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/zidane0000/ai-interview-platform/ai"
+	"github.com/zidane0000/ai-interview-platform/internal/testsupport"
+)
+
+func TestSubmitEvaluation_AnswerStats(t *testing.T) {
+	provider := ai.NewMockProvider()
+	router := setupTestRouterWithProvider(provider, func(deps *HandlerDependencies) {
+		deps.MinAnswerWords = 3
+	})
+	interview := createTestInterview(t, router, testsupport.NewInterviewBuilder().WithQuestions(4))
+
+	// One thorough answer, one too short and two left out
+	body, _ := json.Marshal(SubmitEvaluationRequestDTO{
+		InterviewID: interview.ID,
+		Answers:     map[string]string{"question_0": "I would shard by tenant", "question_1": "No idea"},
+	})
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("POST", "/api/evaluation", bytes.NewReader(body)))
+	evaluation := decodeEvaluation(t, w)
+
+	expected := &AnswerStatsDTO{Questions: 4, Unanswered: 3, Empty: 2, TooShort: 1, MinWords: 3, WordCounts: []int{5, 2, 0, 0}}
+	if !reflect.DeepEqual(evaluation.AnswerStats, expected) {
+		t.Fatalf("expected %+v, got %+v", expected, evaluation.AnswerStats)
+	}
+	if prompt := provider.EvaluationRequests()[0]; prompt.AnswerStats == nil || prompt.AnswerStats.Unanswered() != 3 {
+		t.Errorf("expected the stats sent to the evaluator, got %+v", prompt.AnswerStats)
+	}
+
+	// The stats are stored with the evaluation
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/api/evaluation/"+evaluation.ID, nil))
+	if stored := decodeEvaluation(t, w); !reflect.DeepEqual(stored.AnswerStats, expected) {
+		t.Errorf("expected the stored stats, got %+v", stored.AnswerStats)
+	}
+}
+
+func TestEndChatSession_AnswerStats(t *testing.T) {
+	router := setupTestRouterWithProvider(ai.NewMockProvider(), nil)
+	interview := createTestInterview(t, router, testsupport.NewInterviewBuilder().WithQuestions(3))
+
+	// The default minimum applies; each CJK character is a word
+	session := startChatSession(t, router, testsupport.NewSessionBuilder().
+		ForInterviewID(interview.ID).
+		WithTranscript(testsupport.Pair("", "不知道")))
+	expected := &AnswerStatsDTO{Questions: 1, Unanswered: 1, TooShort: 1, MinWords: ai.DefaultMinAnswerWords, WordCounts: []int{3}}
+	if ended := endSession(t, router, session.ID); !reflect.DeepEqual(ended.AnswerStats, expected) {
+		t.Errorf("expected %+v, got %+v", expected, ended.AnswerStats)
+	}
+
+	// A session without answers is not scored and has no stats
+	other := createTestInterview(t, router, testsupport.NewInterviewBuilder().WithQuestions(3))
+	empty := startChatSession(t, router, testsupport.NewSessionBuilder().ForInterviewID(other.ID))
+	if ended := endSession(t, router, empty.ID); ended.Status != "no_answers" || ended.AnswerStats != nil {
+		t.Errorf("expected an unscored evaluation without stats, got %+v", ended)
+	}
+}
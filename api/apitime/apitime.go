@@ -0,0 +1,115 @@
+// Timestamps as they appear in the API: UTC, RFC 3339 with millisecond precision
+package apitime
+
+import (
+	"bytes"
+	"fmt"
+	"time"
+	_ "time/tzdata" // Time zones resolve on hosts without a zoneinfo database
+)
+
+// Layout is the format every API timestamp is written in, e.g. 2024-05-01T09:30:00.000Z
+const Layout = "2006-01-02T15:04:05.000Z07:00"
+
+// Time is a timestamp in a DTO; it is marshaled in UTC using Layout and accepts any RFC 3339 value,
+// which is converted to UTC
+type Time struct {
+	time.Time
+}
+
+// New wraps t, converted to UTC
+func New(t time.Time) Time {
+	return Time{Time: t.UTC()}
+}
+
+// NewPtr wraps t, converted to UTC; nil stays nil
+func NewPtr(t *time.Time) *Time {
+	if t == nil {
+		return nil
+	}
+	wrapped := New(*t)
+	return &wrapped
+}
+
+// Std returns the wrapped time; nil stays nil
+func (t *Time) Std() *time.Time {
+	if t == nil {
+		return nil
+	}
+	std := t.Time
+	return &std
+}
+
+// String formats t using Layout
+func (t Time) String() string {
+	return t.Time.UTC().Format(Layout)
+}
+
+// MarshalJSON writes t in UTC using Layout
+func (t Time) MarshalJSON() ([]byte, error) {
+	if y := t.Year(); y < 0 || y >= 10000 {
+		return nil, fmt.Errorf("apitime: year %d outside of range [0,9999]", y)
+	}
+	return []byte(`"` + t.String() + `"`), nil
+}
+
+// UnmarshalJSON reads an RFC 3339 timestamp, with or without fractional seconds, and converts it to UTC
+// null leaves t unchanged
+func (t *Time) UnmarshalJSON(b []byte) error {
+	if bytes.Equal(b, []byte("null")) {
+		return nil
+	}
+	if len(b) < 2 || b[0] != '"' || b[len(b)-1] != '"' {
+		return fmt.Errorf("apitime: timestamp must be an RFC 3339 string, got %s", b)
+	}
+	parsed, err := time.Parse(time.RFC3339Nano, string(b[1:len(b)-1]))
+	if err != nil {
+		return fmt.Errorf("apitime: timestamp must be RFC 3339, e.g. 2024-05-01T09:30:00Z: %w", err)
+	}
+	t.Time = parsed.UTC()
+	return nil
+}
+
+// ParseQuery parses a query parameter holding either an RFC 3339 timestamp or a YYYY-MM-DD date
+// A date is midnight UTC; a timestamp is converted to UTC
+func ParseQuery(value string) (time.Time, error) {
+	if parsed, err := time.Parse(time.RFC3339Nano, value); err == nil {
+		return parsed.UTC(), nil
+	}
+	parsed, err := time.Parse(time.DateOnly, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("apitime: %q is neither an RFC 3339 timestamp nor a YYYY-MM-DD date", value)
+	}
+	return parsed, nil
+}
+
+// LoadLocation resolves an IANA time zone name such as "Asia/Taipei"; an empty name is UTC
+// "Local" is refused, since the server's zone means nothing to API clients.
+func LoadLocation(name string) (*time.Location, error) {
+	if name == "Local" {
+		return nil, fmt.Errorf("apitime: %q is not an IANA time zone", name)
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("apitime: unknown time zone %q", name)
+	}
+	return loc, nil
+}
+
+// ParseQueryBound parses a query parameter bounding a range like ParseQuery, taking a YYYY-MM-DD
+// date in loc: the start of the day, or with end the last instant of the day, so the bound
+// includes the whole day. The result is in UTC.
+func ParseQueryBound(value string, loc *time.Location, end bool) (time.Time, error) {
+	if parsed, err := time.Parse(time.RFC3339Nano, value); err == nil {
+		return parsed.UTC(), nil
+	}
+	parsed, err := time.ParseInLocation(time.DateOnly, value, loc)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("apitime: %q is neither an RFC 3339 timestamp nor a YYYY-MM-DD date", value)
+	}
+	if end {
+		// The database keeps microseconds, so the day's last instant is the microsecond before midnight
+		parsed = parsed.AddDate(0, 0, 1).Add(-time.Microsecond)
+	}
+	return parsed.UTC(), nil
+}
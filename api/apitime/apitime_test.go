@@ -0,0 +1,176 @@
+package apitime
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestTime_MarshalJSON(t *testing.T) {
+	taipei := time.FixedZone("UTC+8", 8*60*60)
+	tests := []struct {
+		name string
+		time time.Time
+		want string
+	}{
+		{"utc", time.Date(2024, 5, 1, 9, 30, 0, 0, time.UTC), `"2024-05-01T09:30:00.000Z"`},
+		{"offset converted to utc", time.Date(2024, 5, 1, 17, 30, 0, 0, taipei), `"2024-05-01T09:30:00.000Z"`},
+		{"truncated to milliseconds", time.Date(2024, 5, 1, 9, 30, 0, 123456789, time.UTC), `"2024-05-01T09:30:00.123Z"`},
+		{"zero", time.Time{}, `"0001-01-01T00:00:00.000Z"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := json.Marshal(New(tt.time))
+			if err != nil {
+				t.Fatalf("Marshal failed: %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("expected %s, got %s", tt.want, got)
+			}
+		})
+	}
+
+	// Wrapping in place of a time.Time value keeps the same layout
+	got, err := json.Marshal(Time{Time: time.Date(2024, 5, 1, 17, 30, 0, 0, taipei)})
+	if err != nil || string(got) != `"2024-05-01T09:30:00.000Z"` {
+		t.Errorf("expected the unconverted value to be written in UTC, got %s (%v)", got, err)
+	}
+}
+
+func TestTime_UnmarshalJSON(t *testing.T) {
+	want := time.Date(2024, 5, 1, 9, 30, 0, 0, time.UTC)
+	for _, input := range []string{
+		`"2024-05-01T09:30:00Z"`,
+		`"2024-05-01T09:30:00.000Z"`,
+		`"2024-05-01T17:30:00+08:00"`,
+		`"2024-05-01T05:30:00.000-04:00"`,
+	} {
+		var got Time
+		if err := json.Unmarshal([]byte(input), &got); err != nil {
+			t.Errorf("Unmarshal(%s) failed: %v", input, err)
+			continue
+		}
+		if !got.Equal(want) || got.Location() != time.UTC {
+			t.Errorf("Unmarshal(%s): expected %v in UTC, got %v", input, want, got.Time)
+		}
+	}
+
+	for _, input := range []string{`"2024-05-01"`, `"2024-05-01 09:30:00"`, `1714555800`, `"yesterday"`} {
+		var got Time
+		if err := json.Unmarshal([]byte(input), &got); err == nil {
+			t.Errorf("expected Unmarshal(%s) to fail, got %v", input, got.Time)
+		}
+	}
+
+	// null keeps pointer fields nil and value fields unchanged
+	var body struct {
+		At  *Time `json:"at"`
+		Not Time  `json:"not"`
+	}
+	if err := json.Unmarshal([]byte(`{"at":null,"not":null}`), &body); err != nil {
+		t.Fatalf("Unmarshal of null failed: %v", err)
+	}
+	if body.At != nil || !body.Not.IsZero() {
+		t.Errorf("expected null to leave the fields unset, got %+v", body)
+	}
+}
+
+func TestTime_RoundTrip(t *testing.T) {
+	original := New(time.Date(2024, 5, 1, 9, 30, 0, 123000000, time.FixedZone("UTC-4", -4*60*60)))
+	encoded, err := json.Marshal(original)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	var decoded Time
+	if err := json.Unmarshal(encoded, &decoded); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if decoded != original {
+		t.Errorf("expected %v after a round trip, got %v", original, decoded)
+	}
+}
+
+func TestNewPtrAndStd(t *testing.T) {
+	if NewPtr(nil) != nil {
+		t.Error("expected NewPtr(nil) to be nil")
+	}
+	var missing *Time
+	if missing.Std() != nil {
+		t.Error("expected Std of a nil *Time to be nil")
+	}
+	local := time.Date(2024, 5, 1, 17, 30, 0, 0, time.FixedZone("UTC+8", 8*60*60))
+	wrapped := NewPtr(&local)
+	if std := wrapped.Std(); std == nil || !std.Equal(local) || std.Location() != time.UTC {
+		t.Errorf("expected %v in UTC, got %v", local, std)
+	}
+}
+
+func TestParseQuery(t *testing.T) {
+	tests := []struct {
+		value string
+		want  time.Time
+	}{
+		{"2024-05-01", time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)},
+		{"2024-05-01T09:30:00Z", time.Date(2024, 5, 1, 9, 30, 0, 0, time.UTC)},
+		{"2024-05-01T17:30:00.5+08:00", time.Date(2024, 5, 1, 9, 30, 0, 500000000, time.UTC)},
+	}
+	for _, tt := range tests {
+		got, err := ParseQuery(tt.value)
+		if err != nil {
+			t.Errorf("ParseQuery(%q) failed: %v", tt.value, err)
+			continue
+		}
+		if !got.Equal(tt.want) || got.Location() != time.UTC {
+			t.Errorf("ParseQuery(%q): expected %v, got %v", tt.value, tt.want, got)
+		}
+	}
+	for _, value := range []string{"05/01/2024", "2024-05-01T09:30", "soon"} {
+		if _, err := ParseQuery(value); err == nil {
+			t.Errorf("expected ParseQuery(%q) to fail", value)
+		}
+	}
+}
+
+func TestParseQueryBound(t *testing.T) {
+	taipei, err := LoadLocation("Asia/Taipei")
+	if err != nil {
+		t.Fatalf("LoadLocation failed: %v", err)
+	}
+	newYork, err := LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatalf("LoadLocation failed: %v", err)
+	}
+	tests := []struct {
+		name  string
+		value string
+		loc   *time.Location
+		end   bool
+		want  time.Time
+	}{
+		{"utc start", "2024-05-01", time.UTC, false, time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)},
+		{"utc end", "2024-05-01", time.UTC, true, time.Date(2024, 5, 1, 23, 59, 59, 999999000, time.UTC)},
+		{"taipei start", "2024-05-01", taipei, false, time.Date(2024, 4, 30, 16, 0, 0, 0, time.UTC)},
+		{"taipei end", "2024-05-01", taipei, true, time.Date(2024, 5, 1, 15, 59, 59, 999999000, time.UTC)},
+		{"new york start", "2024-01-15", newYork, false, time.Date(2024, 1, 15, 5, 0, 0, 0, time.UTC)},
+		{"new york end", "2024-01-15", newYork, true, time.Date(2024, 1, 16, 4, 59, 59, 999999000, time.UTC)},
+		{"daylight saving day is 23 hours", "2024-03-10", newYork, true, time.Date(2024, 3, 11, 3, 59, 59, 999999000, time.UTC)},
+		{"timestamps keep their offset", "2024-05-01T09:30:00Z", taipei, true, time.Date(2024, 5, 1, 9, 30, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseQueryBound(tt.value, tt.loc, tt.end)
+			if err != nil {
+				t.Fatalf("ParseQueryBound(%q) failed: %v", tt.value, err)
+			}
+			if !got.Equal(tt.want) || got.Location() != time.UTC {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+
+	for _, name := range []string{"Local", "Mars/Olympus_Mons"} {
+		if _, err := LoadLocation(name); err == nil {
+			t.Errorf("expected LoadLocation(%q) to fail", name)
+		}
+	}
+}
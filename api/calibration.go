@@ -0,0 +1,349 @@
+// Evaluation score calibration across AI providers and models
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/zidane0000/ai-interview-platform/ai"
+	"github.com/zidane0000/ai-interview-platform/api/apitime"
+	"github.com/zidane0000/ai-interview-platform/data"
+	"github.com/zidane0000/ai-interview-platform/utils"
+)
+
+// Size limits of one calibration run; every session is evaluated once per model
+const (
+	maxCalibrationSessions = 50
+	maxCalibrationModels   = 5
+)
+
+// CalibrateEvaluationsHandler handles POST /admin/evaluations/calibrate
+// Evaluates every listed session with every listed model, with up to BackfillWorkers evaluations in
+// flight, and reports the overall scores as a matrix with per-model statistics against the first
+// (baseline) model. No evaluation is stored or changed; persist saves the run itself so it can be
+// fetched again from GET /admin/evaluations/calibrations/{id}.
+func (deps *HandlerDependencies) CalibrateEvaluationsHandler(w http.ResponseWriter, r *http.Request) {
+	var req CalibrateEvaluationsRequestDTO
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSONError(w, http.StatusBadRequest, ErrCodeInvalidJSON, "Invalid JSON", err.Error())
+		return
+	}
+	if problems := validateCalibrationRequest(&req, deps.ModelAliases); len(problems) > 0 {
+		writeJSONError(w, http.StatusBadRequest, ErrCodeValidationFailed, "Invalid calibration request", problems...)
+		return
+	}
+
+	store := deps.Store.WithContext(r.Context())
+	sessions := make([]*data.ChatSession, len(req.SessionIDs))
+	var missing []string
+	for i, id := range req.SessionIDs {
+		session, err := store.GetChatSession(id)
+		if err != nil {
+			missing = append(missing, id)
+			continue
+		}
+		sessions[i] = session
+	}
+	if len(missing) > 0 {
+		writeJSONError(w, http.StatusNotFound, ErrCodeNotFound, ErrMsgSessionNotFound, strings.Join(missing, ", "))
+		return
+	}
+
+	run := &data.CalibrationRun{ID: data.GenerateID(), SessionIDs: req.SessionIDs}
+	for _, model := range req.Models {
+		run.Models = append(run.Models, data.CalibrationModel{Provider: model.Provider, Model: model.Model})
+	}
+	run.Scores = deps.runCalibration(r, store, sessions, run.Models)
+
+	if !req.Persist {
+		resp := toCalibrationRunResponseDTO(run)
+		resp.ID = ""
+		deps.writeCalibrationRun(w, r, store, http.StatusOK, resp)
+		return
+	}
+	if err := store.CreateCalibrationRun(run); err != nil {
+		writeJSONError(w, http.StatusInternalServerError, ErrCodeInternal, "Failed to save calibration run", err.Error())
+		return
+	}
+	resp := toCalibrationRunResponseDTO(run)
+	resp.CreatedAt = apitime.NewPtr(&run.CreatedAt)
+	deps.writeCalibrationRun(w, r, store, http.StatusCreated, resp)
+}
+
+// GetCalibrationRunHandler handles GET /admin/evaluations/calibrations/{id}
+func (deps *HandlerDependencies) GetCalibrationRunHandler(w http.ResponseWriter, r *http.Request) {
+	run, err := deps.Store.WithContext(r.Context()).GetCalibrationRun(chi.URLParam(r, "id"))
+	if err != nil {
+		writeJSONError(w, http.StatusNotFound, ErrCodeNotFound, "Calibration run not found")
+		return
+	}
+	resp := toCalibrationRunResponseDTO(run)
+	resp.CreatedAt = apitime.NewPtr(&run.CreatedAt)
+	deps.writeCalibrationRun(w, r, deps.Store.WithContext(r.Context()), http.StatusOK, resp)
+}
+
+// writeCalibrationRun writes a calibration run response, ranking its scores by percentile first
+// when ?include=percentile asks for it
+func (deps *HandlerDependencies) writeCalibrationRun(w http.ResponseWriter, r *http.Request, store data.Store, status int, resp CalibrationRunResponseDTO) {
+	if includeRequested(r, "percentile") {
+		if err := deps.rankCalibrationScores(store, &resp); err != nil {
+			writeJSONError(w, http.StatusInternalServerError, ErrCodeInternal, "Failed to rank calibration scores")
+			return
+		}
+	}
+	writeJSON(w, status, resp)
+}
+
+// rankCalibrationScores sets the percentile of every score in resp within the cohort of the model
+// and the session's interview type, so models that score on different scales can be compared.
+// Sessions whose interview is gone are left unranked; a too small cohort adds one warning.
+func (deps *HandlerDependencies) rankCalibrationScores(store data.Store, resp *CalibrationRunResponseDTO) error {
+	ranker := deps.newCohortRanker(store)
+	var warnings warningList
+	warned := make(map[[2]string]bool)
+	for i := range resp.Sessions {
+		session, err := store.GetChatSession(resp.Sessions[i].SessionID)
+		if err != nil {
+			continue
+		}
+		interview, err := store.GetInterview(session.InterviewID)
+		if err != nil {
+			continue
+		}
+		for j := range resp.Sessions[i].Scores {
+			score := &resp.Sessions[i].Scores[j]
+			if score.Score == nil {
+				continue
+			}
+			percentile, size, err := ranker.rank(interview.InterviewType, score.Model, *score.Score)
+			if err != nil {
+				return err
+			}
+			score.Percentile = percentile
+			if key := [2]string{interview.InterviewType, score.Model}; percentile == nil && !warned[key] {
+				warned[key] = true
+				warnings.add(WarnCodeSmallCohort, "percentile",
+					fmt.Sprintf("%s (%s interviews): %s", score.Model, interview.InterviewType, smallCohortMessage(size)))
+			}
+		}
+	}
+	resp.Warnings = warnings
+	return nil
+}
+
+// validateCalibrationRequest lists what is wrong with a calibration request
+func validateCalibrationRequest(req *CalibrateEvaluationsRequestDTO, aliases map[string]ai.ModelAlias) []string {
+	var problems []string
+	switch {
+	case len(req.SessionIDs) == 0:
+		problems = append(problems, "session_ids is required")
+	case len(req.SessionIDs) > maxCalibrationSessions:
+		problems = append(problems, fmt.Sprintf("session_ids must list at most %d sessions", maxCalibrationSessions))
+	}
+	seenSessions := make(map[string]bool)
+	for _, id := range req.SessionIDs {
+		if seenSessions[id] {
+			problems = append(problems, fmt.Sprintf("session %q is listed more than once", id))
+		}
+		seenSessions[id] = true
+	}
+
+	switch {
+	case len(req.Models) == 0:
+		problems = append(problems, "models is required")
+	case len(req.Models) > maxCalibrationModels:
+		problems = append(problems, fmt.Sprintf("models must list at most %d models", maxCalibrationModels))
+	}
+	seenModels := make(map[data.CalibrationModel]bool)
+	for _, model := range req.Models {
+		if model.Provider == "" || model.Model == "" {
+			problems = append(problems, "every model needs a provider and a model")
+			continue
+		}
+		if err := ai.ValidateModel(model.Provider+"/"+model.Model, aliases); err != nil {
+			problems = append(problems, err.Error())
+		}
+		key := data.CalibrationModel{Provider: model.Provider, Model: model.Model}
+		if seenModels[key] {
+			problems = append(problems, fmt.Sprintf("model %s/%s is listed more than once", model.Provider, model.Model))
+		}
+		seenModels[key] = true
+	}
+	return problems
+}
+
+// calibrationJob is the evaluation of one session by one model; score is where its result goes
+type calibrationJob struct {
+	input *sessionEvaluationInput
+	score *data.CalibrationScore
+}
+
+// runCalibration scores every session with every model, session by session in models order
+// Each session's transcript is loaded once, so every model is given exactly the same input.
+func (deps *HandlerDependencies) runCalibration(r *http.Request, store data.Store, sessions []*data.ChatSession, models []data.CalibrationModel) data.CalibrationScoreList {
+	requestID := middleware.GetReqID(r.Context())
+	// Jobs point into scores, so it is allocated at its final size up front
+	scores := make(data.CalibrationScoreList, 0, len(sessions)*len(models))
+	var jobs []calibrationJob
+	for _, session := range sessions {
+		input, err := deps.calibrationInput(store, session)
+		for _, model := range models {
+			scores = append(scores, data.CalibrationScore{SessionID: session.ID, Provider: model.Provider, Model: model.Model})
+			if err != nil {
+				scores[len(scores)-1].Error = err.Error()
+				continue
+			}
+			jobs = append(jobs, calibrationJob{input: input, score: &scores[len(scores)-1]})
+		}
+	}
+	utils.Infof("[%s] Evaluation calibration: %d sessions, %d models, %d evaluations", requestID, len(sessions), len(models), len(jobs))
+
+	queue := make(chan calibrationJob)
+	var wg sync.WaitGroup
+	for range min(deps.BackfillWorkers, len(jobs)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for job := range queue {
+				deps.calibrateSession(r, job)
+			}
+		}()
+	}
+	for _, job := range jobs {
+		queue <- job
+	}
+	close(queue)
+	wg.Wait()
+	return scores
+}
+
+// calibrationInput loads what a session's evaluation sends to the provider
+// Job descriptions are never summarized for calibration: a summary written by one of the compared
+// models would change the input of the others, so long ones use the cached summary or are truncated.
+func (deps *HandlerDependencies) calibrationInput(store data.Store, session *data.ChatSession) (*sessionEvaluationInput, error) {
+	input, err := deps.loadSessionEvaluationInput(store, session, "")
+	if err != nil {
+		var failure *sessionEvaluationError
+		if errors.As(err, &failure) {
+			return nil, errors.New(failure.message)
+		}
+		return nil, err
+	}
+	if len(input.answers) == 0 {
+		return nil, errors.New("session has no candidate answers")
+	}
+	if interview := input.interview; deps.JobDescriptionLimits.NeedsSummary(interview.JobDescription) {
+		input.evalCtx.JobDescription = interview.JobDescSummary
+		if input.evalCtx.JobDescription == "" {
+			input.evalCtx.JobDescription = deps.JobDescriptionLimits.Truncate(interview.JobDescription)
+		}
+	}
+	return input, nil
+}
+
+// calibrateSession evaluates one session with one model within BackfillSessionTimeout
+func (deps *HandlerDependencies) calibrateSession(r *http.Request, job calibrationJob) {
+	ctx, cancel := context.WithTimeout(r.Context(), deps.BackfillSessionTimeout)
+	defer cancel()
+
+	aiClient, err := deps.newModelAIClient(r, job.score.Provider, job.score.Model)
+	if err != nil {
+		job.score.Error = "Failed to create AI client: " + err.Error()
+		return
+	}
+	result, err := aiClient.EvaluateAnswersDetailed(ctx, job.input.questions, job.input.answers, job.input.evalCtx)
+	if err != nil {
+		utils.Errorf("Evaluation calibration failed for session %s with %s/%s: %v", job.score.SessionID, job.score.Provider, job.score.Model, err)
+		if errors.Is(err, context.DeadlineExceeded) {
+			job.score.Error = fmt.Sprintf("timed out after %s", deps.BackfillSessionTimeout)
+		} else {
+			job.score.Error = "Failed to generate evaluation"
+		}
+		return
+	}
+	score := result.OverallScore
+	job.score.Score = &score
+}
+
+// toCalibrationRunResponseDTO lays a run's scores out as a session by model matrix with per-model stats
+func toCalibrationRunResponseDTO(run *data.CalibrationRun) CalibrationRunResponseDTO {
+	resp := CalibrationRunResponseDTO{
+		ID:       run.ID,
+		Models:   make([]CalibrationModelDTO, len(run.Models)),
+		Sessions: make([]CalibrationSessionDTO, len(run.SessionIDs)),
+		Stats:    calibrationStats(run),
+	}
+	for i, model := range run.Models {
+		resp.Models[i] = CalibrationModelDTO{Provider: model.Provider, Model: model.Model}
+	}
+	for i, sessionID := range run.SessionIDs {
+		resp.Sessions[i] = CalibrationSessionDTO{SessionID: sessionID, Scores: make([]CalibrationScoreDTO, len(run.Models))}
+		for j, model := range run.Models {
+			resp.Sessions[i].Scores[j] = CalibrationScoreDTO{Provider: model.Provider, Model: model.Model}
+			if score := findCalibrationScore(run.Scores, sessionID, model); score != nil {
+				resp.Sessions[i].Scores[j].Score = score.Score
+				resp.Sessions[i].Scores[j].Error = score.Error
+			}
+		}
+	}
+	return resp
+}
+
+// findCalibrationScore returns the score model gave sessionID, or nil when there is none
+func findCalibrationScore(scores data.CalibrationScoreList, sessionID string, model data.CalibrationModel) *data.CalibrationScore {
+	for i := range scores {
+		if scores[i].SessionID == sessionID && scores[i].Provider == model.Provider && scores[i].Model == model.Model {
+			return &scores[i]
+		}
+	}
+	return nil
+}
+
+// calibrationStats computes each model's mean and standard deviation (population) of its scores,
+// and of its score minus the baseline's over the sessions both models scored
+func calibrationStats(run *data.CalibrationRun) []CalibrationModelStatsDTO {
+	stats := make([]CalibrationModelStatsDTO, len(run.Models))
+	for i, model := range run.Models {
+		stats[i] = CalibrationModelStatsDTO{Provider: model.Provider, Model: model.Model}
+		var scores, differences []float64
+		for _, sessionID := range run.SessionIDs {
+			score := findCalibrationScore(run.Scores, sessionID, model)
+			if score == nil || score.Score == nil {
+				stats[i].Failed++
+				continue
+			}
+			scores = append(scores, *score.Score)
+			if baseline := findCalibrationScore(run.Scores, sessionID, run.Models[0]); baseline != nil && baseline.Score != nil {
+				differences = append(differences, *score.Score-*baseline.Score)
+			}
+		}
+		stats[i].Scored = len(scores)
+		stats[i].MeanScore, stats[i].ScoreStdDev = meanAndStdDev(scores)
+		stats[i].MeanDifference, stats[i].DifferenceStdDev = meanAndStdDev(differences)
+	}
+	return stats
+}
+
+// meanAndStdDev returns the mean and population standard deviation of values, or zeros when empty
+func meanAndStdDev(values []float64) (mean, stddev float64) {
+	if len(values) == 0 {
+		return 0, 0
+	}
+	for _, v := range values {
+		mean += v
+	}
+	mean /= float64(len(values))
+	var variance float64
+	for _, v := range values {
+		variance += (v - mean) * (v - mean)
+	}
+	return mean, math.Sqrt(variance / float64(len(values)))
+}
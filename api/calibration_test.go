@@ -0,0 +1,154 @@
+package api
+
+import (
+	"encoding/json"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/zidane0000/ai-interview-platform/ai"
+	"github.com/zidane0000/ai-interview-platform/internal/testsupport"
+)
+
+// setupCalibrationRouter returns an admin router whose models score sessions from the given scripts,
+// keyed by "provider/model"; one worker keeps each model's scores in session order
+func setupCalibrationRouter(scripts map[string][]float64) *testRouter {
+	providers := make(map[string]*ai.MockProvider)
+	for model, scores := range scripts {
+		providers[model] = ai.NewMockProvider()
+		providers[model].SetScores(scores...)
+	}
+	return setupTestRouterWithProvider(ai.NewMockProvider(), func(deps *HandlerDependencies) {
+		deps.AdminToken = "admin-secret"
+		deps.BackfillWorkers = 1
+		deps.newModelAIClient = func(r *http.Request, provider, model string) (*ai.AIClient, error) {
+			return ai.NewAIClientWithProvider(providers[provider+"/"+model], nil), nil
+		}
+	})
+}
+
+// adminRequest sends an admin-authenticated request and returns the recorder
+func adminRequest(router http.Handler, method, path, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set("Authorization", "Bearer admin-secret")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestCalibrateEvaluations_ScoreMatrixAndStats(t *testing.T) {
+	router := setupCalibrationRouter(map[string][]float64{
+		"openai/gpt-4o":           {0.6, 0.7, 0.8},
+		"gemini/gemini-1.5-flash": {0.7, 0.9, 0.8},
+	})
+	var sessionIDs []string
+	for _, id := range []string{"calibrate-1", "calibrate-2", "calibrate-3"} {
+		interview := testsupport.NewInterviewBuilder().WithCandidate("Calibration Candidate").Create(t, router.store)
+		testsupport.NewSessionBuilder().ForInterview(interview).WithID(id).
+			WithTranscript(testsupport.Pair("What is a goroutine?", "A lightweight thread")).Create(t, router.store)
+		sessionIDs = append(sessionIDs, `"`+id+`"`)
+	}
+	silent := testsupport.NewInterviewBuilder().WithCandidate("Silent Candidate").Create(t, router.store)
+	testsupport.NewSessionBuilder().ForInterview(silent).WithID("calibrate-silent").Create(t, router.store)
+	sessionIDs = append(sessionIDs, `"calibrate-silent"`)
+
+	body := `{"session_ids":[` + strings.Join(sessionIDs, ",") + `],` +
+		`"models":[{"provider":"openai","model":"gpt-4o"},{"provider":"gemini","model":"gemini-1.5-flash"}],"persist":true}`
+	w := adminRequest(router, "POST", "/api/admin/evaluations/calibrate", body)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", w.Code, w.Body.String())
+	}
+	var resp CalibrationRunResponseDTO
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+
+	if len(resp.Sessions) != 4 || resp.ID == "" || resp.CreatedAt == nil {
+		t.Fatalf("expected a persisted run with 4 sessions, got %+v", resp)
+	}
+	for i, expected := range [][2]float64{{0.6, 0.7}, {0.7, 0.9}, {0.8, 0.8}} {
+		row := resp.Sessions[i]
+		if row.Scores[0].Score == nil || *row.Scores[0].Score != expected[0] || row.Scores[1].Score == nil || *row.Scores[1].Score != expected[1] {
+			t.Errorf("session %s: expected scores %v, got %+v", row.SessionID, expected, row.Scores)
+		}
+	}
+	if silentRow := resp.Sessions[3]; silentRow.Scores[0].Score != nil || silentRow.Scores[0].Error != "session has no candidate answers" {
+		t.Errorf("expected the session without answers to have no score, got %+v", silentRow.Scores)
+	}
+
+	// Both models spread by sqrt(0.02/3); gemini runs 0.1 above the baseline on average
+	spread := math.Sqrt(0.02 / 3)
+	near := func(got, want float64) bool { return math.Abs(got-want) < 1e-9 }
+	baseline, gemini := resp.Stats[0], resp.Stats[1]
+	if baseline.Scored != 3 || baseline.Failed != 1 || !near(baseline.MeanScore, 0.7) || !near(baseline.ScoreStdDev, spread) ||
+		baseline.MeanDifference != 0 || baseline.DifferenceStdDev != 0 {
+		t.Errorf("unexpected baseline stats %+v", baseline)
+	}
+	if gemini.Scored != 3 || !near(gemini.MeanScore, 0.8) || !near(gemini.ScoreStdDev, spread) ||
+		!near(gemini.MeanDifference, 0.1) || !near(gemini.DifferenceStdDev, spread) {
+		t.Errorf("unexpected gemini stats %+v", gemini)
+	}
+
+	// Calibration leaves stored evaluations alone
+	for _, id := range []string{"calibrate-1", "calibrate-2", "calibrate-3"} {
+		session, _ := router.store.GetChatSession(id)
+		if evaluation, err := router.store.GetLatestEvaluationByInterview(session.InterviewID); err == nil {
+			t.Errorf("expected no evaluation for session %s, got %s", id, evaluation.ID)
+		}
+	}
+
+	// The persisted run reads back with the same matrix and stats
+	w = adminRequest(router, "GET", "/api/admin/evaluations/calibrations/"+resp.ID, "")
+	var stored CalibrationRunResponseDTO
+	if err := json.Unmarshal(w.Body.Bytes(), &stored); err != nil {
+		t.Fatalf("failed to unmarshal stored run: %v", err)
+	}
+	if w.Code != http.StatusOK || stored.ID != resp.ID || len(stored.Sessions) != 4 || !near(stored.Stats[1].MeanDifference, 0.1) {
+		t.Errorf("expected the stored run to match, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestCalibrateEvaluations_NotPersistedByDefault(t *testing.T) {
+	router := setupCalibrationRouter(map[string][]float64{"mock/mock-model": {0.5}})
+	interview := testsupport.NewInterviewBuilder().Create(t, router.store)
+	testsupport.NewSessionBuilder().ForInterview(interview).WithID("calibrate-once").
+		WithTranscript(testsupport.Pair("Why Go?", "Simplicity")).Create(t, router.store)
+
+	w := adminRequest(router, "POST", "/api/admin/evaluations/calibrate",
+		`{"session_ids":["calibrate-once"],"models":[{"provider":"mock","model":"mock-model"}]}`)
+	var resp CalibrationRunResponseDTO
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+	if w.Code != http.StatusOK || resp.ID != "" || resp.Stats[0].MeanScore != 0.5 {
+		t.Errorf("expected an unsaved run scoring 0.5, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestCalibrateEvaluations_Validation(t *testing.T) {
+	router := setupCalibrationRouter(nil)
+	interview := testsupport.NewInterviewBuilder().Create(t, router.store)
+	testsupport.NewSessionBuilder().ForInterview(interview).WithID("calibrate-valid").Create(t, router.store)
+	model := `"models":[{"provider":"mock","model":"mock-model"}]`
+
+	for _, body := range []string{
+		`{` + model + `}`,
+		`{"session_ids":["calibrate-valid"]}`,
+		`{"session_ids":["calibrate-valid","calibrate-valid"],` + model + `}`,
+		`{"session_ids":["calibrate-valid"],"models":[{"provider":"openai","model":"gpt-9000"}]}`,
+		`{"session_ids":["calibrate-valid"],"models":[{"provider":"mock"}]}`,
+	} {
+		if w := adminRequest(router, "POST", "/api/admin/evaluations/calibrate", body); w.Code != http.StatusBadRequest {
+			t.Errorf("expected 400 for %s, got %d", body, w.Code)
+		}
+	}
+	if w := adminRequest(router, "POST", "/api/admin/evaluations/calibrate", `{"session_ids":["missing"],`+model+`}`); w.Code != http.StatusNotFound {
+		t.Errorf("expected 404 for an unknown session, got %d", w.Code)
+	}
+	if w := adminRequest(router, "GET", "/api/admin/evaluations/calibrations/missing", ""); w.Code != http.StatusNotFound {
+		t.Errorf("expected 404 for an unknown run, got %d", w.Code)
+	}
+	assertErrorResponse(t, router, "POST", "/api/admin/evaluations/calibrate", `{"session_ids":["calibrate-valid"],`+model+`}`, http.StatusUnauthorized, ErrCodeUnauthorized)
+}
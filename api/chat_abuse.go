@@ -0,0 +1,105 @@
+// Anti-abuse checks on candidate chat messages, run before the message costs an AI call
+package api
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/zidane0000/ai-interview-platform/data"
+	"github.com/zidane0000/ai-interview-platform/utils"
+)
+
+// duplicateMessageWindow is how many of the candidate's latest messages a new one is compared with
+const duplicateMessageWindow = 3
+
+// refuseAbusiveMessage checks content against the session's anti-abuse limits: the minimum interval
+// since the candidate's previous message, the hourly message cap and near-duplicates of their latest
+// messages. Admin callers are never checked; a tenant API key doesn't exempt a caller, since with
+// multi-tenancy on candidates send it too. A refusal is counted in the metrics and on
+// the session, its error response is written and true is returned.
+func (deps *HandlerDependencies) refuseAbusiveMessage(w http.ResponseWriter, r *http.Request, store data.Store, session *data.ChatSession, content string) bool {
+	if deps.MinMessageInterval <= 0 && deps.MaxMessagesPerSessionHour <= 0 && deps.DuplicateMessageSimilarity <= 0 {
+		return false
+	}
+	if validAdminToken(r, deps.AdminToken) {
+		return false
+	}
+	messages, err := store.GetChatMessages(session.ID)
+	if err != nil {
+		// The checks are best effort; the message goes through rather than failing on them
+		utils.Errorf("Failed to load messages of session %s for the anti-abuse checks: %v", session.ID, err)
+		return false
+	}
+	var sent []*data.ChatMessage
+	for _, msg := range messages {
+		if msg.Type == "user" {
+			sent = append(sent, msg)
+		}
+	}
+	if len(sent) == 0 {
+		return false
+	}
+
+	now := deps.now()
+	refuse := func(status int, code ErrorCode, retryAfter time.Duration, message string) bool {
+		chatAbuseRejections.WithLabelValues(string(code)).Inc()
+		if err := store.RecordChatSessionAbuse(session.ID, string(code)); err != nil {
+			utils.Errorf("Failed to record %s on session %s: %v", code, session.ID, err)
+		}
+		if retryAfter > 0 {
+			w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+		}
+		writeJSONError(w, status, code, message)
+		return true
+	}
+
+	last := sent[len(sent)-1]
+	if deps.MinMessageInterval > 0 {
+		if wait := last.Timestamp.Add(deps.MinMessageInterval).Sub(now); wait > 0 {
+			return refuse(http.StatusTooManyRequests, ErrCodeMessageTooSoon, wait,
+				"Message sent too soon after the previous one; wait "+deps.MinMessageInterval.String()+" between messages")
+		}
+	}
+	if deps.MaxMessagesPerSessionHour > 0 {
+		hourAgo := now.Add(-time.Hour)
+		var recent []*data.ChatMessage
+		for _, msg := range sent {
+			if msg.Timestamp.After(hourAgo) {
+				recent = append(recent, msg)
+			}
+		}
+		if len(recent) >= deps.MaxMessagesPerSessionHour {
+			// A slot frees up once the oldest message counted falls out of the hour
+			wait := recent[len(recent)-deps.MaxMessagesPerSessionHour].Timestamp.Sub(hourAgo)
+			return refuse(http.StatusTooManyRequests, ErrCodeSessionHourlyCap, wait,
+				"Chat session reached its limit of "+strconv.Itoa(deps.MaxMessagesPerSessionHour)+" messages per hour")
+		}
+	}
+	if deps.DuplicateMessageSimilarity > 0 {
+		for _, msg := range sent[max(len(sent)-duplicateMessageWindow, 0):] {
+			if nearDuplicate(content, msg.Content, deps.DuplicateMessageSimilarity) {
+				return refuse(http.StatusUnprocessableEntity, ErrCodeDuplicateMessage, 0,
+					"Message nearly repeats one of your recent messages")
+			}
+		}
+	}
+	return false
+}
+
+// nearDuplicate reports whether a and b are at least threshold similar. Messages whose lengths
+// alone rule that out skip the edit distance, which is quadratic in their length.
+func nearDuplicate(a, b string, threshold float64) bool {
+	length := func(s string) int { return utf8.RuneCountInString(strings.Join(strings.Fields(s), " ")) }
+	shorter, longer := length(a), length(b)
+	if shorter > longer {
+		shorter, longer = longer, shorter
+	}
+	if longer > 0 && float64(shorter)/float64(longer) < threshold {
+		return false
+	}
+	return utils.SimilarityRatio(a, b) >= threshold
+}
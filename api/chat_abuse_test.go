@@ -0,0 +1,198 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	dto "github.com/prometheus/client_model/go"
+
+	"github.com/zidane0000/ai-interview-platform/ai"
+	"github.com/zidane0000/ai-interview-platform/data"
+	"github.com/zidane0000/ai-interview-platform/internal/testsupport"
+)
+
+// setupAbuseTest starts a chat session on a router with a settable clock and the anti-abuse checks
+// configure enables
+func setupAbuseTest(t *testing.T, configure func(deps *HandlerDependencies)) (*testRouter, *time.Time, string) {
+	t.Helper()
+	clock := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	router := setupTestRouterWithProvider(ai.NewMockProvider(), func(deps *HandlerDependencies) {
+		deps.AdminToken = "admin-secret"
+		deps.now = func() time.Time { return clock }
+		configure(deps)
+	})
+	interview := createTestInterview(t, router, testsupport.NewInterviewBuilder().WithQuestions(10))
+	session := startChatSession(t, router, testsupport.NewSessionBuilder().ForInterviewID(interview.ID))
+	return router, &clock, session.ID
+}
+
+// postMessage sends message to the session with the given headers
+func postMessage(router http.Handler, sessionID, message string, headers map[string]string) *httptest.ResponseRecorder {
+	body, _ := json.Marshal(SendMessageRequestDTO{Message: message})
+	req := httptest.NewRequest("POST", "/api/chat/"+sessionID+"/message", bytes.NewReader(body))
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+// assertRefused checks that w is an error response with status and code
+func assertRefused(t *testing.T, w *httptest.ResponseRecorder, status int, code ErrorCode) {
+	t.Helper()
+	var resp ErrorResponseDTO
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil || w.Code != status || resp.Code != code {
+		t.Fatalf("expected %d with code %q, got %d: %s", status, code, w.Code, w.Body.String())
+	}
+}
+
+// abuseRejections reads the rejection counter of reason
+func abuseRejections(t *testing.T, reason ErrorCode) float64 {
+	t.Helper()
+	var m dto.Metric
+	if err := chatAbuseRejections.WithLabelValues(string(reason)).Write(&m); err != nil {
+		t.Fatalf("failed to read the rejection counter: %v", err)
+	}
+	return m.GetCounter().GetValue()
+}
+
+func TestSendMessage_MessageTooSoon(t *testing.T) {
+	router, clock, sessionID := setupAbuseTest(t, func(deps *HandlerDependencies) {
+		deps.MinMessageInterval = 2 * time.Second
+	})
+	before := abuseRejections(t, ErrCodeMessageTooSoon)
+
+	sendMessage(t, router, sessionID, "My first answer")
+	*clock = clock.Add(500 * time.Millisecond)
+	w := postMessage(router, sessionID, "My second answer", nil)
+	assertRefused(t, w, http.StatusTooManyRequests, ErrCodeMessageTooSoon)
+	if got := w.Header().Get("Retry-After"); got != "2" {
+		t.Errorf("expected Retry-After 2, got %q", got)
+	}
+	if got := abuseRejections(t, ErrCodeMessageTooSoon) - before; got != 1 {
+		t.Errorf("expected 1 rejection counted, got %v", got)
+	}
+
+	*clock = clock.Add(1500 * time.Millisecond)
+	sendMessage(t, router, sessionID, "My second answer")
+
+	// The refusal is on the session for reviewers, and hidden from the candidate
+	get := func(token string) ChatInterviewSessionDTO {
+		req := httptest.NewRequest("GET", "/api/chat/"+sessionID, nil)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		var view ChatInterviewSessionDTO
+		if err := json.Unmarshal(w.Body.Bytes(), &view); err != nil || w.Code != http.StatusOK {
+			t.Fatalf("expected the session, got %d: %s", w.Code, w.Body.String())
+		}
+		return view
+	}
+	if view := get("admin-secret"); view.AbuseRejections[string(ErrCodeMessageTooSoon)] != 1 {
+		t.Errorf("expected 1 message_too_soon for the reviewer, got %v", view.AbuseRejections)
+	}
+	if view := get(""); view.AbuseRejections != nil {
+		t.Errorf("expected no abuse rejections in the candidate view, got %v", view.AbuseRejections)
+	}
+}
+
+func TestSendMessage_SessionHourlyCap(t *testing.T) {
+	router, clock, sessionID := setupAbuseTest(t, func(deps *HandlerDependencies) {
+		deps.MaxMessagesPerSessionHour = 3
+	})
+	start := *clock
+	for i, answer := range []string{"Answer one", "Answer two", "Answer three"} {
+		*clock = start.Add(time.Duration(i) * 10 * time.Minute)
+		sendMessage(t, router, sessionID, answer)
+	}
+
+	*clock = start.Add(30 * time.Minute)
+	w := postMessage(router, sessionID, "Answer four", nil)
+	assertRefused(t, w, http.StatusTooManyRequests, ErrCodeSessionHourlyCap)
+	// The first answer leaves the hour 30 minutes later
+	if got := w.Header().Get("Retry-After"); got != "1800" {
+		t.Errorf("expected Retry-After 1800, got %q", got)
+	}
+
+	*clock = start.Add(time.Hour)
+	sendMessage(t, router, sessionID, "Answer four")
+	session, _ := router.store.GetChatSession(sessionID)
+	if session.AbuseRejections[string(ErrCodeSessionHourlyCap)] != 1 {
+		t.Errorf("expected 1 session_hourly_cap on the session, got %v", session.AbuseRejections)
+	}
+}
+
+func TestSendMessage_DuplicateMessage(t *testing.T) {
+	router, clock, sessionID := setupAbuseTest(t, func(deps *HandlerDependencies) {
+		deps.DuplicateMessageSimilarity = 0.9
+	})
+	answers := []string{
+		"I led the payments migration for three years.",
+		"We used Go and Postgres for the services.",
+		"Testing was mostly integration tests.",
+		"I mentored two junior engineers.",
+	}
+	for _, answer := range answers {
+		*clock = clock.Add(time.Minute)
+		sendMessage(t, router, sessionID, answer)
+	}
+
+	tests := []struct {
+		name      string
+		message   string
+		duplicate bool
+	}{
+		{"re-cased and re-spaced copy", "i mentored  two junior engineers!", true},
+		{"copy of an earlier recent message", "We used Go and Postgres for the service.", true},
+		{"copy of a message past the last three", "I led the payments migration for three years.", false},
+		{"different answer", "I would shard the ledger by account.", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			*clock = clock.Add(time.Minute)
+			w := postMessage(router, sessionID, tt.message, nil)
+			if tt.duplicate {
+				assertRefused(t, w, http.StatusUnprocessableEntity, ErrCodeDuplicateMessage)
+			} else if w.Code != http.StatusOK {
+				t.Errorf("expected 200, got %d: %s", w.Code, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestSendMessage_AbuseChecksWithTenantKeys(t *testing.T) {
+	router := setupTestRouterWithProvider(ai.NewMockProvider(), func(deps *HandlerDependencies) {
+		deps.TenantAPIKeys = map[string]string{"key-acme": "acme"}
+		deps.AdminToken = "admin-secret"
+		deps.MinMessageInterval = time.Hour
+		deps.DuplicateMessageSimilarity = 0.9
+		deps.MaxMessagesPerSessionHour = 1
+	})
+	store := router.store.WithContext(data.WithTenant(context.Background(), "acme"))
+	interview := testsupport.NewInterviewBuilder().WithQuestions(10).Create(t, store)
+
+	// With multi-tenancy on, candidates send the tenant key too, so it doesn't skip the checks
+	candidate := testsupport.NewSessionBuilder().ForInterview(interview).Create(t, store)
+	apiKey := map[string]string{"X-API-Key": "key-acme"}
+	if w := postMessage(router, candidate.ID, "Same answer", apiKey); w.Code != http.StatusOK {
+		t.Fatalf("expected the first message accepted, got %d: %s", w.Code, w.Body.String())
+	}
+	assertRefused(t, postMessage(router, candidate.ID, "Same answer", apiKey), http.StatusTooManyRequests, ErrCodeMessageTooSoon)
+
+	// The admin token skips them
+	admin := testsupport.NewSessionBuilder().ForInterview(interview).Create(t, store)
+	adminHeaders := map[string]string{"X-API-Key": "key-acme", "Authorization": "Bearer admin-secret"}
+	for i := range 3 {
+		if w := postMessage(router, admin.ID, "Same answer", adminHeaders); w.Code != http.StatusOK {
+			t.Fatalf("message %d: expected 200 for an admin caller, got %d: %s", i+1, w.Code, w.Body.String())
+		}
+	}
+}
@@ -0,0 +1,196 @@
+// Response compression for the API
+package api
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"mime"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/zidane0000/ai-interview-platform/utils"
+)
+
+// compressionMinSize is the body size in bytes below which responses are sent uncompressed;
+// gzipping smaller bodies costs more CPU than it saves on the wire
+const compressionMinSize = 1024
+
+// uncompressibleContentTypes are sent as-is: streams must flush every event to the client
+// immediately, and already-compressed formats only grow when gzipped again
+var uncompressibleContentTypes = []string{
+	"text/event-stream",
+	"image/",
+	"video/",
+	"audio/",
+	"application/gzip",
+	"application/zip",
+	"application/octet-stream",
+}
+
+// CompressionMiddleware gzips response bodies of at least minSize bytes for clients whose
+// Accept-Encoding allows it. Streaming responses (text/event-stream, or any that flush before
+// minSize bytes are written) and already-encoded or compressed content are passed through.
+func CompressionMiddleware(minSize int) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Add("Vary", "Accept-Encoding")
+			if r.Method == http.MethodHead || !acceptsGzip(r.Header.Get("Accept-Encoding")) {
+				next.ServeHTTP(w, r)
+				return
+			}
+			cw := &compressResponseWriter{responseWriterWrapper: wrapResponseWriter(w), minSize: minSize, status: http.StatusOK}
+			defer cw.close()
+			next.ServeHTTP(cw, r)
+		})
+	}
+}
+
+// acceptsGzip reports whether an Accept-Encoding header allows gzip (explicitly or via *) with a non-zero quality
+func acceptsGzip(header string) bool {
+	for _, part := range strings.Split(header, ",") {
+		coding, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		coding = strings.ToLower(strings.TrimSpace(coding))
+		if coding != "gzip" && coding != "*" {
+			continue
+		}
+		if q, ok := strings.CutPrefix(strings.ReplaceAll(params, " ", ""), "q="); ok {
+			if quality, err := strconv.ParseFloat(q, 64); err == nil && quality == 0 {
+				continue
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// compressResponseWriter buffers the start of a response until it knows whether to compress it:
+// once minSize bytes are written it switches to gzip, while a flush or the end of the handler
+// before that sends the body uncompressed
+type compressResponseWriter struct {
+	responseWriterWrapper
+	minSize     int
+	status      int
+	wroteHeader bool         // WriteHeader was called by the handler
+	buf         bytes.Buffer // Body written before the decision
+	decided     bool         // Headers are sent and the body goes to gz or straight through
+	gz          *gzip.Writer // Set when compressing
+}
+
+func (cw *compressResponseWriter) WriteHeader(code int) {
+	if cw.wroteHeader || cw.decided {
+		return
+	}
+	cw.wroteHeader = true
+	cw.status = code
+	// Bodiless responses have nothing to compress
+	if code == http.StatusNoContent || code == http.StatusNotModified {
+		cw.start(false)
+	}
+}
+
+func (cw *compressResponseWriter) Write(p []byte) (int, error) {
+	if !cw.decided {
+		if cw.buf.Len()+len(p) < cw.minSize {
+			return cw.buf.Write(p)
+		}
+		if err := cw.start(cw.compressible()); err != nil {
+			return 0, err
+		}
+	}
+	if cw.gz != nil {
+		return cw.gz.Write(p)
+	}
+	return cw.ResponseWriter.Write(p)
+}
+
+// Flush sends what has been written so far; a response flushed before reaching minSize is
+// treated as a stream and left uncompressed so each flush reaches the client as written
+func (cw *compressResponseWriter) Flush() {
+	if !cw.decided {
+		if err := cw.start(false); err != nil {
+			return
+		}
+	}
+	if cw.gz != nil {
+		if err := cw.gz.Flush(); err != nil {
+			return
+		}
+	}
+	cw.responseWriterWrapper.Flush()
+}
+
+// Hijack hands the connection to the handler uncompressed; nothing is written to it afterwards
+func (cw *compressResponseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	if cw.decided {
+		return nil, nil, errors.New("response already started")
+	}
+	conn, rw, err := cw.responseWriterWrapper.Hijack()
+	if err == nil {
+		cw.decided = true
+		cw.buf.Reset()
+	}
+	return conn, rw, err
+}
+
+// compressible reports whether the response headers allow gzipping the body
+func (cw *compressResponseWriter) compressible() bool {
+	header := cw.Header()
+	if header.Get("Content-Encoding") != "" {
+		return false
+	}
+	mediaType, _, err := mime.ParseMediaType(header.Get("Content-Type"))
+	if err != nil {
+		mediaType = strings.ToLower(header.Get("Content-Type"))
+	}
+	for _, skipped := range uncompressibleContentTypes {
+		if strings.HasPrefix(mediaType, skipped) {
+			return false
+		}
+	}
+	return true
+}
+
+// start sends the headers, compressed or not, followed by the buffered body
+func (cw *compressResponseWriter) start(compress bool) error {
+	cw.decided = true
+	if compress {
+		cw.Header().Set("Content-Encoding", "gzip")
+		// The handler's length is that of the uncompressed body
+		cw.Header().Del("Content-Length")
+		cw.gz = gzip.NewWriter(cw.ResponseWriter)
+	}
+	cw.ResponseWriter.WriteHeader(cw.status)
+	if cw.buf.Len() == 0 {
+		return nil
+	}
+	var err error
+	if cw.gz != nil {
+		_, err = cw.gz.Write(cw.buf.Bytes())
+	} else {
+		_, err = cw.ResponseWriter.Write(cw.buf.Bytes())
+	}
+	cw.buf.Reset()
+	return err
+}
+
+// close finishes the response once the handler returns, sending bodies smaller than minSize as-is
+func (cw *compressResponseWriter) close() {
+	if !cw.decided {
+		if !cw.wroteHeader && cw.buf.Len() == 0 {
+			return
+		}
+		if err := cw.start(false); err != nil {
+			utils.Errorf("Failed to write response: %v", err)
+		}
+		return
+	}
+	if cw.gz != nil {
+		if err := cw.gz.Close(); err != nil {
+			utils.Errorf("Failed to finish compressed response: %v", err)
+		}
+	}
+}
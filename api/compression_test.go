@@ -0,0 +1,115 @@
+package api
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/zidane0000/ai-interview-platform/internal/testsupport"
+)
+
+func TestCompression_LargeSessionPayload(t *testing.T) {
+	router := setupTestRouter()
+	interview := testsupport.NewInterviewBuilder().WithCandidate("Verbose Candidate").Create(t, router.store)
+	builder := testsupport.NewSessionBuilder().ForInterview(interview).WithID("verbose-session")
+	for i := 0; i < 20; i++ {
+		builder.WithTranscript(testsupport.Pair(fmt.Sprintf("Question %d: tell me about a project?", i),
+			strings.Repeat("I designed and shipped a distributed system with careful attention to reliability. ", 20)))
+	}
+	builder.Create(t, router.store)
+
+	get := func(acceptEncoding string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("GET", "/api/chat/verbose-session", nil)
+		if acceptEncoding != "" {
+			req.Header.Set("Accept-Encoding", acceptEncoding)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+		}
+		return w
+	}
+	plain := get("")
+	compressed := get("br;q=1.0, gzip;q=0.8")
+
+	if plain.Header().Get("Content-Encoding") != "" {
+		t.Errorf("expected an uncompressed response without Accept-Encoding, got %q", plain.Header().Get("Content-Encoding"))
+	}
+	if compressed.Header().Get("Content-Encoding") != "gzip" || compressed.Header().Get("Content-Length") != "" {
+		t.Fatalf("expected a gzip response without Content-Length, got headers %v", compressed.Header())
+	}
+	if compressed.Header().Get("Vary") != "Accept-Encoding" {
+		t.Errorf("expected Vary: Accept-Encoding, got %q", compressed.Header().Get("Vary"))
+	}
+	if compressed.Body.Len()*4 > plain.Body.Len() {
+		t.Errorf("expected gzip to shrink the %d byte payload at least fourfold, got %d bytes", plain.Body.Len(), compressed.Body.Len())
+	}
+	reader, err := gzip.NewReader(compressed.Body)
+	if err != nil {
+		t.Fatalf("invalid gzip body: %v", err)
+	}
+	decompressed, err := io.ReadAll(reader)
+	if err != nil {
+		t.Fatalf("failed to decompress body: %v", err)
+	}
+	if !bytes.Equal(decompressed, plain.Body.Bytes()) {
+		t.Error("expected the decompressed body to match the uncompressed response")
+	}
+}
+
+func TestCompression_SmallAndRefusedResponsesUncompressed(t *testing.T) {
+	router := setupTestRouter()
+	for _, acceptEncoding := range []string{"gzip", "gzip;q=0", "identity"} {
+		req := httptest.NewRequest("GET", "/api/interviews/missing", nil)
+		req.Header.Set("Accept-Encoding", acceptEncoding)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		if w.Header().Get("Content-Encoding") != "" || !strings.Contains(w.Body.String(), "not_found") {
+			t.Errorf("%s: expected a plain error body, got %q encoded %q", acceptEncoding, w.Body.String(), w.Header().Get("Content-Encoding"))
+		}
+	}
+}
+
+func TestCompression_EventStreamUntouched(t *testing.T) {
+	events := strings.Repeat("data: {\"type\":\"token\",\"content\":\"hello\"}\n\n", 100)
+	var flushes int
+	handler := CompressionMiddleware(compressionMinSize)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		for _, event := range strings.SplitAfter(events, "\n\n") {
+			if _, err := io.WriteString(w, event); err != nil {
+				t.Fatalf("write failed: %v", err)
+			}
+			w.(http.Flusher).Flush()
+			flushes++
+		}
+	}))
+
+	req := httptest.NewRequest("GET", "/api/stream", nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	if w.Header().Get("Content-Encoding") != "" {
+		t.Errorf("expected the event stream to bypass compression, got %q", w.Header().Get("Content-Encoding"))
+	}
+	if w.Body.String() != events || !w.Flushed || flushes == 0 {
+		t.Errorf("expected every event flushed through unchanged (flushed %v)", w.Flushed)
+	}
+
+	// A large stream is still left alone once it passes the size threshold without flushing
+	unflushed := CompressionMiddleware(compressionMinSize)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream; charset=utf-8")
+		io.WriteString(w, events)
+	}))
+	w = httptest.NewRecorder()
+	unflushed.ServeHTTP(w, req)
+	if w.Header().Get("Content-Encoding") != "" || w.Body.String() != events {
+		t.Errorf("expected the event stream untouched, got encoding %q", w.Header().Get("Content-Encoding"))
+	}
+}
@@ -1,6 +1,6 @@
 package api
 
-import "time"
+import "github.com/zidane0000/ai-interview-platform/api/apitime"
 
 // Data Transfer Objects (DTOs) for API request and response payloads:
 // - CreateInterviewRequestDTO
@@ -14,44 +14,248 @@ import "time"
 
 // --- Interview DTOs ---
 type CreateInterviewRequestDTO struct {
-	CandidateName     string   `json:"candidate_name"`
-	Questions         []string `json:"questions"`
-	InterviewType     string   `json:"interview_type"`               // Required: "general", "technical", or "behavioral"
-	InterviewLanguage string   `json:"interview_language,omitempty"` // Language preference: "en" or "zh-TW"
-	JobDescription    string   `json:"job_description,omitempty"`    // Optional: Job description text
+	CandidateName     string            `json:"candidate_name"`
+	Questions         []string          `json:"questions"`
+	InterviewType     string            `json:"interview_type"`               // Required: "general", "technical", or "behavioral"
+	InterviewMode     string            `json:"interview_mode,omitempty"`     // "structured" (default) or "conversational", which allows no questions
+	InterviewLanguage string            `json:"interview_language,omitempty"` // Language preference: "en" or "zh-TW"
+	JobDescription    string            `json:"job_description,omitempty"`    // Optional: Job description text
+	ResumeContent     string            `json:"resume_content,omitempty"`     // Optional: Candidate resume as plain text
+	CompanyContext    string            `json:"company_context,omitempty"`    // Optional: Company/persona context for the role
+	ScheduledStart    *apitime.Time     `json:"scheduled_start,omitempty"`    // Optional: chat sessions cannot start before this time
+	ScheduledEnd      *apitime.Time     `json:"scheduled_end,omitempty"`      // Optional: chat sessions cannot start after this time (plus grace)
+	Notify            *NotifyRequestDTO `json:"notify,omitempty"`             // Optional: per-interview webhook
+	Adaptive          *bool             `json:"adaptive,omitempty"`           // Optional: false keeps question difficulty fixed; defaults to true
+	// Optional: "ordered" (default), "shuffled" or "sample:N"; shuffled and sampled orders are drawn per chat session
+	QuestionStrategy string `json:"question_strategy,omitempty"`
+	// Optional: without questions, use the built-in set for interview_type and interview_language
+	UseDefaultQuestions bool `json:"use_default_questions,omitempty"`
+	// Optional: without questions, have the AI generate num_questions (default 5) from the job description and resume
+	GenerateQuestions bool `json:"generate_questions,omitempty"`
+	NumQuestions      int  `json:"num_questions,omitempty"`
 	// TODO: Resume file upload support will be added in future iteration
 }
 
+// NotifyRequestDTO subscribes an interview's own webhook endpoint to events
+type NotifyRequestDTO struct {
+	WebhookURL string   `json:"webhook_url"`      // https only; private and loopback hosts are refused unless allowlisted
+	Events     []string `json:"events,omitempty"` // "evaluation.created", "session.completed"; all when omitted
+	Secret     string   `json:"secret,omitempty"` // Optional: signs deliveries (X-Webhook-Signature); never returned
+}
+
+// NotifyResponseDTO is an interview's webhook subscription; the secret is never returned
+type NotifyResponseDTO struct {
+	WebhookURL string   `json:"webhook_url"`
+	Events     []string `json:"events"`
+	HasSecret  bool     `json:"has_secret"`
+}
+
+// UpdateInterviewRequestDTO replaces an interview's scheduling window; omitted fields are cleared
+type UpdateInterviewRequestDTO struct {
+	ScheduledStart *apitime.Time `json:"scheduled_start,omitempty"`
+	ScheduledEnd   *apitime.Time `json:"scheduled_end,omitempty"`
+}
+
+// RecordOutcomeRequestDTO records the hiring outcome of an interview
+type RecordOutcomeRequestDTO struct {
+	Outcome     string `json:"outcome"`                // "advanced", "rejected", "offer" or "hired"
+	OutcomeNote string `json:"outcome_note,omitempty"` // Optional recruiter note
+}
+
+// CloneInterviewRequestDTO starts a new interview from an existing one for another candidate
+type CloneInterviewRequestDTO struct {
+	CandidateName     string        `json:"candidate_name"`
+	InterviewLanguage string        `json:"interview_language,omitempty"` // Defaults to the source interview's language
+	ScheduledStart    *apitime.Time `json:"scheduled_start,omitempty"`    // Optional: the source's scheduling window is not copied
+}
+
 type InterviewResponseDTO struct {
-	ID                string   `json:"id"`
-	CandidateName     string   `json:"candidate_name"`
-	Questions         []string `json:"questions"`
-	InterviewType     string   `json:"interview_type"`            // "general", "technical", or "behavioral"
-	InterviewLanguage string   `json:"interview_language"`        // Language preference: "en" or "zh-TW"
-	JobDescription    string   `json:"job_description,omitempty"` // Optional: Job description text
+	ID                string             `json:"id"`
+	CandidateName     string             `json:"candidate_name"`
+	Questions         []string           `json:"questions"`
+	InterviewType     string             `json:"interview_type"`                    // "general", "technical", or "behavioral"
+	InterviewMode     string             `json:"interview_mode"`                    // "structured" or "conversational"
+	InterviewLanguage string             `json:"interview_language"`                // Language preference: "en" or "zh-TW"
+	JobDescription    string             `json:"job_description,omitempty"`         // Optional: Job description text
+	JobDescSummary    string             `json:"job_description_summary,omitempty"` // Summary used in AI prompts for long job descriptions, once generated
+	ResumeContent     string             `json:"resume_content,omitempty"`          // Optional: Candidate resume as plain text
+	CompanyContext    string             `json:"company_context,omitempty"`         // Optional: Company/persona context for the role
+	Status            string             `json:"status"`                            // "draft", "scheduled", "active", or "completed"
+	ScheduledStart    *apitime.Time      `json:"scheduled_start,omitempty"`
+	ScheduledEnd      *apitime.Time      `json:"scheduled_end,omitempty"`
+	Notify            *NotifyResponseDTO `json:"notify,omitempty"`      // Per-interview webhook, when configured
+	Adaptive          bool               `json:"adaptive"`              // Question difficulty follows the candidate's answers
+	QuestionStrategy  string             `json:"question_strategy"`     // "ordered", "shuffled" or "sample:N"
+	ClonedFrom        string             `json:"cloned_from,omitempty"` // Source interview ID for cloned interviews
+	Outcome           string             `json:"outcome,omitempty"`     // Hiring outcome recorded by recruiters
+	OutcomeNote       string             `json:"outcome_note,omitempty"`
+	OutcomeHistory    []OutcomeRecordDTO `json:"outcome_history,omitempty"` // Every outcome recorded, oldest first
+	// Only for generate_questions: "generating" until the questions are filled in, then "ready" or "failed"
+	QuestionsStatus string `json:"questions_status,omitempty"`
+	QuestionsError  string `json:"questions_error,omitempty"` // Why question generation failed
 	// TODO: Resume file support will be added in future iteration
-	CreatedAt time.Time `json:"created_at"`
+	CreatedAt       apitime.Time        `json:"created_at"`
+	QuestionDetails []QuestionDetailDTO `json:"question_details,omitempty"` // Only with ?include=question_details; one per question, in order
+	Warnings        []WarningDTO        `json:"warnings,omitempty"`         // Non-fatal issues found while validating the request
+}
+
+// OutcomeRecordDTO is one hiring outcome recorded for an interview
+type OutcomeRecordDTO struct {
+	Outcome    string       `json:"outcome"`
+	Note       string       `json:"note,omitempty"`
+	RecordedAt apitime.Time `json:"recorded_at"`
+}
+
+// QuestionDetailDTO describes one of an interview's questions
+type QuestionDetailDTO struct {
+	Text         string `json:"text"`
+	Category     string `json:"category,omitempty"`
+	Difficulty   string `json:"difficulty,omitempty"`
+	ExpectedTime int    `json:"expected_time,omitempty"` // Expected answer time in minutes
+	Source       string `json:"source"`                  // "ai", "manual" or "bank"
+}
+
+// DefaultQuestionsResponseDTO is a built-in question set for quick-start interviews
+type DefaultQuestionsResponseDTO struct {
+	InterviewType string   `json:"interview_type"`
+	Language      string   `json:"language"`
+	Questions     []string `json:"questions"`
+	Warning       string   `json:"warning,omitempty"` // Set when the requested type or language has no set and a fallback was used
 }
 
 type ListInterviewsResponseDTO struct {
 	Interviews []InterviewResponseDTO `json:"interviews"`
-	// TODO: Add pagination support - Total field exists in frontend types but missing here
-	Total int `json:"total"`
+	Total      int                    `json:"total"`
+	// Applied pagination values (after defaults and clamping)
+	Limit      int          `json:"limit"`
+	Offset     int          `json:"offset"`
+	Page       int          `json:"page"`
+	TotalPages int          `json:"total_pages"`
+	Warnings   []WarningDTO `json:"warnings,omitempty"` // Query parameters that were ignored or clamped
+}
+
+// ListInterviewFieldsResponseDTO is the interview list with only the fields selected by ?fields=
+// on each interview, e.g. {"id": "...", "question_count": 5}
+type ListInterviewFieldsResponseDTO struct {
+	Interviews []map[string]interface{} `json:"interviews"`
+	Total      int                      `json:"total"`
+	// Applied pagination values (after defaults and clamping)
+	Limit      int          `json:"limit"`
+	Offset     int          `json:"offset"`
+	Page       int          `json:"page"`
+	TotalPages int          `json:"total_pages"`
+	Warnings   []WarningDTO `json:"warnings,omitempty"` // Query parameters that were ignored or clamped
+}
+
+// InterviewSummaryDTO is the compact interview view used in grouped listings
+type InterviewSummaryDTO struct {
+	ID                string       `json:"id"`
+	CandidateName     string       `json:"candidate_name"`
+	InterviewType     string       `json:"interview_type"`
+	InterviewLanguage string       `json:"interview_language"`
+	Status            string       `json:"status"`
+	CreatedAt         apitime.Time `json:"created_at"`
+}
+
+// CandidateGroupDTO summarizes all interviews of one candidate
+type CandidateGroupDTO struct {
+	CandidateName   string                `json:"candidate_name"`
+	InterviewCount  int                   `json:"interview_count"`
+	LatestCreatedAt apitime.Time          `json:"latest_created_at"`
+	LatestScore     *float64              `json:"latest_score"` // null when the candidate has no evaluation
+	Interviews      []InterviewSummaryDTO `json:"interviews"`
+}
+
+type ListCandidateGroupsResponseDTO struct {
+	Candidates []CandidateGroupDTO `json:"candidates"`
+	Total      int                 `json:"total"` // Number of distinct candidates
+	// Applied pagination values over candidates (after defaults and clamping)
+	Limit      int          `json:"limit"`
+	Offset     int          `json:"offset"`
+	Page       int          `json:"page"`
+	TotalPages int          `json:"total_pages"`
+	Warnings   []WarningDTO `json:"warnings,omitempty"` // Query parameters that were ignored or clamped
 }
 
 // --- Evaluation DTOs ---
 type SubmitEvaluationRequestDTO struct {
 	InterviewID string            `json:"interview_id"`
 	Answers     map[string]string `json:"answers"`
+	DetailLevel string            `json:"detail_level,omitempty"` // Optional: "brief", "standard" (default) or "detailed"
+	// Optional: languages the feedback is also translated into; omitted or null uses the server default, [] none
+	AdditionalFeedbackLanguages []string `json:"additional_feedback_languages"`
 }
 
 type EvaluationResponseDTO struct {
-	ID          string            `json:"id"`
-	InterviewID string            `json:"interview_id"`
-	Answers     map[string]string `json:"answers"` // TODO: Add answers field to match frontend expectations
-	Score       float64           `json:"score"`
-	Feedback    string            `json:"feedback"`
-	CreatedAt   time.Time         `json:"created_at"`
+	ID                string                              `json:"id"`
+	InterviewID       string                              `json:"interview_id"`
+	Answers           map[string]string                   `json:"answers"`                      // Keyed "question_N"; kept for existing clients
+	AnswersV2         []AnswerDTO                         `json:"answers_v2,omitempty"`         // Answers in question order with the question text
+	QuestionsSnapshot []string                            `json:"questions_snapshot,omitempty"` // Interview questions (or chat questions asked) at evaluation time
+	Score             float64                             `json:"score"`
+	Feedback          string                              `json:"feedback"`
+	Status            string                              `json:"status"`                  // "completed", "no_answers" when the candidate never replied, or "superseded" once voided
+	Provider          string                              `json:"provider,omitempty"`      // AI provider that performed the scoring
+	Model             string                              `json:"model,omitempty"`         // AI model that performed the scoring
+	SupersedesID      string                              `json:"supersedes_id,omitempty"` // Evaluation this one replaced via ?replace=true or a reopen
+	EstimatedCostUSD  float64                             `json:"estimated_cost_usd"`      // Estimated AI cost of producing this evaluation
+	LanguageMismatch  bool                                `json:"language_mismatch"`       // Answers were mostly in another language than the interview; scored on content
+	FeedbackTruncated bool                                `json:"feedback_truncated"`      // Feedback ran over its word limit and was cut at a sentence boundary
+	Decision          string                              `json:"decision,omitempty"`      // "strong_hire", "hire", "no_hire" or "more_data_needed"; omitted when the evaluator gave none
+	NextSteps         []string                            `json:"next_steps,omitempty"`    // Up to three concrete next steps for recruiters
+	Translations      map[string]EvaluationTranslationDTO `json:"translations,omitempty"`  // Feedback in the additional languages, keyed by language code
+	AnswerStats       *AnswerStatsDTO                     `json:"answer_stats,omitempty"`  // Completeness of the answers; omitted when they were not scored
+	CreatedAt         apitime.Time                        `json:"created_at"`
+	// Only with ?include=percentile: rank of the score among current evaluations of the same interview
+	// type and model from the last 90 days; omitted with a small_cohort warning below 5 of them
+	Percentile *float64     `json:"percentile,omitempty"`
+	CohortSize int          `json:"cohort_size,omitempty"`
+	Warnings   []WarningDTO `json:"warnings,omitempty"` // Mirrors language_mismatch, feedback_truncated and failed translations
+}
+
+// AnswerStatsDTO is the completeness of an evaluation's answers, e.g. "3 of 8 questions effectively unanswered"
+type AnswerStatsDTO struct {
+	Questions  int   `json:"questions"`
+	Unanswered int   `json:"unanswered"`  // Empty plus too short answers
+	Empty      int   `json:"empty"`       // Skipped or without a single word
+	TooShort   int   `json:"too_short"`   // Given but shorter than min_words
+	MinWords   int   `json:"min_words"`   // Configured by EVALUATION_MIN_ANSWER_WORDS
+	WordCounts []int `json:"word_counts"` // Per answer in question order; each CJK character counts as one word
+}
+
+// EvaluationTranslationDTO is the prose of an evaluation in an additional language; scores are not translated
+type EvaluationTranslationDTO struct {
+	Feedback   string   `json:"feedback"`
+	Strengths  []string `json:"strengths"`
+	Weaknesses []string `json:"weaknesses"`
+}
+
+// EvaluationTraceResponseDTO is what the evaluator was sent and answered for one evaluation
+// Texts are redacted; ones over the trace size cap end with a truncation marker and set truncated.
+type EvaluationTraceResponseDTO struct {
+	EvaluationID     string       `json:"evaluation_id"`
+	Prompt           string       `json:"prompt"`     // Rendered evaluation prompt
+	Answers          string       `json:"answers"`    // Formatted question and answer block
+	RawOutput        string       `json:"raw_output"` // Model output before parsing
+	Provider         string       `json:"provider"`
+	Model            string       `json:"model"`
+	Temperature      float64      `json:"temperature"`
+	PromptTokens     int          `json:"prompt_tokens"`
+	CompletionTokens int          `json:"completion_tokens"`
+	Truncated        bool         `json:"truncated"`
+	CreatedAt        apitime.Time `json:"created_at"`
+}
+
+// AnswerDTO pairs an answer with the question it responds to
+type AnswerDTO struct {
+	Question string `json:"question"`
+	Answer   string `json:"answer"`
+}
+
+// EvaluationConflictResponseDTO is returned when an interview already has an evaluation
+type EvaluationConflictResponseDTO struct {
+	ErrorResponseDTO
+	ExistingEvaluationID string `json:"existing_evaluation_id"`
 }
 
 // --- Chat DTOs ---
@@ -59,38 +263,510 @@ type EvaluationResponseDTO struct {
 
 type StartChatSessionRequestDTO struct {
 	SessionLanguage string `json:"session_language,omitempty"` // Optional language override
+	// Optional: how the session opens, "ai", "template" (canned, no AI call) or "none" (the AI first
+	// replies to the candidate's first message); defaults to CHAT_GREETING_MODE
+	Greeting string `json:"greeting,omitempty"`
+}
+
+// StartInterviewRequestDTO creates an interview and starts its first chat session in one call
+type StartInterviewRequestDTO struct {
+	CreateInterviewRequestDTO
+	Session StartChatSessionRequestDTO `json:"session"` // Optional session options
+}
+
+// StartInterviewResponseDTO is an interview created together with its first chat session
+type StartInterviewResponseDTO struct {
+	Interview InterviewResponseDTO    `json:"interview"`
+	Session   ChatInterviewSessionDTO `json:"session"`
+	// Set when the AI greeting failed; the session has no messages until POST .../retry-ai fills it
+	GreetingPending bool `json:"greeting_pending,omitempty"`
+}
+
+// StartInterviewErrorResponseDTO is returned when POST /interviews/start is rejected; part names
+// what was invalid, "interview" or "session"
+type StartInterviewErrorResponseDTO struct {
+	ErrorResponseDTO
+	Part string `json:"part"`
+}
+
+// ScheduleWindowErrorResponseDTO is returned when a chat session is started outside the
+// interview's scheduling window; the relevant boundary is included so clients can show it
+type ScheduleWindowErrorResponseDTO struct {
+	ErrorResponseDTO
+	ScheduledStart *apitime.Time `json:"scheduled_start,omitempty"` // Set for too_early
+	ScheduledEnd   *apitime.Time `json:"scheduled_end,omitempty"`   // Set for expired
 }
 
 type ChatMessageDTO struct {
-	ID        string    `json:"id"`
-	Type      string    `json:"type"` // "ai" or "user"
-	Content   string    `json:"content"`
-	Timestamp time.Time `json:"timestamp"`
+	ID              string            `json:"id"`
+	ClientMessageID string            `json:"client_message_id,omitempty"` // User only: echoed from SendMessageRequestDTO
+	Type            string            `json:"type"`                        // "ai", "user" or "system"
+	Subtype         string            `json:"subtype,omitempty"`           // AI only: "greeting", "question", "follow_up", "acknowledgement", "closing"
+	Content         string            `json:"content"`
+	Provider        string            `json:"provider,omitempty"` // AI only, with ?include=meta
+	Model           string            `json:"model,omitempty"`    // AI only, with ?include=meta
+	Metadata        map[string]string `json:"metadata,omitempty"` // AI only: client-facing flags such as "language_mismatch" and "expected_time_minutes"
+	Visibility      string            `json:"visibility"`         // "candidate" or "internal"; internal messages are left out of the candidate view
+	Timestamp       apitime.Time      `json:"timestamp"`
 }
 
 type ChatInterviewSessionDTO struct {
-	ID              string           `json:"id"`
-	InterviewID     string           `json:"interview_id"`
-	SessionLanguage string           `json:"session_language"` // Session language: "en" or "zh-TW"
-	Messages        []ChatMessageDTO `json:"messages"`
-	Status          string           `json:"status"` // "active" or "completed"
-	StartedAt       time.Time        `json:"started_at"`
-	CreatedAt       time.Time        `json:"created_at"`
+	ID               string                `json:"id"`
+	InterviewID      string                `json:"interview_id"`
+	SessionLanguage  string                `json:"session_language"` // Session language: "en" or "zh-TW"
+	Greeting         string                `json:"greeting"`         // How the session opened: "ai", "template" or "none"
+	Messages         []ChatMessageDTO      `json:"messages"`
+	Status           string                `json:"status"`             // "active" or "completed"
+	Provider         string                `json:"provider,omitempty"` // AI provider the session is pinned to; empty until its first AI turn
+	Model            string                `json:"model,omitempty"`    // AI model the session is pinned to
+	EstimatedCostUSD float64               `json:"estimated_cost_usd"` // Estimated AI cost of the conversation so far, excluding the evaluation
+	StartedAt        apitime.Time          `json:"started_at"`
+	EndedAt          *apitime.Time         `json:"ended_at,omitempty"` // When the session completed or was abandoned
+	DurationSeconds  *int64                `json:"duration_seconds"`   // From started_at to ended_at; null while active
+	CreatedAt        apitime.Time          `json:"created_at"`
+	AskedQuestions   []string              `json:"asked_questions,omitempty"`  // Only with ?include=asked_questions
+	LastActivityAt   *apitime.Time         `json:"last_activity_at,omitempty"` // Latest message or heartbeat
+	DifficultyLevel  int                   `json:"difficulty_level,omitempty"` // Adaptive interviews only: current difficulty, 1 (very easy) to 5 (very hard)
+	ExpiresAt        *apitime.Time         `json:"expires_at,omitempty"`       // When an active session is abandoned without further activity
+	ReopenCount      int                   `json:"reopen_count,omitempty"`     // Times the session was reopened after completing
+	Progress         *InterviewProgressDTO `json:"progress,omitempty"`
+	// Set when the session holds more messages than are returned; page through them with GET /chat/{id}/messages
+	MessagesTruncated bool `json:"messages_truncated,omitempty"`
+	TotalMessages     int  `json:"total_messages,omitempty"`
+	// Set once the messages past the transcript retention were deleted; messages lists only those kept
+	TranscriptPurged   bool          `json:"transcript_purged,omitempty"`
+	TranscriptPurgedAt *apitime.Time `json:"transcript_purged_at,omitempty"`
+	// Candidate messages refused by the anti-abuse checks, by error code; admin and API key callers only
+	AbuseRejections map[string]int `json:"abuse_rejections,omitempty"`
+}
+
+// ListChatMessagesResponseDTO is one page of a chat session's messages, oldest first
+type ListChatMessagesResponseDTO struct {
+	Messages []ChatMessageDTO `json:"messages"`
+	Total    int              `json:"total"`
+	// Applied pagination values (after defaults and clamping)
+	Limit      int          `json:"limit"`
+	Offset     int          `json:"offset"`
+	Page       int          `json:"page"`
+	TotalPages int          `json:"total_pages"`
+	Warnings   []WarningDTO `json:"warnings,omitempty"` // Query parameters that were ignored or clamped
+	// Set once the messages past the transcript retention were deleted; total counts only those kept
+	TranscriptPurged   bool          `json:"transcript_purged,omitempty"`
+	TranscriptPurgedAt *apitime.Time `json:"transcript_purged_at,omitempty"`
+}
+
+// InterviewProgressDTO reports how far a chat interview has progressed
+type InterviewProgressDTO struct {
+	QuestionsTotal   int  `json:"questions_total"`     // Planned questions the session asks
+	QuestionsAsked   int  `json:"questions_asked"`     // Questions asked so far, capped at questions_total when questions are planned
+	UserMessages     int  `json:"user_messages"`       // Candidate messages so far
+	PercentComplete  int  `json:"percent_complete"`    // Reaches 100 only once the session is completed
+	WillEndAfterNext bool `json:"will_end_after_next"` // The next candidate message ends the interview
+	// Active sessions only: expected answer time in minutes of the planned question asked last, when it has one
+	CurrentQuestionExpectedTime int `json:"current_question_expected_time,omitempty"`
+}
+
+type UpdateChatSessionRequestDTO struct {
+	SessionLanguage string `json:"session_language"` // New session language: "en" or "zh-TW"
 }
 
 type SendMessageRequestDTO struct {
-	Message string `json:"message"`
-	Model   string `json:"model,omitempty"` // Optional: "openai/gpt-4o", "google/gemini-pro", defaults to configured provider
+	Message         string `json:"message"`
+	Model           string `json:"model,omitempty"`             // Optional: "openai/gpt-4o", "gemini/gemini-1.5-pro", defaults to configured provider
+	ClientMessageID string `json:"client_message_id,omitempty"` // Optional: client-generated UUID, resends with the same ID are deduplicated
 }
 
 type SendMessageResponseDTO struct {
-	Message       ChatMessageDTO  `json:"message"`
-	AIResponse    *ChatMessageDTO `json:"ai_response,omitempty"`
-	SessionStatus string          `json:"session_status"` // "active" or "completed"
+	Message       ChatMessageDTO        `json:"message"`
+	AIResponse    *ChatMessageDTO       `json:"ai_response,omitempty"`
+	SessionStatus string                `json:"session_status"`    // "active" or "completed"
+	Timings       *MessageTimingsDTO    `json:"timings,omitempty"` // Where the server spent time handling the message
+	Progress      *InterviewProgressDTO `json:"progress,omitempty"`
+	Warnings      []WarningDTO          `json:"warnings,omitempty"` // e.g. the message was summarized for the AI or the reply is in another language
+}
+
+// HeartbeatResponseDTO reports a chat session's activity after a heartbeat
+type HeartbeatResponseDTO struct {
+	SessionID      string        `json:"session_id"`
+	LastActivityAt apitime.Time  `json:"last_activity_at"`
+	ExpiresAt      *apitime.Time `json:"expires_at,omitempty"` // Omitted when idle expiry is disabled
+}
+
+// WrapUpResponseDTO is the result of wrapping up a chat session: the AI's sign-off and the evaluation
+type WrapUpResponseDTO struct {
+	ClosingMessage ChatMessageDTO        `json:"closing_message"`
+	Evaluation     EvaluationResponseDTO `json:"evaluation"`
+}
+
+// MessageTimingsDTO breaks down server-side handling time in milliseconds
+type MessageTimingsDTO struct {
+	QueueMs    int64 `json:"queue_ms"`    // Waiting for a rate limiter or worker slot
+	ProviderMs int64 `json:"provider_ms"` // AI provider response time
+	StoreMs    int64 `json:"store_ms"`    // Aggregate persistence time
+	TotalMs    int64 `json:"total_ms"`    // Whole request, measured in the handler
+
+	Budget *TurnBudgetDTO `json:"budget,omitempty"` // Chat turns only; omitted when CHAT_TURN_BUDGET is 0
+}
+
+// TurnBudgetDTO is how a chat turn spent its latency budget
+type TurnBudgetDTO struct {
+	BudgetMs  int64          `json:"budget_ms"`
+	Stages    []TurnStageDTO `json:"stages"`    // Stages entered, in the order they ran
+	Exhausted bool           `json:"exhausted"` // A stage ran out of its allotment
+}
+
+// TurnStageDTO is the time one stage of a chat turn was allotted and used
+type TurnStageDTO struct {
+	Stage      string `json:"stage"`       // "moderation", "persistence", "generation" or "final_persistence"
+	AllottedMs int64  `json:"allotted_ms"` // Its share of the budget left when it started
+	UsedMs     int64  `json:"used_ms"`
+	Exhausted  bool   `json:"exhausted,omitempty"`
+}
+
+// TurnBudgetErrorResponseDTO is returned with 504 when a chat turn ran out of its latency budget
+type TurnBudgetErrorResponseDTO struct {
+	ErrorResponseDTO
+	Timings *MessageTimingsDTO `json:"timings"`
+}
+
+// --- Webhook DTOs ---
+
+// WebhookPayloadDTO is the JSON body delivered for every webhook event
+type WebhookPayloadDTO struct {
+	Event       string                 `json:"event"` // "evaluation.created" or "session.completed"
+	InterviewID string                 `json:"interview_id"`
+	SessionID   string                 `json:"session_id,omitempty"`
+	Evaluation  *EvaluationResponseDTO `json:"evaluation,omitempty"` // evaluation.created only
+	Timestamp   apitime.Time           `json:"timestamp"`
+}
+
+// --- Admin DTOs ---
+
+// AIDebugCaptureResponseDTO lists captured provider exchanges keyed by provider name, oldest first
+type AIDebugCaptureResponseDTO struct {
+	Providers   map[string][]AIDebugExchangeDTO `json:"providers"`             // Empty when AI_DEBUG_CAPTURE is off
+	Concurrency *AIConcurrencyDTO               `json:"concurrency,omitempty"` // Current load against the AI concurrency cap
+}
+
+// AIConcurrencyDTO reports provider calls running and waiting for a slot
+type AIConcurrencyDTO struct {
+	MaxConcurrent int `json:"max_concurrent"`
+	InFlight      int `json:"in_flight"`
+	Queued        int `json:"queued"`
+}
+
+// AIDebugExchangeDTO is one captured provider call; payloads are redacted and truncated
+type AIDebugExchangeDTO struct {
+	Model     string       `json:"model,omitempty"`
+	Operation string       `json:"operation"` // "chat" or "evaluation"
+	Request   string       `json:"request"`
+	Response  string       `json:"response,omitempty"`
+	Error     string       `json:"error,omitempty"`
+	Timestamp apitime.Time `json:"timestamp"`
+}
+
+// AdminStatsResponseDTO aggregates evaluation outcomes for A/B comparisons between models
+type AdminStatsResponseDTO struct {
+	ScoresByModel []ModelScoreStatsDTO `json:"scores_by_model"`
+	InterviewCost *InterviewCostDTO    `json:"interview_cost,omitempty"` // Only when ?interview_id= is given
+	// Only when ?interview_id= is given: how question difficulty moved in each of the interview's sessions
+	DifficultyTrajectories []SessionDifficultyDTO `json:"difficulty_trajectories,omitempty"`
+	// Only when ?interview_id= is given: AI provider calls made for each of the interview's sessions
+	AIAttempts []SessionAIAttemptsDTO `json:"ai_attempts,omitempty"`
+	// Only when ?interview_id= is given: answer latencies against their questions' expected times, per session
+	AnswerTimings []SessionAnswerTimingsDTO `json:"answer_timings,omitempty"`
+	// Only when ?interview_id= is given and the interview has an evaluation: its percentile rank
+	EvaluationPercentile *EvaluationPercentileDTO `json:"evaluation_percentile,omitempty"`
+	// Recommendation decisions of current evaluations: interview type -> decision -> count
+	DecisionsByInterviewType map[string]map[string]int64 `json:"decisions_by_interview_type"`
+	// Average score of current evaluations per recorded hiring outcome
+	ScoresByOutcome []OutcomeScoreStatsDTO `json:"scores_by_outcome"`
+	// Average duration of ended chat sessions per interview type
+	SessionDurations []SessionDurationStatsDTO `json:"session_durations"`
+	// AI token usage and cost of this instance since it started, the same counters /metrics exports
+	AITokenUsage  []AITokenUsageDTO    `json:"ai_token_usage"`
+	Notifications NotificationStatsDTO `json:"notifications"`
+	// Latest store maintenance run with each table's size; omitted before the first
+	Maintenance *MaintenanceReportDTO `json:"maintenance,omitempty"`
+	Warnings    []WarningDTO          `json:"warnings,omitempty"` // e.g. a percentile left out for a small cohort
+}
+
+// EvaluationPercentileDTO ranks an evaluation's score within its reference cohort: current evaluations
+// of the same interview type and model from the last 90 days
+type EvaluationPercentileDTO struct {
+	EvaluationID string   `json:"evaluation_id"`
+	Score        float64  `json:"score"`
+	Percentile   *float64 `json:"percentile"`  // null below 5 evaluations in the cohort
+	CohortSize   int      `json:"cohort_size"` // Evaluations in the cohort, this one included
+}
+
+// NotificationStatsDTO counts the webhook notifications in the outbox by status
+type NotificationStatsDTO struct {
+	Pending   int64 `json:"pending"`   // Queue depth, retries included
+	Retrying  int64 `json:"retrying"`  // Pending after at least one failed attempt
+	Delivered int64 `json:"delivered"` // Delivered successfully
+	Failed    int64 `json:"failed"`    // Given up on after the maximum number of attempts
+}
+
+// SessionAIAttemptsDTO is the number of AI provider calls made for one chat session, retries included
+type SessionAIAttemptsDTO struct {
+	SessionID  string `json:"session_id"`
+	AIAttempts int    `json:"ai_attempts"`
+}
+
+// SessionAnswerTimingsDTO compares the answers of one chat session with their questions' expected times
+type SessionAnswerTimingsDTO struct {
+	SessionID string            `json:"session_id"`
+	Exchanges []AnswerTimingDTO `json:"exchanges"`
+}
+
+// AnswerTimingDTO is how long the candidate took to answer a question with an expected time
+type AnswerTimingDTO struct {
+	QuestionMessageID   string `json:"question_message_id"`
+	AnswerMessageID     string `json:"answer_message_id"`
+	ExpectedTimeMinutes int    `json:"expected_time_minutes"`
+	AnswerSeconds       int64  `json:"answer_seconds"` // From the question to the candidate's next message
+	Indicator           string `json:"indicator"`      // "over" or "under" the expected time
+}
+
+// SessionDifficultyDTO is the adaptive difficulty trajectory of one chat session
+type SessionDifficultyDTO struct {
+	SessionID  string `json:"session_id"`
+	Trajectory []int  `json:"trajectory"` // Levels from 1 (very easy) to 5 (very hard), starting with the initial level
+}
+
+// InterviewCostDTO is the estimated AI spend of one interview: its chat sessions plus every evaluation, superseded ones included
+type InterviewCostDTO struct {
+	InterviewID      string  `json:"interview_id"`
+	EstimatedCostUSD float64 `json:"estimated_cost_usd"`
+}
+
+// ModelScoreStatsDTO is the average score one AI model gave; superseded and unscored evaluations are excluded
+type ModelScoreStatsDTO struct {
+	Provider     string  `json:"provider"`
+	Model        string  `json:"model"`
+	Evaluations  int64   `json:"evaluations"`
+	AverageScore float64 `json:"average_score"`
+}
+
+// OutcomeScoreStatsDTO is the average AI score of interviews that ended with one hiring outcome
+type OutcomeScoreStatsDTO struct {
+	Outcome      string  `json:"outcome"`
+	Evaluations  int64   `json:"evaluations"`
+	AverageScore float64 `json:"average_score"`
+}
+
+// SessionDurationStatsDTO is the average duration of the ended chat sessions of one interview type
+type SessionDurationStatsDTO struct {
+	InterviewType          string  `json:"interview_type"`
+	Sessions               int64   `json:"sessions"`
+	AverageDurationSeconds float64 `json:"average_duration_seconds"`
+}
+
+// AITokenUsageDTO is the AI usage of one provider, model and interview type
+type AITokenUsageDTO struct {
+	Provider         string  `json:"provider"`
+	Model            string  `json:"model"`
+	InterviewType    string  `json:"interview_type"` // "unknown" for calls not made for an interview
+	PromptTokens     int64   `json:"prompt_tokens"`
+	CompletionTokens int64   `json:"completion_tokens"`
+	EstimatedCostUSD float64 `json:"estimated_cost_usd"`
+}
+
+// EvaluationBackfillResponseDTO reports one run of the evaluation backfill
+type EvaluationBackfillResponseDTO struct {
+	DryRun    bool                          `json:"dry_run"`
+	Succeeded int                           `json:"succeeded"`
+	Failed    int                           `json:"failed"`
+	Skipped   int                           `json:"skipped"`
+	Results   []EvaluationBackfillResultDTO `json:"results"`
+	Warnings  []WarningDTO                  `json:"warnings,omitempty"` // Query parameters that were ignored or clamped
+}
+
+// EvaluationBackfillResultDTO is the outcome for one completed session without an evaluation
+type EvaluationBackfillResultDTO struct {
+	SessionID    string `json:"session_id"`
+	InterviewID  string `json:"interview_id"`
+	Status       string `json:"status"` // "succeeded", "failed", "skipped", or "candidate" on a dry run
+	EvaluationID string `json:"evaluation_id,omitempty"`
+	Reason       string `json:"reason,omitempty"` // Why the session failed or was skipped
+}
+
+// CalibrateEvaluationsRequestDTO asks for the given sessions to be scored by every listed model
+type CalibrateEvaluationsRequestDTO struct {
+	SessionIDs []string              `json:"session_ids"`
+	Models     []CalibrationModelDTO `json:"models"`  // The first model is the baseline the others are compared with
+	Persist    bool                  `json:"persist"` // Save the run so it can be fetched again by ID
+}
+
+// CalibrationModelDTO names an AI provider and model
+type CalibrationModelDTO struct {
+	Provider string `json:"provider"`
+	Model    string `json:"model"`
+}
+
+// CalibrationRunResponseDTO is the score matrix of a calibration run and per-model statistics
+type CalibrationRunResponseDTO struct {
+	ID        string                     `json:"id,omitempty"` // Set when the run was persisted
+	Models    []CalibrationModelDTO      `json:"models"`
+	Sessions  []CalibrationSessionDTO    `json:"sessions"`
+	Stats     []CalibrationModelStatsDTO `json:"stats"`
+	CreatedAt *apitime.Time              `json:"created_at,omitempty"`
+	Warnings  []WarningDTO               `json:"warnings,omitempty"` // With ?include=percentile: models whose cohort was too small
+}
+
+// CalibrationSessionDTO is one row of the matrix: a session's score from each model, in models order
+type CalibrationSessionDTO struct {
+	SessionID string                `json:"session_id"`
+	Scores    []CalibrationScoreDTO `json:"scores"`
+}
+
+// CalibrationScoreDTO is the overall score one model gave a session, or why it has none
+type CalibrationScoreDTO struct {
+	Provider string   `json:"provider"`
+	Model    string   `json:"model"`
+	Score    *float64 `json:"score"`
+	Error    string   `json:"error,omitempty"`
+	// Only with ?include=percentile: rank of the score among the model's current evaluations of the
+	// session's interview type from the last 90 days; omitted below 5 of them
+	Percentile *float64 `json:"percentile,omitempty"`
+}
+
+// CalibrationModelStatsDTO summarizes one model's scores; differences are against the baseline
+// model over the sessions both scored
+type CalibrationModelStatsDTO struct {
+	Provider         string  `json:"provider"`
+	Model            string  `json:"model"`
+	Scored           int     `json:"scored"`
+	Failed           int     `json:"failed"`
+	MeanScore        float64 `json:"mean_score"`
+	ScoreStdDev      float64 `json:"score_stddev"`
+	MeanDifference   float64 `json:"mean_difference"`
+	DifferenceStdDev float64 `json:"difference_stddev"`
+}
+
+// VersionResponseDTO describes the running build and how the instance is configured
+type VersionResponseDTO struct {
+	Version      string          `json:"version"`
+	Commit       string          `json:"commit"`
+	BuildDate    string          `json:"build_date"`
+	GoVersion    string          `json:"go_version"`
+	Features     FeatureFlagsDTO `json:"features"`
+	StoreBackend string          `json:"store_backend"` // "memory" or "database"
+	ReadOnly     bool            `json:"read_only"`     // Writes are refused for maintenance
+}
+
+// ReadOnlyRequestDTO switches read-only maintenance mode
+type ReadOnlyRequestDTO struct {
+	ReadOnly *bool `json:"read_only"` // Required
+}
+
+// ReadOnlyResponseDTO reports whether read-only maintenance mode is on
+type ReadOnlyResponseDTO struct {
+	ReadOnly bool `json:"read_only"`
+}
+
+// FeatureFlagsDTO lists the optional features enabled on the instance
+type FeatureFlagsDTO struct {
+	Streaming    bool `json:"streaming"`     // Chat replies can be streamed
+	Webhooks     bool `json:"webhooks"`      // A global WEBHOOK_URL is configured; per-interview webhooks are always available
+	MultiTenancy bool `json:"multi_tenancy"` // TENANT_API_KEYS is set
+}
+
+// MetaResponseDTO lists the values clients validate against, read from the server's own registries
+type MetaResponseDTO struct {
+	InterviewTypes       []string        `json:"interview_types"`
+	DefaultInterviewType string          `json:"default_interview_type"`
+	InterviewModes       []string        `json:"interview_modes"`
+	Languages            []LanguageDTO   `json:"languages"`
+	DefaultLanguage      string          `json:"default_language"`
+	Difficulties         []DifficultyDTO `json:"difficulties"` // From easiest to hardest
+	DefaultDifficulty    int             `json:"default_difficulty"`
+	GreetingModes        []string        `json:"greeting_modes"`
+	InterviewStatuses    []string        `json:"interview_statuses"`
+	SessionStatuses      []string        `json:"session_statuses"`
+	EvaluationStatuses   []string        `json:"evaluation_statuses"`
+	Outcomes             []string        `json:"outcomes"`  // Hiring outcomes recruiters record
+	Decisions            []string        `json:"decisions"` // Evaluation recommendations, from strongest to weakest
+	ErrorCodes           []ErrorCode     `json:"error_codes"`
+	WarningCodes         []WarningCode   `json:"warning_codes"`
+	Limits               MetaLimitsDTO   `json:"limits"`
+}
+
+// LanguageDTO is a supported language
+type LanguageDTO struct {
+	Code string `json:"code"`
+	Name string `json:"name"` // In the language itself
+}
+
+// DifficultyDTO is an interview difficulty level
+type DifficultyDTO struct {
+	Level int    `json:"level"`
+	Name  string `json:"name"`
+}
+
+// MetaLimitsDTO are the instance's configured input limits; 0 means unlimited
+type MetaLimitsDTO struct {
+	MaxQuestionCount      int `json:"max_question_count"`
+	MaxQuestionLength     int `json:"max_question_length"`
+	MaxMessageLength      int `json:"max_message_length"` // Characters per candidate message
+	MaxMessagesPerSession int `json:"max_messages_per_session"`
+}
+
+// RoutesResponseDTO lists the routes the router serves
+type RoutesResponseDTO struct {
+	Routes []RouteDTO `json:"routes"`
+}
+
+// RouteDTO is one method and route pattern
+type RouteDTO struct {
+	Method  string `json:"method"`
+	Pattern string `json:"pattern"`
+}
+
+// WarningDTO is a non-fatal issue reported alongside a successful response (see warnings.go for the codes)
+type WarningDTO struct {
+	Code    WarningCode `json:"code"`
+	Message string      `json:"message"`
+	Field   string      `json:"field,omitempty"` // Request field or query parameter the warning is about
 }
 
 // --- Error DTO ---
 type ErrorResponseDTO struct {
-	Error   string `json:"error"`
-	Details string `json:"details,omitempty"`
+	Error   string    `json:"error"`
+	Code    ErrorCode `json:"code"`
+	Details string    `json:"details,omitempty"`
+}
+
+// healthServiceName identifies this service in health reports
+const healthServiceName = "ai_interview_backend"
+
+// HealthResponseDTO is the response of GET /health
+type HealthResponseDTO struct {
+	Status      string                `json:"status"` // "ok" or "unhealthy"
+	Service     string                `json:"service"`
+	ReadOnly    bool                  `json:"read_only,omitempty"`
+	Maintenance *MaintenanceStatusDTO `json:"maintenance,omitempty"` // Latest store maintenance run; omitted before the first
+}
+
+// MaintenanceStatusDTO is the outcome of the latest store maintenance run, as /health reports it
+type MaintenanceStatusDTO struct {
+	LastRunAt  apitime.Time `json:"last_run_at"`
+	DurationMS int64        `json:"duration_ms"`
+	Outcome    string       `json:"outcome"` // "ok" or "failed"; the error is logged
+}
+
+// MaintenanceReportDTO is the latest store maintenance run with what it found, for admins
+type MaintenanceReportDTO struct {
+	MaintenanceStatusDTO
+	Backend  string                `json:"backend,omitempty"`  // "database" or "memory"
+	Vacuumed []string              `json:"vacuumed,omitempty"` // Tables vacuumed and analyzed
+	Tables   []MaintenanceTableDTO `json:"tables,omitempty"`
+}
+
+// MaintenanceTableDTO is the size of one table after maintenance
+type MaintenanceTableDTO struct {
+	Table          string `json:"table"`
+	Entries        int64  `json:"entries"`         // Live rows, or stored entries on the memory backend
+	EstimatedBytes int64  `json:"estimated_bytes"` // On-disk size with indexes, or the JSON size of the entries in memory
 }
@@ -1,16 +1,52 @@
 package api
 
-import "net/http"
-
 // Centralized error messages and codes for API responses
 
 const (
 	ErrMsgMissingInterviewID  = "Bad Request: missing interview ID"
 	ErrMsgMissingEvaluationID = "Bad Request: missing evaluation ID"
 	ErrMsgMethodNotAllowed    = "Method Not Allowed"
+	ErrMsgInvalidLanguage     = "Invalid language code. Supported languages: en, zh-TW"
+	ErrMsgInvalidDetailLevel  = "Invalid detail_level. Supported levels: brief, standard, detailed"
+	ErrMsgInvalidGreeting     = "Invalid greeting. Supported greetings: ai, template, none"
+	ErrMsgInvalidOutcome      = "Invalid outcome. Supported outcomes: advanced, rejected, offer, hired"
+	ErrMsgMessageLimit        = "Chat session reached its message limit and has been completed"
+	ErrMsgAIBudgetExhausted   = "Chat session used up its AI attempts and has been completed"
+	ErrMsgAIOverloaded        = "AI service is busy, please retry shortly"
+	ErrMsgSessionNotFound     = "Chat session not found"
 )
 
+// ErrorCode is a stable, machine-readable identifier included in every error response
+// Clients should branch on the code rather than on the human-readable message
+type ErrorCode string
+
 const (
-	ErrCodeBadRequest       = http.StatusBadRequest
-	ErrCodeMethodNotAllowed = http.StatusMethodNotAllowed
+	ErrCodeInvalidJSON       ErrorCode = "invalid_json"        // Request body could not be decoded
+	ErrCodeValidationFailed  ErrorCode = "validation_failed"   // Request is well-formed but has missing or invalid fields
+	ErrCodeUnauthorized      ErrorCode = "unauthorized"        // Missing or invalid credentials
+	ErrCodeForbidden         ErrorCode = "forbidden"           // Access is not permitted
+	ErrCodeNotFound          ErrorCode = "not_found"           // Referenced resource does not exist
+	ErrCodeMethodNotAllowed  ErrorCode = "method_not_allowed"  // Route exists but does not serve the request method
+	ErrCodeConflict          ErrorCode = "conflict"            // Request conflicts with the current resource state
+	ErrCodeRateLimited       ErrorCode = "rate_limited"        // Too many requests
+	ErrCodeTooEarly          ErrorCode = "too_early"           // Interview's scheduling window has not opened yet
+	ErrCodeExpired           ErrorCode = "expired"             // Interview's scheduling window (plus grace) has closed
+	ErrCodeAIUnavailable     ErrorCode = "ai_unavailable"      // AI provider failed to produce a response
+	ErrCodeAIBudgetExhausted ErrorCode = "ai_budget_exhausted" // Chat session used up its AI attempts
+	ErrCodeAIOverloaded      ErrorCode = "ai_overloaded"       // Too many AI requests in flight; retry after Retry-After
+	ErrCodeQuestionsPending  ErrorCode = "questions_pending"   // Interview questions are still being generated
+	ErrCodeQuestionsFailed   ErrorCode = "questions_failed"    // Interview question generation failed; retry it
+	ErrCodeReadOnly          ErrorCode = "read_only_mode"      // Instance is in maintenance read-only mode; retry the write later
+	ErrCodeMessageTooSoon    ErrorCode = "message_too_soon"    // Candidate message sent sooner after the previous one than allowed
+	ErrCodeDuplicateMessage  ErrorCode = "duplicate_message"   // Candidate message nearly repeats one of their recent messages
+	ErrCodeSessionHourlyCap  ErrorCode = "session_hourly_cap"  // Chat session reached its candidate messages per hour
+	ErrCodeInternal          ErrorCode = "internal"            // Unexpected server-side failure
 )
+
+// errorCodes lists every error code, as GET /api/meta reports them
+var errorCodes = []ErrorCode{
+	ErrCodeInvalidJSON, ErrCodeValidationFailed, ErrCodeUnauthorized, ErrCodeForbidden, ErrCodeNotFound,
+	ErrCodeMethodNotAllowed, ErrCodeConflict, ErrCodeRateLimited, ErrCodeTooEarly, ErrCodeExpired,
+	ErrCodeAIUnavailable, ErrCodeAIBudgetExhausted, ErrCodeAIOverloaded, ErrCodeQuestionsPending, ErrCodeQuestionsFailed,
+	ErrCodeReadOnly, ErrCodeMessageTooSoon, ErrCodeDuplicateMessage, ErrCodeSessionHourlyCap, ErrCodeInternal,
+}
@@ -0,0 +1,82 @@
+// Evaluation traces: the prompt and output behind each evaluation, for reproducing disputed scores
+package api
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/zidane0000/ai-interview-platform/ai"
+	"github.com/zidane0000/ai-interview-platform/api/apitime"
+	"github.com/zidane0000/ai-interview-platform/data"
+	"github.com/zidane0000/ai-interview-platform/utils"
+)
+
+// Size caps of the texts of an evaluation trace, in characters; together they bound a trace to
+// about 64k characters
+const (
+	traceMaxPromptChars    = 24000
+	traceMaxAnswersChars   = 32000
+	traceMaxRawOutputChars = 8000
+)
+
+// recordEvaluationTrace stores what result was generated from as the trace of evaluation
+// A trace that fails to store is logged; the evaluation itself stands.
+func recordEvaluationTrace(store data.Store, evaluation *data.Evaluation, result *ai.EvaluationResponse) {
+	trace := newEvaluationTrace(evaluation, result)
+	if err := store.CreateEvaluationTrace(trace); err != nil {
+		utils.Errorf("Failed to store the trace of evaluation %s: %v", evaluation.ID, err)
+	}
+}
+
+// newEvaluationTrace builds the trace of evaluation from the prompt trace of result. Texts are
+// redacted before they are capped, so a cut cannot expose part of a secret.
+func newEvaluationTrace(evaluation *data.Evaluation, result *ai.EvaluationResponse) *data.EvaluationTrace {
+	prompt := result.PromptTrace()
+	trace := &data.EvaluationTrace{
+		EvaluationID:     evaluation.ID,
+		TenantID:         evaluation.TenantID,
+		Provider:         result.Provider,
+		Model:            result.Model,
+		Temperature:      prompt.Temperature,
+		PromptTokens:     result.TokensUsed.PromptTokens,
+		CompletionTokens: result.TokensUsed.CompletionTokens,
+	}
+	var cut [3]bool
+	trace.Prompt, cut[0] = capTraceText(utils.Redact(prompt.Prompt), traceMaxPromptChars)
+	trace.Answers, cut[1] = capTraceText(utils.Redact(prompt.Answers), traceMaxAnswersChars)
+	trace.RawOutput, cut[2] = capTraceText(utils.Redact(prompt.RawOutput), traceMaxRawOutputChars)
+	trace.Truncated = cut[0] || cut[1] || cut[2]
+	return trace
+}
+
+// capTraceText cuts text to maxChars characters, marking how much was dropped
+func capTraceText(text string, maxChars int) (string, bool) {
+	runes := []rune(text)
+	if len(runes) <= maxChars {
+		return text, false
+	}
+	return string(runes[:maxChars]) + fmt.Sprintf("\n[truncated %d characters]", len(runes)-maxChars), true
+}
+
+// GetEvaluationTraceHandler handles GET /evaluation/{id}/trace, behind admin auth
+func (deps *HandlerDependencies) GetEvaluationTraceHandler(w http.ResponseWriter, r *http.Request) {
+	trace, err := deps.Store.WithContext(r.Context()).GetEvaluationTrace(chi.URLParam(r, "id"))
+	if err != nil {
+		writeJSONError(w, http.StatusNotFound, ErrCodeNotFound, "Evaluation trace not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, EvaluationTraceResponseDTO{
+		EvaluationID:     trace.EvaluationID,
+		Prompt:           trace.Prompt,
+		Answers:          trace.Answers,
+		RawOutput:        trace.RawOutput,
+		Provider:         trace.Provider,
+		Model:            trace.Model,
+		Temperature:      trace.Temperature,
+		PromptTokens:     trace.PromptTokens,
+		CompletionTokens: trace.CompletionTokens,
+		Truncated:        trace.Truncated,
+		CreatedAt:        apitime.New(trace.CreatedAt),
+	})
+}
@@ -0,0 +1,126 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/zidane0000/ai-interview-platform/ai"
+	"github.com/zidane0000/ai-interview-platform/data"
+	"github.com/zidane0000/ai-interview-platform/internal/testsupport"
+)
+
+// getEvaluationTrace fetches the trace of an evaluation with token as the bearer token
+func getEvaluationTrace(router http.Handler, evaluationID, token string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest("GET", "/api/evaluation/"+evaluationID+"/trace", nil)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+// decodeEvaluationTrace checks w is a trace and decodes it
+func decodeEvaluationTrace(t *testing.T, w *httptest.ResponseRecorder) EvaluationTraceResponseDTO {
+	t.Helper()
+	var trace EvaluationTraceResponseDTO
+	if err := json.Unmarshal(w.Body.Bytes(), &trace); err != nil || w.Code != http.StatusOK {
+		t.Fatalf("expected the trace, got %d: %s", w.Code, w.Body.String())
+	}
+	return trace
+}
+
+func TestEvaluationTrace_SubmitPath(t *testing.T) {
+	router := setupTestRouterWithProvider(ai.NewMockProvider(), func(deps *HandlerDependencies) {
+		deps.AdminToken = "admin-secret"
+	})
+	interview := createTestInterview(t, router, testsupport.NewInterviewBuilder().WithQuestionTexts("How do you test Go code?"))
+	w := submitEvaluation(t, router, "", interview.ID, "Table tests, reach me at jane@example.com")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var evaluation EvaluationResponseDTO
+	if err := json.Unmarshal(w.Body.Bytes(), &evaluation); err != nil {
+		t.Fatalf("failed to unmarshal evaluation: %v", err)
+	}
+
+	trace := decodeEvaluationTrace(t, getEvaluationTrace(router, evaluation.ID, "admin-secret"))
+	if trace.EvaluationID != evaluation.ID || trace.Provider != evaluation.Provider || trace.Model != evaluation.Model {
+		t.Errorf("expected the trace of %s by %s/%s, got %+v", evaluation.ID, evaluation.Provider, evaluation.Model, trace)
+	}
+	if !strings.Contains(trace.Prompt, "You are an expert interview evaluator") {
+		t.Errorf("expected the rendered evaluation prompt, got %q", trace.Prompt)
+	}
+	if !strings.Contains(trace.Answers, "Q1: How do you test Go code?") || strings.Contains(trace.Answers, "jane@example.com") {
+		t.Errorf("expected the redacted answer block, got %q", trace.Answers)
+	}
+	if trace.Temperature != 0.3 || trace.Truncated {
+		t.Errorf("expected an untruncated trace at temperature 0.3, got %v and %v", trace.Temperature, trace.Truncated)
+	}
+
+	// The trace stays out of the evaluation itself
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/api/evaluation/"+evaluation.ID, nil))
+	if strings.Contains(w.Body.String(), "expert interview evaluator") {
+		t.Errorf("expected no trace in the evaluation response, got %s", w.Body.String())
+	}
+}
+
+func TestEvaluationTrace_SessionEndPath(t *testing.T) {
+	router, _, evaluation := setupReopenTest(t, nil)
+	trace := decodeEvaluationTrace(t, getEvaluationTrace(router, evaluation.ID, "admin-secret"))
+	if trace.EvaluationID != evaluation.ID || !strings.Contains(trace.Answers, "A1: ok") {
+		t.Errorf("expected the trace of the session's evaluation, got %+v", trace)
+	}
+
+	// Sessions ended without answers never reach the provider, so there is nothing to trace
+	interview := createTestInterview(t, router, testsupport.NewInterviewBuilder().WithQuestions(1))
+	session := startChatSession(t, router, testsupport.NewSessionBuilder().ForInterviewID(interview.ID))
+	unanswered := endSession(t, router, session.ID)
+	if w := getEvaluationTrace(router, unanswered.ID, "admin-secret"); w.Code != http.StatusNotFound {
+		t.Errorf("expected 404 for an evaluation without answers, got %d", w.Code)
+	}
+}
+
+func TestEvaluationTrace_AdminOnly(t *testing.T) {
+	router, _, evaluation := setupReopenTest(t, nil)
+	for _, token := range []string{"", "wrong"} {
+		if w := getEvaluationTrace(router, evaluation.ID, token); w.Code != http.StatusUnauthorized {
+			t.Errorf("token %q: expected 401, got %d", token, w.Code)
+		}
+	}
+
+	unconfigured := setupTestRouterWithProvider(ai.NewMockProvider(), nil)
+	if w := getEvaluationTrace(unconfigured, evaluation.ID, "admin-secret"); w.Code != http.StatusForbidden {
+		t.Errorf("expected 403 without an admin token configured, got %d", w.Code)
+	}
+}
+
+func TestNewEvaluationTrace_Caps(t *testing.T) {
+	result := &ai.EvaluationResponse{
+		Provider:   "openai",
+		Model:      "gpt-4o",
+		TokensUsed: ai.TokenUsage{PromptTokens: 900, CompletionTokens: 120},
+		Metadata: map[string]interface{}{
+			ai.MetadataEvaluationPrompt:  "Evaluate jane@example.com",
+			ai.MetadataEvaluationAnswers: "Q1: ...",
+			ai.MetadataTemperature:       0.3,
+			ai.MetadataRawOutput:         strings.Repeat("x", traceMaxRawOutputChars+10),
+		},
+	}
+	trace := newEvaluationTrace(&data.Evaluation{ID: "evaluation-1", TenantID: "acme"}, result)
+
+	if trace.EvaluationID != "evaluation-1" || trace.TenantID != "acme" || trace.PromptTokens != 900 || trace.CompletionTokens != 120 {
+		t.Errorf("expected the evaluation's IDs and token usage, got %+v", trace)
+	}
+	if strings.Contains(trace.Prompt, "jane@example.com") {
+		t.Errorf("expected the prompt redacted, got %q", trace.Prompt)
+	}
+	if !trace.Truncated || !strings.HasSuffix(trace.RawOutput, "\n[truncated 10 characters]") ||
+		len(trace.RawOutput) != traceMaxRawOutputChars+len("\n[truncated 10 characters]") {
+		t.Errorf("expected the raw output cut with a marker, got %d characters ending %q", len(trace.RawOutput), trace.RawOutput[len(trace.RawOutput)-30:])
+	}
+}
@@ -0,0 +1,89 @@
+// Evaluation translations: the feedback of an evaluation in languages besides the interview's
+package api
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/zidane0000/ai-interview-platform/ai"
+	"github.com/zidane0000/ai-interview-platform/data"
+	"github.com/zidane0000/ai-interview-platform/utils"
+)
+
+// feedbackLanguages resolves the additional feedback languages of a request: the requested ones when
+// the request chose any (an empty choice means none), otherwise the configured default. ok is false
+// when a requested language is unsupported.
+func (deps *HandlerDependencies) feedbackLanguages(requested []string, chosen bool) (languages []string, ok bool) {
+	if !chosen {
+		return deps.AdditionalFeedbackLanguages, true
+	}
+	for _, language := range requested {
+		if !data.ValidateLanguage(language) {
+			return nil, false
+		}
+		if !slices.Contains(languages, language) {
+			languages = append(languages, language)
+		}
+	}
+	return languages, true
+}
+
+// feedbackLanguagesQuery reads ?additional_feedback_languages=, a comma-separated list, with whether
+// the parameter was given at all
+func feedbackLanguagesQuery(r *http.Request) ([]string, bool) {
+	values, chosen := r.URL.Query()["additional_feedback_languages"]
+	var languages []string
+	for _, value := range values {
+		for _, language := range strings.Split(value, ",") {
+			if language = strings.TrimSpace(language); language != "" {
+				languages = append(languages, language)
+			}
+		}
+	}
+	return languages, chosen
+}
+
+// translateEvaluation adds to evaluation the prose of result translated into each of languages
+// other than primary, the language it was written in. Scores are never re-derived. A translation
+// that fails is recorded on the evaluation, which surfaces it as a warning, and the evaluation
+// stands without it.
+func translateEvaluation(ctx context.Context, aiClient *ai.AIClient, evaluation *data.Evaluation, result *ai.EvaluationResponse, primary string, languages []string) {
+	for _, language := range languages {
+		if language == primary {
+			continue
+		}
+		translation, err := aiClient.TranslateEvaluation(ctx, result, language)
+		if err != nil {
+			utils.Errorf("Failed to translate evaluation %s into %s: %v", evaluation.ID, language, err)
+			evaluation.TranslationFailures = append(evaluation.TranslationFailures, language)
+			continue
+		}
+		if evaluation.Translations == nil {
+			evaluation.Translations = make(data.EvaluationTranslations)
+		}
+		evaluation.Translations[language] = data.EvaluationTranslation{
+			Feedback:   translation.Feedback,
+			Strengths:  translation.Strengths,
+			Weaknesses: translation.Weaknesses,
+		}
+		evaluation.EstimatedCostUSD += translation.EstimatedCostUSD
+	}
+}
+
+// toEvaluationTranslationDTOs converts the stored translations of an evaluation
+func toEvaluationTranslationDTOs(translations data.EvaluationTranslations) map[string]EvaluationTranslationDTO {
+	if len(translations) == 0 {
+		return nil
+	}
+	dtos := make(map[string]EvaluationTranslationDTO, len(translations))
+	for language, translation := range translations {
+		dtos[language] = EvaluationTranslationDTO{
+			Feedback:   translation.Feedback,
+			Strengths:  translation.Strengths,
+			Weaknesses: translation.Weaknesses,
+		}
+	}
+	return dtos
+}
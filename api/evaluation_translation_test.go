@@ -0,0 +1,115 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/zidane0000/ai-interview-platform/ai"
+	"github.com/zidane0000/ai-interview-platform/internal/testsupport"
+)
+
+// submitTranslatedEvaluation submits an answer to the interview's first question, replacing any
+// evaluation it has, with the given additional feedback languages
+func submitTranslatedEvaluation(router http.Handler, interviewID string, languages []string) *httptest.ResponseRecorder {
+	body, _ := json.Marshal(SubmitEvaluationRequestDTO{
+		InterviewID:                 interviewID,
+		Answers:                     map[string]string{"question_0": "Table tests and fuzzing"},
+		AdditionalFeedbackLanguages: languages,
+	})
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("POST", "/api/evaluation?replace=true", bytes.NewReader(body)))
+	return w
+}
+
+// decodeEvaluation checks w is a successful evaluation response and decodes it
+func decodeEvaluation(t *testing.T, w *httptest.ResponseRecorder) EvaluationResponseDTO {
+	t.Helper()
+	var evaluation EvaluationResponseDTO
+	if err := json.Unmarshal(w.Body.Bytes(), &evaluation); err != nil || w.Code != http.StatusOK {
+		t.Fatalf("expected the evaluation, got %d: %s", w.Code, w.Body.String())
+	}
+	return evaluation
+}
+
+func TestSubmitEvaluation_AdditionalFeedbackLanguages(t *testing.T) {
+	router := setupTestRouterWithProvider(ai.NewMockProvider(), func(deps *HandlerDependencies) {
+		deps.AdditionalFeedbackLanguages = []string{"zh-TW"}
+	})
+	interview := createTestInterview(t, router, testsupport.NewInterviewBuilder().WithQuestions(1))
+
+	// Without a choice the configured default applies
+	evaluation := decodeEvaluation(t, submitTranslatedEvaluation(router, interview.ID, nil))
+	translation, ok := evaluation.Translations["zh-TW"]
+	if !ok || len(evaluation.Translations) != 1 {
+		t.Fatalf("expected a zh-TW translation only, got %+v", evaluation.Translations)
+	}
+	if translation.Feedback != "[MOCK zh-TW] "+evaluation.Feedback {
+		t.Errorf("expected the feedback translated, got %q for %q", translation.Feedback, evaluation.Feedback)
+	}
+	for _, point := range append(translation.Strengths, translation.Weaknesses...) {
+		if !strings.HasPrefix(point, "[MOCK zh-TW] ") {
+			t.Errorf("expected every point translated, got %q", point)
+		}
+	}
+
+	// The interview's own language is not translated, and an empty choice means none
+	for _, languages := range [][]string{{"en"}, {}} {
+		if evaluation := decodeEvaluation(t, submitTranslatedEvaluation(router, interview.ID, languages)); evaluation.Translations != nil {
+			t.Errorf("languages %v: expected no translations, got %+v", languages, evaluation.Translations)
+		}
+	}
+
+	w := submitTranslatedEvaluation(router, interview.ID, []string{"fr"})
+	assertRefused(t, w, http.StatusBadRequest, ErrCodeValidationFailed)
+}
+
+func TestEndChatSession_AdditionalFeedbackLanguages(t *testing.T) {
+	router := setupTestRouterWithProvider(ai.NewMockProvider(), nil)
+	interview := createTestInterview(t, router, testsupport.NewInterviewBuilder().WithQuestions(3))
+	session := startChatSession(t, router, testsupport.NewSessionBuilder().
+		ForInterviewID(interview.ID).
+		WithTranscript(testsupport.Pair("", "I test with table tests")))
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("POST", "/api/chat/"+session.ID+"/end?additional_feedback_languages=zh-TW", nil))
+	ended := decodeEvaluation(t, w)
+	if !strings.HasPrefix(ended.Translations["zh-TW"].Feedback, "[MOCK zh-TW] ") {
+		t.Fatalf("expected a zh-TW translation, got %+v", ended.Translations)
+	}
+
+	// The translation is stored with the evaluation
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/api/evaluation/"+ended.ID, nil))
+	if stored := decodeEvaluation(t, w); stored.Translations["zh-TW"].Feedback != ended.Translations["zh-TW"].Feedback {
+		t.Errorf("expected the stored translation, got %+v", stored.Translations)
+	}
+}
+
+func TestEndChatSession_TranslationFailure(t *testing.T) {
+	provider := ai.NewMockProvider()
+	router := setupTestRouterWithProvider(provider, nil)
+	interview := createTestInterview(t, router, testsupport.NewInterviewBuilder().WithQuestions(3))
+	session := startChatSession(t, router, testsupport.NewSessionBuilder().
+		ForInterviewID(interview.ID).
+		WithTranscript(testsupport.Pair("", "I test with table tests")))
+	before := provider.Calls(ai.MockOpEvaluation)
+	provider.PlanFaults(ai.MockFault{Op: ai.MockOpChat, Call: provider.Calls(ai.MockOpChat) + 1})
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("POST", "/api/chat/"+session.ID+"/end?additional_feedback_languages=zh-TW", nil))
+	evaluation := decodeEvaluation(t, w)
+	if evaluation.Status != "completed" || evaluation.Feedback == "" || evaluation.Translations != nil {
+		t.Errorf("expected the primary evaluation without translations, got %+v", evaluation)
+	}
+	if len(evaluation.Warnings) != 1 || evaluation.Warnings[0].Code != WarnCodeTranslationFailed {
+		t.Errorf("expected a translation_failed warning, got %+v", evaluation.Warnings)
+	}
+	// The failure doesn't re-run the evaluation
+	if got := provider.Calls(ai.MockOpEvaluation) - before; got != 1 {
+		t.Errorf("expected 1 evaluation call, got %d", got)
+	}
+}
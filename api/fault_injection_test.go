@@ -0,0 +1,140 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/zidane0000/ai-interview-platform/ai"
+	"github.com/zidane0000/ai-interview-platform/data"
+	"github.com/zidane0000/ai-interview-platform/internal/testsupport"
+)
+
+// newFaultyTestRouter creates a test router whose memory store fails operations as policy decides
+func newFaultyTestRouter(policy *data.FaultPolicy, provider ai.AIProvider) *testRouter {
+	return newTestRouter(data.NewHybridStoreWithMemory(data.NewMemoryStore(data.WithFaultPolicy(policy))), provider, nil)
+}
+
+// decodeError decodes the error response in w after checking its status and code
+func decodeError(t *testing.T, w *httptest.ResponseRecorder, status int, code ErrorCode) ErrorResponseDTO {
+	t.Helper()
+	var resp ErrorResponseDTO
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to unmarshal error response: %v", err)
+	}
+	if w.Code != status || resp.Code != code {
+		t.Fatalf("expected %d %s, got %d: %s", status, code, w.Code, w.Body.String())
+	}
+	return resp
+}
+
+func TestFaultInjection_GreetingFails(t *testing.T) {
+	provider := ai.NewMockProvider()
+	router := newFaultyTestRouter(data.NewFaultPolicy(), provider)
+	interview := createTestInterview(t, router, testsupport.NewInterviewBuilder().WithQuestions(2))
+
+	// Starting the chat fails, leaving an active session without messages for retry-ai
+	provider.PlanFaults(ai.MockFault{Op: ai.MockOpChat, Call: provider.Calls(ai.MockOpChat) + 1})
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("POST", "/api/interviews/"+interview.ID+"/chat/start", nil))
+	decodeError(t, w, http.StatusInternalServerError, ErrCodeAIUnavailable)
+
+	sessions, err := router.store.GetChatSessionsByInterview(interview.ID)
+	if err != nil || len(sessions) != 1 || sessions[0].Status != "active" {
+		t.Fatalf("expected one active session after the failed greeting, got %v (%v)", sessions, err)
+	}
+	if messages, _ := router.store.GetChatMessages(sessions[0].ID); len(messages) != 0 {
+		t.Fatalf("expected no messages after the failed greeting, got %d", len(messages))
+	}
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("POST", "/api/chat/"+sessions[0].ID+"/retry-ai", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected retry-ai to generate the greeting, got %d: %s", w.Code, w.Body.String())
+	}
+	var session ChatInterviewSessionDTO
+	if err := json.Unmarshal(w.Body.Bytes(), &session); err != nil {
+		t.Fatalf("failed to unmarshal session: %v", err)
+	}
+	if len(session.Messages) != 1 || session.Messages[0].Subtype != data.MessageSubtypeGreeting {
+		t.Errorf("expected the greeting after retry-ai, got %+v", session.Messages)
+	}
+
+	// /interviews/start keeps the session and reports the greeting as pending; a typed provider
+	// error keeps its own status on /chat/start
+	provider.PlanFaults(ai.MockFault{Op: ai.MockOpChat, Call: provider.Calls(ai.MockOpChat) + 1})
+	resp := decodeStartInterview(t, startInterview(router, `{"candidate_name":"Bob","questions":["Why Go?"],"interview_type":"technical"}`))
+	if !resp.GreetingPending || len(resp.Session.Messages) != 0 || resp.Session.Status != "active" {
+		t.Errorf("expected an active session with a pending greeting, got %+v (pending %v)", resp.Session, resp.GreetingPending)
+	}
+	provider.PlanFaults(ai.MockFault{Op: ai.MockOpChat, Call: provider.Calls(ai.MockOpChat) + 1, Err: ai.ErrOverloaded})
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("POST", "/api/interviews/"+interview.ID+"/chat/start", nil))
+	if w.Code != http.StatusServiceUnavailable {
+		t.Errorf("expected 503 for an overloaded provider, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestFaultInjection_AIMessageSaveFails(t *testing.T) {
+	policy := data.NewFaultPolicy()
+	router := newFaultyTestRouter(policy, ai.NewMockProvider())
+	ids := createTestInterviewAndSession(t, router)
+
+	// The user message is stored and the AI reply is not
+	policy.Add(data.Fault{Op: "AddChatMessageWithLimit", Call: policy.Calls("AddChatMessageWithLimit") + 2})
+	body := `{"message":"I like Go","client_message_id":"6f1c2a9e-4b7d-4c1e-9a2f-3d8e5b7c1a04"}`
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("POST", "/api/chat/"+ids.SessionID+"/message", strings.NewReader(body)))
+	decodeError(t, w, http.StatusInternalServerError, ErrCodeInternal)
+
+	messages, _ := router.store.GetChatMessages(ids.SessionID)
+	if len(messages) != 2 || messages[1].Type != "user" {
+		t.Fatalf("expected the greeting and the stored answer, got %d messages", len(messages))
+	}
+
+	// Resending the same client message continues from the stored answer instead of duplicating it
+	resp := sendMessageRaw(t, router, ids.SessionID, body)
+	if resp.Message.ID != messages[1].ID || resp.AIResponse == nil {
+		t.Errorf("expected the stored answer with a new AI reply, got %+v", resp)
+	}
+	if messages, _ = router.store.GetChatMessages(ids.SessionID); len(messages) != 3 {
+		t.Errorf("expected the greeting, the answer and the reply, got %d messages", len(messages))
+	}
+}
+
+func TestFaultInjection_EvaluationSaveFails(t *testing.T) {
+	policy := data.NewFaultPolicy(data.Fault{Op: "CreateEvaluation", Call: 1})
+	provider := ai.NewMockProvider()
+	router := newFaultyTestRouter(policy, provider)
+	interview := createTestInterview(t, router, testsupport.NewInterviewBuilder().WithQuestions(3))
+	session := startChatSession(t, router, testsupport.NewSessionBuilder().
+		ForInterviewID(interview.ID).
+		WithTranscript(testsupport.Pair("", "ok")))
+
+	// The session is completed although its evaluation was lost after the AI produced it
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("POST", "/api/chat/"+session.ID+"/end", nil))
+	if resp := decodeError(t, w, http.StatusInternalServerError, ErrCodeInternal); resp.Error != "Failed to save evaluation" {
+		t.Errorf("expected the save failure, got %q", resp.Error)
+	}
+	stored, err := router.store.GetChatSession(session.ID)
+	if err != nil || stored.Status != "completed" {
+		t.Fatalf("expected the session to be completed, got %+v (%v)", stored, err)
+	}
+	if _, err := router.store.GetLatestEvaluationByInterview(interview.ID); err == nil {
+		t.Fatal("expected no evaluation after the failed save")
+	}
+
+	// Ending it again evaluates it exactly once
+	evaluation := endSession(t, router, session.ID)
+	if calls := provider.Calls(ai.MockOpEvaluation); calls != 2 {
+		t.Errorf("expected the provider to evaluate twice, got %d", calls)
+	}
+	latest, err := router.store.GetLatestEvaluationByInterview(interview.ID)
+	if err != nil || latest.ID != evaluation.ID {
+		t.Errorf("expected the second evaluation to be stored, got %+v (%v)", latest, err)
+	}
+	assertErrorResponse(t, router, "POST", "/api/chat/"+session.ID+"/end", "", http.StatusConflict, ErrCodeConflict)
+}
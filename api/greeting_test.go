@@ -0,0 +1,139 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/zidane0000/ai-interview-platform/ai"
+	"github.com/zidane0000/ai-interview-platform/data"
+	"github.com/zidane0000/ai-interview-platform/internal/testsupport"
+)
+
+// startWithGreeting starts a chat session of the interview with the given greeting mode
+func startWithGreeting(router http.Handler, interviewID, greeting string) *httptest.ResponseRecorder {
+	body, _ := json.Marshal(StartChatSessionRequestDTO{Greeting: greeting})
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("POST", "/api/interviews/"+interviewID+"/chat/start", bytes.NewReader(body)))
+	return w
+}
+
+// decodeStartedSession checks w is a started session and decodes it
+func decodeStartedSession(t *testing.T, w *httptest.ResponseRecorder) ChatInterviewSessionDTO {
+	t.Helper()
+	var session ChatInterviewSessionDTO
+	if err := json.Unmarshal(w.Body.Bytes(), &session); err != nil || w.Code != http.StatusCreated {
+		t.Fatalf("expected the session started, got %d: %s", w.Code, w.Body.String())
+	}
+	return session
+}
+
+func TestStartChatSession_GreetingModes(t *testing.T) {
+	tests := []struct {
+		name      string
+		greeting  string
+		language  string
+		chatCalls int
+		expected  string // Opening message; empty for none
+	}{
+		{name: "default", greeting: "", chatCalls: 1, expected: "[MOCK] Interview response - This is a test mock response"},
+		{name: "ai", greeting: data.GreetingModeAI, chatCalls: 1, expected: "[MOCK] Interview response - This is a test mock response"},
+		{name: "template en", greeting: data.GreetingModeTemplate, language: data.LanguageEnglish, expected: "Hi Jane Doe, welcome to your technical interview."},
+		{name: "template zh-TW", greeting: data.GreetingModeTemplate, language: data.LanguageTraditionalChinese, expected: "Jane Doe您好，歡迎參加這場技術面試。"},
+		{name: "none", greeting: data.GreetingModeNone},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := ai.NewMockProvider()
+			router := setupTestRouterWithProvider(provider, nil)
+			interview := createTestInterview(t, router, testsupport.NewInterviewBuilder().
+				WithCandidate("Jane Doe").
+				WithType(data.InterviewTypeTechnical).
+				WithLanguage(tt.language).
+				WithQuestions(2))
+
+			session := decodeStartedSession(t, startWithGreeting(router, interview.ID, tt.greeting))
+			if calls := len(provider.ChatRequests()); calls != tt.chatCalls {
+				t.Errorf("expected %d provider calls, got %d", tt.chatCalls, calls)
+			}
+			wantMode := tt.greeting
+			if wantMode == "" {
+				wantMode = data.GreetingModeAI
+			}
+			if session.Greeting != wantMode {
+				t.Errorf("expected greeting %q, got %q", wantMode, session.Greeting)
+			}
+			if tt.expected == "" {
+				if len(session.Messages) != 0 || session.Progress == nil {
+					t.Errorf("expected an empty transcript with progress, got %+v", session)
+				}
+				return
+			}
+			if len(session.Messages) != 1 || session.Messages[0].Subtype != data.MessageSubtypeGreeting || !strings.HasPrefix(session.Messages[0].Content, tt.expected) {
+				t.Errorf("expected the greeting %q, got %+v", tt.expected, session.Messages)
+			}
+		})
+	}
+
+	router := setupTestRouterWithProvider(ai.NewMockProvider(), nil)
+	interview := createTestInterview(t, router, testsupport.NewInterviewBuilder())
+	assertRefused(t, startWithGreeting(router, interview.ID, "scripted"), http.StatusBadRequest, ErrCodeValidationFailed)
+}
+
+func TestStartChatSession_ConfiguredGreeting(t *testing.T) {
+	provider := ai.NewMockProvider()
+	router := setupTestRouterWithProvider(provider, func(deps *HandlerDependencies) {
+		deps.GreetingMode = data.GreetingModeTemplate
+	})
+	interview := createTestInterview(t, router, testsupport.NewInterviewBuilder().WithQuestions(2))
+
+	// The configured mode applies unless the request chooses
+	if session := decodeStartedSession(t, startWithGreeting(router, interview.ID, "")); session.Greeting != data.GreetingModeTemplate || len(provider.ChatRequests()) != 0 {
+		t.Errorf("expected a template greeting without provider calls, got %q and %d calls", session.Greeting, len(provider.ChatRequests()))
+	}
+	if session := decodeStartedSession(t, startWithGreeting(router, interview.ID, data.GreetingModeAI)); session.Greeting != data.GreetingModeAI || len(provider.ChatRequests()) != 1 {
+		t.Errorf("expected an AI greeting, got %q and %d calls", session.Greeting, len(provider.ChatRequests()))
+	}
+}
+
+func TestTemplateGreeting_EvaluationPairing(t *testing.T) {
+	router := setupTestRouterWithProvider(ai.NewMockProvider(), nil)
+	interview := createTestInterview(t, router, testsupport.NewInterviewBuilder().WithQuestions(2))
+	session := decodeStartedSession(t, startWithGreeting(router, interview.ID, data.GreetingModeTemplate))
+	sendMessage(t, router, session.ID, "I build backend services in Go")
+
+	// The canned greeting asks the opening question the first answer responds to
+	evaluation := endSession(t, router, session.ID)
+	if len(evaluation.AnswersV2) != 1 || evaluation.AnswersV2[0].Question != session.Messages[0].Content {
+		t.Errorf("expected the answer paired with the greeting, got %+v", evaluation.AnswersV2)
+	}
+}
+
+func TestNoGreeting_FirstTurnAfterCandidate(t *testing.T) {
+	provider := ai.NewMockProvider()
+	router := setupTestRouterWithProvider(provider, nil)
+	interview := createTestInterview(t, router, testsupport.NewInterviewBuilder().WithQuestions(2))
+	session := decodeStartedSession(t, startWithGreeting(router, interview.ID, data.GreetingModeNone))
+
+	// There is no greeting to retry
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("POST", "/api/chat/"+session.ID+"/retry-ai", nil))
+	assertRefused(t, w, http.StatusConflict, ErrCodeConflict)
+
+	// The AI speaks first in reply to the candidate's opening message
+	provider.AppendScript("Welcome! How do you test your Go code?")
+	reply := sendMessage(t, router, session.ID, "Hi, I'm ready to start")
+	if reply.AIResponse == nil || reply.AIResponse.Subtype != data.MessageSubtypeFollowUp || len(provider.ChatRequests()) != 1 {
+		t.Fatalf("expected the AI's first question, got %+v", reply.AIResponse)
+	}
+	sendMessage(t, router, session.ID, "Table tests and fuzzing")
+
+	// The opening message answers no question and is left out of the evaluation
+	evaluation := endSession(t, router, session.ID)
+	if len(evaluation.AnswersV2) != 1 || evaluation.AnswersV2[0].Answer != "Table tests and fuzzing" {
+		t.Errorf("expected only the answer to the first question evaluated, got %+v", evaluation.AnswersV2)
+	}
+}
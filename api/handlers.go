@@ -2,37 +2,432 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
+	"slices"
+	"sort"
 	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
+	"unicode/utf8"
 
 	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/google/uuid"
 	"github.com/zidane0000/ai-interview-platform/ai"
+	"github.com/zidane0000/ai-interview-platform/api/apitime"
+	"github.com/zidane0000/ai-interview-platform/config"
 	"github.com/zidane0000/ai-interview-platform/data"
 	"github.com/zidane0000/ai-interview-platform/utils"
 )
 
 // HandlerDependencies contains all dependencies needed by handlers
-// Currently empty - AI clients are created per-request from user-provided keys (BYOK)
+// AI clients are created per-request from user-provided keys (BYOK)
 type HandlerDependencies struct {
-	// Future: Add shared dependencies here (e.g., cache, metrics, etc.)
+	// Chat message limits in characters (see config.Config)
+	MaxMessageLength        int
+	MessageSummaryThreshold int
+
+	// Rolling conversation summarization in turns (see config.Config)
+	SummaryThresholdTurns int
+	SummaryRecentTurns    int
+
+	// Messages stored per chat session (see config.Config)
+	MaxMessagesPerSession int
+
+	// AI provider calls allowed per chat session; 0 disables the cap (see config.Config)
+	MaxAIAttemptsPerSession int
+
+	// How chat sessions open unless the start request chooses (see config.Config)
+	GreetingMode string
+
+	// Time a chat turn may take end to end, split between its stages; 0 disables (see config.Config)
+	TurnBudget time.Duration
+
+	// Anti-abuse checks on candidate messages; 0 disables each, and they are off unless configured (see config.Config)
+	MinMessageInterval         time.Duration
+	DuplicateMessageSimilarity float64
+	MaxMessagesPerSessionHour  int
+
+	// Interview question limits (see config.Config)
+	QuestionLimits data.QuestionLimits
+
+	// Outputs asked of the AI per question generation, merged without repeats (see config.Config)
+	QuestionAlternatives int
+
+	// Evaluation feedback length in words; 0 uses the detail level's default (see config.Config)
+	MaxFeedbackWords int
+
+	// Answers shorter than this many words count as effectively unanswered (see config.Config)
+	MinAnswerWords int
+
+	// Languages evaluation feedback is also translated into unless a request chooses its own (see config.Config)
+	AdditionalFeedbackLanguages []string
+
+	// Job description summarization and rejection thresholds (see config.Config)
+	JobDescriptionLimits ai.JobDescriptionLimits
+
+	// Page sizes for list endpoints (see config.Config)
+	DefaultPageSize int
+	MaxPageSize     int
+
+	// Default model per AI provider (see config.Config)
+	ProviderDefaultModels map[string]string
+
+	// Chat temperature, length and model per interview type (see config.Config)
+	InterviewTypeParams map[string]ai.GenerationParams
+
+	// AI cost estimation (see config.Config)
+	ModelPrices         map[string]ai.ModelPrice
+	DefaultCostPerToken float64
+
+	// Retired AI model names and their replacements (see config.Config)
+	ModelAliases map[string]ai.ModelAlias
+
+	// Lateness tolerated after an interview's scheduled_end (see config.Config)
+	ScheduleGracePeriod time.Duration
+
+	// Idle time after which active chat sessions are abandoned; 0 disables expiry (see config.Config)
+	SessionIdleTimeout time.Duration
+
+	// How long after completion a chat session may be reopened (see config.Config)
+	ReopenWindow time.Duration
+
+	// Age after which the messages of ended chat sessions are deleted; 0 keeps them (see config.Config)
+	TranscriptRetention time.Duration
+
+	// Evaluation backfill concurrency and per-session timeout, also used by calibration runs (see config.Config)
+	BackfillWorkers        int
+	BackfillSessionTimeout time.Duration
+
+	// Runs background jobs such as question generation; main stops it on shutdown (see config.Config)
+	Workers *WorkerPool
+
+	// Shared AI debug capture; nil when AI_DEBUG_CAPTURE is off (see config.Config)
+	DebugCapture *ai.DebugCapture
+
+	// PII redaction of provider payloads (see config.Config)
+	RedactPII      bool
+	RedactPatterns []ai.RedactPattern
+
+	// Cap on concurrent provider calls, shared by every request's AI client (see config.Config)
+	AILimiter *ai.ConcurrencyLimiter
+
+	// Largest AI provider response body read; 0 uses ai.DefaultMaxResponseBytes (see config.Config)
+	MaxResponseBytes int
+
+	// Admin routes (see config.Config)
+	AdminToken           string
+	EnableDebugEndpoints bool
+
+	// Maintenance switch refusing writes; read with ReadOnly, toggled with SetReadOnly (see config.Config)
+	readOnly atomic.Bool
+
+	// API key to tenant for the tenant-scoped routes; empty disables multi-tenancy (see config.Config)
+	TenantAPIKeys map[string]string
+
+	// Storage every handler reads and writes; main wires in data.GlobalStore
+	Store data.Store
+
+	// Webhook delivery and the hosts per-interview webhooks may target despite the SSRF guard (see config.Config)
+	Webhooks            *WebhookDispatcher
+	WebhookAllowedHosts []string
+
+	// now returns the current time; tests replace it to check scheduling windows
+	now func() time.Time
+
+	// Runs store maintenance for StartMaintenanceWorker and keeps the latest outcome for /health
+	maintenance *maintenanceScheduler
+
+	// newAIClient builds the AI client for a request; tests swap it for a scripted mock
+	newAIClient func(r *http.Request) *ai.AIClient
+
+	// routes is the full router, listed by GET /admin/routes; set by SetupRouter
+	routes chi.Routes
+
+	// newModelAIClient builds an AI client for a given provider and model; tests swap it for scripted mocks
+	newModelAIClient func(r *http.Request, provider, model string) (*ai.AIClient, error)
+}
+
+// NewHandlerDependencies creates a new handler dependencies container around store
+// Zero-valued limits in cfg fall back to the config defaults; a nil store gets a fresh in-memory one
+func NewHandlerDependencies(cfg *config.Config, store data.Store) *HandlerDependencies {
+	if store == nil {
+		memory, err := data.NewHybridStore(data.BackendMemory, "")
+		if err != nil {
+			panic("failed to create memory store: " + err.Error())
+		}
+		store = memory
+	}
+	deps := &HandlerDependencies{
+		Store:                   store,
+		MaxMessageLength:        config.DefaultMaxMessageLength,
+		MessageSummaryThreshold: config.DefaultMessageSummaryThreshold,
+		SummaryThresholdTurns:   config.DefaultSummaryThresholdTurns,
+		SummaryRecentTurns:      config.DefaultSummaryRecentTurns,
+		MaxMessagesPerSession:   config.DefaultMaxMessagesPerSession,
+		MaxAIAttemptsPerSession: config.DefaultMaxAIAttemptsPerSession,
+		GreetingMode:            data.GreetingModeAI,
+		TurnBudget:              config.DefaultTurnBudget,
+		QuestionLimits: data.QuestionLimits{
+			MaxLength: config.DefaultMaxQuestionLength,
+			MaxCount:  config.DefaultMaxQuestionCount,
+		},
+		JobDescriptionLimits: ai.JobDescriptionLimits{
+			SoftLimit: config.DefaultJobDescriptionSoftLimit,
+			HardLimit: config.DefaultJobDescriptionHardLimit,
+		},
+		DefaultPageSize:        config.DefaultPageSize,
+		MaxPageSize:            config.DefaultMaxPageSize,
+		SessionIdleTimeout:     config.DefaultSessionIdleTimeout,
+		ReopenWindow:           config.DefaultReopenWindow,
+		BackfillWorkers:        config.DefaultBackfillWorkers,
+		BackfillSessionTimeout: config.DefaultBackfillSessionTimeout,
+		AILimiter:              ai.NewConcurrencyLimiter(ai.DefaultMaxConcurrentRequests, ai.DefaultQueueTimeout),
+		Webhooks:               NewWebhookDispatcher("", "", nil),
+		Workers:                NewWorkerPool(config.DefaultBackgroundWorkers),
+		now:                    time.Now,
+	}
+	deps.newAIClient = func(r *http.Request) *ai.AIClient {
+		return createClientFromRequest(r, deps.sharedAIConfig())
+	}
+	deps.maintenance = newMaintenanceScheduler(func(ctx context.Context) (*data.MaintenanceReport, error) {
+		return deps.Store.Maintenance(ctx)
+	}, func() time.Time { return deps.now() })
+	deps.newModelAIClient = func(r *http.Request, provider, model string) (*ai.AIClient, error) {
+		return createClientForModel(r, deps.sharedAIConfig(), provider, model)
+	}
+	if cfg != nil {
+		if cfg.MaxMessageLength > 0 {
+			deps.MaxMessageLength = cfg.MaxMessageLength
+		}
+		if cfg.MessageSummaryThreshold > 0 {
+			deps.MessageSummaryThreshold = cfg.MessageSummaryThreshold
+		}
+		if cfg.SummaryThresholdTurns > 0 {
+			deps.SummaryThresholdTurns = cfg.SummaryThresholdTurns
+		}
+		if cfg.SummaryRecentTurns > 0 {
+			deps.SummaryRecentTurns = cfg.SummaryRecentTurns
+		}
+		if cfg.MaxMessagesPerSession > 0 {
+			deps.MaxMessagesPerSession = cfg.MaxMessagesPerSession
+		}
+		if cfg.MaxQuestionLength > 0 {
+			deps.QuestionLimits.MaxLength = cfg.MaxQuestionLength
+		}
+		if cfg.MaxQuestionCount > 0 {
+			deps.QuestionLimits.MaxCount = cfg.MaxQuestionCount
+		}
+		if cfg.JobDescriptionSoftLimit > 0 {
+			deps.JobDescriptionLimits.SoftLimit = cfg.JobDescriptionSoftLimit
+		}
+		if cfg.JobDescriptionHardLimit > 0 {
+			deps.JobDescriptionLimits.HardLimit = cfg.JobDescriptionHardLimit
+		}
+		if cfg.DefaultPageSize > 0 {
+			deps.DefaultPageSize = cfg.DefaultPageSize
+		}
+		if cfg.MaxPageSize > 0 {
+			deps.MaxPageSize = cfg.MaxPageSize
+		}
+		if cfg.ReopenWindow > 0 {
+			deps.ReopenWindow = cfg.ReopenWindow
+		}
+		if cfg.BackfillWorkers > 0 {
+			deps.BackfillWorkers = cfg.BackfillWorkers
+		}
+		if cfg.BackfillSessionTimeout > 0 {
+			deps.BackfillSessionTimeout = cfg.BackfillSessionTimeout
+		}
+		if cfg.BackgroundWorkers > 0 {
+			deps.Workers = NewWorkerPool(cfg.BackgroundWorkers)
+		}
+		deps.SessionIdleTimeout = cfg.SessionIdleTimeout
+		deps.TranscriptRetention = time.Duration(cfg.TranscriptRetentionDays) * 24 * time.Hour
+		deps.MaxAIAttemptsPerSession = cfg.MaxAIAttemptsPerSession
+		deps.GreetingMode = cfg.GreetingMode
+		deps.TurnBudget = cfg.TurnBudget
+		deps.MinMessageInterval = cfg.MinMessageInterval
+		deps.DuplicateMessageSimilarity = cfg.DuplicateMessageSimilarity
+		deps.MaxMessagesPerSessionHour = cfg.MaxMessagesPerSessionHour
+		deps.MaxFeedbackWords = cfg.MaxFeedbackWords
+		deps.MinAnswerWords = cfg.MinAnswerWords
+		deps.AdditionalFeedbackLanguages = cfg.AdditionalFeedbackLanguages
+		deps.QuestionAlternatives = cfg.QuestionAlternatives
+		deps.ProviderDefaultModels = cfg.AIProviderDefaultModels
+		deps.InterviewTypeParams = cfg.AIInterviewTypeParams
+		deps.ModelPrices = cfg.AIModelPrices
+		deps.DefaultCostPerToken = cfg.AIDefaultCostPerToken
+		deps.ModelAliases = cfg.AIModelAliases
+		if cfg.InterviewGraceMinutes > 0 {
+			deps.ScheduleGracePeriod = time.Duration(cfg.InterviewGraceMinutes) * time.Minute
+		}
+		if cfg.AIDebugCapture {
+			deps.DebugCapture = ai.NewDebugCapture(cfg.AIDebugCaptureSize)
+		}
+		deps.RedactPII = cfg.AIRedactPII
+		deps.RedactPatterns = cfg.AIRedactPatterns
+		deps.AILimiter = ai.NewConcurrencyLimiter(cfg.AIMaxConcurrentRequests, cfg.AIQueueTimeout)
+		deps.AdminToken = cfg.AdminToken
+		deps.EnableDebugEndpoints = cfg.EnableDebugEndpoints
+		deps.SetReadOnly(cfg.ReadOnly)
+		deps.TenantAPIKeys = cfg.TenantAPIKeys
+		deps.WebhookAllowedHosts = cfg.WebhookAllowedHosts
+		deps.Webhooks = NewWebhookDispatcher(cfg.WebhookURL, cfg.WebhookSecret, cfg.WebhookAllowedHosts)
+		if cfg.WebhookMaxAttempts > 0 {
+			deps.Webhooks.maxAttempts = cfg.WebhookMaxAttempts
+		}
+		if cfg.WebhookRetryBackoff > 0 {
+			deps.Webhooks.retryBackoff = cfg.WebhookRetryBackoff
+		}
+		if cfg.WebhookPollInterval > 0 {
+			deps.Webhooks.pollInterval = cfg.WebhookPollInterval
+		}
+		if cfg.UpstreamMaxResponseBytes > 0 {
+			deps.MaxResponseBytes = cfg.UpstreamMaxResponseBytes
+			deps.Webhooks.maxResponseBytes = cfg.UpstreamMaxResponseBytes
+		}
+	}
+	deps.Webhooks.store = store
+	return deps
 }
 
-// NewHandlerDependencies creates a new handler dependencies container
-func NewHandlerDependencies() *HandlerDependencies {
-	return &HandlerDependencies{}
+// sharedAIConfig is the server-side configuration every request's AI client is built with
+func (deps *HandlerDependencies) sharedAIConfig() ai.AIConfig {
+	return ai.AIConfig{
+		ProviderDefaultModels: deps.ProviderDefaultModels,
+		InterviewTypeParams:   deps.InterviewTypeParams,
+		ModelPrices:           deps.ModelPrices,
+		CostPerToken:          deps.DefaultCostPerToken,
+		ModelAliases:          deps.ModelAliases,
+		DebugCapture:          deps.DebugCapture,
+		RedactPII:             deps.RedactPII,
+		RedactPatterns:        deps.RedactPatterns,
+		Limiter:               deps.AILimiter,
+		MaxResponseBytes:      deps.MaxResponseBytes,
+	}
 }
 
 // Helper: parse integer query parameter with default value
 func parseIntQuery(r *http.Request, key string, defaultValue int) int {
-	if str := r.URL.Query().Get(key); str != "" {
-		if val, err := strconv.Atoi(str); err == nil && val >= 0 {
-			return val
+	return parseIntQueryWithMax(r, key, defaultValue, 0, nil)
+}
+
+// Helper: parse integer query parameter, clamping to maxValue when maxValue > 0
+// Negative or non-numeric values fall back to defaultValue; both fallbacks and clamping are added to warnings
+func parseIntQueryWithMax(r *http.Request, key string, defaultValue, maxValue int, warnings *warningList) int {
+	str := r.URL.Query().Get(key)
+	if str == "" {
+		return defaultValue
+	}
+	val, err := strconv.Atoi(str)
+	if err != nil || val < 0 {
+		warnings.add(WarnCodeInvalidParameter, key, fmt.Sprintf("Ignored invalid %s=%q, using %d", key, str, defaultValue))
+		return defaultValue
+	}
+	if maxValue > 0 && val > maxValue {
+		warnings.add(WarnCodeClampedParameter, key, fmt.Sprintf("%s=%d is over the maximum, using %d", key, val, maxValue))
+		return maxValue
+	}
+	return val
+}
+
+// Sort values accepted by the interview list
+var (
+	interviewSortFields = []string{"date", "name", "status"}
+	sortOrders          = []string{"asc", "desc"}
+)
+
+// Helper: parse an optional query parameter that must be one of choices
+// Returns "" (the default) and adds a warning when the value is not one of them
+func parseChoiceQuery(r *http.Request, key string, choices []string, warnings *warningList) string {
+	str := r.URL.Query().Get(key)
+	if str == "" || slices.Contains(choices, str) {
+		return str
+	}
+	warnings.add(WarnCodeInvalidParameter, key, fmt.Sprintf("Ignored invalid %s=%q, expected one of %s", key, str, strings.Join(choices, ", ")))
+	return ""
+}
+
+// pageParams holds the pagination values applied to a list request
+type pageParams struct {
+	Limit    int
+	Offset   int
+	Page     int
+	Warnings warningList
+}
+
+// Helper: parse an optional RFC 3339 timestamp or YYYY-MM-DD date query parameter, in UTC
+// Returns the zero time and adds a warning when the value cannot be parsed
+func parseTimeQuery(r *http.Request, key string, warnings *warningList) time.Time {
+	str := r.URL.Query().Get(key)
+	if str == "" {
+		return time.Time{}
+	}
+	parsed, err := apitime.ParseQuery(str)
+	if err != nil {
+		warnings.add(WarnCodeInvalidParameter, key, fmt.Sprintf("Ignored invalid %s=%q", key, str))
+		return time.Time{}
+	}
+	return parsed
+}
+
+// Helper: parse an optional range bound query parameter like parseTimeQuery, with a YYYY-MM-DD date
+// taken in loc and, for an end bound, covering the whole day
+func parseTimeBoundQuery(r *http.Request, key string, loc *time.Location, end bool, warnings *warningList) time.Time {
+	str := r.URL.Query().Get(key)
+	if str == "" {
+		return time.Time{}
+	}
+	parsed, err := apitime.ParseQueryBound(str, loc, end)
+	if err != nil {
+		warnings.add(WarnCodeInvalidParameter, key, fmt.Sprintf("Ignored invalid %s=%q", key, str))
+		return time.Time{}
+	}
+	return parsed
+}
+
+// Helper: parse limit/offset/page for list endpoints using the configured page sizes
+// A page number takes precedence over offset when both are given
+func (deps *HandlerDependencies) parsePagination(r *http.Request) pageParams {
+	// A default above the maximum is clamped like any requested limit
+	defaultLimit := deps.DefaultPageSize
+	if deps.MaxPageSize > 0 && defaultLimit > deps.MaxPageSize {
+		defaultLimit = deps.MaxPageSize
+	}
+
+	var params pageParams
+	params.Limit = parseIntQueryWithMax(r, "limit", defaultLimit, deps.MaxPageSize, &params.Warnings)
+	params.Offset = parseIntQueryWithMax(r, "offset", 0, 0, &params.Warnings)
+	params.Page = parseIntQueryWithMax(r, "page", 0, 0, &params.Warnings)
+
+	if params.Limit == 0 {
+		params.Limit = defaultLimit
+	}
+	if params.Page > 0 {
+		params.Offset = (params.Page - 1) * params.Limit
+	} else {
+		params.Page = params.Offset/params.Limit + 1
+	}
+	return params
+}
+
+// Helper: check whether an optional field was requested via ?include=a,b
+func includeRequested(r *http.Request, field string) bool {
+	for _, value := range r.URL.Query()["include"] {
+		for _, item := range strings.Split(value, ",") {
+			if strings.TrimSpace(item) == field {
+				return true
+			}
 		}
 	}
-	return defaultValue
+	return false
 }
 
 // Helper: write JSON response
@@ -45,10 +440,10 @@ func writeJSON(w http.ResponseWriter, status int, v interface{}) {
 }
 
 // Helper: write JSON error response
-func writeJSONError(w http.ResponseWriter, status int, msg string, details ...string) {
+func writeJSONError(w http.ResponseWriter, status int, code ErrorCode, msg string, details ...string) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(status)
-	errResp := ErrorResponseDTO{Error: msg}
+	errResp := ErrorResponseDTO{Error: msg, Code: code}
 	if len(details) > 0 {
 		errResp.Details = details[0]
 	}
@@ -58,14 +453,30 @@ func writeJSONError(w http.ResponseWriter, status int, msg string, details ...st
 	}
 }
 
+// writeStoreError responds to a failed store write: duplicates get a 409 with existsMsg, other
+// constraint violations a generic 409, and anything else a 500 with failureMsg
+// The store error can carry raw database text, so it is logged rather than returned
+func writeStoreError(w http.ResponseWriter, err error, failureMsg, existsMsg string) {
+	utils.Errorf("%s: %v", failureMsg, err)
+	switch {
+	case errors.Is(err, data.ErrAlreadyExists):
+		writeJSONError(w, http.StatusConflict, ErrCodeConflict, existsMsg)
+	case errors.Is(err, data.ErrConstraint):
+		writeJSONError(w, http.StatusConflict, ErrCodeConflict, "Request conflicts with related records")
+	default:
+		writeJSONError(w, http.StatusInternalServerError, ErrCodeInternal, failureMsg)
+	}
+}
+
 // createClientFromRequest creates an AI client from request headers (BYOK pattern)
 // Reads X-OpenAI-Key, X-Gemini-Key, and X-OpenAI-Base-URL headers from frontend
 // Supports custom OpenAI-compatible endpoints (Together.ai, Groq, etc.)
 // Falls back to mock provider if no keys provided (free demo mode)
-func createClientFromRequest(r *http.Request) *ai.AIClient {
+// shared carries the server-side settings applied to every client: provider default models,
+// cost estimation, debug capture and PII redaction
+func createClientFromRequest(r *http.Request, shared ai.AIConfig) *ai.AIClient {
 	openaiKey := r.Header.Get("X-OpenAI-Key")
 	geminiKey := r.Header.Get("X-Gemini-Key")
-	openaiBaseURL := r.Header.Get("X-OpenAI-Base-URL") // Custom endpoint support
 
 	// Determine provider based on which key is provided
 	var provider string
@@ -76,108 +487,443 @@ func createClientFromRequest(r *http.Request) *ai.AIClient {
 		model = "gpt-4"
 	} else if geminiKey != "" {
 		provider = ai.ProviderGemini
-		model = "gemini-pro"
+		model = "gemini-1.5-flash"
 	} else {
 		// No user keys - use mock provider (free demo mode)
 		provider = ai.ProviderMock
 		model = "mock-model"
 	}
-
-	// Create ephemeral AI client for this request only
-	cfg := &ai.AIConfig{
-		OpenAIAPIKey:     openaiKey,
-		GeminiAPIKey:     geminiKey,
-		OpenAIBaseURL:    openaiBaseURL, // Custom endpoint (e.g., Together.ai, Groq)
-		DefaultProvider:  provider,
-		DefaultModel:     model,
-		MaxRetries:       2,
-		RequestTimeout:   60 * time.Second,
-		DefaultMaxTokens: 1000,
-		DefaultTemp:      0.7,
+	if configured := shared.ProviderDefaultModels[provider]; configured != "" {
+		model = configured
 	}
 
-	client, err := ai.NewAIClient(cfg)
+	// Create ephemeral AI client for this request only
+	cfg := requestClientConfig(r, shared, provider, model)
+	client, err := ai.NewAIClient(&cfg)
 	if err != nil {
 		// Fall back to mock on error
 		utils.Warningf("Failed to create AI client with user keys, falling back to mock: %v", err)
-		mockCfg := &ai.AIConfig{
-			DefaultProvider: ai.ProviderMock,
-			DefaultModel:    "mock-model",
-		}
-		client, _ = ai.NewAIClient(mockCfg)
+		mockCfg := shared
+		mockCfg.DefaultProvider = ai.ProviderMock
+		mockCfg.DefaultModel = "mock-model"
+		client, _ = ai.NewAIClient(&mockCfg)
 	}
 
 	return client
 }
 
+// createClientForModel creates an AI client for model on provider with the caller's key for that
+// provider from the request headers (BYOK); unlike createClientFromRequest it fails instead of
+// falling back to the mock provider when the key is missing
+func createClientForModel(r *http.Request, shared ai.AIConfig, provider, model string) (*ai.AIClient, error) {
+	cfg := requestClientConfig(r, shared, provider, model)
+	// The server's default model for the provider would otherwise win over DefaultModel
+	cfg.ProviderDefaultModels = map[string]string{provider: model}
+	return ai.NewAIClient(&cfg)
+}
+
+// requestClientConfig is the configuration of an ephemeral AI client for provider and model using
+// the keys and custom OpenAI-compatible endpoint (Together.ai, Groq, etc.) in the request headers
+func requestClientConfig(r *http.Request, shared ai.AIConfig, provider, model string) ai.AIConfig {
+	cfg := shared
+	cfg.OpenAIAPIKey = r.Header.Get("X-OpenAI-Key")
+	cfg.GeminiAPIKey = r.Header.Get("X-Gemini-Key")
+	cfg.OpenAIBaseURL = r.Header.Get("X-OpenAI-Base-URL")
+	cfg.DefaultProvider = provider
+	cfg.DefaultModel = model
+	cfg.MaxRetries = 2
+	cfg.RequestTimeout = 60 * time.Second
+	cfg.DefaultMaxTokens = 1000
+	cfg.DefaultTemp = 0.7
+	return cfg
+}
+
+// buildEvaluationContext collects the interview details the evaluation prompt needs
+func buildEvaluationContext(interview *data.Interview, language string) ai.EvaluationContext {
+	jobDesc := interview.JobDescription
+	if jobDesc == "" {
+		jobDesc = fmt.Sprintf("General %s interview", interview.InterviewType)
+	}
+	return ai.EvaluationContext{
+		JobDescription: jobDesc,
+		InterviewType:  interview.InterviewType,
+		ResumeContent:  interview.ResumeContent,
+		CompanyContext: interview.CompanyContext,
+		Language:       language,
+	}
+}
+
+// promptJobDescription returns the job description to send in prompts and the AI cost of getting it
+// Descriptions over the soft limit are summarized once and the summary cached on the interview;
+// when summarization fails, the description is truncated to the soft limit for this prompt instead.
+func (deps *HandlerDependencies) promptJobDescription(ctx context.Context, aiClient *ai.AIClient, store data.Store, interview *data.Interview) (string, float64) {
+	if !deps.JobDescriptionLimits.NeedsSummary(interview.JobDescription) {
+		return interview.JobDescription, 0
+	}
+	if interview.JobDescSummary != "" {
+		return interview.JobDescSummary, 0
+	}
+	summary, err := aiClient.SummarizeJobDescription(ctx, interview.JobDescription)
+	if err != nil {
+		utils.Warningf("Failed to summarize the job description of interview %s, truncating it: %v", interview.ID, err)
+		return deps.JobDescriptionLimits.Truncate(interview.JobDescription), 0
+	}
+	if err := store.SetInterviewJobDescriptionSummary(interview.ID, summary.Content); err != nil {
+		utils.Errorf("Failed to cache the job description summary of interview %s: %v", interview.ID, err)
+	}
+	interview.JobDescSummary = summary.Content
+	return summary.Content, summary.EstimatedCostUSD
+}
+
 // CreateInterviewHandler handles POST /interviews
-func CreateInterviewHandler(w http.ResponseWriter, r *http.Request) {
+func (deps *HandlerDependencies) CreateInterviewHandler(w http.ResponseWriter, r *http.Request) {
 	var req CreateInterviewRequestDTO
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		writeJSONError(w, http.StatusBadRequest, "Invalid JSON", err.Error())
+		writeJSONError(w, http.StatusBadRequest, ErrCodeInvalidJSON, "Invalid JSON", err.Error())
+		return
+	}
+	interview, warnings, failure := deps.newInterview(r, &req)
+	if failure != nil {
+		failure.write(w, "")
+		return
+	}
+	interview.Status = scheduleStatus(interview)
+	// Store interview in hybrid store
+	store := deps.Store.WithContext(r.Context())
+	if err := store.CreateInterview(interview); err != nil {
+		writeStoreError(w, err, "Failed to create interview", "Interview already exists")
+		return
+	}
+
+	resp := toInterviewResponseDTO(interview)
+	if includeRequested(r, "question_details") {
+		resp.QuestionDetails = toQuestionDetailDTOs(interview)
+	}
+	resp.Warnings = warnings
+	// Generated in the background; clients poll GET /interviews/{id} for questions_status
+	if interview.QuestionsPending() {
+		deps.queueQuestionGeneration(r, interview.ID)
+	}
+	writeJSON(w, http.StatusCreated, resp)
+}
+
+// interviewRequestError is a rejected interview or session request with the API error it maps to
+type interviewRequestError struct {
+	status  int
+	code    ErrorCode
+	message string
+	details string
+	err     error // AI failure behind the rejection, if any
+}
+
+// invalidInterviewRequest is a validation failure of an interview or session request
+func invalidInterviewRequest(message string, details ...string) *interviewRequestError {
+	e := &interviewRequestError{status: http.StatusBadRequest, code: ErrCodeValidationFailed, message: message}
+	if len(details) > 0 {
+		e.details = details[0]
+	}
+	return e
+}
+
+// write responds with the error; a non-empty part names which part of a combined request failed
+func (e *interviewRequestError) write(w http.ResponseWriter, part string) {
+	if writeAIOverloaded(w, e.err) {
 		return
 	}
-	if req.CandidateName == "" || len(req.Questions) == 0 {
-		writeJSONError(w, http.StatusBadRequest, "Missing candidate_name or questions")
+	if part == "" {
+		writeJSONError(w, e.status, e.code, e.message, e.details)
 		return
 	}
+	writeJSON(w, e.status, StartInterviewErrorResponseDTO{
+		ErrorResponseDTO: ErrorResponseDTO{Error: e.message, Code: e.code, Details: e.details},
+		Part:             part,
+	})
+}
+
+// newInterview validates a create request and builds the interview it describes; warnings list
+// questions dropped or substituted along the way. Interviews whose questions are to be generated are returned
+// with QuestionsStatusGenerating and no questions. Nothing is stored.
+func (deps *HandlerDependencies) newInterview(r *http.Request, req *CreateInterviewRequestDTO) (*data.Interview, warningList, *interviewRequestError) {
+	interviewMode := data.InterviewModeStructured
+	if req.InterviewMode != "" {
+		if !data.ValidateInterviewMode(req.InterviewMode) {
+			return nil, nil, invalidInterviewRequest("Invalid interview_mode. Supported modes: structured, conversational")
+		}
+		interviewMode = req.InterviewMode
+	}
+	// Conversational interviews may have no questions: the AI works from the job description
+	if strings.TrimSpace(req.CandidateName) == "" || (len(req.Questions) == 0 && !req.UseDefaultQuestions && !req.GenerateQuestions && interviewMode != data.InterviewModeConversational) {
+		return nil, nil, invalidInterviewRequest("Missing candidate_name or questions")
+	}
+	candidateName, err := data.NormalizeCandidateName(req.CandidateName)
+	if err != nil {
+		return nil, nil, invalidInterviewRequest("Invalid candidate_name", err.Error())
+	}
 
 	// Validate required interview_type field
 	if req.InterviewType == "" {
-		writeJSONError(w, http.StatusBadRequest, "Missing interview_type field")
-		return
+		return nil, nil, invalidInterviewRequest("Missing interview_type field")
 	}
 	if !data.ValidateInterviewType(req.InterviewType) {
-		writeJSONError(w, http.StatusBadRequest, "Invalid interview_type. Supported types: general, technical, behavioral")
-		return
+		return nil, nil, invalidInterviewRequest("Invalid interview_type. Supported types: general, technical, behavioral")
 	}
 
-	// Validate language if provided
-	if req.InterviewLanguage != "" && !data.ValidateLanguage(req.InterviewLanguage) {
-		writeJSONError(w, http.StatusBadRequest, "Invalid language code. Supported languages: en, zh-TW")
-		return
+	// Validate language if provided, defaulting only when it is absent
+	interviewLanguage := data.GetDefaultLanguage()
+	if req.InterviewLanguage != "" {
+		if !data.ValidateLanguage(req.InterviewLanguage) {
+			return nil, nil, invalidInterviewRequest(ErrMsgInvalidLanguage)
+		}
+		interviewLanguage = req.InterviewLanguage
+	}
+
+	if req.NumQuestions < 0 || (deps.QuestionLimits.MaxCount > 0 && req.NumQuestions > deps.QuestionLimits.MaxCount) {
+		return nil, nil, invalidInterviewRequest("Invalid num_questions",
+			fmt.Sprintf("num_questions must be between 1 and %d", deps.QuestionLimits.MaxCount))
 	}
-	// Process language parameter with default fallback
-	interviewLanguage := data.GetValidatedLanguage(req.InterviewLanguage)
 
+	questions := []string{}
+	var warnings warningList
+	var questionDetails data.QuestionDetailList
+	if len(req.Questions) > 0 {
+		normalized, duplicates, err := data.NormalizeQuestions(req.Questions, deps.QuestionLimits)
+		if err != nil {
+			return nil, nil, invalidInterviewRequest("Invalid questions", err.Error())
+		}
+		questions = normalized
+		for _, duplicate := range duplicates {
+			warnings.add(WarnCodeDuplicateQuestion, "questions", fmt.Sprintf("Duplicate question removed: %q", duplicate))
+		}
+	} else if req.UseDefaultQuestions {
+		set := data.GetDefaultQuestionSet(req.InterviewType, interviewLanguage)
+		questions = set.Questions
+		if set.Warning != "" {
+			warnings.add(WarnCodeDefaultQuestionsFallback, "use_default_questions", set.Warning)
+		}
+		for _, question := range questions {
+			questionDetails = append(questionDetails, data.QuestionDetail{Text: question, Category: set.InterviewType, Source: data.QuestionSourceBank})
+		}
+	}
+	if deps.JobDescriptionLimits.Exceeds(req.JobDescription) {
+		return nil, nil, invalidInterviewRequest("job_description is too long",
+			fmt.Sprintf("job_description must be at most %d characters, got %d", deps.JobDescriptionLimits.HardLimit, utf8.RuneCountInString(req.JobDescription)))
+	}
+	if deps.JobDescriptionLimits.NeedsSummary(req.JobDescription) {
+		warnings.add(WarnCodeJobDescriptionSummarized, "job_description",
+			fmt.Sprintf("job_description is over %d characters and will be summarized for the AI", deps.JobDescriptionLimits.SoftLimit))
+	}
+	if err := validateSchedule(req.ScheduledStart.Std(), req.ScheduledEnd.Std()); err != nil {
+		return nil, nil, invalidInterviewRequest("Invalid scheduling window", err.Error())
+	}
+	var notifyEvents []string
+	if req.Notify != nil {
+		if err := validateWebhookURL(req.Notify.WebhookURL, deps.WebhookAllowedHosts); err != nil {
+			return nil, nil, invalidInterviewRequest("Invalid notify webhook_url", err.Error())
+		}
+		var err error
+		if notifyEvents, err = normalizeWebhookEvents(req.Notify.Events); err != nil {
+			return nil, nil, invalidInterviewRequest("Invalid notify events", err.Error())
+		}
+	}
 	// Generate unique ID and create interview record
 	interviewID := data.GenerateID()
 	interview := &data.Interview{
 		ID:                interviewID,
-		CandidateName:     req.CandidateName,
-		Questions:         req.Questions,
+		CandidateName:     candidateName,
+		Questions:         questions,
+		QuestionDetails:   questionDetails,
 		InterviewType:     req.InterviewType,
+		InterviewMode:     interviewMode,
 		InterviewLanguage: interviewLanguage,
 		JobDescription:    req.JobDescription, // Add job description (optional)
-		CreatedAt:         time.Now(),
-		UpdatedAt:         time.Now(),
+		ResumeContent:     req.ResumeContent,
+		CompanyContext:    req.CompanyContext,
+		ScheduledStart:    req.ScheduledStart.Std(),
+		ScheduledEnd:      req.ScheduledEnd.Std(),
+		NotifyEvents:      notifyEvents,
+	}
+	if req.Adaptive != nil && !*req.Adaptive {
+		interview.AdaptiveDisabled = true
+	}
+	if req.Notify != nil {
+		interview.NotifyWebhookURL = req.Notify.WebhookURL
+		interview.NotifySecret = req.Notify.Secret
+	}
+	// Generation is left to the caller, so invalid requests don't spend an AI call
+	if len(req.Questions) == 0 && !req.UseDefaultQuestions && req.GenerateQuestions {
+		interview.QuestionsStatus = data.QuestionsStatusGenerating
+		interview.QuestionsWanted = req.NumQuestions
+		if interview.QuestionsWanted == 0 {
+			interview.QuestionsWanted = defaultGeneratedQuestions
+		}
 	}
-	// Store interview in hybrid store
-	err := data.GlobalStore.CreateInterview(interview)
+	// Generated questions are checked against the number wanted; sessions cannot start before they arrive
+	questionCount := len(interview.Questions)
+	if interview.QuestionsPending() {
+		questionCount = interview.QuestionsWanted
+	}
+	if err := data.ValidateQuestionStrategy(req.QuestionStrategy, questionCount); err != nil {
+		return nil, nil, invalidInterviewRequest("Invalid question_strategy", err.Error())
+	}
+	interview.QuestionStrategy = req.QuestionStrategy
+	return interview, warnings, nil
+}
+
+// defaultGeneratedQuestions is how many questions generate_questions asks for without num_questions
+const defaultGeneratedQuestions = 5
+
+// generateInterviewQuestions asks the AI for the interview's QuestionsWanted questions, keeping
+// their category, difficulty and expected time. Texts are normalized like submitted questions;
+// repeats are dropped and returned separately.
+func (deps *HandlerDependencies) generateInterviewQuestions(ctx context.Context, aiClient *ai.AIClient, interview *data.Interview) (data.QuestionDetailList, []string, error) {
+	numQuestions := interview.QuestionsWanted
+	if numQuestions == 0 {
+		numQuestions = defaultGeneratedQuestions
+	}
+	resp, err := aiClient.GenerateInterviewQuestions(ctx, &ai.QuestionGenerationRequest{
+		JobDescription: interview.JobDescription,
+		ResumeContent:  interview.ResumeContent,
+		InterviewType:  interview.InterviewType,
+		NumQuestions:   numQuestions,
+		Language:       interview.InterviewLanguage,
+		Alternatives:   deps.QuestionAlternatives,
+	})
 	if err != nil {
-		writeJSONError(w, http.StatusInternalServerError, "Failed to create interview", err.Error())
-		return
+		return nil, nil, err
+	}
+
+	texts := make([]string, 0, len(resp.Questions))
+	generated := make(data.QuestionDetailList, 0, len(resp.Questions))
+	for _, question := range resp.Questions {
+		text := strings.TrimSpace(question.Question)
+		if text == "" {
+			continue
+		}
+		texts = append(texts, text)
+		generated = append(generated, data.QuestionDetail{
+			Text:         text,
+			Category:     question.Category,
+			Difficulty:   question.Difficulty,
+			ExpectedTime: question.ExpectedTime,
+			Source:       data.QuestionSourceAI,
+		})
+	}
+	if len(texts) > numQuestions {
+		texts = texts[:numQuestions]
+	}
+	normalized, duplicates, err := data.NormalizeQuestions(texts, deps.QuestionLimits)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid generated questions: %w", err)
+	}
+	return data.SyncQuestionDetails(normalized, generated), duplicates, nil
+}
+
+// toQuestionDetailDTOs describes each of an interview's questions, in order
+func toQuestionDetailDTOs(interview *data.Interview) []QuestionDetailDTO {
+	details := data.SyncQuestionDetails(interview.Questions, interview.QuestionDetails)
+	dtos := make([]QuestionDetailDTO, len(details))
+	for i, detail := range details {
+		dtos[i] = QuestionDetailDTO{
+			Text:         detail.Text,
+			Category:     detail.Category,
+			Difficulty:   detail.Difficulty,
+			ExpectedTime: detail.ExpectedTime,
+			Source:       detail.Source,
+		}
 	}
+	return dtos
+}
 
-	resp := InterviewResponseDTO{
+// GetDefaultQuestionsHandler handles GET /questions/defaults?type=&language=
+// Returns the built-in question set used by use_default_questions; unknown types and languages
+// fall back to the general and English sets with a warning instead of an error.
+func (deps *HandlerDependencies) GetDefaultQuestionsHandler(w http.ResponseWriter, r *http.Request) {
+	set := data.GetDefaultQuestionSet(r.URL.Query().Get("type"), r.URL.Query().Get("language"))
+	writeJSON(w, http.StatusOK, DefaultQuestionsResponseDTO{
+		InterviewType: set.InterviewType,
+		Language:      set.Language,
+		Questions:     set.Questions,
+		Warning:       set.Warning,
+	})
+}
+
+// toInterviewResponseDTO converts a stored interview to its API representation
+func toInterviewResponseDTO(interview *data.Interview) InterviewResponseDTO {
+	var notify *NotifyResponseDTO
+	if interview.NotifyWebhookURL != "" {
+		notify = &NotifyResponseDTO{
+			WebhookURL: interview.NotifyWebhookURL,
+			Events:     interview.NotifyEvents,
+			HasSecret:  interview.NotifySecret != "",
+		}
+	}
+	return InterviewResponseDTO{
 		ID:                interview.ID,
 		CandidateName:     interview.CandidateName,
 		Questions:         interview.Questions,
 		InterviewType:     interview.InterviewType,
+		InterviewMode:     data.GetValidatedInterviewMode(interview.InterviewMode),
 		InterviewLanguage: interview.InterviewLanguage,
-		JobDescription:    interview.JobDescription, // Include job description in response
-		CreatedAt:         interview.CreatedAt,
+		JobDescription:    interview.JobDescription,
+		JobDescSummary:    interview.JobDescSummary,
+		ResumeContent:     interview.ResumeContent,
+		CompanyContext:    interview.CompanyContext,
+		Status:            interview.Status,
+		ScheduledStart:    apitime.NewPtr(interview.ScheduledStart),
+		ScheduledEnd:      apitime.NewPtr(interview.ScheduledEnd),
+		Notify:            notify,
+		Adaptive:          interview.IsAdaptive(),
+		QuestionStrategy:  data.GetValidatedQuestionStrategy(interview.QuestionStrategy),
+		ClonedFrom:        interview.ClonedFrom,
+		Outcome:           interview.Outcome,
+		OutcomeNote:       interview.OutcomeNote,
+		OutcomeHistory:    toOutcomeRecordDTOs(interview.OutcomeHistory),
+		QuestionsStatus:   interview.QuestionsStatus,
+		QuestionsError:    interview.QuestionsError,
+		CreatedAt:         apitime.New(interview.CreatedAt),
 	}
-	writeJSON(w, http.StatusCreated, resp)
+}
+
+// toOutcomeRecordDTOs converts an interview's outcome history
+func toOutcomeRecordDTOs(history data.OutcomeRecordList) []OutcomeRecordDTO {
+	if len(history) == 0 {
+		return nil
+	}
+	records := make([]OutcomeRecordDTO, len(history))
+	for i, record := range history {
+		records[i] = OutcomeRecordDTO{Outcome: record.Outcome, Note: record.Note, RecordedAt: apitime.New(record.RecordedAt)}
+	}
+	return records
+}
+
+// validateSchedule checks that a scheduling window, when fully given, ends after it starts
+func validateSchedule(start, end *time.Time) error {
+	if start != nil && end != nil && !end.After(*start) {
+		return errors.New("scheduled_end must be after scheduled_start")
+	}
+	return nil
+}
+
+// scheduleStatus returns the status an interview has before it starts: scheduled when
+// it has a scheduling window, draft otherwise. Started or completed interviews keep theirs
+func scheduleStatus(interview *data.Interview) string {
+	switch interview.Status {
+	case "", data.InterviewStatusDraft, data.InterviewStatusScheduled:
+		if interview.ScheduledStart != nil || interview.ScheduledEnd != nil {
+			return data.InterviewStatusScheduled
+		}
+		return data.InterviewStatusDraft
+	}
+	return interview.Status
 }
 
 // ListInterviewsHandler handles GET /interviews
-func ListInterviewsHandler(w http.ResponseWriter, r *http.Request) {
+func (deps *HandlerDependencies) ListInterviewsHandler(w http.ResponseWriter, r *http.Request) {
 	// Parse query parameters for pagination, filtering, and sorting
+	page := deps.parsePagination(r)
 	opts := data.ListInterviewsOptions{
-		Limit:  parseIntQuery(r, "limit", 10),
-		Offset: parseIntQuery(r, "offset", 0),
-		Page:   parseIntQuery(r, "page", 0),
+		Limit:  page.Limit,
+		Offset: page.Offset,
 	}
 
 	// Parse filtering parameters
@@ -187,563 +933,2341 @@ func ListInterviewsHandler(w http.ResponseWriter, r *http.Request) {
 	if status := r.URL.Query().Get("status"); status != "" {
 		opts.Status = status
 	}
-	if dateFrom := r.URL.Query().Get("date_from"); dateFrom != "" {
-		if parsed, err := time.Parse("2006-01-02", dateFrom); err == nil {
-			opts.DateFrom = parsed
+	if outcome := r.URL.Query().Get("outcome"); outcome != "" {
+		if !data.ValidateInterviewOutcome(outcome) {
+			writeJSONError(w, http.StatusBadRequest, ErrCodeValidationFailed, ErrMsgInvalidOutcome)
+			return
 		}
+		opts.Outcome = outcome
 	}
-	if dateTo := r.URL.Query().Get("date_to"); dateTo != "" {
-		if parsed, err := time.Parse("2006-01-02", dateTo); err == nil {
-			opts.DateTo = parsed
-		}
+	fields, err := parseInterviewFields(r)
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, ErrCodeValidationFailed, "Invalid fields parameter", err.Error())
+		return
 	}
 
-	// Parse sorting parameters
-	if sortBy := r.URL.Query().Get("sort_by"); sortBy != "" {
-		opts.SortBy = sortBy
-	}
-	if sortOrder := r.URL.Query().Get("sort_order"); sortOrder != "" {
-		opts.SortOrder = sortOrder
+	// Dates are days in the caller's time zone, e.g. tz=Asia/Taipei, and UTC days without one
+	loc, err := apitime.LoadLocation(r.URL.Query().Get("tz"))
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, ErrCodeValidationFailed, "Invalid tz parameter", err.Error())
+		return
 	}
+	opts.DateFrom = parseTimeBoundQuery(r, "date_from", loc, false, &page.Warnings)
+	opts.DateTo = parseTimeBoundQuery(r, "date_to", loc, true, &page.Warnings)
+	opts.ScheduledAfter = parseTimeQuery(r, "scheduled_after", &page.Warnings)
+	opts.ScheduledBefore = parseTimeQuery(r, "scheduled_before", &page.Warnings)
+
+	// Parse sorting parameters; unknown values fall back to the newest first
+	opts.SortBy = parseChoiceQuery(r, "sort_by", interviewSortFields, &page.Warnings)
+	opts.SortOrder = parseChoiceQuery(r, "sort_order", sortOrders, &page.Warnings)
 	// Fetch interviews from memory store with options
-	result, err := data.GlobalStore.GetInterviewsWithOptions(opts)
+	store := deps.Store.WithContext(r.Context())
+	result, err := store.GetInterviewsWithOptions(opts)
 	if err != nil {
-		writeJSONError(w, http.StatusInternalServerError, "Failed to fetch interviews", err.Error())
+		writeJSONError(w, http.StatusInternalServerError, ErrCodeInternal, "Failed to fetch interviews", err.Error())
+		return
+	}
+
+	totalPages := (result.Total + page.Limit - 1) / page.Limit
+	if totalPages == 0 {
+		totalPages = 1
+	}
+	if fields != nil {
+		latest, err := latestEvaluationsForFields(store, result.Interviews, fields)
+		if err != nil {
+			writeJSONError(w, http.StatusInternalServerError, ErrCodeInternal, "Failed to fetch latest evaluations", err.Error())
+			return
+		}
+		projected := make([]map[string]interface{}, len(result.Interviews))
+		for i, interview := range result.Interviews {
+			projected[i] = projectInterview(latest, interview, fields)
+		}
+		writeJSON(w, http.StatusOK, ListInterviewFieldsResponseDTO{
+			Interviews: projected,
+			Total:      result.Total,
+			Limit:      page.Limit,
+			Offset:     page.Offset,
+			Page:       page.Page,
+			TotalPages: totalPages,
+			Warnings:   page.Warnings,
+		})
 		return
 	}
+
 	// Convert to DTOs
 	interviewDTOs := make([]InterviewResponseDTO, len(result.Interviews))
 	for i, interview := range result.Interviews {
-		interviewDTOs[i] = InterviewResponseDTO{
-			ID:                interview.ID,
-			CandidateName:     interview.CandidateName,
-			Questions:         interview.Questions,
-			InterviewType:     interview.InterviewType,
-			InterviewLanguage: interview.InterviewLanguage,
-			JobDescription:    interview.JobDescription, // Include job description
-			CreatedAt:         interview.CreatedAt,
-		}
+		interviewDTOs[i] = toInterviewResponseDTO(interview)
 	}
-
 	resp := ListInterviewsResponseDTO{
 		Interviews: interviewDTOs,
 		Total:      result.Total,
+		Limit:      page.Limit,
+		Offset:     page.Offset,
+		Page:       page.Page,
+		TotalPages: totalPages,
+		Warnings:   page.Warnings,
 	}
 	writeJSON(w, http.StatusOK, resp)
 }
 
+// ListInterviewsByCandidateHandler handles GET /interviews/by-candidate
+// Pagination applies to candidates, not interviews
+func (deps *HandlerDependencies) ListInterviewsByCandidateHandler(w http.ResponseWriter, r *http.Request) {
+	page := deps.parsePagination(r)
+	opts := data.CandidateGroupOptions{
+		Limit:  page.Limit,
+		Offset: page.Offset,
+		SortBy: data.CandidateSortActivity,
+	}
+	if sortBy := r.URL.Query().Get("sort_by"); sortBy != "" {
+		if sortBy != data.CandidateSortActivity && sortBy != data.CandidateSortScore {
+			writeJSONError(w, http.StatusBadRequest, ErrCodeValidationFailed,
+				fmt.Sprintf("sort_by must be %q or %q", data.CandidateSortActivity, data.CandidateSortScore))
+			return
+		}
+		opts.SortBy = sortBy
+	}
+
+	store := deps.Store.WithContext(r.Context())
+	result, err := store.GetInterviewsGroupedByCandidate(opts)
+	if err != nil {
+		writeJSONError(w, http.StatusInternalServerError, ErrCodeInternal, "Failed to fetch interviews", err.Error())
+		return
+	}
+
+	groupDTOs := make([]CandidateGroupDTO, len(result.Groups))
+	for i, group := range result.Groups {
+		summaries := make([]InterviewSummaryDTO, len(group.Interviews))
+		for j, interview := range group.Interviews {
+			summaries[j] = InterviewSummaryDTO{
+				ID:                interview.ID,
+				CandidateName:     interview.CandidateName,
+				InterviewType:     interview.InterviewType,
+				InterviewLanguage: interview.InterviewLanguage,
+				Status:            interview.Status,
+				CreatedAt:         apitime.New(interview.CreatedAt),
+			}
+		}
+		groupDTOs[i] = CandidateGroupDTO{
+			CandidateName:   group.CandidateName,
+			InterviewCount:  group.InterviewCount,
+			LatestCreatedAt: apitime.New(group.LatestCreatedAt),
+			LatestScore:     group.LatestScore,
+			Interviews:      summaries,
+		}
+	}
+
+	totalPages := (result.Total + page.Limit - 1) / page.Limit
+	if totalPages == 0 {
+		totalPages = 1
+	}
+	writeJSON(w, http.StatusOK, ListCandidateGroupsResponseDTO{
+		Candidates: groupDTOs,
+		Total:      result.Total,
+		Limit:      page.Limit,
+		Offset:     page.Offset,
+		Page:       page.Page,
+		TotalPages: totalPages,
+		Warnings:   page.Warnings,
+	})
+}
+
 // GetInterviewHandler handles GET /interviews/{id}
-func GetInterviewHandler(w http.ResponseWriter, r *http.Request) {
+func (deps *HandlerDependencies) GetInterviewHandler(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "id")
 	if id == "" {
-		writeJSONError(w, ErrCodeBadRequest, ErrMsgMissingInterviewID)
+		writeJSONError(w, http.StatusBadRequest, ErrCodeValidationFailed, ErrMsgMissingInterviewID)
 		return
 	}
 
 	// Get interview from memory store
-	interview, err := data.GlobalStore.GetInterview(id)
+	store := deps.Store.WithContext(r.Context())
+	interview, err := store.GetInterview(id)
 	if err != nil {
-		writeJSONError(w, http.StatusNotFound, "Interview not found")
+		writeJSONError(w, http.StatusNotFound, ErrCodeNotFound, "Interview not found")
 		return
 	}
 
-	resp := InterviewResponseDTO{
-		ID:                interview.ID,
-		CandidateName:     interview.CandidateName,
-		Questions:         interview.Questions,
-		InterviewType:     interview.InterviewType,
-		InterviewLanguage: interview.InterviewLanguage,
-		JobDescription:    interview.JobDescription, // Include job description
-		CreatedAt:         interview.CreatedAt,
+	resp := toInterviewResponseDTO(interview)
+	if includeRequested(r, "question_details") {
+		resp.QuestionDetails = toQuestionDetailDTOs(interview)
 	}
 	writeJSON(w, http.StatusOK, resp)
 }
 
-// SubmitEvaluationHandler handles POST /evaluation
-func (deps *HandlerDependencies) SubmitEvaluationHandler(w http.ResponseWriter, r *http.Request) {
-	var req SubmitEvaluationRequestDTO
+// UpdateInterviewHandler handles PATCH /interviews/{id}
+// Currently replaces the interview's scheduling window; omitted fields are cleared
+func (deps *HandlerDependencies) UpdateInterviewHandler(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		writeJSONError(w, http.StatusBadRequest, ErrCodeValidationFailed, ErrMsgMissingInterviewID)
+		return
+	}
+
+	var req UpdateInterviewRequestDTO
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		writeJSONError(w, http.StatusBadRequest, "Invalid JSON", err.Error())
+		writeJSONError(w, http.StatusBadRequest, ErrCodeInvalidJSON, "Invalid JSON", err.Error())
 		return
 	}
-	if req.InterviewID == "" || len(req.Answers) == 0 {
-		writeJSONError(w, http.StatusBadRequest, "Missing interview_id or answers")
+	if err := validateSchedule(req.ScheduledStart.Std(), req.ScheduledEnd.Std()); err != nil {
+		writeJSONError(w, http.StatusBadRequest, ErrCodeValidationFailed, "Invalid scheduling window", err.Error())
 		return
 	}
-	// Validate interview exists before creating evaluation
-	interview, err := data.GlobalStore.GetInterview(req.InterviewID)
+
+	store := deps.Store.WithContext(r.Context())
+	interview, err := store.GetInterview(id)
 	if err != nil {
-		writeJSONError(w, http.StatusNotFound, "Interview not found")
+		writeJSONError(w, http.StatusNotFound, ErrCodeNotFound, "Interview not found")
 		return
 	}
 
-	// Convert answers map to arrays for AI evaluation
-	questions := interview.Questions
-	answers := make([]string, len(questions))
+	interview.ScheduledStart = req.ScheduledStart.Std()
+	interview.ScheduledEnd = req.ScheduledEnd.Std()
+	interview.Status = scheduleStatus(interview)
+	if err := store.UpdateInterview(interview); err != nil {
+		writeJSONError(w, http.StatusInternalServerError, ErrCodeInternal, "Failed to update interview", err.Error())
+		return
+	}
 
-	// Map answers from the request to the questions order
-	for i := range questions {
-		answerKey := fmt.Sprintf("question_%d", i)
-		if answer, exists := req.Answers[answerKey]; exists {
-			answers[i] = answer
+	writeJSON(w, http.StatusOK, toInterviewResponseDTO(interview))
+}
+
+// CloneInterviewHandler handles POST /interviews/{id}/clone
+// Creates a new interview for another candidate with the source's questions, type, mode, job
+// description, company context, webhook and adaptive settings. Candidate-specific data (resume,
+// scheduling window, sessions and evaluations) is not copied.
+func (deps *HandlerDependencies) CloneInterviewHandler(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		writeJSONError(w, http.StatusBadRequest, ErrCodeValidationFailed, ErrMsgMissingInterviewID)
+		return
+	}
+
+	var req CloneInterviewRequestDTO
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSONError(w, http.StatusBadRequest, ErrCodeInvalidJSON, "Invalid JSON", err.Error())
+		return
+	}
+	if strings.TrimSpace(req.CandidateName) == "" {
+		writeJSONError(w, http.StatusBadRequest, ErrCodeValidationFailed, "Missing candidate_name")
+		return
+	}
+	candidateName, err := data.NormalizeCandidateName(req.CandidateName)
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, ErrCodeValidationFailed, "Invalid candidate_name", err.Error())
+		return
+	}
+	if req.InterviewLanguage != "" && !data.ValidateLanguage(req.InterviewLanguage) {
+		writeJSONError(w, http.StatusBadRequest, ErrCodeValidationFailed, ErrMsgInvalidLanguage)
+		return
+	}
+
+	store := deps.Store.WithContext(r.Context())
+	source, err := store.GetInterview(id)
+	if err != nil {
+		writeJSONError(w, http.StatusNotFound, ErrCodeNotFound, "Interview not found")
+		return
+	}
+	// There are no questions to copy yet
+	if errResp := checkQuestionsReady(source); errResp != nil {
+		writeJSON(w, http.StatusConflict, errResp)
+		return
+	}
+
+	language := source.InterviewLanguage
+	if req.InterviewLanguage != "" {
+		language = req.InterviewLanguage
+	}
+	interview := &data.Interview{
+		ID:                data.GenerateID(),
+		CandidateName:     candidateName,
+		Questions:         append(data.StringArray{}, source.Questions...),
+		QuestionDetails:   append(data.QuestionDetailList(nil), source.QuestionDetails...),
+		InterviewType:     source.InterviewType,
+		InterviewMode:     source.InterviewMode,
+		InterviewLanguage: language,
+		JobDescription:    source.JobDescription,
+		JobDescSummary:    source.JobDescSummary,
+		CompanyContext:    source.CompanyContext,
+		ScheduledStart:    req.ScheduledStart.Std(),
+		NotifyWebhookURL:  source.NotifyWebhookURL,
+		NotifyEvents:      append(data.StringArray(nil), source.NotifyEvents...),
+		NotifySecret:      source.NotifySecret,
+		AdaptiveDisabled:  source.AdaptiveDisabled,
+		QuestionStrategy:  source.QuestionStrategy,
+		ClonedFrom:        source.ID,
+	}
+	interview.Status = scheduleStatus(interview)
+	if err := store.CreateInterview(interview); err != nil {
+		writeStoreError(w, err, "Failed to clone interview", "Interview already exists")
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, toInterviewResponseDTO(interview))
+}
+
+// SubmitEvaluationHandler handles POST /evaluation
+func (deps *HandlerDependencies) SubmitEvaluationHandler(w http.ResponseWriter, r *http.Request) {
+	var req SubmitEvaluationRequestDTO
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSONError(w, http.StatusBadRequest, ErrCodeInvalidJSON, "Invalid JSON", err.Error())
+		return
+	}
+	if req.InterviewID == "" || len(req.Answers) == 0 {
+		writeJSONError(w, http.StatusBadRequest, ErrCodeValidationFailed, "Missing interview_id or answers")
+		return
+	}
+	if req.DetailLevel != "" && !ai.ValidateDetailLevel(req.DetailLevel) {
+		writeJSONError(w, http.StatusBadRequest, ErrCodeValidationFailed, ErrMsgInvalidDetailLevel)
+		return
+	}
+	feedbackLanguages, ok := deps.feedbackLanguages(req.AdditionalFeedbackLanguages, req.AdditionalFeedbackLanguages != nil)
+	if !ok {
+		writeJSONError(w, http.StatusBadRequest, ErrCodeValidationFailed, ErrMsgInvalidLanguage)
+		return
+	}
+	// Validate interview exists before creating evaluation
+	store := deps.Store.WithContext(r.Context())
+	interview, err := store.GetInterview(req.InterviewID)
+	if err != nil {
+		writeJSONError(w, http.StatusNotFound, ErrCodeNotFound, "Interview not found")
+		return
+	}
+
+	// Conversational interviews have no indexed questions to key answers by; they are evaluated
+	// from the chat transcript when the session ends
+	if interview.IsConversational() {
+		writeJSONError(w, http.StatusBadRequest, ErrCodeValidationFailed, "Conversational interviews are evaluated from their chat transcript; end the chat session instead")
+		return
+	}
+
+	// Each interview has one authoritative evaluation. A second submission is rejected
+	// unless ?replace=true, in which case the new evaluation supersedes the current one.
+	// Checked before the AI call so rejected submissions don't spend provider quota.
+	var supersedesID string
+	if existing, err := store.GetLatestEvaluationByInterview(req.InterviewID); err == nil {
+		if r.URL.Query().Get("replace") != "true" {
+			writeEvaluationConflict(w, "Interview already has an evaluation; resubmit with ?replace=true to replace it", existing.ID)
+			return
+		}
+		supersedesID = existing.ID
+	}
+
+	// Reject answers that don't correspond to a question rather than silently dropping them
+	questions := interview.Questions
+	if invalid := invalidAnswerKeys(req.Answers, len(questions)); len(invalid) > 0 {
+		writeJSONError(w, http.StatusBadRequest, ErrCodeValidationFailed, "Answer keys do not match interview questions",
+			fmt.Sprintf("unknown answer keys: %s (expected question_0 to question_%d)", strings.Join(invalid, ", "), len(questions)-1))
+		return
+	}
+
+	// Convert answers map to arrays for AI evaluation
+	answers := make([]string, len(questions))
+
+	// Map answers from the request to the questions order
+	for i := range questions {
+		if answer, exists := req.Answers[answerKey(i)]; exists {
+			answers[i] = answer
 		} else {
 			answers[i] = "" // Empty answer if not provided
 		}
 	}
 	// Generate AI evaluation using the same method as chat evaluation
-	jobDesc := interview.JobDescription
-	if jobDesc == "" {
-		jobDesc = fmt.Sprintf("General %s interview", interview.InterviewType)
+	// Use interview language for evaluation
+	evalCtx := buildEvaluationContext(interview, interview.InterviewLanguage)
+	evalCtx.DetailLevel = req.DetailLevel
+	evalCtx.MaxFeedbackWords = deps.MaxFeedbackWords
+	evalCtx.MinAnswerWords = deps.MinAnswerWords
+
+	// Create AI client from request headers (BYOK pattern)
+	aiClient := deps.newAIClient(r)
+
+	var jobDescCost float64
+	if interview.JobDescription != "" {
+		evalCtx.JobDescription, jobDescCost = deps.promptJobDescription(r.Context(), aiClient, deps.Store.WithContext(r.Context()), interview)
+	}
+	result, err := aiClient.EvaluateAnswersDetailed(r.Context(), questions, answers, evalCtx)
+	if err != nil {
+		if writeAIOverloaded(w, err) {
+			return
+		}
+		writeJSONError(w, http.StatusInternalServerError, ErrCodeAIUnavailable, "Failed to generate evaluation")
+		return
+	}
+
+	// Create evaluation record
+	evaluationID := data.GenerateID()
+	evaluation := &data.Evaluation{
+		ID:                evaluationID,
+		InterviewID:       req.InterviewID,
+		Answers:           req.Answers,
+		QuestionsSnapshot: append([]string(nil), questions...),
+		Score:             result.OverallScore,
+		Feedback:          result.Feedback,
+		Status:            data.EvaluationStatusCompleted,
+		Provider:          result.Provider,
+		Model:             result.Model,
+		SupersedesID:      supersedesID,
+		EstimatedCostUSD:  result.EstimatedCostUSD + jobDescCost,
+		LanguageMismatch:  result.LanguageMismatch,
+		FeedbackTruncated: feedbackTruncated(result),
+		Decision:          result.Decision,
+		NextSteps:         result.NextSteps,
+		AnswerStats:       answerStats(result),
+	}
+	translateEvaluation(r.Context(), aiClient, evaluation, result, interview.InterviewLanguage, feedbackLanguages)
+
+	err = store.CreateEvaluation(evaluation)
+	if err != nil {
+		writeStoreError(w, err, "Failed to save evaluation", "Evaluation already exists")
+		return
+	}
+	recordEvaluationTrace(store, evaluation, result)
+	deps.notifyEvaluationCreated(interview, evaluation, "")
+
+	resp := toEvaluationResponseDTO(evaluation)
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// writeEvaluationConflict rejects a request that would add a second evaluation to an interview
+func writeEvaluationConflict(w http.ResponseWriter, message, existingID string) {
+	writeJSON(w, http.StatusConflict, EvaluationConflictResponseDTO{
+		ErrorResponseDTO:     ErrorResponseDTO{Error: message, Code: ErrCodeConflict},
+		ExistingEvaluationID: existingID,
+	})
+}
+
+// GetEvaluationHandler handles GET /evaluation/{id}
+func (deps *HandlerDependencies) GetEvaluationHandler(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		writeJSONError(w, http.StatusBadRequest, ErrCodeValidationFailed, ErrMsgMissingEvaluationID)
+		return
+	}
+	// Get evaluation from database
+	store := deps.Store.WithContext(r.Context())
+	evaluation, err := store.GetEvaluation(id)
+	if err != nil {
+		writeJSONError(w, http.StatusNotFound, ErrCodeNotFound, "Evaluation not found")
+		return
+	}
+
+	resp := toEvaluationResponseDTO(evaluation)
+	if includeRequested(r, "percentile") {
+		warnings := warningList(resp.Warnings)
+		percentile, size, err := deps.newCohortRanker(store).evaluationPercentile(evaluation, &warnings)
+		if err != nil {
+			writeJSONError(w, http.StatusInternalServerError, ErrCodeInternal, "Failed to rank evaluation score")
+			return
+		}
+		resp.Percentile, resp.CohortSize, resp.Warnings = percentile, size, warnings
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// evaluationStatus returns the evaluation's status, treating records created before
+// the field existed as completed
+func evaluationStatus(evaluation *data.Evaluation) string {
+	if evaluation.Status == "" {
+		return data.EvaluationStatusCompleted
+	}
+	return evaluation.Status
+}
+
+// answerKey returns the answers map key for the question at index i
+func answerKey(i int) string {
+	return fmt.Sprintf("question_%d", i)
+}
+
+// invalidAnswerKeys returns, sorted, the answer keys that aren't "question_N" for 0 <= N < questionCount
+func invalidAnswerKeys(answers map[string]string, questionCount int) []string {
+	var invalid []string
+	for key := range answers {
+		index, err := strconv.Atoi(strings.TrimPrefix(key, "question_"))
+		if err != nil || index < 0 || index >= questionCount || answerKey(index) != key {
+			invalid = append(invalid, key)
+		}
+	}
+	sort.Strings(invalid)
+	return invalid
+}
+
+// toAnswerDTOs resolves the evaluation's answers against its question snapshot, never the
+// live interview, so later edits to the interview don't change how an evaluation reads
+// Evaluations stored before the snapshot existed have no questions and yield nil
+func toAnswerDTOs(evaluation *data.Evaluation) []AnswerDTO {
+	if len(evaluation.QuestionsSnapshot) == 0 {
+		return nil
+	}
+	answers := make([]AnswerDTO, len(evaluation.QuestionsSnapshot))
+	for i, question := range evaluation.QuestionsSnapshot {
+		answers[i] = AnswerDTO{Question: question, Answer: evaluation.Answers[answerKey(i)]}
+	}
+	return answers
+}
+
+// feedbackTruncated reports whether the AI client cut the evaluation's feedback to its word limit
+func feedbackTruncated(result *ai.EvaluationResponse) bool {
+	truncated, _ := result.Metadata[ai.MetadataFeedbackTruncated].(bool)
+	return truncated
+}
+
+// answerStats returns the completeness of the answers the AI client counted; nil when it counted none
+func answerStats(result *ai.EvaluationResponse) *data.AnswerStats {
+	stats, ok := result.AnswerStats()
+	if !ok {
+		return nil
+	}
+	return &data.AnswerStats{WordCounts: stats.WordCounts, MinWords: stats.MinWords, Empty: stats.Empty, TooShort: stats.TooShort}
+}
+
+// toAnswerStatsDTO converts the completeness of an evaluation's answers; nil when it wasn't recorded
+func toAnswerStatsDTO(stats *data.AnswerStats) *AnswerStatsDTO {
+	if stats == nil {
+		return nil
+	}
+	return &AnswerStatsDTO{
+		Questions:  len(stats.WordCounts),
+		Unanswered: stats.Unanswered(),
+		Empty:      stats.Empty,
+		TooShort:   stats.TooShort,
+		MinWords:   stats.MinWords,
+		WordCounts: stats.WordCounts,
+	}
+}
+
+// toEvaluationResponseDTO converts a stored evaluation to its API representation
+func toEvaluationResponseDTO(evaluation *data.Evaluation) EvaluationResponseDTO {
+	return EvaluationResponseDTO{
+		ID:                evaluation.ID,
+		InterviewID:       evaluation.InterviewID,
+		Answers:           evaluation.Answers,
+		AnswersV2:         toAnswerDTOs(evaluation),
+		QuestionsSnapshot: evaluation.QuestionsSnapshot,
+		Score:             evaluation.Score,
+		Feedback:          evaluation.Feedback,
+		Status:            evaluationStatus(evaluation),
+		Provider:          evaluation.Provider,
+		Model:             evaluation.Model,
+		SupersedesID:      evaluation.SupersedesID,
+		EstimatedCostUSD:  evaluation.EstimatedCostUSD,
+		LanguageMismatch:  evaluation.LanguageMismatch,
+		FeedbackTruncated: evaluation.FeedbackTruncated,
+		Decision:          evaluation.Decision,
+		NextSteps:         evaluation.NextSteps,
+		Translations:      toEvaluationTranslationDTOs(evaluation.Translations),
+		AnswerStats:       toAnswerStatsDTO(evaluation.AnswerStats),
+		CreatedAt:         apitime.New(evaluation.CreatedAt),
+		Warnings:          evaluationWarnings(evaluation),
+	}
+}
+
+// checkScheduleWindow rejects starting a chat session outside the interview's scheduling window
+// Starting up to ScheduleGracePeriod after scheduled_end is tolerated
+func (deps *HandlerDependencies) checkScheduleWindow(interview *data.Interview) *ScheduleWindowErrorResponseDTO {
+	now := deps.now()
+	if interview.ScheduledStart != nil && now.Before(*interview.ScheduledStart) {
+		return &ScheduleWindowErrorResponseDTO{
+			ErrorResponseDTO: ErrorResponseDTO{Error: "Interview has not started yet", Code: ErrCodeTooEarly},
+			ScheduledStart:   apitime.NewPtr(interview.ScheduledStart),
+		}
+	}
+	if interview.ScheduledEnd != nil && now.After(interview.ScheduledEnd.Add(deps.ScheduleGracePeriod)) {
+		return &ScheduleWindowErrorResponseDTO{
+			ErrorResponseDTO: ErrorResponseDTO{Error: "Interview scheduling window has closed", Code: ErrCodeExpired},
+			ScheduledEnd:     apitime.NewPtr(interview.ScheduledEnd),
+		}
+	}
+	return nil
+}
+
+// StartChatSessionHandler handles POST /interviews/{id}/chat/start
+func (deps *HandlerDependencies) StartChatSessionHandler(w http.ResponseWriter, r *http.Request) {
+	// The session and opening message are read back right after being written, so skip the replica
+	store := deps.Store.WithContext(r.Context()).WithPrimaryReads()
+
+	interviewID := chi.URLParam(r, "id")
+	if interviewID == "" {
+		writeJSONError(w, http.StatusBadRequest, ErrCodeValidationFailed, "Missing interview ID")
+		return
+	}
+
+	// Validate interview exists and get it for language inheritance
+	interview, err := store.GetInterview(interviewID)
+	if err != nil {
+		writeJSONError(w, http.StatusNotFound, ErrCodeNotFound, "Interview not found")
+		return
+	}
+
+	if errResp := deps.checkScheduleWindow(interview); errResp != nil {
+		writeJSON(w, http.StatusForbidden, errResp)
+		return
+	}
+	if errResp := checkQuestionsReady(interview); errResp != nil {
+		writeJSON(w, http.StatusConflict, errResp)
+		return
+	}
+
+	// Parse optional request body for language preference
+	var req StartChatSessionRequestDTO
+	if r.ContentLength > 0 {
+		// Ignore decode errors for optional body - use interview language as fallback
+		_ = json.NewDecoder(r.Body).Decode(&req)
+	}
+	sessionLanguage, failure := sessionLanguageFor(interview, &req)
+	if failure != nil {
+		failure.write(w, "")
+		return
+	}
+	greetingMode, failure := deps.greetingModeFor(&req)
+	if failure != nil {
+		failure.write(w, "")
+		return
+	}
+
+	// Create AI client from request headers (BYOK pattern)
+	aiClient := deps.newAIClient(r)
+	session := deps.newChatSession(interview, sessionLanguage, greetingMode)
+	err = store.CreateChatSession(session)
+	if err != nil {
+		writeStoreError(w, err, "Failed to create chat session", "Chat session already exists")
+		return
+	}
+	// A draft or scheduled interview becomes active once a session starts
+	if interview.Status != data.InterviewStatusActive && interview.Status != data.InterviewStatusCompleted {
+		interview.Status = data.InterviewStatusActive
+		if err := store.UpdateInterview(interview); err != nil {
+			utils.Errorf("Failed to mark interview %s active: %v", interviewID, err)
+		}
+	}
+
+	// Open the session as its greeting mode says; sessions without a greeting start empty
+	greeting, err := deps.greet(r.Context(), aiClient, store, interview, session)
+	if err != nil {
+		utils.Errorf("Failed to generate AI greeting: %v", err)
+		if deps.writeAIBudgetExhausted(w, store, session, err) || writeAIOverloaded(w, err) {
+			return
+		}
+		writeJSONError(w, http.StatusInternalServerError, ErrCodeAIUnavailable, "Failed to generate AI response", err.Error())
+		return
+	}
+	var cost float64
+	if greeting != nil {
+		if err := deps.saveGreeting(store, interview, session.ID, greeting, aiClient.Redactor()); err != nil {
+			writeStoreError(w, err, "Failed to save AI message", "Message already exists")
+			return
+		}
+		cost = greeting.EstimatedCostUSD
+	}
+
+	writeJSON(w, http.StatusCreated, deps.startedSessionResponse(r, store, session, cost))
+}
+
+// sessionLanguageFor picks a new session's language: the requested one if given, otherwise the
+// interview's. An unsupported language is rejected rather than replaced, matching interview creation
+func sessionLanguageFor(interview *data.Interview, req *StartChatSessionRequestDTO) (string, *interviewRequestError) {
+	if req.SessionLanguage == "" {
+		return data.GetValidatedLanguage(interview.InterviewLanguage), nil
+	}
+	if !data.ValidateLanguage(req.SessionLanguage) {
+		return "", invalidInterviewRequest(ErrMsgInvalidLanguage)
+	}
+	return req.SessionLanguage, nil
+}
+
+// greetingModeFor picks a new session's greeting mode: the requested one if given, otherwise the configured one
+func (deps *HandlerDependencies) greetingModeFor(req *StartChatSessionRequestDTO) (string, *interviewRequestError) {
+	if req.Greeting == "" {
+		return deps.GreetingMode, nil
+	}
+	if !data.ValidateGreetingMode(req.Greeting) {
+		return "", invalidInterviewRequest(ErrMsgInvalidGreeting)
+	}
+	return req.Greeting, nil
+}
+
+// newChatSession builds an active chat session of interview opening with greetingMode; its question
+// order is drawn from the interview's strategy. Its provider and model are pinned by its first
+// successful AI turn (see pinProvider).
+func (deps *HandlerDependencies) newChatSession(interview *data.Interview, language, greetingMode string) *data.ChatSession {
+	session := &data.ChatSession{
+		ID:              data.GenerateID(),
+		InterviewID:     interview.ID,
+		SessionLanguage: language,
+		GreetingMode:    greetingMode,
+		Status:          "active",
+		StartedAt:       deps.now(),
+	}
+	if interview.IsAdaptive() {
+		session.DifficultyLevel = ai.DefaultDifficultyLevel
+		session.DifficultyTrajectory = data.IntArray{ai.DefaultDifficultyLevel}
+	}
+	session.QuestionOrder = data.QuestionOrder(interview.QuestionStrategy, session.ID, len(interview.PlannedQuestions()))
+	return session
+}
+
+// greet produces the opening message of a session according to its greeting mode: generated by the
+// AI, rendered from the greeting template without a provider call, or nil for sessions that open
+// without one
+func (deps *HandlerDependencies) greet(ctx context.Context, aiClient *ai.AIClient, store data.Store, interview *data.Interview, session *data.ChatSession) (*ai.ChatResponse, error) {
+	switch session.GreetingMode {
+	case data.GreetingModeNone:
+		return nil, nil
+	case data.GreetingModeTemplate:
+		return &ai.ChatResponse{Content: ai.RenderGreeting(session.SessionLanguage, interview.CandidateName, interview.InterviewType)}, nil
+	default:
+		return deps.generateGreeting(ctx, aiClient, store, interview, session)
+	}
+}
+
+// generateGreeting asks the AI for the opening message of a session, counting it against the session's
+// AI attempts; a greeting generated pins the session to aiClient's provider and model
+func (deps *HandlerDependencies) generateGreeting(ctx context.Context, aiClient *ai.AIClient, store data.Store, interview *data.Interview, session *data.ChatSession) (*ai.ChatResponse, error) {
+	deps.limitAIAttempts(aiClient, store, session.ID)
+	greeting, err := aiClient.GenerateInterviewTurn(ctx, ai.InterviewTurnRequest{
+		SessionID:     session.ID,
+		InterviewType: interview.InterviewType,
+		Language:      session.SessionLanguage,
+	})
+	if err != nil {
+		return nil, err
+	}
+	deps.pinProvider(store, session, aiClient)
+	return greeting, nil
+}
+
+// saveGreeting stores the opening AI message of a session along with the question it asks and its cost
+func (deps *HandlerDependencies) saveGreeting(store data.Store, interview *data.Interview, sessionID string, greeting *ai.ChatResponse, redactor *ai.Redactor) error {
+	aiMessage := &data.ChatMessage{
+		ID:        data.GenerateID(),
+		SessionID: sessionID,
+		Type:      "ai",
+		Subtype:   data.MessageSubtypeGreeting,
+		Content:   greeting.Content,
+		Metadata:  withExpectedTime(aiReplyMetadata(greeting, redactor), interview, greeting.Content),
+		Provider:  greeting.Provider,
+		Model:     greeting.Model,
+		Timestamp: deps.now(),
+	}
+	if err := store.AddChatMessageWithLimit(sessionID, aiMessage, deps.MaxMessagesPerSession); err != nil {
+		return err
+	}
+	// The greeting carries the opening question the first answer responds to
+	// Conversational interviews don't track questions; their evaluation pairs answers from the transcript
+	if !interview.IsConversational() {
+		recordAskedQuestion(store, sessionID, greeting.Content)
+	}
+	recordSessionCost(store, sessionID, greeting.EstimatedCostUSD)
+	return nil
+}
+
+// startedSessionResponse converts a just-started session, with its greeting if any, to its API representation
+// cost is the estimated cost of the AI calls made so far
+func (deps *HandlerDependencies) startedSessionResponse(r *http.Request, store data.Store, session *data.ChatSession, cost float64) ChatInterviewSessionDTO {
+	includeMeta := includeRequested(r, "meta")
+	messages, _ := store.GetChatMessages(session.ID)
+	messageDTOs := make([]ChatMessageDTO, len(messages))
+	for i, msg := range messages {
+		messageDTOs[i] = toChatMessageDTO(msg, includeMeta)
+	}
+
+	response := ChatInterviewSessionDTO{
+		ID:               session.ID,
+		InterviewID:      session.InterviewID,
+		SessionLanguage:  session.SessionLanguage,
+		Greeting:         greetingMode(session),
+		Messages:         messageDTOs,
+		Status:           session.Status,
+		Provider:         session.Provider,
+		Model:            session.Model,
+		EstimatedCostUSD: cost,
+		StartedAt:        apitime.New(session.StartedAt),
+		EndedAt:          apitime.NewPtr(session.EndedAt),
+		DurationSeconds:  sessionDurationSeconds(session),
+		CreatedAt:        apitime.New(session.CreatedAt),
+		DifficultyLevel:  session.DifficultyLevel,
+	}
+	// Reload so the database backend reflects the recorded greeting
+	if updated, err := store.GetChatSession(session.ID); err == nil {
+		session = updated
+	}
+	response.Progress = deps.sessionProgress(store, session, 0, len(messages))
+	if includeRequested(r, "asked_questions") {
+		response.AskedQuestions = session.AskedQuestions
+	}
+	return response
+}
+
+// greetingMode returns how a session opened; sessions stored before greeting modes opened with the AI
+func greetingMode(session *data.ChatSession) string {
+	if session.GreetingMode == "" {
+		return data.GreetingModeAI
+	}
+	return session.GreetingMode
+}
+
+// toChatMessageDTO converts a stored chat message to its API representation
+// Provider/model attribution is only included when requested via ?include=meta
+func toChatMessageDTO(msg *data.ChatMessage, includeMeta bool) ChatMessageDTO {
+	dto := ChatMessageDTO{
+		ID:              msg.ID,
+		ClientMessageID: msg.ClientMessageID,
+		Type:            msg.Type,
+		Subtype:         msg.Subtype,
+		Content:         msg.Content,
+		Visibility:      messageVisibility(msg),
+		Timestamp:       apitime.New(msg.Timestamp),
+	}
+	if includeMeta {
+		dto.Provider = msg.Provider
+		dto.Model = msg.Model
+	}
+	if msg.Metadata[data.MessageMetaLanguageMismatch] == "true" {
+		dto.Metadata = map[string]string{data.MessageMetaLanguageMismatch: "true"}
+	}
+	if minutes := msg.Metadata[data.MessageMetaExpectedTime]; minutes != "" {
+		if dto.Metadata == nil {
+			dto.Metadata = make(map[string]string)
+		}
+		dto.Metadata[data.MessageMetaExpectedTime] = minutes
+	}
+	return dto
+}
+
+// aiReplyMetadata returns the message metadata to store for an AI reply
+// A language mismatch is recorded so clients can warn that the reply isn't in the session language.
+// When redactor is non-nil, the PII it redacted from the provider payload is recorded for audit.
+func aiReplyMetadata(resp *ai.ChatResponse, redactor *ai.Redactor) data.StringMap {
+	var metadata data.StringMap
+	if mismatch, _ := resp.Metadata[ai.MetadataLanguageMismatch].(bool); mismatch {
+		metadata = data.StringMap{data.MessageMetaLanguageMismatch: "true"}
+	}
+	if redactor != nil {
+		if mapping := redactor.Mapping(); len(mapping) > 0 {
+			encoded, err := json.Marshal(mapping)
+			if err != nil {
+				utils.Errorf("Failed to encode PII redaction mapping: %v", err)
+				return metadata
+			}
+			if metadata == nil {
+				metadata = data.StringMap{}
+			}
+			metadata[data.MessageMetaRedactions] = string(encoded)
+		}
+	}
+	return metadata
+}
+
+// Helper: normalize a client-provided message ID to canonical UUID form
+// Malformed IDs are ignored so the message is processed as if none was given
+func normalizeClientMessageID(id string) string {
+	if id == "" {
+		return ""
+	}
+	parsed, err := uuid.Parse(id)
+	if err != nil {
+		utils.Warningf("Ignoring malformed client_message_id %q: %v", id, err)
+		return ""
+	}
+	return parsed.String()
+}
+
+// Helper: find the AI reply stored directly after a user message, if any
+func findAIReply(store data.Store, sessionID, userMessageID string) *data.ChatMessage {
+	messages, err := store.GetChatMessages(sessionID)
+	if err != nil {
+		return nil
+	}
+	for i, msg := range messages {
+		if msg.ID != userMessageID {
+			continue
+		}
+		if i+1 < len(messages) && messages[i+1].Type == "ai" {
+			return messages[i+1]
+		}
+		return nil
+	}
+	return nil
+}
+
+// questionPrompts are imperative openings that ask something without a question mark
+var questionPrompts = []string{
+	"describe", "explain", "tell me", "walk me through", "give me an example", "talk about",
+	"請", "描述", "說明", "介紹",
+}
+
+// classifyAIReply determines the subtype of a regular (non-greeting, non-closing) AI turn
+// Replies that pose one of the interview's planned questions are questions; other replies that
+// ask something are improvised follow-ups; anything else is an acknowledgement
+func classifyAIReply(reply string, plannedQuestions []string) string {
+	for _, planned := range plannedQuestions {
+		if asksPlannedQuestion(reply, planned) {
+			return data.MessageSubtypeQuestion
+		}
+	}
+	if strings.ContainsAny(reply, "?？") {
+		return data.MessageSubtypeFollowUp
+	}
+	for _, prompt := range questionPrompts {
+		if strings.Contains(strings.ToLower(reply), prompt) {
+			return data.MessageSubtypeFollowUp
+		}
+	}
+	return data.MessageSubtypeAcknowledgement
+}
+
+// asksPlannedQuestion reports whether an AI reply contains the planned question, ignoring case and trailing punctuation
+func asksPlannedQuestion(reply, planned string) bool {
+	normalized := strings.ToLower(strings.TrimRight(strings.TrimSpace(planned), "?？.。!！ "))
+	return normalized != "" && strings.Contains(strings.ToLower(reply), normalized)
+}
+
+// plannedQuestionsAsked counts the planned questions that appear in the session's asked questions
+func plannedQuestionsAsked(plannedQuestions, askedQuestions []string) int {
+	count := 0
+	for _, planned := range plannedQuestions {
+		for _, asked := range askedQuestions {
+			if asksPlannedQuestion(asked, planned) {
+				count++
+				break
+			}
+		}
+	}
+	return count
+}
+
+// pairAnswersWithQuestions groups the candidate's messages under the question they answer
+// Each question-bearing AI message opens a new question; acknowledgements and closings do not,
+// so consecutive answers to the same question are joined. Question text is taken from the
+// session's recorded asked questions when available, otherwise from the transcript itself.
+// Messages before the first question, as sessions opening without a greeting start with, answer
+// nothing and are left out.
+func pairAnswersWithQuestions(messages []*data.ChatMessage, askedQuestions []string) ([]string, []string) {
+	questions := make([]string, 0)
+	answers := make([]string, 0)
+	questionIndex := -1
+	currentQuestion := ""
+	answered := false
+
+	for _, msg := range messages {
+		switch msg.Type {
+		case "ai":
+			if !data.IsQuestionSubtype(msg.Subtype) {
+				continue
+			}
+			questionIndex++
+			currentQuestion = msg.Content
+			if questionIndex < len(askedQuestions) {
+				currentQuestion = askedQuestions[questionIndex]
+			}
+			answered = false
+		case "user":
+			if questionIndex < 0 {
+				continue
+			}
+			if answered {
+				answers[len(answers)-1] += "\n\n" + msg.Content
+				continue
+			}
+			questions = append(questions, currentQuestion)
+			answers = append(answers, msg.Content)
+			answered = true
+		}
+	}
+
+	return questions, answers
+}
+
+// recordSessionCost adds the estimated cost of an AI call to the session's total
+// Failures are logged rather than failing the chat turn
+func recordSessionCost(store data.Store, sessionID string, amount float64) {
+	if amount == 0 {
+		return
+	}
+	if err := store.AddChatSessionCost(sessionID, amount); err != nil {
+		utils.Errorf("Failed to record AI cost for session %s: %v", sessionID, err)
+	}
+}
+
+// adaptDifficulty assesses the candidate's answer to the last AI turn, records the resulting
+// difficulty level and returns the history note that steers the next question
+// Returns nil when there is no AI turn before the answer or the level did not change
+func adaptDifficulty(ctx context.Context, aiClient *ai.AIClient, store data.Store, session *data.ChatSession, messages []*data.ChatMessage, answer *data.ChatMessage) *ai.Turn {
+	var question string
+	for _, msg := range messages {
+		if msg.ID == answer.ID {
+			break
+		}
+		if msg.Type == "ai" {
+			question = msg.ContextContent()
+		}
+	}
+	if question == "" {
+		return nil
+	}
+
+	assessment := aiClient.AssessAnswer(ctx, question, answer.ContextContent(), session.SessionLanguage)
+	recordSessionCost(store, session.ID, assessment.EstimatedCostUSD)
+	// Sessions started before adaptation existed begin at the default level
+	previous := session.DifficultyLevel
+	if previous == 0 {
+		previous = ai.DefaultDifficultyLevel
+	}
+	level := ai.NextDifficultyLevel(previous, assessment.Quality)
+	if err := store.AppendDifficultyLevel(session.ID, level); err != nil {
+		utils.Errorf("Failed to record difficulty level for session %s: %v", session.ID, err)
+	}
+	session.DifficultyLevel = level
+	// An unchanged level needs no steering; the interviewer keeps its current pitch
+	if level == previous {
+		return nil
+	}
+	note := ai.DifficultyNote(previous, level)
+	return &note
+}
+
+// recordAskedQuestion stores a question the AI asked on the session
+// Failures are logged rather than failing the chat turn
+func recordAskedQuestion(store data.Store, sessionID, question string) {
+	if err := store.AppendAskedQuestion(sessionID, question); err != nil {
+		utils.Errorf("Failed to record asked question for session %s: %v", sessionID, err)
+	}
+}
+
+// buildConversationHistory converts a transcript into the history sent to the AI, leaving out
+// the message with excludeID (the one being answered)
+// System notes (e.g. language switches) are already reflected in the system prompt
+func buildConversationHistory(messages []*data.ChatMessage, excludeID string) []ai.Turn {
+	history := make([]ai.Turn, 0, len(messages))
+	for _, msg := range messages {
+		if msg.ID != excludeID && msg.Type != "system" {
+			history = append(history, ai.Turn{Role: msg.Type, Content: msg.ContextContent()})
+		}
+	}
+	return history
+}
+
+// countUserMessages counts the candidate's messages in a transcript
+func countUserMessages(messages []*data.ChatMessage) int {
+	count := 0
+	for _, msg := range messages {
+		if msg.Type == "user" {
+			count++
+		}
+	}
+	return count
+}
+
+// endsInterview decides whether the candidate message that brings a session to userMessages
+// candidate messages and totalMessages stored messages ends the interview. It ends on the AI
+// message limit, once every planned question has been asked, or when only the closing reply
+// still fits under MaxMessagesPerSession. A session reopened reopens times already ended once, so
+// it ignores the planned questions and ends on the message limit raised for its reopens instead.
+func (deps *HandlerDependencies) endsInterview(userMessages, totalMessages, questionsAsked, questionsTotal, reopens int) bool {
+	return ai.ReachedMessageLimit(userMessages, reopens) ||
+		(reopens == 0 && questionsTotal > 0 && questionsAsked >= questionsTotal) ||
+		totalMessages >= deps.MaxMessagesPerSession-1
+}
+
+// sessionProgress reports interview progress from the session's asked questions and message counts
+// Interviews with planned questions progress by planned questions asked; free-form interviews
+// progress toward the message limit that ends the interview
+func (deps *HandlerDependencies) sessionProgress(store data.Store, session *data.ChatSession, userMessages, totalMessages int) *InterviewProgressDTO {
+	progress := &InterviewProgressDTO{
+		QuestionsAsked: len(session.AskedQuestions),
+		UserMessages:   userMessages,
+	}
+	if interview, err := store.GetInterview(session.InterviewID); err == nil {
+		if planned := interview.PlannedQuestionsFor(session); len(planned) > 0 {
+			progress.QuestionsTotal = len(planned)
+			progress.QuestionsAsked = plannedQuestionsAsked(planned, session.AskedQuestions)
+			if session.Status == "active" {
+				progress.CurrentQuestionExpectedTime = currentQuestionExpectedTime(interview, session.AskedQuestions)
+			}
+		}
+	}
+
+	if session.Status != "active" {
+		progress.PercentComplete = 100
+	} else {
+		if progress.QuestionsTotal > 0 {
+			progress.PercentComplete = progress.QuestionsAsked * 100 / progress.QuestionsTotal
+		} else {
+			limit := ai.MessageLimit(session.ReopenCount)
+			progress.PercentComplete = min(userMessages, limit) * 100 / limit
+		}
+		// Progress only reaches 100 once the session completes
+		progress.PercentComplete = min(progress.PercentComplete, 99)
+		progress.WillEndAfterNext = deps.endsInterview(userMessages+1, totalMessages+1, progress.QuestionsAsked, progress.QuestionsTotal, session.ReopenCount)
+	}
+	return progress
+}
+
+// interviewTypeContext tags ctx with the type of the session's interview, for the AI usage metrics
+func interviewTypeContext(ctx context.Context, store data.Store, session *data.ChatSession) context.Context {
+	interview, err := store.GetInterview(session.InterviewID)
+	if err != nil {
+		return ctx
+	}
+	return ai.WithInterviewType(ctx, interview.InterviewType)
+}
+
+// compactHistory replaces the oldest turns of a long conversation with the session's running summary
+// Once the history exceeds SummaryThresholdTurns, every turn but the most recent SummaryRecentTurns
+// is folded into the summary, which is stored on the session and sent in their place.
+// If summarization fails, the turns not yet summarized are sent in full.
+func (deps *HandlerDependencies) compactHistory(ctx context.Context, aiClient *ai.AIClient, session *data.ChatSession, history []ai.Turn) []ai.Turn {
+	store := deps.Store.WithContext(ctx)
+	covered := min(session.SummarizedTurns, len(history))
+	if target := len(history) - deps.SummaryRecentTurns; len(history) > deps.SummaryThresholdTurns && target > covered {
+		summary, err := aiClient.SummarizeConversation(ctx, session.ConversationSummary, history[covered:target], session.SessionLanguage)
+		if err != nil {
+			utils.Warningf("Failed to summarize conversation for session %s: %v", session.ID, err)
+		} else {
+			recordSessionCost(store, session.ID, summary.EstimatedCostUSD)
+			session.ConversationSummary = summary.Content
+			session.SummarizedTurns = target
+			covered = target
+			if err := store.UpdateChatSession(session); err != nil {
+				utils.Errorf("Failed to save conversation summary for session %s: %v", session.ID, err)
+			}
+		}
+	}
+
+	if covered == 0 || session.ConversationSummary == "" {
+		return history
+	}
+	return append([]ai.Turn{ai.ConversationSummaryNote(session.ConversationSummary)}, history[covered:]...)
+}
+
+// SendMessageHandler handles POST /chat/{sessionId}/message
+func (deps *HandlerDependencies) SendMessageHandler(w http.ResponseWriter, r *http.Request) {
+	// Each stage of the turn gets its share of the latency budget, and its store calls retry
+	// transient database failures until the stage's time is up
+	// Reads go to the primary: the reply lookup and history must see the messages just written
+	timings := newRequestTimings()
+	budget := newTurnBudget(r.Context(), deps.TurnBudget)
+	timings.budget = budget
+	store := deps.Store.WithContext(budget.enter(turnStageModeration)).WithPrimaryReads()
+	includeMeta := includeRequested(r, "meta")
+	sessionID := chi.URLParam(r, "sessionId")
+	if sessionID == "" {
+		writeJSONError(w, http.StatusBadRequest, ErrCodeValidationFailed, "Missing session ID")
+		return
+	}
+
+	// Parse request body
+	var req SendMessageRequestDTO
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSONError(w, http.StatusBadRequest, ErrCodeInvalidJSON, "Invalid JSON", err.Error())
+		return
+	}
+
+	if req.Message == "" {
+		writeJSONError(w, http.StatusBadRequest, ErrCodeValidationFailed, "Message cannot be empty")
+		return
+	}
+
+	messageLength := utf8.RuneCountInString(req.Message)
+	if messageLength > deps.MaxMessageLength {
+		writeJSONError(w, http.StatusRequestEntityTooLarge, ErrCodeValidationFailed, "Message too long",
+			fmt.Sprintf("message has %d characters, maximum is %d", messageLength, deps.MaxMessageLength))
+		return
+	}
+
+	// Log model specification for future provider/model format implementation
+	// Unknown models are rejected here rather than failing at the provider with a 404
+	if req.Model != "" {
+		if err := ai.ValidateModel(req.Model, deps.ModelAliases); err != nil {
+			writeJSONError(w, http.StatusBadRequest, ErrCodeValidationFailed, "Invalid model", err.Error())
+			return
+		}
+		utils.Infof("Model specified: %s (using default provider for now)", req.Model)
+		// TODO: Integrate ai.CreateProvider(req.Model, config) when multiple providers needed
+	}
+
+	// Validate chat session exists and is active
+	storeStart := time.Now()
+	session, err := store.GetChatSession(sessionID)
+	timings.addStore(storeStart)
+	if err != nil {
+		writeJSONError(w, http.StatusNotFound, ErrCodeNotFound, ErrMsgSessionNotFound)
+		return
+	}
+
+	// A resend of an already received client message returns the stored exchange instead of duplicating it
+	var userMessage *data.ChatMessage
+	clientMessageID := normalizeClientMessageID(req.ClientMessageID)
+	if clientMessageID != "" {
+		storeStart = time.Now()
+		existing, err := store.GetChatMessageByClientID(sessionID, clientMessageID)
+		timings.addStore(storeStart)
+		if err == nil {
+			if existing.Content != req.Message {
+				writeJSONError(w, http.StatusUnprocessableEntity, ErrCodeConflict, "client_message_id was already used for a different message")
+				return
+			}
+			storeStart = time.Now()
+			aiReply := findAIReply(store, sessionID, existing.ID)
+			timings.addStore(storeStart)
+			if aiReply != nil {
+				aiMessageDTO := toChatMessageDTO(aiReply, includeMeta)
+				var progress *InterviewProgressDTO
+				if messages, err := store.GetChatMessages(sessionID); err == nil {
+					progress = deps.sessionProgress(store, session, countUserMessages(messages), len(messages))
+				}
+				writeJSON(w, http.StatusOK, SendMessageResponseDTO{
+					Message:       toChatMessageDTO(existing, includeMeta),
+					AIResponse:    &aiMessageDTO,
+					SessionStatus: session.Status,
+					Timings:       timings.finish(routeLabel(r)),
+					Progress:      progress,
+					Warnings:      exchangeWarnings(existing, aiReply),
+				})
+				return
+			}
+			// The earlier attempt was stored but never answered, so continue from it
+			userMessage = existing
+		}
+	}
+
+	if session.Status != "active" {
+		writeJSONError(w, http.StatusConflict, ErrCodeConflict, "Chat session is not active")
+		return
+	}
+
+	// Cheap checks refuse bot traffic before it costs an AI call
+	if userMessage == nil && deps.refuseAbusiveMessage(w, r, store, session, req.Message) {
+		return
+	}
+
+	// Create AI client from request headers (BYOK pattern), preferring the session's pinned provider
+	// Attempts are counted outside the budget, so a stage running out can't lose the count
+	aiClient, fallback := deps.turnAIClient(r, session)
+	attemptStore := deps.Store.WithContext(r.Context()).WithPrimaryReads()
+	deps.limitAIAttempts(aiClient, attemptStore, sessionID)
+	if fallback != nil {
+		deps.limitAIAttempts(fallback, attemptStore, sessionID)
+	}
+
+	store = deps.Store.WithContext(budget.enter(turnStagePersistence)).WithPrimaryReads()
+	if userMessage == nil {
+		// Create user message
+		userMessage = &data.ChatMessage{
+			ID:              data.GenerateID(),
+			SessionID:       sessionID,
+			ClientMessageID: clientMessageID,
+			Type:            "user",
+			Content:         req.Message,
+			Timestamp:       deps.now(),
+		}
+
+		// Long messages are stored in full but summarized for the AI conversation context
+		if messageLength > deps.MessageSummaryThreshold {
+			summary, err := aiClient.SummarizeForContextDetailed(interviewTypeContext(budget.context(), store, session), req.Message, session.SessionLanguage)
+			if err != nil {
+				utils.Errorf("Failed to summarize long message: %v", err)
+				if writeTurnBudgetExhausted(w, r, budget, timings) || deps.writeAIBudgetExhausted(w, store, session, err) || writeAIOverloaded(w, err) {
+					return
+				}
+				writeJSONError(w, http.StatusInternalServerError, ErrCodeAIUnavailable, "Failed to summarize message", err.Error())
+				return
+			}
+			recordSessionCost(store, sessionID, summary.EstimatedCostUSD)
+			userMessage.Metadata = data.StringMap{
+				data.MessageMetaSummarized:     "true",
+				data.MessageMetaContextSummary: summary.Content,
+			}
+		}
+
+		// The last slot under the message cap is kept for the closing reply
+		storeStart = time.Now()
+		err = store.AddChatMessageWithLimit(sessionID, userMessage, deps.MaxMessagesPerSession-1)
+		timings.addStore(storeStart)
+		if errors.Is(err, data.ErrMessageLimitReached) {
+			deps.completeSession(store, session, "its message limit")
+			writeJSONError(w, http.StatusConflict, ErrCodeConflict, ErrMsgMessageLimit)
+			return
+		}
+		if writeTurnBudgetExhausted(w, r, budget, timings) {
+			return
+		}
+		if err != nil {
+			// A concurrent resend with the same client_message_id was stored first
+			writeStoreError(w, err, "Failed to save user message", "client_message_id was already used in this session")
+			return
+		}
+	}
+
+	// Get conversation history for AI context (excluding the current message)
+	storeStart = time.Now()
+	messages, err := store.GetChatMessages(sessionID)
+	timings.addStore(storeStart)
+	if err != nil {
+		if !writeTurnBudgetExhausted(w, r, budget, timings) {
+			writeJSONError(w, http.StatusInternalServerError, ErrCodeInternal, "Failed to get chat history")
+		}
+		return
+	}
+
+	// Check if interview should end BEFORE generating AI response
+	userMessageCount := countUserMessages(messages)
+	// Conversational interviews have no planned questions and end on the message thresholds alone
+	plannedQuestions := []string{}
+	conversational, adaptive := false, false
+	interviewType := ""
+	storeStart = time.Now()
+	interview, err := store.GetInterview(session.InterviewID)
+	if err == nil {
+		plannedQuestions = interview.PlannedQuestionsFor(session)
+		conversational = interview.IsConversational()
+		adaptive = interview.IsAdaptive()
+		interviewType = interview.InterviewType
+	}
+	timings.addStore(storeStart)
+	// Every provider call below is counted under the interview's type, and shares the generation stage's budget
+	ctx := ai.WithInterviewType(budget.enter(turnStageGeneration), interviewType)
+	store = deps.Store.WithContext(ctx).WithPrimaryReads()
+	shouldEndInterview := deps.endsInterview(userMessageCount, len(messages),
+		plannedQuestionsAsked(plannedQuestions, session.AskedQuestions), len(plannedQuestions), session.ReopenCount)
+
+	// Build structured conversation history excluding the current user message
+	conversationHistory := buildConversationHistory(messages, userMessage.ID)
+
+	// Long conversations send a running summary in place of their oldest turns
+	conversationHistory = deps.compactHistory(ctx, aiClient, session, conversationHistory)
+
+	// Adaptive interviews judge the answer and pitch the next question harder or easier
+	if adaptive && !shouldEndInterview {
+		if note := adaptDifficulty(ctx, aiClient, store, session, messages, userMessage); note != nil {
+			conversationHistory = append(conversationHistory, *note)
+		}
+	}
+
+	// Generate AI response - use closing context if interview should end
+	// A failing pinned provider hands the turn to the request's default, which the session is re-pinned to
+	var reply *ai.ChatResponse
+	aiClient, err = runTurn(ctx, session, aiClient, fallback, func(aiClient *ai.AIClient) (err error) {
+		reply, err = aiClient.GenerateInterviewTurn(ctx, ai.InterviewTurnRequest{
+			SessionID:     sessionID,
+			History:       conversationHistory,
+			UserMessage:   userMessage.ContextContent(),
+			InterviewType: interviewType,
+			Language:      session.SessionLanguage,
+			Closing:       shouldEndInterview,
+		})
+		return err
+	})
+	if err != nil {
+		utils.Errorf("Failed to generate AI chat response: %v", err)
+		if writeTurnBudgetExhausted(w, r, budget, timings) || deps.writeAIBudgetExhausted(w, store, session, err) || writeAIOverloaded(w, err) {
+			return
+		}
+		writeJSONError(w, http.StatusInternalServerError, ErrCodeAIUnavailable, "Failed to generate AI response", err.Error())
+		return
+	}
+	timings.addProvider(reply.ResponseTime)
+	store = deps.Store.WithContext(budget.enter(turnStageFinalPersistence)).WithPrimaryReads()
+	recordSessionCost(store, sessionID, reply.EstimatedCostUSD)
+	deps.pinProvider(store, session, aiClient)
+	aiResponse := reply.Content
+
+	// Classify the AI turn: closing when the interview ends, otherwise by its content
+	subtype := data.MessageSubtypeClosing
+	if !shouldEndInterview {
+		subtype = classifyAIReply(aiResponse, plannedQuestions)
+	}
+
+	// Planned questions carry their expected answer time for the candidate
+	metadata := aiReplyMetadata(reply, aiClient.Redactor())
+	if subtype == data.MessageSubtypeQuestion && interview != nil {
+		metadata = withExpectedTime(metadata, interview, aiResponse)
+	}
+
+	// Create AI message
+	aiMessageID := data.GenerateID()
+	aiMessage := &data.ChatMessage{
+		ID:        aiMessageID,
+		SessionID: sessionID,
+		Type:      "ai",
+		Subtype:   subtype,
+		Content:   aiResponse,
+		Metadata:  metadata,
+		Provider:  reply.Provider,
+		Model:     reply.Model,
+		Timestamp: deps.now(),
+	}
+
+	storeStart = time.Now()
+	err = store.AddChatMessageWithLimit(sessionID, aiMessage, deps.MaxMessagesPerSession)
+	if errors.Is(err, data.ErrMessageLimitReached) {
+		// A concurrent message took the last slot
+		deps.completeSession(store, session, "its message limit")
+		writeJSONError(w, http.StatusConflict, ErrCodeConflict, ErrMsgMessageLimit)
+		return
+	}
+	if err != nil {
+		writeStoreError(w, err, "Failed to save AI message", "Message already exists")
+		return
+	}
+	// Acknowledgements and closings are not questions
+	if data.IsQuestionSubtype(subtype) && !conversational {
+		recordAskedQuestion(store, sessionID, aiResponse)
+		// Reload so the database backend reflects the recorded question
+		if updated, err := store.GetChatSession(sessionID); err == nil {
+			session.AskedQuestions = updated.AskedQuestions
+		}
+	}
+	timings.addStore(storeStart)
+
+	// Update session status if interview should end
+	// Auto-ending only completes the session: like an explicit end, its evaluation is created by
+	// POST /chat/{sessionId}/end, which applies the no-answers policy. An auto-end always follows
+	// the candidate reply stored above, so auto-ended sessions are scored by the AI.
+	if shouldEndInterview {
+		session.End("completed", deps.now())
+		storeStart = time.Now()
+		if err := store.UpdateChatSession(session); err != nil {
+			utils.Errorf("Failed to update chat session: %v", err)
+		} else {
+			deps.notifySessionCompleted(store, session)
+		}
+		timings.addStore(storeStart)
+	}
+
+	// Convert to DTO format
+	userMessageDTO := toChatMessageDTO(userMessage, includeMeta)
+	aiMessageDTO := toChatMessageDTO(aiMessage, includeMeta)
+	response := SendMessageResponseDTO{
+		Message:       userMessageDTO,
+		AIResponse:    &aiMessageDTO,
+		SessionStatus: session.Status,
+		Timings:       timings.finish(routeLabel(r)),
+		Progress:      deps.sessionProgress(store, session, userMessageCount, len(messages)+1),
+		Warnings:      exchangeWarnings(userMessage, aiMessage),
+	}
+
+	writeJSON(w, http.StatusOK, response)
+}
+
+// GetChatSessionHandler handles GET /chat/{sessionId}
+// At most MaxMessagesPerSession messages are returned; GET /chat/{sessionId}/messages pages through the rest
+func (deps *HandlerDependencies) GetChatSessionHandler(w http.ResponseWriter, r *http.Request) {
+	sessionID := chi.URLParam(r, "sessionId")
+	if sessionID == "" {
+		writeJSONError(w, http.StatusBadRequest, ErrCodeValidationFailed, "Missing session ID")
+		return
+	}
+	deps.writeChatSession(w, r, deps.Store.WithContext(r.Context()), sessionID)
+}
+
+// writeChatSession writes the session view returned by GET /chat/{sessionId}, read through store
+// Internal messages are left out of the candidate view (see candidateView); the activity and
+// progress still account for them.
+func (deps *HandlerDependencies) writeChatSession(w http.ResponseWriter, r *http.Request, store data.Store, sessionID string) {
+	candidateOnly, ok := deps.candidateView(w, r)
+	if !ok {
+		return
+	}
+	session, err := store.GetChatSession(sessionID)
+	if err != nil {
+		writeJSONError(w, http.StatusNotFound, ErrCodeNotFound, ErrMsgSessionNotFound)
+		return
+	}
+
+	// Get the session's messages, bounded for sessions stored before the message cap
+	result, err := store.GetChatMessagesWithOptions(sessionID, data.ListMessagesOptions{Limit: deps.MaxMessagesPerSession})
+	if err != nil {
+		writeJSONError(w, http.StatusInternalServerError, ErrCodeInternal, "Failed to get chat messages")
+		return
+	}
+	messages := result.Messages
+
+	// Convert to DTO format
+	includeMeta := includeRequested(r, "meta")
+	messageDTOs := make([]ChatMessageDTO, 0, len(messages))
+	for _, msg := range messages {
+		if candidateOnly && !msg.CandidateVisible() {
+			continue
+		}
+		messageDTOs = append(messageDTOs, toChatMessageDTO(msg, includeMeta))
+	}
+	response := ChatInterviewSessionDTO{
+		ID:               session.ID,
+		InterviewID:      session.InterviewID,
+		SessionLanguage:  session.SessionLanguage,
+		Greeting:         greetingMode(session),
+		Messages:         messageDTOs,
+		Status:           session.Status,
+		Provider:         session.Provider,
+		Model:            session.Model,
+		EstimatedCostUSD: session.EstimatedCostUSD,
+		StartedAt:        apitime.New(session.StartedAt),
+		EndedAt:          apitime.NewPtr(session.EndedAt),
+		DurationSeconds:  sessionDurationSeconds(session),
+		CreatedAt:        apitime.New(session.CreatedAt),
+		DifficultyLevel:  session.DifficultyLevel,
+		ReopenCount:      session.ReopenCount,
+	}
+	response.TranscriptPurged, response.TranscriptPurgedAt = transcriptPurge(session)
+	if !candidateOnly {
+		response.AbuseRejections = session.AbuseRejections
+	}
+	if result.Total > len(messages) {
+		response.MessagesTruncated = true
+		response.TotalMessages = result.Total
+	}
+	var lastMessageAt time.Time
+	if len(messages) > 0 {
+		lastMessageAt = messages[len(messages)-1].Timestamp
+	}
+	response.LastActivityAt, response.ExpiresAt = deps.sessionActivity(session, lastMessageAt)
+	response.Progress = deps.sessionProgress(store, session, countUserMessages(messages), result.Total)
+	if includeRequested(r, "asked_questions") {
+		response.AskedQuestions = session.AskedQuestions
+	}
+
+	writeJSON(w, http.StatusOK, response)
+}
+
+// sessionDurationSeconds returns how long an ended session ran in whole seconds, or nil while it is active
+func sessionDurationSeconds(session *data.ChatSession) *int64 {
+	duration, ok := session.Duration()
+	if !ok {
+		return nil
 	}
-	interviewLanguage := interview.InterviewLanguage // Use interview language for evaluation
+	seconds := int64(duration / time.Second)
+	return &seconds
+}
 
-	// Create AI client from request headers (BYOK pattern)
-	aiClient := createClientFromRequest(r)
+// transcriptPurge reports whether the session's old messages were deleted by the transcript
+// retention, and when
+func transcriptPurge(session *data.ChatSession) (bool, *apitime.Time) {
+	return session.TranscriptPurgedAt != nil, apitime.NewPtr(session.TranscriptPurgedAt)
+}
 
-	score, feedback, err := aiClient.EvaluateAnswersWithContext(questions, answers, jobDesc, interviewLanguage)
-	if err != nil {
-		writeJSONError(w, http.StatusInternalServerError, "Failed to generate evaluation")
+// ListChatMessagesHandler handles GET /chat/{sessionId}/messages
+// Pages through a session's messages, oldest first, with the list endpoint limit/offset/page parameters
+func (deps *HandlerDependencies) ListChatMessagesHandler(w http.ResponseWriter, r *http.Request) {
+	sessionID := chi.URLParam(r, "sessionId")
+	if sessionID == "" {
+		writeJSONError(w, http.StatusBadRequest, ErrCodeValidationFailed, "Missing session ID")
 		return
 	}
 
-	// Create evaluation record
-	evaluationID := data.GenerateID()
-	evaluation := &data.Evaluation{
-		ID:          evaluationID,
-		InterviewID: req.InterviewID,
-		Answers:     req.Answers,
-		Score:       score,
-		Feedback:    feedback,
-		CreatedAt:   time.Now(),
-		UpdatedAt:   time.Now(),
+	candidateOnly, ok := deps.candidateView(w, r)
+	if !ok {
+		return
 	}
-
-	err = data.GlobalStore.CreateEvaluation(evaluation)
+	page := deps.parsePagination(r)
+	store := deps.Store.WithContext(r.Context())
+	session, err := store.GetChatSession(sessionID)
+	if err != nil {
+		writeJSONError(w, http.StatusNotFound, ErrCodeNotFound, ErrMsgSessionNotFound)
+		return
+	}
+	result, err := store.GetChatMessagesWithOptions(sessionID, data.ListMessagesOptions{
+		Limit:         page.Limit,
+		Offset:        page.Offset,
+		CandidateOnly: candidateOnly,
+	})
 	if err != nil {
-		writeJSONError(w, http.StatusInternalServerError, "Failed to save evaluation")
+		writeJSONError(w, http.StatusNotFound, ErrCodeNotFound, ErrMsgSessionNotFound)
 		return
 	}
 
-	resp := EvaluationResponseDTO{
-		ID:          evaluationID,
-		InterviewID: req.InterviewID,
-		Answers:     req.Answers,
-		Score:       score,
-		Feedback:    feedback,
-		CreatedAt:   evaluation.CreatedAt,
+	includeMeta := includeRequested(r, "meta")
+	messageDTOs := make([]ChatMessageDTO, len(result.Messages))
+	for i, msg := range result.Messages {
+		messageDTOs[i] = toChatMessageDTO(msg, includeMeta)
 	}
-	writeJSON(w, http.StatusOK, resp)
+
+	totalPages := (result.Total + page.Limit - 1) / page.Limit
+	if totalPages == 0 {
+		totalPages = 1
+	}
+	response := ListChatMessagesResponseDTO{
+		Messages:   messageDTOs,
+		Total:      result.Total,
+		Limit:      page.Limit,
+		Offset:     page.Offset,
+		Page:       page.Page,
+		TotalPages: totalPages,
+		Warnings:   page.Warnings,
+	}
+	response.TranscriptPurged, response.TranscriptPurgedAt = transcriptPurge(session)
+	writeJSON(w, http.StatusOK, response)
 }
 
-// GetEvaluationHandler handles GET /evaluation/{id}
-func GetEvaluationHandler(w http.ResponseWriter, r *http.Request) {
-	id := chi.URLParam(r, "id")
-	if id == "" {
-		writeJSONError(w, ErrCodeBadRequest, ErrMsgMissingEvaluationID)
+// completeSession completes an active session that reached a cap; reason names the cap for the log
+func (deps *HandlerDependencies) completeSession(store data.Store, session *data.ChatSession, reason string) {
+	if session.Status != "active" {
 		return
 	}
-	// Get evaluation from database
-	evaluation, err := data.GlobalStore.GetEvaluation(id)
-	if err != nil {
-		writeJSONError(w, http.StatusNotFound, "Evaluation not found")
+	session.End("completed", deps.now())
+	if err := store.UpdateChatSession(session); err != nil {
+		utils.Errorf("Failed to complete session %s at %s: %v", session.ID, reason, err)
 		return
 	}
+	deps.notifySessionCompleted(store, session)
+}
 
-	resp := EvaluationResponseDTO{
-		ID:          evaluation.ID,
-		InterviewID: evaluation.InterviewID,
-		Answers:     evaluation.Answers,
-		Score:       evaluation.Score,
-		Feedback:    evaluation.Feedback,
-		CreatedAt:   evaluation.CreatedAt,
+// limitAIAttempts counts every provider call aiClient makes toward the session's AI attempt budget
+// Once MaxAIAttemptsPerSession calls were made, further calls fail with ai.ErrAttemptBudgetExhausted.
+// A failure to count is logged and the call allowed, so a store outage doesn't stop interviews.
+func (deps *HandlerDependencies) limitAIAttempts(aiClient *ai.AIClient, store data.Store, sessionID string) {
+	aiClient.SetAttemptHook(func() error {
+		recorded, err := store.RecordChatSessionAIAttempt(sessionID, deps.MaxAIAttemptsPerSession)
+		if err != nil {
+			utils.Errorf("Failed to count an AI attempt for session %s: %v", sessionID, err)
+			return nil
+		}
+		if !recorded {
+			return ai.ErrAttemptBudgetExhausted
+		}
+		return nil
+	})
+}
+
+// writeAIBudgetExhausted completes the session and writes 429 when err is a call refused by its AI
+// attempt budget; for any other error it writes nothing and returns false
+func (deps *HandlerDependencies) writeAIBudgetExhausted(w http.ResponseWriter, store data.Store, session *data.ChatSession, err error) bool {
+	if !errors.Is(err, ai.ErrAttemptBudgetExhausted) {
+		return false
 	}
-	writeJSON(w, http.StatusOK, resp)
+	deps.completeSession(store, session, "its AI attempt budget")
+	writeJSONError(w, http.StatusTooManyRequests, ErrCodeAIBudgetExhausted, ErrMsgAIBudgetExhausted)
+	return true
 }
 
-// StartChatSessionHandler handles POST /interviews/{id}/chat/start
-func (deps *HandlerDependencies) StartChatSessionHandler(w http.ResponseWriter, r *http.Request) {
-	interviewID := chi.URLParam(r, "id")
-	if interviewID == "" {
-		writeJSONError(w, http.StatusBadRequest, "Missing interview ID")
-		return
+// aiOverloadedRetryAfter is the Retry-After sent with 503 when the AI concurrency cap is reached
+const aiOverloadedRetryAfter = 5 * time.Second
+
+// writeAIOverloaded writes 503 with Retry-After when err is a call refused by the AI concurrency cap;
+// for any other error it writes nothing and returns false
+func writeAIOverloaded(w http.ResponseWriter, err error) bool {
+	if !errors.Is(err, ai.ErrOverloaded) {
+		return false
 	}
+	w.Header().Set("Retry-After", strconv.Itoa(int(aiOverloadedRetryAfter.Seconds())))
+	writeJSONError(w, http.StatusServiceUnavailable, ErrCodeAIOverloaded, ErrMsgAIOverloaded)
+	return true
+}
 
-	// Validate interview exists and get it for language inheritance
-	interview, err := data.GlobalStore.GetInterview(interviewID)
+// notifySessionCompleted queues the session.completed webhook
+func (deps *HandlerDependencies) notifySessionCompleted(store data.Store, session *data.ChatSession) {
+	interview, err := store.GetInterview(session.InterviewID)
 	if err != nil {
-		writeJSONError(w, http.StatusNotFound, "Interview not found")
-		return
-	}
+		utils.Errorf("Failed to load interview %s for session.completed webhook: %v", session.InterviewID, err)
+		interview = nil
+	}
+	deps.Webhooks.Dispatch(interview, WebhookPayloadDTO{
+		Event:       WebhookEventSessionCompleted,
+		InterviewID: session.InterviewID,
+		SessionID:   session.ID,
+		Timestamp:   apitime.New(deps.now()),
+	})
+}
 
-	// Parse optional request body for language preference
-	var req StartChatSessionRequestDTO
-	if r.ContentLength > 0 {
-		// Ignore decode errors for optional body - use interview language as fallback
-		_ = json.NewDecoder(r.Body).Decode(&req)
-	}
-	// Determine language: use request language if provided, otherwise inherit from interview
-	sessionLanguage := interview.InterviewLanguage // Default to interview language
-	if req.SessionLanguage != "" {
-		sessionLanguage = data.GetValidatedLanguage(req.SessionLanguage)
-	}
+// notifyEvaluationCreated queues the evaluation.created webhook; sessionID is empty for submitted answers
+func (deps *HandlerDependencies) notifyEvaluationCreated(interview *data.Interview, evaluation *data.Evaluation, sessionID string) {
+	dto := toEvaluationResponseDTO(evaluation)
+	deps.Webhooks.Dispatch(interview, WebhookPayloadDTO{
+		Event:       WebhookEventEvaluationCreated,
+		InterviewID: evaluation.InterviewID,
+		SessionID:   sessionID,
+		Evaluation:  &dto,
+		Timestamp:   apitime.New(deps.now()),
+	})
+}
 
-	// Create chat session
-	sessionID := data.GenerateID()
-	session := &data.ChatSession{
-		ID:              sessionID,
-		InterviewID:     interviewID,
-		SessionLanguage: sessionLanguage,
-		Status:          "active",
-		StartedAt:       time.Now(),
-		CreatedAt:       time.Now(),
-		UpdatedAt:       time.Now(),
+// messageLimitNotes returns the evaluation notes for a session that reached the message cap
+// loaded is the number of messages evaluated and total the number stored
+func (deps *HandlerDependencies) messageLimitNotes(loaded, total int) []string {
+	var notes []string
+	if total >= deps.MaxMessagesPerSession {
+		notes = append(notes, fmt.Sprintf("The session was ended automatically after reaching the limit of %d messages", deps.MaxMessagesPerSession))
 	}
-	err = data.GlobalStore.CreateChatSession(session)
-	if err != nil {
-		writeJSONError(w, http.StatusInternalServerError, "Failed to create chat session")
-		return
+	if total > loaded {
+		notes = append(notes, fmt.Sprintf("Transcript truncated: only the first %d of %d messages were evaluated", loaded, total))
 	}
+	return notes
+}
 
-	// Create AI client from request headers (BYOK pattern)
-	aiClient := createClientFromRequest(r)
+// noAnswersFeedback is the feedback recorded for sessions ended before the candidate replied
+const noAnswersFeedback = "No candidate responses were recorded"
 
-	// Generate initial AI greeting message
-	aiResponse, err := aiClient.GenerateChatResponseWithLanguage(sessionID, []map[string]string{}, "", sessionLanguage)
-	if err != nil {
-		utils.Errorf("Failed to generate AI greeting: %v", err)
-		writeJSONError(w, http.StatusInternalServerError, "Failed to generate AI response", err.Error())
+// UpdateChatSessionHandler handles PATCH /chat/{sessionId}
+// Currently supports switching the session language while the interview is active
+func (deps *HandlerDependencies) UpdateChatSessionHandler(w http.ResponseWriter, r *http.Request) {
+	sessionID := chi.URLParam(r, "sessionId")
+	if sessionID == "" {
+		writeJSONError(w, http.StatusBadRequest, ErrCodeValidationFailed, "Missing session ID")
 		return
 	}
 
-	// Create initial AI message
-	messageID := data.GenerateID()
-	aiMessage := &data.ChatMessage{
-		ID:        messageID,
-		SessionID: sessionID,
-		Type:      "ai",
-		Content:   aiResponse, Timestamp: time.Now(), CreatedAt: time.Now(),
+	var req UpdateChatSessionRequestDTO
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSONError(w, http.StatusBadRequest, ErrCodeInvalidJSON, "Invalid JSON", err.Error())
+		return
+	}
+	if !data.ValidateLanguage(req.SessionLanguage) {
+		writeJSONError(w, http.StatusBadRequest, ErrCodeValidationFailed, ErrMsgInvalidLanguage)
+		return
 	}
 
-	err = data.GlobalStore.AddChatMessage(sessionID, aiMessage)
+	// The response reflects this update, which a replica may not have received yet
+	store := deps.Store.WithContext(r.Context()).WithPrimaryReads()
+	session, err := store.GetChatSession(sessionID)
 	if err != nil {
-		writeJSONError(w, http.StatusInternalServerError, "Failed to save AI message")
+		writeJSONError(w, http.StatusNotFound, ErrCodeNotFound, ErrMsgSessionNotFound)
 		return
 	}
 
-	// Convert to DTO format
-	messages, _ := data.GlobalStore.GetChatMessages(sessionID)
-	messageDTOs := make([]ChatMessageDTO, len(messages))
-	for i, msg := range messages {
-		messageDTOs[i] = ChatMessageDTO{
-			ID:        msg.ID,
-			Type:      msg.Type,
-			Content:   msg.Content,
-			Timestamp: msg.Timestamp,
+	if req.SessionLanguage != session.SessionLanguage {
+		if session.Status != "active" {
+			writeJSONError(w, http.StatusConflict, ErrCodeConflict, "Chat session is not active")
+			return
 		}
-	}
 
-	response := ChatInterviewSessionDTO{
-		ID:              session.ID,
-		InterviewID:     session.InterviewID,
-		SessionLanguage: session.SessionLanguage,
-		Messages:        messageDTOs,
-		Status:          session.Status,
-		StartedAt:       session.StartedAt,
-		CreatedAt:       session.CreatedAt,
+		// Record the switch in the transcript so the evaluation can take it into account
+		// Like a candidate message, the note leaves the last slot under the cap for the closing reply
+		note := &data.ChatMessage{
+			ID:        data.GenerateID(),
+			SessionID: sessionID,
+			Type:      "system",
+			Content:   fmt.Sprintf("Session language changed from %s to %s", session.SessionLanguage, req.SessionLanguage),
+			Timestamp: deps.now(),
+		}
+		err := store.AddChatMessageWithLimit(sessionID, note, deps.MaxMessagesPerSession-1)
+		if errors.Is(err, data.ErrMessageLimitReached) {
+			deps.completeSession(store, session, "its message limit")
+			writeJSONError(w, http.StatusConflict, ErrCodeConflict, ErrMsgMessageLimit)
+			return
+		}
+		if err != nil {
+			writeStoreError(w, err, "Failed to save language change", "Message already exists")
+			return
+		}
+
+		session.SessionLanguage = req.SessionLanguage
+		if err := store.UpdateChatSession(session); err != nil {
+			writeJSONError(w, http.StatusInternalServerError, ErrCodeInternal, "Failed to update session")
+			return
+		}
 	}
 
-	writeJSON(w, http.StatusCreated, response)
+	deps.writeChatSession(w, r, store, sessionID)
 }
 
-// SendMessageHandler handles POST /chat/{sessionId}/message
-func (deps *HandlerDependencies) SendMessageHandler(w http.ResponseWriter, r *http.Request) {
+// EndChatSessionHandler handles POST /chat/{sessionId}/end
+func (deps *HandlerDependencies) EndChatSessionHandler(w http.ResponseWriter, r *http.Request) {
+	// The evaluation needs the session's latest messages and status, which a replica may lag on
+	store := deps.Store.WithContext(r.Context()).WithPrimaryReads()
+
 	sessionID := chi.URLParam(r, "sessionId")
 	if sessionID == "" {
-		writeJSONError(w, http.StatusBadRequest, "Missing session ID")
+		writeJSONError(w, http.StatusBadRequest, ErrCodeValidationFailed, "Missing session ID")
 		return
 	}
-
-	// Parse request body
-	var req SendMessageRequestDTO
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		writeJSONError(w, http.StatusBadRequest, "Invalid JSON", err.Error())
+	// Optional ?detail_level= for the evaluation, validated before the session is closed
+	detailLevel := r.URL.Query().Get("detail_level")
+	if detailLevel != "" && !ai.ValidateDetailLevel(detailLevel) {
+		writeJSONError(w, http.StatusBadRequest, ErrCodeValidationFailed, ErrMsgInvalidDetailLevel)
+		return
+	}
+	feedbackLanguages, ok := deps.feedbackLanguages(feedbackLanguagesQuery(r))
+	if !ok {
+		writeJSONError(w, http.StatusBadRequest, ErrCodeValidationFailed, ErrMsgInvalidLanguage)
 		return
 	}
 
-	if req.Message == "" {
-		writeJSONError(w, http.StatusBadRequest, "Message cannot be empty")
+	// Get chat session
+	session, err := store.GetChatSession(sessionID)
+	if err != nil {
+		writeJSONError(w, http.StatusNotFound, ErrCodeNotFound, ErrMsgSessionNotFound)
 		return
 	}
 
-	// Log model specification for future provider/model format implementation
-	if req.Model != "" {
-		utils.Infof("Model specified: %s (using default provider for now)", req.Model)
-		// TODO: Integrate ai.CreateProvider(req.Model, config) when multiple providers needed
+	// A session is evaluated once, checked before the session is closed: a completed session that was
+	// already evaluated can't be ended again, while one completed automatically (or whose evaluation
+	// failed) has no evaluation yet and is evaluated here. Other sessions of the interview, e.g. the
+	// rounds of a multi-session interview, each get their own evaluation. A reopened session replaces
+	// the evaluation it had when reopened.
+	if session.Status == "completed" {
+		if existing, err := store.GetLatestEvaluationByInterview(session.InterviewID); err == nil && !existing.CreatedAt.Before(session.StartedAt) {
+			writeEvaluationConflict(w, "Chat session has already been ended", existing.ID)
+			return
+		}
 	}
 
-	// Validate chat session exists and is active
-	session, err := data.GlobalStore.GetChatSession(sessionID)
+	// Mark session as completed
+	session.End("completed", deps.now())
+
+	err = store.UpdateChatSession(session)
 	if err != nil {
-		writeJSONError(w, http.StatusNotFound, "Chat session not found")
+		writeJSONError(w, http.StatusInternalServerError, ErrCodeInternal, "Failed to update session")
 		return
 	}
+	deps.notifySessionCompleted(store, session)
 
-	if session.Status != "active" {
-		writeJSONError(w, http.StatusBadRequest, "Chat session is not active")
+	evaluation, ok := deps.evaluateChatSession(w, r, store, session, session.ReopenedEvaluationID, detailLevel, feedbackLanguages)
+	if !ok {
 		return
 	}
+	writeJSON(w, http.StatusOK, toEvaluationResponseDTO(evaluation))
+}
 
-	// Create user message
-	userMessageID := data.GenerateID()
-	userMessage := &data.ChatMessage{
-		ID:        userMessageID,
-		SessionID: sessionID,
-		Type:      "user", Content: req.Message,
-		Timestamp: time.Now(),
-		CreatedAt: time.Now(),
+// WrapUpChatSessionHandler handles POST /chat/{sessionId}/wrap-up
+// Ends an active session early with an AI sign-off: the closing message is stored as the final
+// message, the session is completed and then evaluated like /end
+func (deps *HandlerDependencies) WrapUpChatSessionHandler(w http.ResponseWriter, r *http.Request) {
+	// Like /end, the closing message and evaluation need the session's latest state
+	store := deps.Store.WithContext(r.Context()).WithPrimaryReads()
+
+	sessionID := chi.URLParam(r, "sessionId")
+	if sessionID == "" {
+		writeJSONError(w, http.StatusBadRequest, ErrCodeValidationFailed, "Missing session ID")
+		return
 	}
-	err = data.GlobalStore.AddChatMessage(sessionID, userMessage)
-	if err != nil {
-		writeJSONError(w, http.StatusInternalServerError, "Failed to save user message")
+	detailLevel := r.URL.Query().Get("detail_level")
+	if detailLevel != "" && !ai.ValidateDetailLevel(detailLevel) {
+		writeJSONError(w, http.StatusBadRequest, ErrCodeValidationFailed, ErrMsgInvalidDetailLevel)
+		return
+	}
+	feedbackLanguages, ok := deps.feedbackLanguages(feedbackLanguagesQuery(r))
+	if !ok {
+		writeJSONError(w, http.StatusBadRequest, ErrCodeValidationFailed, ErrMsgInvalidLanguage)
 		return
 	}
 
-	// Get conversation history for AI context (excluding the current message)
-	messages, err := data.GlobalStore.GetChatMessages(sessionID)
+	session, err := store.GetChatSession(sessionID)
 	if err != nil {
-		writeJSONError(w, http.StatusInternalServerError, "Failed to get chat history")
+		writeJSONError(w, http.StatusNotFound, ErrCodeNotFound, ErrMsgSessionNotFound)
 		return
 	}
-
-	// Create AI client from request headers (BYOK pattern)
-	aiClient := createClientFromRequest(r)
-
-	// Check if interview should end BEFORE generating AI response
-	userMessageCount := 0
-	for _, msg := range messages {
-		if msg.Type == "user" {
-			userMessageCount++
-		}
+	if session.Status != "active" {
+		writeJSONError(w, http.StatusConflict, ErrCodeConflict, "Chat session is not active")
+		return
 	}
 
-	shouldEndInterview := aiClient.ShouldEndInterview(userMessageCount)
-
-	// Build structured conversation history excluding the current user message
-	conversationHistory := make([]map[string]string, 0)
-	for _, msg := range messages {
-		// Skip the current user message we just added
-		if msg.ID != userMessage.ID {
-			conversationHistory = append(conversationHistory, map[string]string{
-				"role":    msg.Type,
-				"content": msg.Content,
-			})
-		}
+	messages, err := store.GetChatMessages(sessionID)
+	if err != nil {
+		writeJSONError(w, http.StatusInternalServerError, ErrCodeInternal, "Failed to get chat history")
+		return
 	}
 
-	// Generate AI response - use closing context if interview should end
-	var aiResponse string
-	if shouldEndInterview {
-		aiResponse, err = aiClient.GenerateClosingMessageWithLanguage(sessionID, conversationHistory, req.Message, session.SessionLanguage)
-	} else {
-		aiResponse, err = aiClient.GenerateChatResponseWithLanguage(sessionID, conversationHistory, req.Message, session.SessionLanguage)
-	}
+	// The sign-off is a closing interviewer turn, so it carries its provider, model and cost
+	// like every other AI turn, and comes from the session's pinned provider
+	aiClient, fallback := deps.turnAIClient(r, session)
+	deps.limitAIAttempts(aiClient, store, sessionID)
+	if fallback != nil {
+		deps.limitAIAttempts(fallback, store, sessionID)
+	}
+	turn := ai.InterviewTurnRequest{SessionID: sessionID, Language: session.SessionLanguage, Closing: true}
+	if interview, err := store.GetInterview(session.InterviewID); err == nil {
+		turn.InterviewType = interview.InterviewType
+	}
+	ctx := ai.WithInterviewType(r.Context(), turn.InterviewType)
+	turn.History = deps.compactHistory(ctx, aiClient, session, buildConversationHistory(messages, ""))
+	var reply *ai.ChatResponse
+	aiClient, err = runTurn(ctx, session, aiClient, fallback, func(aiClient *ai.AIClient) (err error) {
+		reply, err = aiClient.GenerateInterviewTurn(ctx, turn)
+		return err
+	})
 	if err != nil {
-		utils.Errorf("Failed to generate AI chat response: %v", err)
-		writeJSONError(w, http.StatusInternalServerError, "Failed to generate AI response", err.Error())
+		utils.Errorf("Failed to generate AI closing message: %v", err)
+		if deps.writeAIBudgetExhausted(w, store, session, err) || writeAIOverloaded(w, err) {
+			return
+		}
+		writeJSONError(w, http.StatusInternalServerError, ErrCodeAIUnavailable, "Failed to generate AI response", err.Error())
 		return
 	}
+	recordSessionCost(store, sessionID, reply.EstimatedCostUSD)
+	deps.pinProvider(store, session, aiClient)
 
-	// Create AI message
-	aiMessageID := data.GenerateID()
-	aiMessage := &data.ChatMessage{
-		ID:        aiMessageID,
+	closing := &data.ChatMessage{
+		ID:        data.GenerateID(),
 		SessionID: sessionID,
 		Type:      "ai",
-		Content:   aiResponse, Timestamp: time.Now(),
-		CreatedAt: time.Now()}
-
-	err = data.GlobalStore.AddChatMessage(sessionID, aiMessage)
+		Subtype:   data.MessageSubtypeClosing,
+		Content:   reply.Content,
+		Metadata:  aiReplyMetadata(reply, aiClient.Redactor()),
+		Provider:  reply.Provider,
+		Model:     reply.Model,
+		Timestamp: deps.now(),
+	}
+	err = store.AddChatMessageWithLimit(sessionID, closing, deps.MaxMessagesPerSession)
+	if errors.Is(err, data.ErrMessageLimitReached) {
+		// No room for the sign-off; the session is completed and can still be evaluated with /end
+		deps.completeSession(store, session, "its message limit")
+		writeJSONError(w, http.StatusConflict, ErrCodeConflict, ErrMsgMessageLimit)
+		return
+	}
 	if err != nil {
-		writeJSONError(w, http.StatusInternalServerError, "Failed to save AI message")
+		writeStoreError(w, err, "Failed to save AI message", "Message already exists")
 		return
 	}
 
-	// Update session status if interview should end
-	if shouldEndInterview {
-		session.Status = "completed"
-		session.UpdatedAt = time.Now()
-		endedAt := time.Now()
-		session.EndedAt = &endedAt
-		if err := data.GlobalStore.UpdateChatSession(session); err != nil {
-			utils.Errorf("Failed to update chat session: %v", err)
-		}
+	session.End("completed", deps.now())
+	if err := store.UpdateChatSession(session); err != nil {
+		writeJSONError(w, http.StatusInternalServerError, ErrCodeInternal, "Failed to update session")
+		return
 	}
+	deps.notifySessionCompleted(store, session)
 
-	// Convert to DTO format
-	userMessageDTO := ChatMessageDTO{
-		ID:        userMessage.ID,
-		Type:      userMessage.Type,
-		Content:   userMessage.Content,
-		Timestamp: userMessage.Timestamp,
+	evaluation, ok := deps.evaluateChatSession(w, r, store, session, session.ReopenedEvaluationID, detailLevel, feedbackLanguages)
+	if !ok {
+		return
 	}
+	writeJSON(w, http.StatusOK, WrapUpResponseDTO{
+		ClosingMessage: toChatMessageDTO(closing, includeRequested(r, "meta")),
+		Evaluation:     toEvaluationResponseDTO(evaluation),
+	})
+}
 
-	aiMessageDTO := ChatMessageDTO{
-		ID:        aiMessage.ID,
-		Type:      aiMessage.Type,
-		Content:   aiMessage.Content,
-		Timestamp: aiMessage.Timestamp,
-	}
-	response := SendMessageResponseDTO{
-		Message:       userMessageDTO,
-		AIResponse:    &aiMessageDTO,
-		SessionStatus: session.Status,
+// evaluateChatSession evaluates a completed session's transcript and stores the evaluation
+// Shared by ending and wrapping up a session; on failure the error response is written and ok is false
+func (deps *HandlerDependencies) evaluateChatSession(w http.ResponseWriter, r *http.Request, store data.Store, session *data.ChatSession, supersedesID, detailLevel string, feedbackLanguages []string) (*data.Evaluation, bool) {
+	// Create AI client from request headers (BYOK pattern)
+	aiClient := deps.newAIClient(r)
+	deps.limitAIAttempts(aiClient, store, session.ID)
+	evaluation, err := deps.evaluateSession(r.Context(), aiClient, store, session, supersedesID, detailLevel, feedbackLanguages)
+	if err != nil {
+		utils.Errorf("Failed to evaluate session %s: %v", session.ID, err)
+		if errors.Is(err, ai.ErrAttemptBudgetExhausted) {
+			writeJSONError(w, http.StatusTooManyRequests, ErrCodeAIBudgetExhausted, ErrMsgAIBudgetExhausted)
+			return nil, false
+		}
+		if writeAIOverloaded(w, err) {
+			return nil, false
+		}
+		var failure *sessionEvaluationError
+		if !errors.As(err, &failure) {
+			failure = &sessionEvaluationError{code: ErrCodeInternal, message: "Failed to evaluate session", err: err}
+		}
+		status := http.StatusInternalServerError
+		if failure.code == ErrCodeConflict {
+			status = http.StatusConflict
+		}
+		writeJSONError(w, status, failure.code, failure.message)
+		return nil, false
 	}
+	return evaluation, true
+}
 
-	writeJSON(w, http.StatusOK, response)
+// sessionEvaluationError is a failed step of evaluateSession with the API error it maps to
+type sessionEvaluationError struct {
+	code    ErrorCode
+	message string
+	err     error
 }
 
-// GetChatSessionHandler handles GET /chat/{sessionId}
-func GetChatSessionHandler(w http.ResponseWriter, r *http.Request) {
-	sessionID := chi.URLParam(r, "sessionId")
-	if sessionID == "" {
-		writeJSONError(w, http.StatusBadRequest, "Missing session ID")
-		return
-	}
-	// Get chat session
-	session, err := data.GlobalStore.GetChatSession(sessionID)
+func (e *sessionEvaluationError) Error() string { return e.message + ": " + e.err.Error() }
+
+func (e *sessionEvaluationError) Unwrap() error { return e.err }
+
+// sessionEvaluationInput is what evaluating a session sends to the AI provider
+type sessionEvaluationInput struct {
+	interview *data.Interview
+	questions []string // questions[i] is the question answers[i] responds to
+	answers   []string
+	evalCtx   ai.EvaluationContext
+}
+
+// loadSessionEvaluationInput reads a session's transcript and interview and pairs each candidate
+// answer with the question it responds to
+func (deps *HandlerDependencies) loadSessionEvaluationInput(store data.Store, session *data.ChatSession, detailLevel string) (*sessionEvaluationInput, error) {
+	// Get the messages for evaluation, bounded for sessions stored before the message cap
+	result, err := store.GetChatMessagesWithOptions(session.ID, data.ListMessagesOptions{Limit: deps.MaxMessagesPerSession})
 	if err != nil {
-		writeJSONError(w, http.StatusNotFound, "Chat session not found")
-		return
+		return nil, &sessionEvaluationError{code: ErrCodeInternal, message: "Failed to get chat messages", err: err}
 	}
+	messages := result.Messages
 
-	// Get all messages for the session
-	messages, err := data.GlobalStore.GetChatMessages(sessionID)
+	// Get interview details for context
+	interview, err := store.GetInterview(session.InterviewID)
 	if err != nil {
-		writeJSONError(w, http.StatusInternalServerError, "Failed to get chat messages")
-		return
+		return nil, &sessionEvaluationError{code: ErrCodeInternal, message: "Failed to get interview details", err: err}
 	}
 
-	// Convert to DTO format
-	messageDTOs := make([]ChatMessageDTO, len(messages))
-	for i, msg := range messages {
-		messageDTOs[i] = ChatMessageDTO{
-			ID:        msg.ID,
-			Type:      msg.Type,
-			Content:   msg.Content,
-			Timestamp: msg.Timestamp,
+	// Convert chat messages to evaluation format, pairing each answer with the question it responds to
+	questions, answers := pairAnswersWithQuestions(messages, session.AskedQuestions)
+
+	// Use session language for evaluation
+	evalCtx := buildEvaluationContext(interview, session.SessionLanguage)
+	evalCtx.ConversationSummary = session.ConversationSummary
+	evalCtx.DetailLevel = detailLevel
+	evalCtx.MaxFeedbackWords = deps.MaxFeedbackWords
+	evalCtx.MinAnswerWords = deps.MinAnswerWords
+	for _, msg := range messages {
+		if msg.Type == "system" {
+			evalCtx.SessionNotes = append(evalCtx.SessionNotes, msg.Content)
 		}
 	}
-	response := ChatInterviewSessionDTO{
-		ID:              session.ID,
-		InterviewID:     session.InterviewID,
-		SessionLanguage: session.SessionLanguage,
-		Messages:        messageDTOs,
-		Status:          session.Status,
-		StartedAt:       session.StartedAt,
-		CreatedAt:       session.CreatedAt,
+	evalCtx.SessionNotes = append(evalCtx.SessionNotes, deps.messageLimitNotes(len(messages), result.Total)...)
+	return &sessionEvaluationInput{interview: interview, questions: questions, answers: answers, evalCtx: evalCtx}, nil
+}
+
+// evaluateSession evaluates a completed session's transcript with aiClient and stores the evaluation,
+// with its feedback translated into feedbackLanguages
+// Sessions without candidate answers get a "no_answers" evaluation without calling the provider
+func (deps *HandlerDependencies) evaluateSession(ctx context.Context, aiClient *ai.AIClient, store data.Store, session *data.ChatSession, supersedesID, detailLevel string, feedbackLanguages []string) (*data.Evaluation, error) {
+	input, err := deps.loadSessionEvaluationInput(store, session, detailLevel)
+	if err != nil {
+		return nil, err
+	}
+	interview, questions, userAnswers, evalCtx := input.interview, input.questions, input.answers, input.evalCtx
+	answers := make(map[string]string)
+	for i, answer := range userAnswers {
+		answers[answerKey(i)] = answer
 	}
 
-	writeJSON(w, http.StatusOK, response)
+	// Create evaluation record
+	evaluation := &data.Evaluation{
+		ID:                data.GenerateID(),
+		TenantID:          session.TenantID,
+		InterviewID:       session.InterviewID,
+		Answers:           answers,
+		QuestionsSnapshot: append([]string(nil), questions...),
+		Status:            data.EvaluationStatusCompleted,
+		SupersedesID:      supersedesID,
+	}
+
+	// The AI response the evaluation was generated from; nil when no provider was called
+	var trace *ai.EvaluationResponse
+	if len(userAnswers) == 0 {
+		// The candidate never replied: skip the AI call (providers produce meaningless scores for
+		// an empty transcript) and record a zero-score evaluation marked "no_answers" instead of
+		// failing, so the session still ends with a result clients can tell apart from a poor one.
+		// Auto-ended sessions are evaluated here too (see SendMessageHandler).
+		evaluation.Score = 0
+		evaluation.Feedback = noAnswersFeedback
+		evaluation.Status = data.EvaluationStatusNoAnswers
+	} else {
+		var jobDescCost float64
+		if interview.JobDescription != "" {
+			evalCtx.JobDescription, jobDescCost = deps.promptJobDescription(ctx, aiClient, store, interview)
+		}
+		result, err := aiClient.EvaluateAnswersDetailed(ctx, questions, userAnswers, evalCtx)
+		if err != nil {
+			return nil, &sessionEvaluationError{code: ErrCodeAIUnavailable, message: "Failed to generate evaluation", err: err}
+		}
+		evaluation.Score = result.OverallScore
+		evaluation.Feedback = result.Feedback
+		evaluation.Provider = result.Provider
+		evaluation.Model = result.Model
+		// The one-time job description summary is charged to the evaluation that needed it
+		evaluation.EstimatedCostUSD = result.EstimatedCostUSD + jobDescCost
+		evaluation.LanguageMismatch = result.LanguageMismatch
+		evaluation.FeedbackTruncated = feedbackTruncated(result)
+		evaluation.Decision = result.Decision
+		evaluation.NextSteps = result.NextSteps
+		evaluation.AnswerStats = answerStats(result)
+		translateEvaluation(ctx, aiClient, evaluation, result, session.SessionLanguage, feedbackLanguages)
+		trace = result
+	}
+
+	if err := store.CreateEvaluation(evaluation); err != nil {
+		if errors.Is(err, data.ErrAlreadyExists) {
+			return nil, &sessionEvaluationError{code: ErrCodeConflict, message: "Evaluation already exists", err: err}
+		}
+		if errors.Is(err, data.ErrConstraint) {
+			return nil, &sessionEvaluationError{code: ErrCodeConflict, message: "Request conflicts with related records", err: err}
+		}
+		return nil, &sessionEvaluationError{code: ErrCodeInternal, message: "Failed to save evaluation", err: err}
+	}
+	if trace != nil {
+		recordEvaluationTrace(store, evaluation, trace)
+	}
+	deps.notifyEvaluationCreated(interview, evaluation, session.ID)
+	return evaluation, nil
 }
 
-// EndChatSessionHandler handles POST /chat/{sessionId}/end
-func (deps *HandlerDependencies) EndChatSessionHandler(w http.ResponseWriter, r *http.Request) {
-	sessionID := chi.URLParam(r, "sessionId")
-	if sessionID == "" {
-		writeJSONError(w, http.StatusBadRequest, "Missing session ID")
+// GetAIDebugCaptureHandler returns the captured AI provider exchanges, oldest first per provider,
+// and the current load against the AI concurrency cap
+// Only mounted when debug endpoints are enabled, behind admin auth
+func (deps *HandlerDependencies) GetAIDebugCaptureHandler(w http.ResponseWriter, r *http.Request) {
+	resp := AIDebugCaptureResponseDTO{Providers: make(map[string][]AIDebugExchangeDTO)}
+	if deps.AILimiter != nil {
+		stats := deps.AILimiter.Stats()
+		resp.Concurrency = &AIConcurrencyDTO{MaxConcurrent: stats.MaxConcurrent, InFlight: stats.InFlight, Queued: stats.Queued}
+	}
+	if deps.DebugCapture == nil {
+		writeJSON(w, http.StatusOK, resp)
 		return
 	}
+	for provider, exchanges := range deps.DebugCapture.Snapshot() {
+		dtos := make([]AIDebugExchangeDTO, len(exchanges))
+		for i, exchange := range exchanges {
+			dtos[i] = AIDebugExchangeDTO{
+				Model:     exchange.Model,
+				Operation: exchange.Operation,
+				Request:   exchange.Request,
+				Response:  exchange.Response,
+				Error:     exchange.Error,
+				Timestamp: apitime.New(exchange.Timestamp),
+			}
+		}
+		resp.Providers[provider] = dtos
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
 
-	// Get chat session
-	session, err := data.GlobalStore.GetChatSession(sessionID)
+// GetAdminStatsHandler handles GET /admin/stats
+// Reports the average evaluation score per AI provider and model and per recorded hiring outcome, the
+// recommendation decisions and average session duration per interview type, plus one interview's estimated cost,
+// difficulty trajectories and AI attempts per session with ?interview_id=
+func (deps *HandlerDependencies) GetAdminStatsHandler(w http.ResponseWriter, r *http.Request) {
+	store := deps.Store.WithContext(r.Context())
+
+	var interviewCost *InterviewCostDTO
+	var trajectories []SessionDifficultyDTO
+	var attempts []SessionAIAttemptsDTO
+	var timings []SessionAnswerTimingsDTO
+	var evaluationPercentile *EvaluationPercentileDTO
+	var warnings warningList
+	if interviewID := r.URL.Query().Get("interview_id"); interviewID != "" {
+		if _, err := store.GetInterview(interviewID); err != nil {
+			writeJSONError(w, http.StatusNotFound, ErrCodeNotFound, "Interview not found")
+			return
+		}
+		cost, err := store.GetInterviewEstimatedCost(interviewID)
+		if err != nil {
+			writeJSONError(w, http.StatusInternalServerError, ErrCodeInternal, "Failed to sum interview cost")
+			return
+		}
+		interviewCost = &InterviewCostDTO{InterviewID: interviewID, EstimatedCostUSD: cost}
+
+		sessions, err := store.GetChatSessionsByInterview(interviewID)
+		if err != nil {
+			writeJSONError(w, http.StatusInternalServerError, ErrCodeInternal, "Failed to list interview sessions")
+			return
+		}
+		for _, session := range sessions {
+			if len(session.DifficultyTrajectory) > 0 {
+				trajectories = append(trajectories, SessionDifficultyDTO{SessionID: session.ID, Trajectory: session.DifficultyTrajectory})
+			}
+			attempts = append(attempts, SessionAIAttemptsDTO{SessionID: session.ID, AIAttempts: session.AIAttempts})
+			messages, err := store.GetChatMessages(session.ID)
+			if err != nil {
+				writeJSONError(w, http.StatusInternalServerError, ErrCodeInternal, "Failed to get chat messages")
+				return
+			}
+			if exchanges := answerTimings(messages); len(exchanges) > 0 {
+				timings = append(timings, SessionAnswerTimingsDTO{SessionID: session.ID, Exchanges: exchanges})
+			}
+		}
+
+		// The current evaluation's score, ranked against comparable evaluations
+		if evaluation, err := store.GetLatestEvaluationByInterview(interviewID); err == nil && evaluationStatus(evaluation) == data.EvaluationStatusCompleted {
+			percentile, size, err := deps.newCohortRanker(store).evaluationPercentile(evaluation, &warnings)
+			if err != nil {
+				writeJSONError(w, http.StatusInternalServerError, ErrCodeInternal, "Failed to rank evaluation score")
+				return
+			}
+			evaluationPercentile = &EvaluationPercentileDTO{EvaluationID: evaluation.ID, Score: evaluation.Score, Percentile: percentile, CohortSize: size}
+		}
+	}
+
+	scores, err := store.GetEvaluationScoresByModel()
 	if err != nil {
-		writeJSONError(w, http.StatusNotFound, "Chat session not found")
+		writeJSONError(w, http.StatusInternalServerError, ErrCodeInternal, "Failed to aggregate evaluation scores")
 		return
 	}
-
-	// Mark session as completed
-	session.Status = "completed"
-	session.UpdatedAt = time.Now()
-	endedAt := time.Now()
-	session.EndedAt = &endedAt
-
-	err = data.GlobalStore.UpdateChatSession(session)
+	decisions, err := store.GetEvaluationDecisionCounts()
 	if err != nil {
-		writeJSONError(w, http.StatusInternalServerError, "Failed to update session")
+		writeJSONError(w, http.StatusInternalServerError, ErrCodeInternal, "Failed to count evaluation decisions")
 		return
 	}
-
-	// Get all messages for evaluation
-	messages, err := data.GlobalStore.GetChatMessages(sessionID)
+	outcomeScores, err := store.GetEvaluationScoresByOutcome()
 	if err != nil {
-		writeJSONError(w, http.StatusInternalServerError, "Failed to get chat messages")
+		writeJSONError(w, http.StatusInternalServerError, ErrCodeInternal, "Failed to aggregate scores by outcome")
 		return
 	}
-
-	// Get interview details for context
-	interview, err := data.GlobalStore.GetInterview(session.InterviewID)
+	durations, err := store.GetSessionDurationsByInterviewType()
 	if err != nil {
-		writeJSONError(w, http.StatusInternalServerError, "Failed to get interview details")
+		writeJSONError(w, http.StatusInternalServerError, ErrCodeInternal, "Failed to aggregate session durations")
 		return
 	}
-
-	// Convert chat messages to evaluation format
-	answers := make(map[string]string)
-	questions := make([]string, 0)
-	userAnswers := make([]string, 0)
-
-	for _, msg := range messages {
-		if msg.Type == "ai" {
-			questions = append(questions, msg.Content)
-		} else if msg.Type == "user" {
-			userAnswers = append(userAnswers, msg.Content)
-			// Map answers to question indices
-			questionIndex := len(userAnswers) - 1
-			answers[fmt.Sprintf("question_%d", questionIndex)] = msg.Content
+	notifications, err := store.GetNotificationStats()
+	if err != nil {
+		writeJSONError(w, http.StatusInternalServerError, ErrCodeInternal, "Failed to count notifications")
+		return
+	}
+	resp := AdminStatsResponseDTO{
+		ScoresByModel:            make([]ModelScoreStatsDTO, len(scores)),
+		DecisionsByInterviewType: make(map[string]map[string]int64),
+		ScoresByOutcome:          make([]OutcomeScoreStatsDTO, len(outcomeScores)),
+		SessionDurations:         make([]SessionDurationStatsDTO, len(durations)),
+		AITokenUsage:             []AITokenUsageDTO{},
+		InterviewCost:            interviewCost,
+		DifficultyTrajectories:   trajectories,
+		AIAttempts:               attempts,
+		AnswerTimings:            timings,
+		EvaluationPercentile:     evaluationPercentile,
+		Maintenance:              toMaintenanceReportDTO(deps.maintenance.lastRun()),
+		Warnings:                 warnings,
+		Notifications: NotificationStatsDTO{
+			Pending:   notifications.Pending,
+			Retrying:  notifications.Retrying,
+			Delivered: notifications.Delivered,
+			Failed:    notifications.Failed,
+		},
+	}
+	for i, stats := range outcomeScores {
+		resp.ScoresByOutcome[i] = OutcomeScoreStatsDTO{Outcome: stats.Outcome, Evaluations: stats.Evaluations, AverageScore: stats.AverageScore}
+	}
+	for i, stats := range durations {
+		resp.SessionDurations[i] = SessionDurationStatsDTO{
+			InterviewType:          stats.InterviewType,
+			Sessions:               stats.Sessions,
+			AverageDurationSeconds: stats.AverageDurationSeconds,
 		}
 	}
-	// Generate evaluation using AI service with interview context
-	jobDesc := interview.JobDescription
-	if jobDesc == "" {
-		jobDesc = fmt.Sprintf("General %s interview", interview.InterviewType)
+	for _, usage := range ai.TokenUsageByInterviewType() {
+		resp.AITokenUsage = append(resp.AITokenUsage, AITokenUsageDTO{
+			Provider:         usage.Provider,
+			Model:            usage.Model,
+			InterviewType:    usage.InterviewType,
+			PromptTokens:     usage.PromptTokens,
+			CompletionTokens: usage.CompletionTokens,
+			EstimatedCostUSD: usage.EstimatedCostUSD,
+		})
+	}
+	for _, count := range decisions {
+		if resp.DecisionsByInterviewType[count.InterviewType] == nil {
+			resp.DecisionsByInterviewType[count.InterviewType] = make(map[string]int64)
+		}
+		resp.DecisionsByInterviewType[count.InterviewType][count.Decision] = count.Evaluations
+	}
+	for i, stats := range scores {
+		resp.ScoresByModel[i] = ModelScoreStatsDTO{
+			Provider:     stats.Provider,
+			Model:        stats.Model,
+			Evaluations:  stats.Evaluations,
+			AverageScore: stats.AverageScore,
+		}
 	}
-	sessionLanguage := session.SessionLanguage // Use session language for evaluation
+	writeJSON(w, http.StatusOK, resp)
+}
 
-	// Create AI client from request headers (BYOK pattern)
-	aiClient := createClientFromRequest(r)
+// Outcomes of one session in an evaluation backfill
+const (
+	backfillSucceeded = "succeeded"
+	backfillFailed    = "failed"
+	backfillSkipped   = "skipped"
+	backfillCandidate = "candidate" // Would be evaluated; only reported on a dry run
+)
+
+// Sessions considered per backfill request
+const (
+	defaultBackfillLimit = 100
+	maxBackfillLimit     = 1000
+)
 
-	score, feedback, err := aiClient.EvaluateAnswersWithContext(questions, userAnswers, jobDesc, sessionLanguage)
+// BackfillEvaluationsHandler handles POST /admin/evaluations/backfill
+// Evaluates completed sessions whose interview has no evaluation, oldest first, with up to
+// BackfillWorkers sessions in flight. ?dry_run=true only reports the candidates. An interview gets
+// one evaluation, so its other sessions in the same run are skipped.
+func (deps *HandlerDependencies) BackfillEvaluationsHandler(w http.ResponseWriter, r *http.Request) {
+	// Candidates are re-checked against the primary so a lagging replica can't cause double evaluations
+	store := deps.Store.WithContext(r.Context()).WithPrimaryReads()
+	requestID := middleware.GetReqID(r.Context())
+	dryRun := r.URL.Query().Get("dry_run") == "true"
+	var warnings warningList
+	limit := parseIntQueryWithMax(r, "limit", defaultBackfillLimit, maxBackfillLimit, &warnings)
+
+	sessions, err := store.GetCompletedSessionsWithoutEvaluation(limit)
 	if err != nil {
-		writeJSONError(w, http.StatusInternalServerError, "Failed to generate evaluation")
+		writeJSONError(w, http.StatusInternalServerError, ErrCodeInternal, "Failed to list sessions without evaluations")
 		return
 	}
 
-	// Create evaluation record
-	evaluationID := data.GenerateID()
-	evaluation := &data.Evaluation{
-		ID:          evaluationID,
-		InterviewID: session.InterviewID, Answers: answers,
-		Score:     score,
-		Feedback:  feedback,
-		CreatedAt: time.Now(),
-		UpdatedAt: time.Now(),
+	resp := EvaluationBackfillResponseDTO{DryRun: dryRun, Results: make([]EvaluationBackfillResultDTO, len(sessions)), Warnings: warnings}
+	var pending []int
+	seenInterviews := make(map[string]bool)
+	for i, session := range sessions {
+		resp.Results[i] = EvaluationBackfillResultDTO{SessionID: session.ID, InterviewID: session.InterviewID}
+		switch {
+		case seenInterviews[session.InterviewID]:
+			resp.Results[i].Status = backfillSkipped
+			resp.Results[i].Reason = "interview is evaluated from an earlier session in this run"
+		case dryRun:
+			resp.Results[i].Status = backfillCandidate
+		default:
+			pending = append(pending, i)
+		}
+		seenInterviews[session.InterviewID] = true
+	}
+	utils.Infof("[%s] Evaluation backfill: %d sessions without evaluations, %d to evaluate (dry_run=%t)", requestID, len(sessions), len(pending), dryRun)
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	var completed atomic.Int64
+	for range min(deps.BackfillWorkers, len(pending)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			// Each worker has its own client built from the admin's request headers (BYOK)
+			aiClient := deps.newAIClient(r)
+			for i := range jobs {
+				deps.limitAIAttempts(aiClient, store, sessions[i].ID)
+				resp.Results[i] = deps.backfillSession(r.Context(), aiClient, store, sessions[i], resp.Results[i])
+				utils.Infof("[%s] Evaluation backfill: session %s %s (%d/%d)", requestID, sessions[i].ID, resp.Results[i].Status, completed.Add(1), len(pending))
+			}
+		}()
+	}
+	for _, i := range pending {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	for _, result := range resp.Results {
+		switch result.Status {
+		case backfillSucceeded:
+			resp.Succeeded++
+		case backfillFailed:
+			resp.Failed++
+		case backfillSkipped:
+			resp.Skipped++
+		}
 	}
+	utils.Infof("[%s] Evaluation backfill finished: %d succeeded, %d failed, %d skipped", requestID, resp.Succeeded, resp.Failed, resp.Skipped)
+	writeJSON(w, http.StatusOK, resp)
+}
 
-	err = data.GlobalStore.CreateEvaluation(evaluation)
-	if err != nil {
-		writeJSONError(w, http.StatusInternalServerError, "Failed to save evaluation")
-		return
-	}
+// backfillSession evaluates one session for the backfill within BackfillSessionTimeout
+func (deps *HandlerDependencies) backfillSession(ctx context.Context, aiClient *ai.AIClient, store data.Store, session *data.ChatSession, result EvaluationBackfillResultDTO) EvaluationBackfillResultDTO {
+	ctx, cancel := context.WithTimeout(ctx, deps.BackfillSessionTimeout)
+	defer cancel()
+	store = store.WithContext(ctx)
 
-	// Convert to DTO format
-	response := EvaluationResponseDTO{
-		ID:          evaluation.ID,
-		InterviewID: evaluation.InterviewID,
-		Answers:     evaluation.Answers,
-		Score:       evaluation.Score,
-		Feedback:    evaluation.Feedback,
-		CreatedAt:   evaluation.CreatedAt,
+	// The interview may have been evaluated since the candidates were listed
+	if existing, err := store.GetLatestEvaluationByInterview(session.InterviewID); err == nil {
+		result.Status = backfillSkipped
+		result.Reason = "interview already has evaluation " + existing.ID
+		return result
 	}
 
-	writeJSON(w, http.StatusOK, response)
+	evaluation, err := deps.evaluateSession(ctx, aiClient, store, session, "", "", deps.AdditionalFeedbackLanguages)
+	if err != nil {
+		utils.Errorf("Evaluation backfill failed for session %s: %v", session.ID, err)
+		result.Status = backfillFailed
+		var failure *sessionEvaluationError
+		switch {
+		case errors.Is(err, context.DeadlineExceeded):
+			result.Reason = fmt.Sprintf("timed out after %s", deps.BackfillSessionTimeout)
+		case errors.As(err, &failure):
+			result.Reason = failure.message
+		default:
+			result.Reason = "Failed to evaluate session"
+		}
+		return result
+	}
+	result.Status = backfillSucceeded
+	result.EvaluationID = evaluation.ID
+	return result
 }
@@ -2,46 +2,84 @@ package api
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
+	"math"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"reflect"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/zidane0000/ai-interview-platform/ai"
+	"github.com/zidane0000/ai-interview-platform/api/apitime"
 	"github.com/zidane0000/ai-interview-platform/config"
 	"github.com/zidane0000/ai-interview-platform/data"
+	"github.com/zidane0000/ai-interview-platform/internal/testsupport"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
 )
 
 // Test utilities and helpers
 
-// setupTestRouter creates a test router with mock configuration
-func setupTestRouter() http.Handler {
+// testRouter is a test router together with the store its handlers use
+type testRouter struct {
+	http.Handler
+	store *data.HybridStore
+}
+
+// newTestStore creates a fresh memory store, so each test starts empty
+func newTestStore() *data.HybridStore {
+	store, err := data.NewHybridStore(data.BackendMemory, "")
+	if err != nil {
+		panic("Failed to initialize test store: " + err.Error())
+	}
+	return store
+}
+
+// setupTestRouter creates a test router with mock configuration around a fresh memory store
+func setupTestRouter() *testRouter {
 	testConfig := &config.Config{
 		Port:            "8080",
 		OpenAIAPIKey:    "test-openai-key",
 		GeminiAPIKey:    "test-gemini-key",
 		ShutdownTimeout: 30 * time.Second,
 	}
+	store := newTestStore()
 	// No frontend handler needed for tests (nil)
-	return SetupRouter(testConfig, nil)
+	return &testRouter{Handler: SetupRouter(testConfig, NewHandlerDependencies(testConfig, store), nil), store: store}
 }
 
-// clearMemoryStore clears all data from the memory store for test isolation
-func clearMemoryStore() {
-	var err error
-	data.GlobalStore, err = data.NewHybridStore(data.BackendMemory, "")
-	if err != nil {
-		panic("Failed to initialize test store: " + err.Error())
+// setupTestRouterWithProvider creates a test router whose handlers all use the given AI provider
+// configure may adjust handler dependencies (e.g. limits) before the router is built
+func setupTestRouterWithProvider(provider ai.AIProvider, configure func(*HandlerDependencies)) *testRouter {
+	return newTestRouter(newTestStore(), provider, configure)
+}
+
+// newTestRouter is setupTestRouterWithProvider around store, for routers that share their data
+func newTestRouter(store *data.HybridStore, provider ai.AIProvider, configure func(*HandlerDependencies)) *testRouter {
+	deps := NewHandlerDependencies(nil, store)
+	deps.newAIClient = func(r *http.Request) *ai.AIClient {
+		return ai.NewAIClientWithProvider(provider, nil)
+	}
+	if configure != nil {
+		configure(deps)
 	}
+	return &testRouter{Handler: SetupRouter(nil, deps, nil), store: store}
 }
 
-// createTestInterview creates a test interview and returns the response
-func createTestInterview(t *testing.T, router http.Handler, req CreateInterviewRequestDTO) InterviewResponseDTO {
+// createTestInterview creates the builder's interview through the API and returns the response
+func createTestInterview(t *testing.T, router http.Handler, b *testsupport.InterviewBuilder) InterviewResponseDTO {
 	t.Helper()
-	b, _ := json.Marshal(req)
-	httpReq := httptest.NewRequest("POST", "/api/interviews", bytes.NewReader(b))
+	body, _ := json.Marshal(interviewRequest(b.Build()))
+	httpReq := httptest.NewRequest("POST", "/api/interviews", bytes.NewReader(body))
 	w := httptest.NewRecorder()
 	router.ServeHTTP(w, httpReq)
 
@@ -56,15 +94,42 @@ func createTestInterview(t *testing.T, router http.Handler, req CreateInterviewR
 	return resp
 }
 
-// startChatSession starts a chat session for an interview
-func startChatSession(t *testing.T, router http.Handler, interviewID string, req *StartChatSessionRequestDTO) ChatInterviewSessionDTO {
+// interviewRequest is the API request that creates interview
+func interviewRequest(interview *data.Interview) CreateInterviewRequestDTO {
+	req := CreateInterviewRequestDTO{
+		CandidateName:     interview.CandidateName,
+		Questions:         interview.Questions,
+		InterviewType:     interview.InterviewType,
+		InterviewMode:     interview.InterviewMode,
+		InterviewLanguage: interview.InterviewLanguage,
+		JobDescription:    interview.JobDescription,
+		ResumeContent:     interview.ResumeContent,
+		CompanyContext:    interview.CompanyContext,
+		ScheduledStart:    apitime.NewPtr(interview.ScheduledStart),
+		ScheduledEnd:      apitime.NewPtr(interview.ScheduledEnd),
+		QuestionStrategy:  interview.QuestionStrategy,
+	}
+	if interview.NotifyWebhookURL != "" {
+		req.Notify = &NotifyRequestDTO{WebhookURL: interview.NotifyWebhookURL, Events: interview.NotifyEvents, Secret: interview.NotifySecret}
+	}
+	if !interview.IsAdaptive() {
+		adaptive := false
+		req.Adaptive = &adaptive
+	}
+	return req
+}
+
+// startChatSession starts the builder's session through the API and sends its transcript answers
+// Returns the session as started, before the answers were sent
+func startChatSession(t *testing.T, router http.Handler, b *testsupport.SessionBuilder) ChatInterviewSessionDTO {
 	t.Helper()
+	session, _ := b.Build()
 	var body []byte
-	if req != nil {
-		body, _ = json.Marshal(req)
+	if session.SessionLanguage != "" {
+		body, _ = json.Marshal(StartChatSessionRequestDTO{SessionLanguage: session.SessionLanguage})
 	}
 
-	httpReq := httptest.NewRequest("POST", "/api/interviews/"+interviewID+"/chat/start", bytes.NewReader(body))
+	httpReq := httptest.NewRequest("POST", "/api/interviews/"+session.InterviewID+"/chat/start", bytes.NewReader(body))
 	w := httptest.NewRecorder()
 	router.ServeHTTP(w, httpReq)
 
@@ -76,6 +141,9 @@ func startChatSession(t *testing.T, router http.Handler, interviewID string, req
 	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
 		t.Fatalf("failed to unmarshal chat session response: %v", err)
 	}
+	for _, answer := range b.Answers() {
+		sendMessage(t, router, resp.ID, answer)
+	}
 	return resp
 }
 
@@ -120,14 +188,10 @@ func createTestInterviewAndSession(t *testing.T, router http.Handler) struct {
 	t.Helper()
 
 	// Create interview using helper
-	interview := createTestInterview(t, router, CreateInterviewRequestDTO{
-		CandidateName: "Test User",
-		Questions:     []string{"Q1", "Q2"},
-		InterviewType: "general",
-	})
+	interview := createTestInterview(t, router, testsupport.NewInterviewBuilder().WithCandidate("Test User").WithQuestions(2))
 
 	// Start chat session using helper
-	session := startChatSession(t, router, interview.ID, nil)
+	session := startChatSession(t, router, testsupport.NewSessionBuilder().ForInterviewID(interview.ID))
 
 	return struct {
 		InterviewID string
@@ -143,7 +207,6 @@ func createTestInterviewAndSession(t *testing.T, router http.Handler) struct {
 // ============================================
 
 func TestCreateInterviewHandler_Success(t *testing.T) {
-	clearMemoryStore()
 
 	req := CreateInterviewRequestDTO{
 		CandidateName: "Alice",
@@ -154,7 +217,7 @@ func TestCreateInterviewHandler_Success(t *testing.T) {
 	b, _ := json.Marshal(req)
 	httpReq := httptest.NewRequest("POST", "/api/interviews", bytes.NewReader(b))
 	w := httptest.NewRecorder()
-	CreateInterviewHandler(w, httpReq)
+	NewHandlerDependencies(nil, nil).CreateInterviewHandler(w, httpReq)
 
 	if w.Code != http.StatusCreated {
 		t.Errorf("expected 201 Created, got %d", w.Code)
@@ -174,7 +237,6 @@ func TestCreateInterviewHandler_BadRequest(t *testing.T) {
 }
 
 func TestCreateInterviewHandler_EdgeCases(t *testing.T) {
-	clearMemoryStore()
 	router := setupTestRouter()
 
 	tests := []struct {
@@ -216,6 +278,33 @@ func TestCreateInterviewHandler_EdgeCases(t *testing.T) {
 			},
 			expectedStatus: http.StatusBadRequest,
 		},
+		{
+			name: "whitespace-only candidate name",
+			body: CreateInterviewRequestDTO{
+				CandidateName: " \t ",
+				Questions:     []string{"Q1"},
+				InterviewType: "general",
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "over-length candidate name",
+			body: CreateInterviewRequestDTO{
+				CandidateName: strings.Repeat("a", data.MaxCandidateNameLength+1),
+				Questions:     []string{"Q1"},
+				InterviewType: "general",
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "control character in candidate name",
+			body: CreateInterviewRequestDTO{
+				CandidateName: "Jane\u0007Doe",
+				Questions:     []string{"Q1"},
+				InterviewType: "general",
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
 	}
 
 	for _, tt := range tests {
@@ -226,8 +315,309 @@ func TestCreateInterviewHandler_EdgeCases(t *testing.T) {
 	}
 }
 
+func TestCreateInterviewHandler_QuestionValidation(t *testing.T) {
+	router := setupTestRouterWithProvider(ai.NewMockProvider(), func(deps *HandlerDependencies) {
+		deps.QuestionLimits = data.QuestionLimits{MaxLength: 50, MaxCount: 3}
+	})
+
+	tests := []struct {
+		name           string
+		questions      []string
+		expectedStatus int
+	}{
+		{"valid questions", []string{"Q1", "Q2"}, http.StatusCreated},
+		{"empty question", []string{"Q1", ""}, http.StatusBadRequest},
+		{"whitespace-only question", []string{"   "}, http.StatusBadRequest},
+		{"question too long", []string{strings.Repeat("x", 51)}, http.StatusBadRequest},
+		{"too many questions", []string{"Q1", "Q2", "Q3", "Q4"}, http.StatusBadRequest},
+		{"duplicates within limit", []string{"Q1", "Q2", "Q3", "Q1"}, http.StatusCreated},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, _ := json.Marshal(CreateInterviewRequestDTO{
+				CandidateName: "Validation Candidate",
+				Questions:     tt.questions,
+				InterviewType: "general",
+			})
+			expectHTTPError(t, router, "POST", "/api/interviews", b, tt.expectedStatus)
+		})
+	}
+}
+
+func TestCreateInterviewHandler_InterviewMode(t *testing.T) {
+	router := setupTestRouter()
+
+	tests := []struct {
+		name           string
+		mode           string
+		questions      []string
+		expectedStatus int
+		expectedMode   string
+	}{
+		{"structured by default", "", []string{"Q1"}, http.StatusCreated, data.InterviewModeStructured},
+		{"structured requires questions", data.InterviewModeStructured, []string{}, http.StatusBadRequest, ""},
+		{"default requires questions", "", nil, http.StatusBadRequest, ""},
+		{"conversational without questions", data.InterviewModeConversational, []string{}, http.StatusCreated, data.InterviewModeConversational},
+		{"conversational with questions", data.InterviewModeConversational, []string{"Q1"}, http.StatusCreated, data.InterviewModeConversational},
+		{"conversational still validates questions", data.InterviewModeConversational, []string{" "}, http.StatusBadRequest, ""},
+		{"unknown mode", "freeform", []string{"Q1"}, http.StatusBadRequest, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, _ := json.Marshal(CreateInterviewRequestDTO{
+				CandidateName: "Mode Candidate",
+				Questions:     tt.questions,
+				InterviewType: "general",
+				InterviewMode: tt.mode,
+			})
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest("POST", "/api/interviews", bytes.NewReader(b)))
+			if w.Code != tt.expectedStatus {
+				t.Fatalf("expected %d, got %d: %s", tt.expectedStatus, w.Code, w.Body.String())
+			}
+			if tt.expectedStatus != http.StatusCreated {
+				return
+			}
+			var resp InterviewResponseDTO
+			if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+				t.Fatalf("failed to decode interview: %v", err)
+			}
+			if resp.InterviewMode != tt.expectedMode {
+				t.Errorf("expected mode %q, got %q", tt.expectedMode, resp.InterviewMode)
+			}
+			if resp.Questions == nil {
+				t.Error("expected questions to be an array, got null")
+			}
+		})
+	}
+}
+
+func TestConversationalInterview_EndToEnd(t *testing.T) {
+	provider := ai.NewScriptedMockProvider(
+		"Hi! Tell me about the last system you built.",
+		"Interesting. How did you test it?",
+	)
+	router := setupTestRouterWithProvider(provider, func(deps *HandlerDependencies) {
+		deps.MaxMessagesPerSession = 5
+	})
+	interview := createTestInterview(t, router, testsupport.NewInterviewBuilder().
+		WithCandidate("Conversational Candidate").
+		WithQuestionTexts().
+		WithType("technical").
+		WithMode(data.InterviewModeConversational).
+		WithJobDescription("Backend engineer working on payment systems"))
+
+	session := startChatSession(t, router, testsupport.NewSessionBuilder().ForInterviewID(interview.ID))
+	if session.Progress == nil || session.Progress.QuestionsTotal != 0 {
+		t.Errorf("expected no planned questions, got %+v", session.Progress)
+	}
+	if resp := sendMessage(t, router, session.ID, "A payment ledger"); resp.SessionStatus != "active" {
+		t.Fatalf("expected the session to stay active, got %q", resp.SessionStatus)
+	}
+	// Without questions to exhaust, the message cap ends the interview
+	if resp := sendMessage(t, router, session.ID, "Integration tests against a sandbox"); resp.SessionStatus != "completed" {
+		t.Errorf("expected the message cap to end the interview, got %q", resp.SessionStatus)
+	}
+	if asked := getChatSession(t, router, session.ID, "?include=asked_questions").AskedQuestions; len(asked) != 0 {
+		t.Errorf("expected no tracked questions, got %v", asked)
+	}
+
+	// The form endpoint has no indexed questions to key answers by
+	if w := submitEvaluation(t, router, "", interview.ID, "Answer"); w.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for indexed answers, got %d: %s", w.Code, w.Body.String())
+	}
+
+	// Ending the chat evaluates the transcript, pairing answers with the AI's questions
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("POST", "/api/chat/"+session.ID+"/end", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200 OK, got %d: %s", w.Code, w.Body.String())
+	}
+	requests := provider.EvaluationRequests()
+	if len(requests) != 1 {
+		t.Fatalf("expected 1 evaluation request, got %d", len(requests))
+	}
+	wantQuestions := []string{"Hi! Tell me about the last system you built.", "Interesting. How did you test it?"}
+	wantAnswers := []string{"A payment ledger", "Integration tests against a sandbox"}
+	if !reflect.DeepEqual(requests[0].Questions, wantQuestions) || !reflect.DeepEqual(requests[0].Answers, wantAnswers) {
+		t.Errorf("expected transcript pairs %v / %v, got %v / %v", wantQuestions, wantAnswers, requests[0].Questions, requests[0].Answers)
+	}
+}
+
+func TestCreateInterviewHandler_DuplicateQuestionWarning(t *testing.T) {
+	router := setupTestRouter()
+
+	interview := createTestInterview(t, router, testsupport.NewInterviewBuilder().
+		WithCandidate("Dedup Candidate").
+		WithQuestionTexts("  What is Go?  ", "Why Go?", "What is Go?").
+		WithType("technical"))
+
+	if len(interview.Questions) != 2 || interview.Questions[0] != "What is Go?" || interview.Questions[1] != "Why Go?" {
+		t.Errorf("expected trimmed, deduplicated questions, got %v", interview.Questions)
+	}
+	if len(interview.Warnings) != 1 || interview.Warnings[0].Code != WarnCodeDuplicateQuestion ||
+		interview.Warnings[0].Field != "questions" || !strings.Contains(interview.Warnings[0].Message, "What is Go?") {
+		t.Errorf("expected a duplicate question warning, got %v", interview.Warnings)
+	}
+
+	stored, err := router.store.GetInterview(interview.ID)
+	if err != nil {
+		t.Fatalf("failed to load stored interview: %v", err)
+	}
+	if len(stored.Questions) != 2 {
+		t.Errorf("expected 2 stored questions, got %v", stored.Questions)
+	}
+}
+
+func TestCreateInterviewHandler_UseDefaultQuestions(t *testing.T) {
+	router := setupTestRouter()
+
+	create := func(req CreateInterviewRequestDTO) *httptest.ResponseRecorder {
+		body, _ := json.Marshal(req)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("POST", "/api/interviews", bytes.NewReader(body)))
+		return w
+	}
+
+	w := create(CreateInterviewRequestDTO{CandidateName: "Quick Start", InterviewType: "technical", InterviewLanguage: "zh-TW", UseDefaultQuestions: true})
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", w.Code, w.Body.String())
+	}
+	var interview InterviewResponseDTO
+	if err := json.Unmarshal(w.Body.Bytes(), &interview); err != nil {
+		t.Fatalf("failed to unmarshal interview: %v", err)
+	}
+	want := data.GetDefaultQuestionSet("technical", "zh-TW").Questions
+	if !reflect.DeepEqual(interview.Questions, want) || len(interview.Warnings) != 0 {
+		t.Errorf("expected the built-in zh-TW technical questions, got %v (warnings %v)", interview.Questions, interview.Warnings)
+	}
+
+	// Given questions win over the defaults
+	w = create(CreateInterviewRequestDTO{CandidateName: "Own Questions", Questions: []string{"Why Go?"}, InterviewType: "general", UseDefaultQuestions: true})
+	if err := json.Unmarshal(w.Body.Bytes(), &interview); err != nil || !reflect.DeepEqual(interview.Questions, []string{"Why Go?"}) {
+		t.Errorf("expected the given questions, got %v (err %v)", interview.Questions, err)
+	}
+
+	// Without the flag, questions are still required
+	assertErrorResponse(t, router, "POST", "/api/interviews", `{"candidate_name":"No Questions","interview_type":"general"}`, http.StatusBadRequest, ErrCodeValidationFailed)
+}
+
+func TestCreateInterviewHandler_QuestionDetails(t *testing.T) {
+	provider := ai.NewMockProvider()
+	router := setupTestRouterWithProvider(provider, nil)
+
+	create := func(req CreateInterviewRequestDTO) InterviewResponseDTO {
+		t.Helper()
+		body, _ := json.Marshal(req)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("POST", "/api/interviews", bytes.NewReader(body)))
+		if w.Code != http.StatusCreated {
+			t.Fatalf("expected 201, got %d: %s", w.Code, w.Body.String())
+		}
+		var interview InterviewResponseDTO
+		if err := json.Unmarshal(w.Body.Bytes(), &interview); err != nil {
+			t.Fatalf("failed to unmarshal interview: %v", err)
+		}
+		return interview
+	}
+	details := func(id string) []QuestionDetailDTO {
+		t.Helper()
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("GET", "/api/interviews/"+id+"?include=question_details", nil))
+		var interview InterviewResponseDTO
+		if err := json.Unmarshal(w.Body.Bytes(), &interview); err != nil {
+			t.Fatalf("failed to unmarshal interview: %v", err)
+		}
+		return interview.QuestionDetails
+	}
+
+	// Generated questions keep the AI's metadata while the flat list stays plain text
+	generated := create(CreateInterviewRequestDTO{CandidateName: "Generated", InterviewType: "technical", GenerateQuestions: true, NumQuestions: 2})
+	generated = waitForQuestions(t, router, generated.ID)
+	if !reflect.DeepEqual(generated.Questions, []string{"[MOCK] Test question 1", "[MOCK] Test question 2"}) {
+		t.Errorf("expected the first two generated questions, got %v", generated.Questions)
+	}
+	if generated.QuestionDetails != nil {
+		t.Errorf("expected no question details without include, got %v", generated.QuestionDetails)
+	}
+	if requests := provider.QuestionRequests(); len(requests) != 1 || requests[0].Language != "en" {
+		t.Errorf("expected one generation request in English, got %v", requests)
+	}
+	waitForQuestions(t, router, create(CreateInterviewRequestDTO{CandidateName: "Generated zh", InterviewType: "technical", InterviewLanguage: "zh-TW", GenerateQuestions: true}).ID)
+	if requests := provider.QuestionRequests(); len(requests) != 2 || requests[1].Language != "zh-TW" {
+		t.Errorf("expected the interview language to be passed to generation, got %v", requests)
+	}
+	want := []QuestionDetailDTO{
+		{Text: "[MOCK] Test question 1", Category: "technical", Difficulty: "medium", Source: data.QuestionSourceAI},
+		{Text: "[MOCK] Test question 2", Category: "behavioral", Difficulty: "medium", Source: data.QuestionSourceAI},
+	}
+	if got := details(generated.ID); !reflect.DeepEqual(got, want) {
+		t.Errorf("expected generated question details %v, got %v", want, got)
+	}
+
+	// Manual and built-in questions are recorded with their source
+	manual := create(CreateInterviewRequestDTO{CandidateName: "Manual", Questions: []string{"Why Go?"}, InterviewType: "general"})
+	if got := details(manual.ID); !reflect.DeepEqual(got, []QuestionDetailDTO{{Text: "Why Go?", Source: data.QuestionSourceManual}}) {
+		t.Errorf("expected a manual question, got %v", got)
+	}
+	bank := create(CreateInterviewRequestDTO{CandidateName: "Bank", InterviewType: "behavioral", UseDefaultQuestions: true})
+	if got := details(bank.ID); len(got) != len(bank.Questions) || got[0].Source != data.QuestionSourceBank || got[0].Category != "behavioral" {
+		t.Errorf("expected built-in behavioral questions, got %v", got)
+	}
+
+	// Clones keep the details
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("POST", "/api/interviews/"+generated.ID+"/clone", strings.NewReader(`{"candidate_name":"Clone"}`)))
+	var clone InterviewResponseDTO
+	if err := json.Unmarshal(w.Body.Bytes(), &clone); err != nil {
+		t.Fatalf("failed to unmarshal clone: %v", err)
+	}
+	if got := details(clone.ID); !reflect.DeepEqual(got, want) {
+		t.Errorf("expected the clone to keep the question details, got %v", got)
+	}
+
+	assertErrorResponse(t, router, "POST", "/api/interviews", `{"candidate_name":"Too Many","interview_type":"general","generate_questions":true,"num_questions":1000}`, http.StatusBadRequest, ErrCodeValidationFailed)
+}
+
+func TestGetDefaultQuestionsHandler(t *testing.T) {
+	router := setupTestRouter()
+
+	get := func(query string) DefaultQuestionsResponseDTO {
+		t.Helper()
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("GET", "/api/questions/defaults"+query, nil))
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+		}
+		var resp DefaultQuestionsResponseDTO
+		if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("failed to unmarshal default questions: %v", err)
+		}
+		return resp
+	}
+
+	resp := get("?type=behavioral&language=zh-TW")
+	if resp.InterviewType != "behavioral" || resp.Language != "zh-TW" || resp.Warning != "" {
+		t.Errorf("expected the zh-TW behavioral set, got %+v", resp)
+	}
+	if !reflect.DeepEqual(resp.Questions, data.GetDefaultQuestionSet("behavioral", "zh-TW").Questions) {
+		t.Errorf("unexpected questions %v", resp.Questions)
+	}
+
+	if resp := get(""); resp.InterviewType != "general" || resp.Language != "en" || resp.Warning != "" || len(resp.Questions) == 0 {
+		t.Errorf("expected the English general set by default, got %+v", resp)
+	}
+
+	// Unknown combinations fall back with a warning
+	resp = get("?type=technical&language=fr")
+	if resp.InterviewType != "technical" || resp.Language != "en" || !strings.Contains(resp.Warning, `"fr"`) {
+		t.Errorf("expected the English technical set with a warning, got %+v", resp)
+	}
+}
+
 func TestListInterviewsHandler_Empty(t *testing.T) {
-	clearMemoryStore() // Clear store for test isolation
 	router := setupTestRouter()
 	req := httptest.NewRequest("GET", "/api/interviews", nil)
 	w := httptest.NewRecorder()
@@ -250,19 +640,12 @@ func TestListInterviewsHandler_Empty(t *testing.T) {
 }
 
 func TestListInterviewsHandler_WithData(t *testing.T) {
-	clearMemoryStore()
 	router := setupTestRouter()
 
 	// Create multiple test interviews using helper
-	interviews := []CreateInterviewRequestDTO{
-		{CandidateName: "Alice Johnson", Questions: []string{"Q1", "Q2"}, InterviewType: "general"},
-		{CandidateName: "Bob Smith", Questions: []string{"Q3", "Q4"}, InterviewType: "technical"},
-		{CandidateName: "Charlie Brown", Questions: []string{"Q5", "Q6"}, InterviewType: "behavioral"},
-	}
-
-	for _, interview := range interviews {
-		createTestInterview(t, router, interview)
-	}
+	createTestInterview(t, router, testsupport.NewInterviewBuilder().WithCandidate("Alice Johnson").WithQuestions(2))
+	createTestInterview(t, router, testsupport.NewInterviewBuilder().WithCandidate("Bob Smith").WithType("technical"))
+	createTestInterview(t, router, testsupport.NewInterviewBuilder().WithCandidate("Charlie Brown").WithType("behavioral"))
 
 	// Test listing all interviews
 	req := httptest.NewRequest("GET", "/api/interviews", nil)
@@ -286,24 +669,86 @@ func TestListInterviewsHandler_WithData(t *testing.T) {
 	}
 }
 
+func TestListInterviewsByCandidateHandler(t *testing.T) {
+	router := setupTestRouter()
+
+	// Seed three candidates with 1-3 interviews each, varying name case and whitespace
+	now := time.Now()
+	seed := []struct {
+		id   string
+		name string
+		age  time.Duration
+	}{
+		{"dana-1", "Dana Lee", 5 * time.Hour},
+		{"dana-2", "  dana lee", 4 * time.Hour},
+		{"dana-3", "DANA LEE ", 3 * time.Hour},
+		{"eli-1", "Eli", 2 * time.Hour},
+		{"eli-2", "eli", 1 * time.Hour},
+		{"fay-1", "Fay", 6 * time.Hour},
+	}
+	for _, s := range seed {
+		testsupport.NewInterviewBuilder().WithID(s.id).WithCandidate(s.name).WithCreatedAt(now.Add(-s.age)).Create(t, router.store)
+	}
+	if err := router.store.CreateEvaluation(&data.Evaluation{ID: "eval-fay", InterviewID: "fay-1", Score: 0.7, CreatedAt: now}); err != nil {
+		t.Fatalf("failed to seed evaluation: %v", err)
+	}
+
+	tests := []struct {
+		name           string
+		query          string
+		expectedStatus int
+		expectedNames  []string
+		expectedCounts []int
+		expectedPages  int
+	}{
+		{"all candidates by activity", "", http.StatusOK, []string{"eli", "DANA LEE", "Fay"}, []int{2, 3, 1}, 1},
+		{"sorted by score", "?sort_by=score", http.StatusOK, []string{"Fay", "eli", "DANA LEE"}, []int{1, 2, 3}, 1},
+		{"first page", "?limit=2", http.StatusOK, []string{"eli", "DANA LEE"}, []int{2, 3}, 2},
+		{"last page", "?limit=2&offset=2", http.StatusOK, []string{"Fay"}, []int{1}, 2},
+		{"past the end", "?limit=2&offset=3", http.StatusOK, []string{}, []int{}, 2},
+		{"invalid sort", "?sort_by=name", http.StatusBadRequest, nil, nil, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "/api/interviews/by-candidate"+tt.query, nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			if w.Code != tt.expectedStatus {
+				t.Fatalf("expected status %d, got %d: %s", tt.expectedStatus, w.Code, w.Body.String())
+			}
+			if tt.expectedStatus != http.StatusOK {
+				return
+			}
+
+			var resp ListCandidateGroupsResponseDTO
+			if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if resp.Total != 3 || resp.TotalPages != tt.expectedPages {
+				t.Errorf("expected 3 candidates over %d pages, got %d over %d", tt.expectedPages, resp.Total, resp.TotalPages)
+			}
+			names := make([]string, len(resp.Candidates))
+			counts := make([]int, len(resp.Candidates))
+			for i, group := range resp.Candidates {
+				names[i] = group.CandidateName
+				counts[i] = group.InterviewCount
+				if len(group.Interviews) != group.InterviewCount {
+					t.Errorf("%s: interview_count %d does not match %d summaries", group.CandidateName, group.InterviewCount, len(group.Interviews))
+				}
+			}
+			if !reflect.DeepEqual(names, tt.expectedNames) || !reflect.DeepEqual(counts, tt.expectedCounts) {
+				t.Errorf("expected %v with counts %v, got %v with counts %v", tt.expectedNames, tt.expectedCounts, names, counts)
+			}
+		})
+	}
+}
+
 func TestListInterviewsHandler_Pagination(t *testing.T) {
-	clearMemoryStore() // Clear store for test isolation
 	router := setupTestRouter()
 
 	// Create 5 test interviews
 	for i := 1; i <= 5; i++ {
-		interview := CreateInterviewRequestDTO{
-			CandidateName: fmt.Sprintf("Candidate %d", i),
-			Questions:     []string{"Q1", "Q2"},
-			InterviewType: "general", // Add required interview type
-		}
-		b, _ := json.Marshal(interview)
-		req := httptest.NewRequest("POST", "/api/interviews", bytes.NewReader(b))
-		w := httptest.NewRecorder()
-		router.ServeHTTP(w, req)
-		if w.Code != http.StatusCreated {
-			t.Fatalf("failed to create interview %d, got %d", i, w.Code)
-		}
+		createTestInterview(t, router, testsupport.NewInterviewBuilder().WithCandidate(fmt.Sprintf("Candidate %d", i)).WithQuestions(2))
 	}
 
 	// Test pagination with limit=2
@@ -346,26 +791,112 @@ func TestListInterviewsHandler_Pagination(t *testing.T) {
 	}
 }
 
-func TestListInterviewsHandler_Filtering(t *testing.T) {
-	clearMemoryStore() // Clear store for test isolation
-	router := setupTestRouter()
-
-	// Create test interviews with different names
-	interviews := []CreateInterviewRequestDTO{
-		{CandidateName: "Alice Johnson", Questions: []string{"Q1", "Q2"}, InterviewType: "general"},
-		{CandidateName: "Bob Alice", Questions: []string{"Q3", "Q4"}, InterviewType: "technical"},
-		{CandidateName: "Charlie Brown", Questions: []string{"Q5", "Q6"}, InterviewType: "behavioral"},
+func TestListInterviewsHandler_DefaultPageSizeAboveMax(t *testing.T) {
+	router := setupTestRouterWithProvider(ai.NewMockProvider(), func(deps *HandlerDependencies) {
+		deps.DefaultPageSize = 5
+		deps.MaxPageSize = 2
+	})
+	for i := 1; i <= 3; i++ {
+		createTestInterview(t, router, testsupport.NewInterviewBuilder().WithCandidate(fmt.Sprintf("Candidate %d", i)))
 	}
 
-	for _, interview := range interviews {
-		b, _ := json.Marshal(interview)
-		req := httptest.NewRequest("POST", "/api/interviews", bytes.NewReader(b))
+	for _, query := range []string{"", "?limit=0"} {
 		w := httptest.NewRecorder()
-		router.ServeHTTP(w, req)
-		if w.Code != http.StatusCreated {
-			t.Fatalf("failed to create interview for %s, got %d", interview.CandidateName, w.Code)
+		router.ServeHTTP(w, httptest.NewRequest("GET", "/api/interviews"+query, nil))
+		var resp ListInterviewsResponseDTO
+		if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
 		}
+		if resp.Limit != 2 || len(resp.Interviews) != 2 {
+			t.Errorf("%q: expected the default page size clamped to 2, got limit %d with %d interviews", query, resp.Limit, len(resp.Interviews))
+		}
+	}
+}
+
+func TestListInterviewsHandler_PageSizeLimits(t *testing.T) {
+	router := setupTestRouterWithProvider(ai.NewMockProvider(), func(deps *HandlerDependencies) {
+		deps.DefaultPageSize = 2
+		deps.MaxPageSize = 3
+	})
+	for i := 1; i <= 5; i++ {
+		createTestInterview(t, router, testsupport.NewInterviewBuilder().WithCandidate(fmt.Sprintf("Candidate %d", i)))
+	}
+
+	tests := []struct {
+		name             string
+		query            string
+		expectedCount    int
+		expectedLimit    int
+		expectedOffset   int
+		expectedPage     int
+		expectedWarnings int
+	}{
+		{"default page size", "", 2, 2, 0, 1, 0},
+		{"zero limit uses default", "?limit=0", 2, 2, 0, 1, 0},
+		{"clamped at max warns", "?limit=1000000", 3, 3, 0, 1, 1},
+		{"page computes offset", "?limit=2&page=3", 1, 2, 4, 3, 0},
+		{"offset computes page", "?limit=2&offset=2", 2, 2, 2, 2, 0},
+		{"negative limit warns", "?limit=-5", 2, 2, 0, 1, 1},
+		{"non-numeric limit warns", "?limit=abc", 2, 2, 0, 1, 1},
+		{"multiple invalid params warn", "?limit=x&offset=-1&page=y", 2, 2, 0, 1, 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "/api/interviews"+tt.query, nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			if w.Code != http.StatusOK {
+				t.Fatalf("expected 200 OK, got %d", w.Code)
+			}
+
+			var resp ListInterviewsResponseDTO
+			if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if len(resp.Interviews) != tt.expectedCount {
+				t.Errorf("expected %d interviews, got %d", tt.expectedCount, len(resp.Interviews))
+			}
+			if resp.Limit != tt.expectedLimit || resp.Offset != tt.expectedOffset || resp.Page != tt.expectedPage {
+				t.Errorf("expected limit/offset/page %d/%d/%d, got %d/%d/%d",
+					tt.expectedLimit, tt.expectedOffset, tt.expectedPage, resp.Limit, resp.Offset, resp.Page)
+			}
+			if len(resp.Warnings) != tt.expectedWarnings {
+				t.Errorf("expected %d warnings, got %v", tt.expectedWarnings, resp.Warnings)
+			}
+			if resp.Total != 5 {
+				t.Errorf("expected total count of 5, got %d", resp.Total)
+			}
+		})
+	}
+}
+
+func TestListInterviewsHandler_WarningNamesParameter(t *testing.T) {
+	router := setupTestRouter()
+
+	req := httptest.NewRequest("GET", "/api/interviews?limit=lots", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	var resp ListInterviewsResponseDTO
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(resp.Warnings) != 1 || resp.Warnings[0].Code != WarnCodeInvalidParameter || resp.Warnings[0].Field != "limit" || !strings.Contains(resp.Warnings[0].Message, "lots") {
+		t.Errorf("expected warning naming the ignored limit parameter, got %v", resp.Warnings)
 	}
+	if resp.Limit != 10 || resp.TotalPages != 1 {
+		t.Errorf("expected default limit 10 and 1 total page, got %d and %d", resp.Limit, resp.TotalPages)
+	}
+}
+
+func TestListInterviewsHandler_Filtering(t *testing.T) {
+	router := setupTestRouter()
+
+	// Create test interviews with different names
+	createTestInterview(t, router, testsupport.NewInterviewBuilder().WithCandidate("Alice Johnson"))
+	createTestInterview(t, router, testsupport.NewInterviewBuilder().WithCandidate("Bob Alice").WithType("technical"))
+	createTestInterview(t, router, testsupport.NewInterviewBuilder().WithCandidate("Charlie Brown").WithType("behavioral"))
 
 	// Test filtering by candidate name
 	req := httptest.NewRequest("GET", "/api/interviews?candidate_name=Alice", nil)
@@ -395,35 +926,49 @@ func TestListInterviewsHandler_Filtering(t *testing.T) {
 	}
 }
 
-func TestListInterviewsHandler_Sorting(t *testing.T) {
-	clearMemoryStore() // Clear store for test isolation
+func TestListInterviewsHandler_FilterMatchesNormalizedName(t *testing.T) {
 	router := setupTestRouter()
 
-	// Create test interviews in a specific order
-	interviews := []CreateInterviewRequestDTO{
-		{CandidateName: "Charlie Brown", Questions: []string{"Q1", "Q2"}, InterviewType: "general"},
-		{CandidateName: "Alice Johnson", Questions: []string{"Q3", "Q4"}, InterviewType: "technical"},
-		{CandidateName: "Bob Smith", Questions: []string{"Q5", "Q6"}, InterviewType: "behavioral"},
+	created := createTestInterview(t, router, testsupport.NewInterviewBuilder().WithCandidate("  Mary   Ann\tSmith "))
+	if created.CandidateName != "Mary Ann Smith" {
+		t.Errorf("expected the stored display name to be trimmed and collapsed, got %q", created.CandidateName)
 	}
+	createTestInterview(t, router, testsupport.NewInterviewBuilder().WithCandidate("Annabel Lee"))
 
-	for _, interview := range interviews {
-		b, _ := json.Marshal(interview)
-		req := httptest.NewRequest("POST", "/api/interviews", bytes.NewReader(b))
+	for filter, expected := range map[string]int{"MARY ann": 1, "ann  smith": 1, "ANN": 2, "mary-ann": 0} {
+		req := httptest.NewRequest("GET", "/api/interviews?candidate_name="+url.QueryEscape(filter), nil)
 		w := httptest.NewRecorder()
 		router.ServeHTTP(w, req)
-		if w.Code != http.StatusCreated {
-			t.Fatalf("failed to create interview for %s, got %d", interview.CandidateName, w.Code)
+		var resp ListInterviewsResponseDTO
+		if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if resp.Total != expected {
+			t.Errorf("filter %q: expected %d interviews, got %d", filter, expected, resp.Total)
 		}
-		// Add small delay to ensure different creation times
-		time.Sleep(1 * time.Millisecond)
 	}
+}
 
-	// Test sorting by name ascending
-	req := httptest.NewRequest("GET", "/api/interviews?sort_by=name&sort_order=asc", nil)
-	w := httptest.NewRecorder()
-	router.ServeHTTP(w, req)
-	if w.Code != http.StatusOK {
-		t.Errorf("expected 200 OK, got %d", w.Code)
+func TestListInterviewsHandler_Sorting(t *testing.T) {
+	router := setupTestRouter()
+	// Distinct creation times without sleeping between creates
+	router.store.SetClock(testsupport.SteppingClock(time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC), time.Second))
+
+	// Create test interviews in a specific order
+	for _, interview := range []*testsupport.InterviewBuilder{
+		testsupport.NewInterviewBuilder().WithCandidate("Charlie Brown"),
+		testsupport.NewInterviewBuilder().WithCandidate("Alice Johnson").WithType("technical"),
+		testsupport.NewInterviewBuilder().WithCandidate("Bob Smith").WithType("behavioral"),
+	} {
+		createTestInterview(t, router, interview)
+	}
+
+	// Test sorting by name ascending
+	req := httptest.NewRequest("GET", "/api/interviews?sort_by=name&sort_order=asc", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Errorf("expected 200 OK, got %d", w.Code)
 	}
 
 	var resp ListInterviewsResponseDTO
@@ -442,6 +987,23 @@ func TestListInterviewsHandler_Sorting(t *testing.T) {
 			t.Errorf("expected interview %d to be %s, got %s", i, expectedOrder[i], interview.CandidateName)
 		}
 	}
+
+	// Newest first by default, ordered by the store's creation timestamps
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/api/interviews", nil))
+	resp = ListInterviewsResponseDTO{}
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	expectedOrder = []string{"Bob Smith", "Alice Johnson", "Charlie Brown"}
+	for i, interview := range resp.Interviews {
+		if interview.CandidateName != expectedOrder[i] {
+			t.Errorf("expected interview %d by creation to be %s, got %s", i, expectedOrder[i], interview.CandidateName)
+		}
+	}
+	if first := resp.Interviews[len(resp.Interviews)-1]; !first.CreatedAt.Equal(time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC)) {
+		t.Errorf("expected the first interview to be stamped by the store clock, got %v", first.CreatedAt)
+	}
 }
 
 func TestGetInterviewHandler_BadRequest(t *testing.T) {
@@ -455,26 +1017,10 @@ func TestGetInterviewHandler_BadRequest(t *testing.T) {
 }
 
 func TestGetInterviewHandler_Success(t *testing.T) {
-	clearMemoryStore() // Clear store for test isolation
 	router := setupTestRouter()
 
 	// Step 1: Create an interview
-	createBody := CreateInterviewRequestDTO{
-		CandidateName: "Test User",
-		Questions:     []string{"Q1", "Q2"},
-		InterviewType: "general", // Add required interview type
-	}
-	b, _ := json.Marshal(createBody)
-	createReq := httptest.NewRequest("POST", "/api/interviews", bytes.NewReader(b))
-	createW := httptest.NewRecorder()
-	router.ServeHTTP(createW, createReq)
-	if createW.Code != http.StatusCreated {
-		t.Fatalf("failed to create interview, got %d", createW.Code)
-	}
-	var createdResp InterviewResponseDTO
-	if err := json.Unmarshal(createW.Body.Bytes(), &createdResp); err != nil {
-		t.Fatalf("failed to decode create response: %v", err)
-	}
+	createdResp := createTestInterview(t, router, testsupport.NewInterviewBuilder().WithCandidate("Test User").WithQuestions(2))
 
 	// Step 2: Use the real ID for GET
 	req := httptest.NewRequest("GET", "/api/interviews/"+createdResp.ID, nil)
@@ -486,17 +1032,13 @@ func TestGetInterviewHandler_Success(t *testing.T) {
 }
 
 func TestSubmitEvaluationHandler_Success(t *testing.T) {
-	clearMemoryStore() // Clear store for test isolation
+	router := setupTestRouter()
 	// First create a valid interview
-	interview := &data.Interview{
-		ID:            "test-interview-123",
-		CandidateName: "Test Candidate",
-		Questions:     []string{"What is your experience?", "Tell me about yourself"}, CreatedAt: time.Now(),
-		UpdatedAt: time.Now(),
-	}
-	if err := data.GlobalStore.CreateInterview(interview); err != nil {
-		t.Fatalf("failed to create interview: %v", err)
-	}
+	testsupport.NewInterviewBuilder().
+		WithID("test-interview-123").
+		WithQuestionTexts("What is your experience?", "Tell me about yourself").
+		WithCreatedAt(time.Now()).
+		Create(t, router.store)
 
 	body := SubmitEvaluationRequestDTO{
 		InterviewID: "test-interview-123",
@@ -504,7 +1046,6 @@ func TestSubmitEvaluationHandler_Success(t *testing.T) {
 	}
 	b, _ := json.Marshal(body)
 
-	router := setupTestRouter()
 	req := httptest.NewRequest("POST", "/api/evaluation", bytes.NewReader(b))
 	w := httptest.NewRecorder()
 	router.ServeHTTP(w, req)
@@ -535,6 +1076,388 @@ func TestSubmitEvaluationHandler_BadRequest(t *testing.T) {
 	}
 }
 
+func TestSubmitEvaluationHandler_Uniqueness(t *testing.T) {
+	provider := ai.NewMockProvider()
+	router := setupTestRouterWithProvider(provider, nil)
+	interview := createTestInterview(t, router, testsupport.NewInterviewBuilder().WithCandidate("Repeat Candidate"))
+
+	// First submission creates the authoritative evaluation
+	w := submitEvaluation(t, router, "", interview.ID, "First answer")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200 OK for first submission, got %d: %s", w.Code, w.Body.String())
+	}
+	var first EvaluationResponseDTO
+	if err := json.Unmarshal(w.Body.Bytes(), &first); err != nil {
+		t.Fatalf("failed to decode evaluation: %v", err)
+	}
+	if first.SupersedesID != "" {
+		t.Errorf("expected first evaluation to supersede nothing, got %q", first.SupersedesID)
+	}
+
+	// A second submission is rejected with the existing ID and never reaches the provider
+	w = submitEvaluation(t, router, "", interview.ID, "Second answer")
+	if w.Code != http.StatusConflict {
+		t.Fatalf("expected 409 Conflict for duplicate submission, got %d: %s", w.Code, w.Body.String())
+	}
+	var conflict EvaluationConflictResponseDTO
+	if err := json.Unmarshal(w.Body.Bytes(), &conflict); err != nil {
+		t.Fatalf("failed to decode conflict response: %v", err)
+	}
+	if conflict.Code != ErrCodeConflict || conflict.ExistingEvaluationID != first.ID {
+		t.Errorf("expected conflict code with existing ID %q, got %q/%q", first.ID, conflict.Code, conflict.ExistingEvaluationID)
+	}
+	if n := len(provider.EvaluationRequests()); n != 1 {
+		t.Errorf("expected 1 evaluation request to the provider, got %d", n)
+	}
+
+	// ?replace=true supersedes the current evaluation
+	w = submitEvaluation(t, router, "?replace=true", interview.ID, "Replacement answer")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200 OK for replacement, got %d: %s", w.Code, w.Body.String())
+	}
+	var replacement EvaluationResponseDTO
+	if err := json.Unmarshal(w.Body.Bytes(), &replacement); err != nil {
+		t.Fatalf("failed to decode evaluation: %v", err)
+	}
+	if replacement.SupersedesID != first.ID {
+		t.Errorf("expected replacement to supersede %q, got %q", first.ID, replacement.SupersedesID)
+	}
+	latest, err := router.store.GetLatestEvaluationByInterview(interview.ID)
+	if err != nil || latest.ID != replacement.ID {
+		t.Errorf("expected latest evaluation %q, got %v (err %v)", replacement.ID, latest, err)
+	}
+
+	// The superseded evaluation is still retrievable by its ID
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/api/evaluation/"+first.ID, nil))
+	if w.Code != http.StatusOK {
+		t.Errorf("expected 200 OK for superseded evaluation, got %d: %s", w.Code, w.Body.String())
+	}
+
+	// Further submissions conflict with the replacement, not the original
+	w = submitEvaluation(t, router, "", interview.ID, "Third answer")
+	if err := json.Unmarshal(w.Body.Bytes(), &conflict); err != nil {
+		t.Fatalf("failed to decode conflict response: %v", err)
+	}
+	if w.Code != http.StatusConflict || conflict.ExistingEvaluationID != replacement.ID {
+		t.Errorf("expected 409 with existing ID %q, got %d/%q", replacement.ID, w.Code, conflict.ExistingEvaluationID)
+	}
+}
+
+func TestEndChatSessionHandler_Uniqueness(t *testing.T) {
+	provider := ai.NewMockProvider()
+	router := setupTestRouterWithProvider(provider, nil)
+	interview := createTestInterview(t, router, testsupport.NewInterviewBuilder().WithCandidate("Repeat Chat Candidate"))
+
+	// Another evaluation of the interview doesn't stop a session from being evaluated
+	w := submitEvaluation(t, router, "", interview.ID, "Form answer")
+	var first EvaluationResponseDTO
+	if err := json.Unmarshal(w.Body.Bytes(), &first); err != nil {
+		t.Fatalf("failed to decode evaluation: %v", err)
+	}
+	session := startChatSession(t, router, testsupport.NewSessionBuilder().ForInterviewID(interview.ID))
+	sendMessage(t, router, session.ID, "Chat answer")
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("POST", "/api/chat/"+session.ID+"/end", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200 OK, got %d: %s", w.Code, w.Body.String())
+	}
+	var evaluation EvaluationResponseDTO
+	if err := json.Unmarshal(w.Body.Bytes(), &evaluation); err != nil {
+		t.Fatalf("failed to decode evaluation: %v", err)
+	}
+	if evaluation.ID == first.ID || evaluation.SupersedesID != "" {
+		t.Errorf("expected a new evaluation superseding nothing, got %+v", evaluation)
+	}
+
+	// Ending the completed session again is rejected before reaching the provider
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("POST", "/api/chat/"+session.ID+"/end", nil))
+	var conflict EvaluationConflictResponseDTO
+	if err := json.Unmarshal(w.Body.Bytes(), &conflict); err != nil {
+		t.Fatalf("failed to decode conflict response: %v", err)
+	}
+	if w.Code != http.StatusConflict || conflict.ExistingEvaluationID != evaluation.ID {
+		t.Errorf("expected 409 with existing ID %q, got %d: %s", evaluation.ID, w.Code, w.Body.String())
+	}
+	if n := len(provider.EvaluationRequests()); n != 2 {
+		t.Errorf("expected 2 evaluation requests to the provider, got %d", n)
+	}
+
+	// A second session of the same interview is evaluated on its own
+	second := startChatSession(t, router, testsupport.NewSessionBuilder().ForInterviewID(interview.ID))
+	sendMessage(t, router, second.ID, "Second round answer")
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("POST", "/api/chat/"+second.ID+"/end", nil))
+	if w.Code != http.StatusOK {
+		t.Errorf("expected 200 OK for the second session, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestWrapUpChatSessionHandler(t *testing.T) {
+	provider := ai.NewScriptedMockProvider(
+		"Welcome! Tell me about yourself?",
+		"Thanks. What are you working on now?",
+		"We're out of time. Thank you, it was great talking to you!",
+	)
+	router := setupTestRouterWithProvider(provider, nil)
+	interview := createTestInterview(t, router, testsupport.NewInterviewBuilder().WithCandidate("Wrap Up Candidate").WithQuestions(3))
+	session := startChatSession(t, router, testsupport.NewSessionBuilder().ForInterviewID(interview.ID))
+	sendMessage(t, router, session.ID, "I build APIs")
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("POST", "/api/chat/"+session.ID+"/wrap-up", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200 OK, got %d: %s", w.Code, w.Body.String())
+	}
+	var resp WrapUpResponseDTO
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to decode wrap-up response: %v", err)
+	}
+	if resp.ClosingMessage.Content != "We're out of time. Thank you, it was great talking to you!" || resp.ClosingMessage.Subtype != data.MessageSubtypeClosing {
+		t.Errorf("expected the scripted closing message, got %+v", resp.ClosingMessage)
+	}
+	if resp.Evaluation.ID == "" || resp.Evaluation.InterviewID != interview.ID {
+		t.Errorf("expected an evaluation for the interview, got %+v", resp.Evaluation)
+	}
+
+	// The closing message was stored as the final message before the evaluation ran
+	stored := getChatSession(t, router, session.ID, "")
+	if stored.Status != "completed" {
+		t.Errorf("expected the session to be completed, got %q", stored.Status)
+	}
+	last := stored.Messages[len(stored.Messages)-1]
+	if last.ID != resp.ClosingMessage.ID {
+		t.Errorf("expected the closing message to be the final message, got %+v", last)
+	}
+	if len(provider.ChatRequests()) != 3 || len(provider.EvaluationRequests()) != 1 {
+		t.Errorf("expected 3 chat calls then 1 evaluation, got %d and %d", len(provider.ChatRequests()), len(provider.EvaluationRequests()))
+	}
+	if answers := provider.EvaluationRequests()[0].Answers; len(answers) != 1 || answers[0] != "I build APIs" {
+		t.Errorf("expected the transcript answers to be evaluated, got %v", answers)
+	}
+
+	// Only active sessions can be wrapped up
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("POST", "/api/chat/"+session.ID+"/wrap-up?replace=true", nil))
+	if w.Code != http.StatusConflict {
+		t.Errorf("expected 409 for a completed session, got %d: %s", w.Code, w.Body.String())
+	}
+	if len(provider.ChatRequests()) != 3 {
+		t.Errorf("expected no provider call for a rejected wrap-up, got %d chat calls", len(provider.ChatRequests()))
+	}
+
+	expectHTTPError(t, router, "POST", "/api/chat/missing-session/wrap-up", nil, http.StatusNotFound)
+}
+
+func TestSubmitEvaluationHandler_ResolvesQuestions(t *testing.T) {
+	router := setupTestRouter()
+	interview := createTestInterview(t, router, testsupport.NewInterviewBuilder().
+		WithCandidate("Resolution Candidate").
+		WithQuestionTexts("What is Go?", "Why channels?", "Favorite tool?"))
+
+	body, _ := json.Marshal(SubmitEvaluationRequestDTO{
+		InterviewID: interview.ID,
+		Answers:     map[string]string{"question_0": "A language", "question_2": "Delve"},
+	})
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("POST", "/api/evaluation", bytes.NewReader(body)))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200 OK, got %d: %s", w.Code, w.Body.String())
+	}
+	var evaluation EvaluationResponseDTO
+	if err := json.Unmarshal(w.Body.Bytes(), &evaluation); err != nil {
+		t.Fatalf("failed to decode evaluation: %v", err)
+	}
+
+	// answers_v2 lists every question in order, including unanswered ones
+	expected := []AnswerDTO{
+		{Question: "What is Go?", Answer: "A language"},
+		{Question: "Why channels?", Answer: ""},
+		{Question: "Favorite tool?", Answer: "Delve"},
+	}
+	if !reflect.DeepEqual(evaluation.AnswersV2, expected) {
+		t.Errorf("expected answers_v2 %v, got %v", expected, evaluation.AnswersV2)
+	}
+	// The original keyed map is still returned for existing clients
+	if evaluation.Answers["question_2"] != "Delve" || len(evaluation.Answers) != 2 {
+		t.Errorf("expected back-compat answers map to be unchanged, got %v", evaluation.Answers)
+	}
+
+	// The snapshot is persisted, so GET resolves the same questions
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/api/evaluation/"+evaluation.ID, nil))
+	var stored EvaluationResponseDTO
+	if err := json.Unmarshal(w.Body.Bytes(), &stored); err != nil {
+		t.Fatalf("failed to decode stored evaluation: %v", err)
+	}
+	if !reflect.DeepEqual(stored.AnswersV2, expected) {
+		t.Errorf("expected stored answers_v2 %v, got %v", expected, stored.AnswersV2)
+	}
+}
+
+func TestEvaluation_QuestionsSnapshot(t *testing.T) {
+	router := setupTestRouter()
+	interview := createTestInterview(t, router, testsupport.NewInterviewBuilder().
+		WithCandidate("Snapshot Candidate").
+		WithQuestionTexts("Original Q1", "Original Q2"))
+
+	w := submitEvaluation(t, router, "", interview.ID, "My answer")
+	var evaluation EvaluationResponseDTO
+	if err := json.Unmarshal(w.Body.Bytes(), &evaluation); err != nil {
+		t.Fatalf("failed to decode evaluation: %v", err)
+	}
+	original := []string{"Original Q1", "Original Q2"}
+	if !reflect.DeepEqual(evaluation.QuestionsSnapshot, original) {
+		t.Errorf("expected questions snapshot %v, got %v", original, evaluation.QuestionsSnapshot)
+	}
+
+	// Edit the interview's questions in place after the evaluation was created
+	stored, err := router.store.GetInterview(interview.ID)
+	if err != nil {
+		t.Fatalf("failed to get interview: %v", err)
+	}
+	stored.Questions[0] = "Edited Q1"
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/api/evaluation/"+evaluation.ID, nil))
+	var reread EvaluationResponseDTO
+	if err := json.Unmarshal(w.Body.Bytes(), &reread); err != nil {
+		t.Fatalf("failed to decode evaluation: %v", err)
+	}
+	if !reflect.DeepEqual(reread.QuestionsSnapshot, original) {
+		t.Errorf("expected snapshot to survive interview edit, got %v", reread.QuestionsSnapshot)
+	}
+	if len(reread.AnswersV2) != 2 || reread.AnswersV2[0].Question != "Original Q1" || reread.AnswersV2[0].Answer != "My answer" {
+		t.Errorf("expected rendered answers to use the snapshot, got %v", reread.AnswersV2)
+	}
+}
+
+func TestEndChatSessionHandler_QuestionsSnapshot(t *testing.T) {
+	router := setupTestRouter()
+	ids := createTestInterviewAndSession(t, router)
+	sendMessage(t, router, ids.SessionID, "My answer")
+
+	session, err := router.store.GetChatSession(ids.SessionID)
+	if err != nil {
+		t.Fatalf("failed to get session: %v", err)
+	}
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("POST", "/api/chat/"+ids.SessionID+"/end", nil))
+	var evaluation EvaluationResponseDTO
+	if err := json.Unmarshal(w.Body.Bytes(), &evaluation); err != nil {
+		t.Fatalf("failed to decode evaluation: %v", err)
+	}
+
+	// Chat evaluations snapshot the questions the AI actually asked, not the interview's list
+	if len(evaluation.QuestionsSnapshot) != 1 || evaluation.QuestionsSnapshot[0] != session.AskedQuestions[0] {
+		t.Errorf("expected snapshot of asked question %q, got %v", session.AskedQuestions[0], evaluation.QuestionsSnapshot)
+	}
+}
+
+func TestEndChatSessionHandler_LanguageMismatch(t *testing.T) {
+	tests := []struct {
+		name     string
+		answer   string
+		mismatch bool
+	}{
+		{"english answers", "A goroutine is a lightweight thread managed by the Go runtime.", true},
+		{"chinese answers", "協程是由 Go 執行環境管理的輕量級執行緒。", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := ai.NewScriptedMockProvider("歡迎！什麼是協程？", "謝謝。什麼是通道？")
+			router := setupTestRouterWithProvider(provider, nil)
+			adaptive := false
+			interview := createTestInterview(t, router, testsupport.NewInterviewBuilder().
+				WithCandidate("Language Candidate").
+				WithQuestionTexts("什麼是協程？", "什麼是通道？").
+				WithType("technical").
+				WithLanguage("zh-TW").
+				WithAdaptive(adaptive))
+			session := startChatSession(t, router, testsupport.NewSessionBuilder().ForInterviewID(interview.ID))
+			sendMessage(t, router, session.ID, tt.answer)
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest("POST", "/api/chat/"+session.ID+"/end", nil))
+			if w.Code != http.StatusOK {
+				t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+			}
+			var evaluation EvaluationResponseDTO
+			if err := json.Unmarshal(w.Body.Bytes(), &evaluation); err != nil {
+				t.Fatalf("failed to decode evaluation: %v", err)
+			}
+			if evaluation.LanguageMismatch != tt.mismatch {
+				t.Errorf("expected language_mismatch %v, got %v", tt.mismatch, evaluation.LanguageMismatch)
+			}
+			if warned := len(evaluation.Warnings) == 1 && evaluation.Warnings[0].Code == WarnCodeAnswerLanguageMismatch; warned != tt.mismatch || (!tt.mismatch && len(evaluation.Warnings) != 0) {
+				t.Errorf("expected an answer_language_mismatch warning only on a mismatch, got %v", evaluation.Warnings)
+			}
+			requests := provider.EvaluationRequests()
+			if len(requests) != 1 || requests[0].LanguageMismatch != tt.mismatch {
+				t.Errorf("expected the evaluation request to carry language_mismatch %v", tt.mismatch)
+			}
+			stored, err := router.store.GetEvaluation(evaluation.ID)
+			if err != nil || stored.LanguageMismatch != tt.mismatch {
+				t.Errorf("expected the stored evaluation to carry language_mismatch %v, got %+v (%v)", tt.mismatch, stored, err)
+			}
+		})
+	}
+}
+
+func TestSubmitEvaluationHandler_RejectsUnknownAnswerKeys(t *testing.T) {
+	tests := []struct {
+		name    string
+		answers map[string]string
+		wantKey string
+	}{
+		{"out of range", map[string]string{"question_0": "a", "question_2": "b"}, "question_2"},
+		{"negative index", map[string]string{"question_-1": "a"}, "question_-1"},
+		{"leading zero", map[string]string{"question_01": "a"}, "question_01"},
+		{"unknown format", map[string]string{"answer": "a"}, "answer"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := ai.NewMockProvider()
+			router := setupTestRouterWithProvider(provider, nil)
+			interview := createTestInterview(t, router, testsupport.NewInterviewBuilder().WithCandidate("Key Candidate").WithQuestions(2))
+
+			body, _ := json.Marshal(SubmitEvaluationRequestDTO{InterviewID: interview.ID, Answers: tt.answers})
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest("POST", "/api/evaluation", bytes.NewReader(body)))
+			if w.Code != http.StatusBadRequest {
+				t.Fatalf("expected 400 Bad Request, got %d: %s", w.Code, w.Body.String())
+			}
+			var errResp ErrorResponseDTO
+			if err := json.Unmarshal(w.Body.Bytes(), &errResp); err != nil {
+				t.Fatalf("failed to decode error: %v", err)
+			}
+			if errResp.Code != ErrCodeValidationFailed || !strings.Contains(errResp.Details, tt.wantKey) {
+				t.Errorf("expected validation error naming %q, got %+v", tt.wantKey, errResp)
+			}
+			if n := len(provider.EvaluationRequests()); n != 0 {
+				t.Errorf("expected no evaluation requests to the provider, got %d", n)
+			}
+		})
+	}
+}
+
+// submitEvaluation posts a single-answer evaluation for an interview, with an optional query string
+func submitEvaluation(t *testing.T, router http.Handler, query, interviewID, answer string) *httptest.ResponseRecorder {
+	t.Helper()
+	body, _ := json.Marshal(SubmitEvaluationRequestDTO{
+		InterviewID: interviewID,
+		Answers:     map[string]string{"question_0": answer},
+	})
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("POST", "/api/evaluation"+query, bytes.NewReader(body)))
+	return w
+}
+
 func TestGetEvaluationHandler_BadRequest(t *testing.T) {
 	router := setupTestRouter()
 	req := httptest.NewRequest("GET", "/api/evaluation/", nil)
@@ -546,7 +1469,7 @@ func TestGetEvaluationHandler_BadRequest(t *testing.T) {
 }
 
 func TestGetEvaluationHandler_Success(t *testing.T) {
-	clearMemoryStore() // Clear store for test isolation
+	router := setupTestRouter()
 	// First create a valid evaluation
 	evaluation := &data.Evaluation{
 		ID:          "test-evaluation-456",
@@ -556,11 +1479,10 @@ func TestGetEvaluationHandler_Success(t *testing.T) {
 		Feedback:    "Good performance",
 		CreatedAt:   time.Now(), UpdatedAt: time.Now(),
 	}
-	if err := data.GlobalStore.CreateEvaluation(evaluation); err != nil {
+	if err := router.store.CreateEvaluation(evaluation); err != nil {
 		t.Fatalf("failed to create evaluation: %v", err)
 	}
 
-	router := setupTestRouter()
 	req := httptest.NewRequest("GET", "/api/evaluation/test-evaluation-456", nil)
 	w := httptest.NewRecorder()
 	router.ServeHTTP(w, req)
@@ -574,18 +1496,13 @@ func TestGetEvaluationHandler_Success(t *testing.T) {
 // ============================================
 
 func TestStartChatSessionHandler_Success(t *testing.T) {
-	clearMemoryStore()
 	router := setupTestRouter()
 
 	// Create interview using helper
-	interview := createTestInterview(t, router, CreateInterviewRequestDTO{
-		CandidateName: "Test User",
-		Questions:     []string{"Q1", "Q2"},
-		InterviewType: "general",
-	})
+	interview := createTestInterview(t, router, testsupport.NewInterviewBuilder().WithCandidate("Test User").WithQuestions(2))
 
 	// Start chat session using helper
-	session := startChatSession(t, router, interview.ID, nil)
+	session := startChatSession(t, router, testsupport.NewSessionBuilder().ForInterviewID(interview.ID))
 
 	// Verify response structure
 	if session.ID == "" {
@@ -603,89 +1520,575 @@ func TestStartChatSessionHandler_Success(t *testing.T) {
 }
 
 func TestStartChatSessionHandler_WithLanguage(t *testing.T) {
-	clearMemoryStore()
 	router := setupTestRouter()
 
 	// Create interview with specific language
-	interview := createTestInterview(t, router, CreateInterviewRequestDTO{
-		CandidateName:     "Test User",
-		Questions:         []string{"Q1", "Q2"},
-		InterviewType:     "general",
-		InterviewLanguage: "zh-TW",
-	})
+	interview := createTestInterview(t, router, testsupport.NewInterviewBuilder().
+		WithCandidate("Test User").
+		WithQuestions(2).
+		WithLanguage("zh-TW"))
 	// Start chat session with language override
-	sessionReq := &StartChatSessionRequestDTO{
-		SessionLanguage: "en",
-	}
-	session := startChatSession(t, router, interview.ID, sessionReq)
+	session := startChatSession(t, router, testsupport.NewSessionBuilder().ForInterviewID(interview.ID).WithLanguage("en"))
 	// Should use the overridden language
 	if session.SessionLanguage != "en" {
 		t.Errorf("expected language 'en', got %s", session.SessionLanguage)
 	}
 }
 
-func TestStartChatSessionHandler_InvalidInterview(t *testing.T) {
-	clearMemoryStore()
-	router := setupTestRouter()
-
-	expectHTTPError(t, router, "POST", "/api/interviews/nonexistent/chat/start", nil, http.StatusNotFound)
-}
-
-func TestStartChatSessionHandler_MissingInterviewID(t *testing.T) {
-	clearMemoryStore()
+func TestStartChatSessionHandler_LanguageValidation(t *testing.T) {
 	router := setupTestRouter()
+	interview := createTestInterview(t, router, testsupport.NewInterviewBuilder().WithCandidate("Language Candidate").WithLanguage("zh-TW"))
 
-	expectHTTPError(t, router, "POST", "/api/interviews//chat/start", nil, http.StatusBadRequest)
+	tests := []struct {
+		name             string
+		body             string
+		expectedStatus   int
+		expectedLanguage string
+	}{
+		{"unsupported language rejected", `{"session_language":"fr"}`, http.StatusBadRequest, ""},
+		{"wrong case rejected", `{"session_language":"ZH-tw"}`, http.StatusBadRequest, ""},
+		{"valid override", `{"session_language":"en"}`, http.StatusCreated, "en"},
+		{"empty inherits interview language", `{"session_language":""}`, http.StatusCreated, "zh-TW"},
+		{"absent inherits interview language", `{}`, http.StatusCreated, "zh-TW"},
+		{"no body inherits interview language", "", http.StatusCreated, "zh-TW"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("POST", "/api/interviews/"+interview.ID+"/chat/start", strings.NewReader(tt.body))
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			if w.Code != tt.expectedStatus {
+				t.Fatalf("expected status %d, got %d: %s", tt.expectedStatus, w.Code, w.Body.String())
+			}
+			if tt.expectedStatus != http.StatusCreated {
+				var resp ErrorResponseDTO
+				if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+					t.Fatalf("failed to decode error: %v", err)
+				}
+				if resp.Code != ErrCodeValidationFailed || resp.Error != ErrMsgInvalidLanguage {
+					t.Errorf("expected %s with supported languages, got %s: %q", ErrCodeValidationFailed, resp.Code, resp.Error)
+				}
+				return
+			}
+			var session ChatInterviewSessionDTO
+			if err := json.Unmarshal(w.Body.Bytes(), &session); err != nil {
+				t.Fatalf("failed to decode session: %v", err)
+			}
+			if session.SessionLanguage != tt.expectedLanguage {
+				t.Errorf("expected language %q, got %q", tt.expectedLanguage, session.SessionLanguage)
+			}
+		})
+	}
 }
 
-func TestSendMessageHandler_Success(t *testing.T) {
-	clearMemoryStore()
-	router := setupTestRouter()
+func TestStartChatSessionHandler_ScheduleWindow(t *testing.T) {
+	start := time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC)
+	end := start.Add(time.Hour)
+	var now time.Time
+	router := setupTestRouterWithProvider(ai.NewMockProvider(), func(deps *HandlerDependencies) {
+		deps.ScheduleGracePeriod = 15 * time.Minute
+		deps.now = func() time.Time { return now }
+	})
 
-	interview := createTestInterviewAndSession(t, router)
+	tests := []struct {
+		name           string
+		now            time.Time
+		expectedStatus int
+		expectedCode   ErrorCode
+	}{
+		{"too early", start.Add(-24 * time.Hour), http.StatusForbidden, ErrCodeTooEarly},
+		{"at scheduled start", start, http.StatusCreated, ""},
+		{"in window", start.Add(30 * time.Minute), http.StatusCreated, ""},
+		{"within grace period", end.Add(10 * time.Minute), http.StatusCreated, ""},
+		{"expired", end.Add(16 * time.Minute), http.StatusForbidden, ErrCodeExpired},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			interview := createTestInterview(t, router, testsupport.NewInterviewBuilder().WithCandidate("Scheduled Candidate").WithSchedule(&start, &end))
+			if interview.Status != data.InterviewStatusScheduled {
+				t.Errorf("expected status %q, got %q", data.InterviewStatusScheduled, interview.Status)
+			}
 
-	// Send a message using helper
-	response := sendMessage(t, router, interview.SessionID, "Hello, this is my test message")
+			now = tt.now
+			req := httptest.NewRequest("POST", "/api/interviews/"+interview.ID+"/chat/start", nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			if w.Code != tt.expectedStatus {
+				t.Fatalf("expected status %d, got %d: %s", tt.expectedStatus, w.Code, w.Body.String())
+			}
+			if tt.expectedStatus == http.StatusCreated {
+				stored, _ := router.store.GetInterview(interview.ID)
+				if stored.Status != data.InterviewStatusActive {
+					t.Errorf("expected interview to become active, got %q", stored.Status)
+				}
+				return
+			}
 
-	// Should have user message and AI response
-	if response.Message.Content != "Hello, this is my test message" {
-		t.Errorf("expected user message content to match, got %s", response.Message.Content)
-	}
-	if response.AIResponse == nil {
-		t.Error("expected AI response to be present")
+			var resp ScheduleWindowErrorResponseDTO
+			if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+				t.Fatalf("failed to decode error: %v", err)
+			}
+			if resp.Code != tt.expectedCode {
+				t.Errorf("expected code %s, got %s", tt.expectedCode, resp.Code)
+			}
+			if tt.expectedCode == ErrCodeTooEarly && (resp.ScheduledStart == nil || !resp.ScheduledStart.Equal(start)) {
+				t.Errorf("expected scheduled_start %v for a countdown, got %v", start, resp.ScheduledStart)
+			}
+		})
 	}
 }
 
-func TestSendMessageHandler_EmptyMessage(t *testing.T) {
-	clearMemoryStore()
+func TestUpdateInterviewHandler_Schedule(t *testing.T) {
 	router := setupTestRouter()
+	interview := createTestInterview(t, router, testsupport.NewInterviewBuilder().WithCandidate("Reschedule Candidate"))
+	if interview.Status != data.InterviewStatusDraft {
+		t.Errorf("expected unscheduled interview to be a draft, got %q", interview.Status)
+	}
 
-	interview := createTestInterviewAndSession(t, router)
+	tests := []struct {
+		name           string
+		body           string
+		expectedStatus int
+		expectedState  string
+	}{
+		{"schedule", `{"scheduled_start":"2025-03-10T09:00:00Z","scheduled_end":"2025-03-10T10:00:00Z"}`, http.StatusOK, data.InterviewStatusScheduled},
+		{"end before start", `{"scheduled_start":"2025-03-10T09:00:00Z","scheduled_end":"2025-03-10T08:00:00Z"}`, http.StatusBadRequest, ""},
+		{"invalid json", `{"scheduled_start":`, http.StatusBadRequest, ""},
+		{"clear schedule", `{}`, http.StatusOK, data.InterviewStatusDraft},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("PATCH", "/api/interviews/"+interview.ID, strings.NewReader(tt.body))
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			if w.Code != tt.expectedStatus {
+				t.Fatalf("expected status %d, got %d: %s", tt.expectedStatus, w.Code, w.Body.String())
+			}
+			if tt.expectedStatus != http.StatusOK {
+				return
+			}
+			var resp InterviewResponseDTO
+			if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+				t.Fatalf("failed to decode interview: %v", err)
+			}
+			if resp.Status != tt.expectedState {
+				t.Errorf("expected status %q, got %q", tt.expectedState, resp.Status)
+			}
+		})
+	}
 
-	emptyReq := SendMessageRequestDTO{Message: ""}
-	b, _ := json.Marshal(emptyReq)
-	expectHTTPError(t, router, "POST", "/api/chat/"+interview.SessionID+"/message", b, http.StatusBadRequest)
+	expectHTTPError(t, router, "PATCH", "/api/interviews/missing", []byte(`{}`), http.StatusNotFound)
 }
 
-func TestSendMessageHandler_InvalidSession(t *testing.T) {
-	clearMemoryStore()
+func TestCloneInterviewHandler(t *testing.T) {
 	router := setupTestRouter()
+	router.store.SetClock(testsupport.SteppingClock(time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC), time.Second))
+	adaptive := false
+	source := createTestInterview(t, router, testsupport.NewInterviewBuilder().
+		WithCandidate("Original Candidate").
+		WithQuestions(2).
+		WithType("technical").
+		WithLanguage("zh-TW").
+		WithJobDescription("Backend engineer").
+		WithResume("Original resume").
+		WithCompanyContext("Fintech startup").
+		WithWebhook("https://hooks.example.com/team-a", "s3cret").
+		WithAdaptive(adaptive))
+	startChatSession(t, router, testsupport.NewSessionBuilder().ForInterviewID(source.ID))
 
-	req := SendMessageRequestDTO{Message: "Hello"}
-	b, _ := json.Marshal(req)
-	expectHTTPError(t, router, "POST", "/api/chat/nonexistent/message", b, http.StatusNotFound)
-}
+	start := time.Now().Add(24 * time.Hour).UTC().Truncate(time.Second)
+	body, _ := json.Marshal(CloneInterviewRequestDTO{CandidateName: "  Next   Candidate ", ScheduledStart: apitime.NewPtr(&start)})
+	req := httptest.NewRequest("POST", "/api/interviews/"+source.ID+"/clone", bytes.NewReader(body))
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", w.Code, w.Body.String())
+	}
+	var clone InterviewResponseDTO
+	if err := json.Unmarshal(w.Body.Bytes(), &clone); err != nil {
+		t.Fatalf("failed to decode clone: %v", err)
+	}
+
+	if clone.ID == source.ID || clone.ClonedFrom != source.ID || clone.CandidateName != "Next Candidate" {
+		t.Errorf("expected a new interview for Next Candidate cloned from %s, got %+v", source.ID, clone)
+	}
+	if !reflect.DeepEqual(clone.Questions, source.Questions) || clone.InterviewType != "technical" ||
+		clone.InterviewLanguage != "zh-TW" || clone.JobDescription != "Backend engineer" || clone.CompanyContext != "Fintech startup" {
+		t.Errorf("expected the interview setup to be copied, got %+v", clone)
+	}
+	if clone.Adaptive || clone.Notify == nil || clone.Notify.WebhookURL != "https://hooks.example.com/team-a" || !clone.Notify.HasSecret {
+		t.Errorf("expected adaptive and notify settings to be copied, got adaptive %v notify %+v", clone.Adaptive, clone.Notify)
+	}
+	if clone.ResumeContent != "" {
+		t.Errorf("expected the source candidate's resume not to be copied, got %q", clone.ResumeContent)
+	}
+	if clone.ScheduledStart == nil || !clone.ScheduledStart.Equal(start) || clone.Status != data.InterviewStatusScheduled {
+		t.Errorf("expected the requested schedule, got start %v status %q", clone.ScheduledStart, clone.Status)
+	}
+	if !clone.CreatedAt.After(source.CreatedAt.Time) {
+		t.Errorf("expected fresh timestamps, got %v (source %v)", clone.CreatedAt, source.CreatedAt)
+	}
+	// The clone starts without the source's sessions
+	if sessions, _ := router.store.GetChatSessionsByInterview(clone.ID); len(sessions) != 0 {
+		t.Errorf("expected no sessions on the clone, got %d", len(sessions))
+	}
+
+	// The cloned_from link is persisted
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/api/interviews/"+clone.ID, nil))
+	var fetched InterviewResponseDTO
+	if err := json.Unmarshal(w.Body.Bytes(), &fetched); err != nil {
+		t.Fatalf("failed to decode interview: %v", err)
+	}
+	if fetched.ClonedFrom != source.ID {
+		t.Errorf("expected cloned_from %s on the stored interview, got %q", source.ID, fetched.ClonedFrom)
+	}
+
+	// A language override replaces the source's language
+	body, _ = json.Marshal(CloneInterviewRequestDTO{CandidateName: "Third Candidate", InterviewLanguage: "en"})
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("POST", "/api/interviews/"+source.ID+"/clone", bytes.NewReader(body)))
+	if w.Code != http.StatusCreated || !strings.Contains(w.Body.String(), `"interview_language":"en"`) {
+		t.Errorf("expected an English clone, got %d: %s", w.Code, w.Body.String())
+	}
+
+	expectHTTPError(t, router, "POST", "/api/interviews/missing/clone", []byte(`{"candidate_name":"Jane"}`), http.StatusNotFound)
+	expectHTTPError(t, router, "POST", "/api/interviews/"+source.ID+"/clone", []byte(`{"candidate_name":"  "}`), http.StatusBadRequest)
+	expectHTTPError(t, router, "POST", "/api/interviews/"+source.ID+"/clone", []byte(`{"candidate_name":"Jane","interview_language":"fr"}`), http.StatusBadRequest)
+}
+
+func TestListInterviewsHandler_ScheduledFilters(t *testing.T) {
+	router := setupTestRouter()
+	for _, day := range []int{10, 12, 14} {
+		start := time.Date(2025, 3, day, 9, 0, 0, 0, time.UTC)
+		createTestInterview(t, router, testsupport.NewInterviewBuilder().WithCandidate(fmt.Sprintf("Candidate %d", day)).WithSchedule(&start, nil))
+	}
+	createTestInterview(t, router, testsupport.NewInterviewBuilder().WithCandidate("Unscheduled"))
+
+	tests := []struct {
+		name             string
+		query            string
+		expectedTotal    int
+		expectedWarnings int
+	}{
+		{"no filter", "", 4, 0},
+		{"after date", "?scheduled_after=2025-03-11", 2, 0},
+		{"before timestamp", "?scheduled_before=2025-03-12T09:00:00Z", 2, 0},
+		{"window", "?scheduled_after=2025-03-11&scheduled_before=2025-03-13", 1, 0},
+		{"invalid value ignored", "?scheduled_after=tomorrow", 4, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "/api/interviews"+tt.query, nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			var resp ListInterviewsResponseDTO
+			if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if resp.Total != tt.expectedTotal || len(resp.Warnings) != tt.expectedWarnings {
+				t.Errorf("expected %d interviews and %d warnings, got %d and %v", tt.expectedTotal, tt.expectedWarnings, resp.Total, resp.Warnings)
+			}
+		})
+	}
+}
+
+func TestListInterviewsHandler_CreatedDateFilters(t *testing.T) {
+	router := setupTestRouter()
+	router.store.SetClock(testsupport.SteppingClock(time.Date(2025, 3, 10, 23, 0, 0, 0, time.UTC), 24*time.Hour))
+	for _, name := range []string{"Mar 10", "Mar 11", "Mar 12"} {
+		createTestInterview(t, router, testsupport.NewInterviewBuilder().WithCandidate(name))
+	}
+
+	tests := []struct {
+		name             string
+		query            string
+		expectedTotal    int
+		expectedWarnings int
+	}{
+		{"from date", "?date_from=2025-03-11", 2, 0},
+		{"from timestamp", "?date_from=2025-03-11T23:00:00Z", 2, 0},
+		{"offset converted to UTC", "?date_from=2025-03-12T06:00:00%2B08:00", 2, 0},
+		{"to date includes the whole day", "?date_to=2025-03-11", 2, 0},
+		{"invalid value ignored", "?date_from=03/11/2025", 3, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest("GET", "/api/interviews"+tt.query, nil))
+			var resp ListInterviewsResponseDTO
+			if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if resp.Total != tt.expectedTotal || len(resp.Warnings) != tt.expectedWarnings {
+				t.Errorf("expected %d interviews and %d warnings, got %d and %v", tt.expectedTotal, tt.expectedWarnings, resp.Total, resp.Warnings)
+			}
+		})
+	}
+
+	// Timestamps are written in UTC with millisecond precision
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/api/interviews?tz=Mars/Olympus_Mons", nil))
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for an unknown time zone, got %d", w.Code)
+	}
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/api/interviews?sort_by=created_at&sort_order=asc", nil))
+	if !strings.Contains(w.Body.String(), `"created_at":"2025-03-10T23:00:00.000Z"`) {
+		t.Errorf("expected created_at in UTC with milliseconds, got %s", w.Body.String())
+	}
+}
+
+func TestListInterviewsHandler_CreatedDateFiltersInTimeZone(t *testing.T) {
+	router := setupTestRouter()
+	// Either side of a Taipei midnight (16:00 UTC) and a New York midnight (05:00 UTC) on January 14 (New York is on standard time, UTC-5)
+	created := []time.Time{
+		time.Date(2025, 1, 14, 15, 59, 0, 0, time.UTC), // Jan 14 23:59 Taipei, Jan 14 11:59 New York
+		time.Date(2025, 1, 14, 16, 0, 0, 0, time.UTC),  // Jan 15 00:00 Taipei
+		time.Date(2025, 1, 15, 4, 59, 0, 0, time.UTC),  // Jan 15 12:59 Taipei, Jan 14 23:59 New York
+		time.Date(2025, 1, 15, 5, 0, 0, 0, time.UTC),   // Jan 15 00:00 New York
+	}
+	for i, at := range created {
+		router.store.SetClock(func() time.Time { return at })
+		createTestInterview(t, router, testsupport.NewInterviewBuilder().WithCandidate(fmt.Sprintf("Candidate %d", i)))
+	}
+
+	tests := []struct {
+		name     string
+		query    string
+		expected []string
+	}{
+		{"taipei day", "?date_from=2025-01-15&date_to=2025-01-15&tz=Asia/Taipei", []string{"Candidate 1", "Candidate 2", "Candidate 3"}},
+		{"taipei day before", "?date_to=2025-01-14&tz=Asia/Taipei", []string{"Candidate 0"}},
+		{"new york day", "?date_from=2025-01-14&date_to=2025-01-14&tz=America/New_York", []string{"Candidate 0", "Candidate 1", "Candidate 2"}},
+		{"new york day after", "?date_from=2025-01-15&tz=America/New_York", []string{"Candidate 3"}},
+		{"utc without a zone", "?date_from=2025-01-15&date_to=2025-01-15", []string{"Candidate 2", "Candidate 3"}},
+		{"timestamps ignore the zone", "?date_from=2025-01-14T16:00:00Z&date_to=2025-01-15T04:59:00Z&tz=America/New_York", []string{"Candidate 1", "Candidate 2"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest("GET", "/api/interviews"+tt.query+"&sort_by=created_at&sort_order=asc", nil))
+			var resp ListInterviewsResponseDTO
+			if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil || w.Code != http.StatusOK {
+				t.Fatalf("expected a list, got %d: %s", w.Code, w.Body.String())
+			}
+			var names []string
+			for _, interview := range resp.Interviews {
+				names = append(names, interview.CandidateName)
+			}
+			if strings.Join(names, ",") != strings.Join(tt.expected, ",") {
+				t.Errorf("expected %v, got %v", tt.expected, names)
+			}
+		})
+	}
+}
+
+func TestListInterviewsHandler_LatestScoreLoadedOnce(t *testing.T) {
+	policy := data.NewFaultPolicy()
+	router := newFaultyTestRouter(policy, ai.NewMockProvider())
+	scored := createTestInterview(t, router, testsupport.NewInterviewBuilder().WithCandidate("Scored"))
+	if w := submitEvaluation(t, router, "", scored.ID, "An answer"); w.Code != http.StatusOK {
+		t.Fatalf("expected an evaluation, got %d: %s", w.Code, w.Body.String())
+	}
+	for _, name := range []string{"Second", "Third"} {
+		createTestInterview(t, router, testsupport.NewInterviewBuilder().WithCandidate(name))
+	}
+	single := policy.Calls("GetLatestEvaluationByInterview")
+
+	// The page's scores come from one store read, not one per interview
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/api/interviews?fields=id,latest_score", nil))
+	var resp ListInterviewFieldsResponseDTO
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil || w.Code != http.StatusOK || len(resp.Interviews) != 3 {
+		t.Fatalf("expected three interviews, got %d: %s", w.Code, w.Body.String())
+	}
+	if batch := policy.Calls("GetLatestEvaluationsByInterviews"); batch != 1 || policy.Calls("GetLatestEvaluationByInterview") != single {
+		t.Errorf("expected one batched read, got %d batched and %d single reads", batch, policy.Calls("GetLatestEvaluationByInterview")-single)
+	}
+	for _, interview := range resp.Interviews {
+		if (interview["id"] == scored.ID) != (interview["latest_score"] != nil) {
+			t.Errorf("expected only the evaluated interview scored, got %v", interview)
+		}
+	}
+
+	// Without latest_score the evaluations aren't read at all
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/api/interviews?fields=id", nil))
+	if batch := policy.Calls("GetLatestEvaluationsByInterviews"); batch != 1 {
+		t.Errorf("expected no evaluation read without latest_score, got %d", batch-1)
+	}
+
+	// A failed read fails the list rather than reporting the scores as missing
+	policy.Add(data.Fault{Op: "GetLatestEvaluationsByInterviews", Call: 2})
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/api/interviews?fields=id,latest_score", nil))
+	decodeError(t, w, http.StatusInternalServerError, ErrCodeInternal)
+}
+
+func TestListInterviewsHandler_FieldSelection(t *testing.T) {
+	router, session, evaluation := setupReopenTest(t, nil)
+	createTestInterview(t, router, testsupport.NewInterviewBuilder().WithCandidate("Unscored").WithQuestions(2))
+
+	// Only the selected fields are returned, with the question count in place of the questions
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/api/interviews?fields=id,question_count,latest_score&sort_by=created_at&sort_order=asc", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var resp struct {
+		Interviews []map[string]interface{} `json:"interviews"`
+		Total      int                      `json:"total"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if resp.Total != 2 || len(resp.Interviews) != 2 {
+		t.Fatalf("expected both interviews, got %s", w.Body.String())
+	}
+	scored, unscored := resp.Interviews[0], resp.Interviews[1]
+	if len(scored) != 3 || scored["id"] != session.InterviewID || scored["question_count"] != float64(3) || scored["latest_score"] != evaluation.Score {
+		t.Errorf("expected the scored interview's id, question count and score, got %v", scored)
+	}
+	if len(unscored) != 3 || unscored["question_count"] != float64(2) || unscored["latest_score"] != nil {
+		t.Errorf("expected a null score for the unevaluated interview, got %v", unscored)
+	}
+
+	// Without fields the full interview is returned
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/api/interviews?fields=", nil))
+	var full ListInterviewsResponseDTO
+	if err := json.Unmarshal(w.Body.Bytes(), &full); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(full.Interviews) != 2 || len(full.Interviews[0].Questions) == 0 || full.Interviews[0].CandidateName == "" {
+		t.Errorf("expected full interviews by default, got %s", w.Body.String())
+	}
+
+	// Unknown fields are rejected with the valid ones listed
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/api/interviews?fields=id,questions,resume", nil))
+	var errResp ErrorResponseDTO
+	if err := json.Unmarshal(w.Body.Bytes(), &errResp); err != nil {
+		t.Fatalf("failed to decode error: %v", err)
+	}
+	if w.Code != http.StatusBadRequest || errResp.Code != ErrCodeValidationFailed ||
+		!strings.Contains(errResp.Details, "questions, resume") || !strings.Contains(errResp.Details, "question_count") {
+		t.Errorf("expected 400 naming the unknown and valid fields, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestStartChatSessionHandler_InvalidInterview(t *testing.T) {
+	router := setupTestRouter()
+
+	expectHTTPError(t, router, "POST", "/api/interviews/nonexistent/chat/start", nil, http.StatusNotFound)
+}
+
+func TestStartChatSessionHandler_MissingInterviewID(t *testing.T) {
+	router := setupTestRouter()
+
+	expectHTTPError(t, router, "POST", "/api/interviews//chat/start", nil, http.StatusBadRequest)
+}
+
+func TestSendMessageHandler_Success(t *testing.T) {
+	router := setupTestRouter()
+
+	interview := createTestInterviewAndSession(t, router)
+
+	// Send a message using helper
+	response := sendMessage(t, router, interview.SessionID, "Hello, this is my test message")
+
+	// Should have user message and AI response
+	if response.Message.Content != "Hello, this is my test message" {
+		t.Errorf("expected user message content to match, got %s", response.Message.Content)
+	}
+	if response.AIResponse == nil {
+		t.Error("expected AI response to be present")
+	}
+	if len(response.Warnings) != 0 {
+		t.Errorf("expected no warnings, got %v", response.Warnings)
+	}
+}
+
+func TestSendMessageHandler_EmptyMessage(t *testing.T) {
+	router := setupTestRouter()
+
+	interview := createTestInterviewAndSession(t, router)
+
+	emptyReq := SendMessageRequestDTO{Message: ""}
+	b, _ := json.Marshal(emptyReq)
+	expectHTTPError(t, router, "POST", "/api/chat/"+interview.SessionID+"/message", b, http.StatusBadRequest)
+}
+
+func TestSendMessageHandler_InvalidSession(t *testing.T) {
+	router := setupTestRouter()
+
+	req := SendMessageRequestDTO{Message: "Hello"}
+	b, _ := json.Marshal(req)
+	expectHTTPError(t, router, "POST", "/api/chat/nonexistent/message", b, http.StatusNotFound)
+}
 
 func TestSendMessageHandler_InvalidJSON(t *testing.T) {
-	clearMemoryStore()
 	router := setupTestRouter()
 
 	interview := createTestInterviewAndSession(t, router)
 	expectHTTPError(t, router, "POST", "/api/chat/"+interview.SessionID+"/message", []byte("{"), http.StatusBadRequest)
 }
 
+func TestSendMessageHandler_Model(t *testing.T) {
+	router := setupTestRouter()
+
+	interview := createTestInterviewAndSession(t, router)
+	path := "/api/chat/" + interview.SessionID + "/message"
+
+	// Unknown models are rejected before reaching a provider
+	assertErrorResponse(t, router, "POST", path, `{"message":"Hello","model":"openai/gpt-99"}`, http.StatusBadRequest, ErrCodeValidationFailed)
+
+	// Retired models are accepted and replaced
+	response := sendMessageRaw(t, router, interview.SessionID, `{"message":"Hello","model":"openai/gpt-4-turbo-preview"}`)
+	if response.AIResponse == nil {
+		t.Error("expected AI response to be present")
+	}
+}
+
+func TestSendMessageHandler_InterviewTypeParams(t *testing.T) {
+	provider := ai.NewMockProvider()
+	router := setupTestRouterWithProvider(provider, func(deps *HandlerDependencies) {
+		deps.InterviewTypeParams = map[string]ai.GenerationParams{
+			"behavioral": {Temperature: ai.Float64(0.9), MaxTokens: 800},
+			"technical":  {Temperature: ai.Float64(0.2)},
+		}
+		// Build clients from the server-side config, as in production
+		deps.newAIClient = func(r *http.Request) *ai.AIClient {
+			cfg := deps.sharedAIConfig()
+			cfg.DefaultProvider = ai.ProviderMock
+			return ai.NewAIClientWithProvider(provider, &cfg)
+		}
+	})
+
+	for _, tt := range []struct {
+		interviewType string
+		temperature   float64
+		maxTokens     int
+	}{
+		{"behavioral", 0.9, 800},
+		{"technical", 0.2, 500},
+	} {
+		interview := createTestInterview(t, router, testsupport.NewInterviewBuilder().WithType(tt.interviewType).WithQuestions(3))
+		session := startChatSession(t, router, testsupport.NewSessionBuilder().ForInterviewID(interview.ID))
+		sendMessage(t, router, session.ID, "Hello")
+
+		// Both the greeting and the reply to the message use the type's parameters
+		requests := provider.ChatRequests()
+		for _, req := range requests[len(requests)-2:] {
+			if req.Temperature != tt.temperature || req.MaxTokens != tt.maxTokens {
+				t.Errorf("%s: expected temperature %v with %d tokens, got %v with %d",
+					tt.interviewType, tt.temperature, tt.maxTokens, req.Temperature, req.MaxTokens)
+			}
+		}
+	}
+}
+
 func TestGetChatSessionHandler_Success(t *testing.T) {
-	clearMemoryStore()
 	router := setupTestRouter()
 
 	interview := createTestInterviewAndSession(t, router)
@@ -710,7 +2113,6 @@ func TestGetChatSessionHandler_Success(t *testing.T) {
 }
 
 func TestGetChatSessionHandler_NotFound(t *testing.T) {
-	clearMemoryStore()
 	router := setupTestRouter()
 
 	req := httptest.NewRequest("GET", "/api/chat/nonexistent", nil)
@@ -723,7 +2125,6 @@ func TestGetChatSessionHandler_NotFound(t *testing.T) {
 }
 
 func TestEndChatSessionHandler_Success(t *testing.T) {
-	clearMemoryStore()
 	router := setupTestRouter()
 
 	interview := createTestInterviewAndSession(t, router)
@@ -758,38 +2159,2176 @@ func TestEndChatSessionHandler_Success(t *testing.T) {
 }
 
 func TestEndChatSessionHandler_NotFound(t *testing.T) {
-	clearMemoryStore()
 	router := setupTestRouter()
 
 	expectHTTPError(t, router, "POST", "/api/chat/nonexistent/end", nil, http.StatusNotFound)
 }
 
-// ============================================
-// ADDITIONAL EDGE CASE TESTS
-// ============================================
+func TestEvaluationHandlers_PassInterviewContext(t *testing.T) {
+	provider := ai.NewMockProvider()
+	router := setupTestRouterWithProvider(provider, nil)
+
+	interview := createTestInterview(t, router, testsupport.NewInterviewBuilder().
+		WithCandidate("Context Candidate").
+		WithQuestionTexts("Tell me about a conflict").
+		WithType("behavioral").
+		WithResume("Team lead at Acme").
+		WithCompanyContext("Friendly healthcare startup"))
+	if interview.ResumeContent != "Team lead at Acme" || interview.CompanyContext != "Friendly healthcare startup" {
+		t.Errorf("expected resume and company context in response, got %+v", interview)
+	}
+
+	// Traditional evaluation
+	b, _ := json.Marshal(SubmitEvaluationRequestDTO{
+		InterviewID: interview.ID,
+		Answers:     map[string]string{"question_0": "I listened first"},
+	})
+	expectHTTPError(t, router, "POST", "/api/evaluation", b, http.StatusOK)
+
+	// Chat evaluation
+	session := startChatSession(t, router, testsupport.NewSessionBuilder().ForInterviewID(interview.ID))
+	sendMessage(t, router, session.ID, "I listened first")
+	expectHTTPError(t, router, "POST", "/api/chat/"+session.ID+"/end?replace=true", nil, http.StatusOK)
+
+	requests := provider.EvaluationRequests()
+	if len(requests) != 2 {
+		t.Fatalf("expected 2 evaluation requests, got %d", len(requests))
+	}
+	for _, req := range requests {
+		if req.InterviewType != "behavioral" || req.ResumeContent != "Team lead at Acme" || req.CompanyContext != "Friendly healthcare startup" {
+			t.Errorf("expected interview context in evaluation request, got %+v", req)
+		}
+		if req.JobDesc != "General behavioral interview" {
+			t.Errorf("expected fallback job description, got %q", req.JobDesc)
+		}
+	}
+}
+
+func TestEvaluationHandlers_DetailLevel(t *testing.T) {
+	provider := ai.NewMockProvider()
+	router := setupTestRouterWithProvider(provider, nil)
+	interview := createTestInterview(t, router, testsupport.NewInterviewBuilder().WithCandidate("Detail Candidate"))
+
+	invalid, _ := json.Marshal(SubmitEvaluationRequestDTO{
+		InterviewID: interview.ID,
+		Answers:     map[string]string{"question_0": "A1"},
+		DetailLevel: "verbose",
+	})
+	assertErrorResponse(t, router, "POST", "/api/evaluation", string(invalid), http.StatusBadRequest, ErrCodeValidationFailed)
+
+	b, _ := json.Marshal(SubmitEvaluationRequestDTO{
+		InterviewID: interview.ID,
+		Answers:     map[string]string{"question_0": "A1"},
+		DetailLevel: ai.DetailLevelBrief,
+	})
+	expectHTTPError(t, router, "POST", "/api/evaluation", b, http.StatusOK)
+
+	session := startChatSession(t, router, testsupport.NewSessionBuilder().ForInterviewID(interview.ID))
+	sendMessage(t, router, session.ID, "A1")
+	assertErrorResponse(t, router, "POST", "/api/chat/"+session.ID+"/end?detail_level=verbose", "", http.StatusBadRequest, ErrCodeValidationFailed)
+	expectHTTPError(t, router, "POST", "/api/chat/"+session.ID+"/end?detail_level=detailed&replace=true", nil, http.StatusOK)
+
+	requests := provider.EvaluationRequests()
+	if len(requests) != 2 {
+		t.Fatalf("expected 2 evaluation requests, got %d", len(requests))
+	}
+	if requests[0].DetailLevel != ai.DetailLevelBrief {
+		t.Errorf("expected brief evaluation from submission, got %q", requests[0].DetailLevel)
+	}
+	if requests[1].DetailLevel != ai.DetailLevelDetailed {
+		t.Errorf("expected detailed evaluation from session end, got %q", requests[1].DetailLevel)
+	}
+}
+
+func TestChatSession_AskedQuestionsTracked(t *testing.T) {
+	scripted := []string{"What is a goroutine?", "How do channels work?", "Describe a race condition."}
+	provider := ai.NewScriptedMockProvider(scripted...)
+	router := setupTestRouterWithProvider(provider, nil)
+
+	interview := createTestInterview(t, router, testsupport.NewInterviewBuilder().WithCandidate("Tracked Candidate").WithType("technical"))
+	session := startChatSession(t, router, testsupport.NewSessionBuilder().ForInterviewID(interview.ID))
+	if session.AskedQuestions != nil {
+		t.Errorf("expected asked_questions to be omitted without include flag")
+	}
+	sendMessage(t, router, session.ID, "A lightweight thread")
+	sendMessage(t, router, session.ID, "They pass values between goroutines")
+
+	// Exposed only with the include flag
+	req := httptest.NewRequest("GET", "/api/chat/"+session.ID+"?include=messages,asked_questions", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	var resp ChatInterviewSessionDTO
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to decode session: %v", err)
+	}
+	if len(resp.AskedQuestions) != len(scripted) {
+		t.Fatalf("expected %d asked questions, got %v", len(scripted), resp.AskedQuestions)
+	}
+	for i, q := range scripted {
+		if resp.AskedQuestions[i] != q {
+			t.Errorf("asked question %d: expected %q, got %q", i, q, resp.AskedQuestions[i])
+		}
+	}
+
+	// Evaluation pairs answers with the recorded questions; the last question was never answered
+	expectHTTPError(t, router, "POST", "/api/chat/"+session.ID+"/end", nil, http.StatusOK)
+	evalRequests := provider.EvaluationRequests()
+	if len(evalRequests) != 1 {
+		t.Fatalf("expected 1 evaluation request, got %d", len(evalRequests))
+	}
+	if len(evalRequests[0].Questions) != 2 || evalRequests[0].Questions[0] != scripted[0] || evalRequests[0].Questions[1] != scripted[1] {
+		t.Errorf("expected evaluation questions to match answered asked questions, got %v", evalRequests[0].Questions)
+	}
+}
+
+func TestChatSession_Progress(t *testing.T) {
+	provider := ai.NewScriptedMockProvider(
+		"Welcome! What is Go?",
+		"Can you elaborate?",
+		"What is a goroutine?",
+		"What is a channel?",
+		"Thank you for your time.",
+	)
+	router := setupTestRouterWithProvider(provider, nil)
+	interview := createTestInterview(t, router, testsupport.NewInterviewBuilder().
+		WithCandidate("Progress Candidate").
+		WithQuestionTexts("What is Go?", "What is a goroutine?", "What is a channel?").
+		WithType("technical"))
+
+	session := startChatSession(t, router, testsupport.NewSessionBuilder().ForInterviewID(interview.ID))
+	assertProgress(t, "start", session.Progress, InterviewProgressDTO{QuestionsTotal: 3, QuestionsAsked: 1, PercentComplete: 33})
+
+	// Each turn: planned questions asked (follow-ups don't count), percent, and whether the next message ends it
+	turns := []InterviewProgressDTO{
+		{QuestionsTotal: 3, QuestionsAsked: 1, UserMessages: 1, PercentComplete: 33},
+		{QuestionsTotal: 3, QuestionsAsked: 2, UserMessages: 2, PercentComplete: 66},
+		{QuestionsTotal: 3, QuestionsAsked: 3, UserMessages: 3, PercentComplete: 99, WillEndAfterNext: true},
+		// The answer to the last planned question ends the interview
+		{QuestionsTotal: 3, QuestionsAsked: 3, UserMessages: 4, PercentComplete: 100},
+	}
+	for i, want := range turns {
+		resp := sendMessage(t, router, session.ID, fmt.Sprintf("Answer %d", i+1))
+		assertProgress(t, fmt.Sprintf("turn %d", i+1), resp.Progress, want)
+	}
+
+	// The session view reports the same progress as the last reply
+	final := getChatSession(t, router, session.ID, "")
+	if final.Status != "completed" {
+		t.Fatalf("expected session to be completed, got %q", final.Status)
+	}
+	assertProgress(t, "session", final.Progress, turns[len(turns)-1])
+}
+
+func TestSessionProgress_FreeForm(t *testing.T) {
+	store := newTestStore()
+	testsupport.NewInterviewBuilder().WithID("free-form").WithCandidate("Free Form").WithQuestionTexts().Create(t, store)
+	session := &data.ChatSession{ID: "free-form-session", InterviewID: "free-form", Status: "active", AskedQuestions: []string{"Tell me about yourself?"}}
+	deps := NewHandlerDependencies(nil, store)
+	deps.MaxMessagesPerSession = 10
 
-func TestParseIntQuery_EdgeCases(t *testing.T) {
 	tests := []struct {
-		name         string
-		queryValue   string
-		defaultValue int
-		expected     int
+		userMessages  int
+		totalMessages int
+		expected      InterviewProgressDTO
 	}{
-		{"empty string", "", 10, 10},
-		{"valid positive", "5", 10, 5},
-		{"zero", "0", 10, 0},
-		{"negative", "-1", 10, 10}, // Should use default for negative
-		{"invalid string", "abc", 10, 10},
-		{"float", "5.5", 10, 10}, // Should use default for non-int
+		{0, 1, InterviewProgressDTO{QuestionsAsked: 1, PercentComplete: 0}},
+		{2, 5, InterviewProgressDTO{QuestionsAsked: 1, UserMessages: 2, PercentComplete: 25}},
+		{7, 15, InterviewProgressDTO{QuestionsAsked: 1, UserMessages: 7, PercentComplete: 87, WillEndAfterNext: true}},
+		// Only the next message and its closing reply still fit under the cap
+		{3, 8, InterviewProgressDTO{QuestionsAsked: 1, UserMessages: 3, PercentComplete: 37, WillEndAfterNext: true}},
 	}
-
 	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			req := httptest.NewRequest("GET", "/?test="+tt.queryValue, nil)
-			result := parseIntQuery(req, "test", tt.defaultValue)
-			if result != tt.expected {
-				t.Errorf("expected %d, got %d", tt.expected, result)
-			}
-		})
+		got := deps.sessionProgress(store, session, tt.userMessages, tt.totalMessages)
+		assertProgress(t, fmt.Sprintf("%d of %d messages", tt.userMessages, tt.totalMessages), got, tt.expected)
+	}
+}
+
+func TestSendMessageHandler_EndsWhenPlannedQuestionsAsked(t *testing.T) {
+	provider := ai.NewScriptedMockProvider("Welcome! What is Go?", "Thanks, that's all from me.")
+	router := setupTestRouterWithProvider(provider, nil)
+	interview := createTestInterview(t, router, testsupport.NewInterviewBuilder().
+		WithCandidate("Single Question Candidate").
+		WithQuestionTexts("What is Go?").
+		WithType("technical"))
+	session := startChatSession(t, router, testsupport.NewSessionBuilder().ForInterviewID(interview.ID))
+	if !session.Progress.WillEndAfterNext {
+		t.Errorf("expected the answer to the only question to be announced as the last message")
+	}
+
+	resp := sendMessage(t, router, session.ID, "A language")
+	if resp.SessionStatus != "completed" {
+		t.Errorf("expected the interview to end once every planned question was asked, got %q", resp.SessionStatus)
+	}
+	if resp.AIResponse == nil || resp.AIResponse.Subtype != data.MessageSubtypeClosing {
+		t.Errorf("expected a closing reply, got %+v", resp.AIResponse)
+	}
+}
+
+func assertProgress(t *testing.T, step string, got *InterviewProgressDTO, want InterviewProgressDTO) {
+	t.Helper()
+	if got == nil {
+		t.Fatalf("%s: expected progress, got none", step)
+	}
+	if *got != want {
+		t.Errorf("%s: expected progress %+v, got %+v", step, want, *got)
+	}
+}
+
+func TestStartChatSessionHandler_IncludeAskedQuestions(t *testing.T) {
+	router := setupTestRouterWithProvider(ai.NewScriptedMockProvider("Welcome! Tell me about yourself?"), nil)
+	interview := createTestInterview(t, router, testsupport.NewInterviewBuilder().WithCandidate("Include Candidate"))
+
+	req := httptest.NewRequest("POST", "/api/interviews/"+interview.ID+"/chat/start?include=asked_questions", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	var resp ChatInterviewSessionDTO
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to decode session: %v", err)
+	}
+	if len(resp.AskedQuestions) != 1 || resp.AskedQuestions[0] != "Welcome! Tell me about yourself?" {
+		t.Errorf("expected greeting as first asked question, got %v", resp.AskedQuestions)
+	}
+}
+
+func TestChatSession_MessageSubtypes(t *testing.T) {
+	provider := ai.NewScriptedMockProvider(
+		"Welcome! Let's begin: tell me about yourself.",
+		"Great answer!",
+		"What is your biggest strength?",
+		"Why do you say that?",
+	)
+	router := setupTestRouterWithProvider(provider, nil)
+	interview := createTestInterview(t, router, testsupport.NewInterviewBuilder().
+		WithCandidate("Subtype Candidate").
+		WithQuestionTexts("What is your biggest strength?", "Where do you see yourself in five years?"))
+
+	session := startChatSession(t, router, testsupport.NewSessionBuilder().ForInterviewID(interview.ID))
+	if session.Messages[0].Subtype != "greeting" {
+		t.Errorf("expected greeting subtype, got %q", session.Messages[0].Subtype)
+	}
+
+	expected := []string{"acknowledgement", "question", "follow_up"}
+	answers := []string{"I am a backend developer", "And I enjoy mentoring", "Persistence"}
+	for i, answer := range answers {
+		resp := sendMessage(t, router, session.ID, answer)
+		if resp.AIResponse.Subtype != expected[i] {
+			t.Errorf("turn %d: expected subtype %q, got %q", i, expected[i], resp.AIResponse.Subtype)
+		}
+	}
+
+	// Acknowledgements are not recorded as asked questions
+	stored, _ := router.store.GetChatSession(session.ID)
+	if len(stored.AskedQuestions) != 3 {
+		t.Errorf("expected 3 asked questions (greeting, question, follow-up), got %v", stored.AskedQuestions)
+	}
+
+	// Answers given around an acknowledgement belong to the same question
+	expectHTTPError(t, router, "POST", "/api/chat/"+session.ID+"/end", nil, http.StatusOK)
+	eval := provider.EvaluationRequests()[0]
+	if len(eval.Questions) != 2 || len(eval.Answers) != 2 {
+		t.Fatalf("expected 2 question/answer pairs, got %v / %v", eval.Questions, eval.Answers)
+	}
+	if eval.Answers[0] != "I am a backend developer\n\nAnd I enjoy mentoring" {
+		t.Errorf("expected answers to the greeting to be joined, got %q", eval.Answers[0])
+	}
+	if eval.Questions[1] != "What is your biggest strength?" || eval.Answers[1] != "Persistence" {
+		t.Errorf("unexpected second pair: %q / %q", eval.Questions[1], eval.Answers[1])
+	}
+}
+
+func TestSendMessageHandler_ClosingSubtype(t *testing.T) {
+	router := setupTestRouterWithProvider(ai.NewMockProvider(), nil)
+	ids := createTestInterviewAndSession(t, router)
+
+	var resp SendMessageResponseDTO
+	for i := 0; i < 8; i++ {
+		resp = sendMessage(t, router, ids.SessionID, fmt.Sprintf("Answer %d", i))
+	}
+	if resp.SessionStatus != "completed" || resp.AIResponse.Subtype != "closing" {
+		t.Errorf("expected closing subtype on final turn, got status %q subtype %q", resp.SessionStatus, resp.AIResponse.Subtype)
+	}
+}
+
+func TestClassifyAIReply(t *testing.T) {
+	planned := []string{"Tell me about yourself.", "What is a closure?"}
+	tests := []struct {
+		reply    string
+		expected string
+	}{
+		{"Thanks, great answer!", "acknowledgement"},
+		{"謝謝你的分享。", "acknowledgement"},
+		{"Next one: what is a closure?", "question"},
+		{"Sure. Tell me about yourself", "question"},
+		{"How would you test that?", "follow_up"},
+		{"Describe the hardest bug you fixed.", "follow_up"},
+		{"你會如何測試這個功能？", "follow_up"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.reply, func(t *testing.T) {
+			if got := classifyAIReply(tt.reply, planned); got != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+// ============================================
+// LONG MESSAGE TESTS
+// ============================================
+
+func TestSendMessageHandler_MessageTooLong(t *testing.T) {
+	router := setupTestRouterWithProvider(ai.NewMockProvider(), func(deps *HandlerDependencies) {
+		deps.MaxMessageLength = 100
+	})
+	ids := createTestInterviewAndSession(t, router)
+
+	b, _ := json.Marshal(SendMessageRequestDTO{Message: strings.Repeat("a", 101)})
+	expectHTTPError(t, router, "POST", "/api/chat/"+ids.SessionID+"/message", b, http.StatusRequestEntityTooLarge)
+
+	// Rejected message must not be stored
+	messages, _ := router.store.GetChatMessages(ids.SessionID)
+	for _, msg := range messages {
+		if msg.Type == "user" {
+			t.Errorf("expected rejected message not to be stored, found %q", msg.Content)
+		}
+	}
+
+	// Length is counted in characters, not bytes
+	b, _ = json.Marshal(SendMessageRequestDTO{Message: strings.Repeat("字", 100)})
+	expectHTTPError(t, router, "POST", "/api/chat/"+ids.SessionID+"/message", b, http.StatusOK)
+}
+
+func TestSendMessageHandler_LongMessageSummarized(t *testing.T) {
+	provider := ai.NewScriptedMockProvider("Hello, tell me about your take-home.", "Condensed solution summary", "Why did you pick that design?")
+	router := setupTestRouterWithProvider(provider, func(deps *HandlerDependencies) {
+		deps.MaxMessageLength = 500
+		deps.MessageSummaryThreshold = 50
+	})
+	ids := createTestInterviewAndSession(t, router)
+
+	longMessage := strings.Repeat("func solve() {} ", 20)
+	resp := sendMessage(t, router, ids.SessionID, longMessage)
+
+	// Full text is stored and echoed back
+	if resp.Message.Content != longMessage {
+		t.Errorf("expected full message content to be returned")
+	}
+	if len(resp.Warnings) != 1 || resp.Warnings[0].Code != WarnCodeMessageSummarized {
+		t.Errorf("expected a message_summarized warning, got %v", resp.Warnings)
+	}
+	if resp.AIResponse == nil || resp.AIResponse.Content != "Why did you pick that design?" {
+		t.Errorf("expected scripted AI response, got %+v", resp.AIResponse)
+	}
+
+	messages, _ := router.store.GetChatMessages(ids.SessionID)
+	var stored *data.ChatMessage
+	for _, msg := range messages {
+		if msg.Type == "user" {
+			stored = msg
+		}
+	}
+	if stored == nil || stored.Content != longMessage {
+		t.Fatalf("expected full message to be stored")
+	}
+	if stored.Metadata[data.MessageMetaSummarized] != "true" || stored.Metadata[data.MessageMetaContextSummary] != "Condensed solution summary" {
+		t.Errorf("expected summary metadata, got %v", stored.Metadata)
+	}
+
+	// The chat request sent after summarization must carry the summary, not the full text
+	requests := provider.ChatRequests()
+	if len(requests) != 3 {
+		t.Fatalf("expected 3 provider requests (greeting, summary, reply), got %d", len(requests))
+	}
+	if requests[1].Context["task"] != ai.TaskSummarization {
+		t.Errorf("expected second request to be a summarization request")
+	}
+	for _, msg := range requests[2].Messages {
+		if msg.Content == longMessage {
+			t.Errorf("full message leaked into chat context")
+		}
+	}
+	last := requests[2].Messages[len(requests[2].Messages)-1]
+	if last.Role != "user" || last.Content != "Condensed solution summary" {
+		t.Errorf("expected summary as latest user message, got %+v", last)
+	}
+
+	// Evaluation still uses the full stored answer
+	endReq := httptest.NewRequest("POST", "/api/chat/"+ids.SessionID+"/end", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, endReq)
+	if w.Code != http.StatusOK {
+		t.Fatalf("failed to end session, got %d: %s", w.Code, w.Body.String())
+	}
+	evalRequests := provider.EvaluationRequests()
+	if len(evalRequests) != 1 {
+		t.Fatalf("expected 1 evaluation request, got %d", len(evalRequests))
+	}
+	if len(evalRequests[0].Answers) != 1 || evalRequests[0].Answers[0] != longMessage {
+		t.Errorf("expected evaluation to use the full answer, got %v", evalRequests[0].Answers)
+	}
+}
+
+func TestSendMessageHandler_RollingConversationSummary(t *testing.T) {
+	provider := ai.NewScriptedMockProvider(
+		"Welcome! What brings you here?",
+		"Reply one?",
+		"Reply two?",
+		"SUMMARY-1",
+		"Reply three?",
+		"SUMMARY-2",
+		"Reply four?",
+	)
+	router := setupTestRouterWithProvider(provider, func(deps *HandlerDependencies) {
+		deps.SummaryThresholdTurns = 4
+		deps.SummaryRecentTurns = 2
+	})
+	ids := createTestInterviewAndSession(t, router)
+	for _, answer := range []string{"Answer one", "Answer two", "Answer three", "Answer four"} {
+		sendMessage(t, router, ids.SessionID, answer)
+	}
+
+	// greeting, reply 1, reply 2, summary, reply 3, summary, reply 4
+	requests := provider.ChatRequests()
+	if len(requests) != 7 {
+		t.Fatalf("expected 7 provider requests, got %d", len(requests))
+	}
+	for i, request := range requests {
+		isSummary := request.Context["task"] == ai.TaskSummarization
+		if isSummary != (i == 3 || i == 5) {
+			t.Errorf("request %d: unexpected summarization=%v", i, isSummary)
+		}
+	}
+
+	// The first summary is requested once the history passes the threshold and covers the oldest turns
+	if !requestContains(requests[3], "Answer one") || requestContains(requests[3], "Answer two") {
+		t.Errorf("expected the first summary to cover only the oldest turns")
+	}
+	// The update builds on the previous summary with the newly dropped turns
+	if !requestContains(requests[5], "SUMMARY-1") || !requestContains(requests[5], "Answer two") || requestContains(requests[5], "Answer one") {
+		t.Errorf("expected the summary update to fold the next turns into the previous summary")
+	}
+
+	// Replies after summarization carry the summary instead of the dropped turns
+	if !requestContains(requests[4], "SUMMARY-1") || requestContains(requests[4], "Answer one") || !requestContains(requests[4], "Answer two") {
+		t.Errorf("expected reply 3 payload to contain SUMMARY-1 and only recent turns")
+	}
+	if !requestContains(requests[6], "SUMMARY-2") || requestContains(requests[6], "SUMMARY-1") ||
+		requestContains(requests[6], "Answer one") || requestContains(requests[6], "Answer two") || !requestContains(requests[6], "Answer three") {
+		t.Errorf("expected reply 4 payload to contain SUMMARY-2 and only recent turns")
+	}
+
+	session, err := router.store.GetChatSession(ids.SessionID)
+	if err != nil {
+		t.Fatalf("failed to load session: %v", err)
+	}
+	if session.ConversationSummary != "SUMMARY-2" || session.SummarizedTurns != 5 {
+		t.Errorf("expected stored summary SUMMARY-2 covering 5 turns, got %q covering %d", session.ConversationSummary, session.SummarizedTurns)
+	}
+
+	// The evaluation gets the summary along with the full transcript
+	expectHTTPError(t, router, "POST", "/api/chat/"+ids.SessionID+"/end", nil, http.StatusOK)
+	evalRequests := provider.EvaluationRequests()
+	if len(evalRequests) != 1 {
+		t.Fatalf("expected 1 evaluation request, got %d", len(evalRequests))
+	}
+	if evalRequests[0].ConversationSummary != "SUMMARY-2" || len(evalRequests[0].Answers) != 4 {
+		t.Errorf("expected evaluation with SUMMARY-2 and all 4 answers, got %q with %d answers",
+			evalRequests[0].ConversationSummary, len(evalRequests[0].Answers))
+	}
+}
+
+// requestContains reports whether any message of a provider request contains text
+func requestContains(req *ai.ChatRequest, text string) bool {
+	for _, msg := range req.Messages {
+		if strings.Contains(msg.Content, text) {
+			return true
+		}
+	}
+	return false
+}
+
+func TestSendMessageHandler_ShortMessageNotSummarized(t *testing.T) {
+	provider := ai.NewMockProvider()
+	router := setupTestRouterWithProvider(provider, nil)
+	ids := createTestInterviewAndSession(t, router)
+
+	sendMessage(t, router, ids.SessionID, "Short answer")
+
+	for _, req := range provider.ChatRequests() {
+		if req.Context["task"] == ai.TaskSummarization {
+			t.Errorf("short message should not be summarized")
+		}
+	}
+}
+
+func TestSendMessageHandler_ClientMessageID(t *testing.T) {
+	router := setupTestRouter()
+	ids := createTestInterviewAndSession(t, router)
+	clientID := "3F2504E0-4F89-11D3-9A0C-0305E82C3301"
+
+	send := func(message, clientMessageID string) *httptest.ResponseRecorder {
+		body, _ := json.Marshal(SendMessageRequestDTO{Message: message, ClientMessageID: clientMessageID})
+		req := httptest.NewRequest("POST", "/api/chat/"+ids.SessionID+"/message", bytes.NewReader(body))
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+	decode := func(w *httptest.ResponseRecorder) SendMessageResponseDTO {
+		t.Helper()
+		var resp SendMessageResponseDTO
+		if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		return resp
+	}
+
+	// First send stores and echoes the canonical client ID
+	w := send("My answer", clientID)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200 OK, got %d: %s", w.Code, w.Body.String())
+	}
+	first := decode(w)
+	if first.Message.ClientMessageID != strings.ToLower(clientID) {
+		t.Errorf("expected client message ID to be echoed, got %q", first.Message.ClientMessageID)
+	}
+
+	// Exact resend returns the stored exchange without creating new messages
+	w = send("My answer", clientID)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200 OK on resend, got %d: %s", w.Code, w.Body.String())
+	}
+	resend := decode(w)
+	if resend.Message.ID != first.Message.ID || resend.AIResponse == nil || resend.AIResponse.ID != first.AIResponse.ID {
+		t.Errorf("expected resend to return the original messages, got %+v", resend)
+	}
+	messages, _ := router.store.GetChatMessages(ids.SessionID)
+	if len(messages) != 3 {
+		t.Errorf("expected 3 messages (greeting, user, ai) after resend, got %d", len(messages))
+	}
+
+	// Same ID with different content is rejected
+	assertErrorResponse(t, router, "POST", "/api/chat/"+ids.SessionID+"/message",
+		`{"message":"Different answer","client_message_id":"`+clientID+`"}`, http.StatusUnprocessableEntity, ErrCodeConflict)
+
+	// Malformed IDs are ignored and the message is processed normally
+	w = send("Another answer", "not-a-uuid")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200 OK with malformed ID, got %d", w.Code)
+	}
+	if resp := decode(w); resp.Message.ClientMessageID != "" {
+		t.Errorf("expected malformed client ID to be dropped, got %q", resp.Message.ClientMessageID)
+	}
+	w = send("Another answer", "not-a-uuid")
+	if resp := decode(w); w.Code != http.StatusOK || resp.Message.ID == first.Message.ID {
+		t.Errorf("expected malformed ID resend to be treated as a new message")
+	}
+}
+
+func TestSendMessageHandler_ClientMessageIDResumesUnansweredMessage(t *testing.T) {
+	router := setupTestRouter()
+	ids := createTestInterviewAndSession(t, router)
+	clientID := data.GenerateID()
+	body := `{"message":"My answer","client_message_id":"` + clientID + `"}`
+
+	// The first attempt stores the user message but the AI call fails
+	failingRouter := newTestRouter(router.store, &failingProvider{ai.NewMockProvider()}, nil)
+	assertErrorResponse(t, failingRouter, "POST", "/api/chat/"+ids.SessionID+"/message", body,
+		http.StatusInternalServerError, ErrCodeAIUnavailable)
+
+	// The retry answers the stored message instead of adding a second copy
+	resp := sendMessageRaw(t, router, ids.SessionID, body)
+	if resp.AIResponse == nil {
+		t.Fatal("expected AI response on retry")
+	}
+	messages, _ := router.store.GetChatMessages(ids.SessionID)
+	userCount := 0
+	for _, msg := range messages {
+		if msg.Type == "user" {
+			userCount++
+		}
+	}
+	if userCount != 1 {
+		t.Errorf("expected exactly 1 stored user message, got %d", userCount)
+	}
+}
+
+// sendMessageRaw posts a raw JSON body to the message endpoint and expects success
+func sendMessageRaw(t *testing.T, router http.Handler, sessionID, body string) SendMessageResponseDTO {
+	t.Helper()
+	req := httptest.NewRequest("POST", "/api/chat/"+sessionID+"/message", strings.NewReader(body))
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200 OK, got %d: %s", w.Code, w.Body.String())
+	}
+	var resp SendMessageResponseDTO
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	return resp
+}
+
+func TestSendMessageHandler_Timings(t *testing.T) {
+	provider := ai.NewMockProvider()
+	provider.SetDelay(30 * time.Millisecond)
+	router := setupTestRouterWithProvider(provider, nil)
+	ids := createTestInterviewAndSession(t, router)
+
+	route := "POST /api/chat/{sessionId}/message"
+	before := histogramSampleCount(t, route, stageProvider)
+
+	resp := sendMessage(t, router, ids.SessionID, "My answer")
+	if resp.Timings == nil {
+		t.Fatal("expected timings in response")
+	}
+	timings := resp.Timings
+	if timings.ProviderMs < 30 {
+		t.Errorf("expected provider_ms to include the 30ms mock delay, got %d", timings.ProviderMs)
+	}
+	if timings.ProviderMs > timings.TotalMs || timings.StoreMs > timings.TotalMs || timings.QueueMs > timings.TotalMs {
+		t.Errorf("expected each stage to be at most total_ms, got %+v", timings)
+	}
+	if timings.QueueMs != 0 {
+		t.Errorf("expected queue_ms 0 without a rate limiter, got %d", timings.QueueMs)
+	}
+
+	if after := histogramSampleCount(t, route, stageProvider); after != before+1 {
+		t.Errorf("expected provider histogram to gain one sample, got %d -> %d", before, after)
+	}
+
+	// The metrics endpoint exposes the histogram with the route label
+	req := httptest.NewRequest("GET", "/metrics", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), `route="POST /api/chat/{sessionId}/message"`) {
+		t.Errorf("expected /metrics to expose the send message route label, got %d", w.Code)
+	}
+}
+
+// histogramSampleCount returns how many observations the stage histogram has for a route
+func histogramSampleCount(t *testing.T, route, stage string) uint64 {
+	t.Helper()
+	var metric dto.Metric
+	observer, err := requestStageDuration.GetMetricWithLabelValues(route, stage)
+	if err != nil {
+		t.Fatalf("failed to get histogram: %v", err)
+	}
+	if err := observer.(prometheus.Metric).Write(&metric); err != nil {
+		t.Fatalf("failed to read histogram: %v", err)
+	}
+	return metric.GetHistogram().GetSampleCount()
+}
+
+func TestUpdateChatSessionHandler_LanguageSwitch(t *testing.T) {
+	provider := ai.NewMockProvider()
+	router := setupTestRouterWithProvider(provider, nil)
+	ids := createTestInterviewAndSession(t, router)
+
+	sendMessage(t, router, ids.SessionID, "First answer in English")
+	lastSystemPrompt := func() string {
+		requests := provider.ChatRequests()
+		return requests[len(requests)-1].Messages[0].Content
+	}
+	if strings.Contains(lastSystemPrompt(), "Traditional Chinese") {
+		t.Fatal("expected English system prompt before the switch")
+	}
+
+	session := patchSessionLanguage(t, router, ids.SessionID, "zh-TW", http.StatusOK)
+	if session.SessionLanguage != "zh-TW" {
+		t.Errorf("expected session language zh-TW, got %q", session.SessionLanguage)
+	}
+	last := session.Messages[len(session.Messages)-1]
+	if last.Type != "system" || !strings.Contains(last.Content, "from en to zh-TW") {
+		t.Errorf("expected a system message noting the switch, got %+v", last)
+	}
+
+	// The next AI turn uses the new language's system prompt and omits the system note
+	sendMessage(t, router, ids.SessionID, "第二個回答")
+	if !strings.Contains(lastSystemPrompt(), "Traditional Chinese") {
+		t.Error("expected Traditional Chinese system prompt after the switch")
+	}
+	requests := provider.ChatRequests()
+	for _, msg := range requests[len(requests)-1].Messages[1:] {
+		if msg.Role == "system" {
+			t.Errorf("expected system notes to be excluded from conversation history, got %q", msg.Content)
+		}
+	}
+
+	// Switching to the current language is a no-op
+	before, _ := router.store.GetChatMessages(ids.SessionID)
+	patchSessionLanguage(t, router, ids.SessionID, "zh-TW", http.StatusOK)
+	after, _ := router.store.GetChatMessages(ids.SessionID)
+	if len(after) != len(before) {
+		t.Errorf("expected no new messages for a same-language switch, got %d -> %d", len(before), len(after))
+	}
+
+	// The evaluation prompt mentions the switch
+	expectHTTPError(t, router, "POST", "/api/chat/"+ids.SessionID+"/end", nil, http.StatusOK)
+	evalRequests := provider.EvaluationRequests()
+	if len(evalRequests) != 1 || len(evalRequests[0].SessionNotes) != 1 || evalRequests[0].Language != "zh-TW" {
+		t.Errorf("expected evaluation in zh-TW with one session note, got %+v", evalRequests)
+	}
+}
+
+func TestUpdateChatSessionHandler_Errors(t *testing.T) {
+	router := setupTestRouter()
+	ids := createTestInterviewAndSession(t, router)
+
+	assertErrorResponse(t, router, "PATCH", "/api/chat/"+ids.SessionID, "{", http.StatusBadRequest, ErrCodeInvalidJSON)
+	assertErrorResponse(t, router, "PATCH", "/api/chat/"+ids.SessionID, `{"session_language":"fr"}`, http.StatusBadRequest, ErrCodeValidationFailed)
+	assertErrorResponse(t, router, "PATCH", "/api/chat/nonexistent", `{"session_language":"zh-TW"}`, http.StatusNotFound, ErrCodeNotFound)
+
+	// Only active sessions can switch, but a same-language request is still a no-op
+	expectHTTPError(t, router, "POST", "/api/chat/"+ids.SessionID+"/end", nil, http.StatusOK)
+	assertErrorResponse(t, router, "PATCH", "/api/chat/"+ids.SessionID, `{"session_language":"zh-TW"}`, http.StatusConflict, ErrCodeConflict)
+	patchSessionLanguage(t, router, ids.SessionID, "en", http.StatusOK)
+}
+
+// patchSessionLanguage sends a language switch request and decodes the session on success
+func patchSessionLanguage(t *testing.T, router http.Handler, sessionID, language string, expectedStatus int) ChatInterviewSessionDTO {
+	t.Helper()
+	body, _ := json.Marshal(UpdateChatSessionRequestDTO{SessionLanguage: language})
+	req := httptest.NewRequest("PATCH", "/api/chat/"+sessionID, bytes.NewReader(body))
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Code != expectedStatus {
+		t.Fatalf("expected %d, got %d: %s", expectedStatus, w.Code, w.Body.String())
+	}
+	var session ChatInterviewSessionDTO
+	if expectedStatus == http.StatusOK {
+		if err := json.Unmarshal(w.Body.Bytes(), &session); err != nil {
+			t.Fatalf("failed to decode session: %v", err)
+		}
+	}
+	return session
+}
+
+func TestChatSession_ProviderAttribution(t *testing.T) {
+	router := setupTestRouter()
+	ids := createTestInterviewAndSession(t, router)
+
+	// Session attribution is always included; message attribution only with ?include=meta
+	session := getChatSession(t, router, ids.SessionID, "")
+	if session.Provider != "mock" || session.Model != "mock-model" {
+		t.Errorf("expected session provider/model mock/mock-model, got %q/%q", session.Provider, session.Model)
+	}
+	if session.Messages[0].Provider != "" {
+		t.Errorf("expected message attribution to be omitted by default, got %q", session.Messages[0].Provider)
+	}
+
+	sendMessage(t, router, ids.SessionID, "My answer")
+	session = getChatSession(t, router, ids.SessionID, "?include=meta")
+	for _, msg := range session.Messages {
+		wantProvider := "mock"
+		if msg.Type == "user" {
+			wantProvider = ""
+		}
+		if msg.Provider != wantProvider {
+			t.Errorf("expected %s message provider %q, got %q", msg.Type, wantProvider, msg.Provider)
+		}
+	}
+
+	// The evaluation records which provider/model scored it, and it round-trips through GET
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("POST", "/api/chat/"+ids.SessionID+"/end", nil))
+	var evaluation EvaluationResponseDTO
+	if err := json.Unmarshal(w.Body.Bytes(), &evaluation); err != nil {
+		t.Fatalf("failed to decode evaluation: %v", err)
+	}
+	if evaluation.Provider != "mock" || evaluation.Model != "mock-model" {
+		t.Errorf("expected evaluation provider/model mock/mock-model, got %q/%q", evaluation.Provider, evaluation.Model)
+	}
+	assertEvaluationAttribution(t, router, evaluation.ID, "mock", "mock-model")
+}
+
+func TestSubmitEvaluationHandler_ProviderAttribution(t *testing.T) {
+	router := setupTestRouter()
+	interview := createTestInterview(t, router, testsupport.NewInterviewBuilder().WithCandidate("Attribution Candidate"))
+
+	body, _ := json.Marshal(SubmitEvaluationRequestDTO{
+		InterviewID: interview.ID,
+		Answers:     map[string]string{"question_0": "A1"},
+	})
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("POST", "/api/evaluation", bytes.NewReader(body)))
+	var evaluation EvaluationResponseDTO
+	if err := json.Unmarshal(w.Body.Bytes(), &evaluation); err != nil {
+		t.Fatalf("failed to decode evaluation: %v", err)
+	}
+	if evaluation.Provider != "mock" || evaluation.Model != "mock-model" {
+		t.Errorf("expected evaluation provider/model mock/mock-model, got %q/%q", evaluation.Provider, evaluation.Model)
+	}
+	assertEvaluationAttribution(t, router, evaluation.ID, "mock", "mock-model")
+}
+
+func TestEndChatSessionHandler_NoAnswers(t *testing.T) {
+	tests := []struct {
+		name    string
+		prepare func(t *testing.T, router http.Handler, sessionID string)
+	}{
+		{
+			name:    "greeting only",
+			prepare: func(t *testing.T, router http.Handler, sessionID string) {},
+		},
+		{
+			name: "system messages only",
+			prepare: func(t *testing.T, router http.Handler, sessionID string) {
+				patchSessionLanguage(t, router, sessionID, "zh-TW", http.StatusOK)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := ai.NewMockProvider()
+			router := setupTestRouterWithProvider(provider, nil)
+			ids := createTestInterviewAndSession(t, router)
+			tt.prepare(t, router, ids.SessionID)
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest("POST", "/api/chat/"+ids.SessionID+"/end", nil))
+			if w.Code != http.StatusOK {
+				t.Fatalf("expected 200 OK, got %d: %s", w.Code, w.Body.String())
+			}
+
+			var evaluation EvaluationResponseDTO
+			if err := json.Unmarshal(w.Body.Bytes(), &evaluation); err != nil {
+				t.Fatalf("failed to decode evaluation: %v", err)
+			}
+			if evaluation.Status != data.EvaluationStatusNoAnswers {
+				t.Errorf("expected status %q, got %q", data.EvaluationStatusNoAnswers, evaluation.Status)
+			}
+			if evaluation.Score != 0 {
+				t.Errorf("expected score 0, got %v", evaluation.Score)
+			}
+			if evaluation.Feedback != noAnswersFeedback {
+				t.Errorf("expected feedback %q, got %q", noAnswersFeedback, evaluation.Feedback)
+			}
+			if n := len(provider.EvaluationRequests()); n != 0 {
+				t.Errorf("expected no evaluation requests to the provider, got %d", n)
+			}
+
+			if session := getChatSession(t, router, ids.SessionID, ""); session.Status != "completed" {
+				t.Errorf("expected session to be completed, got %q", session.Status)
+			}
+		})
+	}
+}
+
+func TestEndChatSessionHandler_AfterAutoEnd(t *testing.T) {
+	provider := ai.NewMockProvider()
+	router := setupTestRouterWithProvider(provider, nil)
+	ids := createTestInterviewAndSession(t, router)
+
+	var resp SendMessageResponseDTO
+	for i := 0; i < ai.InterviewMessageLimit; i++ {
+		resp = sendMessage(t, router, ids.SessionID, fmt.Sprintf("Answer %d", i+1))
+	}
+	if resp.SessionStatus != "completed" {
+		t.Fatalf("expected the session to end automatically, got %q", resp.SessionStatus)
+	}
+
+	// The auto-ended session is evaluated through the same end path, under the same policy
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("POST", "/api/chat/"+ids.SessionID+"/end", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200 OK, got %d: %s", w.Code, w.Body.String())
+	}
+	var evaluation EvaluationResponseDTO
+	if err := json.Unmarshal(w.Body.Bytes(), &evaluation); err != nil {
+		t.Fatalf("failed to decode evaluation: %v", err)
+	}
+	if evaluation.Status != data.EvaluationStatusCompleted {
+		t.Errorf("expected an AI-scored evaluation, got status %q", evaluation.Status)
+	}
+	if n := len(provider.EvaluationRequests()); n != 1 {
+		t.Errorf("expected 1 evaluation request to the provider, got %d", n)
+	}
+}
+
+func TestEndChatSessionHandler_CompletedStatus(t *testing.T) {
+	router := setupTestRouter()
+	ids := createTestInterviewAndSession(t, router)
+	sendMessage(t, router, ids.SessionID, "My answer")
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("POST", "/api/chat/"+ids.SessionID+"/end", nil))
+	var evaluation EvaluationResponseDTO
+	if err := json.Unmarshal(w.Body.Bytes(), &evaluation); err != nil {
+		t.Fatalf("failed to decode evaluation: %v", err)
+	}
+	if evaluation.Status != data.EvaluationStatusCompleted {
+		t.Errorf("expected status %q, got %q", data.EvaluationStatusCompleted, evaluation.Status)
+	}
+}
+
+func TestChatSession_LanguageMismatchFlag(t *testing.T) {
+	// The greeting comes back in English twice (original and retry), the next reply in Chinese
+	provider := ai.NewScriptedMockProvider("Welcome! Tell me about yourself?", "Hello! Please introduce yourself?", "請說明你最近的專案。")
+	router := setupTestRouterWithProvider(provider, nil)
+	interview := createTestInterview(t, router, testsupport.NewInterviewBuilder().WithCandidate("Mismatch Candidate"))
+	session := startChatSession(t, router, testsupport.NewSessionBuilder().ForInterviewID(interview.ID).WithLanguage("zh-TW"))
+
+	if got := session.Messages[0].Metadata[data.MessageMetaLanguageMismatch]; got != "true" {
+		t.Errorf("expected greeting to be flagged as a language mismatch, got metadata %v", session.Messages[0].Metadata)
+	}
+
+	resp := sendMessage(t, router, session.ID, "我的答案")
+	if resp.AIResponse == nil || resp.AIResponse.Metadata != nil {
+		t.Errorf("expected an AI reply without metadata in the session language, got %+v", resp.AIResponse)
+	}
+
+	// The flag is persisted and returned when the session is fetched again
+	stored := getChatSession(t, router, session.ID, "")
+	if got := stored.Messages[0].Metadata[data.MessageMetaLanguageMismatch]; got != "true" {
+		t.Errorf("expected stored greeting to keep the mismatch flag, got metadata %v", stored.Messages[0].Metadata)
+	}
+}
+
+func TestSendMessageHandler_PIIRedaction(t *testing.T) {
+	provider := ai.NewMockProvider()
+	router := setupTestRouterWithProvider(provider, func(deps *HandlerDependencies) {
+		deps.newAIClient = func(r *http.Request) *ai.AIClient {
+			return ai.NewAIClientWithProvider(provider, &ai.AIConfig{DefaultModel: "mock-model", RedactPII: true})
+		}
+	})
+	ids := createTestInterviewAndSession(t, router)
+
+	resp := sendMessage(t, router, ids.SessionID, "You can reach me at jane@example.com")
+	if resp.Message.Content != "You can reach me at jane@example.com" {
+		t.Errorf("expected the stored message to keep the original text, got %q", resp.Message.Content)
+	}
+
+	requests := provider.ChatRequests()
+	messages := requests[len(requests)-1].Messages
+	if last := messages[len(messages)-1].Content; last != "You can reach me at [EMAIL_1]" {
+		t.Errorf("expected the provider payload to be redacted, got %q", last)
+	}
+
+	// The mapping is kept server-side for audit but never returned to clients
+	stored, err := router.store.GetChatMessages(ids.SessionID)
+	if err != nil {
+		t.Fatalf("failed to get chat messages: %v", err)
+	}
+	reply := stored[len(stored)-1]
+	if reply.Metadata[data.MessageMetaRedactions] != `{"[EMAIL_1]":"jane@example.com"}` {
+		t.Errorf("expected the redaction mapping on the AI reply, got metadata %v", reply.Metadata)
+	}
+	if resp.AIResponse == nil || resp.AIResponse.Metadata[data.MessageMetaRedactions] != "" {
+		t.Errorf("expected no redaction mapping in the response, got %+v", resp.AIResponse)
+	}
+	for _, msg := range getChatSession(t, router, ids.SessionID, "").Messages {
+		if msg.Metadata[data.MessageMetaRedactions] != "" {
+			t.Errorf("expected no redaction mapping in the fetched session, got %v", msg.Metadata)
+		}
+	}
+}
+
+func TestChatSession_EstimatedCost(t *testing.T) {
+	// Price the mock so each chat turn (10 prompt + 20 completion tokens) costs $0.05,
+	// as does each adaptive answer assessment, and each evaluation (50 prompt + 150 completion tokens) costs $0.35
+	prices := map[string]ai.ModelPrice{"mock-model": {PromptPerMillion: 1000, CompletionPerMillion: 2000}}
+	router := setupTestRouterWithProvider(ai.NewMockProvider(), func(deps *HandlerDependencies) {
+		deps.AdminToken = "admin-secret"
+		deps.newAIClient = func(r *http.Request) *ai.AIClient {
+			return ai.NewAIClientWithProvider(ai.NewMockProvider(), &ai.AIConfig{DefaultModel: "mock-model", ModelPrices: prices})
+		}
+	})
+	interview := createTestInterview(t, router, testsupport.NewInterviewBuilder().WithCandidate("Cost Candidate"))
+
+	session := startChatSession(t, router, testsupport.NewSessionBuilder().ForInterviewID(interview.ID))
+	assertCost(t, "session after greeting", session.EstimatedCostUSD, 0.05)
+
+	sendMessage(t, router, session.ID, "My answer")
+	assertCost(t, "session after reply", getChatSession(t, router, session.ID, "").EstimatedCostUSD, 0.15)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("POST", "/api/chat/"+session.ID+"/end", nil))
+	var evaluation EvaluationResponseDTO
+	if err := json.Unmarshal(w.Body.Bytes(), &evaluation); err != nil {
+		t.Fatalf("failed to decode evaluation: %v", err)
+	}
+	assertCost(t, "evaluation", evaluation.EstimatedCostUSD, 0.35)
+	// The session total covers the conversation only, so ending doesn't change it
+	assertCost(t, "session after end", getChatSession(t, router, session.ID, "").EstimatedCostUSD, 0.15)
+
+	// Admins read the interview total from the stats endpoint
+	req := httptest.NewRequest("GET", "/api/admin/stats?interview_id="+interview.ID, nil)
+	req.Header.Set("Authorization", "Bearer admin-secret")
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	var stats AdminStatsResponseDTO
+	if err := json.Unmarshal(w.Body.Bytes(), &stats); err != nil {
+		t.Fatalf("failed to decode stats: %v", err)
+	}
+	if stats.InterviewCost == nil || stats.InterviewCost.InterviewID != interview.ID {
+		t.Fatalf("expected interview cost for %s, got %s", interview.ID, w.Body.String())
+	}
+	assertCost(t, "interview total", stats.InterviewCost.EstimatedCostUSD, 0.50)
+
+	req = httptest.NewRequest("GET", "/api/admin/stats?interview_id=missing", nil)
+	req.Header.Set("Authorization", "Bearer admin-secret")
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected 404 for unknown interview, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+// assertCost compares estimated costs with a tolerance for floating point sums
+func assertCost(t *testing.T, what string, got, want float64) {
+	t.Helper()
+	if math.Abs(got-want) > 1e-9 {
+		t.Errorf("expected %s cost %v, got %v", what, want, got)
+	}
+}
+
+func TestChatHandlers_CancelledRequestAbortsAICall(t *testing.T) {
+	provider := ai.NewMockProvider()
+	router := setupTestRouterWithProvider(provider, nil)
+	ids := createTestInterviewAndSession(t, router)
+	sendMessage(t, router, ids.SessionID, "My answer")
+
+	// From here on the provider hangs far longer than the client waits
+	provider.SetDelay(time.Minute)
+	tests := []struct {
+		name string
+		path string
+		body string
+	}{
+		{"send message", "/api/chat/" + ids.SessionID + "/message", `{"message":"Another answer"}`},
+		{"end session", "/api/chat/" + ids.SessionID + "/end", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			time.AfterFunc(50*time.Millisecond, cancel)
+			req := httptest.NewRequest("POST", tt.path, strings.NewReader(tt.body)).WithContext(ctx)
+			w := httptest.NewRecorder()
+
+			start := time.Now()
+			router.ServeHTTP(w, req)
+			if elapsed := time.Since(start); elapsed > time.Second {
+				t.Errorf("expected the AI call to stop with the request, took %v", elapsed)
+			}
+			if w.Code != http.StatusInternalServerError {
+				t.Errorf("expected 500 after cancellation, got %d: %s", w.Code, w.Body.String())
+			}
+		})
+	}
+}
+
+// getChatSession fetches a chat session, optionally with a query string such as "?include=meta"
+func getChatSession(t *testing.T, router http.Handler, sessionID, query string) ChatInterviewSessionDTO {
+	t.Helper()
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/api/chat/"+sessionID+query, nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200 OK, got %d: %s", w.Code, w.Body.String())
+	}
+	var session ChatInterviewSessionDTO
+	if err := json.Unmarshal(w.Body.Bytes(), &session); err != nil {
+		t.Fatalf("failed to decode session: %v", err)
+	}
+	return session
+}
+
+// assertEvaluationAttribution checks the stored evaluation's provider/model via GET /api/evaluation/{id}
+func assertEvaluationAttribution(t *testing.T, router http.Handler, evaluationID, provider, model string) {
+	t.Helper()
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/api/evaluation/"+evaluationID, nil))
+	var stored EvaluationResponseDTO
+	if err := json.Unmarshal(w.Body.Bytes(), &stored); err != nil {
+		t.Fatalf("failed to decode stored evaluation: %v", err)
+	}
+	if stored.Provider != provider || stored.Model != model {
+		t.Errorf("expected stored provider/model %q/%q, got %q/%q", provider, model, stored.Provider, stored.Model)
+	}
+}
+
+// ============================================
+// ERROR ENVELOPE TESTS
+// ============================================
+
+// failingProvider is a mock provider whose AI calls always fail
+type failingProvider struct {
+	*ai.MockProvider
+}
+
+func (p *failingProvider) GenerateResponse(ctx context.Context, req *ai.ChatRequest) (*ai.ChatResponse, error) {
+	return nil, fmt.Errorf("provider unavailable")
+}
+
+func (p *failingProvider) EvaluateAnswers(ctx context.Context, req *ai.EvaluationRequest) (*ai.EvaluationResponse, error) {
+	return nil, fmt.Errorf("provider unavailable")
+}
+
+func TestErrorResponses_StatusAndCode(t *testing.T) {
+	router := setupTestRouter()
+	ids := createTestInterviewAndSession(t, router)
+
+	completed := createTestInterviewAndSession(t, router)
+	expectHTTPError(t, router, "POST", "/api/chat/"+completed.SessionID+"/end", nil, http.StatusOK)
+
+	tests := []struct {
+		name           string
+		method         string
+		path           string
+		body           string
+		expectedStatus int
+		expectedCode   ErrorCode
+	}{
+		{"create interview invalid json", "POST", "/api/interviews", "{", http.StatusBadRequest, ErrCodeInvalidJSON},
+		{"create interview missing fields", "POST", "/api/interviews", "{}", http.StatusBadRequest, ErrCodeValidationFailed},
+		{"create interview invalid type", "POST", "/api/interviews", `{"candidate_name":"A","questions":["Q1"],"interview_type":"x"}`, http.StatusBadRequest, ErrCodeValidationFailed},
+		{"get interview not found", "GET", "/api/interviews/nonexistent", "", http.StatusNotFound, ErrCodeNotFound},
+		{"submit evaluation invalid json", "POST", "/api/evaluation", "{", http.StatusBadRequest, ErrCodeInvalidJSON},
+		{"submit evaluation missing fields", "POST", "/api/evaluation", "{}", http.StatusBadRequest, ErrCodeValidationFailed},
+		{"submit evaluation unknown interview", "POST", "/api/evaluation", `{"interview_id":"nonexistent","answers":{"q":"a"}}`, http.StatusNotFound, ErrCodeNotFound},
+		{"get evaluation not found", "GET", "/api/evaluation/nonexistent", "", http.StatusNotFound, ErrCodeNotFound},
+		{"start chat unknown interview", "POST", "/api/interviews/nonexistent/chat/start", "{}", http.StatusNotFound, ErrCodeNotFound},
+		{"send message invalid json", "POST", "/api/chat/" + ids.SessionID + "/message", "{", http.StatusBadRequest, ErrCodeInvalidJSON},
+		{"send message empty", "POST", "/api/chat/" + ids.SessionID + "/message", `{"message":""}`, http.StatusBadRequest, ErrCodeValidationFailed},
+		{"send message unknown session", "POST", "/api/chat/nonexistent/message", `{"message":"hi"}`, http.StatusNotFound, ErrCodeNotFound},
+		{"send message to completed session", "POST", "/api/chat/" + completed.SessionID + "/message", `{"message":"hi"}`, http.StatusConflict, ErrCodeConflict},
+		{"get chat session not found", "GET", "/api/chat/nonexistent", "", http.StatusNotFound, ErrCodeNotFound},
+		{"end chat session not found", "POST", "/api/chat/nonexistent/end", "", http.StatusNotFound, ErrCodeNotFound},
+		{"unknown api route", "GET", "/api/unknown", "", http.StatusNotFound, ErrCodeNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertErrorResponse(t, router, tt.method, tt.path, tt.body, tt.expectedStatus, tt.expectedCode)
+		})
+	}
+}
+
+func TestErrorResponses_AIUnavailable(t *testing.T) {
+	router := setupTestRouter()
+	ids := createTestInterviewAndSession(t, router)
+
+	failingRouter := newTestRouter(router.store, &failingProvider{ai.NewMockProvider()}, nil)
+	assertErrorResponse(t, failingRouter, "POST", "/api/interviews/"+ids.InterviewID+"/chat/start", "{}",
+		http.StatusInternalServerError, ErrCodeAIUnavailable)
+	assertErrorResponse(t, failingRouter, "POST", "/api/chat/"+ids.SessionID+"/message", `{"message":"hi"}`,
+		http.StatusInternalServerError, ErrCodeAIUnavailable)
+	assertErrorResponse(t, failingRouter, "POST", "/api/evaluation", `{"interview_id":"`+ids.InterviewID+`","answers":{"question_0":"a"}}`,
+		http.StatusInternalServerError, ErrCodeAIUnavailable)
+}
+
+func TestErrorResponses_MessageTooLongCode(t *testing.T) {
+	router := setupTestRouterWithProvider(ai.NewMockProvider(), func(deps *HandlerDependencies) {
+		deps.MaxMessageLength = 10
+	})
+	ids := createTestInterviewAndSession(t, router)
+	body, _ := json.Marshal(SendMessageRequestDTO{Message: strings.Repeat("x", 11)})
+	assertErrorResponse(t, router, "POST", "/api/chat/"+ids.SessionID+"/message", string(body),
+		http.StatusRequestEntityTooLarge, ErrCodeValidationFailed)
+}
+
+func TestGetAIDebugCaptureHandler(t *testing.T) {
+	capture := ai.NewDebugCapture(5)
+	router := setupTestRouterWithProvider(ai.NewMockProvider(), func(deps *HandlerDependencies) {
+		deps.DebugCapture = capture
+		deps.AdminToken = "admin-secret"
+		deps.EnableDebugEndpoints = true
+		deps.newAIClient = func(r *http.Request) *ai.AIClient {
+			return ai.NewAIClientWithProvider(ai.NewMockProvider(), &ai.AIConfig{DefaultModel: "mock-model", DebugCapture: capture})
+		}
+	})
+	createTestInterviewAndSession(t, router)
+
+	assertErrorResponse(t, router, "GET", "/api/admin/ai/debug", "", http.StatusUnauthorized, ErrCodeUnauthorized)
+
+	req := httptest.NewRequest("GET", "/api/admin/ai/debug", nil)
+	req.Header.Set("Authorization", "Bearer admin-secret")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200 OK, got %d: %s", w.Code, w.Body.String())
+	}
+	var resp AIDebugCaptureResponseDTO
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to unmarshal debug capture response: %v", err)
+	}
+	if exchanges := resp.Providers["mock"]; len(exchanges) != 1 || exchanges[0].Operation != ai.DebugOperationChat {
+		t.Errorf("expected the session greeting to be captured, got %+v", resp.Providers)
+	}
+
+	noTokenRouter := setupTestRouterWithProvider(ai.NewMockProvider(), func(deps *HandlerDependencies) {
+		deps.DebugCapture = capture
+		deps.EnableDebugEndpoints = true
+	})
+	assertErrorResponse(t, noTokenRouter, "GET", "/api/admin/ai/debug", "", http.StatusForbidden, ErrCodeForbidden)
+
+	disabledRouter := setupTestRouterWithProvider(ai.NewMockProvider(), func(deps *HandlerDependencies) {
+		deps.DebugCapture = capture
+		deps.AdminToken = "admin-secret"
+	})
+	req = httptest.NewRequest("GET", "/api/admin/ai/debug", nil)
+	req.Header.Set("Authorization", "Bearer admin-secret")
+	w = httptest.NewRecorder()
+	disabledRouter.ServeHTTP(w, req)
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected 404 when debug endpoints are disabled, got %d", w.Code)
+	}
+}
+
+func TestAIConcurrencyCap_Overloaded(t *testing.T) {
+	limiter := ai.NewConcurrencyLimiter(1, 10*time.Millisecond)
+	router := setupTestRouterWithProvider(ai.NewMockProvider(), func(deps *HandlerDependencies) {
+		deps.AILimiter = limiter
+		deps.AdminToken = "admin-secret"
+		deps.EnableDebugEndpoints = true
+		deps.newAIClient = func(r *http.Request) *ai.AIClient {
+			return ai.NewAIClientWithProvider(ai.NewMockProvider(), &ai.AIConfig{Limiter: limiter})
+		}
+	})
+	interview := createTestInterview(t, router, testsupport.NewInterviewBuilder())
+
+	// Another request holds the only slot
+	release, err := limiter.Acquire(context.Background())
+	if err != nil {
+		t.Fatalf("failed to take the slot: %v", err)
+	}
+
+	req := httptest.NewRequest("GET", "/api/admin/ai/debug", nil)
+	req.Header.Set("Authorization", "Bearer admin-secret")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	var debug AIDebugCaptureResponseDTO
+	if err := json.Unmarshal(w.Body.Bytes(), &debug); err != nil || w.Code != http.StatusOK {
+		t.Fatalf("expected the debug endpoint to respond, got %d: %s", w.Code, w.Body.String())
+	}
+	if debug.Concurrency == nil || debug.Concurrency.MaxConcurrent != 1 || debug.Concurrency.InFlight != 1 {
+		t.Errorf("expected one call in flight out of 1, got %+v", debug.Concurrency)
+	}
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("POST", "/api/interviews/"+interview.ID+"/chat/start", nil))
+	if w.Code != http.StatusServiceUnavailable || w.Header().Get("Retry-After") != "5" {
+		t.Fatalf("expected 503 with Retry-After, got %d (Retry-After %q): %s", w.Code, w.Header().Get("Retry-After"), w.Body.String())
+	}
+	var errResp ErrorResponseDTO
+	if err := json.Unmarshal(w.Body.Bytes(), &errResp); err != nil || errResp.Code != ErrCodeAIOverloaded {
+		t.Errorf("expected code %s, got %+v", ErrCodeAIOverloaded, errResp)
+	}
+
+	release()
+	startChatSession(t, router, testsupport.NewSessionBuilder().ForInterviewID(interview.ID))
+}
+
+// assertErrorResponse performs a request and checks both the HTTP status and the error envelope code
+func assertErrorResponse(t *testing.T, router http.Handler, method, path, body string, expectedStatus int, expectedCode ErrorCode) {
+	t.Helper()
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != expectedStatus {
+		t.Errorf("expected status %d, got %d: %s", expectedStatus, w.Code, w.Body.String())
+	}
+	var resp ErrorResponseDTO
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("expected JSON error envelope, got %q: %v", w.Body.String(), err)
+	}
+	if resp.Code != expectedCode {
+		t.Errorf("expected code %q, got %q", expectedCode, resp.Code)
+	}
+	if resp.Error == "" {
+		t.Error("expected non-empty error message")
+	}
+}
+
+// ============================================
+// ADDITIONAL EDGE CASE TESTS
+// ============================================
+
+func TestParseIntQuery_EdgeCases(t *testing.T) {
+	tests := []struct {
+		name         string
+		queryValue   string
+		defaultValue int
+		expected     int
+	}{
+		{"empty string", "", 10, 10},
+		{"valid positive", "5", 10, 5},
+		{"zero", "0", 10, 0},
+		{"negative", "-1", 10, 10}, // Should use default for negative
+		{"invalid string", "abc", 10, 10},
+		{"float", "5.5", 10, 10}, // Should use default for non-int
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "/?test="+tt.queryValue, nil)
+			result := parseIntQuery(req, "test", tt.defaultValue)
+			if result != tt.expected {
+				t.Errorf("expected %d, got %d", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestParseIntQueryWithMax(t *testing.T) {
+	tests := []struct {
+		name            string
+		queryValue      string
+		maxValue        int
+		expected        int
+		expectedWarning WarningCode
+	}{
+		{"below max", "5", 10, 5, ""},
+		{"at max", "10", 10, 10, ""},
+		{"above max is clamped", "50", 10, 10, WarnCodeClampedParameter},
+		{"no max", "50", 0, 50, ""},
+		{"missing uses default", "", 10, 3, ""},
+		{"negative warns", "-1", 10, 3, WarnCodeInvalidParameter},
+		{"non-numeric warns", "abc", 10, 3, WarnCodeInvalidParameter},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "/?test="+tt.queryValue, nil)
+			var warnings warningList
+			result := parseIntQueryWithMax(req, "test", 3, tt.maxValue, &warnings)
+			if result != tt.expected {
+				t.Errorf("expected %d, got %d", tt.expected, result)
+			}
+			if tt.expectedWarning == "" && len(warnings) != 0 {
+				t.Errorf("expected no warning, got %v", warnings)
+			}
+			if tt.expectedWarning != "" && (len(warnings) != 1 || warnings[0].Code != tt.expectedWarning || warnings[0].Field != "test") {
+				t.Errorf("expected one %s warning for test, got %v", tt.expectedWarning, warnings)
+			}
+		})
+	}
+
+	// Callers without warnings to collect pass nil
+	if got := parseIntQueryWithMax(httptest.NewRequest("GET", "/?test=x", nil), "test", 3, 0, nil); got != 3 {
+		t.Errorf("expected the default without a warning list, got %d", got)
+	}
+}
+
+func TestListInterviewsHandler_SortWarnings(t *testing.T) {
+	router := setupTestRouter()
+	createTestInterview(t, router, testsupport.NewInterviewBuilder().WithCandidate("Alice"))
+
+	list := func(query string) ListInterviewsResponseDTO {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("GET", "/api/interviews"+query, nil))
+		var resp ListInterviewsResponseDTO
+		if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		return resp
+	}
+
+	if resp := list("?sort_by=name&sort_order=asc"); len(resp.Warnings) != 0 {
+		t.Errorf("expected no warnings for valid sort values, got %v", resp.Warnings)
+	}
+	resp := list("?sort_by=salary&sort_order=sideways")
+	if resp.Total != 1 || len(resp.Warnings) != 2 {
+		t.Fatalf("expected the list with two warnings, got %d and %v", resp.Total, resp.Warnings)
+	}
+	for i, field := range []string{"sort_by", "sort_order"} {
+		if resp.Warnings[i].Code != WarnCodeInvalidParameter || resp.Warnings[i].Field != field {
+			t.Errorf("expected an invalid_parameter warning for %s, got %+v", field, resp.Warnings[i])
+		}
+	}
+}
+
+func TestSendMessageHandler_MessageLimit(t *testing.T) {
+	t.Run("reply taking the last slot closes the session", func(t *testing.T) {
+		router := setupTestRouterWithProvider(ai.NewMockProvider(), func(deps *HandlerDependencies) {
+			deps.MaxMessagesPerSession = 5
+		})
+		ids := createTestInterviewAndSession(t, router) // greeting: 1 message
+
+		if resp := sendMessage(t, router, ids.SessionID, "First answer"); resp.SessionStatus != "active" {
+			t.Fatalf("expected the session to stay active below the cap, got %q", resp.SessionStatus)
+		}
+		resp := sendMessage(t, router, ids.SessionID, "Second answer")
+		if resp.SessionStatus != "completed" || resp.AIResponse == nil || resp.AIResponse.Subtype != data.MessageSubtypeClosing {
+			t.Errorf("expected a closing reply completing the session at the cap, got status %q and reply %+v", resp.SessionStatus, resp.AIResponse)
+		}
+		if session := getChatSession(t, router, ids.SessionID, ""); len(session.Messages) != 5 {
+			t.Errorf("expected 5 stored messages, got %d", len(session.Messages))
+		}
+		assertErrorResponse(t, router, "POST", "/api/chat/"+ids.SessionID+"/message", `{"message":"Third answer"}`, http.StatusConflict, ErrCodeConflict)
+	})
+
+	t.Run("message without room for a reply completes the session", func(t *testing.T) {
+		router := setupTestRouterWithProvider(ai.NewMockProvider(), func(deps *HandlerDependencies) {
+			deps.MaxMessagesPerSession = 4
+		})
+		ids := createTestInterviewAndSession(t, router)
+		sendMessage(t, router, ids.SessionID, "First answer") // 3 messages
+
+		assertErrorResponse(t, router, "POST", "/api/chat/"+ids.SessionID+"/message", `{"message":"Second answer"}`, http.StatusConflict, ErrCodeConflict)
+		session := getChatSession(t, router, ids.SessionID, "")
+		if session.Status != "completed" {
+			t.Errorf("expected the session to be completed, got %q", session.Status)
+		}
+		if len(session.Messages) != 3 {
+			t.Errorf("expected the rejected message not to be stored, got %d messages", len(session.Messages))
+		}
+	})
+
+	t.Run("language switch note is capped like a message", func(t *testing.T) {
+		router := setupTestRouterWithProvider(ai.NewMockProvider(), func(deps *HandlerDependencies) {
+			deps.MaxMessagesPerSession = 4
+		})
+		ids := createTestInterviewAndSession(t, router)
+		sendMessage(t, router, ids.SessionID, "First answer") // 3 messages
+
+		patchSessionLanguage(t, router, ids.SessionID, "zh-TW", http.StatusConflict)
+		session := getChatSession(t, router, ids.SessionID, "")
+		if session.Status != "completed" || session.SessionLanguage != "en" {
+			t.Errorf("expected a completed session still in English, got %q/%q", session.Status, session.SessionLanguage)
+		}
+		if len(session.Messages) != 3 {
+			t.Errorf("expected the language note not to be stored, got %d messages", len(session.Messages))
+		}
+	})
+}
+
+func TestListChatMessagesHandler(t *testing.T) {
+	router := setupTestRouter()
+	ids := createTestInterviewAndSession(t, router)
+	sendMessage(t, router, ids.SessionID, "First answer")
+	sendMessage(t, router, ids.SessionID, "Second answer") // 5 messages
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/api/chat/"+ids.SessionID+"/messages?limit=2&offset=3", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200 OK, got %d: %s", w.Code, w.Body.String())
+	}
+	var page ListChatMessagesResponseDTO
+	if err := json.Unmarshal(w.Body.Bytes(), &page); err != nil {
+		t.Fatalf("failed to decode messages: %v", err)
+	}
+	if page.Total != 5 || page.Page != 2 || page.TotalPages != 3 || len(page.Messages) != 2 {
+		t.Errorf("unexpected page: total %d, page %d of %d, %d messages", page.Total, page.Page, page.TotalPages, len(page.Messages))
+	}
+	if len(page.Messages) > 0 && page.Messages[0].Content != "Second answer" {
+		t.Errorf("expected the window to start at the fourth message, got %q", page.Messages[0].Content)
+	}
+
+	assertErrorResponse(t, router, "GET", "/api/chat/nonexistent/messages", "", http.StatusNotFound, ErrCodeNotFound)
+}
+
+func TestMessageLimit_TruncatedTranscript(t *testing.T) {
+	provider := ai.NewMockProvider()
+	router := setupTestRouterWithProvider(provider, nil)
+	ids := createTestInterviewAndSession(t, router)
+	sendMessage(t, router, ids.SessionID, "First answer")
+	sendMessage(t, router, ids.SessionID, "Second answer") // 5 messages
+
+	// A lower cap applies to sessions stored before it was configured
+	capped := newTestRouter(router.store, provider, func(deps *HandlerDependencies) {
+		deps.MaxMessagesPerSession = 3
+	})
+	session := getChatSession(t, capped, ids.SessionID, "")
+	if len(session.Messages) != 3 || !session.MessagesTruncated || session.TotalMessages != 5 {
+		t.Errorf("expected a truncated session of 3 of 5 messages, got %d messages (truncated %v, total %d)",
+			len(session.Messages), session.MessagesTruncated, session.TotalMessages)
+	}
+
+	w := httptest.NewRecorder()
+	capped.ServeHTTP(w, httptest.NewRequest("POST", "/api/chat/"+ids.SessionID+"/end", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200 OK, got %d: %s", w.Code, w.Body.String())
+	}
+	requests := provider.EvaluationRequests()
+	if len(requests) != 1 {
+		t.Fatalf("expected 1 evaluation request, got %d", len(requests))
+	}
+	if answers := requests[0].Answers; len(answers) != 1 || answers[0] != "First answer" {
+		t.Errorf("expected only the answers within the window to be evaluated, got %v", answers)
+	}
+	notes := strings.Join(requests[0].SessionNotes, "\n")
+	if !strings.Contains(notes, "limit of 3 messages") || !strings.Contains(notes, "only the first 3 of 5 messages") {
+		t.Errorf("expected cap and truncation notes, got %q", notes)
+	}
+}
+
+func TestGetAdminStatsHandler_ScoresByModel(t *testing.T) {
+	router := setupTestRouterWithProvider(ai.NewMockProvider(), func(deps *HandlerDependencies) {
+		deps.AdminToken = "admin-secret"
+	})
+
+	evaluations := []*data.Evaluation{
+		{ID: "eval-1", Provider: "openai", Model: "gpt-4", Score: 80, Status: data.EvaluationStatusCompleted},
+		{ID: "eval-2", Provider: "openai", Model: "gpt-4", Score: 60, Status: data.EvaluationStatusCompleted},
+		{ID: "eval-3", Provider: "gemini", Model: "gemini-flash", Score: 90, Status: data.EvaluationStatusCompleted},
+		// Replaced by eval-5, so only the replacement counts
+		{ID: "eval-4", Provider: "gemini", Model: "gemini-flash", Score: 10, Status: data.EvaluationStatusCompleted},
+		{ID: "eval-5", Provider: "gemini", Model: "gemini-flash", Score: 70, Status: data.EvaluationStatusCompleted, SupersedesID: "eval-4"},
+		// Never scored by a model
+		{ID: "eval-6", Score: 0, Status: data.EvaluationStatusNoAnswers},
+	}
+	for _, evaluation := range evaluations {
+		evaluation.InterviewID = "interview-" + evaluation.ID
+		if err := router.store.CreateEvaluation(evaluation); err != nil {
+			t.Fatalf("failed to create evaluation: %v", err)
+		}
+	}
+
+	assertErrorResponse(t, router, "GET", "/api/admin/stats", "", http.StatusUnauthorized, ErrCodeUnauthorized)
+
+	req := httptest.NewRequest("GET", "/api/admin/stats", nil)
+	req.Header.Set("Authorization", "Bearer admin-secret")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200 OK, got %d: %s", w.Code, w.Body.String())
+	}
+	var resp AdminStatsResponseDTO
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to decode stats: %v", err)
+	}
+
+	expected := []ModelScoreStatsDTO{
+		{Provider: "gemini", Model: "gemini-flash", Evaluations: 2, AverageScore: 80},
+		{Provider: "openai", Model: "gpt-4", Evaluations: 2, AverageScore: 70},
+	}
+	if !reflect.DeepEqual(resp.ScoresByModel, expected) {
+		t.Errorf("expected scores %+v, got %+v", expected, resp.ScoresByModel)
+	}
+	if resp.InterviewCost != nil {
+		t.Errorf("expected no interview cost without interview_id, got %+v", resp.InterviewCost)
+	}
+}
+
+// seedCompletedSession stores an interview with one completed chat session holding the given answers
+func seedCompletedSession(t *testing.T, store data.Store, interviewID, sessionID string, createdAt time.Time, answers ...string) {
+	t.Helper()
+	if _, err := store.GetInterview(interviewID); err != nil {
+		testsupport.NewInterviewBuilder().WithID(interviewID).WithCandidate("Backfill "+interviewID).Create(t, store)
+	}
+	builder := testsupport.NewSessionBuilder().ForInterviewID(interviewID).WithID(sessionID).WithStatus("completed").WithStartedAt(createdAt)
+	for i, answer := range answers {
+		question := "Tell me more?"
+		if i == 0 {
+			question = "Welcome! Tell me about yourself?"
+		}
+		builder.WithTranscript(testsupport.Pair(question, answer))
+	}
+	builder.Create(t, store)
+}
+
+// runBackfill calls the evaluation backfill endpoint as an admin
+func runBackfill(t *testing.T, router http.Handler, query string) EvaluationBackfillResponseDTO {
+	t.Helper()
+	req := httptest.NewRequest("POST", "/api/admin/evaluations/backfill"+query, nil)
+	req.Header.Set("Authorization", "Bearer admin-secret")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200 OK, got %d: %s", w.Code, w.Body.String())
+	}
+	var resp EvaluationBackfillResponseDTO
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to decode backfill report: %v", err)
+	}
+	return resp
+}
+
+func TestBackfillEvaluationsHandler(t *testing.T) {
+	provider := ai.NewMockProvider()
+	router := setupTestRouterWithProvider(provider, func(deps *HandlerDependencies) {
+		deps.AdminToken = "admin-secret"
+		deps.BackfillWorkers = 2
+	})
+	now := time.Now()
+	seedCompletedSession(t, router.store, "interview-a", "session-a", now.Add(-3*time.Hour), "I build APIs")
+	seedCompletedSession(t, router.store, "interview-b", "session-b1", now.Add(-2*time.Hour))
+	seedCompletedSession(t, router.store, "interview-b", "session-b2", now.Add(-time.Hour), "Later answer")
+	seedCompletedSession(t, router.store, "interview-evaluated", "session-evaluated", now.Add(-4*time.Hour), "Already scored")
+	if err := router.store.CreateEvaluation(&data.Evaluation{ID: "eval-existing", InterviewID: "interview-evaluated", CreatedAt: now}); err != nil {
+		t.Fatalf("failed to create evaluation: %v", err)
+	}
+	active := createTestInterviewAndSession(t, router)
+
+	assertErrorResponse(t, router, "POST", "/api/admin/evaluations/backfill", "", http.StatusUnauthorized, ErrCodeUnauthorized)
+
+	// A dry run reports the candidates without evaluating anything
+	report := runBackfill(t, router, "?dry_run=true")
+	wantSessions := []string{"session-a", "session-b1", "session-b2"}
+	if len(report.Results) != len(wantSessions) {
+		t.Fatalf("expected %d sessions, got %+v", len(wantSessions), report.Results)
+	}
+	for i, result := range report.Results {
+		if result.SessionID != wantSessions[i] {
+			t.Errorf("result %d: expected session %s, got %s", i, wantSessions[i], result.SessionID)
+		}
+		if result.SessionID == active.SessionID {
+			t.Errorf("active session must not be a candidate")
+		}
+	}
+	if !report.DryRun || report.Results[0].Status != "candidate" || report.Results[2].Status != "skipped" {
+		t.Errorf("expected candidates and one skipped duplicate, got %+v", report)
+	}
+	if len(provider.EvaluationRequests()) != 0 {
+		t.Errorf("expected no evaluations on a dry run, got %d", len(provider.EvaluationRequests()))
+	}
+
+	report = runBackfill(t, router, "")
+	if report.Succeeded != 2 || report.Failed != 0 || report.Skipped != 1 {
+		t.Fatalf("expected 2 succeeded and 1 skipped, got %+v", report)
+	}
+	for _, result := range report.Results {
+		if result.Status != "succeeded" {
+			continue
+		}
+		evaluation, err := router.store.GetEvaluation(result.EvaluationID)
+		if err != nil || evaluation.InterviewID != result.InterviewID {
+			t.Errorf("expected a stored evaluation for %s, got %v (err %v)", result.InterviewID, evaluation, err)
+		}
+	}
+	// session-b1 has no answers: it is recorded as "no_answers" without calling the provider
+	if len(provider.EvaluationRequests()) != 1 {
+		t.Errorf("expected 1 provider evaluation, got %d", len(provider.EvaluationRequests()))
+	}
+	if latest, err := router.store.GetLatestEvaluationByInterview("interview-b"); err != nil || latest.Status != data.EvaluationStatusNoAnswers {
+		t.Errorf("expected interview-b to be evaluated from its oldest session, got %v (err %v)", latest, err)
+	}
+
+	// Evaluated interviews are no longer candidates
+	if report := runBackfill(t, router, ""); len(report.Results) != 0 {
+		t.Errorf("expected nothing left to backfill, got %+v", report.Results)
+	}
+}
+
+func TestBackfillEvaluationsHandler_SessionTimeout(t *testing.T) {
+	provider := ai.NewMockProvider()
+	provider.SetDelay(time.Second)
+	router := setupTestRouterWithProvider(provider, func(deps *HandlerDependencies) {
+		deps.AdminToken = "admin-secret"
+		deps.BackfillSessionTimeout = 10 * time.Millisecond
+	})
+	seedCompletedSession(t, router.store, "interview-slow", "session-slow", time.Now(), "A slow answer")
+
+	report := runBackfill(t, router, "?limit=5")
+	if report.Failed != 1 || report.Results[0].Status != "failed" || report.Results[0].Reason == "" {
+		t.Fatalf("expected the slow session to fail with a reason, got %+v", report)
+	}
+	if _, err := router.store.GetLatestEvaluationByInterview("interview-slow"); err == nil {
+		t.Error("expected no evaluation for a failed session")
+	}
+}
+
+// mockDatabaseRouter creates a test router around a sqlmock-backed database store, closed when the test ends
+func mockDatabaseRouter(t *testing.T) (*testRouter, sqlmock.Sqlmock) {
+	t.Helper()
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to open sqlmock database: %v", err)
+	}
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{Conn: db}), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to open gorm db with sqlmock: %v", err)
+	}
+	router := newTestRouter(data.NewHybridStoreWithDatabase(data.NewDatabaseService(gormDB)), ai.NewMockProvider(), nil)
+	t.Cleanup(func() {
+		db.Close()
+	})
+	return router, mock
+}
+
+func TestStoreConstraintErrors_ReturnConflict(t *testing.T) {
+	duplicateKey := &pgconn.PgError{Code: "23505", Message: `duplicate key value violates unique constraint "interviews_pkey"`}
+	missingInterview := &pgconn.PgError{Code: "23503", Message: `insert or update on table "chat_sessions" violates foreign key constraint "fk_chat_sessions_interview"`}
+
+	t.Run("create interview", func(t *testing.T) {
+		router, mock := mockDatabaseRouter(t)
+		mock.ExpectBegin()
+		mock.ExpectExec(`INSERT INTO "interviews"`).WillReturnError(duplicateKey)
+		mock.ExpectRollback()
+
+		body, _ := json.Marshal(CreateInterviewRequestDTO{CandidateName: "Duplicate", Questions: []string{"Q1"}, InterviewType: "general"})
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("POST", "/api/interviews", bytes.NewReader(body)))
+		assertConflictWithoutSQL(t, w)
+	})
+
+	t.Run("start chat session", func(t *testing.T) {
+		router, mock := mockDatabaseRouter(t)
+		mock.ExpectQuery(`SELECT \* FROM "interviews"`).WillReturnRows(sqlmock.NewRows([]string{"id", "candidate_name", "questions", "status", "type", "language"}).
+			AddRow("interview-1", "Jane", `["Q1"]`, data.InterviewStatusDraft, "general", "en"))
+		mock.ExpectBegin()
+		mock.ExpectExec(`INSERT INTO "chat_sessions"`).WillReturnError(missingInterview)
+		mock.ExpectRollback()
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("POST", "/api/interviews/interview-1/chat/start", nil))
+		assertConflictWithoutSQL(t, w)
+	})
+}
+
+// assertConflictWithoutSQL checks for a 409 conflict whose body carries no database error text
+func assertConflictWithoutSQL(t *testing.T, w *httptest.ResponseRecorder) {
+	t.Helper()
+	if w.Code != http.StatusConflict {
+		t.Fatalf("expected 409, got %d: %s", w.Code, w.Body.String())
+	}
+	var resp ErrorResponseDTO
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to decode error response: %v", err)
+	}
+	if resp.Code != ErrCodeConflict {
+		t.Errorf("expected code %q, got %q", ErrCodeConflict, resp.Code)
+	}
+	for _, leaked := range []string{"duplicate key", "constraint", "SQLSTATE", "INSERT"} {
+		if strings.Contains(w.Body.String(), leaked) {
+			t.Errorf("expected no database error text in the response, found %q in %s", leaked, w.Body.String())
+		}
+	}
+}
+
+// adaptiveTestRouter serves a scripted interview with four planned questions and an admin token
+func adaptiveTestRouter(t *testing.T, adaptive *bool, qualities ...string) (http.Handler, *ai.MockProvider, string) {
+	t.Helper()
+	provider := ai.NewScriptedMockProvider("Welcome! Q1?", "Q2?", "Q3?", "Q4?")
+	provider.SetAssessments(qualities...)
+	router := setupTestRouterWithProvider(provider, func(deps *HandlerDependencies) {
+		deps.AdminToken = "admin-secret"
+	})
+	builder := testsupport.NewInterviewBuilder().WithCandidate("Adaptive Candidate").WithQuestions(4).WithType("technical")
+	if adaptive != nil {
+		builder.WithAdaptive(*adaptive)
+	}
+	interview := createTestInterview(t, router, builder)
+	return router, provider, interview.ID
+}
+
+// difficultyTrajectories reads the interview's trajectories from the admin stats endpoint
+func difficultyTrajectories(t *testing.T, router http.Handler, interviewID string) []SessionDifficultyDTO {
+	t.Helper()
+	req := httptest.NewRequest("GET", "/api/admin/stats?interview_id="+interviewID, nil)
+	req.Header.Set("Authorization", "Bearer admin-secret")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200 from stats, got %d: %s", w.Code, w.Body.String())
+	}
+	var stats AdminStatsResponseDTO
+	if err := json.Unmarshal(w.Body.Bytes(), &stats); err != nil {
+		t.Fatalf("failed to decode stats: %v", err)
+	}
+	return stats.DifficultyTrajectories
+}
+
+// lastHistoryNote returns the last system entry in the conversation history of the latest chat request
+func lastHistoryNote(provider *ai.MockProvider) string {
+	requests := provider.ChatRequests()
+	note := ""
+	for _, msg := range requests[len(requests)-1].Messages[1:] {
+		if msg.Role == "system" {
+			note = msg.Content
+		}
+	}
+	return note
+}
+
+func TestSendMessageHandler_AdaptiveDifficultyEscalates(t *testing.T) {
+	router, provider, interviewID := adaptiveTestRouter(t, nil, "strong", "strong")
+	session := startChatSession(t, router, testsupport.NewSessionBuilder().ForInterviewID(interviewID))
+	if session.DifficultyLevel != ai.DefaultDifficultyLevel {
+		t.Fatalf("expected sessions to start at level %d, got %d", ai.DefaultDifficultyLevel, session.DifficultyLevel)
+	}
+
+	sendMessage(t, router, session.ID, "A thorough first answer")
+	if note := lastHistoryNote(provider); !strings.Contains(note, "harder") || !strings.Contains(note, "hard)") {
+		t.Errorf("expected a harder-question note, got %q", note)
+	}
+	sendMessage(t, router, session.ID, "A thorough second answer")
+	if note := lastHistoryNote(provider); !strings.Contains(note, "harder") || !strings.Contains(note, "very hard") {
+		t.Errorf("expected a very hard target, got %q", note)
+	}
+
+	if got := getChatSession(t, router, session.ID, "").DifficultyLevel; got != 5 {
+		t.Errorf("expected difficulty level 5, got %d", got)
+	}
+	trajectories := difficultyTrajectories(t, router, interviewID)
+	if len(trajectories) != 1 || trajectories[0].SessionID != session.ID || !reflect.DeepEqual(trajectories[0].Trajectory, []int{3, 4, 5}) {
+		t.Errorf("expected trajectory [3 4 5] for %s, got %+v", session.ID, trajectories)
+	}
+}
+
+func TestSendMessageHandler_AdaptiveDifficultyDeescalates(t *testing.T) {
+	router, provider, interviewID := adaptiveTestRouter(t, nil, "weak", "weak", "adequate")
+	session := startChatSession(t, router, testsupport.NewSessionBuilder().ForInterviewID(interviewID))
+
+	sendMessage(t, router, session.ID, "Not sure")
+	if note := lastHistoryNote(provider); !strings.Contains(note, "easier") {
+		t.Errorf("expected an easier-question note, got %q", note)
+	}
+	sendMessage(t, router, session.ID, "No idea")
+	// An adequate answer keeps the level, so the next turn carries no note
+	sendMessage(t, router, session.ID, "A reasonable answer")
+	if note := lastHistoryNote(provider); note != "" {
+		t.Errorf("expected no note when the level is unchanged, got %q", note)
+	}
+
+	trajectories := difficultyTrajectories(t, router, interviewID)
+	if len(trajectories) != 1 || !reflect.DeepEqual(trajectories[0].Trajectory, []int{3, 2, 1, 1}) {
+		t.Errorf("expected trajectory [3 2 1 1], got %+v", trajectories)
+	}
+}
+
+func TestSendMessageHandler_AdaptiveDisabled(t *testing.T) {
+	adaptive := false
+	router, provider, interviewID := adaptiveTestRouter(t, &adaptive, "strong")
+	session := startChatSession(t, router, testsupport.NewSessionBuilder().ForInterviewID(interviewID))
+	if session.DifficultyLevel != 0 {
+		t.Errorf("expected no difficulty level on a non-adaptive session, got %d", session.DifficultyLevel)
+	}
+
+	sendMessage(t, router, session.ID, "A thorough first answer")
+	if note := lastHistoryNote(provider); note != "" {
+		t.Errorf("expected no difficulty note, got %q", note)
+	}
+	if trajectories := difficultyTrajectories(t, router, interviewID); len(trajectories) != 0 {
+		t.Errorf("expected no trajectories, got %+v", trajectories)
+	}
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/api/interviews/"+interviewID, nil))
+	var interview InterviewResponseDTO
+	if err := json.Unmarshal(w.Body.Bytes(), &interview); err != nil {
+		t.Fatalf("failed to decode interview: %v", err)
+	}
+	if interview.Adaptive {
+		t.Error("expected adaptive false in the interview response")
+	}
+}
+
+func TestSendMessageHandler_AIAttemptBudget(t *testing.T) {
+	router := setupTestRouterWithProvider(ai.NewScriptedMockProvider("Welcome! What is Go?"), nil)
+	adaptive := false
+	interview := createTestInterview(t, router, testsupport.NewInterviewBuilder().
+		WithCandidate("Budget Candidate").
+		WithQuestionTexts("What is Go?", "What is a goroutine?").
+		WithType("technical").
+		WithAdaptive(adaptive))
+	// The greeting is the first attempt
+	session := startChatSession(t, router, testsupport.NewSessionBuilder().ForInterviewID(interview.ID))
+
+	failingRouter := newTestRouter(router.store, &failingProvider{ai.NewMockProvider()}, func(deps *HandlerDependencies) {
+		deps.MaxAIAttemptsPerSession = 3
+		deps.AdminToken = "admin-secret"
+	})
+	path := "/api/chat/" + session.ID + "/message"
+	body := `{"message":"A language"}`
+	for range 2 {
+		assertErrorResponse(t, failingRouter, "POST", path, body, http.StatusInternalServerError, ErrCodeAIUnavailable)
+	}
+	// The budget is spent: the provider is not called again and the session is completed
+	assertErrorResponse(t, failingRouter, "POST", path, body, http.StatusTooManyRequests, ErrCodeAIBudgetExhausted)
+	if got := getChatSession(t, failingRouter, session.ID, ""); got.Status != "completed" {
+		t.Fatalf("expected the session to be completed, got %q", got.Status)
+	}
+	assertErrorResponse(t, failingRouter, "POST", path, body, http.StatusConflict, ErrCodeConflict)
+	// Evaluating the completed session would need another attempt
+	assertErrorResponse(t, failingRouter, "POST", "/api/chat/"+session.ID+"/end", "", http.StatusTooManyRequests, ErrCodeAIBudgetExhausted)
+
+	req := httptest.NewRequest("GET", "/api/admin/stats?interview_id="+interview.ID, nil)
+	req.Header.Set("Authorization", "Bearer admin-secret")
+	w := httptest.NewRecorder()
+	failingRouter.ServeHTTP(w, req)
+	var stats AdminStatsResponseDTO
+	if err := json.Unmarshal(w.Body.Bytes(), &stats); err != nil {
+		t.Fatalf("failed to decode stats: %v", err)
+	}
+	if len(stats.AIAttempts) != 1 || stats.AIAttempts[0].SessionID != session.ID || stats.AIAttempts[0].AIAttempts != 3 {
+		t.Errorf("expected 3 attempts for %s, got %+v", session.ID, stats.AIAttempts)
+	}
+}
+
+func TestJobDescriptionLimits(t *testing.T) {
+	provider := ai.NewScriptedMockProvider("Summary: senior Go engineer building APIs")
+	router := setupTestRouterWithProvider(provider, func(deps *HandlerDependencies) {
+		deps.JobDescriptionLimits = ai.JobDescriptionLimits{SoftLimit: 50, HardLimit: 100}
+	})
+
+	// Over the hard limit: rejected at creation
+	body, _ := json.Marshal(CreateInterviewRequestDTO{
+		CandidateName:  "Oversized",
+		Questions:      []string{"Q1"},
+		InterviewType:  "technical",
+		JobDescription: strings.Repeat("x", 101),
+	})
+	assertErrorResponse(t, router, "POST", "/api/interviews", string(body), http.StatusBadRequest, ErrCodeValidationFailed)
+
+	// Under the soft limit: sent as is, no summarization
+	short := createTestInterview(t, router, testsupport.NewInterviewBuilder().
+		WithCandidate("Short").
+		WithType("technical").
+		WithJobDescription("Go engineer"))
+	if len(short.Warnings) != 0 {
+		t.Errorf("expected no warnings for a short job description, got %v", short.Warnings)
+	}
+	if w := submitEvaluation(t, router, "", short.ID, "Answer"); w.Code != http.StatusOK {
+		t.Fatalf("expected 200 OK, got %d: %s", w.Code, w.Body.String())
+	}
+	if n := len(provider.ChatRequests()); n != 0 {
+		t.Errorf("expected no summarization for a short job description, got %d chat requests", n)
+	}
+	if got := provider.EvaluationRequests()[0].JobDesc; got != "Go engineer" {
+		t.Errorf("expected the job description as is, got %q", got)
+	}
+
+	// Between the limits: summarized once, cached, and the original kept
+	longJD := "Senior Go engineer. " + strings.Repeat("We offer great benefits. ", 3)
+	long := createTestInterview(t, router, testsupport.NewInterviewBuilder().
+		WithCandidate("Long").
+		WithType("technical").
+		WithJobDescription(longJD))
+	if len(long.Warnings) != 1 || long.Warnings[0].Code != WarnCodeJobDescriptionSummarized || long.Warnings[0].Field != "job_description" {
+		t.Errorf("expected a job_description_summarized warning, got %v", long.Warnings)
+	}
+	if w := submitEvaluation(t, router, "", long.ID, "Answer"); w.Code != http.StatusOK {
+		t.Fatalf("expected 200 OK, got %d: %s", w.Code, w.Body.String())
+	}
+	if w := submitEvaluation(t, router, "?replace=true", long.ID, "Another answer"); w.Code != http.StatusOK {
+		t.Fatalf("expected 200 OK, got %d: %s", w.Code, w.Body.String())
+	}
+	if n := len(provider.ChatRequests()); n != 1 {
+		t.Errorf("expected the summary to be generated once, got %d chat requests", n)
+	}
+	for i, req := range provider.EvaluationRequests()[1:] {
+		if req.JobDesc != "Summary: senior Go engineer building APIs" {
+			t.Errorf("evaluation %d: expected the summary in the prompt, got %q", i+1, req.JobDesc)
+		}
+	}
+	stored, err := router.store.GetInterview(long.ID)
+	if err != nil {
+		t.Fatalf("failed to get interview: %v", err)
+	}
+	if stored.JobDescription != longJD || stored.JobDescSummary != "Summary: senior Go engineer building APIs" {
+		t.Errorf("expected original job description and cached summary, got %q / %q", stored.JobDescription, stored.JobDescSummary)
+	}
+}
+
+func TestJobDescriptionLimits_SummaryFailureTruncates(t *testing.T) {
+	provider := &failingProvider{ai.NewMockProvider()}
+	store := newTestStore()
+	deps := NewHandlerDependencies(nil, store)
+	deps.JobDescriptionLimits = ai.JobDescriptionLimits{SoftLimit: 10, HardLimit: 100}
+	interview := testsupport.NewInterviewBuilder().WithID("jd-truncate").WithJobDescription(strings.Repeat("y", 40)).Create(t, store)
+
+	jobDesc, cost := deps.promptJobDescription(context.Background(), ai.NewAIClientWithProvider(provider, nil), store, interview)
+	if !strings.HasPrefix(jobDesc, strings.Repeat("y", 10)) || strings.Contains(jobDesc, strings.Repeat("y", 11)) || cost != 0 {
+		t.Errorf("expected a truncated job description at no cost, got %q (%v)", jobDesc, cost)
+	}
+	if interview.JobDescSummary != "" {
+		t.Errorf("expected no summary to be cached after a failure, got %q", interview.JobDescSummary)
+	}
+}
+
+func TestGetAdminStatsHandler_Notifications(t *testing.T) {
+	router := setupTestRouterWithProvider(ai.NewMockProvider(), func(deps *HandlerDependencies) {
+		deps.AdminToken = "admin-secret"
+	})
+
+	for i, seeded := range []data.Notification{
+		{Status: data.NotificationStatusPending},
+		{Status: data.NotificationStatusPending, Attempts: 2},
+		{Status: data.NotificationStatusDelivered, Attempts: 1},
+		{Status: data.NotificationStatusFailed, Attempts: 5},
+	} {
+		seeded.ID = fmt.Sprintf("notification-%d", i)
+		seeded.Type = data.NotificationTypeWebhook
+		if err := router.store.CreateNotification(&seeded); err != nil {
+			t.Fatalf("failed to seed notification: %v", err)
+		}
+	}
+
+	req := httptest.NewRequest("GET", "/api/admin/stats", nil)
+	req.Header.Set("Authorization", "Bearer admin-secret")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200 OK, got %d: %s", w.Code, w.Body.String())
+	}
+	var resp AdminStatsResponseDTO
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to decode stats: %v", err)
+	}
+	if want := (NotificationStatsDTO{Pending: 2, Retrying: 1, Delivered: 1, Failed: 1}); resp.Notifications != want {
+		t.Errorf("expected notification counts %+v, got %+v", want, resp.Notifications)
+	}
+}
+
+func TestGetAdminStatsHandler_AITokenUsage(t *testing.T) {
+	router := setupTestRouterWithProvider(ai.NewMockProvider(), func(deps *HandlerDependencies) {
+		deps.AdminToken = "admin-secret"
+	})
+	for _, interviewType := range []string{"technical", "behavioral"} {
+		interview := createTestInterview(t, router, testsupport.NewInterviewBuilder().WithType(interviewType))
+		session := startChatSession(t, router, testsupport.NewSessionBuilder().ForInterviewID(interview.ID))
+		sendMessage(t, router, session.ID, "My answer")
+	}
+
+	req := httptest.NewRequest("GET", "/api/admin/stats", nil)
+	req.Header.Set("Authorization", "Bearer admin-secret")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	var resp AdminStatsResponseDTO
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil || w.Code != http.StatusOK {
+		t.Fatalf("expected stats, got %d: %s", w.Code, w.Body.String())
+	}
+
+	// The JSON stats read the counters /metrics exports
+	counters := ai.TokenUsageByInterviewType()
+	if len(resp.AITokenUsage) != len(counters) {
+		t.Fatalf("expected %d usage rows, got %+v", len(counters), resp.AITokenUsage)
+	}
+	seen := make(map[string]bool)
+	for i, usage := range resp.AITokenUsage {
+		if usage.InterviewType != counters[i].InterviewType || usage.PromptTokens != counters[i].PromptTokens || usage.CompletionTokens != counters[i].CompletionTokens {
+			t.Errorf("expected row %d to match the counters %+v, got %+v", i, counters[i], usage)
+		}
+		if usage.Provider == ai.ProviderMock && usage.PromptTokens > 0 {
+			seen[usage.InterviewType] = true
+		}
+	}
+	if !seen["technical"] || !seen["behavioral"] {
+		t.Errorf("expected usage under both interview types, got %+v", resp.AITokenUsage)
+	}
+}
+
+func TestGetAdminStatsHandler_DecisionsByInterviewType(t *testing.T) {
+	router := setupTestRouterWithProvider(ai.NewMockProvider(), func(deps *HandlerDependencies) {
+		deps.AdminToken = "admin-secret"
+	})
+
+	// Decisions flow from the evaluator into the evaluation
+	interview := createTestInterview(t, router, testsupport.NewInterviewBuilder().WithCandidate("Decision Candidate").WithType("technical"))
+	w := submitEvaluation(t, router, "", interview.ID, "Answer")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200 OK, got %d: %s", w.Code, w.Body.String())
+	}
+	var evaluation EvaluationResponseDTO
+	if err := json.Unmarshal(w.Body.Bytes(), &evaluation); err != nil {
+		t.Fatalf("failed to decode evaluation: %v", err)
+	}
+	if evaluation.Decision != ai.DecisionHire || len(evaluation.NextSteps) != 1 {
+		t.Errorf("expected the mock decision and next step, got %q / %v", evaluation.Decision, evaluation.NextSteps)
+	}
+
+	seeded := []struct {
+		interviewType string
+		evaluation    *data.Evaluation
+	}{
+		{"technical", &data.Evaluation{ID: "eval-2", Decision: ai.DecisionNoHire}},
+		{"behavioral", &data.Evaluation{ID: "eval-3", Decision: ai.DecisionStrongHire}},
+		// Replaced by eval-5, so only the replacement counts
+		{"behavioral", &data.Evaluation{ID: "eval-4", Decision: ai.DecisionNoHire}},
+		{"behavioral", &data.Evaluation{ID: "eval-5", Decision: ai.DecisionStrongHire, SupersedesID: "eval-4"}},
+		// No decision given
+		{"behavioral", &data.Evaluation{ID: "eval-6"}},
+	}
+	for _, s := range seeded {
+		interviewID := "interview-" + s.evaluation.ID
+		testsupport.NewInterviewBuilder().WithID(interviewID).WithType(s.interviewType).Create(t, router.store)
+		s.evaluation.InterviewID = interviewID
+		s.evaluation.Status = data.EvaluationStatusCompleted
+		if err := router.store.CreateEvaluation(s.evaluation); err != nil {
+			t.Fatalf("failed to seed evaluation: %v", err)
+		}
+	}
+
+	req := httptest.NewRequest("GET", "/api/admin/stats", nil)
+	req.Header.Set("Authorization", "Bearer admin-secret")
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200 OK, got %d: %s", w.Code, w.Body.String())
+	}
+	var resp AdminStatsResponseDTO
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to decode stats: %v", err)
+	}
+	expected := map[string]map[string]int64{
+		"technical":  {ai.DecisionHire: 1, ai.DecisionNoHire: 1},
+		"behavioral": {ai.DecisionStrongHire: 2},
+	}
+	if !reflect.DeepEqual(resp.DecisionsByInterviewType, expected) {
+		t.Errorf("expected decisions %v, got %v", expected, resp.DecisionsByInterviewType)
+	}
+}
+
+func TestChatSessionScope(t *testing.T) {
+	router := setupTestRouter()
+	interviewA := createTestInterview(t, router, testsupport.NewInterviewBuilder().WithCandidate("Candidate A").WithType("technical"))
+	interviewB := createTestInterview(t, router, testsupport.NewInterviewBuilder().WithCandidate("Candidate B").WithType("technical"))
+	sessionB := startChatSession(t, router, testsupport.NewSessionBuilder().ForInterviewID(interviewB.ID))
+	message := `{"message":"Hello"}`
+
+	// Through its own interview the session works on the canonical routes
+	req := httptest.NewRequest("POST", "/api/interviews/"+interviewB.ID+"/chat/"+sessionB.ID+"/message", strings.NewReader(message))
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200 OK through the owning interview, got %d: %s", w.Code, w.Body.String())
+	}
+
+	// Through another interview it looks exactly like an unknown session, for reads and writes
+	unknown := httptest.NewRecorder()
+	router.ServeHTTP(unknown, httptest.NewRequest("GET", "/api/interviews/"+interviewA.ID+"/chat/no-such-session", nil))
+	for _, tc := range []struct{ method, path, body string }{
+		{"POST", "/message", message},
+		{"GET", "", ""},
+		{"GET", "/messages", ""},
+		{"PATCH", "", `{"language":"en"}`},
+		{"POST", "/end", ""},
+		{"POST", "/wrap-up", ""},
+		{"POST", "/heartbeat", ""},
+	} {
+		path := "/api/interviews/" + interviewA.ID + "/chat/" + sessionB.ID + tc.path
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(tc.method, path, strings.NewReader(tc.body)))
+		if w.Code != http.StatusNotFound || w.Body.String() != unknown.Body.String() {
+			t.Errorf("%s %s: expected the unknown session 404, got %d: %s", tc.method, path, w.Code, w.Body.String())
+		}
+	}
+	if session := getChatSession(t, router, sessionB.ID, "?include=messages"); len(session.Messages) != 3 {
+		t.Errorf("expected only the owning interview's message to reach the session, got %d messages", len(session.Messages))
+	}
+
+	// Legacy routes keep working while the session's interview exists
+	sendMessage(t, router, sessionB.ID, "Still here")
+	testsupport.NewSessionBuilder().WithID("orphan-session").ForInterviewID("deleted-interview").Create(t, router.store)
+	assertErrorResponse(t, router, "POST", "/api/chat/orphan-session/message", message, http.StatusNotFound, ErrCodeNotFound)
+}
+
+func TestSubmitEvaluationHandler_FeedbackTruncated(t *testing.T) {
+	router := setupTestRouterWithProvider(ai.NewMockProvider(), func(deps *HandlerDependencies) {
+		deps.MaxFeedbackWords = 2
+	})
+	interview := createTestInterview(t, router, testsupport.NewInterviewBuilder().WithCandidate("Long Feedback").WithType("technical"))
+
+	w := submitEvaluation(t, router, "", interview.ID, "Answer")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200 OK, got %d: %s", w.Code, w.Body.String())
+	}
+	var evaluation EvaluationResponseDTO
+	if err := json.Unmarshal(w.Body.Bytes(), &evaluation); err != nil {
+		t.Fatalf("failed to decode evaluation: %v", err)
+	}
+	if !evaluation.FeedbackTruncated || evaluation.Feedback != "[MOCK] Test …" {
+		t.Errorf("expected truncated feedback to be flagged, got %q (%v)", evaluation.Feedback, evaluation.FeedbackTruncated)
+	}
+	stored, err := router.store.GetEvaluation(evaluation.ID)
+	if err != nil {
+		t.Fatalf("failed to get evaluation: %v", err)
+	}
+	if !stored.FeedbackTruncated {
+		t.Error("expected the flag to be stored with the evaluation")
 	}
 }
@@ -0,0 +1,84 @@
+// Field selection for the interview list (GET /interviews?fields=...)
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/zidane0000/ai-interview-platform/api/apitime"
+	"github.com/zidane0000/ai-interview-platform/data"
+)
+
+// interviewListFields are the fields GET /interviews?fields= may select
+var interviewListFields = []string{
+	"id", "candidate_name", "interview_type", "interview_language", "created_at", "status", "latest_score", "question_count",
+}
+
+// parseInterviewFields parses the comma-separated fields query parameter
+// Returns nil when no fields were selected, meaning the full interview; unknown fields are an error
+func parseInterviewFields(r *http.Request) ([]string, error) {
+	var fields, unknown []string
+	for _, value := range r.URL.Query()["fields"] {
+		for _, field := range strings.Split(value, ",") {
+			field = strings.TrimSpace(field)
+			switch {
+			case field == "" || slices.Contains(fields, field):
+			case slices.Contains(interviewListFields, field):
+				fields = append(fields, field)
+			default:
+				unknown = append(unknown, field)
+			}
+		}
+	}
+	if len(unknown) > 0 {
+		return nil, fmt.Errorf("unknown fields %s; valid fields are %s", strings.Join(unknown, ", "), strings.Join(interviewListFields, ", "))
+	}
+	return fields, nil
+}
+
+// latestEvaluationsForFields loads the current evaluation of each interview when fields select
+// latest_score, in a single read for the whole page; nil when they don't
+func latestEvaluationsForFields(store data.Store, interviews []*data.Interview, fields []string) (map[string]*data.Evaluation, error) {
+	if !slices.Contains(fields, "latest_score") {
+		return nil, nil
+	}
+	ids := make([]string, len(interviews))
+	for i, interview := range interviews {
+		ids[i] = interview.ID
+	}
+	return store.GetLatestEvaluationsByInterviews(ids)
+}
+
+// projectInterview returns only the selected fields of an interview, keyed by their JSON names
+// latest_score is read from latest (see latestEvaluationsForFields), and is null until the interview
+// has a scored evaluation
+func projectInterview(latest map[string]*data.Evaluation, interview *data.Interview, fields []string) map[string]interface{} {
+	projected := make(map[string]interface{}, len(fields))
+	for _, field := range fields {
+		switch field {
+		case "id":
+			projected[field] = interview.ID
+		case "candidate_name":
+			projected[field] = interview.CandidateName
+		case "interview_type":
+			projected[field] = interview.InterviewType
+		case "interview_language":
+			projected[field] = interview.InterviewLanguage
+		case "created_at":
+			projected[field] = apitime.New(interview.CreatedAt)
+		case "status":
+			projected[field] = interview.Status
+		case "latest_score":
+			var score *float64
+			if evaluation, exists := latest[interview.ID]; exists && evaluation.Status == data.EvaluationStatusCompleted {
+				score = &evaluation.Score
+			}
+			projected[field] = score
+		case "question_count":
+			projected[field] = len(interview.Questions)
+		}
+	}
+	return projected
+}
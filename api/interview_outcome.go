@@ -0,0 +1,58 @@
+// Hiring outcomes recruiters record against evaluated interviews
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"unicode/utf8"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/zidane0000/ai-interview-platform/data"
+)
+
+// maxOutcomeNoteLength is the longest outcome note accepted, in characters
+const maxOutcomeNoteLength = 2000
+
+// RecordInterviewOutcomeHandler handles POST /interviews/{id}/outcome (admin token required)
+// Records the actual hiring outcome so AI scores can later be compared against it. Recording
+// again replaces the current outcome; every recorded outcome is kept in outcome_history.
+func (deps *HandlerDependencies) RecordInterviewOutcomeHandler(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	if id == "" {
+		writeJSONError(w, http.StatusBadRequest, ErrCodeValidationFailed, ErrMsgMissingInterviewID)
+		return
+	}
+
+	var req RecordOutcomeRequestDTO
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSONError(w, http.StatusBadRequest, ErrCodeInvalidJSON, "Invalid JSON", err.Error())
+		return
+	}
+	if !data.ValidateInterviewOutcome(req.Outcome) {
+		writeJSONError(w, http.StatusBadRequest, ErrCodeValidationFailed, ErrMsgInvalidOutcome)
+		return
+	}
+	if utf8.RuneCountInString(req.OutcomeNote) > maxOutcomeNoteLength {
+		writeJSONError(w, http.StatusBadRequest, ErrCodeValidationFailed,
+			fmt.Sprintf("outcome_note exceeds the maximum length of %d characters", maxOutcomeNoteLength))
+		return
+	}
+
+	// The response reads back the outcome just recorded, which a replica may not have yet
+	store := deps.Store.WithContext(r.Context()).WithPrimaryReads()
+	if _, err := store.GetInterview(id); err != nil {
+		writeJSONError(w, http.StatusNotFound, ErrCodeNotFound, "Interview not found")
+		return
+	}
+	if err := store.RecordInterviewOutcome(id, req.Outcome, req.OutcomeNote); err != nil {
+		writeJSONError(w, http.StatusInternalServerError, ErrCodeInternal, "Failed to record interview outcome", err.Error())
+		return
+	}
+	interview, err := store.GetInterview(id)
+	if err != nil {
+		writeJSONError(w, http.StatusInternalServerError, ErrCodeInternal, "Failed to load interview", err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, toInterviewResponseDTO(interview))
+}
@@ -0,0 +1,138 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/zidane0000/ai-interview-platform/ai"
+	"github.com/zidane0000/ai-interview-platform/data"
+	"github.com/zidane0000/ai-interview-platform/internal/testsupport"
+)
+
+// recordOutcome posts an outcome with the admin token and returns the recorder
+func recordOutcome(router http.Handler, interviewID, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest("POST", "/api/interviews/"+interviewID+"/outcome", strings.NewReader(body))
+	req.Header.Set("Authorization", "Bearer admin-secret")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+// setupOutcomeRouter returns a router whose admin token is admin-secret
+func setupOutcomeRouter() *testRouter {
+	return setupTestRouterWithProvider(ai.NewMockProvider(), func(deps *HandlerDependencies) {
+		deps.AdminToken = "admin-secret"
+	})
+}
+
+func TestRecordInterviewOutcome_SetAndOverwrite(t *testing.T) {
+	router := setupOutcomeRouter()
+	interview := testsupport.NewInterviewBuilder().WithCandidate("Outcome Candidate").Create(t, router.store)
+
+	w := recordOutcome(router, interview.ID, `{"outcome":"advanced","outcome_note":"Strong system design"}`)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	w = recordOutcome(router, interview.ID, `{"outcome":"offer"}`)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var resp InterviewResponseDTO
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+	if resp.Outcome != data.InterviewOutcomeOffer || resp.OutcomeNote != "" {
+		t.Errorf("expected the outcome overwritten with offer and no note, got %q (%q)", resp.Outcome, resp.OutcomeNote)
+	}
+	if len(resp.OutcomeHistory) != 2 || resp.OutcomeHistory[0].Outcome != data.InterviewOutcomeAdvanced ||
+		resp.OutcomeHistory[0].Note != "Strong system design" || resp.OutcomeHistory[1].Outcome != data.InterviewOutcomeOffer {
+		t.Errorf("expected both outcomes kept in order, got %+v", resp.OutcomeHistory)
+	}
+
+	// The outcome is part of the interview as read back later
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/api/interviews/"+interview.ID, nil))
+	var got InterviewResponseDTO
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+		t.Fatalf("failed to unmarshal interview: %v", err)
+	}
+	if got.Outcome != data.InterviewOutcomeOffer || len(got.OutcomeHistory) != 2 {
+		t.Errorf("expected the stored interview to carry the outcome, got %q with %d records", got.Outcome, len(got.OutcomeHistory))
+	}
+}
+
+func TestRecordInterviewOutcome_Validation(t *testing.T) {
+	router := setupOutcomeRouter()
+	interview := testsupport.NewInterviewBuilder().WithCandidate("Outcome Candidate").Create(t, router.store)
+
+	for _, body := range []string{`{"outcome":"maybe"}`, `{}`, `{"outcome":"hired","outcome_note":"` + strings.Repeat("x", maxOutcomeNoteLength+1) + `"}`} {
+		if w := recordOutcome(router, interview.ID, body); w.Code != http.StatusBadRequest {
+			t.Errorf("expected 400 for %.40s, got %d", body, w.Code)
+		}
+	}
+	if w := recordOutcome(router, "missing", `{"outcome":"hired"}`); w.Code != http.StatusNotFound {
+		t.Errorf("expected 404 for an unknown interview, got %d", w.Code)
+	}
+	assertErrorResponse(t, router, "POST", "/api/interviews/"+interview.ID+"/outcome", `{"outcome":"hired"}`, http.StatusUnauthorized, ErrCodeUnauthorized)
+}
+
+func TestListInterviews_FilterByOutcome(t *testing.T) {
+	router := setupOutcomeRouter()
+	hired := testsupport.NewInterviewBuilder().WithCandidate("Hired Candidate").Create(t, router.store)
+	rejected := testsupport.NewInterviewBuilder().WithCandidate("Rejected Candidate").Create(t, router.store)
+	testsupport.NewInterviewBuilder().WithCandidate("Pending Candidate").Create(t, router.store)
+	recordOutcome(router, hired.ID, `{"outcome":"hired"}`)
+	recordOutcome(router, rejected.ID, `{"outcome":"rejected"}`)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/api/interviews?outcome=hired", nil))
+	var list ListInterviewsResponseDTO
+	if err := json.Unmarshal(w.Body.Bytes(), &list); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+	if w.Code != http.StatusOK || list.Total != 1 || list.Interviews[0].ID != hired.ID || list.Interviews[0].Outcome != data.InterviewOutcomeHired {
+		t.Errorf("expected only the hired interview, got %d: %s", w.Code, w.Body.String())
+	}
+
+	assertErrorResponse(t, router, "GET", "/api/interviews?outcome=maybe", "", http.StatusBadRequest, ErrCodeValidationFailed)
+}
+
+func TestAdminStats_ScoresByOutcome(t *testing.T) {
+	router := setupOutcomeRouter()
+	for _, seed := range []struct {
+		outcome string
+		score   float64
+	}{{"hired", 0.9}, {"hired", 0.7}, {"rejected", 0.4}, {"", 0.6}} {
+		interview := testsupport.NewInterviewBuilder().WithCandidate("Stats Candidate").Create(t, router.store)
+		evaluation := &data.Evaluation{ID: data.GenerateID(), InterviewID: interview.ID, Score: seed.score, Status: data.EvaluationStatusCompleted}
+		if err := router.store.CreateEvaluation(evaluation); err != nil {
+			t.Fatalf("CreateEvaluation failed: %v", err)
+		}
+		if seed.outcome != "" {
+			recordOutcome(router, interview.ID, `{"outcome":"`+seed.outcome+`"}`)
+		}
+	}
+
+	req := httptest.NewRequest("GET", "/api/admin/stats", nil)
+	req.Header.Set("Authorization", "Bearer admin-secret")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	var stats AdminStatsResponseDTO
+	if err := json.Unmarshal(w.Body.Bytes(), &stats); err != nil {
+		t.Fatalf("failed to unmarshal stats: %v", err)
+	}
+
+	// Interviews without an outcome are left out; outcomes are sorted by name
+	if len(stats.ScoresByOutcome) != 2 {
+		t.Fatalf("expected 2 outcomes, got %+v", stats.ScoresByOutcome)
+	}
+	if got := stats.ScoresByOutcome[0]; got.Outcome != "hired" || got.Evaluations != 2 || got.AverageScore < 0.79 || got.AverageScore > 0.81 {
+		t.Errorf("expected hired averaging 0.8 over 2 evaluations, got %+v", got)
+	}
+	if got := stats.ScoresByOutcome[1]; got.Outcome != "rejected" || got.Evaluations != 1 || got.AverageScore != 0.4 {
+		t.Errorf("expected rejected averaging 0.4 over 1 evaluation, got %+v", got)
+	}
+}
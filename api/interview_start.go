@@ -0,0 +1,143 @@
+// Creating an interview together with its first chat session
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/zidane0000/ai-interview-platform/data"
+	"github.com/zidane0000/ai-interview-platform/utils"
+)
+
+// Parts of a POST /interviews/start request named by its errors
+const (
+	startPartInterview = "interview"
+	startPartSession   = "session"
+)
+
+// StartInterviewHandler handles POST /interviews/start
+// Creates an interview and its first chat session together and greets the candidate, saving the
+// client a second round trip. Both parts are validated before anything is stored, and the interview
+// and session are stored atomically. When the AI greeting fails both are kept, the response sets
+// greeting_pending, and POST /chat/{sessionId}/retry-ai fills the greeting in.
+func (deps *HandlerDependencies) StartInterviewHandler(w http.ResponseWriter, r *http.Request) {
+	// The session and greeting are read back right after being written, so skip the replica
+	store := deps.Store.WithContext(r.Context()).WithPrimaryReads()
+
+	var req StartInterviewRequestDTO
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSONError(w, http.StatusBadRequest, ErrCodeInvalidJSON, "Invalid JSON", err.Error())
+		return
+	}
+	// Checked first so an invalid session doesn't cost a question generation call
+	sessionLanguage, failure := sessionLanguageFor(&data.Interview{InterviewLanguage: req.InterviewLanguage}, &req.Session)
+	if failure != nil {
+		failure.write(w, startPartSession)
+		return
+	}
+	greetingMode, failure := deps.greetingModeFor(&req.Session)
+	if failure != nil {
+		failure.write(w, startPartSession)
+		return
+	}
+	interview, warnings, failure := deps.newInterview(r, &req.CreateInterviewRequestDTO)
+	if failure != nil {
+		failure.write(w, startPartInterview)
+		return
+	}
+	if errResp := deps.checkScheduleWindow(interview); errResp != nil {
+		failure := &interviewRequestError{status: http.StatusForbidden, code: errResp.Code, message: errResp.Error}
+		failure.write(w, startPartSession)
+		return
+	}
+
+	aiClient := deps.newAIClient(r)
+	// The session starts right away, so its questions are generated within the request
+	if interview.QuestionsPending() {
+		generationWarnings, failure := deps.fillGeneratedQuestions(r.Context(), aiClient, interview)
+		if failure != nil {
+			failure.write(w, startPartInterview)
+			return
+		}
+		warnings = append(warnings, generationWarnings...)
+	}
+	session := deps.newChatSession(interview, sessionLanguage, greetingMode)
+	interview.Status = data.InterviewStatusActive
+	if err := store.CreateInterviewWithSession(interview, session); err != nil {
+		writeStoreError(w, err, "Failed to create interview", "Interview already exists")
+		return
+	}
+
+	resp := StartInterviewResponseDTO{Interview: toInterviewResponseDTO(interview)}
+	if includeRequested(r, "question_details") {
+		resp.Interview.QuestionDetails = toQuestionDetailDTOs(interview)
+	}
+	resp.Interview.Warnings = warnings
+
+	var cost float64
+	greeting, err := deps.greet(r.Context(), aiClient, store, interview, session)
+	if err == nil && greeting != nil {
+		cost = greeting.EstimatedCostUSD
+		err = deps.saveGreeting(store, interview, session.ID, greeting, aiClient.Redactor())
+	}
+	if err != nil {
+		utils.Errorf("Interview %s started without a greeting: %v", interview.ID, err)
+		resp.GreetingPending = true
+	}
+	resp.Session = deps.startedSessionResponse(r, store, session, cost)
+	writeJSON(w, http.StatusCreated, resp)
+}
+
+// RetryAIHandler handles POST /chat/{sessionId}/retry-ai
+// Generates the greeting of an active session that has none because the AI failed when it started;
+// sessions that already have messages, or that open without a greeting, get 409
+func (deps *HandlerDependencies) RetryAIHandler(w http.ResponseWriter, r *http.Request) {
+	store := deps.Store.WithContext(r.Context()).WithPrimaryReads()
+
+	sessionID := chi.URLParam(r, "sessionId")
+	if sessionID == "" {
+		writeJSONError(w, http.StatusBadRequest, ErrCodeValidationFailed, "Missing session ID")
+		return
+	}
+	session, err := store.GetChatSession(sessionID)
+	if err != nil {
+		writeJSONError(w, http.StatusNotFound, ErrCodeNotFound, ErrMsgSessionNotFound)
+		return
+	}
+	if session.Status != "active" {
+		writeJSONError(w, http.StatusConflict, ErrCodeConflict, "Chat session is not active")
+		return
+	}
+	result, err := store.GetChatMessagesWithOptions(sessionID, data.ListMessagesOptions{Limit: 1})
+	if err != nil {
+		writeJSONError(w, http.StatusInternalServerError, ErrCodeInternal, "Failed to get chat history")
+		return
+	}
+	if result.Total > 0 || session.GreetingMode == data.GreetingModeNone {
+		writeJSONError(w, http.StatusConflict, ErrCodeConflict, "Chat session has no pending AI response")
+		return
+	}
+	interview, err := store.GetInterview(session.InterviewID)
+	if err != nil {
+		writeJSONError(w, http.StatusInternalServerError, ErrCodeInternal, "Failed to get interview details")
+		return
+	}
+
+	aiClient, _ := deps.turnAIClient(r, session)
+	greeting, err := deps.greet(r.Context(), aiClient, store, interview, session)
+	if err != nil {
+		utils.Errorf("Failed to generate AI greeting: %v", err)
+		if deps.writeAIBudgetExhausted(w, store, session, err) || writeAIOverloaded(w, err) {
+			return
+		}
+		writeJSONError(w, http.StatusInternalServerError, ErrCodeAIUnavailable, "Failed to generate AI response", err.Error())
+		return
+	}
+	if err := deps.saveGreeting(store, interview, sessionID, greeting, aiClient.Redactor()); err != nil {
+		writeStoreError(w, err, "Failed to save AI message", "Message already exists")
+		return
+	}
+
+	deps.writeChatSession(w, r, store, sessionID)
+}
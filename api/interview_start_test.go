@@ -0,0 +1,136 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/zidane0000/ai-interview-platform/ai"
+	"github.com/zidane0000/ai-interview-platform/data"
+)
+
+// startInterview posts body to /api/interviews/start and returns the recorder
+func startInterview(router http.Handler, body string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("POST", "/api/interviews/start", strings.NewReader(body)))
+	return w
+}
+
+// decodeStartInterview decodes a successful /api/interviews/start response
+func decodeStartInterview(t *testing.T, w *httptest.ResponseRecorder) StartInterviewResponseDTO {
+	t.Helper()
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", w.Code, w.Body.String())
+	}
+	var resp StartInterviewResponseDTO
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+	return resp
+}
+
+func TestStartInterviewHandler(t *testing.T) {
+	router := setupTestRouter()
+
+	resp := decodeStartInterview(t, startInterview(router,
+		`{"candidate_name":"Alice","questions":["Why Go?","Why Go?"],"interview_type":"technical","session":{"session_language":"zh-TW"}}`))
+
+	if resp.Interview.ID == "" || resp.Interview.Status != data.InterviewStatusActive {
+		t.Errorf("expected an active interview, got %+v", resp.Interview)
+	}
+	if len(resp.Interview.Warnings) != 1 {
+		t.Errorf("expected the duplicate question warning, got %v", resp.Interview.Warnings)
+	}
+	if resp.Session.InterviewID != resp.Interview.ID || resp.Session.SessionLanguage != "zh-TW" || resp.Session.Status != "active" {
+		t.Errorf("expected an active zh-TW session of the interview, got %+v", resp.Session)
+	}
+	if resp.GreetingPending || len(resp.Session.Messages) != 1 || resp.Session.Messages[0].Subtype != data.MessageSubtypeGreeting {
+		t.Errorf("expected the greeting, got %+v (pending %v)", resp.Session.Messages, resp.GreetingPending)
+	}
+
+	// Both parts are stored
+	if _, err := router.store.GetInterview(resp.Interview.ID); err != nil {
+		t.Errorf("expected the interview to be stored: %v", err)
+	}
+	session, err := router.store.GetChatSession(resp.Session.ID)
+	if err != nil {
+		t.Fatalf("expected the session to be stored: %v", err)
+	}
+	if len(session.AskedQuestions) != 1 {
+		t.Errorf("expected the greeting's question to be recorded, got %v", session.AskedQuestions)
+	}
+}
+
+func TestStartInterviewHandler_GreetingFailure(t *testing.T) {
+	failingRouter := setupTestRouterWithProvider(&failingProvider{ai.NewMockProvider()}, nil)
+
+	resp := decodeStartInterview(t, startInterview(failingRouter,
+		`{"candidate_name":"Bob","questions":["Why Go?"],"interview_type":"general"}`))
+	if !resp.GreetingPending || len(resp.Session.Messages) != 0 {
+		t.Fatalf("expected a pending greeting and no messages, got %+v", resp)
+	}
+	if _, err := failingRouter.store.GetChatSession(resp.Session.ID); err != nil {
+		t.Fatalf("expected the session to be kept: %v", err)
+	}
+
+	// Retrying while the provider still fails reports the AI failure
+	assertErrorResponse(t, failingRouter, "POST", "/api/chat/"+resp.Session.ID+"/retry-ai", "", http.StatusInternalServerError, ErrCodeAIUnavailable)
+
+	// Once the provider recovers, retry-ai fills the greeting in
+	router := newTestRouter(failingRouter.store, ai.NewMockProvider(), nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("POST", "/api/interviews/"+resp.Interview.ID+"/chat/"+resp.Session.ID+"/retry-ai", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var session ChatInterviewSessionDTO
+	if err := json.Unmarshal(w.Body.Bytes(), &session); err != nil {
+		t.Fatalf("failed to unmarshal session: %v", err)
+	}
+	if len(session.Messages) != 1 || session.Messages[0].Subtype != data.MessageSubtypeGreeting {
+		t.Errorf("expected the greeting, got %+v", session.Messages)
+	}
+
+	// Nothing is pending any more
+	assertErrorResponse(t, router, "POST", "/api/chat/"+resp.Session.ID+"/retry-ai", "", http.StatusConflict, ErrCodeConflict)
+}
+
+func TestStartInterviewHandler_ValidationCreatesNothing(t *testing.T) {
+	router := setupTestRouter()
+
+	tests := []struct {
+		name string
+		body string
+		code ErrorCode
+		part string
+	}{
+		{name: "missing candidate", body: `{"questions":["Q1"],"interview_type":"general"}`, code: ErrCodeValidationFailed, part: "interview"},
+		{name: "invalid interview type", body: `{"candidate_name":"Carol","questions":["Q1"],"interview_type":"trivia"}`, code: ErrCodeValidationFailed, part: "interview"},
+		{name: "invalid session language", body: `{"candidate_name":"Carol","questions":["Q1"],"interview_type":"general","session":{"session_language":"fr"}}`, code: ErrCodeValidationFailed, part: "session"},
+		{name: "scheduled in the future", body: `{"candidate_name":"Carol","questions":["Q1"],"interview_type":"general","scheduled_start":"2999-01-01T00:00:00Z"}`, code: ErrCodeTooEarly, part: "session"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := startInterview(router, tt.body)
+			var errResp StartInterviewErrorResponseDTO
+			if err := json.Unmarshal(w.Body.Bytes(), &errResp); err != nil {
+				t.Fatalf("failed to unmarshal error: %v", err)
+			}
+			if w.Code == http.StatusCreated || errResp.Code != tt.code || errResp.Part != tt.part {
+				t.Errorf("expected %s in the %s part, got %d: %s", tt.code, tt.part, w.Code, w.Body.String())
+			}
+		})
+	}
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/api/interviews", nil))
+	var list ListInterviewsResponseDTO
+	if err := json.Unmarshal(w.Body.Bytes(), &list); err != nil {
+		t.Fatalf("failed to unmarshal interviews: %v", err)
+	}
+	if list.Total != 0 {
+		t.Errorf("expected no interviews to be created, got %d", list.Total)
+	}
+}
@@ -0,0 +1,136 @@
+// Store maintenance: periodic vacuuming of the tables purges and retention leave dead rows in
+package api
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/zidane0000/ai-interview-platform/api/apitime"
+	"github.com/zidane0000/ai-interview-platform/config"
+	"github.com/zidane0000/ai-interview-platform/data"
+	"github.com/zidane0000/ai-interview-platform/utils"
+)
+
+// Maintenance outcomes, used as the metric label and in the health report
+const (
+	maintenanceOK     = "ok"
+	maintenanceFailed = "failed"
+)
+
+// maintenanceRun is the outcome of one store maintenance run
+type maintenanceRun struct {
+	startedAt time.Time
+	duration  time.Duration
+	report    *data.MaintenanceReport // May be partial when err is set
+	err       error
+}
+
+// maintenanceScheduler runs store maintenance, never more than one run at a time, and keeps the
+// outcome of the latest run for the health report
+type maintenanceScheduler struct {
+	maintain func(ctx context.Context) (*data.MaintenanceReport, error)
+	now      func() time.Time
+	running  sync.Mutex // Held for the length of a run
+
+	mu   sync.Mutex
+	last *maintenanceRun
+}
+
+// newMaintenanceScheduler creates a scheduler running maintain, timed with now
+func newMaintenanceScheduler(maintain func(ctx context.Context) (*data.MaintenanceReport, error), now func() time.Time) *maintenanceScheduler {
+	return &maintenanceScheduler{maintain: maintain, now: now}
+}
+
+// run runs maintenance unless a run is already in progress, in which case it returns false at once.
+// The duration and outcome are logged and recorded in the metrics.
+func (s *maintenanceScheduler) run(ctx context.Context) bool {
+	if !s.running.TryLock() {
+		utils.Warningf("Skipping database maintenance: the previous run is still in progress")
+		return false
+	}
+	defer s.running.Unlock()
+
+	started := s.now()
+	report, err := s.maintain(ctx)
+	run := &maintenanceRun{startedAt: started, duration: s.now().Sub(started), report: report, err: err}
+	outcome := maintenanceOK
+	if err != nil {
+		outcome = maintenanceFailed
+		utils.Errorf("Database maintenance failed after %v: %v", run.duration, err)
+	} else {
+		utils.Infof("Database maintenance finished in %v, vacuuming %d tables", run.duration, len(report.Vacuumed))
+	}
+	storeMaintenanceDuration.WithLabelValues(outcome).Observe(run.duration.Seconds())
+
+	s.mu.Lock()
+	s.last = run
+	s.mu.Unlock()
+	return true
+}
+
+// lastRun returns the latest completed run; nil before the first
+func (s *maintenanceScheduler) lastRun() *maintenanceRun {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.last
+}
+
+// StartMaintenanceWorker runs store maintenance every cfg.MaintenanceInterval until ctx is done.
+// Does nothing when the interval is 0. Each run is started on its own goroutine so a slow VACUUM
+// can't hold up shutdown; ticks that come while it is still going are skipped, and so are runs while
+// read-only.
+func StartMaintenanceWorker(ctx context.Context, cfg *config.Config, deps *HandlerDependencies) {
+	if cfg.MaintenanceInterval <= 0 {
+		return
+	}
+	go func() {
+		ticker := time.NewTicker(cfg.MaintenanceInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if !deps.ReadOnly() {
+					go deps.maintenance.run(ctx)
+				}
+			}
+		}
+	}()
+}
+
+// toMaintenanceStatusDTO converts the latest maintenance run for the health report; nil before the first
+// Only when and how the run went: /health is unauthenticated, so the error stays in the logs and the
+// table sizes are left to toMaintenanceReportDTO.
+func toMaintenanceStatusDTO(run *maintenanceRun) *MaintenanceStatusDTO {
+	if run == nil {
+		return nil
+	}
+	status := &MaintenanceStatusDTO{
+		LastRunAt:  apitime.New(run.startedAt),
+		DurationMS: run.duration.Milliseconds(),
+		Outcome:    maintenanceOK,
+	}
+	if run.err != nil {
+		status.Outcome = maintenanceFailed
+	}
+	return status
+}
+
+// toMaintenanceReportDTO converts the latest maintenance run for the admin stats; nil before the first
+func toMaintenanceReportDTO(run *maintenanceRun) *MaintenanceReportDTO {
+	status := toMaintenanceStatusDTO(run)
+	if status == nil {
+		return nil
+	}
+	report := &MaintenanceReportDTO{MaintenanceStatusDTO: *status}
+	if run.report != nil {
+		report.Backend = string(run.report.Backend)
+		report.Vacuumed = run.report.Vacuumed
+		for _, table := range run.report.Tables {
+			report.Tables = append(report.Tables, MaintenanceTableDTO{Table: table.Table, Entries: table.Entries, EstimatedBytes: table.EstimatedBytes})
+		}
+	}
+	return report
+}
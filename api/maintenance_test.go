@@ -0,0 +1,175 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/zidane0000/ai-interview-platform/ai"
+	"github.com/zidane0000/ai-interview-platform/config"
+	"github.com/zidane0000/ai-interview-platform/data"
+	"github.com/zidane0000/ai-interview-platform/internal/testsupport"
+)
+
+// slowMaintenance is a maintenance run that takes as long as it is held, counting its calls and the
+// most that were ever in progress at once
+type slowMaintenance struct {
+	calls, inProgress, maxInProgress atomic.Int32
+	started                          chan struct{}
+	release                          chan struct{}
+}
+
+func newSlowMaintenance() *slowMaintenance {
+	return &slowMaintenance{started: make(chan struct{}, 100), release: make(chan struct{})}
+}
+
+func (m *slowMaintenance) maintain(ctx context.Context) (*data.MaintenanceReport, error) {
+	m.calls.Add(1)
+	current := m.inProgress.Add(1)
+	defer m.inProgress.Add(-1)
+	for {
+		if peak := m.maxInProgress.Load(); current <= peak || m.maxInProgress.CompareAndSwap(peak, current) {
+			break
+		}
+	}
+	m.started <- struct{}{}
+	select {
+	case <-m.release:
+	case <-ctx.Done():
+	}
+	return &data.MaintenanceReport{Backend: data.BackendDatabase, Vacuumed: []string{"chat_messages"}}, nil
+}
+
+func TestMaintenanceScheduler_NeverOverlaps(t *testing.T) {
+	slow := newSlowMaintenance()
+	scheduler := newMaintenanceScheduler(slow.maintain, time.Now)
+
+	done := make(chan bool)
+	go func() { done <- scheduler.run(context.Background()) }()
+	<-slow.started
+
+	// A run asked for while one is in progress is skipped rather than queued
+	if scheduler.run(context.Background()) {
+		t.Error("expected a second run to be skipped")
+	}
+	close(slow.release)
+	if !<-done {
+		t.Error("expected the first run to complete")
+	}
+	if slow.calls.Load() != 1 {
+		t.Errorf("expected 1 maintenance call, got %d", slow.calls.Load())
+	}
+	if last := scheduler.lastRun(); last == nil || last.err != nil || last.report.Vacuumed[0] != "chat_messages" {
+		t.Errorf("expected the run recorded, got %+v", last)
+	}
+
+	// Once it is over the next run goes ahead
+	if !scheduler.run(context.Background()) || slow.calls.Load() != 2 {
+		t.Errorf("expected a run after the first finished, got %d calls", slow.calls.Load())
+	}
+}
+
+func TestStartMaintenanceWorker_SkipsTicksDuringARun(t *testing.T) {
+	slow := newSlowMaintenance()
+	deps := NewHandlerDependencies(nil, nil)
+	deps.maintenance = newMaintenanceScheduler(slow.maintain, time.Now)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Ticks every millisecond keep coming while the first run is held
+	StartMaintenanceWorker(ctx, &config.Config{MaintenanceInterval: time.Millisecond}, deps)
+	<-slow.started
+	time.Sleep(50 * time.Millisecond)
+	if calls := slow.calls.Load(); calls != 1 {
+		t.Errorf("expected the ticks during the run skipped, got %d calls", calls)
+	}
+	close(slow.release)
+	<-slow.started
+	cancel()
+	if peak := slow.maxInProgress.Load(); peak != 1 {
+		t.Errorf("expected at most 1 run in progress, got %d", peak)
+	}
+}
+
+func TestHealth_ReportsMaintenance(t *testing.T) {
+	var deps *HandlerDependencies
+	router := setupTestRouterWithProvider(ai.NewMockProvider(), func(d *HandlerDependencies) {
+		deps = d
+		d.AdminToken = "admin-secret"
+		d.now = func() time.Time { return time.Date(2026, 5, 4, 3, 0, 0, 0, time.UTC) }
+	})
+	createTestInterview(t, router, testsupport.NewInterviewBuilder())
+
+	health := func() HealthResponseDTO {
+		t.Helper()
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("GET", "/health", nil))
+		var resp HealthResponseDTO
+		if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil || w.Code != http.StatusOK || resp.Status != "ok" {
+			t.Fatalf("expected a healthy report, got %d: %s", w.Code, w.Body.String())
+		}
+		return resp
+	}
+	adminStats := func() AdminStatsResponseDTO {
+		t.Helper()
+		w := adminRequest(router, "GET", "/api/admin/stats", "")
+		var resp AdminStatsResponseDTO
+		if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil || w.Code != http.StatusOK {
+			t.Fatalf("expected the admin stats, got %d: %s", w.Code, w.Body.String())
+		}
+		return resp
+	}
+	if resp := health(); resp.Maintenance != nil {
+		t.Errorf("expected no maintenance before the first run, got %+v", resp.Maintenance)
+	}
+	if resp := adminStats(); resp.Maintenance != nil {
+		t.Errorf("expected no maintenance in the admin stats before the first run, got %+v", resp.Maintenance)
+	}
+
+	deps.maintenance.run(context.Background())
+	maintenance := health().Maintenance
+	if maintenance == nil || maintenance.Outcome != "ok" {
+		t.Fatalf("expected a successful maintenance run, got %+v", maintenance)
+	}
+	if !maintenance.LastRunAt.Std().Equal(deps.now()) {
+		t.Errorf("expected the run at %v, got %v", deps.now(), maintenance.LastRunAt.Std())
+	}
+
+	// The unauthenticated health check leaves out what the run found
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/health", nil))
+	for _, field := range []string{`"tables"`, `"vacuumed"`, `"backend"`, `"entries"`} {
+		if strings.Contains(w.Body.String(), field) {
+			t.Errorf("expected /health without %s, got %s", field, w.Body.String())
+		}
+	}
+
+	// The admin stats report the memory backend's entries, without vacuuming anything
+	report := adminStats().Maintenance
+	if report == nil || report.Outcome != "ok" || report.Backend != "memory" || len(report.Vacuumed) != 0 || len(report.Tables) == 0 {
+		t.Fatalf("expected a successful memory maintenance run, got %+v", report)
+	}
+	for _, table := range report.Tables {
+		if table.Table == "interviews" && (table.Entries != 1 || table.EstimatedBytes == 0) {
+			t.Errorf("expected 1 stored interview, got %+v", table)
+		}
+	}
+
+	// A failure is reported without its error
+	deps.maintenance.maintain = func(context.Context) (*data.MaintenanceReport, error) {
+		return nil, errors.New("connection refused to db.internal:5432")
+	}
+	deps.maintenance.run(context.Background())
+	if maintenance := health().Maintenance; maintenance.Outcome != "failed" {
+		t.Errorf("expected a failed run, got %+v", maintenance)
+	}
+	if report := adminStats().Maintenance; report.Outcome != "failed" || report.Backend != "" || len(report.Tables) != 0 {
+		t.Errorf("expected a failed run without details, got %+v", report)
+	}
+}
@@ -0,0 +1,58 @@
+// Candidate and full views of a chat session's transcript
+package api
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+
+	"github.com/zidane0000/ai-interview-platform/data"
+)
+
+// Transcript views, selected with ?view=
+const (
+	messageViewCandidate = "candidate" // Candidate-visible messages only
+	messageViewFull      = "full"      // Every message, internal ones included; admin callers only
+)
+
+// validAdminToken reports whether r carries token as its bearer token; an empty token matches nothing
+func validAdminToken(r *http.Request, token string) bool {
+	provided, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	return token != "" && ok && subtle.ConstantTimeCompare([]byte(provided), []byte(token)) == 1
+}
+
+// privilegedCaller reports whether r is authenticated with the admin token
+// A tenant API key doesn't make a caller privileged: with multi-tenancy on, candidates send it too.
+func (deps *HandlerDependencies) privilegedCaller(r *http.Request) bool {
+	return validAdminToken(r, deps.AdminToken)
+}
+
+// candidateView resolves the transcript view of r: ?view= when given, otherwise the full view for
+// admin callers and the candidate view for everyone else. Only privileged callers may
+// ask for the full view. On failure the error response is written and ok is false.
+func (deps *HandlerDependencies) candidateView(w http.ResponseWriter, r *http.Request) (candidateOnly, ok bool) {
+	privileged := deps.privilegedCaller(r)
+	switch r.URL.Query().Get("view") {
+	case "":
+		return !privileged, true
+	case messageViewCandidate:
+		return true, true
+	case messageViewFull:
+		if !privileged {
+			writeJSONError(w, http.StatusForbidden, ErrCodeForbidden, "The full transcript view requires the admin token")
+			return false, false
+		}
+		return false, true
+	default:
+		writeJSONError(w, http.StatusBadRequest, ErrCodeValidationFailed, "view must be candidate or full")
+		return false, false
+	}
+}
+
+// messageVisibility is the visibility reported for msg, candidate for messages stored before it existed
+func messageVisibility(msg *data.ChatMessage) string {
+	if msg.CandidateVisible() {
+		return data.MessageVisibilityCandidate
+	}
+	return data.MessageVisibilityInternal
+}
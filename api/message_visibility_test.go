@@ -0,0 +1,142 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/zidane0000/ai-interview-platform/data"
+)
+
+func TestChatSessionViews(t *testing.T) {
+	router, session, _ := setupReopenTest(t, nil)
+	if w := reopenSession(router, session.ID, ""); w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	stored, _ := router.store.GetChatMessages(session.ID)
+
+	get := func(path, token string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("GET", path, nil)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+	internalCount := func(messages []ChatMessageDTO) int {
+		count := 0
+		for _, msg := range messages {
+			if msg.Visibility == data.MessageVisibilityInternal {
+				count++
+			}
+		}
+		return count
+	}
+
+	tests := []struct {
+		name     string
+		query    string
+		token    string
+		internal bool
+	}{
+		{"candidate by default", "", "", false},
+		{"admin sees everything", "", "admin-secret", true},
+		{"admin asks for the candidate view", "?view=candidate", "admin-secret", false},
+		{"admin asks for the full view", "?view=full", "admin-secret", true},
+		{"wrong token is a candidate", "", "wrong", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := get("/api/chat/"+session.ID+tt.query, tt.token)
+			if w.Code != http.StatusOK {
+				t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+			}
+			var view ChatInterviewSessionDTO
+			if err := json.Unmarshal(w.Body.Bytes(), &view); err != nil {
+				t.Fatalf("failed to decode session: %v", err)
+			}
+			expected, internal := len(stored), 1
+			if !tt.internal {
+				expected, internal = len(stored)-1, 0
+			}
+			if len(view.Messages) != expected || internalCount(view.Messages) != internal {
+				t.Errorf("expected %d messages with %d internal, got %d with %d", expected, internal, len(view.Messages), internalCount(view.Messages))
+			}
+
+			w = get("/api/chat/"+session.ID+"/messages"+tt.query, tt.token)
+			var page ListChatMessagesResponseDTO
+			if err := json.Unmarshal(w.Body.Bytes(), &page); err != nil || w.Code != http.StatusOK {
+				t.Fatalf("expected a message page, got %d: %s", w.Code, w.Body.String())
+			}
+			if page.Total != expected || internalCount(page.Messages) != internal {
+				t.Errorf("expected a total of %d with %d internal, got %d with %d", expected, internal, page.Total, internalCount(page.Messages))
+			}
+		})
+	}
+
+	if w := get("/api/chat/"+session.ID+"?view=full", ""); w.Code != http.StatusForbidden {
+		t.Errorf("expected 403 for a candidate asking for the full view, got %d", w.Code)
+	}
+	if w := get("/api/chat/"+session.ID+"/messages?view=all", "admin-secret"); w.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for an unknown view, got %d", w.Code)
+	}
+}
+
+func TestChatSessionViews_APIKey(t *testing.T) {
+	router := setupTestRouterWithProvider(nil, func(deps *HandlerDependencies) {
+		deps.TenantAPIKeys = map[string]string{"key-acme": "acme"}
+		deps.AdminToken = "admin-secret"
+	})
+	store := router.store.WithContext(data.WithTenant(context.Background(), "acme"))
+	interview := &data.Interview{ID: "interview-1", CandidateName: "Ada", Questions: data.StringArray{"Q1"}, InterviewType: "general"}
+	if err := store.CreateInterview(interview); err != nil {
+		t.Fatalf("CreateInterview failed: %v", err)
+	}
+	session := &data.ChatSession{ID: "session-1", InterviewID: interview.ID, SessionLanguage: "en", Status: "active"}
+	if err := store.CreateChatSession(session); err != nil {
+		t.Fatalf("CreateChatSession failed: %v", err)
+	}
+	for _, msg := range []*data.ChatMessage{
+		{ID: "m1", SessionID: session.ID, Type: "ai", Content: "Hello"},
+		{ID: "m2", SessionID: session.ID, Type: "system", Content: "Calibration probe", Visibility: data.MessageVisibilityInternal},
+	} {
+		if err := store.AddChatMessage(session.ID, msg); err != nil {
+			t.Fatalf("AddChatMessage failed: %v", err)
+		}
+	}
+	get := func(query, adminToken string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("GET", "/api/chat/"+session.ID+query, nil)
+		req.Header.Set("X-API-Key", "key-acme")
+		if adminToken != "" {
+			req.Header.Set("Authorization", "Bearer "+adminToken)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	// With multi-tenancy on, candidates send the tenant key too, so it alone gets the candidate view
+	w := get("", "")
+	var view ChatInterviewSessionDTO
+	if err := json.Unmarshal(w.Body.Bytes(), &view); err != nil || w.Code != http.StatusOK {
+		t.Fatalf("expected the session, got %d: %s", w.Code, w.Body.String())
+	}
+	if len(view.Messages) != 1 || view.Messages[0].Visibility == data.MessageVisibilityInternal {
+		t.Errorf("expected the tenant key alone not to reveal the internal message, got %+v", view.Messages)
+	}
+	if w := get("?view=full", ""); w.Code != http.StatusForbidden {
+		t.Errorf("expected 403 for the full view with only the tenant key, got %d", w.Code)
+	}
+
+	// The admin token alongside it gets the full transcript
+	w = get("", "admin-secret")
+	if err := json.Unmarshal(w.Body.Bytes(), &view); err != nil || w.Code != http.StatusOK {
+		t.Fatalf("expected the session, got %d: %s", w.Code, w.Body.String())
+	}
+	if len(view.Messages) != 2 || view.Messages[1].Visibility != data.MessageVisibilityInternal {
+		t.Errorf("expected admin callers to see the internal message, got %+v", view.Messages)
+	}
+}
@@ -0,0 +1,80 @@
+// Enum and limit listing for clients, so they don't hardcode what the server accepts
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"net/http"
+
+	"github.com/zidane0000/ai-interview-platform/ai"
+	"github.com/zidane0000/ai-interview-platform/data"
+	"github.com/zidane0000/ai-interview-platform/utils"
+	"github.com/zidane0000/ai-interview-platform/version"
+)
+
+// metaMaxAge is how long clients may cache GET /api/meta; values only change with a deploy or restart
+const metaMaxAge = 24 * 60 * 60
+
+// metaResponse reads the enum registries of the data, ai and api packages and the configured limits
+// Nothing is listed here: a value added to a registry shows up without touching this function.
+func (deps *HandlerDependencies) metaResponse() MetaResponseDTO {
+	languages := make([]LanguageDTO, len(data.Languages))
+	for i, language := range data.Languages {
+		languages[i] = LanguageDTO{Code: language.Code, Name: language.Name}
+	}
+	difficulties := make([]DifficultyDTO, 0, ai.MaxDifficultyLevel-ai.MinDifficultyLevel+1)
+	for level := ai.MinDifficultyLevel; level <= ai.MaxDifficultyLevel; level++ {
+		difficulties = append(difficulties, DifficultyDTO{Level: level, Name: ai.DifficultyName(level)})
+	}
+	return MetaResponseDTO{
+		InterviewTypes:       data.InterviewTypes,
+		DefaultInterviewType: data.GetDefaultInterviewType(),
+		InterviewModes:       data.InterviewModes,
+		Languages:            languages,
+		DefaultLanguage:      data.GetDefaultLanguage(),
+		Difficulties:         difficulties,
+		DefaultDifficulty:    ai.DefaultDifficultyLevel,
+		GreetingModes:        data.GreetingModes,
+		InterviewStatuses:    data.InterviewStatuses,
+		SessionStatuses:      data.SessionStatuses,
+		EvaluationStatuses:   data.EvaluationStatuses,
+		Outcomes:             data.InterviewOutcomes,
+		Decisions:            ai.Decisions,
+		ErrorCodes:           errorCodes,
+		WarningCodes:         warningCodes,
+		Limits: MetaLimitsDTO{
+			MaxQuestionCount:      deps.QuestionLimits.MaxCount,
+			MaxQuestionLength:     deps.QuestionLimits.MaxLength,
+			MaxMessageLength:      deps.MaxMessageLength,
+			MaxMessagesPerSession: deps.MaxMessagesPerSession,
+		},
+	}
+}
+
+// GetMetaHandler handles GET /meta
+// Lists the interview types, languages, statuses, codes and limits the instance accepts. The ETag
+// combines the build version with a hash of the body, so it changes with a deploy or a configuration
+// change; a matching If-None-Match gets 304.
+func (deps *HandlerDependencies) GetMetaHandler(w http.ResponseWriter, r *http.Request) {
+	body, err := json.Marshal(deps.metaResponse())
+	if err != nil {
+		writeJSONError(w, http.StatusInternalServerError, ErrCodeInternal, "Failed to encode metadata")
+		return
+	}
+	hash := fnv.New64a()
+	hash.Write(body)
+	etag := fmt.Sprintf(`"%s-%x"`, version.Version, hash.Sum64())
+
+	w.Header().Set("Cache-Control", fmt.Sprintf("public, max-age=%d", metaMaxAge))
+	w.Header().Set("ETag", etag)
+	if r.Header.Get("If-None-Match") == etag {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(append(body, '\n')); err != nil {
+		utils.Errorf("failed to write metadata: %v", err)
+	}
+}
@@ -0,0 +1,117 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/zidane0000/ai-interview-platform/ai"
+	"github.com/zidane0000/ai-interview-platform/data"
+)
+
+// metaContract is the GET /api/meta response with the default configuration
+// Clients build their forms and error handling from it: a change here is a change to the API contract.
+const metaContract = `{
+	"interview_types": ["general", "technical", "behavioral"],
+	"default_interview_type": "general",
+	"interview_modes": ["structured", "conversational"],
+	"languages": [{"code": "en", "name": "English"}, {"code": "zh-TW", "name": "繁體中文"}],
+	"default_language": "en",
+	"difficulties": [
+		{"level": 1, "name": "very easy"},
+		{"level": 2, "name": "easy"},
+		{"level": 3, "name": "medium"},
+		{"level": 4, "name": "hard"},
+		{"level": 5, "name": "very hard"}
+	],
+	"default_difficulty": 3,
+	"greeting_modes": ["ai", "template", "none"],
+	"interview_statuses": ["draft", "scheduled", "active", "completed"],
+	"session_statuses": ["active", "completed", "abandoned"],
+	"evaluation_statuses": ["completed", "no_answers", "superseded"],
+	"outcomes": ["advanced", "rejected", "offer", "hired"],
+	"decisions": ["strong_hire", "hire", "no_hire", "more_data_needed"],
+	"error_codes": [
+		"invalid_json", "validation_failed", "unauthorized", "forbidden", "not_found",
+		"method_not_allowed", "conflict", "rate_limited", "too_early", "expired",
+		"ai_unavailable", "ai_budget_exhausted", "ai_overloaded", "questions_pending", "questions_failed",
+		"read_only_mode", "message_too_soon", "duplicate_message", "session_hourly_cap", "internal"
+	],
+	"warning_codes": [
+		"invalid_parameter", "clamped_parameter", "duplicate_question", "default_questions_fallback",
+		"job_description_summarized", "message_summarized", "reply_language_mismatch", "answer_language_mismatch",
+		"feedback_truncated", "small_cohort", "translation_failed"
+	],
+	"limits": {"max_question_count": 50, "max_question_length": 1000, "max_message_length": 8000, "max_messages_per_session": 2000}
+}`
+
+// getMeta requests GET /api/meta, conditionally when etag is set
+func getMeta(router http.Handler, etag string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest("GET", "/api/meta", nil)
+	if etag != "" {
+		req.Header.Set("If-None-Match", etag)
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestGetMeta_Contract(t *testing.T) {
+	router := setupTestRouterWithProvider(ai.NewMockProvider(), nil)
+	w := getMeta(router, "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var expected, got bytes.Buffer
+	if err := json.Compact(&expected, []byte(metaContract)); err != nil {
+		t.Fatalf("invalid contract: %v", err)
+	}
+	if err := json.Compact(&got, w.Body.Bytes()); err != nil {
+		t.Fatalf("invalid response: %v", err)
+	}
+	if got.String() != expected.String() {
+		t.Errorf("response differs from the contract:\nexpected %s\ngot      %s", expected.String(), got.String())
+	}
+
+	// Cacheable, and revalidated against the ETag
+	etag := w.Header().Get("ETag")
+	if w.Header().Get("Cache-Control") != "public, max-age=86400" || etag == "" {
+		t.Errorf("expected long-lived caching headers, got %v", w.Header())
+	}
+	if w := getMeta(router, etag); w.Code != http.StatusNotModified || w.Body.Len() != 0 {
+		t.Errorf("expected 304 without a body for a matching ETag, got %d: %s", w.Code, w.Body.String())
+	}
+	if w := getMeta(router, `"stale"`); w.Code != http.StatusOK {
+		t.Errorf("expected 200 for a stale ETag, got %d", w.Code)
+	}
+
+	// Configured limits are reported and change the ETag
+	router = setupTestRouterWithProvider(ai.NewMockProvider(), func(deps *HandlerDependencies) {
+		deps.MaxMessageLength = 500
+	})
+	w = getMeta(router, etag)
+	var meta MetaResponseDTO
+	if err := json.Unmarshal(w.Body.Bytes(), &meta); err != nil || w.Code != http.StatusOK || meta.Limits.MaxMessageLength != 500 {
+		t.Errorf("expected the configured message limit with a new ETag, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestGetMeta_RegisteredLanguage(t *testing.T) {
+	defer func(languages []data.LanguageInfo) { data.Languages = languages }(data.Languages)
+	data.Languages = append(slices.Clone(data.Languages), data.LanguageInfo{Code: "x-test", Name: "Test"})
+
+	router := setupTestRouterWithProvider(ai.NewMockProvider(), nil)
+	var meta MetaResponseDTO
+	if err := json.Unmarshal(getMeta(router, "").Body.Bytes(), &meta); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+	if !slices.Contains(meta.Languages, LanguageDTO{Code: "x-test", Name: "Test"}) {
+		t.Errorf("expected the registered language listed, got %+v", meta.Languages)
+	}
+	if !data.ValidateLanguage("x-test") {
+		t.Error("expected the registered language accepted")
+	}
+}
@@ -0,0 +1,93 @@
+// Prometheus metrics and per-request timing helpers
+package api
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// Timing stages recorded for each instrumented request
+const (
+	stageQueue    = "queue"
+	stageProvider = "provider"
+	stageStore    = "store"
+	stageTotal    = "total"
+)
+
+// requestStageDuration records where time goes within a request, labelled by route and stage
+var requestStageDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+	Namespace: "ai_interview",
+	Name:      "request_stage_duration_seconds",
+	Help:      "Time spent in each stage of an API request (queue, provider, store, total).",
+	Buckets:   []float64{0.005, 0.01, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30},
+}, []string{"route", "stage"})
+
+// chatAbuseRejections counts candidate messages refused by the anti-abuse checks, by reason
+var chatAbuseRejections = promauto.NewCounterVec(prometheus.CounterOpts{
+	Namespace: "ai_interview",
+	Name:      "chat_abuse_rejections_total",
+	Help:      "Candidate chat messages refused before any AI call by the anti-abuse checks, by reason.",
+}, []string{"reason"})
+
+// storeMaintenanceDuration records how long store maintenance runs take, by outcome (ok, failed)
+var storeMaintenanceDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+	Namespace: "ai_interview",
+	Name:      "store_maintenance_duration_seconds",
+	Help:      "Time spent in each store maintenance run (VACUUM and table sizing), by outcome (ok, failed).",
+	Buckets:   []float64{0.01, 0.1, 1, 5, 15, 30, 60, 300, 900, 1800},
+}, []string{"outcome"})
+
+// requestTimings accumulates stage durations for a single request
+// All durations are measured with time.Since, so they use the monotonic clock
+type requestTimings struct {
+	start    time.Time
+	queue    time.Duration // Waiting for a rate limiter or worker slot (none exist yet, so always zero)
+	provider time.Duration // Reported by the AI provider (ai.ChatResponse.ResponseTime)
+	store    time.Duration // Sum of all persistence calls
+	budget   *turnBudget   // Chat turns only; its consumption is reported with the timings
+}
+
+func newRequestTimings() *requestTimings {
+	return &requestTimings{start: time.Now()}
+}
+
+// addStore adds the time elapsed since storeStart to the persistence total
+func (t *requestTimings) addStore(storeStart time.Time) {
+	t.store += time.Since(storeStart)
+}
+
+// addProvider adds time reported by the AI provider
+func (t *requestTimings) addProvider(d time.Duration) {
+	t.provider += d
+}
+
+// finish computes the total, records the histograms under route and returns the DTO
+func (t *requestTimings) finish(route string) *MessageTimingsDTO {
+	total := time.Since(t.start)
+	requestStageDuration.WithLabelValues(route, stageQueue).Observe(t.queue.Seconds())
+	requestStageDuration.WithLabelValues(route, stageProvider).Observe(t.provider.Seconds())
+	requestStageDuration.WithLabelValues(route, stageStore).Observe(t.store.Seconds())
+	requestStageDuration.WithLabelValues(route, stageTotal).Observe(total.Seconds())
+
+	return &MessageTimingsDTO{
+		QueueMs:    t.queue.Milliseconds(),
+		ProviderMs: t.provider.Milliseconds(),
+		StoreMs:    t.store.Milliseconds(),
+		TotalMs:    total.Milliseconds(),
+		Budget:     t.budget.dto(),
+	}
+}
+
+// routeLabel returns the matched chi route pattern, so IDs in the path don't become label values
+func routeLabel(r *http.Request) string {
+	if rctx := chi.RouteContext(r.Context()); rctx != nil {
+		if pattern := rctx.RoutePattern(); pattern != "" {
+			return r.Method + " " + pattern
+		}
+	}
+	return r.Method + " unmatched"
+}
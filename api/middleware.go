@@ -2,9 +2,16 @@
 package api
 
 import (
+	"bufio"
+	"fmt"
+	"net"
 	"net/http"
+	"net/url"
+	"strings"
 	"time"
 
+	"github.com/go-chi/chi/v5"
+	"github.com/zidane0000/ai-interview-platform/data"
 	"github.com/zidane0000/ai-interview-platform/utils"
 )
 
@@ -12,16 +19,163 @@ import (
 func LoggingMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		start := time.Now()
-		lrw := &loggingResponseWriter{ResponseWriter: w, statusCode: http.StatusOK}
+		lrw := &loggingResponseWriter{responseWriterWrapper: wrapResponseWriter(w), statusCode: http.StatusOK}
 		next.ServeHTTP(lrw, r)
 		duration := time.Since(start)
 		utils.Infof("%s %s %d %s", r.Method, r.URL.Path, lrw.statusCode, duration)
 	})
 }
 
+// AdminAuthMiddleware requires the admin token as a bearer token in the Authorization header
+// All requests are refused when no admin token is configured
+func AdminAuthMiddleware(token string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if token == "" {
+				writeJSONError(w, http.StatusForbidden, ErrCodeForbidden, "Admin access is not configured")
+				return
+			}
+			if !validAdminToken(r, token) {
+				writeJSONError(w, http.StatusUnauthorized, ErrCodeUnauthorized, "Invalid admin token")
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// TenantMiddleware scopes the request's store operations to the tenant of its X-API-Key header
+// With no keys configured multi-tenancy is off and requests pass through unscoped; otherwise a
+// missing or unknown key gets 401.
+func TenantMiddleware(keys map[string]string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if len(keys) == 0 {
+				next.ServeHTTP(w, r)
+				return
+			}
+			tenantID, ok := keys[r.Header.Get("X-API-Key")]
+			if !ok {
+				writeJSONError(w, http.StatusUnauthorized, ErrCodeUnauthorized, "Invalid or missing API key")
+				return
+			}
+			next.ServeHTTP(w, r.WithContext(data.WithTenant(r.Context(), tenantID)))
+		})
+	}
+}
+
+// ChatSessionScopeMiddleware only lets a chat session be reached through the interview it belongs to
+// On /interviews/{id}/chat/{sessionId} routes the session must belong to interview {id}; on the legacy
+// /chat/{sessionId} routes its interview must still exist. A session outside the caller's interview
+// gets the same 404 as an unknown one, so session IDs can't be probed across interviews.
+func (deps *HandlerDependencies) ChatSessionScopeMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		store := deps.Store.WithContext(r.Context())
+		session, err := store.GetChatSession(chi.URLParam(r, "sessionId"))
+		if err != nil {
+			writeJSONError(w, http.StatusNotFound, ErrCodeNotFound, ErrMsgSessionNotFound)
+			return
+		}
+		if interviewID := chi.URLParam(r, "id"); interviewID != "" {
+			if session.InterviewID != interviewID {
+				writeJSONError(w, http.StatusNotFound, ErrCodeNotFound, ErrMsgSessionNotFound)
+				return
+			}
+		} else if _, err := store.GetInterview(session.InterviewID); err != nil {
+			writeJSONError(w, http.StatusNotFound, ErrCodeNotFound, ErrMsgSessionNotFound)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// maxResourceIDLength bounds the IDs accepted in paths; generated IDs are 36-character UUIDs
+const maxResourceIDLength = 64
+
+// ResourceIDMiddleware rejects a malformed {id} or {sessionId} path parameter with 400 before any
+// store call. An ID must be 1 to maxResourceIDLength letters, digits, '-' or '_', without surrounding
+// whitespace. chi matches escaped paths undecoded, so percent-encoded IDs are decoded here, once; a
+// double-encoded ID stays encoded and is rejected. It must run inline (Group or With) so the
+// parameters are matched.
+func ResourceIDMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rctx := chi.RouteContext(r.Context())
+		if rctx == nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+		for i, key := range rctx.URLParams.Keys {
+			if key != "id" && key != "sessionId" {
+				continue
+			}
+			value := rctx.URLParams.Values[i]
+			if r.URL.RawPath != "" {
+				decoded, err := url.PathUnescape(value)
+				if err != nil {
+					writeJSONError(w, http.StatusBadRequest, ErrCodeValidationFailed, "Invalid "+key, "malformed percent-encoding")
+					return
+				}
+				value = decoded
+				rctx.URLParams.Values[i] = value
+			}
+			if msg := validateResourceID(value); msg != "" {
+				writeJSONError(w, http.StatusBadRequest, ErrCodeValidationFailed, "Invalid "+key, msg)
+				return
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// validateResourceID returns why id is not a valid resource ID, or "" when it is
+func validateResourceID(id string) string {
+	switch {
+	case strings.TrimSpace(id) == "":
+		return "ID is empty"
+	case strings.TrimSpace(id) != id:
+		return "ID has surrounding whitespace"
+	case len(id) > maxResourceIDLength:
+		return fmt.Sprintf("ID is longer than %d characters", maxResourceIDLength)
+	}
+	for _, c := range id {
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '-' || c == '_') {
+			return "ID may only contain letters, digits, '-' and '_'"
+		}
+	}
+	return ""
+}
+
+// responseWriterWrapper is what the api package's ResponseWriter wrappers embed instead of
+// http.ResponseWriter: it passes Flush and Hijack through to the wrapped writer and exposes it to
+// http.ResponseController (write deadlines, full duplex), so server-sent events and WebSocket
+// upgrades keep working behind every middleware. Wrappers override only the methods they change.
+type responseWriterWrapper struct {
+	http.ResponseWriter
+}
+
+// wrapResponseWriter returns the base of a ResponseWriter wrapper around w
+func wrapResponseWriter(w http.ResponseWriter) responseWriterWrapper {
+	return responseWriterWrapper{ResponseWriter: w}
+}
+
+// Flush sends buffered data to the client; a flush is ignored when the writer cannot flush
+func (rw responseWriterWrapper) Flush() {
+	_ = http.NewResponseController(rw.ResponseWriter).Flush()
+}
+
+// Hijack hands the connection over to the handler, failing when the writer cannot be hijacked (HTTP/2)
+func (rw responseWriterWrapper) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	return http.NewResponseController(rw.ResponseWriter).Hijack()
+}
+
+// Unwrap exposes the wrapped writer to http.ResponseController
+func (rw responseWriterWrapper) Unwrap() http.ResponseWriter {
+	return rw.ResponseWriter
+}
+
 // loggingResponseWriter wraps http.ResponseWriter to capture status code.
 type loggingResponseWriter struct {
-	http.ResponseWriter
+	responseWriterWrapper
 	statusCode int
 }
 
@@ -30,6 +184,15 @@ func (lrw *loggingResponseWriter) WriteHeader(code int) {
 	lrw.ResponseWriter.WriteHeader(code)
 }
 
+// Hijack logs a hijacked connection as switching protocols, the status a WebSocket upgrade sends itself
+func (lrw *loggingResponseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	conn, rw, err := lrw.responseWriterWrapper.Hijack()
+	if err == nil {
+		lrw.statusCode = http.StatusSwitchingProtocols
+	}
+	return conn, rw, err
+}
+
 // CORSMiddleware adds CORS headers to allow cross-origin requests from browsers
 func CORSMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -61,17 +224,12 @@ func CORSMiddleware(next http.Handler) http.Handler {
 			w.Header().Set("Access-Control-Allow-Origin", "*")
 		}
 
-		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-Requested-With, X-OpenAI-Key, X-Gemini-Key, X-OpenAI-Base-URL")
+		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, PATCH, DELETE, OPTIONS")
+		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-Requested-With, X-OpenAI-Key, X-Gemini-Key, X-OpenAI-Base-URL, X-API-Key")
 		w.Header().Set("Access-Control-Expose-Headers", "Content-Length, Content-Type")
 		w.Header().Set("Access-Control-Max-Age", "86400")
 
-		// Handle preflight OPTIONS requests
-		if r.Method == "OPTIONS" {
-			w.WriteHeader(http.StatusOK)
-			return
-		}
-
+		// Preflight OPTIONS requests continue to the router, which answers them with the route's Allow header
 		next.ServeHTTP(w, r)
 	})
 }
@@ -114,12 +272,6 @@ func CORSMiddleware(next http.Handler) http.Handler {
 // - Validates required headers and parameters
 // - Returns detailed validation error messages
 
-// TODO: CompressionMiddleware - Optimizes performance
-// - Compresses responses with gzip/deflate
-// - Reduces bandwidth usage and improves speed
-// - Configurable compression levels
-// - Handles Accept-Encoding headers properly
-
 // TODO: SecurityMiddleware - Hardens application security
 // - Adds security headers (CSP, HSTS, X-Frame-Options)
 // - Prevents common web vulnerabilities
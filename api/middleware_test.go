@@ -0,0 +1,116 @@
+package api
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// TestMiddlewareStack_StreamingAndHijack serves a server-sent event stream and a WebSocket-style
+// upgrade behind every middleware SetupRouter installs: the root stack through the frontend
+// handler, with the /api stack chained in front of the test handlers
+func TestMiddlewareStack_StreamingAndHijack(t *testing.T) {
+	deps := NewHandlerDependencies(nil, newTestStore())
+	received := make(chan struct{})
+	mux := http.NewServeMux()
+	mux.HandleFunc("/stream", func(w http.ResponseWriter, r *http.Request) {
+		flusher, ok := w.(http.Flusher)
+		if !ok {
+			t.Error("expected the ResponseWriter to implement http.Flusher")
+			return
+		}
+		// Long streams lift the server's write timeout through http.ResponseController
+		if err := http.NewResponseController(w).SetWriteDeadline(time.Time{}); err != nil {
+			t.Errorf("expected the write deadline to be settable, got %v", err)
+		}
+		w.Header().Set("Content-Type", "text/event-stream")
+		for i := 1; i <= 3; i++ {
+			fmt.Fprintf(w, "data: event %d\n\n", i)
+			flusher.Flush()
+			// Each event must reach the client before the next is written
+			select {
+			case <-received:
+			case <-time.After(5 * time.Second):
+				t.Errorf("event %d was not flushed to the client", i)
+				return
+			}
+		}
+	})
+	mux.HandleFunc("/ws", func(w http.ResponseWriter, r *http.Request) {
+		hijacker, ok := w.(http.Hijacker)
+		if !ok {
+			t.Error("expected the ResponseWriter to implement http.Hijacker")
+			return
+		}
+		conn, rw, err := hijacker.Hijack()
+		if err != nil {
+			t.Errorf("Hijack failed: %v", err)
+			return
+		}
+		defer conn.Close()
+		rw.WriteString("HTTP/1.1 101 Switching Protocols\r\nUpgrade: websocket\r\nConnection: Upgrade\r\n\r\n")
+		rw.Flush()
+		// Echo one line back over the upgraded connection
+		line, err := rw.ReadString('\n')
+		if err != nil {
+			t.Errorf("failed to read from the hijacked connection: %v", err)
+			return
+		}
+		rw.WriteString(line)
+		rw.Flush()
+	})
+	frontend := chi.Chain(deps.apiMiddleware()...).Handler(mux)
+	server := httptest.NewServer(SetupRouter(nil, deps, frontend))
+	defer server.Close()
+
+	t.Run("server-sent events", func(t *testing.T) {
+		req, _ := http.NewRequest("GET", server.URL+"/stream", nil)
+		// Set explicitly, so the transport leaves a compressed body as it is
+		req.Header.Set("Accept-Encoding", "gzip")
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("request failed: %v", err)
+		}
+		defer resp.Body.Close()
+		if resp.Header.Get("Content-Encoding") != "" {
+			t.Errorf("expected the stream uncompressed, got %q", resp.Header.Get("Content-Encoding"))
+		}
+		reader := bufio.NewReader(resp.Body)
+		for i := 1; i <= 3; i++ {
+			line, err := reader.ReadString('\n')
+			if err != nil || line != fmt.Sprintf("data: event %d\n", i) {
+				t.Fatalf("expected event %d, got %q (%v)", i, line, err)
+			}
+			if _, err := reader.ReadString('\n'); err != nil {
+				t.Fatalf("failed to read the end of event %d: %v", i, err)
+			}
+			received <- struct{}{}
+		}
+	})
+
+	t.Run("websocket upgrade", func(t *testing.T) {
+		conn, err := net.Dial("tcp", server.Listener.Addr().String())
+		if err != nil {
+			t.Fatalf("dial failed: %v", err)
+		}
+		defer conn.Close()
+		conn.SetDeadline(time.Now().Add(5 * time.Second))
+		fmt.Fprintf(conn, "GET /ws HTTP/1.1\r\nHost: %s\r\nAccept-Encoding: gzip\r\nConnection: Upgrade\r\nUpgrade: websocket\r\n\r\n", server.Listener.Addr())
+		reader := bufio.NewReader(conn)
+		resp, err := http.ReadResponse(reader, nil)
+		if err != nil || resp.StatusCode != http.StatusSwitchingProtocols {
+			t.Fatalf("expected 101 Switching Protocols, got %v (%v)", resp, err)
+		}
+		fmt.Fprint(conn, "ping\n")
+		if line, err := reader.ReadString('\n'); err != nil || strings.TrimSpace(line) != "ping" {
+			t.Errorf("expected the upgraded connection to echo ping, got %q (%v)", line, err)
+		}
+	})
+}
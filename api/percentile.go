@@ -0,0 +1,78 @@
+// Percentile ranks of scores within a reference cohort, comparable across models and prompt versions
+package api
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/zidane0000/ai-interview-platform/data"
+)
+
+// scoreCohortWindow is how far back the reference cohort of a percentile rank reaches
+const scoreCohortWindow = 90 * 24 * time.Hour
+
+// minCohortSize is the fewest evaluations a cohort needs for its percentile ranks to mean anything
+const minCohortSize = 5
+
+// percentileRank returns the percentage of sorted scores below score, with ties counted as half
+// below (mid-rank interpolation), rounded to one decimal: the lowest of 5 distinct scores ranks 10,
+// the highest 90. sorted must be ascending and non-empty.
+func percentileRank(sorted []float64, score float64) float64 {
+	below := sort.SearchFloat64s(sorted, score)
+	equal := sort.SearchFloat64s(sorted[below:], math.Nextafter(score, math.Inf(1)))
+	rank := (float64(below) + float64(equal)/2) / float64(len(sorted)) * 100
+	return math.Round(rank*10) / 10
+}
+
+// cohortRanker ranks scores within the reference cohort of their interview type and model:
+// current evaluations of the trailing scoreCohortWindow. Each cohort is loaded once.
+type cohortRanker struct {
+	store   data.Store
+	since   time.Time
+	cohorts map[[2]string][]float64
+}
+
+// newCohortRanker creates a ranker reading cohorts from store
+func (deps *HandlerDependencies) newCohortRanker(store data.Store) *cohortRanker {
+	return &cohortRanker{store: store, since: deps.now().Add(-scoreCohortWindow), cohorts: make(map[[2]string][]float64)}
+}
+
+// rank returns the percentile rank of score in its cohort and the cohort size; the rank is nil
+// when the cohort has fewer than minCohortSize evaluations
+func (c *cohortRanker) rank(interviewType, model string, score float64) (*float64, int, error) {
+	key := [2]string{interviewType, model}
+	cohort, ok := c.cohorts[key]
+	if !ok {
+		var err error
+		if cohort, err = c.store.GetScoreDistribution(interviewType, model, c.since); err != nil {
+			return nil, 0, err
+		}
+		c.cohorts[key] = cohort
+	}
+	if len(cohort) < minCohortSize {
+		return nil, len(cohort), nil
+	}
+	percentile := percentileRank(cohort, score)
+	return &percentile, len(cohort), nil
+}
+
+// evaluationPercentile ranks an evaluation within its cohort, adding a warning when the cohort is too small
+func (c *cohortRanker) evaluationPercentile(evaluation *data.Evaluation, warnings *warningList) (*float64, int, error) {
+	interview, err := c.store.GetInterview(evaluation.InterviewID)
+	if err != nil {
+		return nil, 0, err
+	}
+	percentile, size, err := c.rank(interview.InterviewType, evaluation.Model, evaluation.Score)
+	if err == nil && percentile == nil {
+		warnings.add(WarnCodeSmallCohort, "percentile", smallCohortMessage(size))
+	}
+	return percentile, size, err
+}
+
+// smallCohortMessage explains a percentile left out because its cohort has size evaluations
+func smallCohortMessage(size int) string {
+	return fmt.Sprintf("Percentile needs at least %d evaluations of the same interview type and model in the last %d days; found %d",
+		minCohortSize, int(scoreCohortWindow.Hours()/24), size)
+}
@@ -0,0 +1,146 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/zidane0000/ai-interview-platform/ai"
+	"github.com/zidane0000/ai-interview-platform/data"
+	"github.com/zidane0000/ai-interview-platform/internal/testsupport"
+)
+
+func TestPercentileRank(t *testing.T) {
+	sorted := []float64{0.2, 0.4, 0.6, 0.6, 0.8}
+	tests := []struct {
+		name     string
+		scores   []float64
+		score    float64
+		expected float64
+	}{
+		{"lowest", sorted, 0.2, 10},
+		{"highest", sorted, 0.8, 90},
+		{"ties share their mid-rank", sorted, 0.6, 60},
+		{"between scores", sorted, 0.5, 40},
+		{"below the cohort", sorted, 0.1, 0},
+		{"above the cohort", sorted, 1, 100},
+		{"single score", []float64{0.5}, 0.5, 50},
+		{"all tied", []float64{0.7, 0.7, 0.7}, 0.7, 50},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := percentileRank(tt.scores, tt.score); got != tt.expected {
+				t.Errorf("expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+// seedScoredEvaluations creates one technical interview per score, each with a completed evaluation by model
+func seedScoredEvaluations(t *testing.T, store data.Store, model string, scores ...float64) []*data.Evaluation {
+	t.Helper()
+	var evaluations []*data.Evaluation
+	for _, score := range scores {
+		interview := testsupport.NewInterviewBuilder().WithType("technical").Create(t, store)
+		evaluation := &data.Evaluation{ID: data.GenerateID(), InterviewID: interview.ID, Score: score, Model: model, Status: data.EvaluationStatusCompleted}
+		if err := store.CreateEvaluation(evaluation); err != nil {
+			t.Fatalf("CreateEvaluation failed: %v", err)
+		}
+		evaluations = append(evaluations, evaluation)
+	}
+	return evaluations
+}
+
+func TestGetEvaluation_Percentile(t *testing.T) {
+	router := setupTestRouterWithProvider(ai.NewMockProvider(), func(deps *HandlerDependencies) {
+		deps.AdminToken = "admin-secret"
+	})
+	cohort := seedScoredEvaluations(t, router.store, "gpt-4", 0.2, 0.4, 0.6, 0.6, 0.8)
+	lonely := seedScoredEvaluations(t, router.store, "gemini", 0.9)[0]
+
+	getEvaluation := func(id, query string) EvaluationResponseDTO {
+		t.Helper()
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("GET", "/api/evaluation/"+id+query, nil))
+		var resp EvaluationResponseDTO
+		if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil || w.Code != http.StatusOK {
+			t.Fatalf("failed to get evaluation, got %d: %s", w.Code, w.Body.String())
+		}
+		return resp
+	}
+
+	// The percentile is only computed when asked for
+	if resp := getEvaluation(cohort[2].ID, ""); resp.Percentile != nil || resp.CohortSize != 0 {
+		t.Errorf("expected no percentile without include, got %v of %d", resp.Percentile, resp.CohortSize)
+	}
+	resp := getEvaluation(cohort[2].ID, "?include=percentile")
+	if resp.Percentile == nil || *resp.Percentile != 60 || resp.CohortSize != 5 {
+		t.Errorf("expected the tied score to rank 60 of 5, got %v of %d", resp.Percentile, resp.CohortSize)
+	}
+	if resp := getEvaluation(cohort[4].ID, "?include=percentile"); resp.Percentile == nil || *resp.Percentile != 90 {
+		t.Errorf("expected the highest score to rank 90, got %v", resp.Percentile)
+	}
+
+	// A cohort too small to rank against leaves the percentile out with a warning
+	resp = getEvaluation(lonely.ID, "?include=percentile")
+	if resp.Percentile != nil || resp.CohortSize != 1 {
+		t.Errorf("expected no percentile for a cohort of 1, got %v of %d", resp.Percentile, resp.CohortSize)
+	}
+	if len(resp.Warnings) != 1 || resp.Warnings[0].Code != WarnCodeSmallCohort {
+		t.Errorf("expected a small_cohort warning, got %+v", resp.Warnings)
+	}
+
+	// Admin stats rank the interview's evaluation too
+	w := adminRequest(router, "GET", "/api/admin/stats?interview_id="+cohort[0].InterviewID, "")
+	var stats AdminStatsResponseDTO
+	if err := json.Unmarshal(w.Body.Bytes(), &stats); err != nil || w.Code != http.StatusOK {
+		t.Fatalf("failed to get stats, got %d: %s", w.Code, w.Body.String())
+	}
+	if p := stats.EvaluationPercentile; p == nil || p.EvaluationID != cohort[0].ID || p.Percentile == nil || *p.Percentile != 10 || p.CohortSize != 5 {
+		t.Errorf("expected the lowest score to rank 10 of 5, got %+v", p)
+	}
+	w = adminRequest(router, "GET", "/api/admin/stats?interview_id="+lonely.InterviewID, "")
+	stats = AdminStatsResponseDTO{}
+	if err := json.Unmarshal(w.Body.Bytes(), &stats); err != nil {
+		t.Fatalf("failed to unmarshal stats: %v", err)
+	}
+	if p := stats.EvaluationPercentile; p == nil || p.Percentile != nil || len(stats.Warnings) != 1 || stats.Warnings[0].Code != WarnCodeSmallCohort {
+		t.Errorf("expected a null percentile with a small_cohort warning, got %+v, %+v", p, stats.Warnings)
+	}
+	if !strings.Contains(w.Body.String(), `"percentile":null`) {
+		t.Errorf("expected the percentile to be reported as null, got %s", w.Body.String())
+	}
+}
+
+func TestCalibrateEvaluations_Percentile(t *testing.T) {
+	router := setupCalibrationRouter(map[string][]float64{
+		"openai/gpt-4o":           {0.7},
+		"gemini/gemini-1.5-flash": {0.9},
+	})
+	seedScoredEvaluations(t, router.store, "gpt-4o", 0.5, 0.6, 0.7, 0.8, 0.9)
+	interview := testsupport.NewInterviewBuilder().WithType("technical").Create(t, router.store)
+	testsupport.NewSessionBuilder().ForInterview(interview).WithID("calibrate-percentile").
+		WithTranscript(testsupport.Pair("What is a goroutine?", "A lightweight thread")).Create(t, router.store)
+
+	body := `{"session_ids":["calibrate-percentile"],` +
+		`"models":[{"provider":"openai","model":"gpt-4o"},{"provider":"gemini","model":"gemini-1.5-flash"}]}`
+	w := adminRequest(router, "POST", "/api/admin/evaluations/calibrate?include=percentile", body)
+	var resp CalibrationRunResponseDTO
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil || w.Code != http.StatusOK {
+		t.Fatalf("failed to calibrate, got %d: %s", w.Code, w.Body.String())
+	}
+
+	// Each model's score ranks within that model's cohort; gemini has none to rank against
+	scores := resp.Sessions[0].Scores
+	if scores[0].Percentile == nil || *scores[0].Percentile != 50 {
+		t.Errorf("expected gpt-4o's 0.7 to rank 50, got %v", scores[0].Percentile)
+	}
+	if scores[1].Percentile != nil {
+		t.Errorf("expected no percentile for gemini, got %v", *scores[1].Percentile)
+	}
+	if len(resp.Warnings) != 1 || resp.Warnings[0].Code != WarnCodeSmallCohort {
+		t.Errorf("expected one small_cohort warning, got %+v", resp.Warnings)
+	}
+}
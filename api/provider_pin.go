@@ -0,0 +1,75 @@
+// Session provider pinning: every interviewer turn of a chat session is answered by the same provider and model
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/zidane0000/ai-interview-platform/ai"
+	"github.com/zidane0000/ai-interview-platform/data"
+	"github.com/zidane0000/ai-interview-platform/utils"
+)
+
+// turnAIClient returns the AI client for the next interviewer turn of session, and the client to
+// retry the turn with should it fail
+// A session pinned by pinProvider keeps its provider and model even when the request's default differs,
+// and the request's default is the fallback. The default answers outright, without a fallback, when
+// the session isn't pinned yet, is pinned to the default, or the request lacks the pinned provider's key.
+func (deps *HandlerDependencies) turnAIClient(r *http.Request, session *data.ChatSession) (aiClient, fallback *ai.AIClient) {
+	defaultClient := deps.newAIClient(r)
+	if session.Provider == "" || (session.Provider == defaultClient.GetCurrentProvider() && session.Model == defaultClient.GetCurrentModel()) {
+		return defaultClient, nil
+	}
+	pinned, err := deps.newModelAIClient(r, session.Provider, session.Model)
+	if err != nil {
+		utils.Warningf("Chat session %s is pinned to %s/%s, which is unavailable: %v", session.ID, session.Provider, session.Model, err)
+		return defaultClient, nil
+	}
+	return pinned, defaultClient
+}
+
+// runTurn makes an interviewer turn with aiClient and, when that fails, once more with fallback
+// The turn is not retried without a fallback, or when it failed on a limit the fallback shares: the
+// session's attempt budget, the AI concurrency cap or the request's deadline. Both calls go through
+// the clients' limits like any other. Returns the client that made the last call.
+func runTurn(ctx context.Context, session *data.ChatSession, aiClient, fallback *ai.AIClient, turn func(*ai.AIClient) error) (*ai.AIClient, error) {
+	err := turn(aiClient)
+	if err == nil || fallback == nil || ctx.Err() != nil ||
+		errors.Is(err, ai.ErrAttemptBudgetExhausted) || errors.Is(err, ai.ErrOverloaded) {
+		return aiClient, err
+	}
+	utils.Warningf("Chat session %s: pinned provider %s/%s failed, falling back to %s/%s: %v", session.ID,
+		aiClient.GetCurrentProvider(), aiClient.GetCurrentModel(), fallback.GetCurrentProvider(), fallback.GetCurrentModel(), err)
+	return fallback, turn(fallback)
+}
+
+// pinProvider pins session to the provider and model of aiClient once it answered a turn successfully
+// A session pinned to another pair is re-pinned, and an internal system message records the switch
+// for audit; like other system messages, the evaluation sees it as a session note.
+func (deps *HandlerDependencies) pinProvider(store data.Store, session *data.ChatSession, aiClient *ai.AIClient) {
+	provider, model := aiClient.GetCurrentProvider(), aiClient.GetCurrentModel()
+	if provider == session.Provider && model == session.Model {
+		return
+	}
+	previousProvider, previousModel := session.Provider, session.Model
+	if err := store.PinChatSessionProvider(session.ID, provider, model); err != nil {
+		utils.Errorf("Failed to pin chat session %s to %s/%s: %v", session.ID, provider, model, err)
+		return
+	}
+	session.Provider, session.Model = provider, model
+	if previousProvider == "" {
+		return
+	}
+	if err := store.AddChatMessage(session.ID, &data.ChatMessage{
+		ID:         data.GenerateID(),
+		SessionID:  session.ID,
+		Type:       "system",
+		Content:    fmt.Sprintf("AI provider switched from %s/%s to %s/%s", previousProvider, previousModel, provider, model),
+		Visibility: data.MessageVisibilityInternal,
+		Timestamp:  deps.now(),
+	}); err != nil {
+		utils.Errorf("Failed to record the provider switch of session %s: %v", session.ID, err)
+	}
+}
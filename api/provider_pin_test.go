@@ -0,0 +1,101 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/zidane0000/ai-interview-platform/ai"
+	"github.com/zidane0000/ai-interview-platform/data"
+	"github.com/zidane0000/ai-interview-platform/internal/testsupport"
+)
+
+func TestProviderPinning(t *testing.T) {
+	primary, backup := ai.NewMockProvider(), ai.NewMockProvider()
+	providers := map[string]*ai.MockProvider{"mock/mock-model": primary, "mock/mock-backup": backup}
+	clientFor := func(key string) *ai.AIClient {
+		provider, model, _ := strings.Cut(key, "/")
+		return ai.NewAIClientWithProvider(providers[key], &ai.AIConfig{DefaultProvider: provider, DefaultModel: model})
+	}
+	requestDefault := "mock/mock-model"
+	var store data.Store
+	router := setupTestRouterWithProvider(primary, func(deps *HandlerDependencies) {
+		store = deps.Store
+		deps.AdminToken = "admin-secret"
+		deps.newAIClient = func(r *http.Request) *ai.AIClient { return clientFor(requestDefault) }
+		deps.newModelAIClient = func(r *http.Request, provider, model string) (*ai.AIClient, error) {
+			return clientFor(provider + "/" + model), nil
+		}
+	})
+	interview := createTestInterview(t, router, testsupport.NewInterviewBuilder().WithQuestions(3))
+
+	// The greeting is the first successful turn and pins the request's provider and model
+	session := decodeStartedSession(t, startWithGreeting(router, interview.ID, data.GreetingModeAI))
+	if session.Provider != "mock" || session.Model != "mock-model" {
+		t.Fatalf("expected the session pinned to mock/mock-model, got %s/%s", session.Provider, session.Model)
+	}
+
+	// The pin is honored although the request's default changed
+	requestDefault = "mock/mock-backup"
+	sendMessage(t, router, session.ID, "I build backend services in Go")
+	primaryCalls := len(primary.ChatRequests())
+	if primaryCalls != 2 || len(backup.ChatRequests()) != 0 {
+		t.Fatalf("expected the pinned provider to answer, got %d pinned and %d default calls", primaryCalls, len(backup.ChatRequests()))
+	}
+
+	// Once a turn of the pinned provider fails, the default answers it and the session is re-pinned;
+	// both calls count against the session's AI attempts
+	pinned := aiAttempts(t, store, session.ID)
+	primary.PlanFaults(ai.MockFault{Op: ai.MockOpChat})
+	reply := sendMessage(t, router, session.ID, "Mostly with table tests")
+	failed := aiAttempts(t, store, session.ID)
+	if reply.AIResponse == nil || len(backup.ChatRequests()) != 1 {
+		t.Fatalf("expected the default provider to answer, got %d default calls", len(backup.ChatRequests()))
+	}
+
+	w := adminRequest(router, "GET", "/api/chat/"+session.ID+"?view=full", "")
+	var full ChatInterviewSessionDTO
+	if err := json.Unmarshal(w.Body.Bytes(), &full); err != nil || w.Code != http.StatusOK {
+		t.Fatalf("expected the full transcript, got %d: %s", w.Code, w.Body.String())
+	}
+	if full.Provider != "mock" || full.Model != "mock-backup" {
+		t.Errorf("expected the session re-pinned to mock/mock-backup, got %s/%s", full.Provider, full.Model)
+	}
+
+	// Later turns use the new pin without trying the old provider again
+	sendMessage(t, router, session.ID, "And benchmarks")
+	if len(backup.ChatRequests()) != 2 || len(primary.ChatRequests()) != primaryCalls {
+		t.Errorf("expected the re-pinned provider to answer, got %d old and %d new calls", len(primary.ChatRequests()), len(backup.ChatRequests()))
+	}
+	repinned := aiAttempts(t, store, session.ID) - failed
+	if failed-pinned != repinned+1 {
+		t.Errorf("expected the failed call counted on top of a turn's attempts, got %d for the fallback turn and %d for the next", failed-pinned, repinned)
+	}
+	var notes []ChatMessageDTO
+	for _, msg := range full.Messages {
+		if msg.Type == "system" {
+			notes = append(notes, msg)
+		}
+	}
+	if len(notes) != 1 || notes[0].Visibility != data.MessageVisibilityInternal || notes[0].Content != "AI provider switched from mock/mock-model to mock/mock-backup" {
+		t.Errorf("expected one internal note of the switch, got %+v", notes)
+	}
+
+	// The candidate doesn't see the note
+	for _, msg := range getChatSession(t, router, session.ID, "").Messages {
+		if msg.Type == "system" {
+			t.Errorf("expected the switch hidden from the candidate, got %+v", msg)
+		}
+	}
+}
+
+// aiAttempts returns the AI attempts counted for a chat session
+func aiAttempts(t *testing.T, store data.Store, sessionID string) int {
+	t.Helper()
+	session, err := store.GetChatSession(sessionID)
+	if err != nil {
+		t.Fatalf("failed to get session: %v", err)
+	}
+	return session.AIAttempts
+}
@@ -0,0 +1,127 @@
+// Generating interview questions in the background
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/zidane0000/ai-interview-platform/ai"
+	"github.com/zidane0000/ai-interview-platform/data"
+	"github.com/zidane0000/ai-interview-platform/utils"
+)
+
+// questionGenerationTimeout bounds one background question generation, provider retries included
+const questionGenerationTimeout = 2 * time.Minute
+
+// queueQuestionGeneration hands the generation of a stored interview's questions to the worker
+// pool, using the AI client of the request that asked for them (BYOK)
+func (deps *HandlerDependencies) queueQuestionGeneration(r *http.Request, interviewID string) {
+	aiClient := deps.newAIClient(r)
+	deps.Workers.Submit(r.Context(), func(ctx context.Context) {
+		deps.generateQuestionsInBackground(ctx, aiClient, interviewID)
+	})
+}
+
+// generateQuestionsInBackground generates an interview's questions and records them, or records
+// why generation failed; the interview is marked failed too when the pool stops first
+func (deps *HandlerDependencies) generateQuestionsInBackground(ctx context.Context, aiClient *ai.AIClient, interviewID string) {
+	// Outcomes are recorded even after ctx is canceled, so the interview is never left generating
+	store := deps.Store.WithContext(context.WithoutCancel(ctx))
+	fail := func(reason string) {
+		utils.Errorf("Failed to generate the questions of interview %s: %s", interviewID, reason)
+		if err := store.SetInterviewQuestionsStatus(interviewID, data.QuestionsStatusFailed, nil, reason); err != nil {
+			utils.Errorf("Failed to record the question generation failure of interview %s: %v", interviewID, err)
+		}
+	}
+	if ctx.Err() != nil {
+		fail("question generation was interrupted by a server shutdown")
+		return
+	}
+	interview, err := store.GetInterview(interviewID)
+	if err != nil {
+		utils.Errorf("Interview %s is gone before its questions were generated: %v", interviewID, err)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, questionGenerationTimeout)
+	defer cancel()
+	generated, duplicates, err := deps.generateInterviewQuestions(ctx, aiClient, interview)
+	if err != nil {
+		fail(err.Error())
+		return
+	}
+	if len(generated) == 0 {
+		fail("the AI returned no questions")
+		return
+	}
+	for _, duplicate := range duplicates {
+		utils.Infof("Duplicate generated question removed from interview %s: %q", interviewID, duplicate)
+	}
+	if err := store.SetInterviewQuestionsStatus(interviewID, data.QuestionsStatusReady, generated, ""); err != nil {
+		utils.Errorf("Failed to save the generated questions of interview %s: %v", interviewID, err)
+	}
+}
+
+// fillGeneratedQuestions generates the questions of an interview that is not stored yet, in the
+// request; warnings list repeated questions that were dropped
+func (deps *HandlerDependencies) fillGeneratedQuestions(ctx context.Context, aiClient *ai.AIClient, interview *data.Interview) (warningList, *interviewRequestError) {
+	generated, duplicates, err := deps.generateInterviewQuestions(ctx, aiClient, interview)
+	if err != nil {
+		return nil, &interviewRequestError{status: http.StatusInternalServerError, code: ErrCodeAIUnavailable, message: "Failed to generate questions", details: err.Error(), err: err}
+	}
+	interview.Questions = generated.Texts()
+	interview.QuestionDetails = generated
+	interview.QuestionsStatus = data.QuestionsStatusReady
+	var warnings warningList
+	for _, duplicate := range duplicates {
+		warnings.add(WarnCodeDuplicateQuestion, "questions", fmt.Sprintf("Duplicate generated question removed: %q", duplicate))
+	}
+	return warnings, nil
+}
+
+// checkQuestionsReady returns the error for starting a chat session on an interview whose
+// questions are still being generated or failed to generate, or nil when it may start.
+// Conversational interviews may start without questions.
+func checkQuestionsReady(interview *data.Interview) *ErrorResponseDTO {
+	if interview.IsConversational() {
+		return nil
+	}
+	switch interview.QuestionsStatus {
+	case data.QuestionsStatusGenerating:
+		return &ErrorResponseDTO{Error: "Interview questions are still being generated", Code: ErrCodeQuestionsPending}
+	case data.QuestionsStatusFailed:
+		return &ErrorResponseDTO{
+			Error:   "Interview question generation failed; retry it with POST /interviews/{id}/questions/retry",
+			Code:    ErrCodeQuestionsFailed,
+			Details: interview.QuestionsError,
+		}
+	}
+	return nil
+}
+
+// RetryQuestionGenerationHandler handles POST /interviews/{id}/questions/retry
+// Queues the generation of an interview's questions again after it failed and returns the
+// interview, generating; interviews whose questions did not fail get 409
+func (deps *HandlerDependencies) RetryQuestionGenerationHandler(w http.ResponseWriter, r *http.Request) {
+	store := deps.Store.WithContext(r.Context())
+	interview, err := store.GetInterview(chi.URLParam(r, "id"))
+	if err != nil {
+		writeJSONError(w, http.StatusNotFound, ErrCodeNotFound, "Interview not found")
+		return
+	}
+	if interview.QuestionsStatus != data.QuestionsStatusFailed {
+		writeJSONError(w, http.StatusConflict, ErrCodeConflict, "Interview question generation has not failed")
+		return
+	}
+	if err := store.SetInterviewQuestionsStatus(interview.ID, data.QuestionsStatusGenerating, nil, ""); err != nil {
+		writeJSONError(w, http.StatusInternalServerError, ErrCodeInternal, "Failed to queue question generation", err.Error())
+		return
+	}
+	queued := *interview
+	queued.QuestionsStatus, queued.QuestionsError = data.QuestionsStatusGenerating, ""
+	deps.queueQuestionGeneration(r, interview.ID)
+	writeJSON(w, http.StatusAccepted, toInterviewResponseDTO(&queued))
+}
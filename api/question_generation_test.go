@@ -0,0 +1,196 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/zidane0000/ai-interview-platform/ai"
+	"github.com/zidane0000/ai-interview-platform/data"
+)
+
+// gatedQuestionProvider is a mock provider whose question generation waits for release and fails
+// while failures remain
+type gatedQuestionProvider struct {
+	*ai.MockProvider
+	release  chan struct{}
+	failures chan struct{}
+}
+
+func newGatedQuestionProvider(failures int) *gatedQuestionProvider {
+	p := &gatedQuestionProvider{MockProvider: ai.NewMockProvider(), release: make(chan struct{}), failures: make(chan struct{}, failures)}
+	for range failures {
+		p.failures <- struct{}{}
+	}
+	return p
+}
+
+func (p *gatedQuestionProvider) GenerateInterviewQuestions(ctx context.Context, req *ai.QuestionGenerationRequest) (*ai.QuestionGenerationResponse, error) {
+	select {
+	case <-p.release:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	select {
+	case <-p.failures:
+		return nil, fmt.Errorf("provider unavailable")
+	default:
+		return p.MockProvider.GenerateInterviewQuestions(ctx, req)
+	}
+}
+
+// createGeneratingInterview creates an interview whose questions are generated and returns the response
+func createGeneratingInterview(t *testing.T, router http.Handler, body string) InterviewResponseDTO {
+	t.Helper()
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("POST", "/api/interviews", strings.NewReader(body)))
+	if w.Code != http.StatusCreated {
+		t.Fatalf("failed to create interview, got %d: %s", w.Code, w.Body.String())
+	}
+	var resp InterviewResponseDTO
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to unmarshal interview response: %v", err)
+	}
+	return resp
+}
+
+// waitForQuestions polls the interview until its questions are no longer being generated
+func waitForQuestions(t *testing.T, router http.Handler, id string) InterviewResponseDTO {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("GET", "/api/interviews/"+id, nil))
+		var resp InterviewResponseDTO
+		if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("failed to unmarshal interview response: %v", err)
+		}
+		if resp.QuestionsStatus != data.QuestionsStatusGenerating {
+			return resp
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("interview %s still generating its questions", id)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestQuestionGeneration_PendingThenReady(t *testing.T) {
+	provider := newGatedQuestionProvider(0)
+	router := setupTestRouterWithProvider(provider, nil)
+
+	created := createGeneratingInterview(t, router, `{"candidate_name":"Alice","interview_type":"technical","generate_questions":true,"num_questions":2}`)
+	if created.QuestionsStatus != data.QuestionsStatusGenerating || len(created.Questions) != 0 {
+		t.Fatalf("expected the interview to be generating without questions, got %q %v", created.QuestionsStatus, created.Questions)
+	}
+
+	// The session cannot start while the questions are pending
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("POST", "/api/interviews/"+created.ID+"/chat/start", nil))
+	if w.Code != http.StatusConflict || !strings.Contains(w.Body.String(), string(ErrCodeQuestionsPending)) {
+		t.Fatalf("expected 409 questions_pending, got %d: %s", w.Code, w.Body.String())
+	}
+
+	close(provider.release)
+	ready := waitForQuestions(t, router, created.ID)
+	if ready.QuestionsStatus != data.QuestionsStatusReady || len(ready.Questions) != 2 {
+		t.Fatalf("expected two ready questions, got %q %v", ready.QuestionsStatus, ready.Questions)
+	}
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("POST", "/api/interviews/"+created.ID+"/chat/start", nil))
+	if w.Code != http.StatusCreated {
+		t.Errorf("expected the session to start once ready, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestQuestionGeneration_ConversationalStartsWhilePending(t *testing.T) {
+	provider := newGatedQuestionProvider(0)
+	defer close(provider.release)
+	router := setupTestRouterWithProvider(provider, nil)
+
+	created := createGeneratingInterview(t, router, `{"candidate_name":"Alice","interview_type":"technical","interview_mode":"conversational","generate_questions":true}`)
+	if created.QuestionsStatus != data.QuestionsStatusGenerating {
+		t.Fatalf("expected the interview to be generating, got %q", created.QuestionsStatus)
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("POST", "/api/interviews/"+created.ID+"/chat/start", nil))
+	if w.Code != http.StatusCreated {
+		t.Errorf("expected a conversational session to start while generating, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestQuestionGeneration_FailureAndRetry(t *testing.T) {
+	provider := newGatedQuestionProvider(1)
+	close(provider.release)
+	router := setupTestRouterWithProvider(provider, nil)
+
+	created := createGeneratingInterview(t, router, `{"candidate_name":"Alice","interview_type":"technical","generate_questions":true}`)
+	failed := waitForQuestions(t, router, created.ID)
+	if failed.QuestionsStatus != data.QuestionsStatusFailed || !strings.Contains(failed.QuestionsError, "provider unavailable") {
+		t.Fatalf("expected the generation to fail, got %q %q", failed.QuestionsStatus, failed.QuestionsError)
+	}
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("POST", "/api/interviews/"+created.ID+"/chat/start", nil))
+	if w.Code != http.StatusConflict || !strings.Contains(w.Body.String(), string(ErrCodeQuestionsFailed)) {
+		t.Fatalf("expected 409 questions_failed, got %d: %s", w.Code, w.Body.String())
+	}
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("POST", "/api/interviews/"+created.ID+"/questions/retry", nil))
+	if w.Code != http.StatusAccepted {
+		t.Fatalf("expected 202 from retry, got %d: %s", w.Code, w.Body.String())
+	}
+	var retried InterviewResponseDTO
+	if err := json.Unmarshal(w.Body.Bytes(), &retried); err != nil {
+		t.Fatalf("failed to unmarshal retry response: %v", err)
+	}
+	if retried.QuestionsStatus != data.QuestionsStatusGenerating || retried.QuestionsError != "" {
+		t.Errorf("expected the retried interview to be generating, got %q %q", retried.QuestionsStatus, retried.QuestionsError)
+	}
+	ready := waitForQuestions(t, router, created.ID)
+	if ready.QuestionsStatus != data.QuestionsStatusReady || len(ready.Questions) == 0 || ready.QuestionsError != "" {
+		t.Errorf("expected ready questions after the retry, got %q %v %q", ready.QuestionsStatus, ready.Questions, ready.QuestionsError)
+	}
+
+	// Only failed generations can be retried
+	expectHTTPError(t, router, "POST", "/api/interviews/"+created.ID+"/questions/retry", nil, http.StatusConflict)
+	expectHTTPError(t, router, "POST", "/api/interviews/missing/questions/retry", nil, http.StatusNotFound)
+}
+
+func TestQuestionGeneration_StopFailsQueuedJobs(t *testing.T) {
+	provider := newGatedQuestionProvider(0)
+	var workers *WorkerPool
+	router := setupTestRouterWithProvider(provider, func(deps *HandlerDependencies) {
+		workers = NewWorkerPool(1)
+		deps.Workers = workers
+	})
+
+	running := createGeneratingInterview(t, router, `{"candidate_name":"Alice","interview_type":"technical","generate_questions":true}`)
+	queued := createGeneratingInterview(t, router, `{"candidate_name":"Bob","interview_type":"technical","generate_questions":true}`)
+	workers.Stop()
+
+	for _, id := range []string{running.ID, queued.ID} {
+		interview, err := router.store.GetInterview(id)
+		if err != nil {
+			t.Fatalf("failed to get interview: %v", err)
+		}
+		if interview.QuestionsStatus != data.QuestionsStatusFailed {
+			t.Errorf("expected interview %s to fail on shutdown, got %q", id, interview.QuestionsStatus)
+		}
+	}
+}
+
+func TestStartInterviewHandler_GeneratesQuestionsInline(t *testing.T) {
+	router := setupTestRouter()
+
+	resp := decodeStartInterview(t, startInterview(router, `{"candidate_name":"Alice","interview_type":"technical","generate_questions":true}`))
+	if resp.Interview.QuestionsStatus != data.QuestionsStatusReady || len(resp.Interview.Questions) == 0 {
+		t.Errorf("expected the questions to be generated before the session starts, got %q %v", resp.Interview.QuestionsStatus, resp.Interview.Questions)
+	}
+}
@@ -0,0 +1,112 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/zidane0000/ai-interview-platform/ai"
+	"github.com/zidane0000/ai-interview-platform/data"
+	"github.com/zidane0000/ai-interview-platform/internal/testsupport"
+)
+
+func TestCreateInterviewHandler_QuestionStrategy(t *testing.T) {
+	router := setupTestRouterWithProvider(nil, nil)
+	tests := []struct {
+		name     string
+		strategy string
+		status   int
+		expected string
+	}{
+		{"default is ordered", "", http.StatusCreated, data.QuestionStrategyOrdered},
+		{"shuffled", "shuffled", http.StatusCreated, "shuffled"},
+		{"sample", "sample:2", http.StatusCreated, "sample:2"},
+		{"sample larger than the questions", "sample:4", http.StatusBadRequest, ""},
+		{"unknown strategy", "random", http.StatusBadRequest, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body, _ := json.Marshal(interviewRequest(testsupport.NewInterviewBuilder().WithQuestions(3).WithQuestionStrategy(tt.strategy).Build()))
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest("POST", "/api/interviews", bytes.NewReader(body)))
+			if w.Code != tt.status {
+				t.Fatalf("expected %d, got %d: %s", tt.status, w.Code, w.Body.String())
+			}
+			if tt.status != http.StatusCreated {
+				return
+			}
+			var resp InterviewResponseDTO
+			if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil || resp.QuestionStrategy != tt.expected {
+				t.Errorf("expected question_strategy %q, got %q (%v)", tt.expected, resp.QuestionStrategy, err)
+			}
+		})
+	}
+}
+
+// TestQuestionStrategy_SampledSession runs a sampled session through the chat and its evaluation,
+// with the AI asking the session's questions in its order
+func TestQuestionStrategy_SampledSession(t *testing.T) {
+	provider := ai.NewMockProvider()
+	router := setupTestRouterWithProvider(provider, nil)
+	questions := []string{"Describe your last project", "Explain a race condition", "Tell me about a failure", "Walk me through a deploy", "Describe a code review"}
+	interview := createTestInterview(t, router, testsupport.NewInterviewBuilder().
+		WithQuestionTexts(questions...).WithQuestionStrategy("sample:3").WithAdaptive(false))
+	session := startChatSession(t, router, testsupport.NewSessionBuilder().ForInterviewID(interview.ID))
+
+	stored, err := router.store.GetChatSession(session.ID)
+	if err != nil {
+		t.Fatalf("GetChatSession failed: %v", err)
+	}
+	order := stored.QuestionOrder
+	if len(order) != 3 {
+		t.Fatalf("expected 3 sampled questions, got %v", order)
+	}
+	if expected := data.QuestionOrder("sample:3", session.ID, len(questions)); !slices.Equal([]int(order), expected) {
+		t.Errorf("expected the order reproducible from the session ID, got %v and %v", order, expected)
+	}
+
+	// The AI asks the sampled questions in the session's order, then signs off
+	for _, index := range order {
+		provider.AppendScript(questions[index] + "?")
+	}
+	provider.AppendScript("Thank you, that is all.")
+	resp := sendMessage(t, router, session.ID, "Hello")
+	if resp.Progress == nil || resp.Progress.QuestionsTotal != 3 {
+		t.Fatalf("expected progress toward 3 questions, got %+v", resp.Progress)
+	}
+	for _, index := range order {
+		resp = sendMessage(t, router, session.ID, "Answer about "+questions[index])
+	}
+	if resp.SessionStatus != "completed" {
+		t.Fatalf("expected the session to end after its 3 questions, got %q", resp.SessionStatus)
+	}
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("POST", "/api/chat/"+session.ID+"/end", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("failed to evaluate the session, got %d: %s", w.Code, w.Body.String())
+	}
+	requests := provider.EvaluationRequests()
+	if len(requests) != 1 {
+		t.Fatalf("expected one evaluation, got %d", len(requests))
+	}
+	req := requests[0]
+	paired := 0
+	for i, answer := range req.Answers {
+		question, ok := strings.CutPrefix(answer, "Answer about ")
+		if !ok {
+			continue
+		}
+		paired++
+		if !strings.Contains(req.Questions[i], question) {
+			t.Errorf("expected %q paired with its question, got %q", answer, req.Questions[i])
+		}
+	}
+	if paired != 3 {
+		t.Errorf("expected 3 answers to sampled questions, got %d in %v", paired, req.Answers)
+	}
+}
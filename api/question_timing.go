@@ -0,0 +1,85 @@
+// Expected answer times of planned questions: surfaced during the chat and compared in analytics
+package api
+
+import (
+	"strconv"
+
+	"github.com/zidane0000/ai-interview-platform/data"
+)
+
+// Answer timing indicators, comparing an answer's latency with its question's expected time
+const (
+	AnswerTimingOver  = "over"  // Answered after the expected time
+	AnswerTimingUnder = "under" // Answered within the expected time
+)
+
+// questionExpectedTime returns the expected answer time in minutes of the planned question text
+// asks, or 0 when it asks none or the question has no expected time
+func questionExpectedTime(interview *data.Interview, text string) int {
+	planned := interview.PlannedQuestions()
+	details := data.SyncQuestionDetails(interview.Questions, interview.QuestionDetails)
+	for i, question := range planned {
+		if i < len(details) && asksPlannedQuestion(text, question) {
+			return details[i].ExpectedTime
+		}
+	}
+	return 0
+}
+
+// withExpectedTime adds to an AI message's metadata the expected answer time of the planned question content asks, if any
+func withExpectedTime(metadata data.StringMap, interview *data.Interview, content string) data.StringMap {
+	minutes := questionExpectedTime(interview, content)
+	if minutes <= 0 {
+		return metadata
+	}
+	if metadata == nil {
+		metadata = data.StringMap{}
+	}
+	metadata[data.MessageMetaExpectedTime] = strconv.Itoa(minutes)
+	return metadata
+}
+
+// currentQuestionExpectedTime returns the expected answer time in minutes of the planned question
+// asked last in the session, or 0; follow-ups keep the planned question they follow current
+func currentQuestionExpectedTime(interview *data.Interview, askedQuestions []string) int {
+	for i := len(askedQuestions) - 1; i >= 0; i-- {
+		if minutes := questionExpectedTime(interview, askedQuestions[i]); minutes > 0 {
+			return minutes
+		}
+	}
+	return 0
+}
+
+// answerTimings compares each answer to a question with an expected time against that time
+// The answer latency runs from the AI message asking the question to the candidate's next message.
+func answerTimings(messages []*data.ChatMessage) []AnswerTimingDTO {
+	var timings []AnswerTimingDTO
+	for i, msg := range messages {
+		minutes, err := strconv.Atoi(msg.Metadata[data.MessageMetaExpectedTime])
+		if msg.Type != "ai" || err != nil || minutes <= 0 {
+			continue
+		}
+		for _, next := range messages[i+1:] {
+			if next.Type == "ai" {
+				break
+			}
+			if next.Type != "user" {
+				continue
+			}
+			seconds := int64(next.Timestamp.Sub(msg.Timestamp).Seconds())
+			indicator := AnswerTimingUnder
+			if seconds > int64(minutes)*60 {
+				indicator = AnswerTimingOver
+			}
+			timings = append(timings, AnswerTimingDTO{
+				QuestionMessageID:   msg.ID,
+				AnswerMessageID:     next.ID,
+				ExpectedTimeMinutes: minutes,
+				AnswerSeconds:       seconds,
+				Indicator:           indicator,
+			})
+			break
+		}
+	}
+	return timings
+}
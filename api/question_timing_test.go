@@ -0,0 +1,108 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/zidane0000/ai-interview-platform/ai"
+	"github.com/zidane0000/ai-interview-platform/data"
+	"github.com/zidane0000/ai-interview-platform/internal/testsupport"
+)
+
+func TestChatSession_QuestionExpectedTime(t *testing.T) {
+	provider := ai.NewScriptedMockProvider(
+		"Welcome! What is Go?",
+		"Thanks. What is a goroutine?",
+		"Good. What is a channel?",
+		"Thank you for your time.",
+	)
+	clock := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	router := setupTestRouterWithProvider(provider, func(deps *HandlerDependencies) {
+		deps.AdminToken = "admin-secret"
+		deps.now = func() time.Time { return clock }
+	})
+	interview := testsupport.NewInterviewBuilder().WithQuestionDetails(
+		data.QuestionDetail{Text: "What is Go?", ExpectedTime: 2, Source: data.QuestionSourceAI},
+		data.QuestionDetail{Text: "What is a goroutine?", ExpectedTime: 3, Source: data.QuestionSourceAI},
+		data.QuestionDetail{Text: "What is a channel?", ExpectedTime: 1, Source: data.QuestionSourceAI},
+	).Create(t, router.store)
+
+	// The greeting asks the first question
+	session := startChatSession(t, router, testsupport.NewSessionBuilder().ForInterviewID(interview.ID))
+	if got := session.Messages[0].Metadata[data.MessageMetaExpectedTime]; got != "2" {
+		t.Errorf("expected the greeting to carry 2 minutes, got %q", got)
+	}
+	if session.Progress.CurrentQuestionExpectedTime != 2 {
+		t.Errorf("expected the current question to take 2 minutes, got %d", session.Progress.CurrentQuestionExpectedTime)
+	}
+
+	// Each answer comes after latency; the reply asks the next question with its expected time
+	turns := []struct {
+		latency  time.Duration
+		expected string // Expected time on the AI reply; "" for the closing
+		current  int
+	}{
+		{90 * time.Second, "3", 3},
+		{4 * time.Minute, "1", 1},
+		{time.Minute, "", 0},
+	}
+	for i, turn := range turns {
+		clock = clock.Add(turn.latency)
+		resp := sendMessage(t, router, session.ID, "Answer")
+		if got := resp.AIResponse.Metadata[data.MessageMetaExpectedTime]; got != turn.expected {
+			t.Errorf("turn %d: expected the reply to carry %q minutes, got %q", i+1, turn.expected, got)
+		}
+		if resp.Progress.CurrentQuestionExpectedTime != turn.current {
+			t.Errorf("turn %d: expected the current question to take %d minutes, got %d", i+1, turn.current, resp.Progress.CurrentQuestionExpectedTime)
+		}
+	}
+	if resp := getChatSession(t, router, session.ID, ""); resp.Status != "completed" {
+		t.Fatalf("expected the session to be completed, got %q", resp.Status)
+	}
+
+	// Analytics compare each answer's latency with its question's expected time
+	req := httptest.NewRequest("GET", "/api/admin/stats?interview_id="+interview.ID, nil)
+	req.Header.Set("Authorization", "Bearer admin-secret")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	var stats AdminStatsResponseDTO
+	if err := json.Unmarshal(w.Body.Bytes(), &stats); err != nil || w.Code != http.StatusOK {
+		t.Fatalf("failed to get stats, got %d: %s", w.Code, w.Body.String())
+	}
+	if len(stats.AnswerTimings) != 1 || stats.AnswerTimings[0].SessionID != session.ID {
+		t.Fatalf("expected answer timings for the session, got %+v", stats.AnswerTimings)
+	}
+	want := []AnswerTimingDTO{
+		{ExpectedTimeMinutes: 2, AnswerSeconds: 90, Indicator: AnswerTimingUnder},
+		{ExpectedTimeMinutes: 3, AnswerSeconds: 240, Indicator: AnswerTimingOver},
+		{ExpectedTimeMinutes: 1, AnswerSeconds: 60, Indicator: AnswerTimingUnder},
+	}
+	exchanges := stats.AnswerTimings[0].Exchanges
+	if len(exchanges) != len(want) {
+		t.Fatalf("expected %d exchanges, got %+v", len(want), exchanges)
+	}
+	for i, exchange := range exchanges {
+		if exchange.ExpectedTimeMinutes != want[i].ExpectedTimeMinutes || exchange.AnswerSeconds != want[i].AnswerSeconds ||
+			exchange.Indicator != want[i].Indicator || exchange.QuestionMessageID == "" || exchange.AnswerMessageID == "" {
+			t.Errorf("exchange %d: expected %+v, got %+v", i+1, want[i], exchange)
+		}
+	}
+}
+
+func TestChatSession_QuestionExpectedTimeWithoutDetails(t *testing.T) {
+	provider := ai.NewScriptedMockProvider("Welcome! What is Go?", "Thanks. What is a goroutine?")
+	router := setupTestRouterWithProvider(provider, nil)
+	interview := createTestInterview(t, router, testsupport.NewInterviewBuilder().
+		WithQuestionTexts("What is Go?", "What is a goroutine?", "What is a channel?"))
+
+	// Manual questions have no expected time, so none is surfaced
+	session := startChatSession(t, router, testsupport.NewSessionBuilder().ForInterviewID(interview.ID))
+	resp := sendMessage(t, router, session.ID, "Answer")
+	if session.Messages[0].Metadata != nil || resp.AIResponse.Metadata != nil || resp.Progress.CurrentQuestionExpectedTime != 0 {
+		t.Errorf("expected no expected time, got greeting %v, reply %v, progress %+v",
+			session.Messages[0].Metadata, resp.AIResponse.Metadata, resp.Progress)
+	}
+}
@@ -0,0 +1,58 @@
+// Read-only maintenance mode: the API keeps serving reads while refusing writes
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/zidane0000/ai-interview-platform/utils"
+)
+
+// readOnlyTogglePath is the admin route switching read-only mode, which stays writable so it can be switched off
+const readOnlyTogglePath = "/api/admin/readonly"
+
+// ReadOnly reports whether the instance refuses writes
+func (deps *HandlerDependencies) ReadOnly() bool {
+	return deps.readOnly.Load()
+}
+
+// SetReadOnly switches read-only mode; safe to call while requests are served
+func (deps *HandlerDependencies) SetReadOnly(readOnly bool) {
+	if deps.readOnly.Swap(readOnly) != readOnly {
+		utils.Infof("Read-only mode set to %v", readOnly)
+	}
+}
+
+// ReadOnlyMiddleware refuses mutating requests with 503 while the instance is read-only
+// GET, HEAD and OPTIONS pass through, as does the toggle route so the mode can be switched off.
+func (deps *HandlerDependencies) ReadOnlyMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !deps.ReadOnly() || strings.TrimSuffix(r.URL.Path, "/") == readOnlyTogglePath {
+			next.ServeHTTP(w, r)
+			return
+		}
+		switch r.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+			next.ServeHTTP(w, r)
+		default:
+			writeJSONError(w, http.StatusServiceUnavailable, ErrCodeReadOnly, "Service is in read-only maintenance mode; retry later")
+		}
+	})
+}
+
+// SetReadOnlyHandler handles POST /admin/readonly
+// Switches read-only mode on or off and reports the resulting mode
+func (deps *HandlerDependencies) SetReadOnlyHandler(w http.ResponseWriter, r *http.Request) {
+	var req ReadOnlyRequestDTO
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSONError(w, http.StatusBadRequest, ErrCodeInvalidJSON, "Invalid JSON", err.Error())
+		return
+	}
+	if req.ReadOnly == nil {
+		writeJSONError(w, http.StatusBadRequest, ErrCodeValidationFailed, "read_only is required")
+		return
+	}
+	deps.SetReadOnly(*req.ReadOnly)
+	writeJSON(w, http.StatusOK, ReadOnlyResponseDTO{ReadOnly: deps.ReadOnly()})
+}
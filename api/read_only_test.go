@@ -0,0 +1,91 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/zidane0000/ai-interview-platform/ai"
+	"github.com/zidane0000/ai-interview-platform/internal/testsupport"
+)
+
+// setReadOnly switches read-only mode through the admin route and returns the response
+func setReadOnly(t *testing.T, router http.Handler, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest("POST", "/api/admin/readonly", strings.NewReader(body))
+	req.Header.Set("Authorization", "Bearer admin-secret")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestReadOnlyMode(t *testing.T) {
+	var deps *HandlerDependencies
+	router := setupTestRouterWithProvider(ai.NewMockProvider(), func(d *HandlerDependencies) {
+		d.AdminToken = "admin-secret"
+		deps = d
+	})
+	interview := createTestInterview(t, router, testsupport.NewInterviewBuilder().WithCandidate("Read Only"))
+
+	w := setReadOnly(t, router, `{"read_only":true}`)
+	var resp ReadOnlyResponseDTO
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil || w.Code != http.StatusOK || !resp.ReadOnly {
+		t.Fatalf("expected read-only mode to be enabled, got %d: %s", w.Code, w.Body.String())
+	}
+	if !deps.ReadOnly() {
+		t.Fatal("expected the dependencies to be read-only")
+	}
+
+	// Writes are refused, reads are served
+	body, _ := json.Marshal(interviewRequest(testsupport.NewInterviewBuilder().Build()))
+	assertErrorResponse(t, router, "POST", "/api/interviews", string(body), http.StatusServiceUnavailable, ErrCodeReadOnly)
+	assertErrorResponse(t, router, "PATCH", "/api/interviews/"+interview.ID, `{}`, http.StatusServiceUnavailable, ErrCodeReadOnly)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/api/interviews/"+interview.ID, nil))
+	if w.Code != http.StatusOK {
+		t.Errorf("expected reads to be served, got %d: %s", w.Code, w.Body.String())
+	}
+
+	// Health and version report the mode
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/health", nil))
+	if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), `"read_only":true`) {
+		t.Errorf("expected a healthy read-only instance, got %d: %s", w.Code, w.Body.String())
+	}
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/api/version", nil))
+	var version VersionResponseDTO
+	if err := json.Unmarshal(w.Body.Bytes(), &version); err != nil || !version.ReadOnly {
+		t.Errorf("expected the version to report read-only mode, got %s", w.Body.String())
+	}
+
+	// The toggle keeps working while read-only and still requires the admin token
+	assertErrorResponse(t, router, "POST", "/api/admin/readonly", `{"read_only":false}`, http.StatusUnauthorized, ErrCodeUnauthorized)
+	if w = setReadOnly(t, router, `{}`); w.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 without read_only, got %d", w.Code)
+	}
+	if w = setReadOnly(t, router, `{"read_only":false}`); w.Code != http.StatusOK || deps.ReadOnly() {
+		t.Fatalf("expected read-only mode to be disabled, got %d: %s", w.Code, w.Body.String())
+	}
+	createTestInterview(t, router, testsupport.NewInterviewBuilder().WithCandidate("Writable Again"))
+}
+
+func TestReadOnlyMode_PausesSessionExpiry(t *testing.T) {
+	store := newTestStore()
+	deps := NewHandlerDependencies(nil, store)
+	interview := testsupport.NewInterviewBuilder().Create(t, store)
+	testsupport.NewSessionBuilder().ForInterview(interview).WithStartedAt(time.Now().Add(-2*deps.SessionIdleTimeout)).Create(t, store)
+
+	// Candidates can't keep sessions active while read-only, so none are abandoned
+	deps.SetReadOnly(true)
+	if expired, err := deps.expireIdleSessions(store); err != nil || expired != 0 {
+		t.Errorf("expected no sessions to expire while read-only, got %d (%v)", expired, err)
+	}
+	deps.SetReadOnly(false)
+	if expired, err := deps.expireIdleSessions(store); err != nil || expired != 1 {
+		t.Errorf("expected the idle session to expire once writable, got %d (%v)", expired, err)
+	}
+}
@@ -0,0 +1,49 @@
+// Data retention: deleting old chat transcripts while keeping sessions and evaluations
+package api
+
+import (
+	"context"
+	"time"
+
+	"github.com/zidane0000/ai-interview-platform/config"
+	"github.com/zidane0000/ai-interview-platform/data"
+	"github.com/zidane0000/ai-interview-platform/utils"
+)
+
+// purgeExpiredTranscripts deletes the messages of ended sessions older than TranscriptRetention
+// Returns the number of messages deleted; nothing is deleted while read-only
+func (deps *HandlerDependencies) purgeExpiredTranscripts(store data.Store) (int, error) {
+	if deps.TranscriptRetention <= 0 || deps.ReadOnly() {
+		return 0, nil
+	}
+	return store.PurgeMessagesOlderThan(deps.now().Add(-deps.TranscriptRetention))
+}
+
+// StartRetentionWorker purges expired transcripts from deps.Store every cfg.RetentionInterval until
+// ctx is done. Does nothing when deps.TranscriptRetention is 0
+func StartRetentionWorker(ctx context.Context, cfg *config.Config, deps *HandlerDependencies) {
+	if deps.TranscriptRetention <= 0 {
+		return
+	}
+	interval := cfg.RetentionInterval
+	if interval <= 0 {
+		interval = config.DefaultRetentionInterval
+	}
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				purged, err := deps.purgeExpiredTranscripts(deps.Store.WithContext(ctx))
+				if err != nil {
+					utils.Errorf("Transcript retention failed: %v", err)
+				} else if purged > 0 {
+					utils.Infof("Purged %d chat messages past the transcript retention", purged)
+				}
+			}
+		}
+	}()
+}
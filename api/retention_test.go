@@ -0,0 +1,75 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestPurgeExpiredTranscripts(t *testing.T) {
+	var deps *HandlerDependencies
+	router, session, evaluation := setupReopenTest(t, func(d *HandlerDependencies) {
+		deps = d
+		deps.TranscriptRetention = 30 * 24 * time.Hour
+	})
+	messages, err := router.store.GetChatMessages(session.ID)
+	if err != nil || len(messages) == 0 {
+		t.Fatalf("expected the session to have messages, got %v (%v)", messages, err)
+	}
+	first, last := messages[0].Timestamp, messages[len(messages)-1].Timestamp
+
+	// The oldest message is not past the retention until the cutoff passes it
+	deps.now = func() time.Time { return first.Add(deps.TranscriptRetention) }
+	if purged, err := deps.purgeExpiredTranscripts(router.store); err != nil || purged != 0 {
+		t.Fatalf("expected nothing purged at the boundary, got %d (%v)", purged, err)
+	}
+
+	deps.now = func() time.Time { return last.Add(deps.TranscriptRetention + time.Second) }
+	purged, err := deps.purgeExpiredTranscripts(router.store)
+	if err != nil || purged != len(messages) {
+		t.Fatalf("expected all %d messages purged, got %d (%v)", len(messages), purged, err)
+	}
+
+	// The session reads back with the marker instead of looking empty
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/api/chat/"+session.ID, nil))
+	var view ChatInterviewSessionDTO
+	if err := json.Unmarshal(w.Body.Bytes(), &view); err != nil {
+		t.Fatalf("failed to unmarshal session: %v", err)
+	}
+	if w.Code != http.StatusOK || !view.TranscriptPurged || view.TranscriptPurgedAt == nil || len(view.Messages) != 0 || view.Status != "completed" {
+		t.Errorf("expected a completed session marked purged, got %d: %s", w.Code, w.Body.String())
+	}
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/api/chat/"+session.ID+"/messages", nil))
+	var page ListChatMessagesResponseDTO
+	if err := json.Unmarshal(w.Body.Bytes(), &page); err != nil {
+		t.Fatalf("failed to unmarshal messages: %v", err)
+	}
+	if !page.TranscriptPurged || page.TranscriptPurgedAt == nil || page.Total != 0 {
+		t.Errorf("expected the message list marked purged, got %s", w.Body.String())
+	}
+
+	// The evaluation is kept, and the purged session can't be reopened
+	if _, err := router.store.GetEvaluation(evaluation.ID); err != nil {
+		t.Errorf("expected the evaluation to be kept: %v", err)
+	}
+	if w := reopenSession(router, session.ID, ""); w.Code != http.StatusConflict {
+		t.Errorf("expected 409 reopening a purged session, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestPurgeExpiredTranscripts_Disabled(t *testing.T) {
+	var deps *HandlerDependencies
+	router, session, _ := setupReopenTest(t, func(d *HandlerDependencies) { deps = d })
+
+	deps.now = func() time.Time { return time.Now().AddDate(10, 0, 0) }
+	if purged, err := deps.purgeExpiredTranscripts(router.store); err != nil || purged != 0 {
+		t.Errorf("expected no purge without a retention, got %d (%v)", purged, err)
+	}
+	if messages, _ := router.store.GetChatMessages(session.ID); len(messages) == 0 {
+		t.Error("expected the transcript to be kept")
+	}
+}
@@ -3,41 +3,61 @@ package api
 
 import (
 	"net/http"
+	"strings"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/zidane0000/ai-interview-platform/config"
 	"github.com/zidane0000/ai-interview-platform/utils"
 )
 
 // SetupRouter initializes the HTTP routes for the API using chi
-// Config is injected from main.go to avoid loading configuration multiple times
+// Config is injected from main.go to avoid loading configuration multiple times; deps carries the
+// store and limits the handlers run with, and a nil deps is built from cfg around a fresh memory store.
 // frontendHandler is optional - if provided, serves SPA at root
-func SetupRouter(cfg *config.Config, frontendHandler http.Handler) http.Handler {
+func SetupRouter(cfg *config.Config, deps *HandlerDependencies, frontendHandler http.Handler) http.Handler {
 	// BYOK pattern: AI clients created per-request from user-provided keys
 	// No shared client needed - see createClientFromRequest() in handlers.go
-
-	// Create handler dependencies (currently empty - BYOK uses per-request clients)
-	deps := NewHandlerDependencies()
+	if deps == nil {
+		deps = NewHandlerDependencies(cfg, nil)
+	}
 
 	r := chi.NewRouter()
 
 	// Defense in depth middleware
+	r.Use(middleware.RequestID)            // Tags each request with an ID for correlating logs
 	r.Use(middleware.Recoverer)            // Returns 500 on panic instead of connection drop
 	r.Use(middleware.RequestSize(1 << 20)) // 1MB body limit
 
 	r.Use(CORSMiddleware)
 	r.Use(LoggingMiddleware)
 
+	// Known routes requested with another method list their methods; each route group below
+	// installs its own handler so the Allow header is computed from that group's routes
+	r.MethodNotAllowed(methodNotAllowedHandler(r))
+
 	// Health check endpoint at root (for load balancers)
+	// Reports 503 when the store's database (primary or read replica) is unreachable; a read-only
+	// instance is still healthy, since it serves reads, and says so in the body. The latest store
+	// maintenance run is included once there has been one.
 	r.Get("/health", func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(http.StatusOK)
-		if _, err := w.Write([]byte(`{"status":"ok","service":"ai_interview_backend"}`)); err != nil {
-			utils.Errorf("Failed to write health check response: %v", err)
+		if err := deps.Store.Health(); err != nil {
+			utils.Errorf("Store health check failed: %v", err)
+			writeJSON(w, http.StatusServiceUnavailable, HealthResponseDTO{Status: "unhealthy", Service: healthServiceName})
+			return
 		}
+		writeJSON(w, http.StatusOK, HealthResponseDTO{
+			Status:      "ok",
+			Service:     healthServiceName,
+			ReadOnly:    deps.ReadOnly(),
+			Maintenance: toMaintenanceStatusDTO(deps.maintenance.lastRun()),
+		})
 	})
 
+	// Prometheus metrics endpoint
+	r.Handle("/metrics", promhttp.Handler())
+
 	// All API routes under /api prefix
 	r.Route("/api", func(r chi.Router) {
 		// TODO: Add rate limiting middleware for production
@@ -45,51 +65,31 @@ func SetupRouter(cfg *config.Config, frontendHandler http.Handler) http.Handler
 		// TODO: Add request validation middleware
 		// TODO: Add API versioning support (e.g., /v1/)
 
-		// Custom NotFound for trailing slash
+		r.Use(deps.apiMiddleware()...)
+
+		// NotFound is set before mounting so the route groups inherit it
 		r.NotFound(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			if r.URL.Path == "/api/interviews/" {
-				http.Error(w, ErrMsgMissingInterviewID, ErrCodeBadRequest)
-				return
-			}
-			if r.URL.Path == "/api/evaluation/" {
-				http.Error(w, ErrMsgMissingEvaluationID, ErrCodeBadRequest)
-				return
-			}
-			// TODO: Add custom 404 response for chat endpoints
-			http.NotFound(w, r)
+			writeJSONError(w, http.StatusNotFound, ErrCodeNotFound, "Not Found")
 		}))
+		r.MethodNotAllowed(methodNotAllowedHandler(r))
 
-		// Interview routes
-		r.Route("/interviews", func(r chi.Router) {
-			r.Post("/", CreateInterviewHandler)
-			r.Get("/", ListInterviewsHandler)
-			r.Get("/{id}", GetInterviewHandler)
+		r.Mount("/interviews", InterviewRoutes(deps))
+		r.Mount("/evaluation", EvaluationRoutes(deps))
+		r.Mount("/chat", ChatRoutes(deps))
+		r.Mount("/admin", AdminRoutes(deps))
 
-			// Chat session routes for conversational interviews
-			r.Post("/{id}/chat/start", deps.StartChatSessionHandler)
-			// TODO: Add PUT /{id} for updating interviews
-			// TODO: Add DELETE /{id} for removing interviews
-		})
+		// Build and configuration of this instance, for support
+		r.Get("/version", deps.GetVersionHandler)
 
-		// Evaluation routes
-		r.Route("/evaluation", func(r chi.Router) {
-			r.Post("/", deps.SubmitEvaluationHandler)
-			r.Get("/{id}", GetEvaluationHandler)
-			// TODO: Add GET / for listing evaluations
-			// TODO: Add PUT /{id} for updating evaluations
-			// TODO: Add DELETE /{id} for removing evaluations
-		})
+		// Enum values and limits clients validate against
+		r.Get("/meta", deps.GetMetaHandler)
 
-		// Chat routes for real-time interview conversations
-		r.Route("/chat", func(r chi.Router) {
-			r.Post("/{sessionId}/message", deps.SendMessageHandler)
-			r.Get("/{sessionId}", GetChatSessionHandler)
-			r.Post("/{sessionId}/end", deps.EndChatSessionHandler)
-			// TODO: Add WebSocket support for real-time messaging
-			// TODO: Add DELETE /{sessionId} for cleaning up sessions
+		// Built-in question sets for quick-start interviews
+		r.Route("/questions", func(r chi.Router) {
+			r.MethodNotAllowed(methodNotAllowedHandler(r))
+			r.Get("/defaults", deps.GetDefaultQuestionsHandler)
 		})
 
-		// TODO: Add metrics endpoint for monitoring
 		// TODO: Add file upload endpoints for resume handling
 		// TODO: Add internationalization endpoints for multi-language support
 	})
@@ -99,5 +99,143 @@ func SetupRouter(cfg *config.Config, frontendHandler http.Handler) http.Handler
 		r.Handle("/*", frontendHandler)
 	}
 
+	deps.routes = r
+	return r
+}
+
+// apiMiddleware is the middleware stack of every route under /api, in order
+// Each must keep streaming working: see responseWriterWrapper for wrappers of the ResponseWriter.
+func (deps *HandlerDependencies) apiMiddleware() []func(http.Handler) http.Handler {
+	return []func(http.Handler) http.Handler{
+		// Transcripts and lists can run to hundreds of kilobytes of JSON
+		CompressionMiddleware(compressionMinSize),
+		// During maintenance only reads are served
+		deps.ReadOnlyMiddleware,
+		// A trailing slash is stripped before routing, so /interviews/{id}/ is /interviews/{id}
+		middleware.StripSlashes,
+	}
+}
+
+// InterviewRoutes serves interviews and their chat sessions; SetupRouter mounts it at /api/interviews
+func InterviewRoutes(deps *HandlerDependencies) chi.Router {
+	r := chi.NewRouter()
+	r.MethodNotAllowed(methodNotAllowedHandler(r))
+	r.Use(TenantMiddleware(deps.TenantAPIKeys))
+	r.Post("/", deps.CreateInterviewHandler)
+	r.Post("/start", deps.StartInterviewHandler)
+	r.Get("/", deps.ListInterviewsHandler)
+	r.Get("/by-candidate", deps.ListInterviewsByCandidateHandler)
+	r.Group(func(r chi.Router) {
+		r.Use(ResourceIDMiddleware)
+		r.Get("/{id}", deps.GetInterviewHandler)
+		r.Patch("/{id}", deps.UpdateInterviewHandler)
+		r.Post("/{id}/clone", deps.CloneInterviewHandler)
+		r.Post("/{id}/questions/retry", deps.RetryQuestionGenerationHandler)
+		r.With(AdminAuthMiddleware(deps.AdminToken)).Post("/{id}/outcome", deps.RecordInterviewOutcomeHandler)
+
+		// Chat session routes for conversational interviews
+		r.Post("/{id}/chat/start", deps.StartChatSessionHandler)
+	})
+	mountChatSessionRoutes(r, deps, "/{id}/chat/{sessionId}")
+	// TODO: Extend PATCH /{id} beyond the scheduling window
+	// TODO: Add DELETE /{id} for removing interviews
 	return r
 }
+
+// EvaluationRoutes serves evaluations; SetupRouter mounts it at /api/evaluation
+func EvaluationRoutes(deps *HandlerDependencies) chi.Router {
+	r := chi.NewRouter()
+	r.MethodNotAllowed(methodNotAllowedHandler(r))
+	r.Use(TenantMiddleware(deps.TenantAPIKeys))
+	r.Post("/", deps.SubmitEvaluationHandler)
+	r.With(ResourceIDMiddleware).Get("/{id}", deps.GetEvaluationHandler)
+	r.With(ResourceIDMiddleware, AdminAuthMiddleware(deps.AdminToken)).Get("/{id}/trace", deps.GetEvaluationTraceHandler)
+	// TODO: Add GET / for listing evaluations
+	// TODO: Add PUT /{id} for updating evaluations
+	// TODO: Add DELETE /{id} for removing evaluations
+	return r
+}
+
+// ChatRoutes serves the legacy chat session routes, which look the session's interview up instead
+// of taking it from the path; SetupRouter mounts it at /api/chat
+func ChatRoutes(deps *HandlerDependencies) chi.Router {
+	r := chi.NewRouter()
+	r.MethodNotAllowed(methodNotAllowedHandler(r))
+	r.Use(TenantMiddleware(deps.TenantAPIKeys))
+	mountChatSessionRoutes(r, deps, "/{sessionId}")
+	return r
+}
+
+// AdminRoutes serves the admin routes behind the admin token; SetupRouter mounts it at /api/admin
+func AdminRoutes(deps *HandlerDependencies) chi.Router {
+	r := chi.NewRouter()
+	r.MethodNotAllowed(methodNotAllowedHandler(r))
+	r.Use(AdminAuthMiddleware(deps.AdminToken))
+	r.Get("/stats", deps.GetAdminStatsHandler)
+	r.Post("/evaluations/backfill", deps.BackfillEvaluationsHandler)
+	r.Post("/evaluations/calibrate", deps.CalibrateEvaluationsHandler)
+	r.With(ResourceIDMiddleware).Get("/evaluations/calibrations/{id}", deps.GetCalibrationRunHandler)
+	r.Post("/readonly", deps.SetReadOnlyHandler)
+	// Debug endpoints are only mounted when enabled
+	if deps.EnableDebugEndpoints {
+		r.Get("/ai/debug", deps.GetAIDebugCaptureHandler)
+		r.Get("/routes", deps.ListRoutesHandler)
+	}
+	return r
+}
+
+// mountChatSessionRoutes registers the routes of one chat session under prefix, which names the
+// session {sessionId}; ResourceIDMiddleware checks the path's IDs, then deps.ChatSessionScopeMiddleware
+// checks the session is reachable through the path
+func mountChatSessionRoutes(r chi.Router, deps *HandlerDependencies, prefix string) {
+	r.Group(func(r chi.Router) {
+		r.Use(ResourceIDMiddleware)
+		r.Use(deps.ChatSessionScopeMiddleware)
+		r.Post(prefix+"/message", deps.SendMessageHandler)
+		r.Get(prefix, deps.GetChatSessionHandler)
+		r.Get(prefix+"/messages", deps.ListChatMessagesHandler)
+		r.Patch(prefix, deps.UpdateChatSessionHandler)
+		r.Post(prefix+"/end", deps.EndChatSessionHandler)
+		r.Post(prefix+"/wrap-up", deps.WrapUpChatSessionHandler)
+		r.Post(prefix+"/heartbeat", deps.HeartbeatChatSessionHandler)
+		r.Post(prefix+"/retry-ai", deps.RetryAIHandler)
+		// Reopening is a recruiter override, behind the admin token
+		r.With(AdminAuthMiddleware(deps.AdminToken)).Post(prefix+"/reopen", deps.ReopenChatSessionHandler)
+		// TODO: Add WebSocket support for real-time messaging
+		// TODO: Add DELETE for cleaning up sessions
+	})
+}
+
+// routableMethods are the methods checked when listing a route's Allow header, in listing order
+var routableMethods = []string{
+	http.MethodGet, http.MethodHead, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete,
+}
+
+// allowedMethods lists the methods routes serves for path, relative to routes; OPTIONS is always answered
+func allowedMethods(routes chi.Routes, path string) []string {
+	var allowed []string
+	for _, method := range routableMethods {
+		if routes.Match(chi.NewRouteContext(), method, path) {
+			allowed = append(allowed, method)
+		}
+	}
+	return append(allowed, http.MethodOptions)
+}
+
+// methodNotAllowedHandler answers a route of routes requested with a method it doesn't serve
+// OPTIONS (including CORS preflights) gets 204, anything else a JSON 405; both carry the Allow header.
+// routes must be the router the handler is installed on, since chi can't match across mounts.
+func methodNotAllowedHandler(routes chi.Routes) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		path := r.URL.Path
+		if rctx := chi.RouteContext(r.Context()); rctx != nil && rctx.RoutePath != "" {
+			path = rctx.RoutePath
+		}
+		w.Header().Set("Allow", strings.Join(allowedMethods(routes, path), ", "))
+		if r.Method == http.MethodOptions {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		writeJSONError(w, http.StatusMethodNotAllowed, ErrCodeMethodNotAllowed, ErrMsgMethodNotAllowed)
+	}
+}
@@ -1,9 +1,17 @@
 package api
 
 import (
+	"bytes"
+	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/zidane0000/ai-interview-platform/ai"
+	"github.com/zidane0000/ai-interview-platform/data"
+	"github.com/zidane0000/ai-interview-platform/internal/testsupport"
 )
 
 func TestRouter_Interview_MethodNotAllowed(t *testing.T) {
@@ -45,3 +53,200 @@ func TestRouter_Evaluation_MethodNotAllowed(t *testing.T) {
 		t.Errorf("expected 405 Method Not Allowed, got %d", w.Code)
 	}
 }
+
+func TestRouter_MethodNotAllowed_ListsAllowedMethods(t *testing.T) {
+	router := setupTestRouter()
+	tests := []struct {
+		method string
+		path   string
+		allow  string
+	}{
+		{"PUT", "/api/interviews", "GET, POST, OPTIONS"},
+		{"DELETE", "/api/interviews/interview-1", "GET, PATCH, OPTIONS"},
+		{"GET", "/api/evaluation", "POST, OPTIONS"},
+		{"GET", "/api/evaluation/", "POST, OPTIONS"},
+		{"POST", "/api/evaluation/eval-1", "GET, OPTIONS"},
+		{"PUT", "/api/interviews/interview-1/chat/session-1", "GET, PATCH, OPTIONS"},
+		{"GET", "/api/interviews/interview-1/chat/session-1/end", "POST, OPTIONS"},
+		{"PUT", "/api/chat/session-1", "GET, PATCH, OPTIONS"},
+		{"GET", "/api/chat/session-1/end", "POST, OPTIONS"},
+		{"POST", "/health", "GET, OPTIONS"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.path, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(tt.method, tt.path, nil))
+			if w.Code != http.StatusMethodNotAllowed {
+				t.Fatalf("expected 405, got %d", w.Code)
+			}
+			if got := w.Header().Get("Allow"); got != tt.allow {
+				t.Errorf("expected Allow %q, got %q", tt.allow, got)
+			}
+			var resp ErrorResponseDTO
+			if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+				t.Fatalf("expected a JSON error body, got %q", w.Body.String())
+			}
+			if resp.Code != ErrCodeMethodNotAllowed || resp.Error != ErrMsgMethodNotAllowed {
+				t.Errorf("unexpected error body %+v", resp)
+			}
+		})
+	}
+}
+
+func TestRouter_Options_ReturnsAllow(t *testing.T) {
+	router := setupTestRouter()
+	req := httptest.NewRequest("OPTIONS", "/api/chat/session-1", nil)
+	req.Header.Set("Origin", "http://localhost:5173")
+	req.Header.Set("Access-Control-Request-Method", "PATCH")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusNoContent {
+		t.Fatalf("expected 204, got %d", w.Code)
+	}
+	if got := w.Header().Get("Allow"); got != "GET, PATCH, OPTIONS" {
+		t.Errorf("expected Allow for the chat session route, got %q", got)
+	}
+	if got := w.Header().Get("Access-Control-Allow-Origin"); got != "http://localhost:5173" {
+		t.Errorf("expected CORS headers on the preflight, got origin %q", got)
+	}
+
+	// Unknown routes are still not found
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("OPTIONS", "/api/unknown", nil))
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected 404 for an unknown route, got %d", w.Code)
+	}
+}
+
+func TestRouter_IsolatedStores(t *testing.T) {
+	first := setupTestRouter()
+	second := setupTestRouter()
+	interview := createTestInterview(t, first, testsupport.NewInterviewBuilder().WithCandidate("Isolated"))
+
+	assertErrorResponse(t, second, "GET", "/api/interviews/"+interview.ID, "", http.StatusNotFound, ErrCodeNotFound)
+	if _, err := first.store.GetInterview(interview.ID); err != nil {
+		t.Errorf("expected the interview in the first router's store: %v", err)
+	}
+}
+
+func TestRouter_RouteGroupMountedStandalone(t *testing.T) {
+	store := newTestStore()
+	deps := NewHandlerDependencies(nil, store)
+	r := chi.NewRouter()
+	r.Mount("/v2/interviews", InterviewRoutes(deps))
+	testsupport.NewInterviewBuilder().WithID("standalone").WithCandidate("Standalone").Create(t, store)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest("GET", "/v2/interviews/standalone", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest("PUT", "/v2/interviews/standalone", nil))
+	if w.Code != http.StatusMethodNotAllowed || w.Header().Get("Allow") != "GET, PATCH, OPTIONS" {
+		t.Errorf("expected 405 listing the group's methods, got %d (Allow %q)", w.Code, w.Header().Get("Allow"))
+	}
+}
+
+func TestRouter_TenantIsolation(t *testing.T) {
+	router := setupTestRouterWithProvider(ai.NewMockProvider(), func(deps *HandlerDependencies) {
+		deps.TenantAPIKeys = map[string]string{"key-acme": "acme", "key-globex": "globex"}
+	})
+	serve := func(method, path, apiKey string, body []byte) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, bytes.NewReader(body))
+		if apiKey != "" {
+			req.Header.Set("X-API-Key", apiKey)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+	create := func(apiKey, candidate string) InterviewResponseDTO {
+		body, _ := json.Marshal(interviewRequest(testsupport.NewInterviewBuilder().WithCandidate(candidate).Build()))
+		w := serve("POST", "/api/interviews", apiKey, body)
+		if w.Code != http.StatusCreated {
+			t.Fatalf("failed to create interview, got %d: %s", w.Code, w.Body.String())
+		}
+		var resp InterviewResponseDTO
+		json.Unmarshal(w.Body.Bytes(), &resp)
+		return resp
+	}
+	acme := create("key-acme", "Acme Candidate")
+	globex := create("key-globex", "Globex Candidate")
+
+	for _, tc := range []struct{ key, own, other string }{
+		{"key-acme", acme.ID, globex.ID},
+		{"key-globex", globex.ID, acme.ID},
+	} {
+		w := serve("GET", "/api/interviews", tc.key, nil)
+		var list ListInterviewsResponseDTO
+		json.Unmarshal(w.Body.Bytes(), &list)
+		if w.Code != http.StatusOK || list.Total != 1 || list.Interviews[0].ID != tc.own {
+			t.Errorf("%s: expected to list only %s, got %d: %s", tc.key, tc.own, w.Code, w.Body.String())
+		}
+		if w := serve("GET", "/api/interviews/"+tc.own, tc.key, nil); w.Code != http.StatusOK {
+			t.Errorf("%s: expected its own interview, got %d", tc.key, w.Code)
+		}
+		if w := serve("GET", "/api/interviews/"+tc.other, tc.key, nil); w.Code != http.StatusNotFound {
+			t.Errorf("%s: expected 404 for another tenant's interview, got %d", tc.key, w.Code)
+		}
+	}
+
+	for _, key := range []string{"", "key-unknown"} {
+		if w := serve("GET", "/api/interviews", key, nil); w.Code != http.StatusUnauthorized {
+			t.Errorf("expected 401 for API key %q, got %d", key, w.Code)
+		}
+	}
+	if interview, err := router.store.GetInterview(acme.ID); err != nil || interview.TenantID != "acme" {
+		t.Errorf("expected the interview stamped with tenant acme, got %v, %v", interview, err)
+	}
+}
+
+func TestRouter_TrailingSlashAndIDFormat(t *testing.T) {
+	policy := data.NewFaultPolicy()
+	router := newFaultyTestRouter(policy, ai.NewMockProvider())
+	testsupport.NewInterviewBuilder().WithID("interview-1").WithCandidate("Routing").Create(t, router.store)
+	session := testsupport.NewSessionBuilder().WithID("session-1").ForInterviewID("interview-1").Create(t, router.store)
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		status int
+	}{
+		{"list with trailing slash", "GET", "/api/interviews/", http.StatusOK},
+		{"interview with trailing slash", "GET", "/api/interviews/interview-1/", http.StatusOK},
+		{"session messages with trailing slash", "GET", "/api/chat/" + session.ID + "/messages/", http.StatusOK},
+		{"scoped session with trailing slash", "GET", "/api/interviews/interview-1/chat/" + session.ID + "/", http.StatusOK},
+		{"evaluation with trailing slash", "POST", "/api/evaluation/eval-1/", http.StatusMethodNotAllowed},
+		{"encoded ID decoded once", "GET", "/api/interviews/interview%2D1", http.StatusOK},
+		{"encoded session ID decoded once", "GET", "/api/chat/session%2D1", http.StatusOK},
+		{"double-encoded ID", "GET", "/api/interviews/interview%252D1", http.StatusBadRequest},
+		{"encoded slash", "GET", "/api/interviews/interview%2F1", http.StatusBadRequest},
+		{"whitespace ID", "GET", "/api/interviews/%20", http.StatusBadRequest},
+		{"padded ID", "GET", "/api/interviews/%20interview-1", http.StatusBadRequest},
+		{"padded session ID", "GET", "/api/chat/session-1%09", http.StatusBadRequest},
+		{"overlong ID", "GET", "/api/interviews/" + strings.Repeat("a", maxResourceIDLength+1), http.StatusBadRequest},
+		{"overlong session ID", "POST", "/api/interviews/interview-1/chat/" + strings.Repeat("a", maxResourceIDLength+1) + "/end", http.StatusBadRequest},
+		{"disallowed characters", "GET", "/api/evaluation/eval.1", http.StatusBadRequest},
+		{"longest ID", "GET", "/api/interviews/" + strings.Repeat("a", maxResourceIDLength), http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lookups := policy.Calls("GetInterview") + policy.Calls("GetChatSession") + policy.Calls("GetEvaluation")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(tt.method, tt.path, nil))
+			if w.Code != tt.status {
+				t.Fatalf("expected %d, got %d: %s", tt.status, w.Code, w.Body.String())
+			}
+			if tt.status != http.StatusBadRequest {
+				return
+			}
+			decodeError(t, w, http.StatusBadRequest, ErrCodeValidationFailed)
+			if after := policy.Calls("GetInterview") + policy.Calls("GetChatSession") + policy.Calls("GetEvaluation"); after != lookups {
+				t.Errorf("expected no store lookups for a malformed ID, got %d", after-lookups)
+			}
+		})
+	}
+}
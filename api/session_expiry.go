@@ -0,0 +1,138 @@
+// Idle chat session expiry and client heartbeats
+package api
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/zidane0000/ai-interview-platform/api/apitime"
+	"github.com/zidane0000/ai-interview-platform/config"
+	"github.com/zidane0000/ai-interview-platform/data"
+	"github.com/zidane0000/ai-interview-platform/utils"
+)
+
+// HeartbeatInterval is the minimum time between recorded heartbeats of one session
+const HeartbeatInterval = 30 * time.Second
+
+// idleSessionBatchSize caps the sessions abandoned per janitor run
+const idleSessionBatchSize = 500
+
+// HeartbeatChatSessionHandler handles POST /chat/{sessionId}/heartbeat
+// Records that the candidate is still present so the session is not abandoned while they read or think;
+// no message is created. Heartbeats sooner than HeartbeatInterval after the previous one get 429.
+func (deps *HandlerDependencies) HeartbeatChatSessionHandler(w http.ResponseWriter, r *http.Request) {
+	store := deps.Store.WithContext(r.Context())
+
+	sessionID := chi.URLParam(r, "sessionId")
+	if sessionID == "" {
+		writeJSONError(w, http.StatusBadRequest, ErrCodeValidationFailed, "Missing session ID")
+		return
+	}
+	session, err := store.GetChatSession(sessionID)
+	if err != nil {
+		writeJSONError(w, http.StatusNotFound, ErrCodeNotFound, ErrMsgSessionNotFound)
+		return
+	}
+	if session.Status != "active" {
+		writeJSONError(w, http.StatusConflict, ErrCodeConflict, "Chat session is not active")
+		return
+	}
+
+	now := deps.now()
+	recorded, err := store.RecordChatSessionHeartbeat(sessionID, now, HeartbeatInterval)
+	if err != nil {
+		utils.Errorf("Failed to record heartbeat for session %s: %v", sessionID, err)
+		writeJSONError(w, http.StatusInternalServerError, ErrCodeInternal, "Failed to record heartbeat")
+		return
+	}
+	if !recorded {
+		retryAfter := HeartbeatInterval
+		if session.LastActivityAt != nil {
+			retryAfter = session.LastActivityAt.Add(HeartbeatInterval).Sub(now)
+		}
+		w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+		writeJSONError(w, http.StatusTooManyRequests, ErrCodeRateLimited, "Heartbeat sent too soon; send at most one every 30 seconds")
+		return
+	}
+
+	// Messages are never newer than a heartbeat recorded now, so the heartbeat is the last activity
+	session.LastActivityAt = &now
+	lastActivityAt, expiresAt := deps.sessionActivity(session, time.Time{})
+	writeJSON(w, http.StatusOK, HeartbeatResponseDTO{
+		SessionID:      sessionID,
+		LastActivityAt: *lastActivityAt,
+		ExpiresAt:      expiresAt,
+	})
+}
+
+// sessionActivity returns when the session was last active and, for active sessions with idle
+// expiry enabled, when it will be abandoned. lastMessageAt is zero when the session has no messages.
+func (deps *HandlerDependencies) sessionActivity(session *data.ChatSession, lastMessageAt time.Time) (lastActivityAt, expiresAt *apitime.Time) {
+	latest := apitime.New(session.LastActivity(lastMessageAt))
+	if session.Status == "active" && deps.SessionIdleTimeout > 0 {
+		expires := apitime.New(latest.Add(deps.SessionIdleTimeout))
+		expiresAt = &expires
+	}
+	return &latest, expiresAt
+}
+
+// expireIdleSessions abandons active sessions without a message or heartbeat within SessionIdleTimeout
+// Returns the number of sessions abandoned; none are while read-only, when candidates can't keep them active.
+// Each session is abandoned only if it is still idle, so a message or heartbeat landing after the
+// lookup keeps it active.
+func (deps *HandlerDependencies) expireIdleSessions(store data.Store) (int, error) {
+	if deps.SessionIdleTimeout <= 0 || deps.ReadOnly() {
+		return 0, nil
+	}
+	now := deps.now()
+	cutoff := now.Add(-deps.SessionIdleTimeout)
+	sessions, err := store.GetIdleChatSessions(cutoff, idleSessionBatchSize)
+	if err != nil {
+		return 0, err
+	}
+	expired := 0
+	for _, session := range sessions {
+		abandoned, err := store.AbandonIdleChatSession(session.ID, cutoff, now)
+		if err != nil {
+			utils.Errorf("Failed to abandon idle session %s: %v", session.ID, err)
+			continue
+		}
+		if abandoned {
+			expired++
+		}
+	}
+	return expired, nil
+}
+
+// StartSessionJanitor abandons idle chat sessions of deps.Store every cfg.SessionJanitorInterval until
+// ctx is done. Does nothing when deps.SessionIdleTimeout is 0
+func StartSessionJanitor(ctx context.Context, cfg *config.Config, deps *HandlerDependencies) {
+	if deps.SessionIdleTimeout <= 0 {
+		return
+	}
+	interval := cfg.SessionJanitorInterval
+	if interval <= 0 {
+		interval = config.DefaultSessionJanitorInterval
+	}
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				expired, err := deps.expireIdleSessions(deps.Store.WithContext(ctx))
+				if err != nil {
+					utils.Errorf("Idle session expiry failed: %v", err)
+				} else if expired > 0 {
+					utils.Infof("Abandoned %d idle chat sessions", expired)
+				}
+			}
+		}
+	}()
+}
@@ -0,0 +1,215 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/zidane0000/ai-interview-platform/ai"
+	"github.com/zidane0000/ai-interview-platform/data"
+	"github.com/zidane0000/ai-interview-platform/internal/testsupport"
+)
+
+// sendHeartbeat posts a heartbeat and returns the recorder
+func sendHeartbeat(router http.Handler, sessionID string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest("POST", "/api/chat/"+sessionID+"/heartbeat", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestHeartbeat_PostponesIdleExpiry(t *testing.T) {
+	start := time.Now().UTC().Truncate(time.Millisecond) // API timestamps have millisecond precision
+	clock := start
+	var deps *HandlerDependencies
+	router := setupTestRouterWithProvider(ai.NewScriptedMockProvider("Welcome! What is Go?"), func(d *HandlerDependencies) {
+		d.SessionIdleTimeout = 10 * time.Minute
+		d.now = func() time.Time { return clock }
+		deps = d
+	})
+	ids := createTestInterviewAndSession(t, router)
+	store := router.store
+
+	// The candidate reads the question for 8 minutes, then the UI sends a heartbeat
+	clock = start.Add(8 * time.Minute)
+	w := sendHeartbeat(router, ids.SessionID)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var resp HeartbeatResponseDTO
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to decode heartbeat response: %v", err)
+	}
+	if !resp.LastActivityAt.Equal(clock) || resp.ExpiresAt == nil || !resp.ExpiresAt.Equal(clock.Add(10*time.Minute)) {
+		t.Errorf("expected activity at %v expiring 10 minutes later, got %+v", clock, resp)
+	}
+
+	// A second heartbeat within 30 seconds is rate-limited
+	clock = clock.Add(10 * time.Second)
+	w = sendHeartbeat(router, ids.SessionID)
+	if w.Code != http.StatusTooManyRequests {
+		t.Fatalf("expected 429 for a heartbeat within 30s, got %d: %s", w.Code, w.Body.String())
+	}
+	if w.Header().Get("Retry-After") != "20" {
+		t.Errorf("expected Retry-After 20, got %q", w.Header().Get("Retry-After"))
+	}
+
+	// Past the original expiry the heartbeat keeps the session alive
+	clock = start.Add(15 * time.Minute)
+	if expired, err := deps.expireIdleSessions(store); err != nil || expired != 0 {
+		t.Fatalf("expected no expiry after a recent heartbeat, got %d (%v)", expired, err)
+	}
+	session := getChatSession(t, router, ids.SessionID, "")
+	if session.Status != "active" || session.ExpiresAt == nil || !session.ExpiresAt.Equal(start.Add(18*time.Minute)) {
+		t.Errorf("expected an active session expiring 10 minutes after the heartbeat, got status %q expires %v", session.Status, session.ExpiresAt)
+	}
+
+	// Once heartbeats stop the janitor abandons the session
+	clock = start.Add(19 * time.Minute)
+	if expired, err := deps.expireIdleSessions(store); err != nil || expired != 1 {
+		t.Fatalf("expected the idle session to expire, got %d (%v)", expired, err)
+	}
+	session = getChatSession(t, router, ids.SessionID, "")
+	if session.Status != "abandoned" || session.ExpiresAt != nil {
+		t.Errorf("expected an abandoned session without expires_at, got status %q expires %v", session.Status, session.ExpiresAt)
+	}
+	if w := sendHeartbeat(router, ids.SessionID); w.Code != http.StatusConflict {
+		t.Errorf("expected 409 for a heartbeat on an abandoned session, got %d", w.Code)
+	}
+}
+
+func TestExpireIdleSessions_FallsBackToLastMessage(t *testing.T) {
+	start := time.Now()
+	clock := start
+	var deps *HandlerDependencies
+	router := setupTestRouterWithProvider(ai.NewScriptedMockProvider("Welcome! What is Go?", "What is a goroutine?"), func(d *HandlerDependencies) {
+		d.SessionIdleTimeout = 10 * time.Minute
+		d.now = func() time.Time { return clock }
+		deps = d
+	})
+	ids := createTestInterviewAndSession(t, router)
+
+	// A session that never sent a heartbeat is judged by its messages
+	sendMessage(t, router, ids.SessionID, "A language")
+	clock = start.Add(9 * time.Minute)
+	if expired, _ := deps.expireIdleSessions(router.store); expired != 0 {
+		t.Fatalf("expected a session with a recent message to stay active, got %d expired", expired)
+	}
+	clock = start.Add(11 * time.Minute)
+	if expired, _ := deps.expireIdleSessions(router.store); expired != 1 {
+		t.Fatalf("expected the session to expire 10 minutes after its last message, got %d expired", expired)
+	}
+}
+
+// lookupHookStore runs afterLookup once the idle sessions were listed
+type lookupHookStore struct {
+	data.Store
+	afterLookup func()
+}
+
+func (s *lookupHookStore) GetIdleChatSessions(cutoff time.Time, limit int) ([]*data.ChatSession, error) {
+	sessions, err := s.Store.GetIdleChatSessions(cutoff, limit)
+	s.afterLookup()
+	return sessions, err
+}
+
+func TestExpireIdleSessions_ActivityAfterLookup(t *testing.T) {
+	start := time.Now().Add(-time.Hour).Truncate(time.Second)
+	clock := start
+	var deps *HandlerDependencies
+	router := setupTestRouterWithProvider(ai.NewMockProvider(), func(d *HandlerDependencies) {
+		d.SessionIdleTimeout = 10 * time.Minute
+		d.now = func() time.Time { return clock }
+		deps = d
+	})
+	interview := testsupport.NewInterviewBuilder().Create(t, router.store)
+	testsupport.NewSessionBuilder().ForInterview(interview).WithID("returning-session").WithStartedAt(start).Create(t, router.store)
+	testsupport.NewSessionBuilder().ForInterview(interview).WithID("idle-session").WithStartedAt(start).Create(t, router.store)
+
+	// The candidate's heartbeat lands between the janitor's lookup and its update
+	clock = start.Add(11 * time.Minute)
+	store := &lookupHookStore{Store: router.store, afterLookup: func() {
+		if w := sendHeartbeat(router, "returning-session"); w.Code != http.StatusOK {
+			t.Fatalf("expected 200 for the heartbeat, got %d: %s", w.Code, w.Body.String())
+		}
+	}}
+	if expired, err := deps.expireIdleSessions(store); err != nil || expired != 1 {
+		t.Fatalf("expected only the idle session to expire, got %d (%v)", expired, err)
+	}
+	if session := getChatSession(t, router, "returning-session", ""); session.Status != "active" || session.EndedAt != nil {
+		t.Errorf("expected the session with a late heartbeat to stay active, got status %q ended %v", session.Status, session.EndedAt)
+	}
+	if session := getChatSession(t, router, "idle-session", ""); session.Status != "abandoned" {
+		t.Errorf("expected the idle session abandoned, got status %q", session.Status)
+	}
+}
+
+func TestHeartbeat_UnknownSession(t *testing.T) {
+	router := setupTestRouter()
+	if w := sendHeartbeat(router, "missing"); w.Code != http.StatusNotFound {
+		t.Errorf("expected 404, got %d", w.Code)
+	}
+}
+
+func TestExpireIdleSessions_RecordsDuration(t *testing.T) {
+	start := time.Now().Add(-time.Hour).Truncate(time.Second)
+	clock := start
+	var deps *HandlerDependencies
+	router := setupTestRouterWithProvider(ai.NewMockProvider(), func(d *HandlerDependencies) {
+		d.SessionIdleTimeout = 10 * time.Minute
+		d.AdminToken = "admin-secret"
+		d.now = func() time.Time { return clock }
+		deps = d
+	})
+	interview := testsupport.NewInterviewBuilder().WithType("technical").Create(t, router.store)
+	testsupport.NewSessionBuilder().ForInterview(interview).WithID("idle-session").WithStartedAt(start).Create(t, router.store)
+
+	session := getChatSession(t, router, "idle-session", "")
+	if session.EndedAt != nil || session.DurationSeconds != nil {
+		t.Errorf("expected no end time or duration while active, got %v / %v", session.EndedAt, session.DurationSeconds)
+	}
+
+	clock = start.Add(11 * time.Minute)
+	if expired, err := deps.expireIdleSessions(router.store); err != nil || expired != 1 {
+		t.Fatalf("expected the idle session to expire, got %d (%v)", expired, err)
+	}
+	session = getChatSession(t, router, "idle-session", "")
+	if session.EndedAt == nil || !session.EndedAt.Equal(clock) || session.DurationSeconds == nil || *session.DurationSeconds != 660 {
+		t.Errorf("expected the session to end at %v after 660 seconds, got %v / %v", clock, session.EndedAt, session.DurationSeconds)
+	}
+
+	req := httptest.NewRequest("GET", "/api/admin/stats", nil)
+	req.Header.Set("Authorization", "Bearer admin-secret")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	var stats AdminStatsResponseDTO
+	if err := json.Unmarshal(w.Body.Bytes(), &stats); err != nil {
+		t.Fatalf("failed to unmarshal stats: %v", err)
+	}
+	if len(stats.SessionDurations) != 1 || stats.SessionDurations[0].InterviewType != "technical" ||
+		stats.SessionDurations[0].Sessions != 1 || stats.SessionDurations[0].AverageDurationSeconds != 660 {
+		t.Errorf("expected one technical session averaging 660 seconds, got %+v", stats.SessionDurations)
+	}
+}
+
+func TestEndChatSession_RecordsDuration(t *testing.T) {
+	start := time.Now().Add(-time.Hour).Truncate(time.Second)
+	clock := start
+	router := setupTestRouterWithProvider(ai.NewMockProvider(), func(d *HandlerDependencies) {
+		d.now = func() time.Time { return clock }
+	})
+	interview := createTestInterview(t, router, testsupport.NewInterviewBuilder().WithQuestions(3))
+	session := startChatSession(t, router, testsupport.NewSessionBuilder().ForInterviewID(interview.ID))
+	sendMessage(t, router, session.ID, "I build backend services")
+
+	// Sessions start and end on the injected clock, as the expiry above does
+	clock = start.Add(5 * time.Minute)
+	endSession(t, router, session.ID)
+	ended := getChatSession(t, router, session.ID, "")
+	if !ended.StartedAt.Equal(start) || ended.EndedAt == nil || !ended.EndedAt.Equal(clock) ||
+		ended.DurationSeconds == nil || *ended.DurationSeconds != 300 {
+		t.Errorf("expected the session to run from %v for 300 seconds, got %v to %v / %v", start, ended.StartedAt, ended.EndedAt, ended.DurationSeconds)
+	}
+}
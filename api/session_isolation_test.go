@@ -0,0 +1,110 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/zidane0000/ai-interview-platform/ai"
+	"github.com/zidane0000/ai-interview-platform/internal/testsupport"
+)
+
+// TestSessionIsolation runs two interleaved sessions with distinctive content through the handlers
+// and checks that no payload the provider receives mixes them
+func TestSessionIsolation(t *testing.T) {
+	provider := ai.NewMockProvider()
+	router := setupTestRouterWithProvider(provider, func(deps *HandlerDependencies) {
+		// Summarize early so the running summaries are exercised too
+		deps.SummaryThresholdTurns = 4
+		deps.SummaryRecentTurns = 2
+	})
+
+	markers := []string{"ALPHA", "BRAVO"}
+	sessionIDs := make(map[string]string, len(markers))
+	for _, marker := range markers {
+		interview := createTestInterview(t, router, testsupport.NewInterviewBuilder().
+			WithCandidate(marker+" Candidate").
+			WithJobDescription(marker+" job description").
+			WithResume(marker+" resume").
+			WithAdaptive(true).
+			WithQuestionTexts(marker+" question 1", marker+" question 2", marker+" question 3", marker+" question 4", marker+" question 5", marker+" question 6"))
+		sessionIDs[marker] = startChatSession(t, router, testsupport.NewSessionBuilder().ForInterviewID(interview.ID)).ID
+	}
+	for round := 1; round <= 4; round++ {
+		for _, marker := range markers {
+			sendMessage(t, router, sessionIDs[marker], fmt.Sprintf("%s answer %d", marker, round))
+		}
+	}
+	for _, marker := range markers {
+		req := httptest.NewRequest("POST", "/api/chat/"+sessionIDs[marker]+"/wrap-up", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		if w.Code != http.StatusOK {
+			t.Fatalf("failed to wrap up the %s session, got %d: %s", marker, w.Code, w.Body.String())
+		}
+	}
+
+	// owner returns the marker of the one session payload carries content of, failing on a mix
+	owner := func(kind string, payload string) string {
+		t.Helper()
+		var found []string
+		for _, marker := range markers {
+			if strings.Contains(payload, marker) {
+				found = append(found, marker)
+			}
+		}
+		if len(found) > 1 {
+			t.Errorf("%s payload mixes sessions %v: %s", kind, found, payload)
+		}
+		if len(found) == 0 {
+			return ""
+		}
+		return found[0]
+	}
+
+	turns, summaries := make(map[string]int), 0
+	for _, req := range provider.ChatRequests() {
+		var payload strings.Builder
+		payload.WriteString(req.SystemPrompt)
+		for _, msg := range req.Messages {
+			payload.WriteString("\n" + msg.Content)
+		}
+		marker := owner("chat", payload.String())
+		if req.Context["task"] == ai.TaskSummarization {
+			summaries++
+		}
+		if req.Context["task"] != ai.TaskChatTurn {
+			continue
+		}
+		if req.Context[ai.ContextNoStore] != true {
+			t.Errorf("expected every interviewer turn flagged %s, got %v", ai.ContextNoStore, req.Context)
+		}
+		// Greetings carry no session content yet; everything later must match its session ID
+		if marker != "" && req.SessionID != sessionIDs[marker] {
+			t.Errorf("expected the %s turn sent for session %s, got %q", marker, sessionIDs[marker], req.SessionID)
+		}
+		turns[req.SessionID]++
+	}
+	if summaries == 0 {
+		t.Error("expected the conversations summarized")
+	}
+	for _, marker := range markers {
+		// A greeting, four replies and the sign-off
+		if turns[sessionIDs[marker]] != 6 {
+			t.Errorf("expected 6 interviewer turns for the %s session, got %d", marker, turns[sessionIDs[marker]])
+		}
+	}
+
+	evaluated := make(map[string]bool)
+	for _, req := range provider.EvaluationRequests() {
+		payload := strings.Join(req.Questions, "\n") + "\n" + strings.Join(req.Answers, "\n") + "\n" + req.JobDesc + "\n" + req.ResumeContent
+		evaluated[owner("evaluation", payload)] = true
+	}
+	for _, marker := range markers {
+		if !evaluated[marker] {
+			t.Errorf("expected an evaluation of the %s session", marker)
+		}
+	}
+}
@@ -0,0 +1,100 @@
+// Reopening chat sessions that completed too early
+package api
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/zidane0000/ai-interview-platform/data"
+	"github.com/zidane0000/ai-interview-platform/utils"
+)
+
+// reopenNote is the system message recording a reopen in the transcript
+const reopenNote = "Session reopened by a recruiter after it ended early; the interview continues."
+
+// ReopenChatSessionHandler handles POST /chat/{sessionId}/reopen (admin token required)
+// Returns a completed session to active, e.g. after the candidate's short acknowledgements counted
+// as turns and ended it early; sessions completed more than ReopenWindow ago get 409. The
+// interview's current evaluation is kept until the session is ended again, and the new evaluation
+// supersedes it; ?void_evaluation=true marks it superseded right away instead.
+func (deps *HandlerDependencies) ReopenChatSessionHandler(w http.ResponseWriter, r *http.Request) {
+	// The transition is conditional on the session's latest status, which a replica may lag on
+	store := deps.Store.WithContext(r.Context()).WithPrimaryReads()
+
+	sessionID := chi.URLParam(r, "sessionId")
+	if sessionID == "" {
+		writeJSONError(w, http.StatusBadRequest, ErrCodeValidationFailed, "Missing session ID")
+		return
+	}
+	voidEvaluation := r.URL.Query().Get("void_evaluation") == "true"
+
+	session, err := store.GetChatSession(sessionID)
+	if err != nil {
+		writeJSONError(w, http.StatusNotFound, ErrCodeNotFound, ErrMsgSessionNotFound)
+		return
+	}
+	if session.Status != "completed" {
+		writeJSONError(w, http.StatusConflict, ErrCodeConflict, "Only completed chat sessions can be reopened")
+		return
+	}
+	if session.TranscriptPurgedAt != nil {
+		writeJSONError(w, http.StatusConflict, ErrCodeConflict, "Chat session transcript was purged and can no longer be reopened")
+		return
+	}
+	endedAfter := deps.now().Add(-deps.ReopenWindow)
+	if session.EndedAt == nil || session.EndedAt.Before(endedAfter) {
+		writeJSONError(w, http.StatusConflict, ErrCodeConflict,
+			fmt.Sprintf("Chat session completed more than %s ago and can no longer be reopened", deps.ReopenWindow))
+		return
+	}
+
+	// The reopen note, one more candidate message and the closing reply must still fit
+	result, err := store.GetChatMessagesWithOptions(sessionID, data.ListMessagesOptions{Limit: 1})
+	if err != nil {
+		writeJSONError(w, http.StatusInternalServerError, ErrCodeInternal, "Failed to get chat history")
+		return
+	}
+	if result.Total+3 > deps.MaxMessagesPerSession {
+		writeJSONError(w, http.StatusConflict, ErrCodeConflict, "Chat session has no room left for more messages")
+		return
+	}
+
+	var evaluationID string
+	if existing, err := store.GetLatestEvaluationByInterview(session.InterviewID); err == nil {
+		evaluationID = existing.ID
+	}
+	reopened, err := store.ReopenChatSession(sessionID, endedAfter, evaluationID)
+	if err != nil {
+		writeJSONError(w, http.StatusInternalServerError, ErrCodeInternal, "Failed to reopen session")
+		return
+	}
+	if !reopened {
+		// Reopened (or otherwise changed) by a concurrent request since it was read
+		writeJSONError(w, http.StatusConflict, ErrCodeConflict, "Only completed chat sessions can be reopened")
+		return
+	}
+
+	note := reopenNote
+	if voidEvaluation && evaluationID != "" {
+		if err := store.SupersedeEvaluation(evaluationID); err != nil {
+			writeJSONError(w, http.StatusInternalServerError, ErrCodeInternal, "Session reopened, but failed to void its evaluation")
+			return
+		}
+		note += " The previous evaluation was voided."
+	}
+	// System messages are left out of the AI conversation but passed to the evaluation as notes
+	// The note concerns the recruiters' handling of the session, so the candidate doesn't see it
+	if err := store.AddChatMessage(sessionID, &data.ChatMessage{
+		ID:         data.GenerateID(),
+		SessionID:  sessionID,
+		Type:       "system",
+		Content:    note,
+		Visibility: data.MessageVisibilityInternal,
+		Timestamp:  deps.now(),
+	}); err != nil {
+		utils.Errorf("Failed to record the reopen of session %s: %v", sessionID, err)
+	}
+
+	deps.writeChatSession(w, r, store, sessionID)
+}
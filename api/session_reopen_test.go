@@ -0,0 +1,152 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/zidane0000/ai-interview-platform/ai"
+	"github.com/zidane0000/ai-interview-platform/data"
+	"github.com/zidane0000/ai-interview-platform/internal/testsupport"
+)
+
+// reopenSession posts a reopen with the admin token and returns the recorder
+func reopenSession(router http.Handler, sessionID, query string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest("POST", "/api/chat/"+sessionID+"/reopen"+query, nil)
+	req.Header.Set("Authorization", "Bearer admin-secret")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+// endSession ends a chat session and returns its evaluation
+func endSession(t *testing.T, router http.Handler, sessionID string) EvaluationResponseDTO {
+	t.Helper()
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("POST", "/api/chat/"+sessionID+"/end", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("failed to end session, got %d: %s", w.Code, w.Body.String())
+	}
+	var evaluation EvaluationResponseDTO
+	if err := json.Unmarshal(w.Body.Bytes(), &evaluation); err != nil {
+		t.Fatalf("failed to unmarshal evaluation: %v", err)
+	}
+	return evaluation
+}
+
+// setupReopenTest returns a router with an admin token and a session ended with one answer
+func setupReopenTest(t *testing.T, configure func(*HandlerDependencies)) (*testRouter, ChatInterviewSessionDTO, EvaluationResponseDTO) {
+	t.Helper()
+	router := setupTestRouterWithProvider(ai.NewMockProvider(), func(deps *HandlerDependencies) {
+		deps.AdminToken = "admin-secret"
+		if configure != nil {
+			configure(deps)
+		}
+	})
+	interview := createTestInterview(t, router, testsupport.NewInterviewBuilder().WithQuestions(3))
+	session := startChatSession(t, router, testsupport.NewSessionBuilder().
+		ForInterviewID(interview.ID).
+		WithTranscript(testsupport.Pair("", "ok")))
+	return router, session, endSession(t, router, session.ID)
+}
+
+func TestReopenChatSession(t *testing.T) {
+	router, session, first := setupReopenTest(t, nil)
+
+	assertErrorResponse(t, router, "POST", "/api/chat/"+session.ID+"/reopen", "", http.StatusUnauthorized, ErrCodeUnauthorized)
+
+	w := reopenSession(router, session.ID, "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var reopened ChatInterviewSessionDTO
+	if err := json.Unmarshal(w.Body.Bytes(), &reopened); err != nil {
+		t.Fatalf("failed to unmarshal session: %v", err)
+	}
+	if reopened.Status != "active" || reopened.ReopenCount != 1 {
+		t.Errorf("expected an active session reopened once, got %+v", reopened)
+	}
+
+	// The reopen is noted in the transcript, and the old evaluation stays current until the re-end
+	messages, _ := router.store.GetChatMessages(session.ID)
+	if last := messages[len(messages)-1]; last.Type != "system" || last.Content != reopenNote {
+		t.Errorf("expected a reopen note, got %+v", last)
+	}
+	if latest, err := router.store.GetLatestEvaluationByInterview(session.InterviewID); err != nil || latest.ID != first.ID {
+		t.Errorf("expected %s to stay current, got %v (err %v)", first.ID, latest, err)
+	}
+
+	if resp := sendMessage(t, router, session.ID, "Let me add that I led the payments migration"); resp.SessionStatus != "active" {
+		t.Errorf("expected the reopened session to continue, got %s", resp.SessionStatus)
+	}
+
+	// Ending again replaces the first evaluation without ?replace=true
+	second := endSession(t, router, session.ID)
+	if second.ID == first.ID || second.SupersedesID != first.ID {
+		t.Errorf("expected a new evaluation superseding %s, got %+v", first.ID, second)
+	}
+	if latest, err := router.store.GetLatestEvaluationByInterview(session.InterviewID); err != nil || latest.ID != second.ID {
+		t.Errorf("expected %s to be current, got %v (err %v)", second.ID, latest, err)
+	}
+}
+
+func TestReopenChatSession_VoidEvaluation(t *testing.T) {
+	router, session, first := setupReopenTest(t, nil)
+
+	if w := reopenSession(router, session.ID, "?void_evaluation=true"); w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	voided, err := router.store.GetEvaluation(first.ID)
+	if err != nil || voided.Status != data.EvaluationStatusSuperseded {
+		t.Fatalf("expected the evaluation to be voided, got %v (err %v)", voided, err)
+	}
+	if _, err := router.store.GetLatestEvaluationByInterview(session.InterviewID); err == nil {
+		t.Error("expected no current evaluation while the session is reopened")
+	}
+
+	if second := endSession(t, router, session.ID); second.SupersedesID != first.ID {
+		t.Errorf("expected the new evaluation to supersede %s, got %+v", first.ID, second)
+	}
+}
+
+func TestReopenChatSession_Conflicts(t *testing.T) {
+	clock := time.Now()
+	router, session, _ := setupReopenTest(t, func(deps *HandlerDependencies) {
+		deps.ReopenWindow = time.Hour
+		deps.now = func() time.Time { return clock }
+	})
+
+	if w := reopenSession(router, "missing", ""); w.Code != http.StatusNotFound {
+		t.Errorf("expected 404 for an unknown session, got %d", w.Code)
+	}
+
+	// Outside the window
+	clock = clock.Add(2 * time.Hour)
+	if w := reopenSession(router, session.ID, ""); w.Code != http.StatusConflict {
+		t.Errorf("expected 409 outside the reopen window, got %d: %s", w.Code, w.Body.String())
+	}
+
+	// Active sessions can't be reopened
+	clock = time.Now()
+	if w := reopenSession(router, session.ID, ""); w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if w := reopenSession(router, session.ID, ""); w.Code != http.StatusConflict {
+		t.Errorf("expected 409 for an active session, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestReopenChatSession_FullSession(t *testing.T) {
+	router, session, _ := setupReopenTest(t, func(deps *HandlerDependencies) {
+		deps.MaxMessagesPerSession = 4
+	})
+
+	if w := reopenSession(router, session.ID, ""); w.Code != http.StatusConflict {
+		t.Errorf("expected 409 when no messages fit, got %d: %s", w.Code, w.Body.String())
+	} else if !strings.Contains(w.Body.String(), "no room left") {
+		t.Errorf("expected the message limit to be the reason, got %s", w.Body.String())
+	}
+}
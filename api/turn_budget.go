@@ -0,0 +1,151 @@
+// Chat turn latency budget: per-stage deadlines so a slow turn answers before the server's WriteTimeout
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+)
+
+// Stages of a chat turn's budget, in the order they run
+const (
+	turnStageModeration       = "moderation"        // Session lookup and anti-abuse checks
+	turnStagePersistence      = "persistence"       // Summarizing a long message, storing it and loading the history
+	turnStageGeneration       = "generation"        // Every AI call producing the reply, retries included
+	turnStageFinalPersistence = "final_persistence" // Storing the reply and updating the session
+)
+
+// turnStageShares are the portions of the budget each stage gets, in the order the stages run
+var turnStageShares = []struct {
+	stage string
+	share float64
+}{
+	{turnStageModeration, 0.10},
+	{turnStagePersistence, 0.15},
+	{turnStageGeneration, 0.60},
+	{turnStageFinalPersistence, 0.15},
+}
+
+// turnStageSpend is the time one stage of a turn was allotted and used
+type turnStageSpend struct {
+	stage     string
+	allotted  time.Duration
+	used      time.Duration
+	started   time.Time
+	ctx       context.Context
+	cancel    context.CancelFunc
+	exhausted bool // The stage ran out of its allotment
+}
+
+// turnBudget splits a chat turn's latency budget between its stages. Each stage entered is allotted
+// its share of the time still left, in proportion to the shares of the stages still to run, so time
+// a stage doesn't use passes on to the later ones while they keep their portion. A zero budget
+// imposes no deadlines.
+type turnBudget struct {
+	total  time.Duration
+	start  time.Time
+	parent context.Context
+	next   int // Index in turnStageShares of the first stage not entered yet
+	stages []*turnStageSpend
+}
+
+// newTurnBudget starts a budget of total for a turn served under ctx
+func newTurnBudget(ctx context.Context, total time.Duration) *turnBudget {
+	return &turnBudget{total: total, start: time.Now(), parent: ctx}
+}
+
+// enter ends the current stage and starts stage, returning the context its work runs under
+// Entering a stage that already ran, or one out of order, keeps the current one.
+func (b *turnBudget) enter(stage string) context.Context {
+	index := -1
+	for i := b.next; i < len(turnStageShares); i++ {
+		if turnStageShares[i].stage == stage {
+			index = i
+			break
+		}
+	}
+	if index < 0 {
+		return b.context()
+	}
+	b.end()
+
+	spend := &turnStageSpend{stage: stage, started: time.Now()}
+	if b.total <= 0 {
+		spend.ctx, spend.cancel = context.WithCancel(b.parent)
+	} else {
+		var remainingShares float64
+		for _, s := range turnStageShares[index:] {
+			remainingShares += s.share
+		}
+		left := b.total - time.Since(b.start)
+		spend.allotted = max(time.Duration(float64(left)*turnStageShares[index].share/remainingShares), 0)
+		spend.ctx, spend.cancel = context.WithTimeout(b.parent, spend.allotted)
+	}
+	b.stages = append(b.stages, spend)
+	b.next = index + 1
+	return spend.ctx
+}
+
+// context returns the context of the current stage; the turn's own before the first
+func (b *turnBudget) context() context.Context {
+	if len(b.stages) == 0 {
+		return b.parent
+	}
+	return b.stages[len(b.stages)-1].ctx
+}
+
+// end closes the current stage, recording the time it used and whether it ran out
+func (b *turnBudget) end() {
+	if len(b.stages) == 0 {
+		return
+	}
+	current := b.stages[len(b.stages)-1]
+	if current.cancel == nil {
+		return
+	}
+	current.used = time.Since(current.started)
+	current.exhausted = errors.Is(current.ctx.Err(), context.DeadlineExceeded)
+	current.cancel()
+	current.cancel = nil
+}
+
+// exhausted reports whether the current stage ran out of its allotment
+// A turn ended by its client is not: the budget only accounts for time.
+func (b *turnBudget) exhausted() bool {
+	return len(b.stages) > 0 && errors.Is(b.context().Err(), context.DeadlineExceeded) && b.parent.Err() == nil
+}
+
+// dto ends the current stage and reports the budget's consumption; nil without a budget
+func (b *turnBudget) dto() *TurnBudgetDTO {
+	if b == nil || b.total <= 0 {
+		return nil
+	}
+	b.end()
+	budget := &TurnBudgetDTO{BudgetMs: b.total.Milliseconds(), Stages: make([]TurnStageDTO, len(b.stages))}
+	for i, s := range b.stages {
+		budget.Stages[i] = TurnStageDTO{Stage: s.stage, AllottedMs: s.allotted.Milliseconds(), UsedMs: s.used.Milliseconds(), Exhausted: s.exhausted}
+		budget.Exhausted = budget.Exhausted || s.exhausted
+	}
+	return budget
+}
+
+// writeTurnBudgetExhausted writes the AI error response when the turn's current stage ran out of
+// its budget, so the client gets an answer it can retry on before the server's WriteTimeout.
+// The candidate's message, once stored, stays stored; resending it with its client_message_id
+// continues the turn. Returns false, writing nothing, while the stage still has time.
+func writeTurnBudgetExhausted(w http.ResponseWriter, r *http.Request, budget *turnBudget, timings *requestTimings) bool {
+	if !budget.exhausted() {
+		return false
+	}
+	stage := budget.stages[len(budget.stages)-1].stage
+	writeJSON(w, http.StatusGatewayTimeout, TurnBudgetErrorResponseDTO{
+		ErrorResponseDTO: ErrorResponseDTO{
+			Error:   "Failed to generate AI response in time",
+			Code:    ErrCodeAIUnavailable,
+			Details: "the chat turn's time budget ran out during " + stage,
+		},
+		Timings: timings.finish(routeLabel(r)),
+	})
+	return true
+}
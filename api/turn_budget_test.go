@@ -0,0 +1,77 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/zidane0000/ai-interview-platform/ai"
+	"github.com/zidane0000/ai-interview-platform/internal/testsupport"
+)
+
+func TestSendMessage_TurnBudgetExhausted(t *testing.T) {
+	provider := ai.NewMockProvider()
+	router := setupTestRouterWithProvider(provider, func(deps *HandlerDependencies) {
+		deps.TurnBudget = 200 * time.Millisecond
+	})
+	interview := createTestInterview(t, router, testsupport.NewInterviewBuilder().WithQuestions(3))
+	session := startChatSession(t, router, testsupport.NewSessionBuilder().ForInterviewID(interview.ID))
+
+	// The provider answers well after the generation stage's share of the budget
+	provider.SetDelay(2 * time.Second)
+	start := time.Now()
+	w := postMessage(router, session.ID, "I mostly write Go services", nil)
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("expected the turn to give up within its budget, took %v", elapsed)
+	}
+	assertRefused(t, w, http.StatusGatewayTimeout, ErrCodeAIUnavailable)
+
+	var resp TurnBudgetErrorResponseDTO
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil || resp.Timings == nil || resp.Timings.Budget == nil {
+		t.Fatalf("expected the budget's consumption in the timings, got %s", w.Body.String())
+	}
+	budget := resp.Timings.Budget
+	last := budget.Stages[len(budget.Stages)-1]
+	if !budget.Exhausted || last.Stage != turnStageGeneration || !last.Exhausted || budget.BudgetMs != 200 {
+		t.Errorf("expected the generation stage to run out, got %+v", budget)
+	}
+
+	// The candidate's message was stored before generation started
+	provider.SetDelay(0)
+	messages := getChatSession(t, router, session.ID, "").Messages
+	if len(messages) == 0 || messages[len(messages)-1].Type != "user" || messages[len(messages)-1].Content != "I mostly write Go services" {
+		t.Errorf("expected the candidate's message persisted, got %+v", messages)
+	}
+}
+
+func TestSendMessage_TurnBudgetTimings(t *testing.T) {
+	router := setupTestRouterWithProvider(ai.NewMockProvider(), nil)
+	interview := createTestInterview(t, router, testsupport.NewInterviewBuilder().WithQuestions(3))
+	session := startChatSession(t, router, testsupport.NewSessionBuilder().ForInterviewID(interview.ID))
+
+	reply := sendMessage(t, router, session.ID, "I mostly write Go services")
+	if reply.Timings == nil || reply.Timings.Budget == nil {
+		t.Fatalf("expected the budget in the timings, got %+v", reply.Timings)
+	}
+	budget := reply.Timings.Budget
+	stages := []string{turnStageModeration, turnStagePersistence, turnStageGeneration, turnStageFinalPersistence}
+	if budget.Exhausted || budget.BudgetMs != (25*time.Second).Milliseconds() || len(budget.Stages) != len(stages) {
+		t.Fatalf("expected every stage within budget, got %+v", budget)
+	}
+	for i, stage := range budget.Stages {
+		if stage.Stage != stages[i] || stage.AllottedMs <= 0 || stage.UsedMs > stage.AllottedMs {
+			t.Errorf("expected stage %q within its allotment, got %+v", stages[i], stage)
+		}
+	}
+
+	// A zero budget imposes no deadlines and reports none
+	router = setupTestRouterWithProvider(ai.NewMockProvider(), func(deps *HandlerDependencies) {
+		deps.TurnBudget = 0
+	})
+	interview = createTestInterview(t, router, testsupport.NewInterviewBuilder().WithQuestions(3))
+	session = startChatSession(t, router, testsupport.NewSessionBuilder().ForInterviewID(interview.ID))
+	if reply := sendMessage(t, router, session.ID, "I mostly write Go services"); reply.Timings == nil || reply.Timings.Budget != nil {
+		t.Errorf("expected no budget reported, got %+v", reply.Timings)
+	}
+}
@@ -0,0 +1,62 @@
+// Build information and route listing for support engineers
+package api
+
+import (
+	"net/http"
+	"sort"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/zidane0000/ai-interview-platform/data"
+	"github.com/zidane0000/ai-interview-platform/version"
+)
+
+// streamingEnabled reports whether chat replies can be streamed; no streaming endpoint exists yet
+const streamingEnabled = false
+
+// GetVersionHandler handles GET /version
+// Reports the build the instance runs, the features its configuration enables, its store backend and
+// whether it is in read-only mode
+func (deps *HandlerDependencies) GetVersionHandler(w http.ResponseWriter, r *http.Request) {
+	info := version.Get()
+	resp := VersionResponseDTO{
+		Version:   info.Version,
+		Commit:    info.Commit,
+		BuildDate: info.BuildDate,
+		GoVersion: info.GoVersion,
+		Features: FeatureFlagsDTO{
+			Streaming:    streamingEnabled,
+			Webhooks:     deps.Webhooks != nil && deps.Webhooks.globalURL != "",
+			MultiTenancy: len(deps.TenantAPIKeys) > 0,
+		},
+		StoreBackend: "unknown",
+		ReadOnly:     deps.ReadOnly(),
+	}
+	if backend, ok := deps.Store.(interface{ GetBackend() data.StoreBackend }); ok {
+		resp.StoreBackend = string(backend.GetBackend())
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// ListRoutesHandler handles GET /admin/routes
+// Lists every method and route pattern the router serves, sorted by pattern then method
+// Only mounted when debug endpoints are enabled, behind admin auth
+func (deps *HandlerDependencies) ListRoutesHandler(w http.ResponseWriter, r *http.Request) {
+	routes := make([]RouteDTO, 0)
+	if deps.routes != nil {
+		err := chi.Walk(deps.routes, func(method, route string, _ http.Handler, _ ...func(http.Handler) http.Handler) error {
+			routes = append(routes, RouteDTO{Method: method, Pattern: route})
+			return nil
+		})
+		if err != nil {
+			writeJSONError(w, http.StatusInternalServerError, ErrCodeInternal, "Failed to list routes", err.Error())
+			return
+		}
+	}
+	sort.Slice(routes, func(i, j int) bool {
+		if routes[i].Pattern != routes[j].Pattern {
+			return routes[i].Pattern < routes[j].Pattern
+		}
+		return routes[i].Method < routes[j].Method
+	})
+	writeJSON(w, http.StatusOK, RoutesResponseDTO{Routes: routes})
+}
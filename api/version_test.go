@@ -0,0 +1,88 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"testing"
+
+	"github.com/zidane0000/ai-interview-platform/ai"
+	"github.com/zidane0000/ai-interview-platform/version"
+)
+
+func TestGetVersion(t *testing.T) {
+	router := setupTestRouterWithProvider(ai.NewMockProvider(), func(deps *HandlerDependencies) {
+		deps.TenantAPIKeys = map[string]string{"key-a": "tenant-a"}
+	})
+
+	get := func() VersionResponseDTO {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("GET", "/api/version", nil))
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+		}
+		var resp VersionResponseDTO
+		if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("failed to unmarshal response: %v", err)
+		}
+		return resp
+	}
+
+	// Test binaries are built without -ldflags, so the defaults are reported
+	resp := get()
+	if resp.Version != "dev" || resp.Commit != "unknown" || resp.BuildDate != "unknown" || resp.GoVersion != runtime.Version() {
+		t.Errorf("expected the default build info, got %+v", resp)
+	}
+	if resp.StoreBackend != "memory" || !resp.Features.MultiTenancy || resp.Features.Webhooks || resp.Features.Streaming {
+		t.Errorf("expected the memory backend with only multi-tenancy enabled, got %+v", resp)
+	}
+
+	// Values injected with -X are reported as set
+	defer func(v, c, d string) { version.Version, version.Commit, version.BuildDate = v, c, d }(version.Version, version.Commit, version.BuildDate)
+	version.Version, version.Commit, version.BuildDate = "v1.4.0", "abc1234", "2025-03-10T09:00:00Z"
+	if resp := get(); resp.Version != "v1.4.0" || resp.Commit != "abc1234" || resp.BuildDate != "2025-03-10T09:00:00Z" {
+		t.Errorf("expected the injected build info, got %+v", resp)
+	}
+}
+
+func TestListRoutes(t *testing.T) {
+	router := setupTestRouterWithProvider(ai.NewMockProvider(), func(deps *HandlerDependencies) {
+		deps.AdminToken = "admin-secret"
+		deps.EnableDebugEndpoints = true
+	})
+
+	w := adminRequest(router, "GET", "/api/admin/routes", "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var resp RoutesResponseDTO
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to unmarshal response: %v", err)
+	}
+	listed := make(map[RouteDTO]bool)
+	for _, route := range resp.Routes {
+		listed[route] = true
+	}
+	for _, expected := range []RouteDTO{
+		{Method: "GET", Pattern: "/health"},
+		{Method: "GET", Pattern: "/api/version"},
+		{Method: "POST", Pattern: "/api/interviews/"},
+		{Method: "GET", Pattern: "/api/interviews/{id}"},
+		{Method: "POST", Pattern: "/api/chat/{sessionId}/message"},
+		{Method: "POST", Pattern: "/api/admin/evaluations/calibrate"},
+		{Method: "GET", Pattern: "/api/admin/routes"},
+	} {
+		if !listed[expected] {
+			t.Errorf("expected %s %s to be listed", expected.Method, expected.Pattern)
+		}
+	}
+
+	// Without debug endpoints the listing isn't mounted
+	router = setupTestRouterWithProvider(ai.NewMockProvider(), func(deps *HandlerDependencies) {
+		deps.AdminToken = "admin-secret"
+	})
+	if w := adminRequest(router, "GET", "/api/admin/routes", ""); w.Code != http.StatusNotFound {
+		t.Errorf("expected 404 without debug endpoints, got %d", w.Code)
+	}
+}
@@ -0,0 +1,69 @@
+// Soft validation warnings: non-fatal issues reported alongside a successful response
+package api
+
+import "github.com/zidane0000/ai-interview-platform/data"
+
+// WarningCode is a stable, machine-readable identifier of a warning
+// Like error codes, clients should branch on the code rather than on the message
+type WarningCode string
+
+const (
+	WarnCodeInvalidParameter         WarningCode = "invalid_parameter"          // Query parameter could not be parsed and was ignored
+	WarnCodeClampedParameter         WarningCode = "clamped_parameter"          // Query parameter was over its maximum and was lowered to it
+	WarnCodeDuplicateQuestion        WarningCode = "duplicate_question"         // Repeated question was removed
+	WarnCodeDefaultQuestionsFallback WarningCode = "default_questions_fallback" // No built-in set for the type or language; another was used
+	WarnCodeJobDescriptionSummarized WarningCode = "job_description_summarized" // Job description is over the soft limit and is summarized in prompts
+	WarnCodeMessageSummarized        WarningCode = "message_summarized"         // Long message was stored in full but summarized for the AI
+	WarnCodeReplyLanguageMismatch    WarningCode = "reply_language_mismatch"    // AI reply is not in the session language
+	WarnCodeAnswerLanguageMismatch   WarningCode = "answer_language_mismatch"   // Answers were mostly in another language than the interview
+	WarnCodeFeedbackTruncated        WarningCode = "feedback_truncated"         // Evaluation feedback was cut to its word limit
+	WarnCodeSmallCohort              WarningCode = "small_cohort"               // Too few comparable evaluations to rank a score by percentile
+	WarnCodeTranslationFailed        WarningCode = "translation_failed"         // Feedback could not be translated into an additional language
+)
+
+// warningCodes lists every warning code, as GET /api/meta reports them
+var warningCodes = []WarningCode{
+	WarnCodeInvalidParameter, WarnCodeClampedParameter, WarnCodeDuplicateQuestion, WarnCodeDefaultQuestionsFallback,
+	WarnCodeJobDescriptionSummarized, WarnCodeMessageSummarized, WarnCodeReplyLanguageMismatch, WarnCodeAnswerLanguageMismatch,
+	WarnCodeFeedbackTruncated, WarnCodeSmallCohort, WarnCodeTranslationFailed,
+}
+
+// warningList accumulates the warnings of a request while it is processed
+// The zero value is empty; adding to a nil *warningList discards the warning.
+type warningList []WarningDTO
+
+// add records a warning; field names the request field or query parameter it is about, if any
+func (l *warningList) add(code WarningCode, field, message string) {
+	if l == nil {
+		return
+	}
+	*l = append(*l, WarningDTO{Code: code, Message: message, Field: field})
+}
+
+// exchangeWarnings reports what a client should know about a stored message and the AI reply to it
+func exchangeWarnings(userMessage, aiMessage *data.ChatMessage) warningList {
+	var warnings warningList
+	if userMessage.Metadata[data.MessageMetaSummarized] == "true" {
+		warnings.add(WarnCodeMessageSummarized, "message", "Message is stored in full but was summarized for the AI")
+	}
+	if aiMessage != nil && aiMessage.Metadata[data.MessageMetaLanguageMismatch] == "true" {
+		warnings.add(WarnCodeReplyLanguageMismatch, "", "AI reply is not in the session language")
+	}
+	return warnings
+}
+
+// evaluationWarnings reports the evaluation's language mismatch, truncated feedback and failed
+// translations as warnings
+func evaluationWarnings(evaluation *data.Evaluation) warningList {
+	var warnings warningList
+	if evaluation.LanguageMismatch {
+		warnings.add(WarnCodeAnswerLanguageMismatch, "", "Answers were mostly in another language than the interview; scored on content")
+	}
+	if evaluation.FeedbackTruncated {
+		warnings.add(WarnCodeFeedbackTruncated, "feedback", "Feedback ran over its word limit and was cut at a sentence boundary")
+	}
+	for _, language := range evaluation.TranslationFailures {
+		warnings.add(WarnCodeTranslationFailed, "additional_feedback_languages", "Feedback could not be translated into "+language)
+	}
+	return warnings
+}
@@ -0,0 +1,501 @@
+// Webhook notifications for interview events
+package api
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/zidane0000/ai-interview-platform/ai"
+	"github.com/zidane0000/ai-interview-platform/config"
+	"github.com/zidane0000/ai-interview-platform/data"
+	"github.com/zidane0000/ai-interview-platform/utils"
+)
+
+// Webhook events
+const (
+	WebhookEventEvaluationCreated = "evaluation.created"
+	WebhookEventSessionCompleted  = "session.completed"
+)
+
+// webhookEvents lists the supported events; per-interview webhooks subscribe to all of them by default
+var webhookEvents = []string{WebhookEventEvaluationCreated, WebhookEventSessionCompleted}
+
+// webhookTimeout bounds one delivery attempt
+const webhookTimeout = 10 * time.Second
+
+// webhookSignatureHeader carries "sha256=<hex HMAC of the body>" when the endpoint has a secret
+const webhookSignatureHeader = "X-Webhook-Signature"
+
+// normalizeWebhookEvents validates the requested events, dropping repeats
+// An empty list subscribes to every event
+func normalizeWebhookEvents(events []string) ([]string, error) {
+	if len(events) == 0 {
+		return append([]string(nil), webhookEvents...), nil
+	}
+	var normalized []string
+	for _, event := range events {
+		if !isWebhookEvent(event) {
+			return nil, fmt.Errorf("unsupported event %q (supported: %s)", event, strings.Join(webhookEvents, ", "))
+		}
+		if !containsString(normalized, event) {
+			normalized = append(normalized, event)
+		}
+	}
+	return normalized, nil
+}
+
+// isWebhookEvent reports whether event is a supported webhook event
+func isWebhookEvent(event string) bool {
+	return containsString(webhookEvents, event)
+}
+
+// containsString reports whether values contains value
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// validateWebhookURL restricts per-interview webhooks to https and refuses obvious SSRF
+// targets (localhost, loopback, private and link-local addresses) unless the host is allowlisted.
+// Host names are only checked literally here; the dispatcher checks the addresses it dials for each delivery.
+func validateWebhookURL(raw string, allowedHosts []string) error {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return fmt.Errorf("invalid URL: %w", err)
+	}
+	if u.Scheme != "https" {
+		return errors.New("webhook_url must use https")
+	}
+	host := u.Hostname()
+	if host == "" {
+		return errors.New("webhook_url must include a host")
+	}
+	if u.User != nil {
+		return errors.New("webhook_url must not include credentials")
+	}
+	if isAllowedWebhookHost(host, allowedHosts) {
+		return nil
+	}
+	host = strings.ToLower(strings.TrimSuffix(host, "."))
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return fmt.Errorf("webhook_url host %q is not allowed", host)
+	}
+	if ip := net.ParseIP(host); ip != nil && isBlockedWebhookIP(ip) {
+		return fmt.Errorf("webhook_url address %s is not allowed", ip)
+	}
+	return nil
+}
+
+// isAllowedWebhookHost reports whether host is on the configured allowlist
+func isAllowedWebhookHost(host string, allowedHosts []string) bool {
+	for _, allowed := range allowedHosts {
+		if strings.EqualFold(host, allowed) {
+			return true
+		}
+	}
+	return false
+}
+
+// blockedWebhookNetworks are ranges refused besides those net.IP classifies: "this network" and
+// carrier-grade NAT, which can reach hosts inside the provider's network
+var blockedWebhookNetworks = []*net.IPNet{
+	{IP: net.IPv4(0, 0, 0, 0), Mask: net.CIDRMask(8, 32)},
+	{IP: net.IPv4(100, 64, 0, 0), Mask: net.CIDRMask(10, 32)},
+}
+
+// isBlockedWebhookIP reports whether a webhook must not be delivered to ip
+func isBlockedWebhookIP(ip net.IP) bool {
+	if ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsInterfaceLocalMulticast() {
+		return true
+	}
+	for _, network := range blockedWebhookNetworks {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// webhookTarget is one endpoint a payload is delivered to
+type webhookTarget struct {
+	url     string
+	secret  string
+	guarded bool // Per-interview endpoints are checked against the SSRF guard when delivering
+	// Interview of a per-interview endpoint, whose secret is looked up when delivering
+	tenantID    string
+	interviewID string
+}
+
+// webhookBatchSize caps the notifications delivered per pass; the rest wait for the next one
+const webhookBatchSize = 100
+
+// maxWebhookRetryBackoff caps the delay between delivery attempts
+const maxWebhookRetryBackoff = time.Hour
+
+// errWebhookTargetBlocked marks deliveries refused by the SSRF guard; they are not retried
+var errWebhookTargetBlocked = errors.New("webhook target blocked")
+
+// webhookNotification is the outbox payload of one delivery
+// No secret is stored: global deliveries are signed with the current WEBHOOK_SECRET, and per-interview
+// ones with the interview's secret, looked up when delivering.
+type webhookNotification struct {
+	Event       string          `json:"event"`
+	InterviewID string          `json:"interview_id,omitempty"`
+	URL         string          `json:"url"`
+	Global      bool            `json:"global,omitempty"`
+	Body        json.RawMessage `json:"body"`
+}
+
+// WebhookDispatcher delivers event payloads to the global endpoint and to the interview's own
+// endpoint when it subscribes to the event. Dispatch writes each delivery to the store's
+// notification outbox; the worker started by Start delivers them, retrying failures with
+// exponential backoff, so deliveries survive restarts. Endpoints may receive a delivery twice.
+type WebhookDispatcher struct {
+	globalURL    string
+	globalSecret string
+	allowedHosts []string
+	maxAttempts  int
+	retryBackoff time.Duration
+	pollInterval time.Duration
+	client       *http.Client // Delivers to the global endpoint
+	// Delivers to per-interview endpoints: no proxy, and every connection checked by guardedDialContext
+	guardedClient *http.Client
+	// Response bytes read off (and discarded) so the connection can be reused; longer bodies are abandoned
+	maxResponseBytes int
+	lookupIP         func(ctx context.Context, host string) ([]net.IP, error)
+	store            data.Store // Outbox; set by NewHandlerDependencies
+	now              func() time.Time
+	mu               sync.Mutex    // Serializes delivery passes
+	done             chan struct{} // Closed when the worker stops; nil until Start
+}
+
+// NewWebhookDispatcher creates a dispatcher; globalURL may be empty to only serve per-interview webhooks
+// Redirects are not followed, since their target would escape the SSRF guard of per-interview endpoints.
+// Per-interview endpoints are connected to directly, bypassing HTTP(S)_PROXY: through a proxy, the
+// address dialed would be the proxy's rather than the endpoint's.
+func NewWebhookDispatcher(globalURL, globalSecret string, allowedHosts []string) *WebhookDispatcher {
+	d := &WebhookDispatcher{
+		globalURL:        globalURL,
+		globalSecret:     globalSecret,
+		allowedHosts:     allowedHosts,
+		maxAttempts:      config.DefaultWebhookMaxAttempts,
+		retryBackoff:     config.DefaultWebhookRetryBackoff,
+		pollInterval:     config.DefaultWebhookPollInterval,
+		maxResponseBytes: ai.DefaultMaxResponseBytes,
+		lookupIP: func(ctx context.Context, host string) ([]net.IP, error) {
+			return net.DefaultResolver.LookupIP(ctx, "ip", host)
+		},
+		now: time.Now,
+	}
+	noRedirects := func(req *http.Request, via []*http.Request) error {
+		return http.ErrUseLastResponse
+	}
+	d.client = &http.Client{
+		Timeout:       webhookTimeout,
+		Transport:     http.DefaultTransport.(*http.Transport).Clone(),
+		CheckRedirect: noRedirects,
+	}
+	guarded := http.DefaultTransport.(*http.Transport).Clone()
+	guarded.Proxy = nil
+	guarded.DialContext = d.guardedDialContext
+	d.guardedClient = &http.Client{Timeout: webhookTimeout, Transport: guarded, CheckRedirect: noRedirects}
+	return d
+}
+
+// outbox returns the store notifications are queued in
+func (d *WebhookDispatcher) outbox() data.Store {
+	return d.store
+}
+
+// Dispatch queues the payload for every endpoint subscribed to its event
+func (d *WebhookDispatcher) Dispatch(interview *data.Interview, payload WebhookPayloadDTO) {
+	var targets []webhookTarget
+	if d.globalURL != "" {
+		targets = append(targets, webhookTarget{url: d.globalURL, secret: d.globalSecret})
+	}
+	if interview != nil && interview.NotifiesOn(payload.Event) {
+		targets = append(targets, webhookTarget{url: interview.NotifyWebhookURL, guarded: true})
+	}
+	if len(targets) == 0 {
+		return
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		utils.Errorf("Failed to encode %s webhook payload: %v", payload.Event, err)
+		return
+	}
+	tenantID := ""
+	if interview != nil {
+		tenantID = interview.TenantID
+	}
+	for _, target := range targets {
+		if err := d.enqueue(target, tenantID, payload, body); err != nil {
+			utils.Errorf("Failed to queue %s webhook for interview %s: %v", payload.Event, payload.InterviewID, err)
+		}
+	}
+}
+
+// enqueue writes one delivery to the outbox, due immediately, owned by the interview's tenant
+func (d *WebhookDispatcher) enqueue(target webhookTarget, tenantID string, payload WebhookPayloadDTO, body []byte) error {
+	notification := webhookNotification{
+		Event:       payload.Event,
+		InterviewID: payload.InterviewID,
+		URL:         target.url,
+		Global:      !target.guarded,
+		Body:        body,
+	}
+	raw, err := json.Marshal(notification)
+	if err != nil {
+		return err
+	}
+	return d.outbox().CreateNotification(&data.Notification{
+		ID:            data.GenerateID(),
+		TenantID:      tenantID,
+		Type:          data.NotificationTypeWebhook,
+		Payload:       string(raw),
+		Status:        data.NotificationStatusPending,
+		NextAttemptAt: d.now(),
+	})
+}
+
+// Start delivers due notifications every poll interval until ctx is done
+// Use Wait to block until the worker has stopped.
+func (d *WebhookDispatcher) Start(ctx context.Context) {
+	d.done = make(chan struct{})
+	go func() {
+		defer close(d.done)
+		ticker := time.NewTicker(d.pollInterval)
+		defer ticker.Stop()
+		for {
+			d.Flush(ctx)
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// Wait blocks until the worker started by Start has stopped; it returns at once if none was started
+func (d *WebhookDispatcher) Wait() {
+	if d.done != nil {
+		<-d.done
+	}
+}
+
+// Flush attempts the notifications due now and returns how many were attempted
+// Once ctx is done it stops, leaving the rest queued; an interrupted attempt is not counted.
+func (d *WebhookDispatcher) Flush(ctx context.Context) int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	due, err := d.outbox().WithContext(ctx).GetDueNotifications(d.now(), webhookBatchSize)
+	if err != nil {
+		if ctx.Err() == nil {
+			utils.Errorf("Failed to load due notifications: %v", err)
+		}
+		return 0
+	}
+	attempted := 0
+	for _, notification := range due {
+		if ctx.Err() != nil {
+			break
+		}
+		if d.attempt(ctx, notification) {
+			attempted++
+		}
+	}
+	return attempted
+}
+
+// attempt delivers one notification and records the outcome: delivered, retried after a backoff,
+// or failed once the attempts run out. Returns false when ctx ended the attempt, which is left as it was.
+func (d *WebhookDispatcher) attempt(ctx context.Context, notification *data.Notification) bool {
+	var payload webhookNotification
+	err := json.Unmarshal([]byte(notification.Payload), &payload)
+	permanent := err != nil
+	if err == nil {
+		target := webhookTarget{url: payload.URL, secret: d.globalSecret}
+		if !payload.Global {
+			target = webhookTarget{url: payload.URL, guarded: true, tenantID: notification.TenantID, interviewID: payload.InterviewID}
+		}
+		err = d.deliver(ctx, target, payload.Event, payload.Body)
+		permanent = errors.Is(err, errWebhookTargetBlocked)
+	}
+	if err != nil && ctx.Err() != nil {
+		return false
+	}
+
+	notification.Attempts++
+	switch {
+	case err == nil:
+		notification.Status = data.NotificationStatusDelivered
+		notification.LastError = ""
+	case permanent || notification.Attempts >= d.maxAttempts:
+		notification.Status = data.NotificationStatusFailed
+		notification.LastError = err.Error()
+		utils.Errorf("Giving up on %s webhook for interview %s after %d attempts: %v", payload.Event, payload.InterviewID, notification.Attempts, err)
+	default:
+		notification.NextAttemptAt = d.now().Add(d.retryDelay(notification.Attempts))
+		notification.LastError = err.Error()
+		utils.Warningf("Failed to deliver %s webhook for interview %s (attempt %d, retrying at %s): %v",
+			payload.Event, payload.InterviewID, notification.Attempts, notification.NextAttemptAt.Format(time.RFC3339), err)
+	}
+	// Not bound to ctx: a delivery made during shutdown is still recorded
+	if err := d.outbox().UpdateNotification(notification); err != nil {
+		utils.Errorf("Failed to record webhook notification %s: %v", notification.ID, err)
+	}
+	return true
+}
+
+// interviewSecret returns the current webhook secret of the interview a per-interview delivery is for
+func (d *WebhookDispatcher) interviewSecret(ctx context.Context, tenantID, interviewID string) (string, error) {
+	interview, err := d.outbox().WithContext(data.WithTenant(ctx, tenantID)).GetInterview(interviewID)
+	if err != nil {
+		return "", fmt.Errorf("load interview %s for its webhook secret: %w", interviewID, err)
+	}
+	return interview.NotifySecret, nil
+}
+
+// retryDelay returns the wait after a notification's attempts-th failure: retryBackoff, doubled
+// after each further failure and capped at maxWebhookRetryBackoff
+func (d *WebhookDispatcher) retryDelay(attempts int) time.Duration {
+	delay := d.retryBackoff
+	for i := 1; i < attempts && delay < maxWebhookRetryBackoff; i++ {
+		delay *= 2
+	}
+	return min(delay, maxWebhookRetryBackoff)
+}
+
+// deliver posts one payload, signing it when the target has a secret; a per-interview endpoint is
+// signed with its interview's current secret
+func (d *WebhookDispatcher) deliver(ctx context.Context, target webhookTarget, event string, body []byte) error {
+	ctx, cancel := context.WithTimeout(ctx, webhookTimeout)
+	defer cancel()
+
+	client := d.client
+	if target.guarded {
+		if err := validateWebhookURL(target.url, d.allowedHosts); err != nil {
+			return fmt.Errorf("%w: %v", errWebhookTargetBlocked, err)
+		}
+		secret, err := d.interviewSecret(ctx, target.tenantID, target.interviewID)
+		if err != nil {
+			return err
+		}
+		target.secret = secret
+		client = d.guardedClient
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, target.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Webhook-Event", event)
+	if target.secret != "" {
+		req.Header.Set(webhookSignatureHeader, signWebhookPayload(target.secret, body))
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer discardResponse(resp.Body, d.maxResponseBytes)
+	if resp.StatusCode >= 300 && resp.StatusCode < 400 {
+		return fmt.Errorf("endpoint redirected (%d); webhook redirects are not followed", resp.StatusCode)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("endpoint responded %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// discardResponse reads off at most limit bytes of an ignored response body, so the connection can be
+// reused, and closes it; the connection of a longer body is dropped instead of reading it all
+func discardResponse(body io.ReadCloser, limit int) {
+	_, _ = io.Copy(io.Discard, io.LimitReader(body, int64(limit)))
+	body.Close()
+}
+
+// guardedDialContext connects deliveries to per-interview endpoints. Unless allowlisted, the host is
+// resolved here and the checked address is the one dialed, so a host can't pass the guard and then
+// resolve elsewhere.
+func (d *WebhookDispatcher) guardedDialContext(ctx context.Context, network, addr string) (net.Conn, error) {
+	var dialer net.Dialer
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return nil, err
+	}
+	if isAllowedWebhookHost(host, d.allowedHosts) {
+		return dialer.DialContext(ctx, network, addr)
+	}
+	ips, err := d.webhookAddresses(ctx, host)
+	if err != nil {
+		return nil, err
+	}
+	var dialErr error
+	for _, ip := range ips {
+		conn, err := dialer.DialContext(ctx, network, net.JoinHostPort(ip.String(), port))
+		if err == nil {
+			return conn, nil
+		}
+		dialErr = err
+	}
+	return nil, dialErr
+}
+
+// webhookAddresses resolves the host of a per-interview endpoint, refusing it when any of its
+// addresses is blocked
+func (d *WebhookDispatcher) webhookAddresses(ctx context.Context, host string) ([]net.IP, error) {
+	if ip := net.ParseIP(host); ip != nil {
+		if isBlockedWebhookIP(ip) {
+			return nil, fmt.Errorf("%w: address %s is not allowed", errWebhookTargetBlocked, ip)
+		}
+		return []net.IP{ip}, nil
+	}
+	ips, err := d.lookupIP(ctx, host)
+	if err != nil {
+		return nil, fmt.Errorf("resolve webhook host: %w", err)
+	}
+	if len(ips) == 0 {
+		return nil, fmt.Errorf("resolve webhook host: no addresses for %s", host)
+	}
+	for _, ip := range ips {
+		if isBlockedWebhookIP(ip) {
+			return nil, fmt.Errorf("%w: host %s resolves to blocked address %s", errWebhookTargetBlocked, host, ip)
+		}
+	}
+	return ips, nil
+}
+
+// signWebhookPayload returns the signature header value for body
+func signWebhookPayload(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// StartWebhookWorker delivers the webhook notifications queued in deps.Store until ctx is done and
+// returns the worker's dispatcher, whose Wait blocks until it has stopped
+func StartWebhookWorker(ctx context.Context, deps *HandlerDependencies) *WebhookDispatcher {
+	deps.Webhooks.Start(ctx)
+	return deps.Webhooks
+}
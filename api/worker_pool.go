@@ -0,0 +1,48 @@
+// Shared pool running background jobs outside of the request that queued them
+package api
+
+import (
+	"context"
+	"sync"
+)
+
+// WorkerPool runs background jobs, at most size of them at once. Jobs get a context that is
+// canceled by Stop, and must return promptly once it is; Stop waits for them.
+type WorkerPool struct {
+	slots  chan struct{}
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewWorkerPool creates a pool running up to size jobs at once; sizes below 1 run one at a time
+func NewWorkerPool(size int) *WorkerPool {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &WorkerPool{slots: make(chan struct{}, max(size, 1)), ctx: ctx, cancel: cancel}
+}
+
+// Submit queues job to run once a worker is free. The job's context keeps ctx's values, such as
+// the tenant, but not its cancellation, so jobs outlive the request that queued them. A job whose
+// pool stops before a worker is free still runs, with its context already canceled, so it can
+// record that it did not happen.
+func (p *WorkerPool) Submit(ctx context.Context, job func(ctx context.Context)) {
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+		jobCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+		defer cancel()
+		defer context.AfterFunc(p.ctx, cancel)()
+		select {
+		case p.slots <- struct{}{}:
+			defer func() { <-p.slots }()
+		case <-jobCtx.Done():
+		}
+		job(jobCtx)
+	}()
+}
+
+// Stop cancels queued and running jobs and waits for them to return
+func (p *WorkerPool) Stop() {
+	p.cancel()
+	p.wg.Wait()
+}
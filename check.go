@@ -0,0 +1,124 @@
+// Startup self-test for deploy pipelines (--check): validates config, store and AI client without serving
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/zidane0000/ai-interview-platform/ai"
+	"github.com/zidane0000/ai-interview-platform/config"
+	"github.com/zidane0000/ai-interview-platform/data"
+)
+
+// CheckResult is the outcome of checking one component
+type CheckResult struct {
+	Component string `json:"component"`
+	OK        bool   `json:"ok"`
+	Detail    string `json:"detail"`
+}
+
+// Report is the outcome of a --check run; OK only when every component passed
+type Report struct {
+	OK     bool          `json:"ok"`
+	Checks []CheckResult `json:"checks"`
+}
+
+// add records a component check; a non-nil err fails it and the report
+func (r *Report) add(component string, err error, detail string) {
+	if err != nil {
+		r.Checks = append(r.Checks, CheckResult{Component: component, Detail: err.Error()})
+		r.OK = false
+		return
+	}
+	r.Checks = append(r.Checks, CheckResult{Component: component, OK: true, Detail: detail})
+}
+
+// runCheckCommand loads the config, checks every component and writes the report to w as JSON
+// Returns the process exit code: 0 when every check passed, 1 otherwise
+func runCheckCommand(w io.Writer, checkAI bool) int {
+	report := Report{OK: true}
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		report.add("config", err, "")
+	} else {
+		report = runChecks(cfg, checkAI)
+		report.Checks = append([]CheckResult{{Component: "config", OK: true, Detail: "loaded"}}, report.Checks...)
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(report); err != nil || !report.OK {
+		return 1
+	}
+	return 0
+}
+
+// runChecks initializes the store and checks its health, then builds the AI client from the
+// server-side keys; with checkAI the client's provider credentials are validated as well
+// The store is opened on its own and closed again, leaving data.GlobalStore untouched.
+func runChecks(cfg *config.Config, checkAI bool) Report {
+	report := Report{OK: true}
+	report.add("store", checkStore(cfg), storeDetail(cfg))
+
+	aiCfg := checkAIConfig(cfg)
+	aiCfg.ValidateOnStartup = checkAI
+	detail := fmt.Sprintf("%s client built", aiCfg.DefaultProvider)
+	if checkAI {
+		detail = fmt.Sprintf("%s credentials valid", aiCfg.DefaultProvider)
+	}
+	_, err := ai.NewAIClient(aiCfg)
+	report.add("ai", err, detail)
+	return report
+}
+
+// checkStore opens the configured store, runs its health check and closes it
+func checkStore(cfg *config.Config) error {
+	store, err := data.NewHybridStoreWithReplica(storeBackend(cfg), cfg.DatabaseURL, cfg.DatabaseReplicaURL)
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+	return store.Health()
+}
+
+// storeBackend is the backend cfg selects: the database when DATABASE_URL is set, memory otherwise
+func storeBackend(cfg *config.Config) data.StoreBackend {
+	if cfg.DatabaseURL != "" {
+		return data.BackendDatabase
+	}
+	return data.BackendMemory
+}
+
+// storeDetail describes the backend a passing store check used
+func storeDetail(cfg *config.Config) string {
+	if cfg.DatabaseURL != "" {
+		return "database backend healthy"
+	}
+	return "memory backend"
+}
+
+// checkAIConfig is the AI client config for the server-side keys, preferring OpenAI over Gemini
+// like request keys do; without keys the mock provider is used
+func checkAIConfig(cfg *config.Config) *ai.AIConfig {
+	aiCfg := &ai.AIConfig{
+		OpenAIAPIKey:          cfg.OpenAIAPIKey,
+		GeminiAPIKey:          cfg.GeminiAPIKey,
+		OpenAIBaseURL:         cfg.OpenAIBaseURL,
+		ProviderDefaultModels: cfg.AIProviderDefaultModels,
+		ModelPrices:           cfg.AIModelPrices,
+		CostPerToken:          cfg.AIDefaultCostPerToken,
+		DefaultProvider:       ai.ProviderMock,
+		RequestTimeout:        10 * time.Second, // Credential checks use their own, shorter timeout
+		DefaultMaxTokens:      1000,
+	}
+	switch {
+	case cfg.OpenAIAPIKey != "":
+		aiCfg.DefaultProvider = ai.ProviderOpenAI
+	case cfg.GeminiAPIKey != "":
+		aiCfg.DefaultProvider = ai.ProviderGemini
+	}
+	aiCfg.DefaultModel = cfg.AIProviderDefaultModels[aiCfg.DefaultProvider]
+	return aiCfg
+}
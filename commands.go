@@ -0,0 +1,374 @@
+// Subcommands for one-off operational tasks (migrate, purge, export, import, check, seed) run from
+// the same binary as the server, e.g. in a job container
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/zidane0000/ai-interview-platform/config"
+	"github.com/zidane0000/ai-interview-platform/data"
+	"github.com/zidane0000/ai-interview-platform/data/backup"
+	"github.com/zidane0000/ai-interview-platform/data/seed"
+	"github.com/zidane0000/ai-interview-platform/utils"
+)
+
+// Process exit codes
+const (
+	exitOK      = 0 // The command succeeded
+	exitFailure = 1 // The command ran and failed
+	exitUsage   = 2 // The command line was invalid
+)
+
+// command is a subcommand of the binary; run gets the arguments after the command name and
+// returns the process exit code
+type command struct {
+	name    string
+	summary string
+	run     func(args []string, stdout, stderr io.Writer) int
+}
+
+// commands are the subcommands by name; serve is the default
+var commands = map[string]*command{
+	"serve":   {name: "serve", summary: "run the HTTP server (default)", run: serve},
+	"migrate": {name: "migrate", summary: "apply database migrations: migrate up|down", run: migrateCommand},
+	"purge":   {name: "purge", summary: "delete chat transcripts: purge --older-than=90d [--dry-run]", run: purgeCommand},
+	"export":  {name: "export", summary: "write the store to a JSON backup: export --out=backup.json", run: exportCommand},
+	"import":  {name: "import", summary: "load a JSON backup into the store: import --in=backup.json", run: importCommand},
+	"check":   {name: "check", summary: "check config, store and AI client: check [--ai]", run: checkCommand},
+	"seed":    {name: "seed", summary: "seed the store with a dataset: seed demo [--force]", run: seedCommand},
+}
+
+// openCommandStore opens the store a command works on; tests replace it
+var openCommandStore = func(cfg *config.Config) (*data.HybridStore, error) {
+	return data.NewHybridStore(storeBackend(cfg), cfg.DatabaseURL)
+}
+
+// dispatch picks the command for args and returns it with its own arguments
+// No arguments, or flags only, run serve with those flags, as the binary did before it had subcommands.
+func dispatch(args []string) (*command, []string, error) {
+	if len(args) == 0 || strings.HasPrefix(args[0], "-") {
+		return commands["serve"], args, nil
+	}
+	cmd, ok := commands[args[0]]
+	if !ok {
+		return nil, nil, fmt.Errorf("unknown command %q", args[0])
+	}
+	return cmd, args[1:], nil
+}
+
+// runCLI runs the command args select and returns the process exit code
+// Every command but serve logs to stderr and writes its result to stdout as JSON.
+func runCLI(args []string, stdout, stderr io.Writer) int {
+	if len(args) > 0 && args[0] == "help" {
+		printUsage(stdout)
+		return exitOK
+	}
+	cmd, rest, err := dispatch(args)
+	if err != nil {
+		fmt.Fprintf(stderr, "%v\n", err)
+		printUsage(stderr)
+		return exitUsage
+	}
+	if cmd.name != "serve" {
+		utils.InfoWriter = stderr
+		utils.ErrorWriter = stderr
+	}
+	return cmd.run(rest, stdout, stderr)
+}
+
+// printUsage lists the commands
+func printUsage(w io.Writer) {
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	fmt.Fprintf(w, "Usage: %s [command] [flags]\n\nCommands:\n", os.Args[0])
+	for _, name := range names {
+		fmt.Fprintf(w, "  %-8s %s\n", name, commands[name].summary)
+	}
+}
+
+// parseFlags parses args into flags, allowing flags after positional arguments (seed demo --force)
+// Returns the exit code and false when the command should stop: 0 for -h, 2 for invalid flags.
+func parseFlags(flags *flag.FlagSet, args []string) (int, bool) {
+	var positional []string
+	for {
+		if err := flags.Parse(args); err != nil {
+			if errors.Is(err, flag.ErrHelp) {
+				return exitOK, false
+			}
+			return exitUsage, false
+		}
+		rest := flags.Args()
+		if len(rest) == 0 {
+			break
+		}
+		// Everything after an explicit "--" is positional
+		if consumed := len(args) - len(rest); consumed > 0 && args[consumed-1] == "--" {
+			positional = append(positional, rest...)
+			break
+		}
+		positional = append(positional, rest[0])
+		args = rest[1:]
+	}
+	// Leave the positional arguments where flags.Args() reports them
+	_ = flags.Parse(append([]string{"--"}, positional...))
+	return exitOK, true
+}
+
+// usageError reports an invalid command line and returns the usage exit code
+func usageError(flags *flag.FlagSet, format string, args ...interface{}) int {
+	fmt.Fprintf(flags.Output(), format+"\n", args...)
+	flags.Usage()
+	return exitUsage
+}
+
+// writeResult writes a command's result to w as indented JSON
+func writeResult(w io.Writer, result interface{}) int {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(result); err != nil {
+		utils.Errorf("failed to write result: %v", err)
+		return exitFailure
+	}
+	return exitOK
+}
+
+// withStore loads the config, opens its store, runs fn and closes the store again
+func withStore(fn func(cfg *config.Config, store data.Store) int) int {
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		utils.Errorf("failed to load config: %v", err)
+		return exitFailure
+	}
+	store, err := openCommandStore(cfg)
+	if err != nil {
+		utils.Errorf("failed to initialize store: %v", err)
+		return exitFailure
+	}
+	defer func() {
+		if err := store.Close(); err != nil {
+			utils.Errorf("failed to close store: %v", err)
+		}
+	}()
+	return fn(cfg, store)
+}
+
+// checkCommand runs the startup self-test, like serve --check
+func checkCommand(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("check", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	checkAI := flags.Bool("ai", false, "also validate the AI provider credentials")
+	if code, ok := parseFlags(flags, args); !ok {
+		return code
+	}
+	if flags.NArg() > 0 {
+		return usageError(flags, "unexpected arguments: %s", strings.Join(flags.Args(), " "))
+	}
+	return runCheckCommand(stdout, *checkAI)
+}
+
+// migrateCommand applies the database migrations the server runs on startup
+// There are no down migrations: migrate down fails rather than pretending to roll back.
+func migrateCommand(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("migrate", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	if code, ok := parseFlags(flags, args); !ok {
+		return code
+	}
+	if flags.NArg() != 1 {
+		return usageError(flags, "usage: migrate up|down")
+	}
+	switch flags.Arg(0) {
+	case "up":
+	case "down":
+		utils.Errorf("migrate down is not supported: migrations only add tables, columns and indexes; restore a database backup to roll back")
+		return exitFailure
+	default:
+		return usageError(flags, "unknown migration direction %q (expected up or down)", flags.Arg(0))
+	}
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		utils.Errorf("failed to load config: %v", err)
+		return exitFailure
+	}
+	if cfg.DatabaseURL == "" {
+		utils.Errorf("migrate needs DATABASE_URL; the memory backend has nothing to migrate")
+		return exitFailure
+	}
+	// Opening the database runs the migrations and backfills
+	utils.Infof("Migrating database...")
+	db, err := data.InitDB(cfg.DatabaseURL)
+	if err != nil {
+		utils.Errorf("failed to migrate database: %v", err)
+		return exitFailure
+	}
+	data.CloseDB(db)
+	return writeResult(stdout, map[string]string{"direction": "up", "status": "migrated"})
+}
+
+// PurgeResult is the outcome of a purge run
+type PurgeResult struct {
+	Cutoff   time.Time `json:"cutoff"`
+	DryRun   bool      `json:"dry_run"`
+	Messages int       `json:"messages"`
+}
+
+// purgeCommand deletes the messages of finished chat sessions older than --older-than, like the
+// retention worker; with --dry-run it only counts them
+func purgeCommand(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("purge", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	olderThan := flags.String("older-than", "", "age of the messages to delete, in days (90d) or as a duration (72h)")
+	dryRun := flags.Bool("dry-run", false, "count the messages that would be deleted without deleting them")
+	if code, ok := parseFlags(flags, args); !ok {
+		return code
+	}
+	if flags.NArg() > 0 {
+		return usageError(flags, "unexpected arguments: %s", strings.Join(flags.Args(), " "))
+	}
+	age, err := parseAge(*olderThan)
+	if err != nil {
+		return usageError(flags, "invalid --older-than: %v", err)
+	}
+
+	return withStore(func(_ *config.Config, store data.Store) int {
+		result := PurgeResult{Cutoff: time.Now().Add(-age).UTC(), DryRun: *dryRun}
+		if *dryRun {
+			result.Messages, err = store.CountMessagesOlderThan(result.Cutoff)
+		} else {
+			result.Messages, err = store.PurgeMessagesOlderThan(result.Cutoff)
+		}
+		if err != nil {
+			utils.Errorf("failed to purge messages: %v", err)
+			return exitFailure
+		}
+		utils.Infof("Purge before %s: %d messages (dry run: %t)", result.Cutoff.Format(time.RFC3339), result.Messages, result.DryRun)
+		return writeResult(stdout, result)
+	})
+}
+
+// parseAge parses a positive age given in days ("90d") or as a Go duration ("72h")
+func parseAge(value string) (time.Duration, error) {
+	if value == "" {
+		return 0, errors.New("required")
+	}
+	var age time.Duration
+	if days, ok := strings.CutSuffix(value, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil {
+			return 0, fmt.Errorf("%q is not a number of days", value)
+		}
+		age = time.Duration(n) * 24 * time.Hour
+	} else {
+		var err error
+		if age, err = time.ParseDuration(value); err != nil {
+			return 0, err
+		}
+	}
+	if age <= 0 {
+		return 0, fmt.Errorf("%q must be positive", value)
+	}
+	return age, nil
+}
+
+// exportCommand writes the store to a JSON backup file
+func exportCommand(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("export", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	out := flags.String("out", "", "file to write the backup to")
+	if code, ok := parseFlags(flags, args); !ok {
+		return code
+	}
+	if *out == "" || flags.NArg() > 0 {
+		return usageError(flags, "usage: export --out=backup.json")
+	}
+
+	return withStore(func(_ *config.Config, store data.Store) int {
+		file, err := os.Create(*out)
+		if err != nil {
+			utils.Errorf("failed to create %s: %v", *out, err)
+			return exitFailure
+		}
+		summary, err := backup.Export(store, file, time.Now())
+		if closeErr := file.Close(); err == nil && closeErr != nil {
+			err = fmt.Errorf("failed to write %s: %w", *out, closeErr)
+		}
+		if err != nil {
+			utils.Errorf("failed to export: %v", err)
+			return exitFailure
+		}
+		utils.Infof("Exported %d interviews, %d chat sessions, %d messages, %d evaluations to %s",
+			summary.Interviews, summary.ChatSessions, summary.ChatMessages, summary.Evaluations, *out)
+		return writeResult(stdout, summary)
+	})
+}
+
+// importCommand loads a JSON backup written by export into the store
+func importCommand(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("import", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	in := flags.String("in", "", "backup file to read")
+	if code, ok := parseFlags(flags, args); !ok {
+		return code
+	}
+	if *in == "" || flags.NArg() > 0 {
+		return usageError(flags, "usage: import --in=backup.json")
+	}
+
+	return withStore(func(_ *config.Config, store data.Store) int {
+		file, err := os.Open(*in)
+		if err != nil {
+			utils.Errorf("failed to open %s: %v", *in, err)
+			return exitFailure
+		}
+		defer file.Close()
+		summary, err := backup.Import(store, file)
+		if err != nil {
+			utils.Errorf("failed to import: %v", err)
+			return exitFailure
+		}
+		utils.Infof("Imported %d interviews, %d chat sessions, %d messages, %d evaluations from %s",
+			summary.Interviews, summary.ChatSessions, summary.ChatMessages, summary.Evaluations, *in)
+		return writeResult(stdout, summary)
+	})
+}
+
+// seedCommand seeds the store with a dataset, like serve --seed but without serving
+func seedCommand(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("seed", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	force := flags.Bool("force", false, "seed even when the store is not empty")
+	if code, ok := parseFlags(flags, args); !ok {
+		return code
+	}
+	if flags.NArg() != 1 {
+		return usageError(flags, "usage: seed %s [--force]", seed.DatasetDemo)
+	}
+
+	return withStore(func(_ *config.Config, store data.Store) int {
+		summary, err := seed.Run(store, flags.Arg(0), seed.Options{Force: *force})
+		if errors.Is(err, seed.ErrStoreNotEmpty) {
+			utils.Errorf("store already has data; use --force to seed anyway")
+			return exitFailure
+		}
+		if err != nil {
+			utils.Errorf("failed to seed %s data: %v", flags.Arg(0), err)
+			return exitFailure
+		}
+		utils.Infof("Seeded %s data: %d interviews, %d chat sessions, %d messages, %d evaluations",
+			flags.Arg(0), summary.Interviews, summary.Sessions, summary.Messages, summary.Evaluations)
+		return writeResult(stdout, summary)
+	})
+}
@@ -0,0 +1,181 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/zidane0000/ai-interview-platform/config"
+	"github.com/zidane0000/ai-interview-platform/data"
+	"github.com/zidane0000/ai-interview-platform/data/backup"
+	"github.com/zidane0000/ai-interview-platform/data/seed"
+	"github.com/zidane0000/ai-interview-platform/utils"
+)
+
+// useCommandStore makes commands run against store for the rest of the test
+func useCommandStore(t *testing.T, store *data.HybridStore) {
+	t.Helper()
+	original, info, errs := openCommandStore, utils.InfoWriter, utils.ErrorWriter
+	openCommandStore = func(*config.Config) (*data.HybridStore, error) { return store, nil }
+	t.Cleanup(func() {
+		openCommandStore, utils.InfoWriter, utils.ErrorWriter = original, info, errs
+	})
+}
+
+func TestDispatch(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		expected string
+		rest     []string
+	}{
+		{"no arguments serve", nil, "serve", nil},
+		{"legacy flags serve", []string{"--check", "--check-ai"}, "serve", []string{"--check", "--check-ai"}},
+		{"explicit serve", []string{"serve", "--seed=demo"}, "serve", []string{"--seed=demo"}},
+		{"subcommand", []string{"purge", "--older-than=90d", "--dry-run"}, "purge", []string{"--older-than=90d", "--dry-run"}},
+		{"positional argument", []string{"seed", "demo"}, "seed", []string{"demo"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd, rest, err := dispatch(tt.args)
+			if err != nil {
+				t.Fatalf("dispatch failed: %v", err)
+			}
+			if cmd.name != tt.expected || len(rest) != len(tt.rest) {
+				t.Fatalf("expected %s with %v, got %s with %v", tt.expected, tt.rest, cmd.name, rest)
+			}
+			for i := range rest {
+				if rest[i] != tt.rest[i] {
+					t.Errorf("expected arguments %v, got %v", tt.rest, rest)
+				}
+			}
+		})
+	}
+
+	if _, _, err := dispatch([]string{"backup"}); err == nil {
+		t.Error("expected an error for an unknown command")
+	}
+}
+
+func TestRunCLI_UsageErrors(t *testing.T) {
+	useCommandStore(t, data.NewHybridStoreWithMemory(data.NewMemoryStore()))
+	tests := []struct {
+		name     string
+		args     []string
+		expected int
+	}{
+		{"unknown command", []string{"backup"}, exitUsage},
+		{"unknown flag", []string{"check", "--verbose"}, exitUsage},
+		{"help", []string{"export", "-h"}, exitOK},
+		{"export without --out", []string{"export"}, exitUsage},
+		{"import without --in", []string{"import"}, exitUsage},
+		{"purge without --older-than", []string{"purge"}, exitUsage},
+		{"purge with an invalid age", []string{"purge", "--older-than=soon"}, exitUsage},
+		{"purge with a negative age", []string{"purge", "--older-than=-3d"}, exitUsage},
+		{"migrate without a direction", []string{"migrate"}, exitUsage},
+		{"migrate sideways", []string{"migrate", "sideways"}, exitUsage},
+		{"migrate down", []string{"migrate", "down"}, exitFailure},
+		{"seed without a dataset", []string{"seed"}, exitUsage},
+		{"seed an unknown dataset", []string{"seed", "production"}, exitFailure},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			if code := runCLI(tt.args, &stdout, &stderr); code != tt.expected {
+				t.Errorf("expected exit code %d, got %d: %s", tt.expected, code, stderr.String())
+			}
+			if stdout.Len() != 0 {
+				t.Errorf("expected nothing on stdout, got %q", stdout.String())
+			}
+		})
+	}
+}
+
+func TestRunCLI_Check(t *testing.T) {
+	useCommandStore(t, data.NewHybridStoreWithMemory(data.NewMemoryStore()))
+	var stdout, stderr bytes.Buffer
+	if code := runCLI([]string{"check"}, &stdout, &stderr); code != exitOK {
+		t.Fatalf("expected exit code 0, got %d: %s%s", code, stdout.String(), stderr.String())
+	}
+	var report Report
+	if err := json.Unmarshal(stdout.Bytes(), &report); err != nil {
+		t.Fatalf("expected a JSON report on stdout, got %q", stdout.String())
+	}
+	if !report.OK || len(report.Checks) != 3 {
+		t.Errorf("expected three passing checks, got %+v", report)
+	}
+}
+
+func TestRunCLI_ExportImport(t *testing.T) {
+	store := data.NewHybridStoreWithMemory(data.NewMemoryStore())
+	if _, err := seed.Run(store, seed.DatasetDemo, seed.Options{}); err != nil {
+		t.Fatalf("seed failed: %v", err)
+	}
+	useCommandStore(t, store)
+	path := filepath.Join(t.TempDir(), "backup.json")
+
+	var stdout, stderr bytes.Buffer
+	if code := runCLI([]string{"export", "--out=" + path}, &stdout, &stderr); code != exitOK {
+		t.Fatalf("expected exit code 0, got %d: %s", code, stderr.String())
+	}
+	var summary backup.Summary
+	if err := json.Unmarshal(stdout.Bytes(), &summary); err != nil {
+		t.Fatalf("expected a JSON summary on stdout, got %q", stdout.String())
+	}
+	expected := backup.Summary{Interviews: 4, ChatSessions: 2, ChatMessages: 12, Evaluations: 2}
+	if summary != expected {
+		t.Errorf("expected %+v exported, got %+v", expected, summary)
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read the backup: %v", err)
+	}
+	var written backup.Backup
+	if err := json.Unmarshal(content, &written); err != nil || written.Version != backup.FormatVersion || len(written.Interviews) != 4 {
+		t.Fatalf("expected a version %d backup of 4 interviews, got %d with %d (%v)", backup.FormatVersion, written.Version, len(written.Interviews), err)
+	}
+
+	// Importing into an empty store restores everything
+	useCommandStore(t, data.NewHybridStoreWithMemory(data.NewMemoryStore()))
+	stdout.Reset()
+	if code := runCLI([]string{"import", "--in", path}, &stdout, &stderr); code != exitOK {
+		t.Fatalf("expected exit code 0, got %d: %s", code, stderr.String())
+	}
+	summary = backup.Summary{}
+	if err := json.Unmarshal(stdout.Bytes(), &summary); err != nil || summary != expected {
+		t.Errorf("expected %+v imported, got %+v (%v)", expected, summary, err)
+	}
+}
+
+func TestRunCLI_Purge(t *testing.T) {
+	store := data.NewHybridStoreWithMemory(data.NewMemoryStore())
+	if _, err := seed.Run(store, seed.DatasetDemo, seed.Options{Now: time.Now().Add(-200 * 24 * time.Hour)}); err != nil {
+		t.Fatalf("seed failed: %v", err)
+	}
+	useCommandStore(t, store)
+
+	purge := func(args ...string) PurgeResult {
+		t.Helper()
+		var stdout, stderr bytes.Buffer
+		if code := runCLI(append([]string{"purge"}, args...), &stdout, &stderr); code != exitOK {
+			t.Fatalf("expected exit code 0, got %d: %s", code, stderr.String())
+		}
+		var result PurgeResult
+		if err := json.Unmarshal(stdout.Bytes(), &result); err != nil {
+			t.Fatalf("expected a JSON result on stdout, got %q", stdout.String())
+		}
+		return result
+	}
+	if result := purge("--older-than=90d", "--dry-run"); !result.DryRun || result.Messages != 12 {
+		t.Errorf("expected a dry run counting 12 messages, got %+v", result)
+	}
+	if result := purge("--older-than=90d"); result.DryRun || result.Messages != 12 {
+		t.Errorf("expected 12 messages purged, got %+v", result)
+	}
+	if result := purge("--dry-run", "--older-than", "2160h"); result.Messages != 0 {
+		t.Errorf("expected nothing left to purge, got %+v", result)
+	}
+}
@@ -2,13 +2,95 @@
 package config
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
 	"os"
+	"regexp"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/joho/godotenv"
+	"github.com/zidane0000/ai-interview-platform/ai"
+	"github.com/zidane0000/ai-interview-platform/data"
 	"github.com/zidane0000/ai-interview-platform/utils"
 )
 
+// Default chat message limits (in characters)
+const (
+	DefaultMaxMessageLength        = 8000
+	DefaultMessageSummaryThreshold = 4000
+)
+
+// DefaultMaxMessagesPerSession caps the messages stored per chat session; reaching it completes the session
+const DefaultMaxMessagesPerSession = 2000
+
+// MinMaxMessagesPerSession is the smallest usable cap: the greeting, one answer and the closing reply
+const MinMaxMessagesPerSession = 3
+
+// DefaultMaxAIAttemptsPerSession caps the AI provider calls made for one chat session, retries included
+const DefaultMaxAIAttemptsPerSession = 1000
+
+// DefaultTurnBudget bounds a chat turn end to end, kept under the server's 30s WriteTimeout so a slow
+// turn still gets its degraded response out
+const DefaultTurnBudget = 25 * time.Second
+
+// Default rolling summarization settings for long chat sessions (in conversation turns)
+const (
+	DefaultSummaryThresholdTurns = 12
+	DefaultSummaryRecentTurns    = 6
+)
+
+// Default interview question limits
+const (
+	DefaultMaxQuestionLength = 1000 // characters
+	DefaultMaxQuestionCount  = 50
+)
+
+// MaxQuestionAlternatives caps AI_QUESTION_ALTERNATIVES, the outputs asked of the AI per question generation
+const MaxQuestionAlternatives = 5
+
+// Default job description limits (in characters)
+const (
+	DefaultJobDescriptionSoftLimit = 4000  // Longer job descriptions are summarized for prompts
+	DefaultJobDescriptionHardLimit = 20000 // Longer job descriptions are rejected
+)
+
+// Default evaluation backfill settings (POST /api/admin/evaluations/backfill)
+const (
+	DefaultBackfillWorkers        = 4
+	DefaultBackfillSessionTimeout = 2 * time.Minute
+)
+
+// DefaultBackgroundWorkers is how many background jobs, such as question generation, run at once
+const DefaultBackgroundWorkers = 4
+
+// Default idle chat session expiry settings
+const (
+	DefaultSessionIdleTimeout     = 30 * time.Minute
+	DefaultSessionJanitorInterval = time.Minute
+)
+
+// DefaultRetentionInterval is how often the retention worker deletes expired data
+const DefaultRetentionInterval = time.Hour
+
+// Default webhook outbox settings
+const (
+	DefaultWebhookMaxAttempts  = 5
+	DefaultWebhookRetryBackoff = 30 * time.Second // Doubles after each failed attempt
+	DefaultWebhookPollInterval = 2 * time.Second
+)
+
+// DefaultReopenWindow is how long after completion a chat session may be reopened
+const DefaultReopenWindow = time.Hour
+
+// Default list endpoint page sizes
+const (
+	DefaultPageSize    = 10
+	DefaultMaxPageSize = 100
+)
+
 // Config holds all application configuration
 type Config struct {
 	// Server configuration
@@ -16,11 +98,132 @@ type Config struct {
 	ShutdownTimeout time.Duration
 
 	// Database configuration
-	DatabaseURL string
+	DatabaseURL        string
+	DatabaseReplicaURL string // Optional read replica; reads go there unless a request needs the primary
 
 	// AI service configuration
-	GeminiAPIKey string
-	OpenAIAPIKey string
+	GeminiAPIKey  string
+	OpenAIAPIKey  string
+	OpenAIBaseURL string // OpenAI-compatible endpoint for OpenAIAPIKey; empty means api.openai.com
+
+	// Default model per AI provider (AI_OPENAI_DEFAULT_MODEL, AI_GEMINI_DEFAULT_MODEL, ...)
+	AIProviderDefaultModels map[string]string
+
+	// Chat temperature, max tokens and model per interview type, from the JSON in
+	// AI_INTERVIEW_TYPE_PARAMS or the file AI_INTERVIEW_TYPE_PARAMS_FILE; evaluations use only the model
+	AIInterviewTypeParams map[string]ai.GenerationParams
+
+	// AI cost estimation
+	AIModelPrices         map[string]ai.ModelPrice // Overrides ai.DefaultModelPrices per model
+	AIDefaultCostPerToken float64                  // USD per token for models without a price
+
+	// Retired AI model names rewritten to their replacement; extends ai.DefaultModelAliases
+	AIModelAliases map[string]ai.ModelAlias
+
+	// AI debug capture: keeps redacted provider request/response pairs for GET /api/admin/ai/debug
+	AIDebugCapture     bool
+	AIDebugCaptureSize int // Exchanges kept per provider
+
+	// PII redaction of AI provider payloads
+	AIRedactPII      bool
+	AIRedactPatterns []ai.RedactPattern // Custom patterns redacted in addition to emails, phone numbers and national IDs
+
+	// Cap on concurrent AI provider calls; calls queued longer than AIQueueTimeout get 503
+	AIMaxConcurrentRequests int
+	AIQueueTimeout          time.Duration
+
+	// Admin and debug endpoints
+	AdminToken           string // Bearer token required by /api/admin routes; admin routes are refused when empty
+	EnableDebugEndpoints bool   // Mounts debug endpoints under /api/admin
+
+	// Maintenance mode: the API serves reads but refuses writes with 503 (READ_ONLY)
+	// Toggled at runtime with POST /api/admin/readonly or by reloading READ_ONLY on SIGHUP
+	ReadOnly bool
+
+	// Multi-tenancy: API key (X-API-Key header) to the tenant whose data it may access
+	// Empty disables multi-tenancy and every request shares the default tenant
+	TenantAPIKeys map[string]string
+
+	// Chat configuration (limits are in characters)
+	MaxMessageLength        int // Hard limit - longer candidate messages are rejected
+	MessageSummaryThreshold int // Soft limit - longer messages are summarized before entering AI context
+	SummaryThresholdTurns   int // Once the history exceeds this many turns, earlier turns are folded into a running summary
+	SummaryRecentTurns      int // Turns kept verbatim alongside the running summary
+	MaxMessagesPerSession   int // Messages stored per session (all types); reaching it completes the session
+	MaxAIAttemptsPerSession int // AI provider calls per session, retries included; exceeding it completes the session, 0 disables the cap
+
+	// Time a chat turn may take end to end, split between its stages; once spent the turn returns the
+	// AI error response instead of waiting on the provider. 0 disables the budget
+	TurnBudget time.Duration
+
+	// How chat sessions open unless the start request chooses: "ai", "template" or "none" (see data.GreetingMode*)
+	GreetingMode string
+
+	// Anti-abuse checks on candidate messages, run before any AI call; 0 disables each
+	// Callers with an API key or the admin token skip them
+	MinMessageInterval         time.Duration // Shortest time between a candidate's messages
+	DuplicateMessageSimilarity float64       // Messages at least this similar (0-1) to one of the candidate's last three are refused
+	MaxMessagesPerSessionHour  int           // Candidate messages per session in any hour
+
+	// Interview question limits
+	MaxQuestionLength int // Maximum characters per question
+	MaxQuestionCount  int // Maximum number of questions per interview
+
+	// Outputs asked of the AI when generating questions, merged without repeats; 1 asks for one
+	QuestionAlternatives int
+
+	// Job description limits (characters)
+	JobDescriptionSoftLimit int // Longer job descriptions are summarized once by the AI and the summary used in prompts
+	JobDescriptionHardLimit int // Longer job descriptions are rejected at interview creation
+
+	// Evaluation feedback length in words; longer feedback is cut at a sentence boundary
+	// 0 uses the detail level's default (100 brief, 300 standard, 600 detailed)
+	MaxFeedbackWords int
+
+	// Answers shorter than this many words count as effectively unanswered in evaluations
+	MinAnswerWords int
+
+	// Languages evaluation feedback is also translated into when a request doesn't choose its own
+	AdditionalFeedbackLanguages []string
+
+	// Pagination configuration for list endpoints
+	DefaultPageSize int // Page size used when no limit is requested
+	MaxPageSize     int // Requested limits above this are clamped
+
+	// Interview scheduling
+	InterviewGraceMinutes int // Minutes after scheduled_end during which a chat session may still start
+
+	// Idle chat session expiry
+	SessionIdleTimeout     time.Duration // Active sessions without a message or heartbeat for this long are abandoned; 0 disables expiry
+	SessionJanitorInterval time.Duration // How often idle sessions are looked for
+
+	// Reopening completed chat sessions (POST /api/chat/{sessionId}/reopen)
+	ReopenWindow time.Duration // Sessions completed longer ago than this can't be reopened
+
+	// Data retention
+	TranscriptRetentionDays int           // Messages of ended sessions are deleted after this many days, keeping the session and its evaluation; 0 keeps them
+	RetentionInterval       time.Duration // How often the retention worker runs
+
+	// Database maintenance
+	MaintenanceInterval time.Duration // How often hot tables are vacuumed and analyzed; 0 disables it
+
+	// Evaluation backfill
+	BackfillWorkers        int           // Sessions evaluated concurrently by the backfill and calibration runs
+	BackfillSessionTimeout time.Duration // Time allowed to evaluate one session
+
+	// Background jobs
+	BackgroundWorkers int // Jobs run at once by the shared worker pool, e.g. question generation
+
+	// Webhook notifications
+	WebhookURL          string        // Global endpoint receiving every event; trusted, so not subject to the SSRF guard
+	WebhookSecret       string        // Signs deliveries to WebhookURL
+	WebhookAllowedHosts []string      // Hosts per-interview webhooks may target even when they resolve to private addresses
+	WebhookMaxAttempts  int           // Delivery attempts before a notification is marked failed
+	WebhookRetryBackoff time.Duration // Delay before the first retry; doubles after each failure
+	WebhookPollInterval time.Duration // How often the outbox worker looks for due notifications
+
+	// Largest response body read from AI providers and webhook endpoints; longer bodies are abandoned
+	UpstreamMaxResponseBytes int
 
 	// TODO: Add more AI providers
 	// TODO: Add file upload configuration
@@ -36,11 +239,143 @@ func LoadConfig() (*Config, error) {
 	_ = godotenv.Load()
 
 	cfg := &Config{
-		DatabaseURL:     os.Getenv("DATABASE_URL"),
-		Port:            utils.GetEnvString("PORT", "8080"),
-		GeminiAPIKey:    os.Getenv("GEMINI_API_KEY"),
-		OpenAIAPIKey:    os.Getenv("OPENAI_API_KEY"),
-		ShutdownTimeout: utils.GetEnvDuration("SHUTDOWN_TIMEOUT", 30*time.Second),
+		DatabaseURL:        os.Getenv("DATABASE_URL"),
+		DatabaseReplicaURL: os.Getenv("DATABASE_REPLICA_URL"),
+		Port:               utils.GetEnvString("PORT", "8080"),
+		GeminiAPIKey:       os.Getenv("GEMINI_API_KEY"),
+		OpenAIAPIKey:       os.Getenv("OPENAI_API_KEY"),
+		OpenAIBaseURL:      os.Getenv("OPENAI_BASE_URL"),
+		ShutdownTimeout:    utils.GetEnvDuration("SHUTDOWN_TIMEOUT", 30*time.Second),
+
+		MaxMessageLength:        utils.GetEnvInt("CHAT_MAX_MESSAGE_LENGTH", DefaultMaxMessageLength),
+		MessageSummaryThreshold: utils.GetEnvInt("CHAT_MESSAGE_SUMMARY_THRESHOLD", DefaultMessageSummaryThreshold),
+		SummaryThresholdTurns:   utils.GetEnvInt("CHAT_SUMMARY_THRESHOLD_TURNS", DefaultSummaryThresholdTurns),
+		SummaryRecentTurns:      utils.GetEnvInt("CHAT_SUMMARY_RECENT_TURNS", DefaultSummaryRecentTurns),
+		MaxMessagesPerSession:   utils.GetEnvInt("CHAT_MAX_MESSAGES_PER_SESSION", DefaultMaxMessagesPerSession),
+		MaxAIAttemptsPerSession: utils.GetEnvInt("CHAT_MAX_AI_ATTEMPTS_PER_SESSION", DefaultMaxAIAttemptsPerSession),
+
+		TurnBudget:   utils.GetEnvDuration("CHAT_TURN_BUDGET", DefaultTurnBudget),
+		GreetingMode: utils.GetEnvString("CHAT_GREETING_MODE", data.GreetingModeAI),
+
+		MinMessageInterval:         utils.GetEnvDuration("CHAT_MIN_MESSAGE_INTERVAL", 0),
+		DuplicateMessageSimilarity: utils.GetEnvFloat64("CHAT_DUPLICATE_MESSAGE_SIMILARITY", 0),
+		MaxMessagesPerSessionHour:  utils.GetEnvInt("CHAT_MAX_MESSAGES_PER_SESSION_HOUR", 0),
+
+		MaxQuestionLength: utils.GetEnvInt("INTERVIEW_MAX_QUESTION_LENGTH", DefaultMaxQuestionLength),
+		MaxQuestionCount:  utils.GetEnvInt("INTERVIEW_MAX_QUESTION_COUNT", DefaultMaxQuestionCount),
+
+		QuestionAlternatives: utils.GetEnvInt("AI_QUESTION_ALTERNATIVES", 1),
+
+		JobDescriptionSoftLimit: utils.GetEnvInt("INTERVIEW_JOB_DESCRIPTION_SOFT_LIMIT", DefaultJobDescriptionSoftLimit),
+		JobDescriptionHardLimit: utils.GetEnvInt("INTERVIEW_JOB_DESCRIPTION_HARD_LIMIT", DefaultJobDescriptionHardLimit),
+
+		MaxFeedbackWords: utils.GetEnvInt("EVALUATION_MAX_FEEDBACK_WORDS", 0),
+		MinAnswerWords:   utils.GetEnvInt("EVALUATION_MIN_ANSWER_WORDS", ai.DefaultMinAnswerWords),
+
+		AdditionalFeedbackLanguages: ParseList(os.Getenv("EVALUATION_ADDITIONAL_FEEDBACK_LANGUAGES")),
+
+		DefaultPageSize: utils.GetEnvInt("DEFAULT_PAGE_SIZE", DefaultPageSize),
+		MaxPageSize:     utils.GetEnvInt("MAX_PAGE_SIZE", DefaultMaxPageSize),
+
+		InterviewGraceMinutes: utils.GetEnvInt("INTERVIEW_GRACE_MINUTES", 0),
+
+		SessionIdleTimeout:     utils.GetEnvDuration("CHAT_SESSION_IDLE_TIMEOUT", DefaultSessionIdleTimeout),
+		SessionJanitorInterval: utils.GetEnvDuration("CHAT_SESSION_JANITOR_INTERVAL", DefaultSessionJanitorInterval),
+
+		ReopenWindow: utils.GetEnvDuration("CHAT_REOPEN_WINDOW", DefaultReopenWindow),
+
+		TranscriptRetentionDays: utils.GetEnvInt("TRANSCRIPT_RETENTION_DAYS", 0),
+		RetentionInterval:       utils.GetEnvDuration("RETENTION_INTERVAL", DefaultRetentionInterval),
+
+		MaintenanceInterval: utils.GetEnvDuration("DB_MAINTENANCE_INTERVAL", 0),
+
+		BackfillWorkers:        utils.GetEnvInt("EVALUATION_BACKFILL_WORKERS", DefaultBackfillWorkers),
+		BackfillSessionTimeout: utils.GetEnvDuration("EVALUATION_BACKFILL_TIMEOUT", DefaultBackfillSessionTimeout),
+
+		BackgroundWorkers: utils.GetEnvInt("BACKGROUND_WORKERS", DefaultBackgroundWorkers),
+
+		AIProviderDefaultModels: ai.ProviderDefaultModelsFromEnv(),
+
+		AIModelPrices:         ParseModelPrices(os.Getenv("AI_MODEL_PRICES")),
+		AIDefaultCostPerToken: utils.GetEnvFloat64("AI_DEFAULT_COST_PER_TOKEN", 0),
+		AIModelAliases:        ParseModelAliases(os.Getenv("AI_MODEL_ALIASES")),
+
+		AIDebugCapture:     utils.GetEnvBool("AI_DEBUG_CAPTURE", false),
+		AIDebugCaptureSize: utils.GetEnvInt("AI_DEBUG_CAPTURE_SIZE", ai.DefaultDebugCaptureSize),
+
+		AIRedactPII:      utils.GetEnvBool("AI_REDACT_PII", false),
+		AIRedactPatterns: ParseRedactPatterns(os.Getenv("AI_REDACT_PATTERNS")),
+
+		AIMaxConcurrentRequests: utils.GetEnvInt("AI_MAX_CONCURRENT_REQUESTS", ai.DefaultMaxConcurrentRequests),
+		AIQueueTimeout:          utils.GetEnvDuration("AI_QUEUE_TIMEOUT", ai.DefaultQueueTimeout),
+
+		AdminToken:           os.Getenv("ADMIN_API_TOKEN"),
+		EnableDebugEndpoints: utils.GetEnvBool("ENABLE_DEBUG_ENDPOINTS", false),
+
+		ReadOnly: utils.GetEnvBool("READ_ONLY", false),
+
+		TenantAPIKeys: ParseTenantAPIKeys(os.Getenv("TENANT_API_KEYS")),
+
+		WebhookURL:          os.Getenv("WEBHOOK_URL"),
+		WebhookSecret:       os.Getenv("WEBHOOK_SECRET"),
+		WebhookAllowedHosts: ParseList(os.Getenv("WEBHOOK_ALLOWED_HOSTS")),
+		WebhookMaxAttempts:  utils.GetEnvInt("WEBHOOK_MAX_ATTEMPTS", DefaultWebhookMaxAttempts),
+		WebhookRetryBackoff: utils.GetEnvDuration("WEBHOOK_RETRY_BACKOFF", DefaultWebhookRetryBackoff),
+		WebhookPollInterval: utils.GetEnvDuration("WEBHOOK_POLL_INTERVAL", DefaultWebhookPollInterval),
+
+		UpstreamMaxResponseBytes: utils.GetEnvInt("UPSTREAM_MAX_RESPONSE_BYTES", ai.DefaultMaxResponseBytes),
+	}
+
+	for _, warning := range ai.CheckProviderDefaultModels(cfg.AIProviderDefaultModels, cfg.AIModelAliases) {
+		utils.Warningf("AI provider defaults: %s", warning)
+	}
+	// Every problem is reported at once so a deploy doesn't fail on them one by one
+	var problems []error
+	if cfg.MaxMessagesPerSession < MinMaxMessagesPerSession {
+		problems = append(problems, fmt.Errorf("CHAT_MAX_MESSAGES_PER_SESSION must be at least %d, got %d", MinMaxMessagesPerSession, cfg.MaxMessagesPerSession))
+	}
+	if cfg.JobDescriptionHardLimit > 0 && cfg.JobDescriptionSoftLimit > cfg.JobDescriptionHardLimit {
+		problems = append(problems, fmt.Errorf("INTERVIEW_JOB_DESCRIPTION_SOFT_LIMIT (%d) must not exceed INTERVIEW_JOB_DESCRIPTION_HARD_LIMIT (%d)", cfg.JobDescriptionSoftLimit, cfg.JobDescriptionHardLimit))
+	}
+	if cfg.QuestionAlternatives < 1 || cfg.QuestionAlternatives > MaxQuestionAlternatives {
+		problems = append(problems, fmt.Errorf("AI_QUESTION_ALTERNATIVES must be between 1 and %d, got %d", MaxQuestionAlternatives, cfg.QuestionAlternatives))
+	}
+	typeParams, err := LoadInterviewTypeParams(os.Getenv("AI_INTERVIEW_TYPE_PARAMS"), os.Getenv("AI_INTERVIEW_TYPE_PARAMS_FILE"))
+	if err != nil {
+		problems = append(problems, err)
+	}
+	cfg.AIInterviewTypeParams = typeParams
+	if cfg.UpstreamMaxResponseBytes <= 0 {
+		problems = append(problems, fmt.Errorf("UPSTREAM_MAX_RESPONSE_BYTES must be positive, got %d", cfg.UpstreamMaxResponseBytes))
+	}
+	if cfg.DuplicateMessageSimilarity < 0 || cfg.DuplicateMessageSimilarity > 1 {
+		problems = append(problems, fmt.Errorf("CHAT_DUPLICATE_MESSAGE_SIMILARITY must be between 0 and 1, got %g", cfg.DuplicateMessageSimilarity))
+	}
+	if cfg.MinMessageInterval < 0 || cfg.MaxMessagesPerSessionHour < 0 {
+		problems = append(problems, fmt.Errorf("CHAT_MIN_MESSAGE_INTERVAL and CHAT_MAX_MESSAGES_PER_SESSION_HOUR must not be negative, got %v and %d", cfg.MinMessageInterval, cfg.MaxMessagesPerSessionHour))
+	}
+	if cfg.TranscriptRetentionDays < 0 {
+		problems = append(problems, fmt.Errorf("TRANSCRIPT_RETENTION_DAYS must not be negative, got %d", cfg.TranscriptRetentionDays))
+	}
+	if cfg.MaintenanceInterval < 0 {
+		problems = append(problems, fmt.Errorf("DB_MAINTENANCE_INTERVAL must not be negative, got %v", cfg.MaintenanceInterval))
+	}
+	if cfg.TurnBudget < 0 {
+		problems = append(problems, fmt.Errorf("CHAT_TURN_BUDGET must not be negative, got %v", cfg.TurnBudget))
+	}
+	if !data.ValidateGreetingMode(cfg.GreetingMode) {
+		problems = append(problems, fmt.Errorf("CHAT_GREETING_MODE must be ai, template or none, got %q", cfg.GreetingMode))
+	}
+	if cfg.MinAnswerWords < 0 {
+		problems = append(problems, fmt.Errorf("EVALUATION_MIN_ANSWER_WORDS must not be negative, got %d", cfg.MinAnswerWords))
+	}
+	for _, language := range cfg.AdditionalFeedbackLanguages {
+		if !data.ValidateLanguage(language) {
+			problems = append(problems, fmt.Errorf("EVALUATION_ADDITIONAL_FEEDBACK_LANGUAGES: unsupported language %q", language))
+		}
+	}
+	if err := errors.Join(problems...); err != nil {
+		return nil, err
 	}
 
 	// TODO: Load file upload configuration(cfg.UploadPath, cfg.MaxFileSize)
@@ -54,6 +389,154 @@ func LoadConfig() (*Config, error) {
 	return cfg, nil
 }
 
+// ReloadReadOnly returns READ_ONLY as a SIGHUP reload sees it: the value set in the .env file if
+// any, since the environment of a running process can't change, otherwise the environment's
+func ReloadReadOnly() bool {
+	if values, err := godotenv.Read(); err == nil {
+		if value, ok := values["READ_ONLY"]; ok {
+			if readOnly, err := strconv.ParseBool(value); err == nil {
+				return readOnly
+			}
+			utils.Warningf("Ignoring invalid READ_ONLY value %q in .env", value)
+		}
+	}
+	return utils.GetEnvBool("READ_ONLY", false)
+}
+
+// ParseModelPrices parses model prices in the form "model=prompt:completion,...",
+// with prices in USD per million tokens. Malformed entries are logged and skipped.
+func ParseModelPrices(value string) map[string]ai.ModelPrice {
+	prices := make(map[string]ai.ModelPrice)
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		model, rates, ok := strings.Cut(entry, "=")
+		prompt, completion, hasBoth := strings.Cut(rates, ":")
+		promptPrice, promptErr := strconv.ParseFloat(strings.TrimSpace(prompt), 64)
+		completionPrice, completionErr := strconv.ParseFloat(strings.TrimSpace(completion), 64)
+		model = strings.TrimSpace(model)
+		if !ok || !hasBoth || model == "" || promptErr != nil || completionErr != nil || promptPrice < 0 || completionPrice < 0 {
+			utils.Warningf("Ignoring malformed AI_MODEL_PRICES entry %q", entry)
+			continue
+		}
+		prices[model] = ai.ModelPrice{PromptPerMillion: promptPrice, CompletionPerMillion: completionPrice}
+	}
+	return prices
+}
+
+// LoadInterviewTypeParams reads the per-interview-type AI parameters from value, a JSON object such as
+// {"behavioral":{"temperature":0.9,"max_tokens":800},"technical":{"temperature":0.2,"model":"gpt-4o"}},
+// or, when value is empty, from the JSON file at path. Neither set means no per-type parameters.
+func LoadInterviewTypeParams(value, path string) (map[string]ai.GenerationParams, error) {
+	source := "AI_INTERVIEW_TYPE_PARAMS"
+	if value == "" && path != "" {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("AI_INTERVIEW_TYPE_PARAMS_FILE: %w", err)
+		}
+		value, source = string(content), "AI_INTERVIEW_TYPE_PARAMS_FILE"
+	}
+	if strings.TrimSpace(value) == "" {
+		return nil, nil
+	}
+	var params map[string]ai.GenerationParams
+	if err := json.Unmarshal([]byte(value), &params); err != nil {
+		return nil, fmt.Errorf("%s is not valid JSON: %w", source, err)
+	}
+	if err := ai.ValidateInterviewTypeParams(params); err != nil {
+		return nil, fmt.Errorf("%s: %w", source, err)
+	}
+	return params, nil
+}
+
+// ParseModelAliases parses retired model names in the form "old=replacement@YYYY-MM-DD,...";
+// the deprecation date is optional. Malformed entries are logged and skipped.
+func ParseModelAliases(value string) map[string]ai.ModelAlias {
+	aliases := make(map[string]ai.ModelAlias)
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		model, target, ok := strings.Cut(entry, "=")
+		replacement, date, hasDate := strings.Cut(target, "@")
+		model, replacement = strings.TrimSpace(model), strings.TrimSpace(replacement)
+		var deprecatedOn time.Time
+		var dateErr error
+		if hasDate {
+			deprecatedOn, dateErr = time.Parse(time.DateOnly, strings.TrimSpace(date))
+		}
+		if !ok || model == "" || replacement == "" || model == replacement || dateErr != nil {
+			utils.Warningf("Ignoring malformed AI_MODEL_ALIASES entry %q", entry)
+			continue
+		}
+		aliases[model] = ai.ModelAlias{Replacement: replacement, DeprecatedOn: deprecatedOn}
+	}
+	return aliases
+}
+
+// ParseTenantAPIKeys parses API keys in the form "key=tenant,...". Malformed entries are logged and skipped.
+func ParseTenantAPIKeys(value string) map[string]string {
+	keys := make(map[string]string)
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		key, tenant, ok := strings.Cut(entry, "=")
+		key, tenant = strings.TrimSpace(key), strings.TrimSpace(tenant)
+		if !ok || key == "" || tenant == "" {
+			// The key is a secret, so only the tenant side is logged
+			utils.Warningf("Ignoring malformed TENANT_API_KEYS entry for tenant %q", tenant)
+			continue
+		}
+		keys[key] = tenant
+	}
+	return keys
+}
+
+// ParseRedactPatterns parses custom PII patterns in the form "TYPE=regex;...". Entries are
+// separated by semicolons because regular expressions often contain commas. TYPE names the
+// placeholder (e.g. EMPLOYEE_ID gives [EMPLOYEE_ID_1]). Malformed entries are logged and skipped.
+func ParseRedactPatterns(value string) []ai.RedactPattern {
+	var patterns []ai.RedactPattern
+	for _, entry := range strings.Split(value, ";") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		piiType, expr, ok := strings.Cut(entry, "=")
+		piiType, expr = strings.TrimSpace(piiType), strings.TrimSpace(expr)
+		if !ok || !redactTypePattern.MatchString(piiType) || expr == "" {
+			utils.Warningf("Ignoring malformed AI_REDACT_PATTERNS entry %q", entry)
+			continue
+		}
+		pattern, err := regexp.Compile(expr)
+		if err != nil {
+			utils.Warningf("Ignoring AI_REDACT_PATTERNS entry %q: %v", entry, err)
+			continue
+		}
+		patterns = append(patterns, ai.RedactPattern{Type: piiType, Pattern: pattern})
+	}
+	return patterns
+}
+
+// ParseList splits a comma-separated value, trimming entries and dropping empty ones
+func ParseList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// redactTypePattern restricts custom PII type names to placeholder-safe upper case identifiers
+var redactTypePattern = regexp.MustCompile(`^[A-Z][A-Z0-9_]*$`)
+
 // TODO: Add configuration for different environments (dev, staging, prod)
 // TODO: Add configuration documentation and examples
 // TODO: Add configuration schema validation
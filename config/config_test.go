@@ -2,9 +2,15 @@ package config_test
 
 import (
 	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/zidane0000/ai-interview-platform/ai"
 	"github.com/zidane0000/ai-interview-platform/config"
+	"github.com/zidane0000/ai-interview-platform/data"
 )
 
 func TestLoadConfig(t *testing.T) {
@@ -58,3 +64,579 @@ func TestLoadConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestParseModelPrices(t *testing.T) {
+	prices := config.ParseModelPrices("gpt-4=1:2, llama-3-70b = 0.9:0.9,bad,missing=1,negative=-1:2,=1:1")
+	if len(prices) != 2 {
+		t.Fatalf("expected 2 valid prices, got %d: %v", len(prices), prices)
+	}
+	if p := prices["gpt-4"]; p.PromptPerMillion != 1 || p.CompletionPerMillion != 2 {
+		t.Errorf("expected gpt-4 price 1/2, got %+v", p)
+	}
+	if p := prices["llama-3-70b"]; p.PromptPerMillion != 0.9 || p.CompletionPerMillion != 0.9 {
+		t.Errorf("expected llama-3-70b price 0.9/0.9, got %+v", p)
+	}
+	if len(config.ParseModelPrices("")) != 0 {
+		t.Error("expected no prices for an empty value")
+	}
+}
+
+func TestParseModelAliases(t *testing.T) {
+	aliases := config.ParseModelAliases("in-house-v1=gpt-4o@2025-03-01, gpt-4 = gpt-4o ,bad,same=same,=gpt-4o,dated=gpt-4o@March")
+	if len(aliases) != 2 {
+		t.Fatalf("expected 2 valid aliases, got %d: %v", len(aliases), aliases)
+	}
+	if a := aliases["in-house-v1"]; a.Replacement != "gpt-4o" || a.DeprecatedOn.Format(time.DateOnly) != "2025-03-01" {
+		t.Errorf("expected in-house-v1 to be replaced by gpt-4o from 2025-03-01, got %+v", a)
+	}
+	if a := aliases["gpt-4"]; a.Replacement != "gpt-4o" || !a.DeprecatedOn.IsZero() {
+		t.Errorf("expected gpt-4 to be replaced by gpt-4o without a date, got %+v", a)
+	}
+	if len(config.ParseModelAliases("")) != 0 {
+		t.Error("expected no aliases for an empty value")
+	}
+}
+
+func TestParseTenantAPIKeys(t *testing.T) {
+	keys := config.ParseTenantAPIKeys("key-a=acme, key-b = globex ,bad,=acme,key-c=")
+	if len(keys) != 2 || keys["key-a"] != "acme" || keys["key-b"] != "globex" {
+		t.Errorf("expected key-a and key-b mapped to acme and globex, got %v", keys)
+	}
+	if len(config.ParseTenantAPIKeys("")) != 0 {
+		t.Error("expected no keys for an empty value")
+	}
+}
+
+func TestParseRedactPatterns(t *testing.T) {
+	patterns := config.ParseRedactPatterns(`EMPLOYEE_ID=EMP-\d{4,6}; BADGE = B\d{3} ;lower=x;MISSING;BROKEN=(`)
+	if len(patterns) != 2 {
+		t.Fatalf("expected 2 valid patterns, got %d: %v", len(patterns), patterns)
+	}
+	if patterns[0].Type != "EMPLOYEE_ID" || !patterns[0].Pattern.MatchString("EMP-12345") {
+		t.Errorf("expected EMPLOYEE_ID pattern with a comma quantifier, got %+v", patterns[0])
+	}
+	if patterns[1].Type != "BADGE" || !patterns[1].Pattern.MatchString("B123") {
+		t.Errorf("expected BADGE pattern, got %+v", patterns[1])
+	}
+	if len(config.ParseRedactPatterns("")) != 0 {
+		t.Error("expected no patterns for an empty value")
+	}
+}
+
+func TestLoadConfig_ModelPrices(t *testing.T) {
+	os.Setenv("AI_MODEL_PRICES", "gpt-4=1:2")
+	os.Setenv("AI_DEFAULT_COST_PER_TOKEN", "0.00001")
+	defer os.Unsetenv("AI_MODEL_PRICES")
+	defer os.Unsetenv("AI_DEFAULT_COST_PER_TOKEN")
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if p := cfg.AIModelPrices["gpt-4"]; p.PromptPerMillion != 1 || p.CompletionPerMillion != 2 {
+		t.Errorf("expected gpt-4 override 1/2, got %+v", p)
+	}
+	if cfg.AIDefaultCostPerToken != 0.00001 {
+		t.Errorf("expected default cost per token 0.00001, got %v", cfg.AIDefaultCostPerToken)
+	}
+}
+
+func TestLoadConfig_MaxMessagesPerSession(t *testing.T) {
+	defer os.Unsetenv("CHAT_MAX_MESSAGES_PER_SESSION")
+
+	for _, value := range []string{"0", "2"} {
+		os.Setenv("CHAT_MAX_MESSAGES_PER_SESSION", value)
+		if _, err := config.LoadConfig(); err == nil {
+			t.Errorf("expected a cap of %s messages to be rejected", value)
+		}
+	}
+
+	os.Setenv("CHAT_MAX_MESSAGES_PER_SESSION", "3")
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.MaxMessagesPerSession != 3 {
+		t.Errorf("expected a cap of 3 messages, got %d", cfg.MaxMessagesPerSession)
+	}
+}
+
+func TestLoadConfig_ProviderDefaultModels(t *testing.T) {
+	os.Setenv("AI_OPENAI_DEFAULT_MODEL", "gpt-4-turbo")
+	os.Setenv("AI_GEMINI_DEFAULT_MODEL", "gemini-1.5-pro")
+	defer os.Unsetenv("AI_OPENAI_DEFAULT_MODEL")
+	defer os.Unsetenv("AI_GEMINI_DEFAULT_MODEL")
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := cfg.AIProviderDefaultModels["openai"]; got != "gpt-4-turbo" {
+		t.Errorf("expected openai default gpt-4-turbo, got %q", got)
+	}
+	if got := cfg.AIProviderDefaultModels["gemini"]; got != "gemini-1.5-pro" {
+		t.Errorf("expected gemini default gemini-1.5-pro, got %q", got)
+	}
+	if _, ok := cfg.AIProviderDefaultModels["mock"]; ok {
+		t.Error("expected no mock default without AI_MOCK_DEFAULT_MODEL")
+	}
+}
+
+func TestLoadConfig_Backfill(t *testing.T) {
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.BackfillWorkers != config.DefaultBackfillWorkers || cfg.BackfillSessionTimeout != config.DefaultBackfillSessionTimeout {
+		t.Errorf("expected backfill defaults, got %d workers and %v", cfg.BackfillWorkers, cfg.BackfillSessionTimeout)
+	}
+
+	os.Setenv("EVALUATION_BACKFILL_WORKERS", "8")
+	os.Setenv("EVALUATION_BACKFILL_TIMEOUT", "30s")
+	defer os.Unsetenv("EVALUATION_BACKFILL_WORKERS")
+	defer os.Unsetenv("EVALUATION_BACKFILL_TIMEOUT")
+	cfg, err = config.LoadConfig()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.BackfillWorkers != 8 || cfg.BackfillSessionTimeout != 30*time.Second {
+		t.Errorf("expected 8 workers and 30s, got %d and %v", cfg.BackfillWorkers, cfg.BackfillSessionTimeout)
+	}
+}
+
+func TestLoadConfig_BackgroundWorkers(t *testing.T) {
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.BackgroundWorkers != config.DefaultBackgroundWorkers {
+		t.Errorf("expected %d background workers, got %d", config.DefaultBackgroundWorkers, cfg.BackgroundWorkers)
+	}
+
+	os.Setenv("BACKGROUND_WORKERS", "2")
+	defer os.Unsetenv("BACKGROUND_WORKERS")
+	if cfg, err = config.LoadConfig(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.BackgroundWorkers != 2 {
+		t.Errorf("expected 2 background workers, got %d", cfg.BackgroundWorkers)
+	}
+}
+
+func TestLoadConfig_TranscriptRetention(t *testing.T) {
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.TranscriptRetentionDays != 0 || cfg.RetentionInterval != config.DefaultRetentionInterval {
+		t.Errorf("expected transcripts kept and the default interval, got %d days every %v", cfg.TranscriptRetentionDays, cfg.RetentionInterval)
+	}
+
+	os.Setenv("TRANSCRIPT_RETENTION_DAYS", "30")
+	defer os.Unsetenv("TRANSCRIPT_RETENTION_DAYS")
+	if cfg, err = config.LoadConfig(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.TranscriptRetentionDays != 30 {
+		t.Errorf("expected 30 days of transcript retention, got %d", cfg.TranscriptRetentionDays)
+	}
+
+	os.Setenv("TRANSCRIPT_RETENTION_DAYS", "-1")
+	if _, err := config.LoadConfig(); err == nil || !strings.Contains(err.Error(), "TRANSCRIPT_RETENTION_DAYS") {
+		t.Errorf("expected a negative retention to be rejected, got %v", err)
+	}
+}
+
+func TestLoadConfig_MaintenanceInterval(t *testing.T) {
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.MaintenanceInterval != 0 {
+		t.Errorf("expected maintenance disabled by default, got %v", cfg.MaintenanceInterval)
+	}
+
+	os.Setenv("DB_MAINTENANCE_INTERVAL", "6h")
+	defer os.Unsetenv("DB_MAINTENANCE_INTERVAL")
+	if cfg, err = config.LoadConfig(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.MaintenanceInterval != 6*time.Hour {
+		t.Errorf("expected maintenance every 6h, got %v", cfg.MaintenanceInterval)
+	}
+
+	os.Setenv("DB_MAINTENANCE_INTERVAL", "-1h")
+	if _, err := config.LoadConfig(); err == nil || !strings.Contains(err.Error(), "DB_MAINTENANCE_INTERVAL") {
+		t.Errorf("expected a negative interval to be rejected, got %v", err)
+	}
+}
+
+func TestLoadConfig_AdditionalFeedbackLanguages(t *testing.T) {
+	os.Setenv("EVALUATION_ADDITIONAL_FEEDBACK_LANGUAGES", "zh-TW, en")
+	defer os.Unsetenv("EVALUATION_ADDITIONAL_FEEDBACK_LANGUAGES")
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(cfg.AdditionalFeedbackLanguages, []string{"zh-TW", "en"}) {
+		t.Errorf("expected zh-TW and en, got %v", cfg.AdditionalFeedbackLanguages)
+	}
+
+	os.Setenv("EVALUATION_ADDITIONAL_FEEDBACK_LANGUAGES", "fr")
+	if _, err := config.LoadConfig(); err == nil || !strings.Contains(err.Error(), "EVALUATION_ADDITIONAL_FEEDBACK_LANGUAGES") {
+		t.Errorf("expected an unsupported language to be rejected, got %v", err)
+	}
+}
+
+func TestLoadConfig_Webhooks(t *testing.T) {
+	os.Setenv("WEBHOOK_URL", "https://hooks.example.com/all")
+	os.Setenv("WEBHOOK_ALLOWED_HOSTS", " hooks.internal , ,10.0.0.5")
+	defer os.Unsetenv("WEBHOOK_URL")
+	defer os.Unsetenv("WEBHOOK_ALLOWED_HOSTS")
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.WebhookURL != "https://hooks.example.com/all" {
+		t.Errorf("expected the global webhook URL, got %q", cfg.WebhookURL)
+	}
+	if want := []string{"hooks.internal", "10.0.0.5"}; !reflect.DeepEqual(cfg.WebhookAllowedHosts, want) {
+		t.Errorf("expected allowed hosts %v, got %v", want, cfg.WebhookAllowedHosts)
+	}
+	if cfg.WebhookMaxAttempts != config.DefaultWebhookMaxAttempts || cfg.WebhookRetryBackoff != config.DefaultWebhookRetryBackoff {
+		t.Errorf("expected the default retry policy, got %d attempts and %v", cfg.WebhookMaxAttempts, cfg.WebhookRetryBackoff)
+	}
+
+	os.Setenv("WEBHOOK_MAX_ATTEMPTS", "8")
+	os.Setenv("WEBHOOK_RETRY_BACKOFF", "1m")
+	os.Setenv("WEBHOOK_POLL_INTERVAL", "500ms")
+	defer os.Unsetenv("WEBHOOK_MAX_ATTEMPTS")
+	defer os.Unsetenv("WEBHOOK_RETRY_BACKOFF")
+	defer os.Unsetenv("WEBHOOK_POLL_INTERVAL")
+	cfg, err = config.LoadConfig()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.WebhookMaxAttempts != 8 || cfg.WebhookRetryBackoff != time.Minute || cfg.WebhookPollInterval != 500*time.Millisecond {
+		t.Errorf("expected 8 attempts, a 1m backoff and a 500ms poll, got %d, %v and %v", cfg.WebhookMaxAttempts, cfg.WebhookRetryBackoff, cfg.WebhookPollInterval)
+	}
+}
+
+func TestLoadConfig_SessionIdleTimeout(t *testing.T) {
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.SessionIdleTimeout != config.DefaultSessionIdleTimeout || cfg.SessionJanitorInterval != config.DefaultSessionJanitorInterval {
+		t.Errorf("expected default idle timeout and janitor interval, got %v and %v", cfg.SessionIdleTimeout, cfg.SessionJanitorInterval)
+	}
+
+	// 0 disables expiry
+	os.Setenv("CHAT_SESSION_IDLE_TIMEOUT", "0")
+	defer os.Unsetenv("CHAT_SESSION_IDLE_TIMEOUT")
+	cfg, err = config.LoadConfig()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.SessionIdleTimeout != 0 {
+		t.Errorf("expected expiry to be disabled, got %v", cfg.SessionIdleTimeout)
+	}
+}
+
+func TestLoadConfig_ReopenWindow(t *testing.T) {
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.ReopenWindow != config.DefaultReopenWindow {
+		t.Errorf("expected the default reopen window, got %v", cfg.ReopenWindow)
+	}
+
+	os.Setenv("CHAT_REOPEN_WINDOW", "15m")
+	defer os.Unsetenv("CHAT_REOPEN_WINDOW")
+	cfg, err = config.LoadConfig()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.ReopenWindow != 15*time.Minute {
+		t.Errorf("expected a 15m reopen window, got %v", cfg.ReopenWindow)
+	}
+}
+
+func TestLoadConfig_AIConcurrency(t *testing.T) {
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.AIMaxConcurrentRequests != ai.DefaultMaxConcurrentRequests || cfg.AIQueueTimeout != ai.DefaultQueueTimeout {
+		t.Errorf("expected the default AI concurrency cap, got %d and %v", cfg.AIMaxConcurrentRequests, cfg.AIQueueTimeout)
+	}
+
+	os.Setenv("AI_MAX_CONCURRENT_REQUESTS", "4")
+	os.Setenv("AI_QUEUE_TIMEOUT", "2s")
+	defer os.Unsetenv("AI_MAX_CONCURRENT_REQUESTS")
+	defer os.Unsetenv("AI_QUEUE_TIMEOUT")
+	cfg, err = config.LoadConfig()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.AIMaxConcurrentRequests != 4 || cfg.AIQueueTimeout != 2*time.Second {
+		t.Errorf("expected 4 concurrent requests and a 2s queue timeout, got %d and %v", cfg.AIMaxConcurrentRequests, cfg.AIQueueTimeout)
+	}
+}
+
+func TestLoadConfig_MaxAIAttemptsPerSession(t *testing.T) {
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.MaxAIAttemptsPerSession != config.DefaultMaxAIAttemptsPerSession {
+		t.Errorf("expected the default attempt cap, got %d", cfg.MaxAIAttemptsPerSession)
+	}
+
+	// 0 disables the cap
+	os.Setenv("CHAT_MAX_AI_ATTEMPTS_PER_SESSION", "0")
+	defer os.Unsetenv("CHAT_MAX_AI_ATTEMPTS_PER_SESSION")
+	cfg, err = config.LoadConfig()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.MaxAIAttemptsPerSession != 0 {
+		t.Errorf("expected the cap to be disabled, got %d", cfg.MaxAIAttemptsPerSession)
+	}
+}
+
+func TestLoadConfig_AntiAbuse(t *testing.T) {
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// The checks are opt-in
+	if cfg.MinMessageInterval != 0 || cfg.DuplicateMessageSimilarity != 0 || cfg.MaxMessagesPerSessionHour != 0 {
+		t.Errorf("expected the checks off by default, got %v, %v and %d", cfg.MinMessageInterval, cfg.DuplicateMessageSimilarity, cfg.MaxMessagesPerSessionHour)
+	}
+
+	os.Setenv("CHAT_MIN_MESSAGE_INTERVAL", "2s")
+	os.Setenv("CHAT_MAX_MESSAGES_PER_SESSION_HOUR", "120")
+	defer os.Unsetenv("CHAT_MIN_MESSAGE_INTERVAL")
+	defer os.Unsetenv("CHAT_MAX_MESSAGES_PER_SESSION_HOUR")
+	cfg, err = config.LoadConfig()
+	if err != nil || cfg.MinMessageInterval != 2*time.Second || cfg.MaxMessagesPerSessionHour != 120 {
+		t.Errorf("expected the configured checks, got %+v (%v)", cfg, err)
+	}
+
+	os.Setenv("CHAT_DUPLICATE_MESSAGE_SIMILARITY", "1.5")
+	defer os.Unsetenv("CHAT_DUPLICATE_MESSAGE_SIMILARITY")
+	if _, err := config.LoadConfig(); err == nil || !strings.Contains(err.Error(), "CHAT_DUPLICATE_MESSAGE_SIMILARITY") {
+		t.Errorf("expected an out-of-range similarity rejected, got %v", err)
+	}
+}
+
+func TestLoadConfig_JobDescriptionLimits(t *testing.T) {
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.JobDescriptionSoftLimit != config.DefaultJobDescriptionSoftLimit || cfg.JobDescriptionHardLimit != config.DefaultJobDescriptionHardLimit {
+		t.Errorf("expected default limits, got %d/%d", cfg.JobDescriptionSoftLimit, cfg.JobDescriptionHardLimit)
+	}
+
+	// The soft limit can't exceed the hard limit
+	os.Setenv("INTERVIEW_JOB_DESCRIPTION_SOFT_LIMIT", "5000")
+	os.Setenv("INTERVIEW_JOB_DESCRIPTION_HARD_LIMIT", "1000")
+	defer os.Unsetenv("INTERVIEW_JOB_DESCRIPTION_SOFT_LIMIT")
+	defer os.Unsetenv("INTERVIEW_JOB_DESCRIPTION_HARD_LIMIT")
+	if _, err := config.LoadConfig(); err == nil {
+		t.Error("expected an error for a soft limit above the hard limit")
+	}
+}
+
+func TestLoadConfig_ReportsEveryProblem(t *testing.T) {
+	os.Setenv("CHAT_MAX_MESSAGES_PER_SESSION", "1")
+	os.Setenv("INTERVIEW_JOB_DESCRIPTION_SOFT_LIMIT", "5000")
+	os.Setenv("INTERVIEW_JOB_DESCRIPTION_HARD_LIMIT", "1000")
+	defer os.Unsetenv("CHAT_MAX_MESSAGES_PER_SESSION")
+	defer os.Unsetenv("INTERVIEW_JOB_DESCRIPTION_SOFT_LIMIT")
+	defer os.Unsetenv("INTERVIEW_JOB_DESCRIPTION_HARD_LIMIT")
+
+	_, err := config.LoadConfig()
+	if err == nil {
+		t.Fatal("expected an error for an invalid config")
+	}
+	for _, variable := range []string{"CHAT_MAX_MESSAGES_PER_SESSION", "INTERVIEW_JOB_DESCRIPTION_SOFT_LIMIT"} {
+		if !strings.Contains(err.Error(), variable) {
+			t.Errorf("expected the error to mention %s, got %v", variable, err)
+		}
+	}
+}
+
+func TestLoadConfig_MaxFeedbackWords(t *testing.T) {
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.MaxFeedbackWords != 0 {
+		t.Errorf("expected detail level defaults by default, got %d", cfg.MaxFeedbackWords)
+	}
+
+	os.Setenv("EVALUATION_MAX_FEEDBACK_WORDS", "150")
+	defer os.Unsetenv("EVALUATION_MAX_FEEDBACK_WORDS")
+	cfg, err = config.LoadConfig()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.MaxFeedbackWords != 150 {
+		t.Errorf("expected 150, got %d", cfg.MaxFeedbackWords)
+	}
+}
+
+func TestLoadConfig_TurnBudget(t *testing.T) {
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.TurnBudget != config.DefaultTurnBudget {
+		t.Errorf("expected %v by default, got %v", config.DefaultTurnBudget, cfg.TurnBudget)
+	}
+
+	defer os.Unsetenv("CHAT_TURN_BUDGET")
+	os.Setenv("CHAT_TURN_BUDGET", "0")
+	if cfg, err = config.LoadConfig(); err != nil || cfg.TurnBudget != 0 {
+		t.Errorf("expected the budget disabled, got %+v, %v", cfg, err)
+	}
+
+	os.Setenv("CHAT_TURN_BUDGET", "-5s")
+	if _, err := config.LoadConfig(); err == nil || !strings.Contains(err.Error(), "CHAT_TURN_BUDGET") {
+		t.Errorf("expected a negative budget rejected, got %v", err)
+	}
+}
+
+func TestLoadConfig_GreetingMode(t *testing.T) {
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.GreetingMode != data.GreetingModeAI {
+		t.Errorf("expected AI greetings by default, got %q", cfg.GreetingMode)
+	}
+
+	defer os.Unsetenv("CHAT_GREETING_MODE")
+	os.Setenv("CHAT_GREETING_MODE", "template")
+	if cfg, err = config.LoadConfig(); err != nil || cfg.GreetingMode != data.GreetingModeTemplate {
+		t.Errorf("expected template greetings, got %+v, %v", cfg, err)
+	}
+
+	os.Setenv("CHAT_GREETING_MODE", "scripted")
+	if _, err := config.LoadConfig(); err == nil || !strings.Contains(err.Error(), "CHAT_GREETING_MODE") {
+		t.Errorf("expected an unknown mode rejected, got %v", err)
+	}
+}
+
+func TestLoadConfig_MinAnswerWords(t *testing.T) {
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.MinAnswerWords != ai.DefaultMinAnswerWords {
+		t.Errorf("expected %d by default, got %d", ai.DefaultMinAnswerWords, cfg.MinAnswerWords)
+	}
+
+	defer os.Unsetenv("EVALUATION_MIN_ANSWER_WORDS")
+	os.Setenv("EVALUATION_MIN_ANSWER_WORDS", "12")
+	if cfg, err = config.LoadConfig(); err != nil || cfg.MinAnswerWords != 12 {
+		t.Errorf("expected 12, got %+v, %v", cfg, err)
+	}
+
+	os.Setenv("EVALUATION_MIN_ANSWER_WORDS", "-1")
+	if _, err := config.LoadConfig(); err == nil || !strings.Contains(err.Error(), "EVALUATION_MIN_ANSWER_WORDS") {
+		t.Errorf("expected a negative minimum rejected, got %v", err)
+	}
+}
+
+func TestLoadConfig_ReadOnly(t *testing.T) {
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.ReadOnly || config.ReloadReadOnly() {
+		t.Error("expected read-only mode to be off by default")
+	}
+
+	// Without a .env file, a reload reads the environment
+	os.Setenv("READ_ONLY", "true")
+	defer os.Unsetenv("READ_ONLY")
+	cfg, err = config.LoadConfig()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !cfg.ReadOnly || !config.ReloadReadOnly() {
+		t.Error("expected READ_ONLY=true to enable read-only mode")
+	}
+}
+
+func TestLoadConfig_QuestionAlternatives(t *testing.T) {
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.QuestionAlternatives != 1 {
+		t.Errorf("expected one output by default, got %d", cfg.QuestionAlternatives)
+	}
+
+	os.Setenv("AI_QUESTION_ALTERNATIVES", "3")
+	defer os.Unsetenv("AI_QUESTION_ALTERNATIVES")
+	if cfg, err = config.LoadConfig(); err != nil || cfg.QuestionAlternatives != 3 {
+		t.Errorf("expected 3, got %v (%v)", cfg, err)
+	}
+
+	os.Setenv("AI_QUESTION_ALTERNATIVES", "0")
+	if _, err := config.LoadConfig(); err == nil || !strings.Contains(err.Error(), "AI_QUESTION_ALTERNATIVES") {
+		t.Errorf("expected an error for 0 alternatives, got %v", err)
+	}
+}
+
+func TestLoadConfig_InterviewTypeParams(t *testing.T) {
+	cfg, err := config.LoadConfig()
+	if err != nil || cfg.AIInterviewTypeParams != nil {
+		t.Fatalf("expected no per-type parameters by default, got %v (%v)", cfg, err)
+	}
+
+	os.Setenv("AI_INTERVIEW_TYPE_PARAMS", `{"behavioral":{"temperature":0.9,"max_tokens":800},"technical":{"temperature":0,"model":"gpt-4o"}}`)
+	defer os.Unsetenv("AI_INTERVIEW_TYPE_PARAMS")
+	cfg, err = config.LoadConfig()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	behavioral, technical := cfg.AIInterviewTypeParams["behavioral"], cfg.AIInterviewTypeParams["technical"]
+	if behavioral.Temperature == nil || *behavioral.Temperature != 0.9 || behavioral.MaxTokens != 800 {
+		t.Errorf("expected behavioral at 0.9 with 800 tokens, got %+v", behavioral)
+	}
+	if technical.Temperature == nil || *technical.Temperature != 0 || technical.Model != "gpt-4o" {
+		t.Errorf("expected technical at an explicit 0 on gpt-4o, got %+v", technical)
+	}
+
+	// The file is read when the variable is unset
+	path := filepath.Join(t.TempDir(), "params.json")
+	if err := os.WriteFile(path, []byte(`{"general":{"max_tokens":400}}`), 0o600); err != nil {
+		t.Fatalf("failed to write params file: %v", err)
+	}
+	os.Unsetenv("AI_INTERVIEW_TYPE_PARAMS")
+	os.Setenv("AI_INTERVIEW_TYPE_PARAMS_FILE", path)
+	defer os.Unsetenv("AI_INTERVIEW_TYPE_PARAMS_FILE")
+	if cfg, err = config.LoadConfig(); err != nil || cfg.AIInterviewTypeParams["general"].MaxTokens != 400 {
+		t.Errorf("expected general at 400 tokens from the file, got %v (%v)", cfg, err)
+	}
+
+	for _, value := range []string{
+		`not json`,
+		`{"panel":{"temperature":0.5}}`,
+		`{"behavioral":{"temperature":3}}`,
+		`{"technical":{"max_tokens":-1}}`,
+	} {
+		os.Setenv("AI_INTERVIEW_TYPE_PARAMS", value)
+		if _, err := config.LoadConfig(); err == nil || !strings.Contains(err.Error(), "AI_INTERVIEW_TYPE_PARAMS") {
+			t.Errorf("expected an error for %s, got %v", value, err)
+		}
+	}
+}
@@ -0,0 +1,160 @@
+// Package backup exports a store's interviews, chat sessions, messages and evaluations to JSON and
+// imports them again, through the Store interface so it works on either backend
+package backup
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/zidane0000/ai-interview-platform/data"
+)
+
+// FormatVersion is the version of the backup format written by Export
+const FormatVersion = 1
+
+// exportPageSize is how many interviews Export reads at a time
+const exportPageSize = 100
+
+// Backup is the exported content of a store
+// Only each interview's current evaluation is included; superseded evaluations, webhook
+// notifications and calibration runs are not.
+type Backup struct {
+	Version      int                 `json:"version"`
+	ExportedAt   time.Time           `json:"exported_at"`
+	Interviews   []*Interview        `json:"interviews"`
+	ChatSessions []*data.ChatSession `json:"chat_sessions"`
+	ChatMessages []*data.ChatMessage `json:"chat_messages"`
+	Evaluations  []*data.Evaluation  `json:"evaluations"`
+}
+
+// Interview is an exported interview, including the webhook secret the API never returns
+type Interview struct {
+	*data.Interview
+	NotifySecret string `json:"notify_secret,omitempty"`
+}
+
+// Summary counts the records an export wrote or an import created
+type Summary struct {
+	Interviews   int `json:"interviews"`
+	ChatSessions int `json:"chat_sessions"`
+	ChatMessages int `json:"chat_messages"`
+	Evaluations  int `json:"evaluations"`
+}
+
+// Export writes every interview in store with its chat sessions, their messages and its current
+// evaluation to w as JSON
+func Export(store data.Store, w io.Writer, now time.Time) (*Summary, error) {
+	backup := Backup{
+		Version:      FormatVersion,
+		ExportedAt:   now.UTC(),
+		Interviews:   []*Interview{},
+		ChatSessions: []*data.ChatSession{},
+		ChatMessages: []*data.ChatMessage{},
+		Evaluations:  []*data.Evaluation{},
+	}
+	for page := 1; ; page++ {
+		result, err := store.GetInterviewsWithOptions(data.ListInterviewsOptions{Page: page, Limit: exportPageSize})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list interviews: %w", err)
+		}
+		for _, interview := range result.Interviews {
+			if err := backup.add(store, interview); err != nil {
+				return nil, err
+			}
+		}
+		if len(result.Interviews) < exportPageSize {
+			break
+		}
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(backup); err != nil {
+		return nil, fmt.Errorf("failed to write backup: %w", err)
+	}
+	return &Summary{
+		Interviews:   len(backup.Interviews),
+		ChatSessions: len(backup.ChatSessions),
+		ChatMessages: len(backup.ChatMessages),
+		Evaluations:  len(backup.Evaluations),
+	}, nil
+}
+
+// add adds an interview with its sessions, messages and current evaluation to the backup
+func (b *Backup) add(store data.Store, interview *data.Interview) error {
+	b.Interviews = append(b.Interviews, &Interview{Interview: interview, NotifySecret: interview.NotifySecret})
+	sessions, err := store.GetChatSessionsByInterview(interview.ID)
+	if err != nil {
+		return fmt.Errorf("failed to list chat sessions of interview %s: %w", interview.ID, err)
+	}
+	for _, session := range sessions {
+		messages, err := store.GetChatMessages(session.ID)
+		if err != nil {
+			return fmt.Errorf("failed to read messages of chat session %s: %w", session.ID, err)
+		}
+		b.ChatSessions = append(b.ChatSessions, session)
+		b.ChatMessages = append(b.ChatMessages, messages...)
+	}
+	// Stores report an interview without evaluations as an error, like the API's 404
+	evaluation, err := store.GetLatestEvaluationByInterview(interview.ID)
+	if err == nil {
+		b.Evaluations = append(b.Evaluations, evaluation)
+	}
+	return nil
+}
+
+// Import reads a backup written by Export from r and creates its records in store
+// Records whose ID already exists are left as they are, so importing the same backup twice is safe.
+func Import(store data.Store, r io.Reader) (*Summary, error) {
+	var backup Backup
+	if err := json.NewDecoder(r).Decode(&backup); err != nil {
+		return nil, fmt.Errorf("failed to read backup: %w", err)
+	}
+	if backup.Version != FormatVersion {
+		return nil, fmt.Errorf("unsupported backup version %d (expected %d)", backup.Version, FormatVersion)
+	}
+
+	summary := &Summary{}
+	for _, interview := range backup.Interviews {
+		if interview.Interview == nil {
+			continue
+		}
+		interview.Interview.NotifySecret = interview.NotifySecret
+		if err := create(&summary.Interviews, interview.ID, func() error { return store.CreateInterview(interview.Interview) }); err != nil {
+			return summary, err
+		}
+	}
+	for _, session := range backup.ChatSessions {
+		if err := create(&summary.ChatSessions, session.ID, func() error { return store.CreateChatSession(session) }); err != nil {
+			return summary, err
+		}
+	}
+	for _, message := range backup.ChatMessages {
+		if err := create(&summary.ChatMessages, message.ID, func() error { return store.AddChatMessage(message.SessionID, message) }); err != nil {
+			return summary, err
+		}
+	}
+	for _, evaluation := range backup.Evaluations {
+		if err := create(&summary.Evaluations, evaluation.ID, func() error { return store.CreateEvaluation(evaluation) }); err != nil {
+			return summary, err
+		}
+	}
+	return summary, nil
+}
+
+// create runs a create call, counting the record when it was created and skipping it when it already exists
+func create(count *int, id string, fn func() error) error {
+	err := fn()
+	switch {
+	case err == nil:
+		*count++
+		return nil
+	case errors.Is(err, data.ErrAlreadyExists):
+		return nil
+	default:
+		return fmt.Errorf("failed to import %s: %w", id, err)
+	}
+}
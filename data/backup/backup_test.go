@@ -0,0 +1,79 @@
+package backup_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/zidane0000/ai-interview-platform/data"
+	"github.com/zidane0000/ai-interview-platform/data/backup"
+	"github.com/zidane0000/ai-interview-platform/data/seed"
+)
+
+// newMemoryStore returns a store over a fresh memory backend
+func newMemoryStore() data.Store {
+	return data.NewHybridStoreWithMemory(data.NewMemoryStore())
+}
+
+func TestExportImport_RoundTrip(t *testing.T) {
+	source := newMemoryStore()
+	if _, err := seed.Run(source, seed.DatasetDemo, seed.Options{}); err != nil {
+		t.Fatalf("seed failed: %v", err)
+	}
+	interview, err := source.GetInterview(seed.DemoInterviewID)
+	if err != nil {
+		t.Fatalf("GetInterview failed: %v", err)
+	}
+	interview.NotifySecret = "webhook-secret"
+	if err := source.UpdateInterview(interview); err != nil {
+		t.Fatalf("UpdateInterview failed: %v", err)
+	}
+
+	var buf bytes.Buffer
+	exported, err := backup.Export(source, &buf, time.Now())
+	if err != nil {
+		t.Fatalf("Export failed: %v", err)
+	}
+	expected := backup.Summary{Interviews: 4, ChatSessions: 2, ChatMessages: 12, Evaluations: 2}
+	if *exported != expected {
+		t.Errorf("expected %+v exported, got %+v", expected, *exported)
+	}
+	content := buf.String()
+
+	target := newMemoryStore()
+	imported, err := backup.Import(target, strings.NewReader(content))
+	if err != nil {
+		t.Fatalf("Import failed: %v", err)
+	}
+	if *imported != expected {
+		t.Errorf("expected %+v imported, got %+v", expected, *imported)
+	}
+	restored, err := target.GetInterview(seed.DemoInterviewID)
+	if err != nil || restored.NotifySecret != "webhook-secret" {
+		t.Errorf("expected the webhook secret restored, got %+v (%v)", restored, err)
+	}
+	original, _ := source.GetChatMessages(seed.DemoSessionID)
+	messages, err := target.GetChatMessages(seed.DemoSessionID)
+	if err != nil || len(messages) != len(original) {
+		t.Errorf("expected the demo session's %d messages restored, got %d (%v)", len(original), len(messages), err)
+	}
+	if _, err := target.GetEvaluation(seed.DemoEvaluationID); err != nil {
+		t.Errorf("expected the demo evaluation restored: %v", err)
+	}
+
+	// Importing again creates nothing
+	again, err := backup.Import(target, strings.NewReader(content))
+	if err != nil || *again != (backup.Summary{}) {
+		t.Errorf("expected nothing created by a repeated import, got %+v (%v)", again, err)
+	}
+}
+
+func TestImport_RejectsUnknownVersion(t *testing.T) {
+	if _, err := backup.Import(newMemoryStore(), strings.NewReader(`{"version":99}`)); err == nil {
+		t.Error("expected an error for an unknown backup version")
+	}
+	if _, err := backup.Import(newMemoryStore(), strings.NewReader(`not json`)); err == nil {
+		t.Error("expected an error for a malformed backup")
+	}
+}
@@ -0,0 +1,52 @@
+// Evaluation calibration run data access
+package data
+
+import (
+	"errors"
+
+	"gorm.io/gorm"
+)
+
+// CalibrationRunRepository interface defines the contract for calibration run access
+type CalibrationRunRepository interface {
+	Create(run *CalibrationRun) error
+	GetByID(id string) (*CalibrationRun, error)
+}
+
+// calibrationRunRepository implements CalibrationRunRepository
+type calibrationRunRepository struct {
+	db       *gorm.DB
+	tenantID string // Scopes every query to one tenant; empty sees every tenant
+}
+
+// NewCalibrationRunRepository creates a new calibration run repository
+func NewCalibrationRunRepository(db *gorm.DB) CalibrationRunRepository {
+	return newCalibrationRunRepository(db, "")
+}
+
+// newCalibrationRunRepository creates a calibration run repository scoped to tenantID
+func newCalibrationRunRepository(db *gorm.DB, tenantID string) *calibrationRunRepository {
+	return &calibrationRunRepository{db: db, tenantID: tenantID}
+}
+
+// scoped limits query to the repository's tenant
+func (r *calibrationRunRepository) scoped(query *gorm.DB) *gorm.DB {
+	return tenantScope(query, "tenant_id", r.tenantID)
+}
+
+// Create stores a calibration run
+func (r *calibrationRunRepository) Create(run *CalibrationRun) error {
+	stampTenant(r.tenantID, &run.TenantID)
+	stampCreated(r.db.NowFunc(), &run.CreatedAt, &run.UpdatedAt)
+	return r.db.Create(run).Error
+}
+
+// GetByID retrieves a calibration run by ID
+func (r *calibrationRunRepository) GetByID(id string) (*CalibrationRun, error) {
+	var run CalibrationRun
+	err := r.scoped(r.db.Where("id = ?", id)).First(&run).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, errors.New("calibration run not found")
+	}
+	return &run, err
+}
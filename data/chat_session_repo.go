@@ -2,10 +2,12 @@
 package data
 
 import (
+	"encoding/json"
 	"errors"
 	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // ChatSessionFilters defines filter options for chat session queries
@@ -16,39 +18,82 @@ type ChatSessionFilters struct {
 	CreatedBefore time.Time
 }
 
+// SessionDurationStats is the average duration of the ended chat sessions of one interview type
+type SessionDurationStats struct {
+	InterviewType          string
+	Sessions               int64
+	AverageDurationSeconds float64
+}
+
 // ChatSessionRepository interface defines the contract for chat session data access
 type ChatSessionRepository interface {
 	Create(session *ChatSession) error
 	GetByID(id string) (*ChatSession, error)
 	GetByInterviewID(interviewID string) (*ChatSession, error)
 	List(limit, offset int, filters ChatSessionFilters) ([]*ChatSession, int64, error)
+	GetCompletedWithoutEvaluation(limit int) ([]*ChatSession, error)
+	GetIdle(cutoff time.Time, limit int) ([]*ChatSession, error)
+	AbandonIdle(id string, cutoff, at time.Time) (bool, error)
+	ListByInterviewID(interviewID string) ([]*ChatSession, error)
+	GetDurationsByInterviewType() ([]*SessionDurationStats, error)
+	RecordHeartbeat(id string, at time.Time, minInterval time.Duration) (bool, error)
+	RecordAIAttempt(id string, maxAttempts int) (bool, error)
+	RecordAbuse(id, reason string) error
+	Reopen(id string, endedAfter time.Time, evaluationID string) (bool, error)
 	Update(id string, updates map[string]interface{}) error
+	AppendAskedQuestion(id, question string) error
+	AppendDifficultyLevel(id string, level int) error
+	AddEstimatedCost(id string, amount float64) error
 	Delete(id string) error
 	AddMessage(sessionID string, message *ChatMessage) error
+	AddMessageWithLimit(sessionID string, message *ChatMessage, maxMessages int) error
 	GetMessages(sessionID string) ([]*ChatMessage, error)
+	GetMessagesPage(sessionID string, options ListMessagesOptions) ([]*ChatMessage, int64, error)
+	GetMessageByClientID(sessionID, clientMessageID string) (*ChatMessage, error)
+	PurgeMessagesBefore(cutoff time.Time) (int64, error)
+	CountMessagesBefore(cutoff time.Time) (int64, error)
 }
 
 // chatSessionRepository implements ChatSessionRepository interface
 type chatSessionRepository struct {
-	db *gorm.DB
+	db       *gorm.DB
+	tenantID string // Scopes every query to one tenant; empty sees every tenant
 }
 
 // NewChatSessionRepository creates a new chat session repository
 func NewChatSessionRepository(db *gorm.DB) ChatSessionRepository {
-	return &chatSessionRepository{db: db}
+	return newChatSessionRepository(db, "")
+}
+
+// newChatSessionRepository creates a chat session repository scoped to tenantID
+func newChatSessionRepository(db *gorm.DB, tenantID string) *chatSessionRepository {
+	return &chatSessionRepository{db: db, tenantID: tenantID}
+}
+
+// scoped limits a chat session query to the repository's tenant
+func (r *chatSessionRepository) scoped(query *gorm.DB) *gorm.DB {
+	return tenantScope(query, "tenant_id", r.tenantID)
+}
+
+// messagesScoped limits a chat message query to the sessions of the repository's tenant
+func (r *chatSessionRepository) messagesScoped(query *gorm.DB) *gorm.DB {
+	if r.tenantID == "" {
+		return query
+	}
+	return query.Where("session_id IN (?)", r.scoped(r.db.Model(&ChatSession{}).Select("id")))
 }
 
 // Create creates a new chat session
 func (r *chatSessionRepository) Create(session *ChatSession) error {
-	session.CreatedAt = time.Now()
-	session.UpdatedAt = time.Now()
+	stampTenant(r.tenantID, &session.TenantID)
+	stampCreated(r.db.NowFunc(), &session.CreatedAt, &session.UpdatedAt)
 	return r.db.Create(session).Error
 }
 
 // GetByID retrieves a chat session by ID
 func (r *chatSessionRepository) GetByID(id string) (*ChatSession, error) {
 	var session ChatSession
-	err := r.db.Where("id = ?", id).First(&session).Error
+	err := r.scoped(r.db.Where("id = ?", id)).First(&session).Error
 	if errors.Is(err, gorm.ErrRecordNotFound) {
 		return nil, errors.New("chat session not found")
 	}
@@ -58,7 +103,7 @@ func (r *chatSessionRepository) GetByID(id string) (*ChatSession, error) {
 // GetByInterviewID retrieves a chat session by interview ID
 func (r *chatSessionRepository) GetByInterviewID(interviewID string) (*ChatSession, error) {
 	var session ChatSession
-	err := r.db.Where("interview_id = ?", interviewID).First(&session).Error
+	err := r.scoped(r.db.Where("interview_id = ?", interviewID)).First(&session).Error
 	if errors.Is(err, gorm.ErrRecordNotFound) {
 		return nil, errors.New("chat session not found")
 	}
@@ -70,7 +115,7 @@ func (r *chatSessionRepository) List(limit, offset int, filters ChatSessionFilte
 	var sessions []*ChatSession
 	var total int64
 
-	query := r.db.Model(&ChatSession{})
+	query := r.scoped(r.db.Model(&ChatSession{}))
 
 	// Apply filters
 	if filters.InterviewID != "" {
@@ -94,24 +139,209 @@ func (r *chatSessionRepository) List(limit, offset int, filters ChatSessionFilte
 	return sessions, total, err
 }
 
+// GetCompletedWithoutEvaluation lists completed sessions whose interview has no evaluation, oldest first
+// Sessions whose transcript was purged are left out, having nothing left to evaluate.
+// A limit of 0 means no limit
+func (r *chatSessionRepository) GetCompletedWithoutEvaluation(limit int) ([]*ChatSession, error) {
+	var sessions []*ChatSession
+	query := r.scoped(r.db.Where("status = ?", "completed")).
+		Where("transcript_purged_at IS NULL").
+		Where("NOT EXISTS (SELECT 1 FROM evaluations WHERE evaluations.interview_id = chat_sessions.interview_id)").
+		Order("created_at ASC")
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+	err := query.Find(&sessions).Error
+	return sessions, err
+}
+
+// GetIdle lists active sessions with no heartbeat or message since cutoff, oldest first
+// Sessions that never sent a heartbeat fall back to their last message and then their start. A limit of 0 means no limit.
+func (r *chatSessionRepository) GetIdle(cutoff time.Time, limit int) ([]*ChatSession, error) {
+	var sessions []*ChatSession
+	query := r.scoped(r.db.Where("status = ?", "active")).
+		Where("GREATEST(created_at, last_activity_at, (SELECT MAX(timestamp) FROM chat_messages WHERE chat_messages.session_id = chat_sessions.id)) < ?", cutoff).
+		Order("created_at ASC")
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+	err := query.Find(&sessions).Error
+	return sessions, err
+}
+
+// AbandonIdle marks an active session with no heartbeat or message since cutoff abandoned at at
+// Returns false when the session is no longer active or had activity since cutoff.
+func (r *chatSessionRepository) AbandonIdle(id string, cutoff, at time.Time) (bool, error) {
+	result := r.scoped(r.db.Model(&ChatSession{})).
+		Where("id = ? AND status = ?", id, "active").
+		Where("GREATEST(created_at, last_activity_at, (SELECT MAX(timestamp) FROM chat_messages WHERE chat_messages.session_id = chat_sessions.id)) < ?", cutoff).
+		Updates(map[string]interface{}{
+			"status":     SessionStatusAbandoned,
+			"ended_at":   gorm.Expr("COALESCE(ended_at, ?)", at),
+			"updated_at": r.db.NowFunc(),
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// RecordHeartbeat sets last_activity_at to at unless the previous heartbeat is less than minInterval old
+// Returns false when the heartbeat was rate-limited
+func (r *chatSessionRepository) RecordHeartbeat(id string, at time.Time, minInterval time.Duration) (bool, error) {
+	result := r.scoped(r.db.Model(&ChatSession{})).
+		Where("id = ? AND (last_activity_at IS NULL OR last_activity_at <= ?)", id, at.Add(-minInterval)).
+		Updates(map[string]interface{}{"last_activity_at": at, "updated_at": r.db.NowFunc()})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// RecordAIAttempt counts one provider call against the session unless it already made maxAttempts
+// Returns false when the budget is exhausted; a maxAttempts of 0 means no limit
+func (r *chatSessionRepository) RecordAIAttempt(id string, maxAttempts int) (bool, error) {
+	query := r.scoped(r.db.Model(&ChatSession{}).Where("id = ?", id))
+	if maxAttempts > 0 {
+		query = query.Where("ai_attempts < ?", maxAttempts)
+	}
+	result := query.Updates(map[string]interface{}{
+		"ai_attempts": gorm.Expr("ai_attempts + 1"),
+		"updated_at":  r.db.NowFunc(),
+	})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// RecordAbuse counts one candidate message refused for reason in the session's abuse_rejections
+func (r *chatSessionRepository) RecordAbuse(id, reason string) error {
+	result := r.scoped(r.db.Model(&ChatSession{}).Where("id = ?", id)).Updates(map[string]interface{}{
+		"abuse_rejections": gorm.Expr("jsonb_set(COALESCE(abuse_rejections, '{}'::jsonb), ARRAY[?]::text[], to_jsonb(COALESCE((abuse_rejections->>?)::int, 0) + 1))", reason, reason),
+		"updated_at":       r.db.NowFunc(),
+	})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("chat session not found")
+	}
+	return nil
+}
+
+// Reopen returns a session completed at or after endedAfter to active, recording evaluationID as
+// the evaluation its next one supersedes. Returns false when the session is not completed or
+// ended before endedAfter.
+func (r *chatSessionRepository) Reopen(id string, endedAfter time.Time, evaluationID string) (bool, error) {
+	result := r.scoped(r.db.Model(&ChatSession{})).
+		Where("id = ? AND status = ? AND ended_at >= ?", id, "completed", endedAfter).
+		Updates(map[string]interface{}{
+			"status":                 "active",
+			"ended_at":               nil,
+			"reopen_count":           gorm.Expr("reopen_count + 1"),
+			"reopened_evaluation_id": evaluationID,
+			"updated_at":             r.db.NowFunc(),
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// GetDurationsByInterviewType averages the duration of ended sessions per interview type
+// Active sessions have no duration yet and are left out
+func (r *chatSessionRepository) GetDurationsByInterviewType() ([]*SessionDurationStats, error) {
+	var stats []*SessionDurationStats
+	err := tenantScope(r.db.Table("chat_sessions AS s"), "s.tenant_id", r.tenantID).
+		Select("i.type AS interview_type, COUNT(*) AS sessions, AVG(EXTRACT(EPOCH FROM (s.ended_at - s.created_at))) AS average_duration_seconds").
+		Joins("JOIN interviews AS i ON i.id = s.interview_id").
+		Where("s.ended_at IS NOT NULL").
+		Group("i.type").
+		Order("i.type").
+		Scan(&stats).Error
+	return stats, err
+}
+
+// ListByInterviewID lists all sessions of an interview, oldest first
+func (r *chatSessionRepository) ListByInterviewID(interviewID string) ([]*ChatSession, error) {
+	var sessions []*ChatSession
+	err := r.scoped(r.db.Where("interview_id = ?", interviewID)).Order("created_at ASC").Find(&sessions).Error
+	return sessions, err
+}
+
 // Update updates a chat session
 func (r *chatSessionRepository) Update(id string, updates map[string]interface{}) error {
-	updates["updated_at"] = time.Now()
-	return r.db.Model(&ChatSession{}).Where("id = ?", id).Updates(updates).Error
+	updates["updated_at"] = r.db.NowFunc()
+	return r.scoped(r.db.Model(&ChatSession{}).Where("id = ?", id)).Updates(updates).Error
+}
+
+// AddEstimatedCost adds amount to the session's estimated AI cost atomically
+func (r *chatSessionRepository) AddEstimatedCost(id string, amount float64) error {
+	result := r.scoped(r.db.Model(&ChatSession{}).Where("id = ?", id)).Updates(map[string]interface{}{
+		"estimated_cost_usd": gorm.Expr("estimated_cost_usd + ?", amount),
+		"updated_at":         r.db.NowFunc(),
+	})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("chat session not found")
+	}
+	return nil
+}
+
+// AppendAskedQuestion appends a question to the session's asked_questions array atomically
+func (r *chatSessionRepository) AppendAskedQuestion(id, question string) error {
+	encoded, err := json.Marshal([]string{question})
+	if err != nil {
+		return err
+	}
+	result := r.scoped(r.db.Model(&ChatSession{}).Where("id = ?", id)).Updates(map[string]interface{}{
+		"asked_questions": gorm.Expr("COALESCE(asked_questions, '[]'::jsonb) || ?::jsonb", string(encoded)),
+		"updated_at":      r.db.NowFunc(),
+	})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("chat session not found")
+	}
+	return nil
+}
+
+// AppendDifficultyLevel sets the session's difficulty level and appends it to the trajectory atomically
+func (r *chatSessionRepository) AppendDifficultyLevel(id string, level int) error {
+	encoded, err := json.Marshal([]int{level})
+	if err != nil {
+		return err
+	}
+	result := r.scoped(r.db.Model(&ChatSession{}).Where("id = ?", id)).Updates(map[string]interface{}{
+		"difficulty_level":      level,
+		"difficulty_trajectory": gorm.Expr("COALESCE(difficulty_trajectory, '[]'::jsonb) || ?::jsonb", string(encoded)),
+		"updated_at":            r.db.NowFunc(),
+	})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("chat session not found")
+	}
+	return nil
 }
 
 // Delete deletes a chat session
 func (r *chatSessionRepository) Delete(id string) error {
 	// Also delete associated messages
-	r.db.Where("session_id = ?", id).Delete(&ChatMessage{})
-	return r.db.Where("id = ?", id).Delete(&ChatSession{}).Error
+	r.messagesScoped(r.db.Where("session_id = ?", id)).Delete(&ChatMessage{})
+	return r.scoped(r.db.Where("id = ?", id)).Delete(&ChatSession{}).Error
 }
 
 // AddMessage adds a message to a chat session
 func (r *chatSessionRepository) AddMessage(sessionID string, message *ChatMessage) error {
 	// Verify session exists
 	var session ChatSession
-	if err := r.db.Where("id = ?", sessionID).First(&session).Error; err != nil {
+	if err := r.scoped(r.db.Where("id = ?", sessionID)).First(&session).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return errors.New("chat session not found")
 		}
@@ -119,13 +349,118 @@ func (r *chatSessionRepository) AddMessage(sessionID string, message *ChatMessag
 	}
 
 	message.SessionID = sessionID
-	message.CreatedAt = time.Now()
+	stampCreated(r.db.NowFunc(), &message.CreatedAt, nil)
 	return r.db.Create(message).Error
 }
 
+// AddMessageWithLimit adds a message unless the session already holds maxMessages messages
+// The session row is locked so concurrent writers cannot overshoot the cap. A maxMessages of 0 means no limit.
+func (r *chatSessionRepository) AddMessageWithLimit(sessionID string, message *ChatMessage, maxMessages int) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var session ChatSession
+		locked := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", sessionID)
+		if err := tenantScope(locked, "tenant_id", r.tenantID).First(&session).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errors.New("chat session not found")
+			}
+			return err
+		}
+
+		var count int64
+		if err := tx.Model(&ChatMessage{}).Where("session_id = ?", sessionID).Count(&count).Error; err != nil {
+			return err
+		}
+		if maxMessages > 0 && count >= int64(maxMessages) {
+			return ErrMessageLimitReached
+		}
+
+		message.SessionID = sessionID
+		stampCreated(tx.NowFunc(), &message.CreatedAt, nil)
+		return tx.Create(message).Error
+	})
+}
+
 // GetMessages retrieves all messages for a chat session
 func (r *chatSessionRepository) GetMessages(sessionID string) ([]*ChatMessage, error) {
 	var messages []*ChatMessage
-	err := r.db.Where("session_id = ?", sessionID).Order("timestamp ASC").Find(&messages).Error
+	err := r.messagesScoped(r.db.Where("session_id = ?", sessionID)).Order("timestamp ASC").Find(&messages).Error
 	return messages, err
 }
+
+// GetMessagesPage retrieves a window of a chat session's messages, oldest first, with the total count
+// A limit of 0 returns every message after offset
+func (r *chatSessionRepository) GetMessagesPage(sessionID string, options ListMessagesOptions) ([]*ChatMessage, int64, error) {
+	var messages []*ChatMessage
+	var total int64
+	scoped := func(db *gorm.DB) *gorm.DB {
+		db = r.messagesScoped(db.Where("session_id = ?", sessionID))
+		if options.CandidateOnly {
+			// Messages stored before visibility existed have no visibility and are visible
+			db = db.Where("COALESCE(visibility, '') <> ?", MessageVisibilityInternal)
+		}
+		return db
+	}
+	if err := scoped(r.db.Model(&ChatMessage{})).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	query := scoped(r.db).Order("timestamp ASC").Offset(options.Offset)
+	if options.Limit > 0 {
+		query = query.Limit(options.Limit)
+	}
+	err := query.Find(&messages).Error
+	return messages, total, err
+}
+
+// GetMessageByClientID retrieves a message by its client-provided ID within a session
+func (r *chatSessionRepository) GetMessageByClientID(sessionID, clientMessageID string) (*ChatMessage, error) {
+	var message ChatMessage
+	err := r.messagesScoped(r.db.Where("session_id = ? AND client_message_id = ?", sessionID, clientMessageID)).First(&message).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("chat message not found")
+		}
+		return nil, err
+	}
+	return &message, nil
+}
+
+// CountMessagesBefore counts the messages PurgeMessagesBefore would delete
+func (r *chatSessionRepository) CountMessagesBefore(cutoff time.Time) (int64, error) {
+	ended := tenantScope(r.db.Model(&ChatSession{}).Select("id"), "tenant_id", r.tenantID).Where("status <> ?", "active")
+	var count int64
+	err := r.db.Model(&ChatMessage{}).Where("timestamp < ? AND session_id IN (?)", cutoff, ended).Count(&count).Error
+	return count, err
+}
+
+// PurgeMessagesBefore deletes the messages sent before cutoff in sessions that are no longer
+// active, clears those sessions' conversation summaries and marks them purged, in one transaction
+// Returns the number of messages deleted
+func (r *chatSessionRepository) PurgeMessagesBefore(cutoff time.Time) (int64, error) {
+	var purged int64
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		// Built per use: a gorm subquery can't be shared between statements
+		ended := func() *gorm.DB {
+			return tenantScope(tx.Model(&ChatSession{}).Select("id"), "tenant_id", r.tenantID).Where("status <> ?", "active")
+		}
+		stale := func() *gorm.DB {
+			return tx.Model(&ChatMessage{}).Where("timestamp < ? AND session_id IN (?)", cutoff, ended())
+		}
+		now := tx.NowFunc()
+		err := tx.Model(&ChatSession{}).
+			Where("id IN (?)", stale().Select("session_id")).
+			Updates(map[string]interface{}{
+				"conversation_summary": "",
+				"summarized_turns":     0,
+				"transcript_purged_at": now,
+				"updated_at":           now,
+			}).Error
+		if err != nil {
+			return err
+		}
+		result := stale().Delete(&ChatMessage{})
+		purged = result.RowsAffected
+		return result.Error
+	})
+	return purged, err
+}
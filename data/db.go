@@ -13,6 +13,47 @@ import (
 
 // InitDB initializes and returns a PostgreSQL connection using GORM
 func InitDB(databaseURL string) (*gorm.DB, error) {
+	db, err := openDB(databaseURL)
+	if err != nil {
+		return nil, err
+	}
+
+	// Run database migrations automatically
+	if err := runMigrations(db); err != nil {
+		return nil, fmt.Errorf("migration failed: %w", err)
+	}
+
+	// Add performance indexes for better concurrent query performance
+	if err := AddPerformanceIndexes(db); err != nil {
+		// Don't fail if indexes can't be created, just log warning
+		utils.Warningf("Some performance indexes could not be created: %v\n", err)
+	}
+
+	// Add database health check
+	if err := db.Exec("SELECT 1").Error; err != nil {
+		return nil, fmt.Errorf("database health check failed: %w", err)
+	}
+
+	return db, nil
+}
+
+// InitReplicaDB connects to a read-only replica of the primary database
+// Migrations are skipped: the replica receives the schema through replication
+func InitReplicaDB(replicaURL string) (*gorm.DB, error) {
+	db, err := openDB(replicaURL)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := db.Exec("SELECT 1").Error; err != nil {
+		return nil, fmt.Errorf("replica health check failed: %w", err)
+	}
+
+	return db, nil
+}
+
+// openDB opens a PostgreSQL connection with the shared GORM and pool settings
+func openDB(databaseURL string) (*gorm.DB, error) {
 	// Configure GORM for better performance
 	config := &gorm.Config{
 		Logger:                                   logger.Default.LogMode(logger.Silent), // Reduce logging overhead in production
@@ -36,34 +77,35 @@ func InitDB(databaseURL string) (*gorm.DB, error) {
 	sqlDB.SetMaxIdleConns(25)                  // Keep 25 idle connections ready
 	sqlDB.SetMaxOpenConns(100)                 // Allow up to 100 concurrent connections
 	sqlDB.SetConnMaxLifetime(time.Hour)        // Recycle connections every hour
-	sqlDB.SetConnMaxIdleTime(15 * time.Minute) // Close idle connections after 15 minutes	// Run database migrations automatically
-	if err := runMigrations(db); err != nil {
-		return nil, fmt.Errorf("migration failed: %w", err)
-	}
-
-	// Add performance indexes for better concurrent query performance
-	if err := AddPerformanceIndexes(db); err != nil {
-		// Don't fail if indexes can't be created, just log warning
-		utils.Warningf("Some performance indexes could not be created: %v\n", err)
-	}
-
-	// Add database health check
-	if err := db.Exec("SELECT 1").Error; err != nil {
-		return nil, fmt.Errorf("database health check failed: %w", err)
-	}
+	sqlDB.SetConnMaxIdleTime(15 * time.Minute) // Close idle connections after 15 minutes
 
 	return db, nil
 }
 
 // Implement database migration function
 func runMigrations(db *gorm.DB) error {
-	return db.AutoMigrate(
+	if err := db.AutoMigrate(
 		&Interview{},
 		&Evaluation{},
 		&ChatSession{},
 		&ChatMessage{},
+		&Notification{},
+		&CalibrationRun{},
+		&EvaluationTrace{},
 		// &File{}, // TODO: Uncomment when File model is implemented
-	)
+	); err != nil {
+		return err
+	}
+	if err := BackfillCandidateKeys(db); err != nil {
+		return err
+	}
+	if err := BackfillTenantIDs(db); err != nil {
+		return err
+	}
+	if err := BackfillSessionEndedAt(db); err != nil {
+		return err
+	}
+	return BackfillQuestionDetails(db)
 }
 
 // Implement database seeding for development
@@ -99,5 +141,4 @@ func CloseDB(db *gorm.DB) {
 
 // TODO: Add database monitoring and metrics collection
 // TODO: Implement connection retry logic with exponential backoff
-// TODO: Add support for read replicas and write/read separation
 // TODO: Add database transaction helpers and utilities
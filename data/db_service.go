@@ -2,25 +2,86 @@
 package data
 
 import (
+	"context"
+	"errors"
+	"fmt"
+
 	"gorm.io/gorm"
 )
 
 // DatabaseService provides a unified interface for all database operations
 type DatabaseService struct {
-	db              *gorm.DB
-	InterviewRepo   InterviewRepository
-	EvaluationRepo  EvaluationRepository
-	ChatSessionRepo ChatSessionRepository
+	db               *gorm.DB
+	InterviewRepo    InterviewRepository
+	EvaluationRepo   EvaluationRepository
+	ChatSessionRepo  ChatSessionRepository
+	NotificationRepo NotificationRepository
+	CalibrationRepo  CalibrationRunRepository
+	replica          *DatabaseService // Serves reads when set; nil routes everything to the primary
+	tenantID         string           // Tenant the repositories are scoped to; empty sees every tenant
 }
 
 // NewDatabaseService creates a new database service with all repositories
 func NewDatabaseService(db *gorm.DB) *DatabaseService {
+	return newDatabaseService(db, "")
+}
+
+// newDatabaseService creates a database service whose repositories are scoped to tenantID
+func newDatabaseService(db *gorm.DB, tenantID string) *DatabaseService {
 	return &DatabaseService{
-		db:              db,
-		InterviewRepo:   NewInterviewRepository(db),
-		EvaluationRepo:  NewEvaluationRepository(db),
-		ChatSessionRepo: NewChatSessionRepository(db),
+		db:               db,
+		InterviewRepo:    newInterviewRepository(db, tenantID),
+		EvaluationRepo:   newEvaluationRepository(db, tenantID),
+		ChatSessionRepo:  newChatSessionRepository(db, tenantID),
+		NotificationRepo: newNotificationRepository(db, tenantID),
+		CalibrationRepo:  newCalibrationRunRepository(db, tenantID),
+		tenantID:         tenantID,
+	}
+}
+
+// NewDatabaseServiceWithReplica creates a database service that sends reads through Reader to replica
+func NewDatabaseServiceWithReplica(primary, replica *gorm.DB) *DatabaseService {
+	service := NewDatabaseService(primary)
+	if replica != nil {
+		service.replica = NewDatabaseService(replica)
+	}
+	return service
+}
+
+// WithContext returns a service whose queries are bound to ctx
+func (s *DatabaseService) WithContext(ctx context.Context) *DatabaseService {
+	bound := newDatabaseService(s.db.WithContext(ctx), s.tenantID)
+	if s.replica != nil {
+		bound.replica = s.replica.WithContext(ctx)
+	}
+	return bound
+}
+
+// ForTenant returns a service whose repositories only see and create records of tenantID
+// An empty tenantID returns an unscoped service
+func (s *DatabaseService) ForTenant(tenantID string) *DatabaseService {
+	if tenantID == s.tenantID {
+		return s
 	}
+	scoped := newDatabaseService(s.db, tenantID)
+	if s.replica != nil {
+		scoped.replica = s.replica.ForTenant(tenantID)
+	}
+	return scoped
+}
+
+// Reader returns the service reads should go to: the replica when configured, else the primary
+// Replicas lag behind the primary, so reads of just-written rows must use the primary instead
+func (s *DatabaseService) Reader() *DatabaseService {
+	if s.replica != nil {
+		return s.replica
+	}
+	return s
+}
+
+// HasReplica reports whether reads are routed to a replica
+func (s *DatabaseService) HasReplica() bool {
+	return s.replica != nil
 }
 
 // DB returns the underlying GORM database instance for advanced operations
@@ -33,30 +94,52 @@ func (s *DatabaseService) Transaction(fn func(*gorm.DB) error) error {
 	return s.db.Transaction(fn)
 }
 
-// Health checks database connectivity
+// Health checks database connectivity of the primary and, when configured, the replica
 func (s *DatabaseService) Health() error {
-	return s.db.Exec("SELECT 1").Error
+	if err := s.db.Exec("SELECT 1").Error; err != nil {
+		return fmt.Errorf("primary: %w", err)
+	}
+	if s.replica != nil {
+		if err := s.replica.db.Exec("SELECT 1").Error; err != nil {
+			return fmt.Errorf("replica: %w", err)
+		}
+	}
+	return nil
 }
 
-// Close closes the database connection
+// Close closes the database connections
 func (s *DatabaseService) Close() error {
 	sqlDB, err := s.db.DB()
 	if err != nil {
 		return err
 	}
-	return sqlDB.Close()
+	err = sqlDB.Close()
+	if s.replica != nil {
+		err = errors.Join(err, s.replica.Close())
+	}
+	return err
 }
 
 // Global database service instance (will be initialized when database is connected)
 var DBService *DatabaseService
 
 // InitDatabaseService initializes the global database service
-func InitDatabaseService(databaseURL string) error {
+// replicaURL is optional; when set, reads are served by that replica
+func InitDatabaseService(databaseURL, replicaURL string) error {
 	db, err := InitDB(databaseURL)
 	if err != nil {
 		return err
 	}
 
-	DBService = NewDatabaseService(db)
+	var replica *gorm.DB
+	if replicaURL != "" {
+		replica, err = InitReplicaDB(replicaURL)
+		if err != nil {
+			CloseDB(db)
+			return fmt.Errorf("failed to connect to read replica: %w", err)
+		}
+	}
+
+	DBService = NewDatabaseServiceWithReplica(db, replica)
 	return nil
 }
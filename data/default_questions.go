@@ -0,0 +1,94 @@
+// Built-in question sets for quick-start interviews
+package data
+
+import "fmt"
+
+// defaultQuestionSets holds the built-in questions by interview type, then language
+var defaultQuestionSets = map[string]map[string][]string{
+	InterviewTypeGeneral: {
+		LanguageEnglish: {
+			"Tell me about yourself",
+			"What are your strengths?",
+			"Describe a challenging project you worked on",
+			"Where do you see yourself in 5 years?",
+		},
+		LanguageTraditionalChinese: {
+			"請簡單介紹一下你自己",
+			"你的優勢是什麼？",
+			"請描述一個你參與過、具有挑戰性的專案",
+			"你認為五年後的自己會在哪裡？",
+		},
+	},
+	InterviewTypeTechnical: {
+		LanguageEnglish: {
+			"Tell me about your technical background and experience",
+			"Describe a challenging technical problem you solved recently",
+			"How do you approach debugging and troubleshooting?",
+			"What technologies are you most excited about learning?",
+			"Walk me through your development process for a new feature",
+		},
+		LanguageTraditionalChinese: {
+			"請談談你的技術背景與經驗",
+			"請描述你最近解決過的一個具有挑戰性的技術問題",
+			"你如何進行除錯與問題排查？",
+			"你最想學習哪些技術？",
+			"請帶我走一遍你開發新功能的流程",
+		},
+	},
+	InterviewTypeBehavioral: {
+		LanguageEnglish: {
+			"Tell me about a time when you had to work under pressure",
+			"Describe a situation where you had to resolve a conflict with a colleague",
+			"Give me an example of when you showed leadership",
+			"Tell me about a time you failed and what you learned from it",
+			"How do you handle feedback and criticism?",
+		},
+		LanguageTraditionalChinese: {
+			"請分享一次你在壓力下工作的經驗",
+			"請描述一次你必須化解與同事之間衝突的情況",
+			"請舉一個你展現領導力的例子",
+			"請談談一次失敗的經驗，以及你從中學到了什麼",
+			"你如何面對回饋與批評？",
+		},
+	},
+}
+
+// DefaultQuestionSet is a built-in list of questions for one interview type and language
+type DefaultQuestionSet struct {
+	InterviewType string
+	Language      string
+	Questions     []string
+	Warning       string // Set when the requested type or language had no set and another was used
+}
+
+// GetDefaultQuestionSet returns a copy of the built-in questions for an interview type and language
+// Empty arguments use the default type and language. An unknown type falls back to the general
+// set and an unknown language to English, with Warning describing the substitution.
+func GetDefaultQuestionSet(interviewType, language string) DefaultQuestionSet {
+	set := DefaultQuestionSet{InterviewType: interviewType, Language: language}
+	if set.InterviewType == "" {
+		set.InterviewType = GetDefaultInterviewType()
+	}
+	if set.Language == "" {
+		set.Language = GetDefaultLanguage()
+	}
+
+	byLanguage, ok := defaultQuestionSets[set.InterviewType]
+	if !ok {
+		set.Warning = fmt.Sprintf("No default questions for interview type %q; using %q", set.InterviewType, GetDefaultInterviewType())
+		set.InterviewType = GetDefaultInterviewType()
+		byLanguage = defaultQuestionSets[set.InterviewType]
+	}
+	questions, ok := byLanguage[set.Language]
+	if !ok {
+		warning := fmt.Sprintf("No default questions in language %q; using %q", set.Language, GetDefaultLanguage())
+		if set.Warning != "" {
+			warning = set.Warning + "; " + warning
+		}
+		set.Warning = warning
+		set.Language = GetDefaultLanguage()
+		questions = byLanguage[set.Language]
+	}
+	set.Questions = append([]string{}, questions...)
+	return set
+}
@@ -0,0 +1,37 @@
+package data_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zidane0000/ai-interview-platform/data"
+)
+
+func TestGetDefaultQuestionSet(t *testing.T) {
+	// Every supported type has a set in every supported language
+	for _, interviewType := range []string{data.InterviewTypeGeneral, data.InterviewTypeTechnical, data.InterviewTypeBehavioral} {
+		english := data.GetDefaultQuestionSet(interviewType, data.LanguageEnglish)
+		chinese := data.GetDefaultQuestionSet(interviewType, data.LanguageTraditionalChinese)
+		assert.Empty(t, english.Warning, interviewType)
+		assert.Empty(t, chinese.Warning, interviewType)
+		assert.NotEmpty(t, english.Questions, interviewType)
+		assert.Len(t, chinese.Questions, len(english.Questions), "translations of %s", interviewType)
+		assert.NotEqual(t, english.Questions, chinese.Questions, interviewType)
+	}
+
+	defaults := data.GetDefaultQuestionSet("", "")
+	assert.Equal(t, data.InterviewTypeGeneral, defaults.InterviewType)
+	assert.Equal(t, data.LanguageEnglish, defaults.Language)
+	assert.Empty(t, defaults.Warning)
+
+	fallback := data.GetDefaultQuestionSet("sales", "fr")
+	assert.Equal(t, data.InterviewTypeGeneral, fallback.InterviewType)
+	assert.Equal(t, data.LanguageEnglish, fallback.Language)
+	assert.Contains(t, fallback.Warning, `"sales"`)
+	assert.Contains(t, fallback.Warning, `"fr"`)
+	assert.Equal(t, defaults.Questions, fallback.Questions)
+
+	// Callers get their own copy
+	fallback.Questions[0] = "Changed"
+	assert.NotEqual(t, "Changed", data.GetDefaultQuestionSet("", "").Questions[0])
+}
@@ -15,6 +15,9 @@ type EvaluationFilters struct {
 	MaxScore      float64
 	CreatedAfter  time.Time
 	CreatedBefore time.Time
+
+	// IncludeSuperseded also returns evaluations that a later evaluation replaced
+	IncludeSuperseded bool
 }
 
 // EvaluationStatistics provides aggregated statistics for evaluations
@@ -26,40 +29,81 @@ type EvaluationStatistics struct {
 	ScoreDistribution map[string]int `json:"score_distribution"` // Score ranges
 }
 
+// ModelScoreStats aggregates the scores an AI model gave across evaluations
+type ModelScoreStats struct {
+	Provider     string
+	Model        string
+	Evaluations  int64
+	AverageScore float64
+}
+
+// DecisionCount is how many evaluations of one interview type ended with one recommendation decision
+type DecisionCount struct {
+	InterviewType string
+	Decision      string
+	Evaluations   int64
+}
+
+// OutcomeScoreStats is the average score of current evaluations of interviews with one hiring outcome
+type OutcomeScoreStats struct {
+	Outcome      string
+	Evaluations  int64
+	AverageScore float64
+}
+
 // EvaluationRepository interface defines the contract for evaluation data access
 type EvaluationRepository interface {
 	Create(evaluation *Evaluation) error
 	GetByID(id string) (*Evaluation, error)
 	GetByInterviewID(interviewID string) (*Evaluation, error)
+	GetLatestByInterviewID(interviewID string) (*Evaluation, error)
+	GetLatestByInterviewIDs(interviewIDs []string) (map[string]*Evaluation, error)
 	List(limit, offset int, filters EvaluationFilters) ([]*Evaluation, int64, error)
 	Update(id string, updates map[string]interface{}) error
 	Delete(id string) error
 	GetStatistics() (*EvaluationStatistics, error)
+	GetScoresByModel() ([]*ModelScoreStats, error)
+	GetDecisionCounts() ([]*DecisionCount, error)
+	GetScoresByOutcome() ([]*OutcomeScoreStats, error)
+	GetScoreDistribution(interviewType, model string, since time.Time) ([]float64, error)
+	CreateTrace(trace *EvaluationTrace) error
+	GetTrace(evaluationID string) (*EvaluationTrace, error)
 }
 
 // evaluationRepository implements EvaluationRepository interface
 type evaluationRepository struct {
-	db *gorm.DB
+	db       *gorm.DB
+	tenantID string // Scopes every query to one tenant; empty sees every tenant
 }
 
 // NewEvaluationRepository creates a new evaluation repository
 func NewEvaluationRepository(db *gorm.DB) EvaluationRepository {
-	return &evaluationRepository{db: db}
+	return newEvaluationRepository(db, "")
+}
+
+// newEvaluationRepository creates an evaluation repository scoped to tenantID
+func newEvaluationRepository(db *gorm.DB, tenantID string) *evaluationRepository {
+	return &evaluationRepository{db: db, tenantID: tenantID}
+}
+
+// scoped limits query to the repository's tenant
+func (r *evaluationRepository) scoped(query *gorm.DB) *gorm.DB {
+	return tenantScope(query, "tenant_id", r.tenantID)
 }
 
 // Create creates a new evaluation with validation
 func (r *evaluationRepository) Create(evaluation *Evaluation) error {
 	// Validate that interview exists
 	var interview Interview
-	if err := r.db.Where("id = ?", evaluation.InterviewID).First(&interview).Error; err != nil {
+	if err := r.scoped(r.db.Where("id = ?", evaluation.InterviewID)).First(&interview).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return errors.New("interview not found")
 		}
 		return err
 	}
 
-	evaluation.CreatedAt = time.Now()
-	evaluation.UpdatedAt = time.Now()
+	stampTenant(r.tenantID, &evaluation.TenantID)
+	stampCreated(r.db.NowFunc(), &evaluation.CreatedAt, &evaluation.UpdatedAt)
 
 	return r.db.Create(evaluation).Error
 }
@@ -67,7 +111,7 @@ func (r *evaluationRepository) Create(evaluation *Evaluation) error {
 // GetByID retrieves an evaluation by ID
 func (r *evaluationRepository) GetByID(id string) (*Evaluation, error) {
 	var evaluation Evaluation
-	err := r.db.Where("id = ?", id).First(&evaluation).Error
+	err := r.scoped(r.db.Where("id = ?", id)).First(&evaluation).Error
 	if errors.Is(err, gorm.ErrRecordNotFound) {
 		return nil, errors.New("evaluation not found")
 	}
@@ -77,19 +121,63 @@ func (r *evaluationRepository) GetByID(id string) (*Evaluation, error) {
 // GetByInterviewID retrieves an evaluation by interview ID for frontend requirements
 func (r *evaluationRepository) GetByInterviewID(interviewID string) (*Evaluation, error) {
 	var evaluation Evaluation
-	err := r.db.Where("interview_id = ?", interviewID).First(&evaluation).Error
+	err := r.scoped(r.db.Where("interview_id = ?", interviewID)).First(&evaluation).Error
 	if errors.Is(err, gorm.ErrRecordNotFound) {
 		return nil, errors.New("evaluation not found")
 	}
 	return &evaluation, err
 }
 
+// GetLatestByInterviewID retrieves the authoritative evaluation for an interview:
+// the most recent one that no other evaluation supersedes
+func (r *evaluationRepository) GetLatestByInterviewID(interviewID string) (*Evaluation, error) {
+	var evaluation Evaluation
+	err := r.scoped(r.db.Where("interview_id = ?", interviewID)).
+		Where("id NOT IN (?)", r.supersededIDs()).
+		Order("created_at DESC").
+		First(&evaluation).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, errors.New("evaluation not found")
+	}
+	return &evaluation, err
+}
+
+// GetLatestByInterviewIDs retrieves the authoritative evaluation of each of the interviews in one
+// query, keyed by interview ID; interviews without one are left out
+func (r *evaluationRepository) GetLatestByInterviewIDs(interviewIDs []string) (map[string]*Evaluation, error) {
+	latest := make(map[string]*Evaluation, len(interviewIDs))
+	if len(interviewIDs) == 0 {
+		return latest, nil
+	}
+	var evaluations []*Evaluation
+	err := r.scoped(r.db.Where("interview_id IN ?", interviewIDs)).
+		Where("id NOT IN (?)", r.supersededIDs()).
+		Order("created_at DESC").
+		Find(&evaluations).Error
+	if err != nil {
+		return nil, err
+	}
+	// Newest first, so the first of each interview is its latest
+	for _, evaluation := range evaluations {
+		if _, exists := latest[evaluation.InterviewID]; !exists {
+			latest[evaluation.InterviewID] = evaluation
+		}
+	}
+	return latest, nil
+}
+
+// supersededIDs returns a subquery selecting the IDs of evaluations that were replaced or voided
+func (r *evaluationRepository) supersededIDs() *gorm.DB {
+	return r.db.Raw("SELECT supersedes_id FROM evaluations WHERE supersedes_id <> '' UNION SELECT id FROM evaluations WHERE status = ?",
+		EvaluationStatusSuperseded)
+}
+
 // List retrieves evaluations with filtering and sorting
 func (r *evaluationRepository) List(limit, offset int, filters EvaluationFilters) ([]*Evaluation, int64, error) {
 	var evaluations []*Evaluation
 	var total int64
 
-	query := r.db.Model(&Evaluation{})
+	query := r.scoped(r.db.Model(&Evaluation{}))
 
 	// Apply filters
 	if filters.InterviewID != "" {
@@ -107,6 +195,9 @@ func (r *evaluationRepository) List(limit, offset int, filters EvaluationFilters
 	if !filters.CreatedBefore.IsZero() {
 		query = query.Where("created_at <= ?", filters.CreatedBefore)
 	}
+	if !filters.IncludeSuperseded {
+		query = query.Where("id NOT IN (?)", r.supersededIDs())
+	}
 
 	// Get total count
 	query.Count(&total)
@@ -118,13 +209,30 @@ func (r *evaluationRepository) List(limit, offset int, filters EvaluationFilters
 
 // Update updates an evaluation
 func (r *evaluationRepository) Update(id string, updates map[string]interface{}) error {
-	updates["updated_at"] = time.Now()
-	return r.db.Model(&Evaluation{}).Where("id = ?", id).Updates(updates).Error
+	updates["updated_at"] = r.db.NowFunc()
+	return r.scoped(r.db.Model(&Evaluation{}).Where("id = ?", id)).Updates(updates).Error
 }
 
 // Delete deletes an evaluation
 func (r *evaluationRepository) Delete(id string) error {
-	return r.db.Where("id = ?", id).Delete(&Evaluation{}).Error
+	return r.scoped(r.db.Where("id = ?", id)).Delete(&Evaluation{}).Error
+}
+
+// CreateTrace stores the trace of an evaluation
+func (r *evaluationRepository) CreateTrace(trace *EvaluationTrace) error {
+	stampTenant(r.tenantID, &trace.TenantID)
+	stampCreated(r.db.NowFunc(), &trace.CreatedAt, nil)
+	return r.db.Create(trace).Error
+}
+
+// GetTrace retrieves the trace of an evaluation
+func (r *evaluationRepository) GetTrace(evaluationID string) (*EvaluationTrace, error) {
+	var trace EvaluationTrace
+	err := r.scoped(r.db.Where("evaluation_id = ?", evaluationID)).First(&trace).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, errors.New("evaluation trace not found")
+	}
+	return &trace, err
 }
 
 // GetStatistics implements statistics aggregation for analytics
@@ -132,7 +240,7 @@ func (r *evaluationRepository) GetStatistics() (*EvaluationStatistics, error) {
 	var stats EvaluationStatistics
 
 	// Total count
-	r.db.Model(&Evaluation{}).Count(&stats.TotalEvaluations)
+	r.scoped(r.db.Model(&Evaluation{})).Count(&stats.TotalEvaluations)
 
 	if stats.TotalEvaluations == 0 {
 		return &stats, nil
@@ -145,7 +253,7 @@ func (r *evaluationRepository) GetStatistics() (*EvaluationStatistics, error) {
 		Max float64
 	}
 
-	err := r.db.Model(&Evaluation{}).
+	err := r.scoped(r.db.Model(&Evaluation{})).
 		Select("AVG(score) as avg, MIN(score) as min, MAX(score) as max").
 		Scan(&result).Error
 
@@ -165,7 +273,7 @@ func (r *evaluationRepository) GetStatistics() (*EvaluationStatistics, error) {
 		Count int
 	}
 
-	err = r.db.Model(&Evaluation{}).
+	err = r.scoped(r.db.Model(&Evaluation{})).
 		Select(`
 			CASE 
 				WHEN score >= 90 THEN '90-100'
@@ -189,3 +297,64 @@ func (r *evaluationRepository) GetStatistics() (*EvaluationStatistics, error) {
 
 	return &stats, nil
 }
+
+// GetScoresByModel averages the scores of AI-scored evaluations per provider and model
+// Superseded evaluations and sessions without answers are left out
+func (r *evaluationRepository) GetScoresByModel() ([]*ModelScoreStats, error) {
+	var stats []*ModelScoreStats
+	err := r.scoped(r.db.Model(&Evaluation{})).
+		Select("provider, model, COUNT(*) AS evaluations, AVG(score) AS average_score").
+		Where("status = ?", EvaluationStatusCompleted).
+		Where("id NOT IN (?)", r.supersededIDs()).
+		Group("provider, model").
+		Order("provider, model").
+		Scan(&stats).Error
+	return stats, err
+}
+
+// GetScoresByOutcome averages the scores of current completed evaluations per interview outcome
+// Interviews without a recorded outcome are left out
+func (r *evaluationRepository) GetScoresByOutcome() ([]*OutcomeScoreStats, error) {
+	var stats []*OutcomeScoreStats
+	err := tenantScope(r.db.Table("evaluations AS e"), "e.tenant_id", r.tenantID).
+		Select("i.outcome, COUNT(*) AS evaluations, AVG(e.score) AS average_score").
+		Joins("JOIN interviews AS i ON i.id = e.interview_id").
+		Where("i.outcome <> ''").
+		Where("e.status = ?", EvaluationStatusCompleted).
+		Where("e.id NOT IN (?)", r.supersededIDs()).
+		Group("i.outcome").
+		Order("i.outcome").
+		Scan(&stats).Error
+	return stats, err
+}
+
+// GetScoreDistribution returns, sorted ascending, the scores of current completed evaluations of
+// interviewType interviews scored by model and created at or after since
+func (r *evaluationRepository) GetScoreDistribution(interviewType, model string, since time.Time) ([]float64, error) {
+	scores := make([]float64, 0)
+	err := tenantScope(r.db.Table("evaluations AS e"), "e.tenant_id", r.tenantID).
+		Joins("JOIN interviews AS i ON i.id = e.interview_id").
+		Where("i.type = ?", interviewType).
+		Where("e.model = ?", model).
+		Where("e.created_at >= ?", since).
+		Where("e.status = ?", EvaluationStatusCompleted).
+		Where("e.id NOT IN (?)", r.supersededIDs()).
+		Order("e.score").
+		Pluck("e.score", &scores).Error
+	return scores, err
+}
+
+// GetDecisionCounts counts the recommendation decisions of current evaluations per interview type
+// Superseded evaluations and evaluations without a decision are left out
+func (r *evaluationRepository) GetDecisionCounts() ([]*DecisionCount, error) {
+	var counts []*DecisionCount
+	err := tenantScope(r.db.Table("evaluations AS e"), "e.tenant_id", r.tenantID).
+		Select("i.type AS interview_type, e.decision, COUNT(*) AS evaluations").
+		Joins("JOIN interviews AS i ON i.id = e.interview_id").
+		Where("e.decision <> ''").
+		Where("e.id NOT IN (?)", r.supersededIDs()).
+		Group("i.type, e.decision").
+		Order("i.type, e.decision").
+		Scan(&counts).Error
+	return counts, err
+}
@@ -0,0 +1,78 @@
+// Fault injection for the memory store, so tests can exercise failures mid-flow
+package data
+
+import (
+	"errors"
+	"sync"
+)
+
+// ErrInjectedFault is returned by a store operation failed by a Fault that names no error
+var ErrInjectedFault = errors.New("injected store fault")
+
+// Fault fails the Call-th call of the store operation named Op (e.g. "CreateEvaluation"),
+// counted from when the policy was created; a Call of 0 fails every call
+type Fault struct {
+	Op   string
+	Call int
+	Err  error // nil means ErrInjectedFault
+}
+
+// FaultPolicy decides which memory store operations fail; attach it with WithFaultPolicy (tests only)
+// Calls are counted per operation across every tenant view of the store.
+type FaultPolicy struct {
+	mu     sync.Mutex
+	faults []Fault
+	calls  map[string]int
+}
+
+// NewFaultPolicy creates a policy failing the given faults
+func NewFaultPolicy(faults ...Fault) *FaultPolicy {
+	return &FaultPolicy{faults: faults, calls: make(map[string]int)}
+}
+
+// Add fails more operations; use Calls to target a call relative to the ones already made
+func (p *FaultPolicy) Add(faults ...Fault) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.faults = append(p.faults, faults...)
+}
+
+// Calls returns how many times the operation op has been called, failed calls included
+func (p *FaultPolicy) Calls(op string) int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.calls[op]
+}
+
+// check counts a call of op and returns the error it fails with, or nil
+func (p *FaultPolicy) check(op string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.calls[op]++
+	for _, fault := range p.faults {
+		if fault.Op != op || (fault.Call != 0 && fault.Call != p.calls[op]) {
+			continue
+		}
+		if fault.Err == nil {
+			return ErrInjectedFault
+		}
+		return fault.Err
+	}
+	return nil
+}
+
+// MemoryStoreOption configures a memory store at construction
+type MemoryStoreOption func(*memoryData)
+
+// WithFaultPolicy makes the store's operations fail as policy decides (tests only)
+func WithFaultPolicy(policy *FaultPolicy) MemoryStoreOption {
+	return func(d *memoryData) { d.faults = policy }
+}
+
+// fault returns the injected error for a call of op, or nil when the store has no fault policy
+func (ms *MemoryStore) fault(op string) error {
+	if ms.faults == nil {
+		return nil
+	}
+	return ms.faults.check(op)
+}
@@ -12,8 +12,12 @@
 package data
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"time"
+
+	"gorm.io/gorm"
 )
 
 // StoreBackend defines the type of backend storage
@@ -25,14 +29,24 @@ const (
 )
 
 // HybridStore provides a unified interface that can use either memory or database
+// Database operations retry transient failures (see retry.go), bounded by the store's context
 type HybridStore struct {
-	backend     StoreBackend
-	memoryStore *MemoryStore
-	dbService   *DatabaseService
+	backend      StoreBackend
+	memoryStore  *MemoryStore
+	dbService    *DatabaseService
+	ctx          context.Context // Set by WithContext; nil means context.Background()
+	primaryRead  bool            // Set by WithPrimaryReads; reads skip the replica
+	instrumented bool            // Set by WithInstrumentation; operations record metrics
 }
 
 // NewHybridStore creates a new hybrid store
 func NewHybridStore(backend StoreBackend, databaseURL string) (*HybridStore, error) {
+	return NewHybridStoreWithReplica(backend, databaseURL, "")
+}
+
+// NewHybridStoreWithReplica creates a new hybrid store whose database reads go to replicaURL
+// An empty replicaURL sends reads to the primary
+func NewHybridStoreWithReplica(backend StoreBackend, databaseURL, replicaURL string) (*HybridStore, error) {
 	store := &HybridStore{
 		backend:     backend,
 		memoryStore: NewMemoryStore(),
@@ -43,7 +57,7 @@ func NewHybridStore(backend StoreBackend, databaseURL string) (*HybridStore, err
 			return nil, fmt.Errorf("database URL required for database backend")
 		}
 
-		err := InitDatabaseService(databaseURL)
+		err := InitDatabaseService(databaseURL, replicaURL)
 		if err != nil {
 			return nil, fmt.Errorf("failed to initialize database service: %w", err)
 		}
@@ -54,6 +68,108 @@ func NewHybridStore(backend StoreBackend, databaseURL string) (*HybridStore, err
 	return store, nil
 }
 
+// NewHybridStoreWithDatabase creates a database-backed store around an existing service
+// (e.g. one backed by sqlmock in tests)
+func NewHybridStoreWithDatabase(dbService *DatabaseService) *HybridStore {
+	return &HybridStore{
+		backend:     BackendDatabase,
+		memoryStore: NewMemoryStore(),
+		dbService:   dbService,
+	}
+}
+
+// NewHybridStoreWithMemory creates a memory-backed store around an existing memory store
+// (e.g. one with a fault policy in tests)
+func NewHybridStoreWithMemory(memoryStore *MemoryStore) *HybridStore {
+	return &HybridStore{
+		backend:     BackendMemory,
+		memoryStore: memoryStore,
+	}
+}
+
+// SetClock makes the store stamp CreatedAt/UpdatedAt with now instead of the wall clock (for tests)
+// The clock is shared by every store derived from h
+func (h *HybridStore) SetClock(now func() time.Time) {
+	h.memoryStore.SetClock(now)
+	if h.dbService != nil {
+		h.dbService.db.Config.NowFunc = func() time.Time { return now().UTC() }
+	}
+}
+
+// WithContext returns a store whose database queries and retries are bounded by ctx
+// The returned store shares the backend with h
+func (h *HybridStore) WithContext(ctx context.Context) Store {
+	bound := *h
+	bound.ctx = ctx
+	return &bound
+}
+
+// WithPrimaryReads returns a store whose reads go to the primary even when a replica is configured
+// Use it where a request reads back what it just wrote, which a lagging replica may not have yet
+func (h *HybridStore) WithPrimaryReads() Store {
+	return h.primaryReads()
+}
+
+// primaryReads is WithPrimaryReads for use inside the store
+func (h *HybridStore) primaryReads() *HybridStore {
+	bound := *h
+	bound.primaryRead = true
+	return &bound
+}
+
+// context returns the context database operations are bound to
+func (h *HybridStore) context() context.Context {
+	if h.ctx == nil {
+		return context.Background()
+	}
+	return h.ctx
+}
+
+// tenant returns the tenant the store's context is scoped to, or "" when it is unscoped
+func (h *HybridStore) tenant() string {
+	return TenantFromContext(h.ctx)
+}
+
+// memory returns the memory store scoped to the store's tenant
+func (h *HybridStore) memory() *MemoryStore {
+	return h.memoryStore.ForTenant(h.tenant())
+}
+
+// db returns the database service bound to the store's context and scoped to its tenant
+func (h *HybridStore) db() *DatabaseService {
+	if h.ctx == nil {
+		return h.dbService
+	}
+	return h.dbService.WithContext(h.ctx).ForTenant(h.tenant())
+}
+
+// dbWrite runs a database write, retrying transient failures
+// idempotent marks writes that are harmless to repeat when their outcome is unknown. Inserts and
+// increments are not: a connection lost after the commit would repeat them or fail on the primary key.
+// Constraint violations are returned as ErrAlreadyExists or ErrConstraint.
+func (h *HybridStore) dbWrite(idempotent bool, fn func(db *DatabaseService) error) error {
+	db := h.db()
+	return translateConstraintError(withRetry(h.context(), writeRetryPolicy, retryOperationWrite, idempotent, func() error {
+		return fn(db)
+	}))
+}
+
+// dbRead runs a database read, retrying a transient failure once
+// Reads go to the replica unless the store was created with WithPrimaryReads
+func dbRead[T any](h *HybridStore, fn func(db *DatabaseService) (T, error)) (T, error) {
+	db := h.db()
+	if !h.primaryRead {
+		db = db.Reader()
+	}
+	var result T
+	err := withRetry(h.context(), readRetryPolicy, retryOperationRead, true, func() error {
+		var err error
+		result, err = fn(db)
+		return err
+	})
+	return result, err
+}
+
 // AutoDetectBackend automatically detects which backend to use based on environment
 func AutoDetectBackend() StoreBackend {
 	if databaseURL := os.Getenv("DATABASE_URL"); databaseURL != "" {
@@ -63,28 +179,103 @@ func AutoDetectBackend() StoreBackend {
 }
 
 // CreateInterview creates a new interview using the configured backend
-func (h *HybridStore) CreateInterview(interview *Interview) error {
+func (h *HybridStore) CreateInterview(interview *Interview) (err error) {
+	defer h.track("CreateInterview")(&err)
+	interview.utc()
 	if h.backend == BackendDatabase && h.dbService != nil {
-		return h.dbService.InterviewRepo.Create(interview)
+		return h.dbWrite(false, func(db *DatabaseService) error { return db.InterviewRepo.Create(interview) })
 	}
-	return h.memoryStore.CreateInterview(interview)
+	return h.memory().CreateInterview(interview)
+}
+
+// CreateInterviewWithSession creates an interview together with its first chat session
+// Either both are stored or neither is
+func (h *HybridStore) CreateInterviewWithSession(interview *Interview, session *ChatSession) (err error) {
+	defer h.track("CreateInterviewWithSession")(&err)
+	interview.utc()
+	session.utc()
+	if h.backend == BackendDatabase && h.dbService != nil {
+		return h.dbWrite(false, func(db *DatabaseService) error {
+			return db.Transaction(func(tx *gorm.DB) error {
+				if err := newInterviewRepository(tx, db.tenantID).Create(interview); err != nil {
+					return err
+				}
+				return newChatSessionRepository(tx, db.tenantID).Create(session)
+			})
+		})
+	}
+	return h.memory().CreateInterviewWithSession(interview, session)
 }
 
 // GetInterview retrieves an interview by ID
-func (h *HybridStore) GetInterview(id string) (*Interview, error) {
+func (h *HybridStore) GetInterview(id string) (_ *Interview, err error) {
+	defer h.track("GetInterview")(&err)
+	if h.backend == BackendDatabase && h.dbService != nil {
+		return dbRead(h, func(db *DatabaseService) (*Interview, error) { return db.InterviewRepo.GetByID(id) })
+	}
+	return h.memory().GetInterview(id)
+}
+
+// UpdateInterview updates an interview's status and scheduling window
+func (h *HybridStore) UpdateInterview(interview *Interview) (err error) {
+	defer h.track("UpdateInterview")(&err)
+	interview.utc()
+	if h.backend == BackendDatabase && h.dbService != nil {
+		updates := map[string]interface{}{
+			"status":          interview.Status,
+			"scheduled_start": interview.ScheduledStart,
+			"scheduled_end":   interview.ScheduledEnd,
+		}
+		return h.dbWrite(true, func(db *DatabaseService) error { return db.InterviewRepo.Update(interview.ID, updates) })
+	}
+	return h.memory().UpdateInterview(interview)
+}
+
+// SetInterviewJobDescriptionSummary caches the prompt summary of an interview's job description
+// The job description itself is left untouched
+func (h *HybridStore) SetInterviewJobDescriptionSummary(id, summary string) (err error) {
+	defer h.track("SetInterviewJobDescriptionSummary")(&err)
+	if h.backend == BackendDatabase && h.dbService != nil {
+		updates := map[string]interface{}{"job_description_summary": summary}
+		return h.dbWrite(true, func(db *DatabaseService) error { return db.InterviewRepo.Update(id, updates) })
+	}
+	return h.memory().SetInterviewJobDescriptionSummary(id, summary)
+}
+
+// SetInterviewQuestionsStatus records the progress of generating an interview's questions
+// The questions replace the interview's when given; failure is the reason generation failed
+func (h *HybridStore) SetInterviewQuestionsStatus(id, status string, questions QuestionDetailList, failure string) (err error) {
+	defer h.track("SetInterviewQuestionsStatus")(&err)
+	if h.backend == BackendDatabase && h.dbService != nil {
+		updates := map[string]interface{}{"questions_status": status, "questions_error": failure}
+		if questions != nil {
+			updates["questions"] = StringArray(questions.Texts())
+			updates["question_details"] = questions
+		}
+		return h.dbWrite(true, func(db *DatabaseService) error { return db.InterviewRepo.Update(id, updates) })
+	}
+	return h.memory().SetInterviewQuestionsStatus(id, status, questions, failure)
+}
+
+// RecordInterviewOutcome sets an interview's hiring outcome, keeping earlier outcomes in its history
+func (h *HybridStore) RecordInterviewOutcome(id, outcome, note string) (err error) {
+	defer h.track("RecordInterviewOutcome")(&err)
 	if h.backend == BackendDatabase && h.dbService != nil {
-		return h.dbService.InterviewRepo.GetByID(id)
+		// Recording again after an unknown outcome could add the history entry twice
+		return h.dbWrite(false, func(db *DatabaseService) error { return db.InterviewRepo.RecordOutcome(id, outcome, note) })
 	}
-	return h.memoryStore.GetInterview(id)
+	return h.memory().RecordInterviewOutcome(id, outcome, note)
 }
 
 // GetInterviewsWithOptions retrieves interviews with pagination, filtering, and sorting
-func (h *HybridStore) GetInterviewsWithOptions(options ListInterviewsOptions) (*ListInterviewsResult, error) {
+func (h *HybridStore) GetInterviewsWithOptions(options ListInterviewsOptions) (_ *ListInterviewsResult, err error) {
+	defer h.track("GetInterviewsWithOptions")(&err)
 	if h.backend == BackendDatabase && h.dbService != nil {
 		// Convert to database filters
 		filters := InterviewFilters{
 			CandidateName: options.CandidateName,
 			Status:        options.Status,
+			Outcome:       options.Outcome,
 		}
 		if !options.DateFrom.IsZero() {
 			filters.CreatedAfter = options.DateFrom
@@ -92,8 +283,15 @@ func (h *HybridStore) GetInterviewsWithOptions(options ListInterviewsOptions) (*
 		if !options.DateTo.IsZero() {
 			filters.CreatedBefore = options.DateTo
 		}
+		filters.ScheduledAfter = options.ScheduledAfter
+		filters.ScheduledBefore = options.ScheduledBefore
 
-		interviews, total, err := h.dbService.InterviewRepo.List(options.Limit, options.Offset, filters)
+		var total int64
+		interviews, err := dbRead(h, func(db *DatabaseService) ([]*Interview, error) {
+			interviews, count, err := db.InterviewRepo.List(options.Limit, options.Offset, filters)
+			total = count
+			return interviews, err
+		})
 		if err != nil {
 			return nil, err
 		}
@@ -117,69 +315,521 @@ func (h *HybridStore) GetInterviewsWithOptions(options ListInterviewsOptions) (*
 	}
 
 	// Fallback to memory store
-	return h.memoryStore.GetInterviewsWithOptions(options)
+	return h.memory().GetInterviewsWithOptions(options)
+}
+
+// GetInterviewsGroupedByCandidate retrieves interviews grouped by candidate, paginated over candidates
+func (h *HybridStore) GetInterviewsGroupedByCandidate(options CandidateGroupOptions) (_ *CandidateGroupsResult, err error) {
+	defer h.track("GetInterviewsGroupedByCandidate")(&err)
+	if h.backend == BackendDatabase && h.dbService != nil {
+		if options.Limit <= 0 {
+			options.Limit = 10
+		}
+		if options.Offset < 0 {
+			options.Offset = 0
+		}
+		var total int64
+		groups, err := dbRead(h, func(db *DatabaseService) ([]*CandidateGroup, error) {
+			groups, count, err := db.InterviewRepo.GetGroupedByCandidate(options.Limit, options.Offset, options.SortBy)
+			total = count
+			return groups, err
+		})
+		if err != nil {
+			return nil, err
+		}
+		return &CandidateGroupsResult{
+			Groups: groups,
+			Total:  int(total),
+		}, nil
+	}
+	return h.memory().GetInterviewsGroupedByCandidate(options)
 }
 
 // CreateEvaluation creates a new evaluation
-func (h *HybridStore) CreateEvaluation(evaluation *Evaluation) error {
+func (h *HybridStore) CreateEvaluation(evaluation *Evaluation) (err error) {
+	defer h.track("CreateEvaluation")(&err)
+	toUTC(&evaluation.CreatedAt, &evaluation.UpdatedAt)
 	if h.backend == BackendDatabase && h.dbService != nil {
-		return h.dbService.EvaluationRepo.Create(evaluation)
+		return h.dbWrite(false, func(db *DatabaseService) error { return db.EvaluationRepo.Create(evaluation) })
 	}
-	return h.memoryStore.CreateEvaluation(evaluation)
+	return h.memory().CreateEvaluation(evaluation)
 }
 
 // GetEvaluation retrieves an evaluation by ID
-func (h *HybridStore) GetEvaluation(id string) (*Evaluation, error) {
+func (h *HybridStore) GetEvaluation(id string) (_ *Evaluation, err error) {
+	defer h.track("GetEvaluation")(&err)
+	if h.backend == BackendDatabase && h.dbService != nil {
+		return dbRead(h, func(db *DatabaseService) (*Evaluation, error) { return db.EvaluationRepo.GetByID(id) })
+	}
+	return h.memory().GetEvaluation(id)
+}
+
+// GetLatestEvaluationByInterview retrieves the current (non-superseded) evaluation for an interview
+func (h *HybridStore) GetLatestEvaluationByInterview(interviewID string) (_ *Evaluation, err error) {
+	defer h.track("GetLatestEvaluationByInterview")(&err)
+	if h.backend == BackendDatabase && h.dbService != nil {
+		return dbRead(h, func(db *DatabaseService) (*Evaluation, error) {
+			return db.EvaluationRepo.GetLatestByInterviewID(interviewID)
+		})
+	}
+	return h.memory().GetLatestEvaluationByInterview(interviewID)
+}
+
+// GetLatestEvaluationsByInterviews retrieves the current evaluation of each of the interviews in one
+// read, keyed by interview ID; interviews without one are left out
+func (h *HybridStore) GetLatestEvaluationsByInterviews(interviewIDs []string) (_ map[string]*Evaluation, err error) {
+	defer h.track("GetLatestEvaluationsByInterviews")(&err)
+	if h.backend == BackendDatabase && h.dbService != nil {
+		return dbRead(h, func(db *DatabaseService) (map[string]*Evaluation, error) {
+			return db.EvaluationRepo.GetLatestByInterviewIDs(interviewIDs)
+		})
+	}
+	return h.memory().GetLatestEvaluationsByInterviews(interviewIDs)
+}
+
+// SupersedeEvaluation voids an evaluation without replacing it: it stays stored (and readable by
+// ID) but is no longer the interview's current evaluation
+func (h *HybridStore) SupersedeEvaluation(id string) (err error) {
+	defer h.track("SupersedeEvaluation")(&err)
+	if h.backend == BackendDatabase && h.dbService != nil {
+		return h.dbWrite(true, func(db *DatabaseService) error {
+			return db.EvaluationRepo.Update(id, map[string]interface{}{"status": EvaluationStatusSuperseded})
+		})
+	}
+	return h.memory().SupersedeEvaluation(id)
+}
+
+// GetEvaluationScoresByModel averages evaluation scores per AI provider and model
+func (h *HybridStore) GetEvaluationScoresByModel() (_ []*ModelScoreStats, err error) {
+	defer h.track("GetEvaluationScoresByModel")(&err)
+	if h.backend == BackendDatabase && h.dbService != nil {
+		return dbRead(h, func(db *DatabaseService) ([]*ModelScoreStats, error) { return db.EvaluationRepo.GetScoresByModel() })
+	}
+	return h.memory().GetEvaluationScoresByModel()
+}
+
+// GetEvaluationScoresByOutcome averages evaluation scores per recorded interview outcome
+func (h *HybridStore) GetEvaluationScoresByOutcome() (_ []*OutcomeScoreStats, err error) {
+	defer h.track("GetEvaluationScoresByOutcome")(&err)
 	if h.backend == BackendDatabase && h.dbService != nil {
-		return h.dbService.EvaluationRepo.GetByID(id)
+		return dbRead(h, func(db *DatabaseService) ([]*OutcomeScoreStats, error) { return db.EvaluationRepo.GetScoresByOutcome() })
 	}
-	return h.memoryStore.GetEvaluation(id)
+	return h.memory().GetEvaluationScoresByOutcome()
+}
+
+// GetScoreDistribution returns the sorted scores of the reference cohort of an interview type and model
+func (h *HybridStore) GetScoreDistribution(interviewType, model string, since time.Time) (_ []float64, err error) {
+	defer h.track("GetScoreDistribution")(&err)
+	if h.backend == BackendDatabase && h.dbService != nil {
+		return dbRead(h, func(db *DatabaseService) ([]float64, error) {
+			return db.EvaluationRepo.GetScoreDistribution(interviewType, model, since)
+		})
+	}
+	return h.memory().GetScoreDistribution(interviewType, model, since)
+}
+
+// CreateEvaluationTrace stores the trace of an evaluation
+func (h *HybridStore) CreateEvaluationTrace(trace *EvaluationTrace) (err error) {
+	defer h.track("CreateEvaluationTrace")(&err)
+	if h.backend == BackendDatabase && h.dbService != nil {
+		return h.dbWrite(false, func(db *DatabaseService) error { return db.EvaluationRepo.CreateTrace(trace) })
+	}
+	return h.memory().CreateEvaluationTrace(trace)
+}
+
+// GetEvaluationTrace retrieves the trace of an evaluation
+func (h *HybridStore) GetEvaluationTrace(evaluationID string) (_ *EvaluationTrace, err error) {
+	defer h.track("GetEvaluationTrace")(&err)
+	if h.backend == BackendDatabase && h.dbService != nil {
+		return dbRead(h, func(db *DatabaseService) (*EvaluationTrace, error) { return db.EvaluationRepo.GetTrace(evaluationID) })
+	}
+	return h.memory().GetEvaluationTrace(evaluationID)
+}
+
+// GetEvaluationDecisionCounts counts recommendation decisions per interview type
+func (h *HybridStore) GetEvaluationDecisionCounts() (_ []*DecisionCount, err error) {
+	defer h.track("GetEvaluationDecisionCounts")(&err)
+	if h.backend == BackendDatabase && h.dbService != nil {
+		return dbRead(h, func(db *DatabaseService) ([]*DecisionCount, error) { return db.EvaluationRepo.GetDecisionCounts() })
+	}
+	return h.memory().GetEvaluationDecisionCounts()
 }
 
 // CreateChatSession creates a new chat session
-func (h *HybridStore) CreateChatSession(session *ChatSession) error {
+func (h *HybridStore) CreateChatSession(session *ChatSession) (err error) {
+	defer h.track("CreateChatSession")(&err)
+	session.utc()
 	if h.backend == BackendDatabase && h.dbService != nil {
-		return h.dbService.ChatSessionRepo.Create(session)
+		return h.dbWrite(false, func(db *DatabaseService) error { return db.ChatSessionRepo.Create(session) })
 	}
-	return h.memoryStore.CreateChatSession(session)
+	return h.memory().CreateChatSession(session)
 }
 
 // GetChatSession retrieves a chat session by ID
-func (h *HybridStore) GetChatSession(id string) (*ChatSession, error) {
+func (h *HybridStore) GetChatSession(id string) (_ *ChatSession, err error) {
+	defer h.track("GetChatSession")(&err)
 	if h.backend == BackendDatabase && h.dbService != nil {
-		return h.dbService.ChatSessionRepo.GetByID(id)
+		return dbRead(h, func(db *DatabaseService) (*ChatSession, error) { return db.ChatSessionRepo.GetByID(id) })
 	}
-	return h.memoryStore.GetChatSession(id)
+	return h.memory().GetChatSession(id)
 }
 
 // UpdateChatSession updates a chat session
-func (h *HybridStore) UpdateChatSession(session *ChatSession) error {
+func (h *HybridStore) UpdateChatSession(session *ChatSession) (err error) {
+	defer h.track("UpdateChatSession")(&err)
+	session.utc()
 	if h.backend == BackendDatabase && h.dbService != nil {
 		updates := map[string]interface{}{
-			"status":   session.Status,
-			"ended_at": session.EndedAt,
+			"status":               session.Status,
+			"language":             session.SessionLanguage,
+			"ended_at":             session.EndedAt,
+			"conversation_summary": session.ConversationSummary,
+			"summarized_turns":     session.SummarizedTurns,
 		}
-		return h.dbService.ChatSessionRepo.Update(session.ID, updates)
+		return h.dbWrite(true, func(db *DatabaseService) error { return db.ChatSessionRepo.Update(session.ID, updates) })
+	}
+	return h.memory().UpdateChatSession(session)
+}
+
+// AppendAskedQuestion records a question the AI asked during a chat session
+func (h *HybridStore) AppendAskedQuestion(sessionID, question string) (err error) {
+	defer h.track("AppendAskedQuestion")(&err)
+	if h.backend == BackendDatabase && h.dbService != nil {
+		// Appending again after an unknown outcome could record the question twice
+		return h.dbWrite(false, func(db *DatabaseService) error { return db.ChatSessionRepo.AppendAskedQuestion(sessionID, question) })
 	}
-	return h.memoryStore.UpdateChatSession(session)
+	return h.memory().AppendAskedQuestion(sessionID, question)
+}
+
+// AppendDifficultyLevel records a new adaptive difficulty level on a chat session
+func (h *HybridStore) AppendDifficultyLevel(sessionID string, level int) (err error) {
+	defer h.track("AppendDifficultyLevel")(&err)
+	if h.backend == BackendDatabase && h.dbService != nil {
+		// Appending again after an unknown outcome could record the level twice
+		return h.dbWrite(false, func(db *DatabaseService) error { return db.ChatSessionRepo.AppendDifficultyLevel(sessionID, level) })
+	}
+	return h.memory().AppendDifficultyLevel(sessionID, level)
+}
+
+// PinChatSessionProvider records the AI provider and model a chat session's turns are answered by
+func (h *HybridStore) PinChatSessionProvider(sessionID, provider, model string) (err error) {
+	defer h.track("PinChatSessionProvider")(&err)
+	if h.backend == BackendDatabase && h.dbService != nil {
+		return h.dbWrite(true, func(db *DatabaseService) error {
+			return db.ChatSessionRepo.Update(sessionID, map[string]interface{}{"provider": provider, "model": model})
+		})
+	}
+	return h.memory().PinChatSessionProvider(sessionID, provider, model)
+}
+
+// GetSessionDurationsByInterviewType averages the duration of ended chat sessions per interview type
+func (h *HybridStore) GetSessionDurationsByInterviewType() (_ []*SessionDurationStats, err error) {
+	defer h.track("GetSessionDurationsByInterviewType")(&err)
+	if h.backend == BackendDatabase && h.dbService != nil {
+		return dbRead(h, func(db *DatabaseService) ([]*SessionDurationStats, error) {
+			return db.ChatSessionRepo.GetDurationsByInterviewType()
+		})
+	}
+	return h.memory().GetSessionDurationsByInterviewType()
+}
+
+// GetChatSessionsByInterview lists all chat sessions of an interview, oldest first
+func (h *HybridStore) GetChatSessionsByInterview(interviewID string) (_ []*ChatSession, err error) {
+	defer h.track("GetChatSessionsByInterview")(&err)
+	if h.backend == BackendDatabase && h.dbService != nil {
+		return dbRead(h, func(db *DatabaseService) ([]*ChatSession, error) {
+			return db.ChatSessionRepo.ListByInterviewID(interviewID)
+		})
+	}
+	return h.memory().GetChatSessionsByInterview(interviewID)
+}
+
+// AddChatSessionCost adds amount to a chat session's estimated AI cost
+func (h *HybridStore) AddChatSessionCost(sessionID string, amount float64) (err error) {
+	defer h.track("AddChatSessionCost")(&err)
+	if h.backend == BackendDatabase && h.dbService != nil {
+		// Adding again after an unknown outcome could count the cost twice
+		return h.dbWrite(false, func(db *DatabaseService) error { return db.ChatSessionRepo.AddEstimatedCost(sessionID, amount) })
+	}
+	return h.memory().AddChatSessionCost(sessionID, amount)
+}
+
+// GetCompletedSessionsWithoutEvaluation lists completed chat sessions whose interview has no
+// evaluation, oldest first, for backfilling evaluations. A limit of 0 means no limit.
+func (h *HybridStore) GetCompletedSessionsWithoutEvaluation(limit int) (_ []*ChatSession, err error) {
+	defer h.track("GetCompletedSessionsWithoutEvaluation")(&err)
+	if h.backend == BackendDatabase && h.dbService != nil {
+		return dbRead(h, func(db *DatabaseService) ([]*ChatSession, error) {
+			return db.ChatSessionRepo.GetCompletedWithoutEvaluation(limit)
+		})
+	}
+	return h.memory().GetCompletedSessionsWithoutEvaluation(limit)
+}
+
+// GetIdleChatSessions lists active chat sessions with no heartbeat or message since cutoff, oldest first
+// A limit of 0 means no limit.
+func (h *HybridStore) GetIdleChatSessions(cutoff time.Time, limit int) (_ []*ChatSession, err error) {
+	defer h.track("GetIdleChatSessions")(&err)
+	cutoff = cutoff.UTC()
+	if h.backend == BackendDatabase && h.dbService != nil {
+		return dbRead(h, func(db *DatabaseService) ([]*ChatSession, error) { return db.ChatSessionRepo.GetIdle(cutoff, limit) })
+	}
+	return h.memory().GetIdleChatSessions(cutoff, limit)
+}
+
+// AbandonIdleChatSession marks a session abandoned at at if it is still active with no heartbeat or
+// message since cutoff. Returns false when activity landed since the session was found idle.
+func (h *HybridStore) AbandonIdleChatSession(sessionID string, cutoff, at time.Time) (_ bool, err error) {
+	defer h.track("AbandonIdleChatSession")(&err)
+	cutoff, at = cutoff.UTC(), at.UTC()
+	if h.backend == BackendDatabase && h.dbService != nil {
+		var abandoned bool
+		// Conditional on the session being active, so repeating it after an unknown outcome is harmless
+		err := h.dbWrite(true, func(db *DatabaseService) error {
+			var err error
+			abandoned, err = db.ChatSessionRepo.AbandonIdle(sessionID, cutoff, at)
+			return err
+		})
+		return abandoned, err
+	}
+	return h.memory().AbandonIdleChatSession(sessionID, cutoff, at)
+}
+
+// RecordChatSessionHeartbeat records client activity on a chat session at most once per minInterval
+// Returns false when the heartbeat was rate-limited
+func (h *HybridStore) RecordChatSessionHeartbeat(sessionID string, at time.Time, minInterval time.Duration) (_ bool, err error) {
+	defer h.track("RecordChatSessionHeartbeat")(&err)
+	at = at.UTC()
+	if h.backend == BackendDatabase && h.dbService != nil {
+		var recorded bool
+		// Conditional on the previous heartbeat, so repeating it after an unknown outcome is harmless
+		err := h.dbWrite(true, func(db *DatabaseService) error {
+			var err error
+			recorded, err = db.ChatSessionRepo.RecordHeartbeat(sessionID, at, minInterval)
+			return err
+		})
+		return recorded, err
+	}
+	return h.memory().RecordChatSessionHeartbeat(sessionID, at, minInterval)
+}
+
+// RecordChatSessionAIAttempt counts one provider call against the session's attempt budget
+// Returns false when the session already made maxAttempts calls; a maxAttempts of 0 means no limit
+func (h *HybridStore) RecordChatSessionAIAttempt(sessionID string, maxAttempts int) (_ bool, err error) {
+	defer h.track("RecordChatSessionAIAttempt")(&err)
+	if h.backend == BackendDatabase && h.dbService != nil {
+		var recorded bool
+		// Counting again after an unknown outcome could charge the call twice
+		err := h.dbWrite(false, func(db *DatabaseService) error {
+			var err error
+			recorded, err = db.ChatSessionRepo.RecordAIAttempt(sessionID, maxAttempts)
+			return err
+		})
+		return recorded, err
+	}
+	return h.memory().RecordChatSessionAIAttempt(sessionID, maxAttempts)
+}
+
+// RecordChatSessionAbuse counts one candidate message refused by the anti-abuse checks for reason
+func (h *HybridStore) RecordChatSessionAbuse(sessionID, reason string) (err error) {
+	defer h.track("RecordChatSessionAbuse")(&err)
+	if h.backend == BackendDatabase && h.dbService != nil {
+		// Counting again after an unknown outcome would count the refusal twice
+		return h.dbWrite(false, func(db *DatabaseService) error {
+			return db.ChatSessionRepo.RecordAbuse(sessionID, reason)
+		})
+	}
+	return h.memory().RecordChatSessionAbuse(sessionID, reason)
+}
+
+// ReopenChatSession returns a session completed at or after endedAfter to active, clearing its
+// end time and counting the reopen. evaluationID is recorded as the evaluation the session's next
+// evaluation supersedes. Returns false when the session is not completed or ended before endedAfter.
+func (h *HybridStore) ReopenChatSession(sessionID string, endedAfter time.Time, evaluationID string) (_ bool, err error) {
+	defer h.track("ReopenChatSession")(&err)
+	endedAfter = endedAfter.UTC()
+	if h.backend == BackendDatabase && h.dbService != nil {
+		var reopened bool
+		// Reopening again after an unknown outcome would count the reopen twice
+		err := h.dbWrite(false, func(db *DatabaseService) error {
+			var err error
+			reopened, err = db.ChatSessionRepo.Reopen(sessionID, endedAfter, evaluationID)
+			return err
+		})
+		return reopened, err
+	}
+	return h.memory().ReopenChatSession(sessionID, endedAfter, evaluationID)
+}
+
+// PurgeMessagesOlderThan deletes the messages sent before cutoff in sessions that are no longer
+// active, along with their conversation summaries; the sessions, their evaluations and their
+// other metadata are kept, and the sessions are marked purged. Returns the number of messages deleted.
+func (h *HybridStore) PurgeMessagesOlderThan(cutoff time.Time) (_ int, err error) {
+	defer h.track("PurgeMessagesOlderThan")(&err)
+	cutoff = cutoff.UTC()
+	if h.backend == BackendDatabase && h.dbService != nil {
+		var purged int64
+		// Purging again after an unknown outcome deletes nothing more than the first attempt would
+		err := h.dbWrite(true, func(db *DatabaseService) error {
+			var err error
+			purged, err = db.ChatSessionRepo.PurgeMessagesBefore(cutoff)
+			return err
+		})
+		return int(purged), err
+	}
+	return h.memory().PurgeMessagesOlderThan(cutoff)
+}
+
+// CountMessagesOlderThan counts the messages PurgeMessagesOlderThan would delete, deleting nothing
+func (h *HybridStore) CountMessagesOlderThan(cutoff time.Time) (_ int, err error) {
+	defer h.track("CountMessagesOlderThan")(&err)
+	cutoff = cutoff.UTC()
+	if h.backend == BackendDatabase && h.dbService != nil {
+		count, err := dbRead(h, func(db *DatabaseService) (int64, error) {
+			return db.ChatSessionRepo.CountMessagesBefore(cutoff)
+		})
+		return int(count), err
+	}
+	return h.memory().CountMessagesOlderThan(cutoff)
+}
+
+// GetInterviewEstimatedCost returns the total estimated AI cost of an interview
+// Session costs exclude evaluations, so the two are summed without double counting
+func (h *HybridStore) GetInterviewEstimatedCost(interviewID string) (_ float64, err error) {
+	defer h.track("GetInterviewEstimatedCost")(&err)
+	if h.backend == BackendDatabase && h.dbService != nil {
+		return dbRead(h, func(db *DatabaseService) (float64, error) { return db.InterviewRepo.GetEstimatedCost(interviewID) })
+	}
+	return h.memory().GetInterviewEstimatedCost(interviewID)
 }
 
 // AddChatMessage adds a message to a chat session
-func (h *HybridStore) AddChatMessage(sessionID string, message *ChatMessage) error {
+func (h *HybridStore) AddChatMessage(sessionID string, message *ChatMessage) (err error) {
+	defer h.track("AddChatMessage")(&err)
+	toUTC(&message.Timestamp, &message.CreatedAt)
 	if h.backend == BackendDatabase && h.dbService != nil {
-		return h.dbService.ChatSessionRepo.AddMessage(sessionID, message)
+		return h.dbWrite(false, func(db *DatabaseService) error { return db.ChatSessionRepo.AddMessage(sessionID, message) })
 	}
 	// Memory store expects message with SessionID already set
 	message.SessionID = sessionID
-	return h.memoryStore.AddChatMessage(message)
+	return h.memory().AddChatMessage(message)
+}
+
+// AddChatMessageWithLimit adds a message unless the session already holds maxMessages messages,
+// returning ErrMessageLimitReached in that case. A maxMessages of 0 means no limit.
+func (h *HybridStore) AddChatMessageWithLimit(sessionID string, message *ChatMessage, maxMessages int) (err error) {
+	defer h.track("AddChatMessageWithLimit")(&err)
+	toUTC(&message.Timestamp, &message.CreatedAt)
+	if maxMessages <= 0 {
+		return h.AddChatMessage(sessionID, message)
+	}
+	if h.backend == BackendDatabase && h.dbService != nil {
+		return h.dbWrite(false, func(db *DatabaseService) error {
+			return db.ChatSessionRepo.AddMessageWithLimit(sessionID, message, maxMessages)
+		})
+	}
+	message.SessionID = sessionID
+	return h.memory().AddChatMessageWithLimit(message, maxMessages)
 }
 
 // GetChatMessages retrieves all messages for a chat session
-func (h *HybridStore) GetChatMessages(sessionID string) ([]*ChatMessage, error) {
+func (h *HybridStore) GetChatMessages(sessionID string) (_ []*ChatMessage, err error) {
+	defer h.track("GetChatMessages")(&err)
+	if h.backend == BackendDatabase && h.dbService != nil {
+		return dbRead(h, func(db *DatabaseService) ([]*ChatMessage, error) { return db.ChatSessionRepo.GetMessages(sessionID) })
+	}
+	return h.memory().GetChatMessages(sessionID)
+}
+
+// GetChatMessagesWithOptions retrieves a window of a chat session's messages, oldest first
+func (h *HybridStore) GetChatMessagesWithOptions(sessionID string, options ListMessagesOptions) (_ *ListMessagesResult, err error) {
+	defer h.track("GetChatMessagesWithOptions")(&err)
+	if h.backend == BackendDatabase && h.dbService != nil {
+		var total int64
+		messages, err := dbRead(h, func(db *DatabaseService) ([]*ChatMessage, error) {
+			messages, count, err := db.ChatSessionRepo.GetMessagesPage(sessionID, options)
+			total = count
+			return messages, err
+		})
+		if err != nil {
+			return nil, err
+		}
+		return &ListMessagesResult{Messages: messages, Total: int(total)}, nil
+	}
+	return h.memory().GetChatMessagesWithOptions(sessionID, options)
+}
+
+// GetChatMessageByClientID retrieves a message by its client-provided ID within a session
+func (h *HybridStore) GetChatMessageByClientID(sessionID, clientMessageID string) (_ *ChatMessage, err error) {
+	defer h.track("GetChatMessageByClientID")(&err)
+	if h.backend == BackendDatabase && h.dbService != nil {
+		return dbRead(h, func(db *DatabaseService) (*ChatMessage, error) {
+			return db.ChatSessionRepo.GetMessageByClientID(sessionID, clientMessageID)
+		})
+	}
+	return h.memory().GetChatMessageByClientID(sessionID, clientMessageID)
+}
+
+// CreateNotification adds a notification to the outbox
+func (h *HybridStore) CreateNotification(notification *Notification) (err error) {
+	defer h.track("CreateNotification")(&err)
 	if h.backend == BackendDatabase && h.dbService != nil {
-		return h.dbService.ChatSessionRepo.GetMessages(sessionID)
+		return h.dbWrite(false, func(db *DatabaseService) error { return db.NotificationRepo.Create(notification) })
 	}
-	return h.memoryStore.GetChatMessages(sessionID)
+	return h.memory().CreateNotification(notification)
+}
+
+// GetDueNotifications lists pending notifications due at now, earliest first
+// Reads the primary, since notifications are usually due right after they are written.
+// A limit of 0 means no limit.
+func (h *HybridStore) GetDueNotifications(now time.Time, limit int) (_ []*Notification, err error) {
+	defer h.track("GetDueNotifications")(&err)
+	if h.backend == BackendDatabase && h.dbService != nil {
+		return dbRead(h.primaryReads(), func(db *DatabaseService) ([]*Notification, error) { return db.NotificationRepo.GetDue(now, limit) })
+	}
+	return h.memory().GetDueNotifications(now, limit)
+}
+
+// UpdateNotification records a notification's attempts, next attempt, status and last error
+func (h *HybridStore) UpdateNotification(notification *Notification) (err error) {
+	defer h.track("UpdateNotification")(&err)
+	if h.backend == BackendDatabase && h.dbService != nil {
+		updates := map[string]interface{}{
+			"attempts":        notification.Attempts,
+			"next_attempt_at": notification.NextAttemptAt,
+			"status":          notification.Status,
+			"last_error":      notification.LastError,
+		}
+		return h.dbWrite(true, func(db *DatabaseService) error { return db.NotificationRepo.Update(notification.ID, updates) })
+	}
+	return h.memory().UpdateNotification(notification)
+}
+
+// GetNotificationStats counts the outbox's notifications by status
+func (h *HybridStore) GetNotificationStats() (_ *NotificationStats, err error) {
+	defer h.track("GetNotificationStats")(&err)
+	if h.backend == BackendDatabase && h.dbService != nil {
+		return dbRead(h, func(db *DatabaseService) (*NotificationStats, error) { return db.NotificationRepo.GetStats() })
+	}
+	return h.memory().GetNotificationStats()
+}
+
+// CreateCalibrationRun stores a calibration run
+func (h *HybridStore) CreateCalibrationRun(run *CalibrationRun) (err error) {
+	defer h.track("CreateCalibrationRun")(&err)
+	if h.backend == BackendDatabase && h.dbService != nil {
+		return h.dbWrite(false, func(db *DatabaseService) error { return db.CalibrationRepo.Create(run) })
+	}
+	return h.memory().CreateCalibrationRun(run)
+}
+
+// GetCalibrationRun retrieves a calibration run by ID
+func (h *HybridStore) GetCalibrationRun(id string) (_ *CalibrationRun, err error) {
+	defer h.track("GetCalibrationRun")(&err)
+	if h.backend == BackendDatabase && h.dbService != nil {
+		return dbRead(h, func(db *DatabaseService) (*CalibrationRun, error) { return db.CalibrationRepo.GetByID(id) })
+	}
+	return h.memory().GetCalibrationRun(id)
 }
 
 // GetBackend returns the current backend type
@@ -195,6 +845,16 @@ func (h *HybridStore) Health() error {
 	return nil // Memory store is always healthy
 }
 
+// Maintenance vacuums the database's hot tables and reports the size of every table, or reports
+// the entries of the memory store. It covers every tenant and, bounded by ctx alone, is not retried.
+func (h *HybridStore) Maintenance(ctx context.Context) (_ *MaintenanceReport, err error) {
+	defer h.track("Maintenance")(&err)
+	if h.backend == BackendDatabase && h.dbService != nil {
+		return h.dbService.WithContext(ctx).Maintenance()
+	}
+	return h.memoryStore.Maintenance()
+}
+
 // Close closes the hybrid store and cleans up resources
 func (h *HybridStore) Close() error {
 	if h.backend == BackendDatabase && h.dbService != nil {
@@ -206,16 +866,19 @@ func (h *HybridStore) Close() error {
 // Global hybrid store instance
 var GlobalStore *HybridStore
 
-// InitGlobalStore initializes the global store with auto-detected backend
-func InitGlobalStore() error {
-	backend := AutoDetectBackend()
-	databaseURL := os.Getenv("DATABASE_URL")
+// InitGlobalStore initializes the global store from the configured database URLs
+// An empty databaseURL selects the in-memory backend; replicaURL is optional
+func InitGlobalStore(databaseURL, replicaURL string) error {
+	backend := BackendMemory
+	if databaseURL != "" {
+		backend = BackendDatabase
+	}
 
-	store, err := NewHybridStore(backend, databaseURL)
+	store, err := NewHybridStoreWithReplica(backend, databaseURL, replicaURL)
 	if err != nil {
 		return err
 	}
 
-	GlobalStore = store
+	GlobalStore = WithInstrumentation(store)
 	return nil
 }
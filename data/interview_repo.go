@@ -2,7 +2,9 @@
 package data
 
 import (
+	"encoding/json"
 	"errors"
+	"strings"
 	"time"
 
 	"gorm.io/gorm"
@@ -13,8 +15,12 @@ type InterviewFilters struct {
 	CandidateName string
 	Status        string
 	Type          string
+	Outcome       string
 	CreatedAfter  time.Time
 	CreatedBefore time.Time
+	// Scheduling window filters match on scheduled_start; unscheduled interviews never match
+	ScheduledAfter  time.Time
+	ScheduledBefore time.Time
 }
 
 // InterviewRepository interface defines the contract for interview data access
@@ -23,31 +29,47 @@ type InterviewRepository interface {
 	GetByID(id string) (*Interview, error)
 	List(limit, offset int, filters InterviewFilters) ([]*Interview, int64, error)
 	Update(id string, updates map[string]interface{}) error
+	RecordOutcome(id, outcome, note string) error
 	Delete(id string) error
 	GetWithEvaluation(id string) (*Interview, *Evaluation, error)
+	GetEstimatedCost(id string) (float64, error)
+	GetGroupedByCandidate(limit, offset int, sortBy string) ([]*CandidateGroup, int64, error)
 }
 
 // interviewRepository implements InterviewRepository interface
 type interviewRepository struct {
-	db *gorm.DB
+	db       *gorm.DB
+	tenantID string // Scopes every query to one tenant; empty sees every tenant
 }
 
 // NewInterviewRepository creates a new interview repository
 func NewInterviewRepository(db *gorm.DB) InterviewRepository {
-	return &interviewRepository{db: db}
+	return newInterviewRepository(db, "")
+}
+
+// newInterviewRepository creates an interview repository scoped to tenantID
+func newInterviewRepository(db *gorm.DB, tenantID string) *interviewRepository {
+	return &interviewRepository{db: db, tenantID: tenantID}
+}
+
+// scoped limits query to the repository's tenant
+func (r *interviewRepository) scoped(query *gorm.DB) *gorm.DB {
+	return tenantScope(query, "tenant_id", r.tenantID)
 }
 
 // Create creates a new interview
 func (r *interviewRepository) Create(interview *Interview) error {
-	interview.CreatedAt = time.Now()
-	interview.UpdatedAt = time.Now()
+	interview.CandidateKey = CandidateNameKey(interview.CandidateName)
+	interview.QuestionDetails = SyncQuestionDetails(interview.Questions, interview.QuestionDetails)
+	stampTenant(r.tenantID, &interview.TenantID)
+	stampCreated(r.db.NowFunc(), &interview.CreatedAt, &interview.UpdatedAt)
 	return r.db.Create(interview).Error
 }
 
 // GetByID retrieves an interview by ID
 func (r *interviewRepository) GetByID(id string) (*Interview, error) {
 	var interview Interview
-	err := r.db.Where("id = ?", id).First(&interview).Error
+	err := r.scoped(r.db.Where("id = ?", id)).First(&interview).Error
 	if errors.Is(err, gorm.ErrRecordNotFound) {
 		return nil, errors.New("interview not found")
 	}
@@ -59,10 +81,10 @@ func (r *interviewRepository) List(limit, offset int, filters InterviewFilters)
 	var interviews []*Interview
 	var total int64
 
-	query := r.db.Model(&Interview{})
+	query := r.scoped(r.db.Model(&Interview{}))
 	// Apply filters
 	if filters.CandidateName != "" {
-		query = query.Where("candidate_name ILIKE ?", "%"+filters.CandidateName+"%")
+		query = query.Where("candidate_key LIKE ?", "%"+CandidateNameKey(filters.CandidateName)+"%")
 	}
 	if filters.Status != "" {
 		query = query.Where("status = ?", filters.Status)
@@ -70,12 +92,21 @@ func (r *interviewRepository) List(limit, offset int, filters InterviewFilters)
 	if filters.Type != "" {
 		query = query.Where("type = ?", filters.Type)
 	}
+	if filters.Outcome != "" {
+		query = query.Where("outcome = ?", filters.Outcome)
+	}
 	if !filters.CreatedAfter.IsZero() {
 		query = query.Where("created_at >= ?", filters.CreatedAfter)
 	}
 	if !filters.CreatedBefore.IsZero() {
 		query = query.Where("created_at <= ?", filters.CreatedBefore)
 	}
+	if !filters.ScheduledAfter.IsZero() {
+		query = query.Where("scheduled_start >= ?", filters.ScheduledAfter)
+	}
+	if !filters.ScheduledBefore.IsZero() {
+		query = query.Where("scheduled_start <= ?", filters.ScheduledBefore)
+	}
 
 	// Get total count
 	query.Count(&total)
@@ -87,13 +118,35 @@ func (r *interviewRepository) List(limit, offset int, filters InterviewFilters)
 
 // Update updates an interview
 func (r *interviewRepository) Update(id string, updates map[string]interface{}) error {
-	updates["updated_at"] = time.Now()
-	return r.db.Model(&Interview{}).Where("id = ?", id).Updates(updates).Error
+	updates["updated_at"] = r.db.NowFunc()
+	return r.scoped(r.db.Model(&Interview{}).Where("id = ?", id)).Updates(updates).Error
+}
+
+// RecordOutcome sets the interview's outcome and appends it to outcome_history atomically
+func (r *interviewRepository) RecordOutcome(id, outcome, note string) error {
+	now := r.db.NowFunc()
+	encoded, err := json.Marshal(OutcomeRecordList{{Outcome: outcome, Note: note, RecordedAt: now}})
+	if err != nil {
+		return err
+	}
+	result := r.scoped(r.db.Model(&Interview{}).Where("id = ?", id)).Updates(map[string]interface{}{
+		"outcome":         outcome,
+		"outcome_note":    note,
+		"outcome_history": gorm.Expr("COALESCE(outcome_history, '[]'::jsonb) || ?::jsonb", string(encoded)),
+		"updated_at":      now,
+	})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("interview not found")
+	}
+	return nil
 }
 
 // Delete deletes an interview (soft delete could be implemented here)
 func (r *interviewRepository) Delete(id string) error {
-	return r.db.Where("id = ?", id).Delete(&Interview{}).Error
+	return r.scoped(r.db.Where("id = ?", id)).Delete(&Interview{}).Error
 }
 
 // GetWithEvaluation retrieves an interview with its evaluation
@@ -101,7 +154,7 @@ func (r *interviewRepository) GetWithEvaluation(id string) (*Interview, *Evaluat
 	var interview Interview
 	var evaluation Evaluation
 
-	err := r.db.Where("id = ?", id).First(&interview).Error
+	err := r.scoped(r.db.Where("id = ?", id)).First(&interview).Error
 	if err != nil {
 		return nil, nil, err
 	}
@@ -114,6 +167,98 @@ func (r *interviewRepository) GetWithEvaluation(id string) (*Interview, *Evaluat
 	return &interview, &evaluation, err
 }
 
+// GetEstimatedCost sums the estimated AI cost of an interview: its chat sessions plus
+// every evaluation created for it, including superseded ones
+func (r *interviewRepository) GetEstimatedCost(id string) (float64, error) {
+	var sessionCost, evaluationCost float64
+	if err := r.scoped(r.db.Model(&ChatSession{}).Where("interview_id = ?", id)).
+		Select("COALESCE(SUM(estimated_cost_usd), 0)").Scan(&sessionCost).Error; err != nil {
+		return 0, err
+	}
+	if err := r.scoped(r.db.Model(&Evaluation{}).Where("interview_id = ?", id)).
+		Select("COALESCE(SUM(estimated_cost_usd), 0)").Scan(&evaluationCost).Error; err != nil {
+		return 0, err
+	}
+	return sessionCost + evaluationCost, nil
+}
+
+// candidateKeyExpr groups interviews by their stored CandidateNameKey
+const candidateKeyExpr = "candidate_key"
+
+// candidateGroupRow is one aggregated row of GetGroupedByCandidate
+type candidateGroupRow struct {
+	CandidateKey    string
+	InterviewCount  int
+	LatestCreatedAt time.Time
+	LatestScore     *float64
+}
+
+// GetGroupedByCandidate groups interviews by normalized candidate name and returns one page
+// of groups along with the number of distinct candidates
+func (r *interviewRepository) GetGroupedByCandidate(limit, offset int, sortBy string) ([]*CandidateGroup, int64, error) {
+	var total int64
+	if err := r.scoped(r.db.Model(&Interview{})).
+		Select("COUNT(DISTINCT " + candidateKeyExpr + ")").Scan(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	aggregates := r.scoped(r.db.Model(&Interview{})).
+		Select(candidateKeyExpr + " AS candidate_key, COUNT(*) AS interview_count, MAX(created_at) AS latest_created_at").
+		Group(candidateKeyExpr)
+	// Latest non-superseded evaluation score per candidate
+	scores := tenantScope(r.db.Table("evaluations AS e"), "i.tenant_id", r.tenantID).
+		Select("i.candidate_key, e.score, "+
+			"ROW_NUMBER() OVER (PARTITION BY i.candidate_key ORDER BY e.created_at DESC) AS rn").
+		Joins("JOIN interviews AS i ON i.id = e.interview_id").
+		Where("e.id NOT IN (?)", r.db.Model(&Evaluation{}).Select("supersedes_id").Where("supersedes_id <> ''")).
+		Where("e.status <> ?", EvaluationStatusSuperseded)
+
+	order := "g.latest_created_at DESC, g.candidate_key"
+	if sortBy == CandidateSortScore {
+		order = "s.score DESC NULLS LAST, " + order
+	}
+
+	var rows []candidateGroupRow
+	err := r.db.Table("(?) AS g", aggregates).
+		Select("g.candidate_key, g.interview_count, g.latest_created_at, s.score AS latest_score").
+		Joins("LEFT JOIN (?) AS s ON s.candidate_key = g.candidate_key AND s.rn = 1", scores).
+		Order(order).Limit(limit).Offset(offset).
+		Scan(&rows).Error
+	if err != nil || len(rows) == 0 {
+		return []*CandidateGroup{}, total, err
+	}
+
+	keys := make([]string, len(rows))
+	groups := make([]*CandidateGroup, len(rows))
+	byKey := make(map[string]*CandidateGroup, len(rows))
+	for i, row := range rows {
+		keys[i] = row.CandidateKey
+		groups[i] = &CandidateGroup{
+			InterviewCount:  row.InterviewCount,
+			LatestCreatedAt: row.LatestCreatedAt,
+			LatestScore:     row.LatestScore,
+		}
+		byKey[row.CandidateKey] = groups[i]
+	}
+
+	var interviews []*Interview
+	if err := r.scoped(r.db.Where(candidateKeyExpr+" IN ?", keys)).
+		Order("created_at DESC").Find(&interviews).Error; err != nil {
+		return nil, 0, err
+	}
+	for _, interview := range interviews {
+		group := byKey[interview.CandidateKey]
+		if group == nil {
+			continue
+		}
+		if group.CandidateName == "" {
+			group.CandidateName = strings.TrimSpace(interview.CandidateName)
+		}
+		group.Interviews = append(group.Interviews, interview)
+	}
+	return groups, total, nil
+}
+
 // TODO: Add database transaction support for complex operations
 // TODO: Implement bulk operations (create, update, delete multiple records)
 // TODO: Add database indexing recommendations in comments
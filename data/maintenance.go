@@ -0,0 +1,105 @@
+// Store maintenance: reclaiming the space purges and retention leave behind, and sizing the tables
+package data
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// storeTables are the tables of the store, whose sizes maintenance reports
+var storeTables = []string{
+	"interviews",
+	"evaluations",
+	"evaluation_traces",
+	"chat_sessions",
+	"chat_messages",
+	"notifications",
+	"calibration_runs",
+}
+
+// vacuumedTables are the tables maintenance vacuums: the ones deletes and frequent updates leave
+// dead rows in (transcript purges, session updates, superseded evaluations)
+var vacuumedTables = []string{"interviews", "chat_sessions", "chat_messages", "evaluations"}
+
+// TableStats is the size of one table of the store
+type TableStats struct {
+	Table          string `json:"table"`
+	Entries        int64  `json:"entries"`         // Live rows, or stored entries in memory
+	EstimatedBytes int64  `json:"estimated_bytes"` // On-disk size with indexes, or the JSON size of the entries in memory
+}
+
+// MaintenanceReport is what a maintenance run did and the table sizes it left
+type MaintenanceReport struct {
+	Backend  StoreBackend
+	Vacuumed []string // Tables vacuumed and analyzed; none on the memory backend
+	Tables   []TableStats
+}
+
+// Maintenance runs VACUUM (ANALYZE) on the vacuumed tables of the primary, then reads the size
+// of every table. VACUUM can't run inside a transaction, so each table is its own statement; a
+// table that fails is reported and the others are still vacuumed.
+func (s *DatabaseService) Maintenance() (*MaintenanceReport, error) {
+	report := &MaintenanceReport{Backend: BackendDatabase}
+	var failures []error
+	for _, table := range vacuumedTables {
+		if err := s.db.Exec(`VACUUM (ANALYZE) "` + table + `"`).Error; err != nil {
+			failures = append(failures, fmt.Errorf("vacuum %s: %w", table, err))
+			continue
+		}
+		report.Vacuumed = append(report.Vacuumed, table)
+	}
+	err := s.db.Raw(`SELECT relname AS "table", n_live_tup AS entries, pg_total_relation_size(relid) AS estimated_bytes `+
+		`FROM pg_stat_user_tables WHERE relname IN ? ORDER BY relname`, storeTables).Scan(&report.Tables).Error
+	if err != nil {
+		failures = append(failures, fmt.Errorf("table sizes: %w", err))
+	}
+	return report, errors.Join(failures...)
+}
+
+// Maintenance reports the entries of every table of the memory store, across tenants; there is
+// nothing to reclaim, since deleted entries are freed by the garbage collector
+func (ms *MemoryStore) Maintenance() (*MaintenanceReport, error) {
+	if err := ms.fault("Maintenance"); err != nil {
+		return nil, err
+	}
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+	messages := TableStats{Table: "chat_messages"}
+	for _, sessionMessages := range ms.chatMessages {
+		for _, message := range sessionMessages {
+			messages.Entries++
+			messages.EstimatedBytes += jsonSize(message)
+		}
+	}
+	return &MaintenanceReport{
+		Backend: BackendMemory,
+		Tables: []TableStats{
+			memoryTableStats("interviews", ms.interviews),
+			memoryTableStats("evaluations", ms.evaluations),
+			memoryTableStats("evaluation_traces", ms.traces),
+			memoryTableStats("chat_sessions", ms.chatSessions),
+			messages,
+			memoryTableStats("notifications", ms.notifications),
+			memoryTableStats("calibration_runs", ms.calibrations),
+		},
+	}, nil
+}
+
+// memoryTableStats counts the entries of a memory store map and estimates their size
+func memoryTableStats[T any](table string, entries map[string]T) TableStats {
+	stats := TableStats{Table: table, Entries: int64(len(entries))}
+	for _, entry := range entries {
+		stats.EstimatedBytes += jsonSize(entry)
+	}
+	return stats
+}
+
+// jsonSize is the length of v encoded as JSON, an estimate of the memory it holds
+func jsonSize(v any) int64 {
+	encoded, err := json.Marshal(v)
+	if err != nil {
+		return 0
+	}
+	return int64(len(encoded))
+}
@@ -11,62 +11,239 @@ import (
 // MemoryStore provides in-memory storage for development and testing
 // TODO: Replace with proper database implementation
 type MemoryStore struct {
-	interviews   map[string]*Interview
-	evaluations  map[string]*Evaluation
-	chatSessions map[string]*ChatSession
-	chatMessages map[string][]*ChatMessage
-	mu           sync.RWMutex
+	*memoryData
+	tenantID string // Set by ForTenant; empty sees every tenant
+}
+
+// memoryData is the storage shared by a MemoryStore and its tenant views
+type memoryData struct {
+	interviews    map[string]*Interview
+	evaluations   map[string]*Evaluation
+	chatSessions  map[string]*ChatSession
+	chatMessages  map[string][]*ChatMessage
+	notifications map[string]*Notification
+	calibrations  map[string]*CalibrationRun
+	traces        map[string]*EvaluationTrace // By evaluation ID
+	clock         func() time.Time            // Stamps CreatedAt/UpdatedAt; nil means time.Now
+	faults        *FaultPolicy                // Fails operations in tests; nil in production
+	mu            sync.RWMutex
 }
 
 // NewMemoryStore creates a new in-memory store
-func NewMemoryStore() *MemoryStore {
-	return &MemoryStore{
-		interviews:   make(map[string]*Interview),
-		evaluations:  make(map[string]*Evaluation),
-		chatSessions: make(map[string]*ChatSession),
-		chatMessages: make(map[string][]*ChatMessage),
+func NewMemoryStore(options ...MemoryStoreOption) *MemoryStore {
+	d := &memoryData{
+		interviews:    make(map[string]*Interview),
+		evaluations:   make(map[string]*Evaluation),
+		chatSessions:  make(map[string]*ChatSession),
+		chatMessages:  make(map[string][]*ChatMessage),
+		notifications: make(map[string]*Notification),
+		calibrations:  make(map[string]*CalibrationRun),
+		traces:        make(map[string]*EvaluationTrace),
+	}
+	for _, option := range options {
+		option(d)
+	}
+	return &MemoryStore{memoryData: d}
+}
+
+// ForTenant returns a view of the store that only sees tenantID's records and creates records for it
+// An empty tenantID sees every tenant
+func (ms *MemoryStore) ForTenant(tenantID string) *MemoryStore {
+	return &MemoryStore{memoryData: ms.memoryData, tenantID: tenantID}
+}
+
+// visible reports whether a record of tenantID is visible through the store
+func (ms *MemoryStore) visible(tenantID string) bool {
+	return ms.tenantID == "" || tenantID == ms.tenantID
+}
+
+// sessionVisible reports whether the chat session exists and is visible; callers hold ms.mu
+func (ms *MemoryStore) sessionVisible(sessionID string) bool {
+	session, exists := ms.chatSessions[sessionID]
+	return exists && ms.visible(session.TenantID)
+}
+
+// SetClock makes the store stamp CreatedAt/UpdatedAt with now instead of the wall clock
+func (ms *MemoryStore) SetClock(now func() time.Time) {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	ms.clock = now
+}
+
+// now reads the store's clock in UTC, as the database records it; callers hold ms.mu
+func (ms *MemoryStore) now() time.Time {
+	if ms.clock != nil {
+		return ms.clock().UTC()
 	}
+	return time.Now().UTC()
 }
 
 // Interview operations
 func (ms *MemoryStore) CreateInterview(interview *Interview) error {
+	if err := ms.fault("CreateInterview"); err != nil {
+		return err
+	}
 	ms.mu.Lock()
 	defer ms.mu.Unlock()
+	if _, exists := ms.interviews[interview.ID]; exists {
+		return ErrAlreadyExists
+	}
+	interview.CandidateKey = CandidateNameKey(interview.CandidateName)
+	interview.QuestionDetails = SyncQuestionDetails(interview.Questions, interview.QuestionDetails)
+	stampTenant(ms.tenantID, &interview.TenantID)
+	stampCreated(ms.now(), &interview.CreatedAt, &interview.UpdatedAt)
 	ms.interviews[interview.ID] = interview
 	return nil
 }
 
+// CreateInterviewWithSession stores an interview and its first chat session, or neither if either exists
+func (ms *MemoryStore) CreateInterviewWithSession(interview *Interview, session *ChatSession) error {
+	if err := ms.fault("CreateInterviewWithSession"); err != nil {
+		return err
+	}
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	if _, exists := ms.interviews[interview.ID]; exists {
+		return ErrAlreadyExists
+	}
+	if _, exists := ms.chatSessions[session.ID]; exists {
+		return ErrAlreadyExists
+	}
+	interview.CandidateKey = CandidateNameKey(interview.CandidateName)
+	interview.QuestionDetails = SyncQuestionDetails(interview.Questions, interview.QuestionDetails)
+	stampTenant(ms.tenantID, &interview.TenantID)
+	stampTenant(ms.tenantID, &session.TenantID)
+	stampCreated(ms.now(), &interview.CreatedAt, &interview.UpdatedAt)
+	stampCreated(ms.now(), &session.CreatedAt, &session.UpdatedAt)
+	ms.interviews[interview.ID] = interview
+	ms.chatSessions[session.ID] = session
+	ms.chatMessages[session.ID] = []*ChatMessage{}
+	return nil
+}
+
 func (ms *MemoryStore) GetInterview(id string) (*Interview, error) {
+	if err := ms.fault("GetInterview"); err != nil {
+		return nil, err
+	}
 	ms.mu.RLock()
 	defer ms.mu.RUnlock()
 	interview, exists := ms.interviews[id]
-	if !exists {
+	if !exists || !ms.visible(interview.TenantID) {
 		return nil, fmt.Errorf("interview not found")
 	}
 	return interview, nil
 }
 
+func (ms *MemoryStore) UpdateInterview(interview *Interview) error {
+	if err := ms.fault("UpdateInterview"); err != nil {
+		return err
+	}
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	stored, exists := ms.interviews[interview.ID]
+	if !exists || !ms.visible(stored.TenantID) {
+		return fmt.Errorf("interview not found")
+	}
+	interview.TenantID = stored.TenantID
+	// Outcomes only change through RecordInterviewOutcome, as in the database
+	interview.Outcome, interview.OutcomeNote, interview.OutcomeHistory = stored.Outcome, stored.OutcomeNote, stored.OutcomeHistory
+	// Generated questions only change through SetInterviewQuestionsStatus, as in the database
+	interview.Questions, interview.QuestionDetails = stored.Questions, stored.QuestionDetails
+	interview.QuestionsStatus, interview.QuestionsError = stored.QuestionsStatus, stored.QuestionsError
+	interview.UpdatedAt = ms.now()
+	ms.interviews[interview.ID] = interview
+	return nil
+}
+
+// SetInterviewJobDescriptionSummary caches the prompt summary of an interview's job description
+func (ms *MemoryStore) SetInterviewJobDescriptionSummary(id, summary string) error {
+	if err := ms.fault("SetInterviewJobDescriptionSummary"); err != nil {
+		return err
+	}
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	interview, exists := ms.interviews[id]
+	if !exists || !ms.visible(interview.TenantID) {
+		return fmt.Errorf("interview not found")
+	}
+	interview.JobDescSummary = summary
+	interview.UpdatedAt = ms.now()
+	return nil
+}
+
+// SetInterviewQuestionsStatus records the progress of generating an interview's questions; the
+// questions replace the interview's when given
+func (ms *MemoryStore) SetInterviewQuestionsStatus(id, status string, questions QuestionDetailList, failure string) error {
+	if err := ms.fault("SetInterviewQuestionsStatus"); err != nil {
+		return err
+	}
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	interview, exists := ms.interviews[id]
+	if !exists || !ms.visible(interview.TenantID) {
+		return fmt.Errorf("interview not found")
+	}
+	// Replaced rather than modified, as readers may hold the interview while generation finishes
+	updated := *interview
+	if questions != nil {
+		updated.Questions = questions.Texts()
+		updated.QuestionDetails = questions
+	}
+	updated.QuestionsStatus = status
+	updated.QuestionsError = failure
+	updated.UpdatedAt = ms.now()
+	ms.interviews[id] = &updated
+	return nil
+}
+
+// RecordInterviewOutcome sets an interview's hiring outcome and appends it to the outcome history
+func (ms *MemoryStore) RecordInterviewOutcome(id, outcome, note string) error {
+	if err := ms.fault("RecordInterviewOutcome"); err != nil {
+		return err
+	}
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	interview, exists := ms.interviews[id]
+	if !exists || !ms.visible(interview.TenantID) {
+		return fmt.Errorf("interview not found")
+	}
+	now := ms.now()
+	interview.Outcome = outcome
+	interview.OutcomeNote = note
+	interview.OutcomeHistory = append(interview.OutcomeHistory, OutcomeRecord{Outcome: outcome, Note: note, RecordedAt: now})
+	interview.UpdatedAt = now
+	return nil
+}
+
 func (ms *MemoryStore) GetInterviews() ([]*Interview, error) {
+	if err := ms.fault("GetInterviews"); err != nil {
+		return nil, err
+	}
 	ms.mu.RLock()
 	defer ms.mu.RUnlock()
 	interviews := make([]*Interview, 0, len(ms.interviews))
 	for _, interview := range ms.interviews {
-		interviews = append(interviews, interview)
+		if ms.visible(interview.TenantID) {
+			interviews = append(interviews, interview)
+		}
 	}
 	return interviews, nil
 }
 
 // ListInterviewsOptions defines options for listing interviews with pagination, filtering and sorting
 type ListInterviewsOptions struct {
-	Limit         int       // Page size (default: 10)
-	Offset        int       // Number of records to skip (default: 0)
-	Page          int       // Page number (1-based, used to calculate offset if provided)
-	CandidateName string    // Filter by candidate name (case-insensitive partial match)
-	Status        string    // Filter by status
-	DateFrom      time.Time // Filter interviews created after this date
-	DateTo        time.Time // Filter interviews created before this date
-	SortBy        string    // Sort field: "date", "name", "status" (default: "date")
-	SortOrder     string    // Sort order: "asc", "desc" (default: "desc")
+	Limit           int       // Page size (default: 10)
+	Offset          int       // Number of records to skip (default: 0)
+	Page            int       // Page number (1-based, used to calculate offset if provided)
+	CandidateName   string    // Filter by candidate name (case- and whitespace-insensitive partial match)
+	Status          string    // Filter by status
+	Outcome         string    // Filter by recorded hiring outcome
+	DateFrom        time.Time // Filter interviews created at or after this instant
+	DateTo          time.Time // Filter interviews created at or before this instant
+	ScheduledAfter  time.Time // Filter interviews scheduled to start at or after this time
+	ScheduledBefore time.Time // Filter interviews scheduled to start at or before this time
+	SortBy          string    // Sort field: "date", "name", "status" (default: "date")
+	SortOrder       string    // Sort order: "asc", "desc" (default: "desc")
 }
 
 // ListInterviewsResult contains the result of listing interviews with pagination info
@@ -78,8 +255,53 @@ type ListInterviewsResult struct {
 	TotalPages int
 }
 
+// ListMessagesOptions selects a window of a chat session's messages, oldest first
+type ListMessagesOptions struct {
+	Limit         int  // Maximum messages returned (0: no limit)
+	Offset        int  // Number of messages to skip
+	CandidateOnly bool // Leave out internal messages, from the window and the total
+}
+
+// ListMessagesResult represents a window of a chat session's messages
+type ListMessagesResult struct {
+	Messages []*ChatMessage
+	Total    int // Messages in the whole session, of the requested visibility
+}
+
+// Candidate group sort fields
+const (
+	CandidateSortActivity = "activity" // Most recent interview first
+	CandidateSortScore    = "score"    // Highest latest score first, candidates without a score last
+)
+
+// CandidateGroupOptions defines pagination and sorting for interviews grouped by candidate
+type CandidateGroupOptions struct {
+	Limit  int    // Number of candidates per page (default: 10)
+	Offset int    // Number of candidates to skip (default: 0)
+	SortBy string // Sort field: "activity", "score" (default: "activity")
+}
+
+// CandidateGroup summarizes all interviews of one candidate. Candidates are matched by
+// trimmed, case-insensitive name
+type CandidateGroup struct {
+	CandidateName   string       // Name as written on the candidate's most recent interview
+	InterviewCount  int          // Number of interviews for the candidate
+	LatestCreatedAt time.Time    // Creation time of the most recent interview
+	LatestScore     *float64     // Score of the most recent evaluation, nil when none exists
+	Interviews      []*Interview // Most recent first
+}
+
+// CandidateGroupsResult contains one page of candidate groups
+type CandidateGroupsResult struct {
+	Groups []*CandidateGroup
+	Total  int // Number of distinct candidates
+}
+
 // GetInterviewsWithOptions returns interviews with pagination, filtering, and sorting
 func (ms *MemoryStore) GetInterviewsWithOptions(opts ListInterviewsOptions) (*ListInterviewsResult, error) {
+	if err := ms.fault("GetInterviewsWithOptions"); err != nil {
+		return nil, err
+	}
 	ms.mu.RLock()
 	defer ms.mu.RUnlock()
 
@@ -101,8 +323,12 @@ func (ms *MemoryStore) GetInterviewsWithOptions(opts ListInterviewsOptions) (*Li
 	allInterviews := make([]*Interview, 0)
 	for _, interview := range ms.interviews {
 		// Apply filters
+		if !ms.visible(interview.TenantID) {
+			continue
+		}
+
 		if opts.CandidateName != "" {
-			if !strings.Contains(strings.ToLower(interview.CandidateName), strings.ToLower(opts.CandidateName)) {
+			if !strings.Contains(interview.CandidateKey, CandidateNameKey(opts.CandidateName)) {
 				continue
 			}
 		}
@@ -111,6 +337,10 @@ func (ms *MemoryStore) GetInterviewsWithOptions(opts ListInterviewsOptions) (*Li
 			continue
 		}
 
+		if opts.Outcome != "" && interview.Outcome != opts.Outcome {
+			continue
+		}
+
 		if !opts.DateFrom.IsZero() && interview.CreatedAt.Before(opts.DateFrom) {
 			continue
 		}
@@ -119,6 +349,14 @@ func (ms *MemoryStore) GetInterviewsWithOptions(opts ListInterviewsOptions) (*Li
 			continue
 		}
 
+		if !opts.ScheduledAfter.IsZero() && (interview.ScheduledStart == nil || interview.ScheduledStart.Before(opts.ScheduledAfter)) {
+			continue
+		}
+
+		if !opts.ScheduledBefore.IsZero() && (interview.ScheduledStart == nil || interview.ScheduledStart.After(opts.ScheduledBefore)) {
+			continue
+		}
+
 		allInterviews = append(allInterviews, interview)
 	}
 
@@ -180,69 +418,1007 @@ func (ms *MemoryStore) GetInterviewsWithOptions(opts ListInterviewsOptions) (*Li
 
 // Evaluation operations
 func (ms *MemoryStore) CreateEvaluation(evaluation *Evaluation) error {
+	if err := ms.fault("CreateEvaluation"); err != nil {
+		return err
+	}
 	ms.mu.Lock()
 	defer ms.mu.Unlock()
+	if _, exists := ms.evaluations[evaluation.ID]; exists {
+		return ErrAlreadyExists
+	}
+	stampTenant(ms.tenantID, &evaluation.TenantID)
+	stampCreated(ms.now(), &evaluation.CreatedAt, &evaluation.UpdatedAt)
 	ms.evaluations[evaluation.ID] = evaluation
 	return nil
 }
 
 func (ms *MemoryStore) GetEvaluation(id string) (*Evaluation, error) {
+	if err := ms.fault("GetEvaluation"); err != nil {
+		return nil, err
+	}
 	ms.mu.RLock()
 	defer ms.mu.RUnlock()
 	evaluation, exists := ms.evaluations[id]
-	if !exists {
+	if !exists || !ms.visible(evaluation.TenantID) {
 		return nil, fmt.Errorf("evaluation not found")
 	}
 	return evaluation, nil
 }
 
+// SupersedeEvaluation voids an evaluation, leaving it stored but no longer current
+func (ms *MemoryStore) SupersedeEvaluation(id string) error {
+	if err := ms.fault("SupersedeEvaluation"); err != nil {
+		return err
+	}
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	evaluation, exists := ms.evaluations[id]
+	if !exists || !ms.visible(evaluation.TenantID) {
+		return fmt.Errorf("evaluation not found")
+	}
+	evaluation.Status = EvaluationStatusSuperseded
+	evaluation.UpdatedAt = ms.now()
+	return nil
+}
+
+// supersededEvaluationIDs returns the IDs of evaluations that were replaced or voided
+// Callers must hold ms.mu.
+func (ms *MemoryStore) supersededEvaluationIDs() map[string]bool {
+	superseded := make(map[string]bool)
+	for _, evaluation := range ms.evaluations {
+		if evaluation.SupersedesID != "" {
+			superseded[evaluation.SupersedesID] = true
+		}
+		if evaluation.Status == EvaluationStatusSuperseded {
+			superseded[evaluation.ID] = true
+		}
+	}
+	return superseded
+}
+
+// GetLatestEvaluationByInterview returns the most recent evaluation for an interview
+// that no other evaluation supersedes
+func (ms *MemoryStore) GetLatestEvaluationByInterview(interviewID string) (*Evaluation, error) {
+	if err := ms.fault("GetLatestEvaluationByInterview"); err != nil {
+		return nil, err
+	}
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+
+	latest, exists := ms.latestEvaluations([]string{interviewID})[interviewID]
+	if !exists {
+		return nil, fmt.Errorf("evaluation not found")
+	}
+	return latest, nil
+}
+
+// GetLatestEvaluationsByInterviews returns the evaluation GetLatestEvaluationByInterview would for each
+// of the interviews, keyed by interview ID; interviews without one are left out
+func (ms *MemoryStore) GetLatestEvaluationsByInterviews(interviewIDs []string) (map[string]*Evaluation, error) {
+	if err := ms.fault("GetLatestEvaluationsByInterviews"); err != nil {
+		return nil, err
+	}
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+	return ms.latestEvaluations(interviewIDs), nil
+}
+
+// latestEvaluations returns the most recent evaluation no other supersedes of each of the interviews;
+// the caller holds ms.mu
+func (ms *MemoryStore) latestEvaluations(interviewIDs []string) map[string]*Evaluation {
+	wanted := make(map[string]bool, len(interviewIDs))
+	for _, id := range interviewIDs {
+		wanted[id] = true
+	}
+	superseded := ms.supersededEvaluationIDs()
+
+	latest := make(map[string]*Evaluation)
+	for _, evaluation := range ms.evaluations {
+		if !wanted[evaluation.InterviewID] || superseded[evaluation.ID] || !ms.visible(evaluation.TenantID) {
+			continue
+		}
+		if current, exists := latest[evaluation.InterviewID]; !exists || evaluation.CreatedAt.After(current.CreatedAt) {
+			latest[evaluation.InterviewID] = evaluation
+		}
+	}
+	return latest
+}
+
+// GetEvaluationScoresByModel averages the scores of AI-scored evaluations per provider and model
+// Superseded evaluations and sessions without answers are left out
+func (ms *MemoryStore) GetEvaluationScoresByModel() ([]*ModelScoreStats, error) {
+	if err := ms.fault("GetEvaluationScoresByModel"); err != nil {
+		return nil, err
+	}
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+
+	superseded := ms.supersededEvaluationIDs()
+
+	byModel := make(map[[2]string]*ModelScoreStats)
+	for _, evaluation := range ms.evaluations {
+		if evaluation.Status != EvaluationStatusCompleted || superseded[evaluation.ID] || !ms.visible(evaluation.TenantID) {
+			continue
+		}
+		key := [2]string{evaluation.Provider, evaluation.Model}
+		stats, ok := byModel[key]
+		if !ok {
+			stats = &ModelScoreStats{Provider: evaluation.Provider, Model: evaluation.Model}
+			byModel[key] = stats
+		}
+		stats.Evaluations++
+		stats.AverageScore += evaluation.Score // Summed here, divided below
+	}
+
+	result := make([]*ModelScoreStats, 0, len(byModel))
+	for _, stats := range byModel {
+		stats.AverageScore /= float64(stats.Evaluations)
+		result = append(result, stats)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Provider != result[j].Provider {
+			return result[i].Provider < result[j].Provider
+		}
+		return result[i].Model < result[j].Model
+	})
+	return result, nil
+}
+
+// GetEvaluationScoresByOutcome averages the scores of current completed evaluations per interview outcome
+// Interviews without a recorded outcome are left out
+func (ms *MemoryStore) GetEvaluationScoresByOutcome() ([]*OutcomeScoreStats, error) {
+	if err := ms.fault("GetEvaluationScoresByOutcome"); err != nil {
+		return nil, err
+	}
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+
+	superseded := ms.supersededEvaluationIDs()
+
+	byOutcome := make(map[string]*OutcomeScoreStats)
+	for _, evaluation := range ms.evaluations {
+		interview, ok := ms.interviews[evaluation.InterviewID]
+		if !ok || interview.Outcome == "" || evaluation.Status != EvaluationStatusCompleted || superseded[evaluation.ID] || !ms.visible(evaluation.TenantID) {
+			continue
+		}
+		stats, ok := byOutcome[interview.Outcome]
+		if !ok {
+			stats = &OutcomeScoreStats{Outcome: interview.Outcome}
+			byOutcome[interview.Outcome] = stats
+		}
+		stats.Evaluations++
+		stats.AverageScore += evaluation.Score // Summed here, divided below
+	}
+
+	result := make([]*OutcomeScoreStats, 0, len(byOutcome))
+	for _, stats := range byOutcome {
+		stats.AverageScore /= float64(stats.Evaluations)
+		result = append(result, stats)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Outcome < result[j].Outcome })
+	return result, nil
+}
+
+// GetScoreDistribution returns, sorted ascending, the scores of current completed evaluations of
+// interviewType interviews scored by model and created at or after since
+func (ms *MemoryStore) GetScoreDistribution(interviewType, model string, since time.Time) ([]float64, error) {
+	if err := ms.fault("GetScoreDistribution"); err != nil {
+		return nil, err
+	}
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+
+	superseded := ms.supersededEvaluationIDs()
+
+	scores := make([]float64, 0)
+	for _, evaluation := range ms.evaluations {
+		interview, ok := ms.interviews[evaluation.InterviewID]
+		if !ok || interview.InterviewType != interviewType || evaluation.Model != model || evaluation.CreatedAt.Before(since) ||
+			(evaluation.Status != "" && evaluation.Status != EvaluationStatusCompleted) || superseded[evaluation.ID] || !ms.visible(evaluation.TenantID) {
+			continue
+		}
+		scores = append(scores, evaluation.Score)
+	}
+	sort.Float64s(scores)
+	return scores, nil
+}
+
+// CreateEvaluationTrace stores a copy of the trace of an evaluation
+func (ms *MemoryStore) CreateEvaluationTrace(trace *EvaluationTrace) error {
+	if err := ms.fault("CreateEvaluationTrace"); err != nil {
+		return err
+	}
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	if _, exists := ms.traces[trace.EvaluationID]; exists {
+		return ErrAlreadyExists
+	}
+	stampTenant(ms.tenantID, &trace.TenantID)
+	stampCreated(ms.now(), &trace.CreatedAt, nil)
+	stored := *trace
+	ms.traces[trace.EvaluationID] = &stored
+	return nil
+}
+
+// GetEvaluationTrace retrieves a copy of the trace of an evaluation
+func (ms *MemoryStore) GetEvaluationTrace(evaluationID string) (*EvaluationTrace, error) {
+	if err := ms.fault("GetEvaluationTrace"); err != nil {
+		return nil, err
+	}
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+	trace, exists := ms.traces[evaluationID]
+	if !exists || !ms.visible(trace.TenantID) {
+		return nil, fmt.Errorf("evaluation trace not found")
+	}
+	copied := *trace
+	return &copied, nil
+}
+
+// GetEvaluationDecisionCounts counts the recommendation decisions of current evaluations per interview type
+// Superseded evaluations and evaluations without a decision are left out
+func (ms *MemoryStore) GetEvaluationDecisionCounts() ([]*DecisionCount, error) {
+	if err := ms.fault("GetEvaluationDecisionCounts"); err != nil {
+		return nil, err
+	}
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+
+	superseded := ms.supersededEvaluationIDs()
+
+	byKey := make(map[[2]string]*DecisionCount)
+	for _, evaluation := range ms.evaluations {
+		interview, ok := ms.interviews[evaluation.InterviewID]
+		if evaluation.Decision == "" || superseded[evaluation.ID] || !ok || !ms.visible(evaluation.TenantID) {
+			continue
+		}
+		key := [2]string{interview.InterviewType, evaluation.Decision}
+		count, ok := byKey[key]
+		if !ok {
+			count = &DecisionCount{InterviewType: interview.InterviewType, Decision: evaluation.Decision}
+			byKey[key] = count
+		}
+		count.Evaluations++
+	}
+
+	result := make([]*DecisionCount, 0, len(byKey))
+	for _, count := range byKey {
+		result = append(result, count)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].InterviewType != result[j].InterviewType {
+			return result[i].InterviewType < result[j].InterviewType
+		}
+		return result[i].Decision < result[j].Decision
+	})
+	return result, nil
+}
+
+// GetInterviewsGroupedByCandidate returns interviews grouped by candidate, paginated over candidates
+func (ms *MemoryStore) GetInterviewsGroupedByCandidate(opts CandidateGroupOptions) (*CandidateGroupsResult, error) {
+	if err := ms.fault("GetInterviewsGroupedByCandidate"); err != nil {
+		return nil, err
+	}
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+
+	if opts.Limit <= 0 {
+		opts.Limit = 10
+	}
+	if opts.Offset < 0 {
+		opts.Offset = 0
+	}
+
+	byKey := make(map[string]*CandidateGroup)
+	byInterview := make(map[string]*CandidateGroup)
+	for _, interview := range ms.interviews {
+		if !ms.visible(interview.TenantID) {
+			continue
+		}
+		key := interview.CandidateKey
+		group, ok := byKey[key]
+		if !ok {
+			group = &CandidateGroup{}
+			byKey[key] = group
+		}
+		group.Interviews = append(group.Interviews, interview)
+		byInterview[interview.ID] = group
+	}
+
+	superseded := ms.supersededEvaluationIDs()
+	latestEvaluation := make(map[*CandidateGroup]*Evaluation)
+	for _, evaluation := range ms.evaluations {
+		group, ok := byInterview[evaluation.InterviewID]
+		if !ok || superseded[evaluation.ID] {
+			continue
+		}
+		if latest := latestEvaluation[group]; latest == nil || evaluation.CreatedAt.After(latest.CreatedAt) {
+			latestEvaluation[group] = evaluation
+		}
+	}
+
+	groups := make([]*CandidateGroup, 0, len(byKey))
+	for _, group := range byKey {
+		sort.Slice(group.Interviews, func(i, j int) bool {
+			return group.Interviews[i].CreatedAt.After(group.Interviews[j].CreatedAt)
+		})
+		group.CandidateName = strings.TrimSpace(group.Interviews[0].CandidateName)
+		group.InterviewCount = len(group.Interviews)
+		group.LatestCreatedAt = group.Interviews[0].CreatedAt
+		if evaluation := latestEvaluation[group]; evaluation != nil {
+			score := evaluation.Score
+			group.LatestScore = &score
+		}
+		groups = append(groups, group)
+	}
+
+	sort.Slice(groups, func(i, j int) bool {
+		a, b := groups[i], groups[j]
+		if opts.SortBy == CandidateSortScore && (a.LatestScore == nil) != (b.LatestScore == nil) {
+			return a.LatestScore != nil
+		}
+		if opts.SortBy == CandidateSortScore && a.LatestScore != nil && *a.LatestScore != *b.LatestScore {
+			return *a.LatestScore > *b.LatestScore
+		}
+		if !a.LatestCreatedAt.Equal(b.LatestCreatedAt) {
+			return a.LatestCreatedAt.After(b.LatestCreatedAt)
+		}
+		return CandidateNameKey(a.CandidateName) < CandidateNameKey(b.CandidateName)
+	})
+
+	total := len(groups)
+	start := opts.Offset
+	if start > total {
+		start = total
+	}
+	end := start + opts.Limit
+	if end > total {
+		end = total
+	}
+
+	return &CandidateGroupsResult{
+		Groups: groups[start:end],
+		Total:  total,
+	}, nil
+}
+
 // Chat session operations
 func (ms *MemoryStore) CreateChatSession(session *ChatSession) error {
+	if err := ms.fault("CreateChatSession"); err != nil {
+		return err
+	}
 	ms.mu.Lock()
 	defer ms.mu.Unlock()
+	if _, exists := ms.chatSessions[session.ID]; exists {
+		return ErrAlreadyExists
+	}
+	stampTenant(ms.tenantID, &session.TenantID)
+	stampCreated(ms.now(), &session.CreatedAt, &session.UpdatedAt)
 	ms.chatSessions[session.ID] = session
 	ms.chatMessages[session.ID] = []*ChatMessage{}
 	return nil
 }
 
 func (ms *MemoryStore) GetChatSession(id string) (*ChatSession, error) {
+	if err := ms.fault("GetChatSession"); err != nil {
+		return nil, err
+	}
 	ms.mu.RLock()
 	defer ms.mu.RUnlock()
 	session, exists := ms.chatSessions[id]
-	if !exists {
+	if !exists || !ms.visible(session.TenantID) {
 		return nil, fmt.Errorf("chat session not found")
 	}
 	return session, nil
 }
 
 func (ms *MemoryStore) UpdateChatSession(session *ChatSession) error {
+	if err := ms.fault("UpdateChatSession"); err != nil {
+		return err
+	}
 	ms.mu.Lock()
 	defer ms.mu.Unlock()
-	if _, exists := ms.chatSessions[session.ID]; !exists {
+	stored, exists := ms.chatSessions[session.ID]
+	if !exists || !ms.visible(stored.TenantID) {
 		return fmt.Errorf("chat session not found")
 	}
-	session.UpdatedAt = time.Now()
+	session.TenantID = stored.TenantID
+	session.UpdatedAt = ms.now()
 	ms.chatSessions[session.ID] = session
 	return nil
 }
 
+// AppendAskedQuestion records a question asked during a chat session
+func (ms *MemoryStore) AppendAskedQuestion(sessionID, question string) error {
+	if err := ms.fault("AppendAskedQuestion"); err != nil {
+		return err
+	}
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	session, exists := ms.chatSessions[sessionID]
+	if !exists || !ms.visible(session.TenantID) {
+		return fmt.Errorf("chat session not found")
+	}
+	session.AskedQuestions = append(session.AskedQuestions, question)
+	session.UpdatedAt = ms.now()
+	return nil
+}
+
+// AppendDifficultyLevel sets a chat session's difficulty level and appends it to the trajectory
+func (ms *MemoryStore) AppendDifficultyLevel(sessionID string, level int) error {
+	if err := ms.fault("AppendDifficultyLevel"); err != nil {
+		return err
+	}
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	session, exists := ms.chatSessions[sessionID]
+	if !exists || !ms.visible(session.TenantID) {
+		return fmt.Errorf("chat session not found")
+	}
+	session.DifficultyLevel = level
+	session.DifficultyTrajectory = append(session.DifficultyTrajectory, level)
+	session.UpdatedAt = ms.now()
+	return nil
+}
+
+// PinChatSessionProvider records the AI provider and model a chat session's turns are answered by
+func (ms *MemoryStore) PinChatSessionProvider(sessionID, provider, model string) error {
+	if err := ms.fault("PinChatSessionProvider"); err != nil {
+		return err
+	}
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	session, exists := ms.chatSessions[sessionID]
+	if !exists || !ms.visible(session.TenantID) {
+		return fmt.Errorf("chat session not found")
+	}
+	session.Provider = provider
+	session.Model = model
+	session.UpdatedAt = ms.now()
+	return nil
+}
+
+// GetSessionDurationsByInterviewType averages the duration of ended chat sessions per interview type
+// Active sessions have no duration yet and are left out
+func (ms *MemoryStore) GetSessionDurationsByInterviewType() ([]*SessionDurationStats, error) {
+	if err := ms.fault("GetSessionDurationsByInterviewType"); err != nil {
+		return nil, err
+	}
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+
+	byType := make(map[string]*SessionDurationStats)
+	for _, session := range ms.chatSessions {
+		interview, ok := ms.interviews[session.InterviewID]
+		duration, ended := session.Duration()
+		if !ok || !ended || !ms.visible(session.TenantID) {
+			continue
+		}
+		stats, ok := byType[interview.InterviewType]
+		if !ok {
+			stats = &SessionDurationStats{InterviewType: interview.InterviewType}
+			byType[interview.InterviewType] = stats
+		}
+		stats.Sessions++
+		stats.AverageDurationSeconds += duration.Seconds() // Summed here, divided below
+	}
+
+	result := make([]*SessionDurationStats, 0, len(byType))
+	for _, stats := range byType {
+		stats.AverageDurationSeconds /= float64(stats.Sessions)
+		result = append(result, stats)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].InterviewType < result[j].InterviewType })
+	return result, nil
+}
+
+// GetChatSessionsByInterview lists all chat sessions of an interview, oldest first
+func (ms *MemoryStore) GetChatSessionsByInterview(interviewID string) ([]*ChatSession, error) {
+	if err := ms.fault("GetChatSessionsByInterview"); err != nil {
+		return nil, err
+	}
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+	sessions := make([]*ChatSession, 0)
+	for _, session := range ms.chatSessions {
+		if session.InterviewID == interviewID && ms.visible(session.TenantID) {
+			sessions = append(sessions, session)
+		}
+	}
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].CreatedAt.Before(sessions[j].CreatedAt)
+	})
+	return sessions, nil
+}
+
+// AddChatSessionCost adds amount to a chat session's estimated AI cost
+func (ms *MemoryStore) AddChatSessionCost(sessionID string, amount float64) error {
+	if err := ms.fault("AddChatSessionCost"); err != nil {
+		return err
+	}
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	session, exists := ms.chatSessions[sessionID]
+	if !exists || !ms.visible(session.TenantID) {
+		return fmt.Errorf("chat session not found")
+	}
+	session.EstimatedCostUSD += amount
+	session.UpdatedAt = ms.now()
+	return nil
+}
+
+// GetInterviewEstimatedCost sums the estimated AI cost of an interview's chat sessions and evaluations
+func (ms *MemoryStore) GetInterviewEstimatedCost(interviewID string) (float64, error) {
+	if err := ms.fault("GetInterviewEstimatedCost"); err != nil {
+		return 0, err
+	}
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+	total := 0.0
+	for _, session := range ms.chatSessions {
+		if session.InterviewID == interviewID && ms.visible(session.TenantID) {
+			total += session.EstimatedCostUSD
+		}
+	}
+	for _, evaluation := range ms.evaluations {
+		if evaluation.InterviewID == interviewID && ms.visible(evaluation.TenantID) {
+			total += evaluation.EstimatedCostUSD
+		}
+	}
+	return total, nil
+}
+
+// GetCompletedSessionsWithoutEvaluation lists completed chat sessions whose interview has no
+// evaluation, oldest first. A limit of 0 means no limit.
+func (ms *MemoryStore) GetCompletedSessionsWithoutEvaluation(limit int) ([]*ChatSession, error) {
+	if err := ms.fault("GetCompletedSessionsWithoutEvaluation"); err != nil {
+		return nil, err
+	}
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+
+	evaluated := make(map[string]bool)
+	for _, evaluation := range ms.evaluations {
+		evaluated[evaluation.InterviewID] = true
+	}
+	sessions := make([]*ChatSession, 0)
+	for _, session := range ms.chatSessions {
+		if session.Status == "completed" && session.TranscriptPurgedAt == nil && !evaluated[session.InterviewID] && ms.visible(session.TenantID) {
+			sessions = append(sessions, session)
+		}
+	}
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].CreatedAt.Before(sessions[j].CreatedAt)
+	})
+	if limit > 0 && len(sessions) > limit {
+		sessions = sessions[:limit]
+	}
+	return sessions, nil
+}
+
+// GetIdleChatSessions lists active sessions with no heartbeat or message since cutoff, oldest first
+// A limit of 0 means no limit.
+func (ms *MemoryStore) GetIdleChatSessions(cutoff time.Time, limit int) ([]*ChatSession, error) {
+	if err := ms.fault("GetIdleChatSessions"); err != nil {
+		return nil, err
+	}
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+
+	sessions := make([]*ChatSession, 0)
+	for _, session := range ms.chatSessions {
+		if session.Status != "active" || !ms.visible(session.TenantID) {
+			continue
+		}
+		var lastMessageAt time.Time
+		for _, message := range ms.chatMessages[session.ID] {
+			if message.Timestamp.After(lastMessageAt) {
+				lastMessageAt = message.Timestamp
+			}
+		}
+		if session.LastActivity(lastMessageAt).Before(cutoff) {
+			sessions = append(sessions, session)
+		}
+	}
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].CreatedAt.Before(sessions[j].CreatedAt)
+	})
+	if limit > 0 && len(sessions) > limit {
+		sessions = sessions[:limit]
+	}
+	return sessions, nil
+}
+
+// AbandonIdleChatSession marks a session abandoned at at if it is still active with no heartbeat or
+// message since cutoff. Returns false otherwise.
+func (ms *MemoryStore) AbandonIdleChatSession(sessionID string, cutoff, at time.Time) (bool, error) {
+	if err := ms.fault("AbandonIdleChatSession"); err != nil {
+		return false, err
+	}
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	session, exists := ms.chatSessions[sessionID]
+	if !exists || !ms.visible(session.TenantID) {
+		return false, fmt.Errorf("chat session not found")
+	}
+	var lastMessageAt time.Time
+	for _, message := range ms.chatMessages[sessionID] {
+		if message.Timestamp.After(lastMessageAt) {
+			lastMessageAt = message.Timestamp
+		}
+	}
+	if session.Status != "active" || !session.LastActivity(lastMessageAt).Before(cutoff) {
+		return false, nil
+	}
+	session.End(SessionStatusAbandoned, at)
+	session.UpdatedAt = ms.now()
+	return true, nil
+}
+
+// RecordChatSessionHeartbeat sets the session's LastActivityAt to at unless the previous heartbeat
+// is less than minInterval old. Returns false when the heartbeat was rate-limited.
+func (ms *MemoryStore) RecordChatSessionHeartbeat(sessionID string, at time.Time, minInterval time.Duration) (bool, error) {
+	if err := ms.fault("RecordChatSessionHeartbeat"); err != nil {
+		return false, err
+	}
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	session, exists := ms.chatSessions[sessionID]
+	if !exists || !ms.visible(session.TenantID) {
+		return false, fmt.Errorf("chat session not found")
+	}
+	if session.LastActivityAt != nil && at.Sub(*session.LastActivityAt) < minInterval {
+		return false, nil
+	}
+	session.LastActivityAt = &at
+	session.UpdatedAt = ms.now()
+	return true, nil
+}
+
+// RecordChatSessionAIAttempt counts one provider call against the session unless it already made
+// maxAttempts. Returns false when the budget is exhausted; a maxAttempts of 0 means no limit.
+func (ms *MemoryStore) RecordChatSessionAIAttempt(sessionID string, maxAttempts int) (bool, error) {
+	if err := ms.fault("RecordChatSessionAIAttempt"); err != nil {
+		return false, err
+	}
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	session, exists := ms.chatSessions[sessionID]
+	if !exists || !ms.visible(session.TenantID) {
+		return false, fmt.Errorf("chat session not found")
+	}
+	if maxAttempts > 0 && session.AIAttempts >= maxAttempts {
+		return false, nil
+	}
+	session.AIAttempts++
+	session.UpdatedAt = ms.now()
+	return true, nil
+}
+
+// RecordChatSessionAbuse counts one candidate message refused by the anti-abuse checks for reason
+func (ms *MemoryStore) RecordChatSessionAbuse(sessionID, reason string) error {
+	if err := ms.fault("RecordChatSessionAbuse"); err != nil {
+		return err
+	}
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	session, exists := ms.chatSessions[sessionID]
+	if !exists || !ms.visible(session.TenantID) {
+		return fmt.Errorf("chat session not found")
+	}
+	// Replaced rather than updated in place, since callers may be reading the map they were handed
+	counts := make(CountMap, len(session.AbuseRejections)+1)
+	for r, n := range session.AbuseRejections {
+		counts[r] = n
+	}
+	counts[reason]++
+	session.AbuseRejections = counts
+	session.UpdatedAt = ms.now()
+	return nil
+}
+
+// ReopenChatSession returns a session completed at or after endedAfter to active
+// Returns false when the session is not completed or ended before endedAfter
+func (ms *MemoryStore) ReopenChatSession(sessionID string, endedAfter time.Time, evaluationID string) (bool, error) {
+	if err := ms.fault("ReopenChatSession"); err != nil {
+		return false, err
+	}
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	session, exists := ms.chatSessions[sessionID]
+	if !exists || !ms.visible(session.TenantID) {
+		return false, fmt.Errorf("chat session not found")
+	}
+	if session.Status != "completed" || session.EndedAt == nil || session.EndedAt.Before(endedAfter) {
+		return false, nil
+	}
+	session.Status = "active"
+	session.EndedAt = nil
+	session.ReopenCount++
+	session.ReopenedEvaluationID = evaluationID
+	session.UpdatedAt = ms.now()
+	return true, nil
+}
+
 // Chat message operations
 func (ms *MemoryStore) AddChatMessage(message *ChatMessage) error {
+	if err := ms.fault("AddChatMessage"); err != nil {
+		return err
+	}
+	return ms.addChatMessage(message, 0)
+}
+
+// AddChatMessageWithLimit adds a message unless its session already holds maxMessages messages
+// A maxMessages of 0 means no limit
+func (ms *MemoryStore) AddChatMessageWithLimit(message *ChatMessage, maxMessages int) error {
+	if err := ms.fault("AddChatMessageWithLimit"); err != nil {
+		return err
+	}
+	return ms.addChatMessage(message, maxMessages)
+}
+
+func (ms *MemoryStore) addChatMessage(message *ChatMessage, maxMessages int) error {
 	ms.mu.Lock()
 	defer ms.mu.Unlock()
-	if _, exists := ms.chatMessages[message.SessionID]; !exists {
+	messages, exists := ms.chatMessages[message.SessionID]
+	if !exists || !ms.sessionVisible(message.SessionID) {
 		return fmt.Errorf("chat session not found")
 	}
+	if maxMessages > 0 && len(messages) >= maxMessages {
+		return ErrMessageLimitReached
+	}
+	// Mirror the database primary key and unique index on (session_id, client_message_id)
+	for _, existing := range messages {
+		if existing.ID == message.ID || (message.ClientMessageID != "" && existing.ClientMessageID == message.ClientMessageID) {
+			return ErrAlreadyExists
+		}
+	}
+	stampCreated(ms.now(), &message.CreatedAt, nil)
 	ms.chatMessages[message.SessionID] = append(ms.chatMessages[message.SessionID], message)
 	return nil
 }
 
 func (ms *MemoryStore) GetChatMessages(sessionID string) ([]*ChatMessage, error) {
+	if err := ms.fault("GetChatMessages"); err != nil {
+		return nil, err
+	}
 	ms.mu.RLock()
 	defer ms.mu.RUnlock()
 	messages, exists := ms.chatMessages[sessionID]
-	if !exists {
+	if !exists || !ms.sessionVisible(sessionID) {
 		return nil, fmt.Errorf("chat session not found")
 	}
 	return messages, nil
 }
+
+// GetChatMessagesWithOptions returns a window of a session's messages, oldest first
+func (ms *MemoryStore) GetChatMessagesWithOptions(sessionID string, options ListMessagesOptions) (*ListMessagesResult, error) {
+	if err := ms.fault("GetChatMessagesWithOptions"); err != nil {
+		return nil, err
+	}
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+	messages, exists := ms.chatMessages[sessionID]
+	if !exists || !ms.sessionVisible(sessionID) {
+		return nil, fmt.Errorf("chat session not found")
+	}
+	if options.CandidateOnly {
+		visible := make([]*ChatMessage, 0, len(messages))
+		for _, message := range messages {
+			if message.CandidateVisible() {
+				visible = append(visible, message)
+			}
+		}
+		messages = visible
+	}
+
+	start := min(options.Offset, len(messages))
+	end := len(messages)
+	if options.Limit > 0 {
+		end = min(start+options.Limit, end)
+	}
+	return &ListMessagesResult{Messages: messages[start:end:end], Total: len(messages)}, nil
+}
+
+// GetChatMessageByClientID finds a message by its client-provided ID within a session
+func (ms *MemoryStore) GetChatMessageByClientID(sessionID, clientMessageID string) (*ChatMessage, error) {
+	if err := ms.fault("GetChatMessageByClientID"); err != nil {
+		return nil, err
+	}
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+	if !ms.sessionVisible(sessionID) {
+		return nil, fmt.Errorf("chat message not found")
+	}
+	for _, message := range ms.chatMessages[sessionID] {
+		if clientMessageID != "" && message.ClientMessageID == clientMessageID {
+			return message, nil
+		}
+	}
+	return nil, fmt.Errorf("chat message not found")
+}
+
+// PurgeMessagesOlderThan deletes the messages sent before cutoff in sessions that are no longer
+// active, along with their conversation summaries, and marks those sessions purged
+// Returns the number of messages deleted
+func (ms *MemoryStore) PurgeMessagesOlderThan(cutoff time.Time) (int, error) {
+	if err := ms.fault("PurgeMessagesOlderThan"); err != nil {
+		return 0, err
+	}
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	purged := 0
+	for id, session := range ms.chatSessions {
+		if session.Status == "active" || !ms.visible(session.TenantID) {
+			continue
+		}
+		// A new slice, since readers may still hold the old one
+		messages := ms.chatMessages[id]
+		kept := make([]*ChatMessage, 0, len(messages))
+		for _, message := range messages {
+			if !message.Timestamp.Before(cutoff) {
+				kept = append(kept, message)
+			}
+		}
+		if len(kept) == len(messages) {
+			continue
+		}
+		purged += len(messages) - len(kept)
+		ms.chatMessages[id] = kept
+		now := ms.now()
+		session.ConversationSummary = ""
+		session.SummarizedTurns = 0
+		session.TranscriptPurgedAt = &now
+		session.UpdatedAt = now
+	}
+	return purged, nil
+}
+
+// CountMessagesOlderThan counts the messages PurgeMessagesOlderThan would delete
+func (ms *MemoryStore) CountMessagesOlderThan(cutoff time.Time) (int, error) {
+	if err := ms.fault("CountMessagesOlderThan"); err != nil {
+		return 0, err
+	}
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+	count := 0
+	for id, session := range ms.chatSessions {
+		if session.Status == "active" || !ms.visible(session.TenantID) {
+			continue
+		}
+		for _, message := range ms.chatMessages[id] {
+			if message.Timestamp.Before(cutoff) {
+				count++
+			}
+		}
+	}
+	return count, nil
+}
+
+// Notification outbox operations
+
+// CreateNotification adds a copy of the notification to the outbox
+func (ms *MemoryStore) CreateNotification(notification *Notification) error {
+	if err := ms.fault("CreateNotification"); err != nil {
+		return err
+	}
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	if _, exists := ms.notifications[notification.ID]; exists {
+		return ErrAlreadyExists
+	}
+	stampTenant(ms.tenantID, &notification.TenantID)
+	stampCreated(ms.now(), &notification.CreatedAt, &notification.UpdatedAt)
+	stored := *notification
+	ms.notifications[notification.ID] = &stored
+	return nil
+}
+
+// GetDueNotifications lists copies of the pending notifications due at now, earliest first
+// A limit of 0 means no limit.
+func (ms *MemoryStore) GetDueNotifications(now time.Time, limit int) ([]*Notification, error) {
+	if err := ms.fault("GetDueNotifications"); err != nil {
+		return nil, err
+	}
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+
+	due := make([]*Notification, 0)
+	for _, notification := range ms.notifications {
+		if notification.Status == NotificationStatusPending && !notification.NextAttemptAt.After(now) && ms.visible(notification.TenantID) {
+			copied := *notification
+			due = append(due, &copied)
+		}
+	}
+	sort.Slice(due, func(i, j int) bool {
+		if !due[i].NextAttemptAt.Equal(due[j].NextAttemptAt) {
+			return due[i].NextAttemptAt.Before(due[j].NextAttemptAt)
+		}
+		return due[i].CreatedAt.Before(due[j].CreatedAt)
+	})
+	if limit > 0 && len(due) > limit {
+		due = due[:limit]
+	}
+	return due, nil
+}
+
+// UpdateNotification records a notification's delivery state
+func (ms *MemoryStore) UpdateNotification(notification *Notification) error {
+	if err := ms.fault("UpdateNotification"); err != nil {
+		return err
+	}
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	stored, exists := ms.notifications[notification.ID]
+	if !exists || !ms.visible(stored.TenantID) {
+		return fmt.Errorf("notification not found")
+	}
+	stored.Attempts = notification.Attempts
+	stored.NextAttemptAt = notification.NextAttemptAt
+	stored.Status = notification.Status
+	stored.LastError = notification.LastError
+	stored.UpdatedAt = ms.now()
+	return nil
+}
+
+// GetNotificationStats counts notifications by status
+func (ms *MemoryStore) GetNotificationStats() (*NotificationStats, error) {
+	if err := ms.fault("GetNotificationStats"); err != nil {
+		return nil, err
+	}
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+
+	stats := &NotificationStats{}
+	for _, notification := range ms.notifications {
+		if !ms.visible(notification.TenantID) {
+			continue
+		}
+		switch notification.Status {
+		case NotificationStatusPending:
+			stats.Pending++
+			if notification.Attempts > 0 {
+				stats.Retrying++
+			}
+		case NotificationStatusDelivered:
+			stats.Delivered++
+		case NotificationStatusFailed:
+			stats.Failed++
+		}
+	}
+	return stats, nil
+}
+
+// Calibration run operations
+
+// CreateCalibrationRun stores a copy of a calibration run
+func (ms *MemoryStore) CreateCalibrationRun(run *CalibrationRun) error {
+	if err := ms.fault("CreateCalibrationRun"); err != nil {
+		return err
+	}
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	if _, exists := ms.calibrations[run.ID]; exists {
+		return ErrAlreadyExists
+	}
+	stampTenant(ms.tenantID, &run.TenantID)
+	stampCreated(ms.now(), &run.CreatedAt, &run.UpdatedAt)
+	stored := *run
+	ms.calibrations[run.ID] = &stored
+	return nil
+}
+
+// GetCalibrationRun retrieves a copy of a calibration run by ID
+func (ms *MemoryStore) GetCalibrationRun(id string) (*CalibrationRun, error) {
+	if err := ms.fault("GetCalibrationRun"); err != nil {
+		return nil, err
+	}
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+	run, exists := ms.calibrations[id]
+	if !exists || !ms.visible(run.TenantID) {
+		return nil, fmt.Errorf("calibration run not found")
+	}
+	copied := *run
+	return &copied, nil
+}
@@ -1,7 +1,10 @@
 package data_test
 
 import (
+	"errors"
 	"fmt"
+	"reflect"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -18,6 +21,7 @@ func TestMemoryStore_InterviewOperations(t *testing.T) {
 		CandidateName:  "John Doe",
 		Questions:      []string{"Q1", "Q2"},
 		InterviewType:  "technical",
+		InterviewMode:  data.InterviewModeConversational,
 		JobDescription: "Software Engineer",
 		Status:         "pending",
 		CreatedAt:      time.Now(),
@@ -44,6 +48,9 @@ func TestMemoryStore_InterviewOperations(t *testing.T) {
 	if retrieved.InterviewType != interview.InterviewType {
 		t.Errorf("expected InterviewType %s, got %s", interview.InterviewType, retrieved.InterviewType)
 	}
+	if retrieved.InterviewMode != data.InterviewModeConversational {
+		t.Errorf("expected InterviewMode %s, got %s", data.InterviewModeConversational, retrieved.InterviewMode)
+	}
 
 	// Test GetInterview with non-existent ID
 	_, err = store.GetInterview("non-existent")
@@ -310,6 +317,186 @@ func TestMemoryStore_ChatSessionOperations(t *testing.T) {
 	}
 }
 
+func TestMemoryStore_AppendAskedQuestion(t *testing.T) {
+	store := data.NewMemoryStore()
+
+	session := &data.ChatSession{
+		ID:          "asked-session",
+		InterviewID: "test-interview-1",
+		Status:      "active",
+	}
+	if err := store.CreateChatSession(session); err != nil {
+		t.Fatalf("CreateChatSession failed: %v", err)
+	}
+
+	for _, q := range []string{"First?", "Second?"} {
+		if err := store.AppendAskedQuestion("asked-session", q); err != nil {
+			t.Fatalf("AppendAskedQuestion failed: %v", err)
+		}
+	}
+
+	retrieved, _ := store.GetChatSession("asked-session")
+	if len(retrieved.AskedQuestions) != 2 || retrieved.AskedQuestions[1] != "Second?" {
+		t.Errorf("expected asked questions in order, got %v", retrieved.AskedQuestions)
+	}
+
+	if err := store.AppendAskedQuestion("non-existent", "Q?"); err == nil {
+		t.Error("expected error for non-existent chat session")
+	}
+}
+
+func TestMemoryStore_AppendDifficultyLevel(t *testing.T) {
+	store := data.NewMemoryStore()
+	for _, id := range []string{"difficulty-session-1", "difficulty-session-2"} {
+		session := &data.ChatSession{ID: id, InterviewID: "test-interview-1", Status: "active", DifficultyLevel: 3, DifficultyTrajectory: data.IntArray{3}}
+		if err := store.CreateChatSession(session); err != nil {
+			t.Fatalf("CreateChatSession failed: %v", err)
+		}
+	}
+
+	for _, level := range []int{4, 5} {
+		if err := store.AppendDifficultyLevel("difficulty-session-1", level); err != nil {
+			t.Fatalf("AppendDifficultyLevel failed: %v", err)
+		}
+	}
+
+	retrieved, _ := store.GetChatSession("difficulty-session-1")
+	if retrieved.DifficultyLevel != 5 || !reflect.DeepEqual([]int(retrieved.DifficultyTrajectory), []int{3, 4, 5}) {
+		t.Errorf("expected level 5 with trajectory [3 4 5], got %d %v", retrieved.DifficultyLevel, retrieved.DifficultyTrajectory)
+	}
+
+	sessions, err := store.GetChatSessionsByInterview("test-interview-1")
+	if err != nil {
+		t.Fatalf("GetChatSessionsByInterview failed: %v", err)
+	}
+	if len(sessions) != 2 {
+		t.Errorf("expected 2 sessions for the interview, got %d", len(sessions))
+	}
+
+	if err := store.AppendDifficultyLevel("non-existent", 2); err == nil {
+		t.Error("expected error for non-existent chat session")
+	}
+}
+
+func TestMemoryStore_PinChatSessionProvider(t *testing.T) {
+	store := data.NewMemoryStore()
+	if err := store.CreateChatSession(&data.ChatSession{ID: "pin-session-1", InterviewID: "test-interview-1", Status: "active"}); err != nil {
+		t.Fatalf("CreateChatSession failed: %v", err)
+	}
+
+	if err := store.PinChatSessionProvider("pin-session-1", "openai", "gpt-4o"); err != nil {
+		t.Fatalf("PinChatSessionProvider failed: %v", err)
+	}
+	retrieved, _ := store.GetChatSession("pin-session-1")
+	if retrieved.Provider != "openai" || retrieved.Model != "gpt-4o" {
+		t.Errorf("expected the session pinned to openai/gpt-4o, got %s/%s", retrieved.Provider, retrieved.Model)
+	}
+
+	if err := store.PinChatSessionProvider("non-existent", "openai", "gpt-4o"); err == nil {
+		t.Error("expected error for non-existent chat session")
+	}
+}
+
+func TestMemoryStore_GetChatMessageByClientID(t *testing.T) {
+	store := data.NewMemoryStore()
+	for _, id := range []string{"client-session-1", "client-session-2"} {
+		if err := store.CreateChatSession(&data.ChatSession{ID: id, InterviewID: "test-interview-1", Status: "active"}); err != nil {
+			t.Fatalf("CreateChatSession failed: %v", err)
+		}
+	}
+
+	message := &data.ChatMessage{ID: "msg-1", SessionID: "client-session-1", ClientMessageID: "client-1", Type: "user", Content: "Hello"}
+	if err := store.AddChatMessage(message); err != nil {
+		t.Fatalf("AddChatMessage failed: %v", err)
+	}
+
+	found, err := store.GetChatMessageByClientID("client-session-1", "client-1")
+	if err != nil || found.ID != "msg-1" {
+		t.Errorf("expected to find msg-1, got %v (err %v)", found, err)
+	}
+
+	// Lookups are scoped to the session
+	if _, err := store.GetChatMessageByClientID("client-session-2", "client-1"); err == nil {
+		t.Error("expected no match in a different session")
+	}
+
+	// The same client ID may not be stored twice in one session, but may be reused across sessions
+	duplicate := &data.ChatMessage{ID: "msg-2", SessionID: "client-session-1", ClientMessageID: "client-1", Type: "user", Content: "Hello"}
+	if err := store.AddChatMessage(duplicate); err == nil {
+		t.Error("expected error for duplicate client message ID in the same session")
+	}
+	other := &data.ChatMessage{ID: "msg-3", SessionID: "client-session-2", ClientMessageID: "client-1", Type: "user", Content: "Hello"}
+	if err := store.AddChatMessage(other); err != nil {
+		t.Errorf("expected client message ID to be reusable across sessions, got %v", err)
+	}
+
+	// Messages without a client ID never collide
+	for _, id := range []string{"msg-4", "msg-5"} {
+		if err := store.AddChatMessage(&data.ChatMessage{ID: id, SessionID: "client-session-1", Type: "ai", Content: "Hi"}); err != nil {
+			t.Errorf("AddChatMessage without client ID failed: %v", err)
+		}
+	}
+	if _, err := store.GetChatMessageByClientID("client-session-1", ""); err == nil {
+		t.Error("expected empty client ID to never match")
+	}
+}
+
+func TestMemoryStore_MessageLimitAndWindows(t *testing.T) {
+	store := data.NewMemoryStore()
+	if err := store.CreateChatSession(&data.ChatSession{ID: "capped-session", InterviewID: "test-interview-1", Status: "active"}); err != nil {
+		t.Fatalf("CreateChatSession failed: %v", err)
+	}
+
+	// The cap admits exactly maxMessages messages
+	for i := 0; i < 3; i++ {
+		message := &data.ChatMessage{ID: fmt.Sprintf("msg-%d", i), SessionID: "capped-session", Type: "user", Content: "Hello"}
+		if err := store.AddChatMessageWithLimit(message, 3); err != nil {
+			t.Fatalf("AddChatMessageWithLimit %d failed: %v", i, err)
+		}
+	}
+	overflow := &data.ChatMessage{ID: "msg-3", SessionID: "capped-session", Type: "user", Content: "Hello"}
+	if err := store.AddChatMessageWithLimit(overflow, 3); !errors.Is(err, data.ErrMessageLimitReached) {
+		t.Errorf("expected ErrMessageLimitReached at the cap, got %v", err)
+	}
+	if err := store.AddChatMessageWithLimit(overflow, 0); err != nil {
+		t.Errorf("expected no cap with a limit of 0, got %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		options data.ListMessagesOptions
+		wantIDs []string
+	}{
+		{"no limit", data.ListMessagesOptions{}, []string{"msg-0", "msg-1", "msg-2", "msg-3"}},
+		{"first page", data.ListMessagesOptions{Limit: 2}, []string{"msg-0", "msg-1"}},
+		{"second page", data.ListMessagesOptions{Limit: 2, Offset: 2}, []string{"msg-2", "msg-3"}},
+		{"partial page", data.ListMessagesOptions{Limit: 3, Offset: 3}, []string{"msg-3"}},
+		{"offset past end", data.ListMessagesOptions{Limit: 2, Offset: 10}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := store.GetChatMessagesWithOptions("capped-session", tt.options)
+			if err != nil {
+				t.Fatalf("GetChatMessagesWithOptions failed: %v", err)
+			}
+			if result.Total != 4 {
+				t.Errorf("expected total 4, got %d", result.Total)
+			}
+			var ids []string
+			for _, msg := range result.Messages {
+				ids = append(ids, msg.ID)
+			}
+			if strings.Join(ids, ",") != strings.Join(tt.wantIDs, ",") {
+				t.Errorf("expected messages %v, got %v", tt.wantIDs, ids)
+			}
+		})
+	}
+
+	if _, err := store.GetChatMessagesWithOptions("missing-session", data.ListMessagesOptions{Limit: 2}); err == nil {
+		t.Error("expected error for non-existent session")
+	}
+}
+
 func TestMemoryStore_ChatMessageOperations(t *testing.T) {
 	store := data.NewMemoryStore()
 
@@ -509,3 +696,825 @@ func TestMemoryStore_EdgeCases(t *testing.T) {
 		}
 	})
 }
+
+func TestMemoryStore_GetLatestEvaluationByInterview(t *testing.T) {
+	store := data.NewMemoryStore()
+	if _, err := store.GetLatestEvaluationByInterview("interview-1"); err == nil {
+		t.Error("expected error when the interview has no evaluations")
+	}
+
+	now := time.Now()
+	evaluations := []*data.Evaluation{
+		{ID: "eval-1", InterviewID: "interview-1", CreatedAt: now.Add(-2 * time.Minute)},
+		{ID: "eval-other", InterviewID: "interview-2", CreatedAt: now},
+	}
+	for _, evaluation := range evaluations {
+		if err := store.CreateEvaluation(evaluation); err != nil {
+			t.Fatalf("CreateEvaluation failed: %v", err)
+		}
+	}
+
+	latest, err := store.GetLatestEvaluationByInterview("interview-1")
+	if err != nil || latest.ID != "eval-1" {
+		t.Fatalf("expected eval-1, got %v (err %v)", latest, err)
+	}
+
+	// A superseding evaluation wins even if its timestamp is not later
+	replacement := &data.Evaluation{ID: "eval-2", InterviewID: "interview-1", SupersedesID: "eval-1", CreatedAt: now.Add(-3 * time.Minute)}
+	if err := store.CreateEvaluation(replacement); err != nil {
+		t.Fatalf("CreateEvaluation failed: %v", err)
+	}
+	latest, err = store.GetLatestEvaluationByInterview("interview-1")
+	if err != nil || latest.ID != "eval-2" {
+		t.Errorf("expected superseding eval-2, got %v (err %v)", latest, err)
+	}
+
+	// The superseded evaluation remains retrievable by ID
+	if _, err := store.GetEvaluation("eval-1"); err != nil {
+		t.Errorf("expected superseded evaluation to remain retrievable, got %v", err)
+	}
+}
+
+func TestMemoryStore_GetLatestEvaluationsByInterviews(t *testing.T) {
+	store := data.NewMemoryStore()
+	now := time.Now()
+	evaluations := []*data.Evaluation{
+		{ID: "eval-1", InterviewID: "interview-1", CreatedAt: now.Add(-2 * time.Minute)},
+		{ID: "eval-1-new", InterviewID: "interview-1", CreatedAt: now.Add(-time.Minute)},
+		{ID: "eval-2", InterviewID: "interview-2", CreatedAt: now},
+		{ID: "eval-2-replacement", InterviewID: "interview-2", SupersedesID: "eval-2", CreatedAt: now.Add(-3 * time.Minute)},
+		{ID: "eval-other", InterviewID: "interview-other", CreatedAt: now},
+	}
+	for _, evaluation := range evaluations {
+		if err := store.CreateEvaluation(evaluation); err != nil {
+			t.Fatalf("CreateEvaluation failed: %v", err)
+		}
+	}
+
+	latest, err := store.GetLatestEvaluationsByInterviews([]string{"interview-1", "interview-2", "interview-none"})
+	if err != nil {
+		t.Fatalf("GetLatestEvaluationsByInterviews failed: %v", err)
+	}
+	if len(latest) != 2 || latest["interview-1"].ID != "eval-1-new" || latest["interview-2"].ID != "eval-2-replacement" {
+		t.Errorf("expected the current evaluation of each requested interview, got %v", latest)
+	}
+	// Each agrees with the single-interview read
+	for interviewID, evaluation := range latest {
+		if single, err := store.GetLatestEvaluationByInterview(interviewID); err != nil || single.ID != evaluation.ID {
+			t.Errorf("expected %s for %s, as GetLatestEvaluationByInterview returns, got %v (%v)", evaluation.ID, interviewID, single, err)
+		}
+	}
+
+	if latest, err := store.GetLatestEvaluationsByInterviews(nil); err != nil || len(latest) != 0 {
+		t.Errorf("expected nothing for no interviews, got %v (%v)", latest, err)
+	}
+}
+
+func TestMemoryStore_GetInterviewsGroupedByCandidate(t *testing.T) {
+	store := data.NewMemoryStore()
+	now := time.Now()
+	// Three candidates with 1-3 interviews each; names differ only in case and whitespace
+	interviews := []*data.Interview{
+		{ID: "alice-1", CandidateName: "Alice", CreatedAt: now.Add(-5 * time.Hour)},
+		{ID: "alice-2", CandidateName: " alice ", CreatedAt: now.Add(-4 * time.Hour)},
+		{ID: "alice-3", CandidateName: "ALICE", CreatedAt: now.Add(-1 * time.Hour)},
+		{ID: "bob-1", CandidateName: "Bob", CreatedAt: now.Add(-3 * time.Hour)},
+		{ID: "bob-2", CandidateName: "bob", CreatedAt: now.Add(-2 * time.Hour)},
+		{ID: "carol-1", CandidateName: "Carol", CreatedAt: now.Add(-6 * time.Hour)},
+	}
+	for _, interview := range interviews {
+		if err := store.CreateInterview(interview); err != nil {
+			t.Fatalf("CreateInterview failed: %v", err)
+		}
+	}
+	evaluations := []*data.Evaluation{
+		{ID: "eval-alice-old", InterviewID: "alice-1", Score: 0.9, CreatedAt: now.Add(-5 * time.Hour)},
+		{ID: "eval-alice", InterviewID: "alice-2", Score: 0.4, CreatedAt: now.Add(-4 * time.Hour)},
+		{ID: "eval-carol", InterviewID: "carol-1", Score: 0.6, CreatedAt: now.Add(-6 * time.Hour)},
+		{ID: "eval-carol-new", InterviewID: "carol-1", Score: 0.8, SupersedesID: "eval-carol", CreatedAt: now.Add(-6 * time.Hour)},
+	}
+	for _, evaluation := range evaluations {
+		if err := store.CreateEvaluation(evaluation); err != nil {
+			t.Fatalf("CreateEvaluation failed: %v", err)
+		}
+	}
+
+	t.Run("groups by activity", func(t *testing.T) {
+		result, err := store.GetInterviewsGroupedByCandidate(data.CandidateGroupOptions{Limit: 10})
+		if err != nil {
+			t.Fatalf("GetInterviewsGroupedByCandidate failed: %v", err)
+		}
+		if result.Total != 3 || len(result.Groups) != 3 {
+			t.Fatalf("expected 3 candidates, got total %d with %d groups", result.Total, len(result.Groups))
+		}
+		expected := []struct {
+			name  string
+			count int
+			score *float64
+		}{
+			{"ALICE", 3, floatPtr(0.4)},
+			{"bob", 2, nil},
+			{"Carol", 1, floatPtr(0.8)},
+		}
+		for i, want := range expected {
+			group := result.Groups[i]
+			if group.CandidateName != want.name || group.InterviewCount != want.count || len(group.Interviews) != want.count {
+				t.Errorf("group %d: expected %s with %d interviews, got %s with %d (%d listed)",
+					i, want.name, want.count, group.CandidateName, group.InterviewCount, len(group.Interviews))
+			}
+			if !group.LatestCreatedAt.Equal(group.Interviews[0].CreatedAt) {
+				t.Errorf("group %d: latest_created_at should match the newest interview", i)
+			}
+			if (want.score == nil) != (group.LatestScore == nil) || (want.score != nil && *want.score != *group.LatestScore) {
+				t.Errorf("group %d: expected latest score %v, got %v", i, want.score, group.LatestScore)
+			}
+		}
+		if result.Groups[0].Interviews[0].ID != "alice-3" || result.Groups[0].Interviews[2].ID != "alice-1" {
+			t.Error("expected interviews within a group ordered most recent first")
+		}
+	})
+
+	t.Run("sorts by score", func(t *testing.T) {
+		result, err := store.GetInterviewsGroupedByCandidate(data.CandidateGroupOptions{Limit: 10, SortBy: data.CandidateSortScore})
+		if err != nil {
+			t.Fatalf("GetInterviewsGroupedByCandidate failed: %v", err)
+		}
+		var names []string
+		for _, group := range result.Groups {
+			names = append(names, group.CandidateName)
+		}
+		if strings.Join(names, ",") != "Carol,ALICE,bob" {
+			t.Errorf("expected Carol,ALICE,bob, got %v", names)
+		}
+	})
+
+	t.Run("paginates over candidates", func(t *testing.T) {
+		tests := []struct {
+			offset   int
+			expected int
+		}{
+			{0, 2},
+			{2, 1},
+			{3, 0},
+			{10, 0},
+		}
+		for _, tt := range tests {
+			result, err := store.GetInterviewsGroupedByCandidate(data.CandidateGroupOptions{Limit: 2, Offset: tt.offset})
+			if err != nil {
+				t.Fatalf("GetInterviewsGroupedByCandidate failed: %v", err)
+			}
+			if len(result.Groups) != tt.expected || result.Total != 3 {
+				t.Errorf("offset %d: expected %d groups of 3 total, got %d of %d", tt.offset, tt.expected, len(result.Groups), result.Total)
+			}
+		}
+	})
+}
+
+func floatPtr(v float64) *float64 {
+	return &v
+}
+
+func TestMemoryStore_GetCompletedSessionsWithoutEvaluation(t *testing.T) {
+	store := data.NewMemoryStore()
+	now := time.Now()
+	sessions := []*data.ChatSession{
+		{ID: "session-new", InterviewID: "interview-1", Status: "completed", CreatedAt: now},
+		{ID: "session-old", InterviewID: "interview-2", Status: "completed", CreatedAt: now.Add(-time.Hour)},
+		{ID: "session-active", InterviewID: "interview-3", Status: "active", CreatedAt: now},
+		{ID: "session-evaluated", InterviewID: "interview-4", Status: "completed", CreatedAt: now},
+	}
+	for _, session := range sessions {
+		if err := store.CreateChatSession(session); err != nil {
+			t.Fatalf("CreateChatSession failed: %v", err)
+		}
+	}
+	if err := store.CreateEvaluation(&data.Evaluation{ID: "eval-1", InterviewID: "interview-4"}); err != nil {
+		t.Fatalf("CreateEvaluation failed: %v", err)
+	}
+
+	got, err := store.GetCompletedSessionsWithoutEvaluation(0)
+	if err != nil {
+		t.Fatalf("GetCompletedSessionsWithoutEvaluation failed: %v", err)
+	}
+	if len(got) != 2 || got[0].ID != "session-old" || got[1].ID != "session-new" {
+		t.Errorf("expected session-old then session-new, got %v", got)
+	}
+
+	got, _ = store.GetCompletedSessionsWithoutEvaluation(1)
+	if len(got) != 1 || got[0].ID != "session-old" {
+		t.Errorf("expected the limit to keep the oldest session, got %v", got)
+	}
+}
+
+func TestMemoryStore_DuplicatesReturnErrAlreadyExists(t *testing.T) {
+	store := data.NewMemoryStore()
+	if err := store.CreateInterview(&data.Interview{ID: "interview-1"}); err != nil {
+		t.Fatalf("CreateInterview failed: %v", err)
+	}
+	if err := store.CreateChatSession(&data.ChatSession{ID: "session-1", InterviewID: "interview-1"}); err != nil {
+		t.Fatalf("CreateChatSession failed: %v", err)
+	}
+	if err := store.CreateEvaluation(&data.Evaluation{ID: "eval-1", InterviewID: "interview-1"}); err != nil {
+		t.Fatalf("CreateEvaluation failed: %v", err)
+	}
+	if err := store.AddChatMessage(&data.ChatMessage{ID: "msg-1", SessionID: "session-1", ClientMessageID: "client-1"}); err != nil {
+		t.Fatalf("AddChatMessage failed: %v", err)
+	}
+
+	duplicates := map[string]error{
+		"interview":         store.CreateInterview(&data.Interview{ID: "interview-1"}),
+		"session":           store.CreateChatSession(&data.ChatSession{ID: "session-1", InterviewID: "interview-1"}),
+		"evaluation":        store.CreateEvaluation(&data.Evaluation{ID: "eval-1", InterviewID: "interview-1"}),
+		"message ID":        store.AddChatMessage(&data.ChatMessage{ID: "msg-1", SessionID: "session-1"}),
+		"client message ID": store.AddChatMessage(&data.ChatMessage{ID: "msg-2", SessionID: "session-1", ClientMessageID: "client-1"}),
+	}
+	for name, err := range duplicates {
+		if !errors.Is(err, data.ErrAlreadyExists) {
+			t.Errorf("%s: expected ErrAlreadyExists, got %v", name, err)
+		}
+	}
+	// The duplicate session did not reset the stored transcript
+	if messages, _ := store.GetChatMessages("session-1"); len(messages) != 1 {
+		t.Errorf("expected the original message to remain, got %d messages", len(messages))
+	}
+}
+
+func TestMemoryStore_IdleSessionsAndHeartbeats(t *testing.T) {
+	store := data.NewMemoryStore()
+	now := time.Now()
+	sessions := []*data.ChatSession{
+		{ID: "session-quiet", Status: "active", StartedAt: now.Add(-time.Hour), CreatedAt: now.Add(-time.Hour)},
+		{ID: "session-message", Status: "active", StartedAt: now.Add(-time.Hour), CreatedAt: now.Add(-time.Hour)},
+		{ID: "session-heartbeat", Status: "active", StartedAt: now.Add(-time.Hour), CreatedAt: now.Add(-time.Hour)},
+		{ID: "session-completed", Status: "completed", StartedAt: now.Add(-time.Hour), CreatedAt: now.Add(-time.Hour)},
+	}
+	for _, session := range sessions {
+		if err := store.CreateChatSession(session); err != nil {
+			t.Fatalf("CreateChatSession failed: %v", err)
+		}
+	}
+	if err := store.AddChatMessage(&data.ChatMessage{ID: "msg-1", SessionID: "session-message", Timestamp: now.Add(-time.Minute)}); err != nil {
+		t.Fatalf("AddChatMessage failed: %v", err)
+	}
+	recorded, err := store.RecordChatSessionHeartbeat("session-heartbeat", now.Add(-time.Minute), 30*time.Second)
+	if err != nil || !recorded {
+		t.Fatalf("expected the first heartbeat to be recorded, got %v (%v)", recorded, err)
+	}
+	if recorded, _ := store.RecordChatSessionHeartbeat("session-heartbeat", now.Add(-time.Minute+10*time.Second), 30*time.Second); recorded {
+		t.Error("expected a heartbeat within the interval to be rate-limited")
+	}
+	if _, err := store.RecordChatSessionHeartbeat("missing", now, 30*time.Second); err == nil {
+		t.Error("expected an error for an unknown session")
+	}
+
+	idle, err := store.GetIdleChatSessions(now.Add(-10*time.Minute), 0)
+	if err != nil {
+		t.Fatalf("GetIdleChatSessions failed: %v", err)
+	}
+	if len(idle) != 1 || idle[0].ID != "session-quiet" {
+		t.Errorf("expected only session-quiet to be idle, got %v", idle)
+	}
+}
+
+func TestMemoryStore_AbandonIdleChatSession(t *testing.T) {
+	store := data.NewMemoryStore()
+	now := time.Now()
+	if err := store.CreateChatSession(&data.ChatSession{ID: "session-1", Status: "active", StartedAt: now.Add(-time.Hour)}); err != nil {
+		t.Fatalf("CreateChatSession failed: %v", err)
+	}
+	cutoff := now.Add(-10 * time.Minute)
+
+	// A message since cutoff keeps the session active
+	if err := store.AddChatMessage(&data.ChatMessage{ID: "msg-1", SessionID: "session-1", Timestamp: now.Add(-time.Minute)}); err != nil {
+		t.Fatalf("AddChatMessage failed: %v", err)
+	}
+	if abandoned, err := store.AbandonIdleChatSession("session-1", cutoff, now); err != nil || abandoned {
+		t.Fatalf("expected a session with a recent message to stay active, got %v (%v)", abandoned, err)
+	}
+	if abandoned, err := store.AbandonIdleChatSession("session-1", now, now); err != nil || !abandoned {
+		t.Fatalf("expected the idle session to be abandoned, got %v (%v)", abandoned, err)
+	}
+	session, _ := store.GetChatSession("session-1")
+	if session.Status != data.SessionStatusAbandoned || session.EndedAt == nil || !session.EndedAt.Equal(now) {
+		t.Errorf("expected the session abandoned at %v, got %q at %v", now, session.Status, session.EndedAt)
+	}
+	// Only active sessions are abandoned
+	if abandoned, _ := store.AbandonIdleChatSession("session-1", now, now); abandoned {
+		t.Error("expected an abandoned session not to be abandoned again")
+	}
+	if _, err := store.AbandonIdleChatSession("missing", now, now); err == nil {
+		t.Error("expected an error for an unknown session")
+	}
+}
+
+func TestMemoryStore_RecordChatSessionAIAttempt(t *testing.T) {
+	store := data.NewMemoryStore()
+	if err := store.CreateChatSession(&data.ChatSession{ID: "session-1", Status: "active"}); err != nil {
+		t.Fatalf("CreateChatSession failed: %v", err)
+	}
+	for i := range 2 {
+		if recorded, err := store.RecordChatSessionAIAttempt("session-1", 2); err != nil || !recorded {
+			t.Fatalf("expected attempt %d to be recorded, got %v (%v)", i+1, recorded, err)
+		}
+	}
+	if recorded, _ := store.RecordChatSessionAIAttempt("session-1", 2); recorded {
+		t.Error("expected a third attempt to be refused")
+	}
+	// No limit
+	if recorded, _ := store.RecordChatSessionAIAttempt("session-1", 0); !recorded {
+		t.Error("expected an attempt without a limit to be recorded")
+	}
+	session, _ := store.GetChatSession("session-1")
+	if session.AIAttempts != 3 {
+		t.Errorf("expected 3 attempts, got %d", session.AIAttempts)
+	}
+	if _, err := store.RecordChatSessionAIAttempt("missing", 2); err == nil {
+		t.Error("expected an error for an unknown session")
+	}
+}
+
+func TestMemoryStore_RecordChatSessionAbuse(t *testing.T) {
+	store := data.NewMemoryStore()
+	if err := store.CreateChatSession(&data.ChatSession{ID: "session-1", Status: "active"}); err != nil {
+		t.Fatalf("CreateChatSession failed: %v", err)
+	}
+	for _, reason := range []string{"message_too_soon", "duplicate_message", "message_too_soon"} {
+		if err := store.RecordChatSessionAbuse("session-1", reason); err != nil {
+			t.Fatalf("RecordChatSessionAbuse failed: %v", err)
+		}
+	}
+	session, _ := store.GetChatSession("session-1")
+	if session.AbuseRejections["message_too_soon"] != 2 || session.AbuseRejections["duplicate_message"] != 1 {
+		t.Errorf("expected 2 message_too_soon and 1 duplicate_message, got %v", session.AbuseRejections)
+	}
+	if err := store.RecordChatSessionAbuse("missing", "message_too_soon"); err == nil {
+		t.Error("expected an error for an unknown session")
+	}
+}
+
+func TestMemoryStore_ReopenChatSession(t *testing.T) {
+	store := data.NewMemoryStore()
+	now := time.Now()
+	endedAt := now.Add(-10 * time.Minute)
+	if err := store.CreateChatSession(&data.ChatSession{ID: "session-1", InterviewID: "interview-1", Status: "completed", EndedAt: &endedAt}); err != nil {
+		t.Fatalf("CreateChatSession failed: %v", err)
+	}
+	if err := store.CreateEvaluation(&data.Evaluation{ID: "eval-1", InterviewID: "interview-1", CreatedAt: now}); err != nil {
+		t.Fatalf("CreateEvaluation failed: %v", err)
+	}
+
+	// Ended before the window
+	if reopened, err := store.ReopenChatSession("session-1", now.Add(-5*time.Minute), "eval-1"); err != nil || reopened {
+		t.Errorf("expected a session ended before the window to stay completed, got %v (%v)", reopened, err)
+	}
+	if reopened, err := store.ReopenChatSession("session-1", now.Add(-time.Hour), "eval-1"); err != nil || !reopened {
+		t.Fatalf("expected the session to be reopened, got %v (%v)", reopened, err)
+	}
+	session, _ := store.GetChatSession("session-1")
+	if session.Status != "active" || session.EndedAt != nil || session.ReopenCount != 1 || session.ReopenedEvaluationID != "eval-1" {
+		t.Errorf("unexpected reopened session %+v", session)
+	}
+	// Only completed sessions are reopened
+	if reopened, _ := store.ReopenChatSession("session-1", now.Add(-time.Hour), "eval-1"); reopened {
+		t.Error("expected an active session not to be reopened")
+	}
+	if _, err := store.ReopenChatSession("missing", now, ""); err == nil {
+		t.Error("expected an error for an unknown session")
+	}
+
+	// A voided evaluation is no longer current
+	if err := store.SupersedeEvaluation("eval-1"); err != nil {
+		t.Fatalf("SupersedeEvaluation failed: %v", err)
+	}
+	if latest, err := store.GetLatestEvaluationByInterview("interview-1"); err == nil {
+		t.Errorf("expected no current evaluation, got %v", latest)
+	}
+	if evaluation, err := store.GetEvaluation("eval-1"); err != nil || evaluation.Status != data.EvaluationStatusSuperseded {
+		t.Errorf("expected the voided evaluation to remain retrievable, got %v (err %v)", evaluation, err)
+	}
+}
+
+func TestMemoryStore_SetInterviewJobDescriptionSummary(t *testing.T) {
+	store := data.NewMemoryStore()
+	if err := store.CreateInterview(&data.Interview{ID: "interview-1", JobDescription: "A long posting"}); err != nil {
+		t.Fatalf("CreateInterview failed: %v", err)
+	}
+	if err := store.SetInterviewJobDescriptionSummary("interview-1", "Short summary"); err != nil {
+		t.Fatalf("SetInterviewJobDescriptionSummary failed: %v", err)
+	}
+	interview, _ := store.GetInterview("interview-1")
+	if interview.JobDescSummary != "Short summary" || interview.JobDescription != "A long posting" {
+		t.Errorf("expected summary cached next to the original, got %q / %q", interview.JobDescSummary, interview.JobDescription)
+	}
+	if err := store.SetInterviewJobDescriptionSummary("missing", "Summary"); err == nil {
+		t.Error("expected an error for an unknown interview")
+	}
+}
+
+func TestMemoryStore_GetEvaluationDecisionCounts(t *testing.T) {
+	store := data.NewMemoryStore()
+	for _, interview := range []*data.Interview{
+		{ID: "interview-1", InterviewType: "technical"},
+		{ID: "interview-2", InterviewType: "technical"},
+		{ID: "interview-3", InterviewType: "behavioral"},
+	} {
+		if err := store.CreateInterview(interview); err != nil {
+			t.Fatalf("CreateInterview failed: %v", err)
+		}
+	}
+	for _, evaluation := range []*data.Evaluation{
+		{ID: "eval-1", InterviewID: "interview-1", Decision: "hire"},
+		{ID: "eval-2", InterviewID: "interview-2", Decision: "hire"},
+		{ID: "eval-3", InterviewID: "interview-3", Decision: "no_hire"},
+		{ID: "eval-4", InterviewID: "interview-3", Decision: "more_data_needed", SupersedesID: "eval-3"},
+		{ID: "eval-5", InterviewID: "interview-3"},
+	} {
+		if err := store.CreateEvaluation(evaluation); err != nil {
+			t.Fatalf("CreateEvaluation failed: %v", err)
+		}
+	}
+
+	counts, err := store.GetEvaluationDecisionCounts()
+	if err != nil {
+		t.Fatalf("GetEvaluationDecisionCounts failed: %v", err)
+	}
+	expected := []*data.DecisionCount{
+		{InterviewType: "behavioral", Decision: "more_data_needed", Evaluations: 1},
+		{InterviewType: "technical", Decision: "hire", Evaluations: 2},
+	}
+	if !reflect.DeepEqual(counts, expected) {
+		t.Errorf("expected %+v, got %+v", expected, counts)
+	}
+}
+
+func TestMemoryStore_GetScoreDistribution(t *testing.T) {
+	store := data.NewMemoryStore()
+	now := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	for _, interview := range []*data.Interview{
+		{ID: "interview-1", InterviewType: "technical"},
+		{ID: "interview-2", InterviewType: "behavioral"},
+	} {
+		if err := store.CreateInterview(interview); err != nil {
+			t.Fatalf("CreateInterview failed: %v", err)
+		}
+	}
+	for _, evaluation := range []*data.Evaluation{
+		{ID: "eval-1", InterviewID: "interview-1", Model: "gpt-4", Score: 0.8, CreatedAt: now},
+		{ID: "eval-2", InterviewID: "interview-1", Model: "gpt-4", Score: 0.4, CreatedAt: now.Add(-time.Hour)},
+		{ID: "eval-3", InterviewID: "interview-1", Model: "gpt-4", Score: 0.6, CreatedAt: now, SupersedesID: "eval-1"},
+		{ID: "eval-4", InterviewID: "interview-1", Model: "gpt-4", Score: 0.9, CreatedAt: now.Add(-48 * time.Hour)},
+		{ID: "eval-5", InterviewID: "interview-1", Model: "gemini", Score: 0.5, CreatedAt: now},
+		{ID: "eval-6", InterviewID: "interview-2", Model: "gpt-4", Score: 0.7, CreatedAt: now},
+		{ID: "eval-7", InterviewID: "interview-1", Model: "gpt-4", Status: data.EvaluationStatusNoAnswers, CreatedAt: now},
+	} {
+		if err := store.CreateEvaluation(evaluation); err != nil {
+			t.Fatalf("CreateEvaluation failed: %v", err)
+		}
+	}
+
+	// Only current, completed technical gpt-4 evaluations of the last day count, sorted ascending
+	scores, err := store.GetScoreDistribution("technical", "gpt-4", now.Add(-24*time.Hour))
+	if err != nil {
+		t.Fatalf("GetScoreDistribution failed: %v", err)
+	}
+	if expected := []float64{0.4, 0.6}; !reflect.DeepEqual(scores, expected) {
+		t.Errorf("expected %v, got %v", expected, scores)
+	}
+
+	scores, err = store.GetScoreDistribution("technical", "claude", time.Time{})
+	if err != nil || len(scores) != 0 {
+		t.Errorf("expected no scores for an unknown model, got %v (%v)", scores, err)
+	}
+}
+
+func TestMemoryStore_NotificationOutbox(t *testing.T) {
+	store := data.NewMemoryStore()
+	now := time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC)
+	for _, notification := range []*data.Notification{
+		{ID: "later", Status: data.NotificationStatusPending, NextAttemptAt: now.Add(time.Minute)},
+		{ID: "due-second", Status: data.NotificationStatusPending, NextAttemptAt: now},
+		{ID: "due-first", Status: data.NotificationStatusPending, NextAttemptAt: now.Add(-time.Minute)},
+		{ID: "done", Status: data.NotificationStatusDelivered, NextAttemptAt: now.Add(-time.Hour)},
+	} {
+		notification.Type = data.NotificationTypeWebhook
+		if err := store.CreateNotification(notification); err != nil {
+			t.Fatalf("CreateNotification failed: %v", err)
+		}
+	}
+	if err := store.CreateNotification(&data.Notification{ID: "later"}); !errors.Is(err, data.ErrAlreadyExists) {
+		t.Errorf("expected ErrAlreadyExists for a duplicate ID, got %v", err)
+	}
+
+	due, err := store.GetDueNotifications(now, 0)
+	if err != nil {
+		t.Fatalf("GetDueNotifications failed: %v", err)
+	}
+	if len(due) != 2 || due[0].ID != "due-first" || due[1].ID != "due-second" {
+		t.Fatalf("expected the two due notifications, earliest first, got %v", due)
+	}
+
+	// Returned notifications are copies; changes are only kept through UpdateNotification
+	due[0].Attempts, due[0].Status = 1, data.NotificationStatusFailed
+	if stats, _ := store.GetNotificationStats(); stats.Failed != 0 {
+		t.Errorf("expected the stored notification to be unchanged, got %+v", stats)
+	}
+	if err := store.UpdateNotification(due[0]); err != nil {
+		t.Fatalf("UpdateNotification failed: %v", err)
+	}
+	due[1].Attempts, due[1].NextAttemptAt = 1, now.Add(time.Hour)
+	if err := store.UpdateNotification(due[1]); err != nil {
+		t.Fatalf("UpdateNotification failed: %v", err)
+	}
+	if due, _ := store.GetDueNotifications(now, 0); len(due) != 0 {
+		t.Errorf("expected nothing due after the updates, got %v", due)
+	}
+
+	stats, err := store.GetNotificationStats()
+	if err != nil {
+		t.Fatalf("GetNotificationStats failed: %v", err)
+	}
+	if *stats != (data.NotificationStats{Pending: 2, Retrying: 1, Delivered: 1, Failed: 1}) {
+		t.Errorf("unexpected stats %+v", stats)
+	}
+	if err := store.UpdateNotification(&data.Notification{ID: "missing"}); err == nil {
+		t.Error("expected an error updating a missing notification")
+	}
+}
+
+func TestMemoryStore_CalibrationRuns(t *testing.T) {
+	store := data.NewMemoryStore()
+	score := 0.75
+	run := &data.CalibrationRun{
+		ID:         "run-1",
+		SessionIDs: data.StringArray{"session-1"},
+		Models:     data.CalibrationModelList{{Provider: "mock", Model: "mock-model"}},
+		Scores:     data.CalibrationScoreList{{SessionID: "session-1", Provider: "mock", Model: "mock-model", Score: &score}},
+	}
+	if err := store.CreateCalibrationRun(run); err != nil {
+		t.Fatalf("CreateCalibrationRun failed: %v", err)
+	}
+	if run.TenantID != data.DefaultTenantID || run.CreatedAt.IsZero() {
+		t.Errorf("expected the run stamped with the default tenant and creation time, got %q %v", run.TenantID, run.CreatedAt)
+	}
+	if err := store.CreateCalibrationRun(&data.CalibrationRun{ID: "run-1"}); !errors.Is(err, data.ErrAlreadyExists) {
+		t.Errorf("expected ErrAlreadyExists for a duplicate ID, got %v", err)
+	}
+
+	got, err := store.GetCalibrationRun("run-1")
+	if err != nil {
+		t.Fatalf("GetCalibrationRun failed: %v", err)
+	}
+	if !reflect.DeepEqual(got.Scores, run.Scores) || !reflect.DeepEqual(got.Models, run.Models) {
+		t.Errorf("expected the stored run back, got %+v", got)
+	}
+	if _, err := store.ForTenant("acme").GetCalibrationRun("run-1"); err == nil {
+		t.Error("expected another tenant not to see the run")
+	}
+	if _, err := store.GetCalibrationRun("missing"); err == nil {
+		t.Error("expected an error for a missing run")
+	}
+}
+
+func TestMemoryStore_EvaluationTraces(t *testing.T) {
+	store := data.NewMemoryStore()
+	trace := &data.EvaluationTrace{EvaluationID: "evaluation-1", Prompt: "Evaluate", Answers: "Q1: ...", Provider: "mock", Temperature: 0.3}
+	if err := store.CreateEvaluationTrace(trace); err != nil {
+		t.Fatalf("CreateEvaluationTrace failed: %v", err)
+	}
+	if trace.TenantID != data.DefaultTenantID || trace.CreatedAt.IsZero() {
+		t.Errorf("expected the trace stamped with the default tenant and creation time, got %q %v", trace.TenantID, trace.CreatedAt)
+	}
+	if err := store.CreateEvaluationTrace(&data.EvaluationTrace{EvaluationID: "evaluation-1"}); !errors.Is(err, data.ErrAlreadyExists) {
+		t.Errorf("expected ErrAlreadyExists for a second trace, got %v", err)
+	}
+
+	got, err := store.GetEvaluationTrace("evaluation-1")
+	if err != nil {
+		t.Fatalf("GetEvaluationTrace failed: %v", err)
+	}
+	if *got != *trace {
+		t.Errorf("expected the stored trace back, got %+v", got)
+	}
+	if _, err := store.ForTenant("acme").GetEvaluationTrace("evaluation-1"); err == nil {
+		t.Error("expected another tenant not to see the trace")
+	}
+	if _, err := store.GetEvaluationTrace("missing"); err == nil {
+		t.Error("expected an error for a missing trace")
+	}
+}
+
+func TestMemoryStore_Maintenance(t *testing.T) {
+	store := data.NewMemoryStore()
+	for _, tenant := range []string{"acme", "globex"} {
+		scoped := store.ForTenant(tenant)
+		if err := scoped.CreateInterview(&data.Interview{ID: "interview-" + tenant, CandidateName: "Alice"}); err != nil {
+			t.Fatalf("CreateInterview failed: %v", err)
+		}
+	}
+	if err := store.CreateChatSession(&data.ChatSession{ID: "session-1", InterviewID: "interview-acme"}); err != nil {
+		t.Fatalf("CreateChatSession failed: %v", err)
+	}
+	for i := range 3 {
+		if err := store.AddChatMessage(&data.ChatMessage{ID: fmt.Sprintf("message-%d", i), SessionID: "session-1", Type: "user", Content: "Hello"}); err != nil {
+			t.Fatalf("AddChatMessage failed: %v", err)
+		}
+	}
+
+	// Every table is reported, counting the entries of every tenant
+	report, err := store.ForTenant("acme").Maintenance()
+	if err != nil {
+		t.Fatalf("Maintenance failed: %v", err)
+	}
+	if report.Backend != data.BackendMemory || len(report.Vacuumed) != 0 || len(report.Tables) != 7 {
+		t.Fatalf("expected the 7 memory tables with nothing vacuumed, got %+v", report)
+	}
+	entries := make(map[string]int64)
+	for _, table := range report.Tables {
+		entries[table.Table] = table.Entries
+		if (table.Entries == 0) != (table.EstimatedBytes == 0) {
+			t.Errorf("%s: expected a size for and only for stored entries, got %d bytes for %d entries", table.Table, table.EstimatedBytes, table.Entries)
+		}
+	}
+	if entries["interviews"] != 2 || entries["chat_sessions"] != 1 || entries["chat_messages"] != 3 || entries["evaluations"] != 0 {
+		t.Errorf("expected 2 interviews, 1 session and 3 messages, got %v", entries)
+	}
+}
+
+func TestMemoryStore_CreateInterviewWithSession(t *testing.T) {
+	store := data.NewMemoryStore()
+	interview := &data.Interview{ID: "interview-1", CandidateName: "Alice", Questions: []string{"Q1"}}
+	session := &data.ChatSession{ID: "session-1", InterviewID: "interview-1", Status: "active"}
+	if err := store.CreateInterviewWithSession(interview, session); err != nil {
+		t.Fatalf("CreateInterviewWithSession failed: %v", err)
+	}
+	if _, err := store.GetInterview("interview-1"); err != nil {
+		t.Errorf("expected the interview to be stored: %v", err)
+	}
+	if messages, err := store.GetChatMessages("session-1"); err != nil || len(messages) != 0 {
+		t.Errorf("expected the session to be stored without messages, got %v, %v", messages, err)
+	}
+
+	// A clashing session ID stores neither
+	err := store.CreateInterviewWithSession(&data.Interview{ID: "interview-2"}, &data.ChatSession{ID: "session-1", InterviewID: "interview-2"})
+	if !errors.Is(err, data.ErrAlreadyExists) {
+		t.Errorf("expected ErrAlreadyExists, got %v", err)
+	}
+	if _, err := store.GetInterview("interview-2"); err == nil {
+		t.Error("expected the interview not to be stored when its session clashes")
+	}
+}
+
+func TestMemoryStore_Timestamps(t *testing.T) {
+	store := data.NewMemoryStore()
+	start := time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC)
+	now := start
+	store.SetClock(func() time.Time { return now })
+
+	interview := &data.Interview{ID: "interview-1", CandidateName: "Alice"}
+	if err := store.CreateInterview(interview); err != nil {
+		t.Fatalf("CreateInterview failed: %v", err)
+	}
+	if !interview.CreatedAt.Equal(start) || !interview.UpdatedAt.Equal(start) {
+		t.Errorf("expected both timestamps to be %v, got %v and %v", start, interview.CreatedAt, interview.UpdatedAt)
+	}
+
+	// Timestamps set by the caller are kept on create
+	imported := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	session := &data.ChatSession{ID: "session-1", InterviewID: "interview-1", CreatedAt: imported}
+	if err := store.CreateChatSession(session); err != nil {
+		t.Fatalf("CreateChatSession failed: %v", err)
+	}
+	if !session.CreatedAt.Equal(imported) || !session.UpdatedAt.Equal(imported) {
+		t.Errorf("expected the given creation time to be kept, got %v and %v", session.CreatedAt, session.UpdatedAt)
+	}
+	message := &data.ChatMessage{ID: "message-1", SessionID: "session-1", Type: "ai"}
+	if err := store.AddChatMessage(message); err != nil {
+		t.Fatalf("AddChatMessage failed: %v", err)
+	}
+	if !message.CreatedAt.Equal(start) {
+		t.Errorf("expected the message to be stamped %v, got %v", start, message.CreatedAt)
+	}
+
+	// Updates always bump UpdatedAt
+	now = start.Add(time.Minute)
+	if err := store.UpdateInterview(interview); err != nil {
+		t.Fatalf("UpdateInterview failed: %v", err)
+	}
+	if err := store.AppendAskedQuestion("session-1", "Q1"); err != nil {
+		t.Fatalf("AppendAskedQuestion failed: %v", err)
+	}
+	if !interview.CreatedAt.Equal(start) || !interview.UpdatedAt.Equal(now) || !session.UpdatedAt.Equal(now) {
+		t.Errorf("expected UpdatedAt %v, got interview %v/%v and session %v", now, interview.CreatedAt, interview.UpdatedAt, session.UpdatedAt)
+	}
+}
+
+func TestMemoryStore_PurgeMessagesOlderThan(t *testing.T) {
+	store := data.NewMemoryStore()
+	purgedAt := time.Date(2025, 4, 10, 3, 0, 0, 0, time.UTC)
+	store.SetClock(func() time.Time { return purgedAt })
+	cutoff := time.Date(2025, 3, 11, 3, 0, 0, 0, time.UTC)
+
+	for _, session := range []*data.ChatSession{
+		{ID: "ended", InterviewID: "interview-1", Status: "completed", ConversationSummary: "Earlier turns", SummarizedTurns: 4},
+		{ID: "active", InterviewID: "interview-2", Status: "active"},
+		{ID: "recent", InterviewID: "interview-3", Status: "abandoned"},
+	} {
+		if err := store.CreateChatSession(session); err != nil {
+			t.Fatalf("CreateChatSession failed: %v", err)
+		}
+	}
+	add := func(id, sessionID string, at time.Time) {
+		if err := store.AddChatMessage(&data.ChatMessage{ID: id, SessionID: sessionID, Type: "user", Content: "Hi", Timestamp: at}); err != nil {
+			t.Fatalf("AddChatMessage failed: %v", err)
+		}
+	}
+	add("old", "ended", cutoff.Add(-time.Nanosecond))
+	add("at-cutoff", "ended", cutoff)
+	add("active-old", "active", cutoff.Add(-time.Hour))
+	add("recent-new", "recent", cutoff.Add(time.Hour))
+
+	// Counting deletes nothing
+	if count, err := store.CountMessagesOlderThan(cutoff); err != nil || count != 1 {
+		t.Errorf("expected 1 message to purge, got %d (%v)", count, err)
+	}
+	if messages, _ := store.GetChatMessages("ended"); len(messages) != 2 {
+		t.Errorf("expected counting to keep the messages, got %d", len(messages))
+	}
+
+	purged, err := store.PurgeMessagesOlderThan(cutoff)
+	if err != nil {
+		t.Fatalf("PurgeMessagesOlderThan failed: %v", err)
+	}
+	if purged != 1 {
+		t.Errorf("expected only the message before the cutoff to be purged, got %d", purged)
+	}
+	messages, _ := store.GetChatMessages("ended")
+	if len(messages) != 1 || messages[0].ID != "at-cutoff" {
+		t.Errorf("expected the message at the cutoff to be kept, got %v", messages)
+	}
+	ended, _ := store.GetChatSession("ended")
+	if ended.TranscriptPurgedAt == nil || !ended.TranscriptPurgedAt.Equal(purgedAt) || ended.ConversationSummary != "" || ended.SummarizedTurns != 0 {
+		t.Errorf("expected the session to be marked purged with its summary cleared, got %+v", ended)
+	}
+	if ended.Status != "completed" || ended.InterviewID != "interview-1" {
+		t.Errorf("expected the session shell to be kept, got %+v", ended)
+	}
+
+	// Active sessions and sessions with nothing to purge are left alone
+	for _, id := range []string{"active", "recent"} {
+		session, _ := store.GetChatSession(id)
+		messages, _ := store.GetChatMessages(id)
+		if session.TranscriptPurgedAt != nil || len(messages) != 1 {
+			t.Errorf("expected session %s untouched, got purged at %v with %d messages", id, session.TranscriptPurgedAt, len(messages))
+		}
+	}
+
+	// Purged sessions are not evaluated by the backfill
+	if sessions, _ := store.GetCompletedSessionsWithoutEvaluation(0); len(sessions) != 0 {
+		t.Errorf("expected no purged session to be backfilled, got %v", sessions)
+	}
+}
+
+func TestMemoryStore_FaultPolicy(t *testing.T) {
+	errDown := errors.New("disk full")
+	policy := data.NewFaultPolicy(
+		data.Fault{Op: "CreateInterview", Call: 2},
+		data.Fault{Op: "GetInterview", Err: errDown},
+	)
+	store := data.NewMemoryStore(data.WithFaultPolicy(policy))
+
+	if err := store.CreateInterview(&data.Interview{ID: "first", CandidateName: "Alice"}); err != nil {
+		t.Fatalf("expected the first create to succeed, got %v", err)
+	}
+	if err := store.CreateInterview(&data.Interview{ID: "second", CandidateName: "Bob"}); !errors.Is(err, data.ErrInjectedFault) {
+		t.Errorf("expected the second create to fail with the injected fault, got %v", err)
+	}
+	if err := store.CreateInterview(&data.Interview{ID: "third", CandidateName: "Carol"}); err != nil {
+		t.Errorf("expected the third create to succeed, got %v", err)
+	}
+	for _, id := range []string{"first", "third"} {
+		if _, err := store.GetInterview(id); !errors.Is(err, errDown) {
+			t.Errorf("expected every GetInterview to fail with its error, got %v", err)
+		}
+	}
+	if interviews, _ := store.GetInterviews(); len(interviews) != 2 {
+		t.Errorf("expected the failed create to store nothing, got %d interviews", len(interviews))
+	}
+
+	// Tenant views share the policy and its call counts
+	policy.Add(data.Fault{Op: "CreateInterview", Call: policy.Calls("CreateInterview") + 1})
+	if err := store.ForTenant("acme").CreateInterview(&data.Interview{ID: "fourth", CandidateName: "Dan"}); !errors.Is(err, data.ErrInjectedFault) {
+		t.Errorf("expected the tenant view to fail the planned call, got %v", err)
+	}
+	if calls := policy.Calls("CreateInterview"); calls != 4 {
+		t.Errorf("expected 4 CreateInterview calls, got %d", calls)
+	}
+
+	// Adding a message counts once, under the method that was called
+	if err := store.AddChatMessage(&data.ChatMessage{ID: "m", SessionID: "missing"}); err == nil {
+		t.Error("expected adding to a missing session to fail")
+	}
+	if policy.Calls("AddChatMessage") != 1 || policy.Calls("AddChatMessageWithLimit") != 0 {
+		t.Errorf("expected one AddChatMessage call, got %d and %d", policy.Calls("AddChatMessage"), policy.Calls("AddChatMessageWithLimit"))
+	}
+}
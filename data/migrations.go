@@ -40,3 +40,37 @@ func AddPerformanceIndexes(db *gorm.DB) error { // Index for interview queries
 
 	return nil
 }
+
+// BackfillCandidateKeys sets candidate_key on interviews stored before it existed, using the SQL
+// equivalent of CandidateNameKey so filtering and grouping keep matching existing data
+func BackfillCandidateKeys(db *gorm.DB) error {
+	return db.Exec(`UPDATE interviews SET candidate_key = LOWER(BTRIM(REGEXP_REPLACE(candidate_name, '\s+', ' ', 'g'))) ` +
+		`WHERE candidate_key IS NULL OR candidate_key = ''`).Error
+}
+
+// BackfillQuestionDetails sets question_details on interviews stored before it existed, recording
+// each question as manual since where it came from is unknown
+func BackfillQuestionDetails(db *gorm.DB) error {
+	return db.Exec(`UPDATE interviews SET question_details = (` +
+		`SELECT jsonb_agg(jsonb_build_object('text', q.text, 'source', 'manual') ORDER BY q.n) ` +
+		`FROM jsonb_array_elements_text(questions) WITH ORDINALITY AS q(text, n)) ` +
+		`WHERE question_details IS NULL AND jsonb_typeof(questions) = 'array' AND jsonb_array_length(questions) > 0`).Error
+}
+
+// BackfillSessionEndedAt sets ended_at on sessions that ended before it was always recorded, using
+// their last message, or their last update when they have no messages
+func BackfillSessionEndedAt(db *gorm.DB) error {
+	return db.Exec(`UPDATE chat_sessions SET ended_at = COALESCE(` +
+		`(SELECT MAX(m.timestamp) FROM chat_messages AS m WHERE m.session_id = chat_sessions.id), updated_at) ` +
+		`WHERE ended_at IS NULL AND status IN ('completed', 'abandoned')`).Error
+}
+
+// BackfillTenantIDs assigns records stored before tenants existed to DefaultTenantID
+func BackfillTenantIDs(db *gorm.DB) error {
+	for _, table := range []string{"interviews", "evaluations", "chat_sessions", "notifications"} {
+		if err := db.Exec("UPDATE "+table+" SET tenant_id = ? WHERE tenant_id IS NULL OR tenant_id = ''", DefaultTenantID).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
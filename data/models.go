@@ -4,8 +4,13 @@ package data
 import (
 	"database/sql/driver"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"slices"
+	"strings"
 	"time"
+	"unicode"
+	"unicode/utf8"
 )
 
 // Language constants for interview support
@@ -14,6 +19,18 @@ const (
 	LanguageTraditionalChinese = "zh-TW"
 )
 
+// LanguageInfo is a supported language and the name it is shown under, in that language
+type LanguageInfo struct {
+	Code string
+	Name string
+}
+
+// Languages lists every supported language; ValidateLanguage accepts exactly these
+var Languages = []LanguageInfo{
+	{Code: LanguageEnglish, Name: "English"},
+	{Code: LanguageTraditionalChinese, Name: "繁體中文"},
+}
+
 // Interview type constants
 const (
 	InterviewTypeGeneral    = "general"
@@ -21,9 +38,56 @@ const (
 	InterviewTypeBehavioral = "behavioral"
 )
 
-// ValidateLanguage checks if the provided language code is supported
+// InterviewTypes lists every supported interview type; ValidateInterviewType accepts exactly these
+var InterviewTypes = []string{InterviewTypeGeneral, InterviewTypeTechnical, InterviewTypeBehavioral}
+
+// Interview mode constants
+const (
+	InterviewModeStructured     = "structured"     // The AI works through the interview's planned questions
+	InterviewModeConversational = "conversational" // The AI free-styles from the job description; questions are optional
+)
+
+// InterviewModes lists every interview mode
+var InterviewModes = []string{InterviewModeStructured, InterviewModeConversational}
+
+// Interview status constants
+const (
+	InterviewStatusDraft     = "draft"
+	InterviewStatusScheduled = "scheduled" // Has a scheduling window and has not started
+	InterviewStatusActive    = "active"
+	InterviewStatusCompleted = "completed"
+)
+
+// InterviewStatuses lists every interview status, in lifecycle order
+var InterviewStatuses = []string{InterviewStatusDraft, InterviewStatusScheduled, InterviewStatusActive, InterviewStatusCompleted}
+
+// Question generation status constants for interviews created with generate_questions
+// Interviews with given or built-in questions have no status
+const (
+	QuestionsStatusGenerating = "generating" // Queued for or being generated by the AI; the interview has no questions yet
+	QuestionsStatusReady      = "ready"
+	QuestionsStatusFailed     = "failed" // Generation failed; QuestionsError says why
+)
+
+// Interview outcome constants: the hiring result recruiters record once the interview is evaluated
+const (
+	InterviewOutcomeAdvanced = "advanced" // Moved on to the next round
+	InterviewOutcomeRejected = "rejected"
+	InterviewOutcomeOffer    = "offer"
+	InterviewOutcomeHired    = "hired"
+)
+
+// InterviewOutcomes lists every interview outcome; ValidateInterviewOutcome accepts exactly these
+var InterviewOutcomes = []string{InterviewOutcomeAdvanced, InterviewOutcomeRejected, InterviewOutcomeOffer, InterviewOutcomeHired}
+
+// ValidateInterviewOutcome checks if the provided outcome is one of InterviewOutcomes
+func ValidateInterviewOutcome(outcome string) bool {
+	return slices.Contains(InterviewOutcomes, outcome)
+}
+
+// ValidateLanguage checks if the provided language code is one of Languages
 func ValidateLanguage(lang string) bool {
-	return lang == LanguageEnglish || lang == LanguageTraditionalChinese
+	return slices.ContainsFunc(Languages, func(l LanguageInfo) bool { return l.Code == lang })
 }
 
 // GetDefaultLanguage returns the default language when none is specified
@@ -32,6 +96,8 @@ func GetDefaultLanguage() string {
 }
 
 // GetValidatedLanguage returns a valid language, defaulting to English if invalid
+// It normalizes values already inside the system, such as stored records; request input
+// must be checked with ValidateLanguage and rejected when unsupported
 func GetValidatedLanguage(lang string) string {
 	if ValidateLanguage(lang) {
 		return lang
@@ -39,11 +105,9 @@ func GetValidatedLanguage(lang string) string {
 	return GetDefaultLanguage()
 }
 
-// ValidateInterviewType checks if the provided interview type is supported
+// ValidateInterviewType checks if the provided interview type is one of InterviewTypes
 func ValidateInterviewType(interviewType string) bool {
-	return interviewType == InterviewTypeGeneral ||
-		interviewType == InterviewTypeTechnical ||
-		interviewType == InterviewTypeBehavioral
+	return slices.Contains(InterviewTypes, interviewType)
 }
 
 // GetDefaultInterviewType returns the default interview type when none is specified
@@ -59,6 +123,84 @@ func GetValidatedInterviewType(interviewType string) string {
 	return GetDefaultInterviewType()
 }
 
+// ValidateInterviewMode checks if the provided interview mode is one of InterviewModes
+func ValidateInterviewMode(mode string) bool {
+	return slices.Contains(InterviewModes, mode)
+}
+
+// GetValidatedInterviewMode returns a valid interview mode, defaulting to structured if invalid
+// Interviews stored before modes existed have no mode and are structured
+func GetValidatedInterviewMode(mode string) string {
+	if ValidateInterviewMode(mode) {
+		return mode
+	}
+	return InterviewModeStructured
+}
+
+// QuestionLimits bounds the question list of an interview
+type QuestionLimits struct {
+	MaxLength int // Maximum characters per question
+	MaxCount  int // Maximum number of questions
+}
+
+// NormalizeQuestions trims each question, rejects empty or overlong entries and
+// enforces the question count. Exact repeats are dropped and returned separately
+// so callers can warn about them instead of storing them silently.
+func NormalizeQuestions(questions []string, limits QuestionLimits) (normalized []string, duplicates []string, err error) {
+	if len(questions) == 0 {
+		return nil, nil, fmt.Errorf("at least one question is required")
+	}
+
+	seen := make(map[string]bool, len(questions))
+	for i, question := range questions {
+		question = strings.TrimSpace(question)
+		if question == "" {
+			return nil, nil, fmt.Errorf("question %d is empty", i+1)
+		}
+		if limits.MaxLength > 0 && utf8.RuneCountInString(question) > limits.MaxLength {
+			return nil, nil, fmt.Errorf("question %d exceeds the maximum length of %d characters", i+1, limits.MaxLength)
+		}
+		if seen[question] {
+			duplicates = append(duplicates, question)
+			continue
+		}
+		seen[question] = true
+		normalized = append(normalized, question)
+	}
+
+	if limits.MaxCount > 0 && len(normalized) > limits.MaxCount {
+		return nil, nil, fmt.Errorf("too many questions: %d provided, maximum is %d", len(normalized), limits.MaxCount)
+	}
+	return normalized, duplicates, nil
+}
+
+// MaxCandidateNameLength is the longest candidate name accepted, in characters
+const MaxCandidateNameLength = 200
+
+// NormalizeCandidateName trims a candidate name and collapses internal whitespace into single
+// spaces, rejecting names that are empty, longer than MaxCandidateNameLength or contain control characters
+func NormalizeCandidateName(name string) (string, error) {
+	for _, r := range name {
+		if unicode.IsControl(r) && !unicode.IsSpace(r) {
+			return "", errors.New("candidate_name must not contain control characters")
+		}
+	}
+	name = strings.Join(strings.Fields(name), " ")
+	if name == "" {
+		return "", errors.New("candidate_name is empty")
+	}
+	if utf8.RuneCountInString(name) > MaxCandidateNameLength {
+		return "", fmt.Errorf("candidate_name exceeds the maximum length of %d characters", MaxCandidateNameLength)
+	}
+	return name, nil
+}
+
+// CandidateNameKey is the case- and whitespace-insensitive form of a candidate name used for
+// filtering and grouping
+func CandidateNameKey(name string) string {
+	return strings.ToLower(strings.Join(strings.Fields(name), " "))
+}
+
 // StringArray is a custom type for handling PostgreSQL arrays with GORM
 type StringArray []string
 
@@ -87,6 +229,34 @@ func (s StringArray) Value() (driver.Value, error) {
 	return json.Marshal(s)
 }
 
+// IntArray is a custom type for handling JSON integer arrays with GORM
+type IntArray []int
+
+// Scan implements the Scanner interface for database/sql
+func (a *IntArray) Scan(value interface{}) error {
+	if value == nil {
+		*a = nil
+		return nil
+	}
+
+	switch v := value.(type) {
+	case []byte:
+		return json.Unmarshal(v, a)
+	case string:
+		return json.Unmarshal([]byte(v), a)
+	default:
+		return fmt.Errorf("cannot scan %T into IntArray", value)
+	}
+}
+
+// Value implements the Valuer interface for database/sql
+func (a IntArray) Value() (driver.Value, error) {
+	if a == nil {
+		return nil, nil
+	}
+	return json.Marshal(a)
+}
+
 // StringMap is a custom type for handling JSON maps with GORM
 type StringMap map[string]string
 
@@ -115,51 +285,623 @@ func (s StringMap) Value() (driver.Value, error) {
 	return json.Marshal(s)
 }
 
+// CountMap is a custom type for handling JSON objects of counts with GORM
+type CountMap map[string]int
+
+// Scan implements the Scanner interface for database/sql
+func (c *CountMap) Scan(value interface{}) error {
+	if value == nil {
+		*c = nil
+		return nil
+	}
+
+	switch v := value.(type) {
+	case []byte:
+		return json.Unmarshal(v, c)
+	case string:
+		return json.Unmarshal([]byte(v), c)
+	default:
+		return fmt.Errorf("cannot scan %T into CountMap", value)
+	}
+}
+
+// Value implements the Valuer interface for database/sql
+func (c CountMap) Value() (driver.Value, error) {
+	if c == nil {
+		return nil, nil
+	}
+	return json.Marshal(c)
+}
+
+// EvaluationTranslation is the prose of an evaluation in another language than the interview's
+type EvaluationTranslation struct {
+	Feedback   string   `json:"feedback"`
+	Strengths  []string `json:"strengths"`
+	Weaknesses []string `json:"weaknesses"`
+}
+
+// EvaluationTranslations holds the translations of an evaluation, keyed by language code
+type EvaluationTranslations map[string]EvaluationTranslation
+
+// Scan implements the Scanner interface for database/sql
+func (t *EvaluationTranslations) Scan(value interface{}) error {
+	if value == nil {
+		*t = nil
+		return nil
+	}
+
+	switch v := value.(type) {
+	case []byte:
+		return json.Unmarshal(v, t)
+	case string:
+		return json.Unmarshal([]byte(v), t)
+	default:
+		return fmt.Errorf("cannot scan %T into EvaluationTranslations", value)
+	}
+}
+
+// Value implements the Valuer interface for database/sql
+func (t EvaluationTranslations) Value() (driver.Value, error) {
+	if t == nil {
+		return nil, nil
+	}
+	return json.Marshal(t)
+}
+
+// AnswerStats is the completeness of the answers an evaluation scored
+type AnswerStats struct {
+	WordCounts []int `json:"word_counts"` // Words in each answer, in question order; each CJK character is one word
+	MinWords   int   `json:"min_words"`   // Answers with fewer words count as too short
+	Empty      int   `json:"empty"`       // Answers skipped or without a single word
+	TooShort   int   `json:"too_short"`   // Answers given but shorter than MinWords
+}
+
+// Unanswered is the number of questions effectively left unanswered: empty or too short answers
+func (s AnswerStats) Unanswered() int {
+	return s.Empty + s.TooShort
+}
+
+// Scan implements the Scanner interface for database/sql
+func (s *AnswerStats) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case []byte:
+		return json.Unmarshal(v, s)
+	case string:
+		return json.Unmarshal([]byte(v), s)
+	default:
+		return fmt.Errorf("cannot scan %T into AnswerStats", value)
+	}
+}
+
+// Value implements the Valuer interface for database/sql
+func (s AnswerStats) Value() (driver.Value, error) {
+	return json.Marshal(s)
+}
+
+// Question sources recorded in QuestionDetail.Source
+const (
+	QuestionSourceAI     = "ai"     // Generated by the AI provider
+	QuestionSourceManual = "manual" // Written by the interview's creator
+	QuestionSourceBank   = "bank"   // Taken from a built-in question set
+)
+
+// QuestionDetail is the structured form of one of an interview's questions
+type QuestionDetail struct {
+	Text         string `json:"text"`
+	Category     string `json:"category,omitempty"`
+	Difficulty   string `json:"difficulty,omitempty"`
+	ExpectedTime int    `json:"expected_time,omitempty"` // Expected answer time in minutes
+	Source       string `json:"source"`                  // See QuestionSource* constants
+}
+
+// QuestionDetailList is a custom type for handling JSON question details with GORM
+type QuestionDetailList []QuestionDetail
+
+// Scan implements the Scanner interface for database/sql
+func (l *QuestionDetailList) Scan(value interface{}) error {
+	if value == nil {
+		*l = nil
+		return nil
+	}
+
+	switch v := value.(type) {
+	case []byte:
+		return json.Unmarshal(v, l)
+	case string:
+		return json.Unmarshal([]byte(v), l)
+	default:
+		return fmt.Errorf("cannot scan %T into QuestionDetailList", value)
+	}
+}
+
+// Value implements the Valuer interface for database/sql
+func (l QuestionDetailList) Value() (driver.Value, error) {
+	if l == nil {
+		return nil, nil
+	}
+	return json.Marshal(l)
+}
+
+// Texts returns the question texts, in order
+func (l QuestionDetailList) Texts() []string {
+	texts := make([]string, len(l))
+	for i, detail := range l {
+		texts[i] = detail.Text
+	}
+	return texts
+}
+
+// OutcomeRecord is one hiring outcome recorded for an interview
+type OutcomeRecord struct {
+	Outcome    string    `json:"outcome"` // See InterviewOutcome* constants
+	Note       string    `json:"note,omitempty"`
+	RecordedAt time.Time `json:"recorded_at"`
+}
+
+// OutcomeRecordList is a custom type for handling JSON outcome records with GORM
+type OutcomeRecordList []OutcomeRecord
+
+// Scan implements the Scanner interface for database/sql
+func (l *OutcomeRecordList) Scan(value interface{}) error {
+	if value == nil {
+		*l = nil
+		return nil
+	}
+
+	switch v := value.(type) {
+	case []byte:
+		return json.Unmarshal(v, l)
+	case string:
+		return json.Unmarshal([]byte(v), l)
+	default:
+		return fmt.Errorf("cannot scan %T into OutcomeRecordList", value)
+	}
+}
+
+// Value implements the Valuer interface for database/sql
+func (l OutcomeRecordList) Value() (driver.Value, error) {
+	if l == nil {
+		return nil, nil
+	}
+	return json.Marshal(l)
+}
+
+// SyncQuestionDetails returns details lined up one-to-one with questions
+// Each question keeps the first unused detail with the same text; questions without one are
+// recorded as manual. Details for questions no longer present are dropped.
+func SyncQuestionDetails(questions []string, details QuestionDetailList) QuestionDetailList {
+	if len(questions) == 0 {
+		return nil
+	}
+	used := make([]bool, len(details))
+	synced := make(QuestionDetailList, len(questions))
+	for i, question := range questions {
+		synced[i] = QuestionDetail{Text: question, Source: QuestionSourceManual}
+		for j, detail := range details {
+			if !used[j] && detail.Text == question {
+				used[j] = true
+				synced[i] = detail
+				break
+			}
+		}
+	}
+	return synced
+}
+
 // Interview model with proper GORM tags
 type Interview struct {
-	ID                string      `gorm:"primaryKey;type:varchar(255)" json:"id"`
-	CandidateName     string      `gorm:"type:varchar(255);not null" json:"candidate_name"`
-	Questions         StringArray `gorm:"type:jsonb" json:"questions"`
-	InterviewLanguage string      `gorm:"column:language;type:varchar(10);not null;default:'en'" json:"interview_language"` // Interview language: "en" or "zh-TW"
-	Status            string      `gorm:"type:varchar(50);not null;default:'draft'" json:"status"`                          // "draft", "active", "completed"
-	InterviewType     string      `gorm:"column:type;type:varchar(50);not null" json:"interview_type"`                      // "general", "technical", "behavioral"
-	JobDescription    string      `gorm:"type:text" json:"job_description,omitempty"`                                       // Optional: Job description text
+	ID                string             `gorm:"primaryKey;type:varchar(255)" json:"id"`
+	TenantID          string             `gorm:"type:varchar(64);not null;default:'default';index" json:"tenant_id"` // Owning tenant; see WithTenant
+	CandidateName     string             `gorm:"type:varchar(255);not null" json:"candidate_name"`
+	CandidateKey      string             `gorm:"type:varchar(255);index" json:"-"` // CandidateNameKey of CandidateName, for filtering and grouping
+	Questions         StringArray        `gorm:"type:jsonb" json:"questions"`
+	QuestionDetails   QuestionDetailList `gorm:"type:jsonb" json:"question_details,omitempty"`                                     // Structured Questions, kept in sync by the store; the chat flow and evaluation use Questions
+	InterviewLanguage string             `gorm:"column:language;type:varchar(10);not null;default:'en'" json:"interview_language"` // Interview language: "en" or "zh-TW"
+	Status            string             `gorm:"type:varchar(50);not null;default:'draft'" json:"status"`                          // "draft", "scheduled", "active", "completed"
+	InterviewType     string             `gorm:"column:type;type:varchar(50);not null" json:"interview_type"`                      // "general", "technical", "behavioral"
+	InterviewMode     string             `gorm:"column:mode;type:varchar(20);not null;default:'structured'" json:"interview_mode"` // "structured" or "conversational"
+	JobDescription    string             `gorm:"type:text" json:"job_description,omitempty"`                                       // Optional: Job description text
+	JobDescSummary    string             `gorm:"column:job_description_summary" json:"job_description_summary,omitempty"`          // AI summary used in prompts when JobDescription is over the soft limit
+	ResumeContent     string             `gorm:"type:text" json:"resume_content,omitempty"`                                        // Optional: Candidate resume as plain text
+	CompanyContext    string             `gorm:"type:text" json:"company_context,omitempty"`                                       // Optional: Company/persona context for the role
+	ScheduledStart    *time.Time         `gorm:"index" json:"scheduled_start,omitempty"`                                           // Optional: chat sessions cannot start before this time
+	ScheduledEnd      *time.Time         `json:"scheduled_end,omitempty"`                                                          // Optional: chat sessions cannot start after this time (plus grace)
+	NotifyWebhookURL  string             `gorm:"type:varchar(2048)" json:"notify_webhook_url,omitempty"`                           // Optional: per-interview webhook endpoint
+	NotifyEvents      StringArray        `gorm:"type:jsonb" json:"notify_events,omitempty"`                                        // Events delivered to NotifyWebhookURL
+	NotifySecret      string             `gorm:"type:varchar(255)" json:"-"`                                                       // Signs per-interview deliveries; never returned by the API
+	AdaptiveDisabled  bool               `gorm:"not null;default:false" json:"adaptive_disabled,omitempty"`                        // Keeps question difficulty fixed instead of adapting to answers
+	ClonedFrom        string             `gorm:"type:varchar(255);index" json:"cloned_from,omitempty"`                             // Source interview ID when created by cloning
+	Outcome           string             `gorm:"type:varchar(20);index" json:"outcome,omitempty"`                                  // Hiring outcome recorded by recruiters (see InterviewOutcome* constants)
+	OutcomeNote       string             `gorm:"type:text" json:"outcome_note,omitempty"`                                          // Recruiter's note on Outcome
+	OutcomeHistory    OutcomeRecordList  `gorm:"type:jsonb" json:"outcome_history,omitempty"`                                      // Every outcome recorded, oldest first; the last is Outcome
+	QuestionsStatus   string             `gorm:"type:varchar(20)" json:"questions_status,omitempty"`                               // Progress of AI question generation (see QuestionsStatus* constants); empty when questions were given
+	QuestionsError    string             `gorm:"type:text" json:"questions_error,omitempty"`                                       // Why question generation failed
+	QuestionsWanted   int                `gorm:"not null;default:0" json:"questions_wanted,omitempty"`                             // Number of questions asked of the AI, kept for retries
+	QuestionStrategy  string             `gorm:"type:varchar(20)" json:"question_strategy,omitempty"`                              // Which questions each session asks, in which order (see QuestionStrategy*); empty is ordered
 	// TODO: Resume file support will be added in future iteration
 	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
 	UpdatedAt time.Time `gorm:"autoUpdateTime" json:"updated_at"`
 }
 
+// utc converts the interview's timestamps to UTC before it is written
+func (i *Interview) utc() {
+	toUTC(&i.CreatedAt, &i.UpdatedAt, i.ScheduledStart, i.ScheduledEnd)
+}
+
+// QuestionsPending reports whether the interview is still waiting for its generated questions
+func (i *Interview) QuestionsPending() bool {
+	return i.QuestionsStatus == QuestionsStatusGenerating
+}
+
+// IsConversational reports whether the interview is run without planned questions
+func (i *Interview) IsConversational() bool {
+	return i.InterviewMode == InterviewModeConversational
+}
+
+// PlannedQuestions returns the questions the chat flow tracks and ends on
+// Conversational interviews track none, even when questions were supplied
+func (i *Interview) PlannedQuestions() []string {
+	if i.IsConversational() {
+		return nil
+	}
+	return i.Questions
+}
+
+// IsAdaptive reports whether question difficulty follows the candidate's answers
+func (i *Interview) IsAdaptive() bool {
+	return !i.AdaptiveDisabled
+}
+
+// NotifiesOn reports whether the interview's own webhook subscribes to the event
+func (i *Interview) NotifiesOn(event string) bool {
+	if i.NotifyWebhookURL == "" {
+		return false
+	}
+	for _, subscribed := range i.NotifyEvents {
+		if subscribed == event {
+			return true
+		}
+	}
+	return false
+}
+
 // Evaluation model with proper GORM tags
 type Evaluation struct {
-	ID          string    `gorm:"primaryKey;type:varchar(255)" json:"id"`
-	InterviewID string    `gorm:"type:varchar(255);not null;index" json:"interview_id"`
-	Answers     StringMap `gorm:"type:jsonb" json:"answers"`
-	Score       float64   `gorm:"type:decimal(5,2)" json:"score"`
-	Feedback    string    `gorm:"type:text" json:"feedback"`
-	CreatedAt   time.Time `gorm:"autoCreateTime" json:"created_at"`
-	UpdatedAt   time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+	ID                  string                 `gorm:"primaryKey;type:varchar(255)" json:"id"`
+	TenantID            string                 `gorm:"type:varchar(64);not null;default:'default';index" json:"tenant_id"` // Owning tenant; see WithTenant
+	InterviewID         string                 `gorm:"type:varchar(255);not null;index" json:"interview_id"`
+	Answers             StringMap              `gorm:"type:jsonb" json:"answers"`
+	QuestionsSnapshot   StringArray            `gorm:"type:jsonb" json:"questions_snapshot,omitempty"` // Questions as they were when evaluated; answers["question_N"] responds to QuestionsSnapshot[N]
+	Score               float64                `gorm:"type:decimal(5,2)" json:"score"`
+	Feedback            string                 `gorm:"type:text" json:"feedback"`
+	Status              string                 `gorm:"type:varchar(50);not null;default:'completed'" json:"status"`     // See EvaluationStatus* constants
+	Provider            string                 `gorm:"type:varchar(50)" json:"provider,omitempty"`                      // AI provider that performed the scoring
+	Model               string                 `gorm:"type:varchar(100)" json:"model,omitempty"`                        // AI model that performed the scoring
+	SupersedesID        string                 `gorm:"type:varchar(255);index" json:"supersedes_id,omitempty"`          // Evaluation this one replaced, if any
+	EstimatedCostUSD    float64                `gorm:"type:decimal(12,6);not null;default:0" json:"estimated_cost_usd"` // AI cost of producing this evaluation
+	LanguageMismatch    bool                   `gorm:"not null;default:false" json:"language_mismatch"`                 // Answers were mostly in another language than the interview
+	FeedbackTruncated   bool                   `gorm:"not null;default:false" json:"feedback_truncated"`                // Feedback ran over its word limit and was cut
+	Decision            string                 `gorm:"type:varchar(50);index" json:"decision,omitempty"`                // Recommendation decision (see ai.Decisions); empty when none was given
+	NextSteps           StringArray            `gorm:"type:jsonb" json:"next_steps,omitempty"`                          // Concrete next steps for recruiters
+	Translations        EvaluationTranslations `gorm:"type:jsonb" json:"translations,omitempty"`                        // Feedback, strengths and weaknesses in additional languages
+	TranslationFailures StringArray            `gorm:"type:jsonb" json:"translation_failures,omitempty"`                // Additional languages whose translation failed
+	AnswerStats         *AnswerStats           `gorm:"type:jsonb" json:"answer_stats,omitempty"`                        // Completeness of the answers; nil when they were not scored
+	CreatedAt           time.Time              `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt           time.Time              `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+// Evaluation statuses
+const (
+	EvaluationStatusCompleted  = "completed"  // Scored by the AI
+	EvaluationStatusNoAnswers  = "no_answers" // Session ended before the candidate replied; not scored
+	EvaluationStatusSuperseded = "superseded" // Voided when its session was reopened; no longer current
+)
+
+// EvaluationStatuses lists every evaluation status
+var EvaluationStatuses = []string{EvaluationStatusCompleted, EvaluationStatusNoAnswers, EvaluationStatusSuperseded}
+
+// Greeting modes: how a chat session opens
+const (
+	GreetingModeAI       = "ai"       // The AI writes an opening message (the default)
+	GreetingModeTemplate = "template" // A canned opening message in the session language; no AI call
+	GreetingModeNone     = "none"     // No opening message; the AI first speaks after the candidate's first message
+)
+
+// GreetingModes lists every greeting mode; ValidateGreetingMode accepts exactly these
+var GreetingModes = []string{GreetingModeAI, GreetingModeTemplate, GreetingModeNone}
+
+// ValidateGreetingMode checks if the provided greeting mode is one of GreetingModes
+func ValidateGreetingMode(mode string) bool {
+	return slices.Contains(GreetingModes, mode)
 }
 
+// Chat session status constants
+const (
+	SessionStatusActive    = "active"
+	SessionStatusCompleted = "completed" // Ended by the candidate, the AI or the message limit
+	SessionStatusAbandoned = "abandoned" // Expired after going idle
+)
+
+// SessionStatuses lists every chat session status
+var SessionStatuses = []string{SessionStatusActive, SessionStatusCompleted, SessionStatusAbandoned}
+
 // ChatSession model for conversational interviews with proper GORM tags
 type ChatSession struct {
-	ID              string     `gorm:"primaryKey;type:varchar(255)" json:"id"`
-	InterviewID     string     `gorm:"type:varchar(255);not null;index" json:"interview_id"`
-	SessionLanguage string     `gorm:"column:language;type:varchar(10);not null;default:'en'" json:"session_language"` // Session language: "en" or "zh-TW"
-	Status          string     `gorm:"type:varchar(50);not null;default:'active'" json:"status"`                       // "active", "completed", "abandoned"
-	StartedAt       time.Time  `gorm:"column:created_at;autoCreateTime" json:"started_at"`                             // When session started
-	CreatedAt       time.Time  `gorm:"autoCreateTime" json:"created_at"`
-	UpdatedAt       time.Time  `gorm:"autoUpdateTime" json:"updated_at"`
-	EndedAt         *time.Time `gorm:"type:timestamp" json:"ended_at,omitempty"`
+	ID                   string      `gorm:"primaryKey;type:varchar(255)" json:"id"`
+	TenantID             string      `gorm:"type:varchar(64);not null;default:'default';index" json:"tenant_id"` // Owning tenant; see WithTenant
+	InterviewID          string      `gorm:"type:varchar(255);not null;index" json:"interview_id"`
+	SessionLanguage      string      `gorm:"column:language;type:varchar(10);not null;default:'en'" json:"session_language"` // Session language: "en" or "zh-TW"
+	Status               string      `gorm:"type:varchar(50);not null;default:'active'" json:"status"`                       // "active", "completed", "abandoned"
+	StartedAt            time.Time   `gorm:"column:created_at;autoCreateTime" json:"started_at"`                             // When session started
+	CreatedAt            time.Time   `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt            time.Time   `gorm:"autoUpdateTime" json:"updated_at"`
+	EndedAt              *time.Time  `gorm:"type:timestamp" json:"ended_at,omitempty"`
+	AskedQuestions       StringArray `gorm:"type:jsonb" json:"asked_questions,omitempty"`                     // Questions the AI asked, in order
+	Provider             string      `gorm:"type:varchar(50)" json:"provider,omitempty"`                      // AI provider pinned by the first successful AI turn
+	Model                string      `gorm:"type:varchar(100)" json:"model,omitempty"`                        // AI model pinned by the first successful AI turn
+	EstimatedCostUSD     float64     `gorm:"type:decimal(12,6);not null;default:0" json:"estimated_cost_usd"` // AI cost of the conversation, excluding its evaluation
+	ConversationSummary  string      `gorm:"type:text" json:"conversation_summary,omitempty"`                 // Running summary of the earliest turns of a long conversation
+	SummarizedTurns      int         `gorm:"not null;default:0" json:"summarized_turns,omitempty"`            // Number of leading conversation turns the summary covers
+	LastActivityAt       *time.Time  `gorm:"type:timestamp" json:"last_activity_at,omitempty"`                // Last heartbeat from the client; nil for sessions that never sent one
+	DifficultyLevel      int         `gorm:"not null;default:0" json:"difficulty_level,omitempty"`            // Current adaptive difficulty (1-5); 0 when the session does not adapt
+	DifficultyTrajectory IntArray    `gorm:"type:jsonb" json:"difficulty_trajectory,omitempty"`               // Difficulty levels in order, starting with the initial level
+	QuestionOrder        IntArray    `gorm:"type:jsonb" json:"question_order,omitempty"`                      // Indexes of the planned questions the session asks, in order; empty asks them all in order
+	AbuseRejections      CountMap    `gorm:"type:jsonb" json:"abuse_rejections,omitempty"`                    // Candidate messages refused by the anti-abuse checks, by reason
+	AIAttempts           int         `gorm:"not null;default:0" json:"ai_attempts"`                           // Provider calls made for the session, including failed ones
+	ReopenCount          int         `gorm:"not null;default:0" json:"reopen_count,omitempty"`                // Times the session was reopened after completing
+	ReopenedEvaluationID string      `gorm:"type:varchar(255)" json:"reopened_evaluation_id,omitempty"`       // Evaluation current when last reopened; the session's next evaluation supersedes it
+	TranscriptPurgedAt   *time.Time  `gorm:"type:timestamp" json:"transcript_purged_at,omitempty"`            // When messages past the transcript retention were deleted; nil while the transcript is whole
+	GreetingMode         string      `gorm:"type:varchar(20)" json:"greeting_mode,omitempty"`                 // How the session opened (see GreetingMode* constants); empty for sessions stored before, which opened with the AI
+}
+
+// utc converts the session's timestamps to UTC before it is written
+func (s *ChatSession) utc() {
+	toUTC(&s.StartedAt, &s.CreatedAt, &s.UpdatedAt, s.EndedAt, s.LastActivityAt, s.TranscriptPurgedAt)
+}
+
+// End moves the session to a final status ("completed" or "abandoned") at the given time
+// EndedAt is only set once: ending a session that already ended keeps its original end time
+func (s *ChatSession) End(status string, at time.Time) {
+	s.Status = status
+	if s.EndedAt == nil {
+		s.EndedAt = &at
+	}
+}
+
+// Duration returns how long the session ran, from StartedAt to EndedAt
+// ok is false while the session has not ended
+func (s *ChatSession) Duration() (duration time.Duration, ok bool) {
+	if s.EndedAt == nil {
+		return 0, false
+	}
+	return s.EndedAt.Sub(s.StartedAt), true
+}
+
+// LastActivity returns when the session was last active: the latest of its start, its last
+// heartbeat and lastMessageAt (zero when the session has no messages)
+func (s *ChatSession) LastActivity(lastMessageAt time.Time) time.Time {
+	latest := s.StartedAt
+	if s.LastActivityAt != nil && s.LastActivityAt.After(latest) {
+		latest = *s.LastActivityAt
+	}
+	if lastMessageAt.After(latest) {
+		latest = lastMessageAt
+	}
+	return latest
 }
 
+// ErrMessageLimitReached is returned when a message would exceed the per-session message cap
+var ErrMessageLimitReached = errors.New("chat session message limit reached")
+
+// Constraint violations reported by both store backends. Database errors keep the driver error
+// wrapped alongside the sentinel for logging; it is not meant for API responses.
+var (
+	ErrAlreadyExists = errors.New("record already exists")              // Duplicate ID or unique index value
+	ErrConstraint    = errors.New("record violates a store constraint") // e.g. a reference to a missing record
+)
+
+// AI chat message subtypes
+const (
+	MessageSubtypeGreeting        = "greeting"
+	MessageSubtypeQuestion        = "question"
+	MessageSubtypeFollowUp        = "follow_up"
+	MessageSubtypeAcknowledgement = "acknowledgement"
+	MessageSubtypeClosing         = "closing"
+)
+
+// IsQuestionSubtype reports whether an AI message with this subtype asks the candidate something
+// Messages stored before subtypes existed have an empty subtype and are treated as questions
+func IsQuestionSubtype(subtype string) bool {
+	switch subtype {
+	case MessageSubtypeGreeting, MessageSubtypeQuestion, MessageSubtypeFollowUp, "":
+		return true
+	}
+	return false
+}
+
+// Chat message visibility
+const (
+	MessageVisibilityCandidate = "candidate" // Shown to the candidate; the default
+	MessageVisibilityInternal  = "internal"  // Only shown to admin and API key callers
+)
+
+// Chat message metadata keys
+const (
+	MessageMetaSummarized       = "summarized"            // "true" when the AI context uses a summary instead of the content
+	MessageMetaContextSummary   = "context_summary"       // Condensed content sent to the AI provider in place of the full text
+	MessageMetaLanguageMismatch = "language_mismatch"     // "true" when an AI reply is not in the session language
+	MessageMetaRedactions       = "pii_redactions"        // JSON placeholder-to-value map of PII redacted from the provider payload; never sent to clients
+	MessageMetaExpectedTime     = "expected_time_minutes" // Expected answer time in minutes of the planned question an AI message asks
+)
+
 // ChatMessage model with proper GORM tags
 type ChatMessage struct {
-	ID        string    `gorm:"primaryKey;type:varchar(255)" json:"id"`
-	SessionID string    `gorm:"type:varchar(255);not null;index" json:"session_id"`
-	Type      string    `gorm:"type:varchar(50);not null" json:"type"` // "user", "ai"
-	Content   string    `gorm:"type:text;not null" json:"content"`
-	Timestamp time.Time `gorm:"not null" json:"timestamp"`
-	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
+	ID        string `gorm:"primaryKey;type:varchar(255)" json:"id"`
+	SessionID string `gorm:"type:varchar(255);not null;index;uniqueIndex:idx_chat_messages_session_client_message_id,priority:1" json:"session_id"`
+	// Optional client-generated ID (user messages only) used to deduplicate resends
+	ClientMessageID string    `gorm:"type:varchar(255);uniqueIndex:idx_chat_messages_session_client_message_id,priority:2,where:client_message_id <> ''" json:"client_message_id,omitempty"`
+	Type            string    `gorm:"type:varchar(50);not null" json:"type"`     // "user", "ai", "system"
+	Subtype         string    `gorm:"type:varchar(50)" json:"subtype,omitempty"` // AI messages only, see MessageSubtype* constants
+	Content         string    `gorm:"type:text;not null" json:"content"`
+	Metadata        StringMap `gorm:"type:jsonb" json:"metadata,omitempty"`         // Optional flags, see MessageMeta* keys
+	Visibility      string    `gorm:"type:varchar(20)" json:"visibility,omitempty"` // See MessageVisibility* constants; empty is candidate
+	Provider        string    `gorm:"type:varchar(50)" json:"provider,omitempty"`   // AI messages only: provider that generated the reply
+	Model           string    `gorm:"type:varchar(100)" json:"model,omitempty"`     // AI messages only: model that generated the reply
+	Timestamp       time.Time `gorm:"not null" json:"timestamp"`
+	CreatedAt       time.Time `gorm:"autoCreateTime" json:"created_at"`
+}
+
+// CandidateVisible reports whether the message is shown to the candidate
+// Messages stored before visibility existed have an empty visibility and are visible.
+func (m *ChatMessage) CandidateVisible() bool {
+	return m.Visibility != MessageVisibilityInternal
+}
+
+// ContextContent returns the text that should represent this message in AI conversation history
+// Falls back to the full content when no summary was recorded
+func (m *ChatMessage) ContextContent() string {
+	if m.Metadata[MessageMetaSummarized] == "true" && m.Metadata[MessageMetaContextSummary] != "" {
+		return m.Metadata[MessageMetaContextSummary]
+	}
+	return m.Content
+}
+
+// Notification types
+const (
+	NotificationTypeWebhook = "webhook"
+)
+
+// Notification statuses
+const (
+	NotificationStatusPending   = "pending"   // Waiting for its first or next delivery attempt
+	NotificationStatusDelivered = "delivered" // Delivered successfully
+	NotificationStatusFailed    = "failed"    // Gave up after the maximum number of attempts
+)
+
+// Notification is an outgoing notification in the outbox, kept until it is delivered or given up on
+// so deliveries survive restarts; Payload is interpreted by the dispatcher of its Type
+type Notification struct {
+	ID            string    `gorm:"primaryKey;type:varchar(255)" json:"id"`
+	TenantID      string    `gorm:"type:varchar(64);not null;default:'default';index" json:"tenant_id"` // Owning tenant; see WithTenant
+	Type          string    `gorm:"type:varchar(50);not null" json:"type"`
+	Payload       string    `gorm:"type:text;not null" json:"payload"`
+	Attempts      int       `gorm:"not null;default:0" json:"attempts"`
+	NextAttemptAt time.Time `gorm:"index" json:"next_attempt_at"`
+	Status        string    `gorm:"type:varchar(20);not null;default:'pending';index" json:"status"` // See NotificationStatus* constants
+	LastError     string    `gorm:"type:text" json:"last_error,omitempty"`
+	CreatedAt     time.Time `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt     time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+// NotificationStats counts the outbox's notifications by status
+type NotificationStats struct {
+	Pending   int64 // Queue depth: waiting for delivery, retries included
+	Retrying  int64 // Pending notifications that already failed at least once
+	Delivered int64
+	Failed    int64
+}
+
+// CalibrationModel is an AI provider and model compared in a calibration run
+type CalibrationModel struct {
+	Provider string `json:"provider"`
+	Model    string `json:"model"`
+}
+
+// CalibrationModelList is a custom type for handling JSON calibration models with GORM
+type CalibrationModelList []CalibrationModel
+
+// Scan implements the Scanner interface for database/sql
+func (l *CalibrationModelList) Scan(value interface{}) error {
+	if value == nil {
+		*l = nil
+		return nil
+	}
+
+	switch v := value.(type) {
+	case []byte:
+		return json.Unmarshal(v, l)
+	case string:
+		return json.Unmarshal([]byte(v), l)
+	default:
+		return fmt.Errorf("cannot scan %T into CalibrationModelList", value)
+	}
+}
+
+// Value implements the Valuer interface for database/sql
+func (l CalibrationModelList) Value() (driver.Value, error) {
+	if l == nil {
+		return nil, nil
+	}
+	return json.Marshal(l)
+}
+
+// CalibrationScore is the overall score one model gave one session in a calibration run
+// Error is set instead of Score when the evaluation failed
+type CalibrationScore struct {
+	SessionID string   `json:"session_id"`
+	Provider  string   `json:"provider"`
+	Model     string   `json:"model"`
+	Score     *float64 `json:"score"`
+	Error     string   `json:"error,omitempty"`
+}
+
+// CalibrationScoreList is a custom type for handling JSON calibration scores with GORM
+type CalibrationScoreList []CalibrationScore
+
+// Scan implements the Scanner interface for database/sql
+func (l *CalibrationScoreList) Scan(value interface{}) error {
+	if value == nil {
+		*l = nil
+		return nil
+	}
+
+	switch v := value.(type) {
+	case []byte:
+		return json.Unmarshal(v, l)
+	case string:
+		return json.Unmarshal([]byte(v), l)
+	default:
+		return fmt.Errorf("cannot scan %T into CalibrationScoreList", value)
+	}
+}
+
+// Value implements the Valuer interface for database/sql
+func (l CalibrationScoreList) Value() (driver.Value, error) {
+	if l == nil {
+		return nil, nil
+	}
+	return json.Marshal(l)
+}
+
+// CalibrationRun is a saved comparison of the overall scores several AI models give the same
+// chat sessions; running one never changes stored evaluations
+type CalibrationRun struct {
+	ID         string               `gorm:"primaryKey;type:varchar(255)" json:"id"`
+	TenantID   string               `gorm:"type:varchar(64);not null;default:'default';index" json:"tenant_id"` // Owning tenant; see WithTenant
+	SessionIDs StringArray          `gorm:"type:jsonb" json:"session_ids"`
+	Models     CalibrationModelList `gorm:"type:jsonb" json:"models"` // The first model is the baseline the others are compared with
+	Scores     CalibrationScoreList `gorm:"type:jsonb" json:"scores"`
+	CreatedAt  time.Time            `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt  time.Time            `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+// EvaluationTrace is what the evaluator was sent and answered for one evaluation, kept so a
+// disputed score can be reproduced. Texts are redacted and capped with a truncation marker before
+// they are stored. Traces are only returned by the admin trace endpoint, never in evaluation
+// responses or backups.
+type EvaluationTrace struct {
+	EvaluationID     string    `gorm:"primaryKey;type:varchar(255)" json:"evaluation_id"`
+	TenantID         string    `gorm:"type:varchar(64);not null;default:'default';index" json:"tenant_id"` // Owning tenant; see WithTenant
+	Prompt           string    `gorm:"type:text" json:"prompt"`                                            // Rendered evaluation prompt
+	Answers          string    `gorm:"type:text" json:"answers"`                                           // Formatted question and answer block
+	RawOutput        string    `gorm:"type:text" json:"raw_output"`                                        // Model output before parsing
+	Provider         string    `gorm:"type:varchar(50)" json:"provider"`
+	Model            string    `gorm:"type:varchar(100)" json:"model"`
+	Temperature      float64   `gorm:"type:decimal(4,2)" json:"temperature"`
+	PromptTokens     int       `gorm:"not null;default:0" json:"prompt_tokens"`
+	CompletionTokens int       `gorm:"not null;default:0" json:"completion_tokens"`
+	Truncated        bool      `gorm:"not null;default:false" json:"truncated"` // A text ran over the trace size cap and was cut
+	CreatedAt        time.Time `gorm:"autoCreateTime" json:"created_at"`
 }
 
 // TODO: Implement File model for resume uploads
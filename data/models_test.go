@@ -3,7 +3,9 @@ package data_test
 import (
 	"database/sql/driver"
 	"encoding/json"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -111,7 +113,171 @@ func TestGetValidatedInterviewType(t *testing.T) {
 	}
 }
 
+func TestGetValidatedInterviewMode(t *testing.T) {
+	tests := []struct {
+		name     string
+		mode     string
+		expected string
+	}{
+		{"valid structured", data.InterviewModeStructured, data.InterviewModeStructured},
+		{"valid conversational", data.InterviewModeConversational, data.InterviewModeConversational},
+		{"empty string defaults to structured", "", data.InterviewModeStructured},
+		{"invalid mode defaults to structured", "freeform", data.InterviewModeStructured},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, data.GetValidatedInterviewMode(tt.mode))
+		})
+	}
+}
+
+func TestInterview_PlannedQuestions(t *testing.T) {
+	structured := &data.Interview{Questions: []string{"Q1"}}
+	assert.False(t, structured.IsConversational())
+	assert.Equal(t, []string{"Q1"}, structured.PlannedQuestions())
+
+	conversational := &data.Interview{Questions: []string{"Q1"}, InterviewMode: data.InterviewModeConversational}
+	assert.True(t, conversational.IsConversational())
+	assert.Empty(t, conversational.PlannedQuestions())
+}
+
 // Test StringArray custom type
+func TestNormalizeQuestions(t *testing.T) {
+	limits := data.QuestionLimits{MaxLength: 20, MaxCount: 3}
+
+	tests := []struct {
+		name               string
+		questions          []string
+		expected           []string
+		expectedDuplicates []string
+		expectError        string
+	}{
+		{"valid questions", []string{"Q1", "Q2"}, []string{"Q1", "Q2"}, nil, ""},
+		{"trims whitespace", []string{"  Q1 ", "\tQ2\n"}, []string{"Q1", "Q2"}, nil, ""},
+		{"no questions", []string{}, nil, nil, "at least one question"},
+		{"empty entry", []string{"Q1", ""}, nil, nil, "question 2 is empty"},
+		{"whitespace-only entry", []string{"   "}, nil, nil, "question 1 is empty"},
+		{"too long", []string{strings.Repeat("a", 21)}, nil, nil, "maximum length of 20"},
+		{"length counts characters not bytes", []string{strings.Repeat("問", 20)}, []string{strings.Repeat("問", 20)}, nil, ""},
+		{"too many", []string{"Q1", "Q2", "Q3", "Q4"}, nil, nil, "too many questions"},
+		{"duplicates removed", []string{"Q1", "Q2", "Q1"}, []string{"Q1", "Q2"}, []string{"Q1"}, ""},
+		{"duplicates after trimming", []string{"Q1", " Q1 "}, []string{"Q1"}, []string{"Q1"}, ""},
+		{"duplicates do not count toward limit", []string{"Q1", "Q2", "Q3", "Q3"}, []string{"Q1", "Q2", "Q3"}, []string{"Q3"}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, duplicates, err := data.NormalizeQuestions(tt.questions, limits)
+			if tt.expectError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+			assert.Equal(t, tt.expectedDuplicates, duplicates)
+		})
+	}
+}
+
+func TestNormalizeQuestions_ZeroLimitsDisableChecks(t *testing.T) {
+	questions := []string{strings.Repeat("a", 5000), "Q2"}
+	result, _, err := data.NormalizeQuestions(questions, data.QuestionLimits{})
+	require.NoError(t, err)
+	assert.Len(t, result, 2)
+}
+
+func TestSyncQuestionDetails(t *testing.T) {
+	details := data.QuestionDetailList{
+		{Text: "Q1", Category: "technical", Difficulty: "hard", ExpectedTime: 5, Source: data.QuestionSourceAI},
+		{Text: "Removed", Source: data.QuestionSourceAI},
+		{Text: "Q1", Category: "behavioral", Source: data.QuestionSourceBank},
+	}
+
+	synced := data.SyncQuestionDetails([]string{"Q2", "Q1", "Q1", "Q1"}, details)
+	require.Len(t, synced, 4)
+	assert.Equal(t, data.QuestionDetail{Text: "Q2", Source: data.QuestionSourceManual}, synced[0])
+	assert.Equal(t, details[0], synced[1])
+	assert.Equal(t, details[2], synced[2], "repeated questions take the next matching detail")
+	assert.Equal(t, data.QuestionSourceManual, synced[3].Source)
+
+	assert.Nil(t, data.SyncQuestionDetails(nil, details))
+}
+
+func TestQuestionDetailList_ScanValue(t *testing.T) {
+	list := data.QuestionDetailList{{Text: "Q1", Category: "technical", Source: data.QuestionSourceAI}}
+	value, err := list.Value()
+	require.NoError(t, err)
+
+	var scanned data.QuestionDetailList
+	require.NoError(t, scanned.Scan(value))
+	assert.Equal(t, list, scanned)
+
+	require.NoError(t, scanned.Scan(nil))
+	assert.Nil(t, scanned)
+	assert.Error(t, scanned.Scan(42))
+}
+
+func TestEvaluationTranslations_ScanValue(t *testing.T) {
+	translations := data.EvaluationTranslations{
+		"zh-TW": {Feedback: "回答扎實", Strengths: []string{"結構清楚"}, Weaknesses: []string{"缺少數據"}},
+	}
+	value, err := translations.Value()
+	require.NoError(t, err)
+
+	var scanned data.EvaluationTranslations
+	require.NoError(t, scanned.Scan(value))
+	assert.Equal(t, translations, scanned)
+
+	require.NoError(t, scanned.Scan(nil))
+	assert.Nil(t, scanned)
+	assert.Error(t, scanned.Scan(42))
+}
+
+func TestAnswerStats_ScanValue(t *testing.T) {
+	stats := data.AnswerStats{WordCounts: []int{12, 0, 3}, MinWords: 5, Empty: 1, TooShort: 1}
+	value, err := stats.Value()
+	require.NoError(t, err)
+
+	var scanned data.AnswerStats
+	require.NoError(t, scanned.Scan(value))
+	assert.Equal(t, stats, scanned)
+	assert.Equal(t, 2, scanned.Unanswered())
+	assert.Error(t, scanned.Scan(42))
+}
+
+func TestNormalizeCandidateName(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       string
+		expected    string
+		expectError string
+	}{
+		{"trims and collapses whitespace", "  Jane \t  Doe\n", "Jane Doe", ""},
+		{"keeps casing for display", "Jane McDonald", "Jane McDonald", ""},
+		{"whitespace only", "   \t ", "", "empty"},
+		{"control character", "Jane\x00Doe", "", "control characters"},
+		{"too long", strings.Repeat("a", data.MaxCandidateNameLength+1), "", "maximum length of 200"},
+		{"length counts characters not bytes", strings.Repeat("王", data.MaxCandidateNameLength), strings.Repeat("王", data.MaxCandidateNameLength), ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := data.NormalizeCandidateName(tt.input)
+			if tt.expectError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+
+	assert.Equal(t, "jane doe", data.CandidateNameKey("  JANE   Doe "))
+}
+
 func TestStringArray_Scan(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -526,3 +692,23 @@ func TestModelStructures(t *testing.T) { // Test Interview model
 	assert.Equal(t, "user", message.Type)
 	assert.Equal(t, "Hello", message.Content)
 }
+
+func TestChatSession_EndAndDuration(t *testing.T) {
+	started := time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC)
+	session := data.ChatSession{ID: "session-id", Status: "active", StartedAt: started}
+
+	_, ok := session.Duration()
+	assert.False(t, ok, "an active session has no duration")
+
+	session.End("completed", started.Add(12*time.Minute+30*time.Second))
+	duration, ok := session.Duration()
+	require.True(t, ok)
+	assert.Equal(t, "completed", session.Status)
+	assert.Equal(t, 750*time.Second, duration)
+
+	// Ending again changes the status but keeps the first end time
+	session.End("abandoned", started.Add(time.Hour))
+	duration, _ = session.Duration()
+	assert.Equal(t, "abandoned", session.Status)
+	assert.Equal(t, 750*time.Second, duration)
+}
@@ -0,0 +1,94 @@
+// Notification outbox data access
+package data
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// NotificationRepository interface defines the contract for notification outbox access
+type NotificationRepository interface {
+	Create(notification *Notification) error
+	GetDue(now time.Time, limit int) ([]*Notification, error)
+	Update(id string, updates map[string]interface{}) error
+	GetStats() (*NotificationStats, error)
+}
+
+// notificationRepository implements NotificationRepository
+type notificationRepository struct {
+	db       *gorm.DB
+	tenantID string // Scopes every query to one tenant; empty sees every tenant
+}
+
+// NewNotificationRepository creates a new notification repository
+func NewNotificationRepository(db *gorm.DB) NotificationRepository {
+	return newNotificationRepository(db, "")
+}
+
+// newNotificationRepository creates a notification repository scoped to tenantID
+func newNotificationRepository(db *gorm.DB, tenantID string) *notificationRepository {
+	return &notificationRepository{db: db, tenantID: tenantID}
+}
+
+// scoped limits query to the repository's tenant
+func (r *notificationRepository) scoped(query *gorm.DB) *gorm.DB {
+	return tenantScope(query, "tenant_id", r.tenantID)
+}
+
+// Create adds a notification to the outbox
+func (r *notificationRepository) Create(notification *Notification) error {
+	stampTenant(r.tenantID, &notification.TenantID)
+	stampCreated(r.db.NowFunc(), &notification.CreatedAt, &notification.UpdatedAt)
+	return r.db.Create(notification).Error
+}
+
+// GetDue lists pending notifications whose next attempt is due at now, earliest first
+// A limit of 0 means no limit.
+func (r *notificationRepository) GetDue(now time.Time, limit int) ([]*Notification, error) {
+	var notifications []*Notification
+	query := r.scoped(r.db.Where("status = ? AND next_attempt_at <= ?", NotificationStatusPending, now)).
+		Order("next_attempt_at ASC, created_at ASC")
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+	err := query.Find(&notifications).Error
+	return notifications, err
+}
+
+// Update updates a notification's delivery state
+func (r *notificationRepository) Update(id string, updates map[string]interface{}) error {
+	updates["updated_at"] = r.db.NowFunc()
+	return r.scoped(r.db.Model(&Notification{}).Where("id = ?", id)).Updates(updates).Error
+}
+
+// GetStats counts notifications by status
+func (r *notificationRepository) GetStats() (*NotificationStats, error) {
+	var rows []struct {
+		Status   string
+		Retrying bool
+		Count    int64
+	}
+	err := r.scoped(r.db.Model(&Notification{})).
+		Select("status, (status = ? AND attempts > 0) AS retrying, COUNT(*) AS count", NotificationStatusPending).
+		Group("status, retrying").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	stats := &NotificationStats{}
+	for _, row := range rows {
+		switch row.Status {
+		case NotificationStatusPending:
+			stats.Pending += row.Count
+			if row.Retrying {
+				stats.Retrying += row.Count
+			}
+		case NotificationStatusDelivered:
+			stats.Delivered += row.Count
+		case NotificationStatusFailed:
+			stats.Failed += row.Count
+		}
+	}
+	return stats, nil
+}
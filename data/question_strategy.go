@@ -0,0 +1,94 @@
+// Question strategies: which planned questions each chat session of an interview asks, and in which order
+package data
+
+import (
+	"fmt"
+	"hash/fnv"
+	"math/rand"
+	"strconv"
+	"strings"
+)
+
+// Question strategies of an interview
+const (
+	QuestionStrategyOrdered  = "ordered"  // Every question, in the interview's order (the default)
+	QuestionStrategyShuffled = "shuffled" // Every question, in a random order per session
+	QuestionStrategySample   = "sample"   // "sample:N": N questions picked at random per session, in random order
+)
+
+// ParseQuestionStrategy validates a question strategy, returning the sample size of "sample:N" or 0
+// An empty strategy is ordered.
+func ParseQuestionStrategy(strategy string) (int, error) {
+	switch strategy {
+	case "", QuestionStrategyOrdered, QuestionStrategyShuffled:
+		return 0, nil
+	}
+	size, ok := strings.CutPrefix(strategy, QuestionStrategySample+":")
+	if !ok {
+		return 0, fmt.Errorf("unknown question strategy %q (supported: ordered, shuffled, sample:N)", strategy)
+	}
+	n, err := strconv.Atoi(size)
+	if err != nil || n < 1 {
+		return 0, fmt.Errorf("sample size must be a positive integer, got %q", size)
+	}
+	return n, nil
+}
+
+// ValidateQuestionStrategy checks a question strategy against the number of questions it picks from
+func ValidateQuestionStrategy(strategy string, questionCount int) error {
+	n, err := ParseQuestionStrategy(strategy)
+	if err != nil {
+		return err
+	}
+	if n > questionCount {
+		return fmt.Errorf("sample size %d is larger than the %d questions of the interview", n, questionCount)
+	}
+	return nil
+}
+
+// GetValidatedQuestionStrategy returns a valid question strategy, defaulting to ordered
+// Interviews stored before strategies existed have none and are ordered
+func GetValidatedQuestionStrategy(strategy string) string {
+	if strategy == "" {
+		return QuestionStrategyOrdered
+	}
+	if _, err := ParseQuestionStrategy(strategy); err != nil {
+		return QuestionStrategyOrdered
+	}
+	return strategy
+}
+
+// QuestionOrder resolves a question strategy into the indexes of the questions a session asks, in
+// the order it asks them. Ordered strategies return nil: every question, in the interview's order.
+// The order is drawn from an RNG seeded with the session ID, so it can be reproduced for audit.
+// A sample larger than questionCount (questions generated short of the number wanted) takes them all.
+func QuestionOrder(strategy, sessionID string, questionCount int) []int {
+	n, err := ParseQuestionStrategy(strategy)
+	if err != nil || strategy == "" || strategy == QuestionStrategyOrdered || questionCount == 0 {
+		return nil
+	}
+	seed := fnv.New64a()
+	seed.Write([]byte(sessionID))
+	order := rand.New(rand.NewSource(int64(seed.Sum64()))).Perm(questionCount)
+	if n > 0 && n < questionCount {
+		order = order[:n]
+	}
+	return order
+}
+
+// PlannedQuestionsFor returns the planned questions session asks, in its QuestionOrder
+// Sessions without an order ask every planned question in the interview's order.
+func (i *Interview) PlannedQuestionsFor(session *ChatSession) []string {
+	planned := i.PlannedQuestions()
+	if len(session.QuestionOrder) == 0 || len(planned) == 0 {
+		return planned
+	}
+	questions := make([]string, 0, len(session.QuestionOrder))
+	for _, index := range session.QuestionOrder {
+		// Indexes past the end would only come from questions removed after the session started
+		if index >= 0 && index < len(planned) {
+			questions = append(questions, planned[index])
+		}
+	}
+	return questions
+}
@@ -0,0 +1,77 @@
+package data_test
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zidane0000/ai-interview-platform/data"
+)
+
+func TestValidateQuestionStrategy(t *testing.T) {
+	tests := []struct {
+		name     string
+		strategy string
+		valid    bool
+	}{
+		{"empty is ordered", "", true},
+		{"ordered", "ordered", true},
+		{"shuffled", "shuffled", true},
+		{"sample within the questions", "sample:3", true},
+		{"sample of every question", "sample:5", true},
+		{"sample larger than the questions", "sample:6", false},
+		{"zero sample", "sample:0", false},
+		{"sample without a size", "sample:", false},
+		{"non-numeric sample", "sample:three", false},
+		{"unknown strategy", "random", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := data.ValidateQuestionStrategy(tt.strategy, 5)
+			assert.Equal(t, tt.valid, err == nil, "unexpected result: %v", err)
+		})
+	}
+}
+
+func TestQuestionOrder(t *testing.T) {
+	assert.Nil(t, data.QuestionOrder("", "session-1", 5))
+	assert.Nil(t, data.QuestionOrder(data.QuestionStrategyOrdered, "session-1", 5))
+
+	// The same session always draws the same order
+	shuffled := data.QuestionOrder(data.QuestionStrategyShuffled, "session-1", 8)
+	assert.Equal(t, shuffled, data.QuestionOrder(data.QuestionStrategyShuffled, "session-1", 8))
+	sorted := append([]int(nil), shuffled...)
+	sort.Ints(sorted)
+	assert.Equal(t, []int{0, 1, 2, 3, 4, 5, 6, 7}, sorted, "expected every question exactly once")
+
+	differs := false
+	for _, sessionID := range []string{"session-2", "session-3", "session-4", "session-5"} {
+		if !assert.ObjectsAreEqual(shuffled, data.QuestionOrder(data.QuestionStrategyShuffled, sessionID, 8)) {
+			differs = true
+		}
+	}
+	assert.True(t, differs, "expected other sessions to draw other orders")
+
+	sample := data.QuestionOrder("sample:3", "session-1", 8)
+	require.Len(t, sample, 3)
+	assert.Equal(t, sample, data.QuestionOrder("sample:3", "session-1", 8))
+	seen := make(map[int]bool)
+	for _, index := range sample {
+		assert.True(t, index >= 0 && index < 8, "index %d out of range", index)
+		assert.False(t, seen[index], "index %d picked twice", index)
+		seen[index] = true
+	}
+
+	// Questions generated short of the number wanted are all asked
+	assert.Len(t, data.QuestionOrder("sample:5", "session-1", 3), 3)
+}
+
+func TestInterview_PlannedQuestionsFor(t *testing.T) {
+	interview := &data.Interview{Questions: data.StringArray{"Q0", "Q1", "Q2"}}
+	assert.Equal(t, []string{"Q0", "Q1", "Q2"}, interview.PlannedQuestionsFor(&data.ChatSession{}))
+	assert.Equal(t, []string{"Q2", "Q0"}, interview.PlannedQuestionsFor(&data.ChatSession{QuestionOrder: data.IntArray{2, 0, 7}}))
+
+	interview.InterviewMode = data.InterviewModeConversational
+	assert.Empty(t, interview.PlannedQuestionsFor(&data.ChatSession{QuestionOrder: data.IntArray{2, 0}}))
+}
@@ -0,0 +1,118 @@
+// Retries of database operations that fail for transient reasons such as a Postgres failover
+package data
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net"
+	"syscall"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// retryPolicy bounds how often and how fast a database operation is retried
+type retryPolicy struct {
+	retries   int           // Attempts after the first one
+	baseDelay time.Duration // Backoff before the first retry, doubled for each further retry
+	maxDelay  time.Duration // Upper bound of a single backoff
+}
+
+// Writes ride out a brief failover; reads are cheap to fail and get a single retry
+var (
+	writeRetryPolicy = retryPolicy{retries: 3, baseDelay: 50 * time.Millisecond, maxDelay: 400 * time.Millisecond}
+	readRetryPolicy  = retryPolicy{retries: 1, baseDelay: 50 * time.Millisecond, maxDelay: 50 * time.Millisecond}
+)
+
+// Retried operation kinds, used as the metric label
+const (
+	retryOperationWrite = "write"
+	retryOperationRead  = "read"
+)
+
+// storeRetries counts database operations retried after a transient failure
+var storeRetries = promauto.NewCounterVec(prometheus.CounterOpts{
+	Namespace: "ai_interview",
+	Name:      "store_retries_total",
+	Help:      "Database operations retried after a transient failure, by operation (read, write).",
+}, []string{"operation"})
+
+// retryablePgCodes are Postgres SQLSTATE codes for failures that leave nothing applied and may
+// succeed when tried again. Constraint violations (class 23) are deliberately absent.
+var retryablePgCodes = map[string]bool{
+	"40001": true, // serialization_failure
+	"40P01": true, // deadlock_detected
+	"57P01": true, // admin_shutdown
+	"57P02": true, // crash_shutdown
+	"57P03": true, // cannot_connect_now, e.g. "the database system is starting up"
+	"08000": true, // connection_exception
+	"08001": true, // sqlclient_unable_to_establish_sqlconnection
+	"08003": true, // connection_does_not_exist
+	"08004": true, // sqlserver_rejected_establishment_of_sqlconnection
+	"08006": true, // connection_failure
+}
+
+// translateConstraintError wraps Postgres unique (23505) and foreign key (23503) violations with
+// ErrAlreadyExists and ErrConstraint, keeping the driver error for logs; other errors pass through
+func translateConstraintError(err error) error {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return err
+	}
+	switch pgErr.Code {
+	case "23505": // unique_violation
+		return fmt.Errorf("%w: %w", ErrAlreadyExists, err)
+	case "23503": // foreign_key_violation
+		return fmt.Errorf("%w: %w", ErrConstraint, err)
+	}
+	return err
+}
+
+// isRetryableError reports whether a failed database operation may be tried again
+// Errors reported by Postgres are classified by SQLSTATE. A connection lost mid-statement
+// leaves the outcome unknown, so it is only retried when repeating the operation is harmless.
+func isRetryableError(err error, idempotent bool) bool {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		return retryablePgCodes[pgErr.Code]
+	}
+	if pgconn.SafeToRetry(err) || errors.Is(err, driver.ErrBadConn) {
+		return true // The statement never reached the server
+	}
+	if errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, net.ErrClosed) {
+		return idempotent
+	}
+	return false
+}
+
+// withRetry runs fn, retrying transient failures with jittered exponential backoff
+// Retries stop early once ctx is done; the last error from fn is returned
+func withRetry(ctx context.Context, policy retryPolicy, operation string, idempotent bool, fn func() error) error {
+	err := fn()
+	for attempt := 0; attempt < policy.retries && err != nil && isRetryableError(err, idempotent); attempt++ {
+		delay := policy.baseDelay << attempt
+		if delay > policy.maxDelay {
+			delay = policy.maxDelay
+		}
+		// Jitter in [delay/2, delay] spreads out retries from concurrent requests
+		delay = delay/2 + time.Duration(rand.Int63n(int64(delay/2)+1))
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+		storeRetries.WithLabelValues(operation).Inc()
+		err = fn()
+	}
+	return err
+}
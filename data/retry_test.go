@@ -0,0 +1,173 @@
+package data_test
+
+import (
+	"context"
+	"errors"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/zidane0000/ai-interview-platform/data"
+)
+
+// newRetryTestStore creates a database-backed store on top of sqlmock
+func newRetryTestStore(t *testing.T) (*data.HybridStore, sqlmock.Sqlmock, func()) {
+	gormDB, mock, cleanup := newMockGormDB(t)
+	return data.NewHybridStoreWithDatabase(data.NewDatabaseService(gormDB)), mock, cleanup
+}
+
+// expectSessionUpdate expects one UpdateChatSession attempt, failing with err when non-nil
+func expectSessionUpdate(mock sqlmock.Sqlmock, err error) {
+	mock.ExpectBegin()
+	update := mock.ExpectExec(`UPDATE "chat_sessions"`)
+	if err != nil {
+		update.WillReturnError(err)
+		mock.ExpectRollback()
+		return
+	}
+	update.WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+}
+
+// storeRetryCount reads the store retry counter for operation from the default registry
+func storeRetryCount(t *testing.T, operation string) float64 {
+	t.Helper()
+	families, err := prometheus.DefaultGatherer.Gather()
+	if err != nil {
+		t.Fatalf("failed to gather metrics: %v", err)
+	}
+	for _, family := range families {
+		if family.GetName() != "ai_interview_store_retries_total" {
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			for _, label := range metric.GetLabel() {
+				if label.GetName() == "operation" && label.GetValue() == operation {
+					return metric.GetCounter().GetValue()
+				}
+			}
+		}
+	}
+	return 0
+}
+
+func TestHybridStore_WriteRetries(t *testing.T) {
+	serializationFailure := &pgconn.PgError{Code: "40001", Message: "could not serialize access"}
+	startingUp := &pgconn.PgError{Code: "57P03", Message: "the database system is starting up"}
+
+	tests := []struct {
+		name        string
+		failures    []error
+		wantErr     bool
+		wantRetries float64
+	}{
+		{"succeeds first time", nil, false, 0},
+		{"serialization failure then success", []error{serializationFailure}, false, 1},
+		{"database starting up three times then success", []error{startingUp, startingUp, startingUp}, false, 3},
+		{"connection reset then success", []error{syscall.ECONNRESET}, false, 1},
+		{"gives up after three retries", []error{startingUp, startingUp, startingUp, startingUp}, true, 3},
+		{"unique violation is not retried", []error{&pgconn.PgError{Code: "23505", Message: "duplicate key"}}, true, 0},
+		{"unknown error is not retried", []error{errors.New("syntax error")}, true, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store, mock, cleanup := newRetryTestStore(t)
+			defer cleanup()
+			for _, err := range tt.failures {
+				expectSessionUpdate(mock, err)
+			}
+			if !tt.wantErr {
+				expectSessionUpdate(mock, nil)
+			}
+
+			before := storeRetryCount(t, "write")
+			err := store.UpdateChatSession(&data.ChatSession{ID: "session-1", Status: "active"})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+			if got := storeRetryCount(t, "write") - before; got != tt.wantRetries {
+				t.Errorf("expected %v retries counted, got %v", tt.wantRetries, got)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("unexpected number of attempts: %v", err)
+			}
+		})
+	}
+}
+
+func TestHybridStore_NonIdempotentWritesNotRetriedOnConnectionReset(t *testing.T) {
+	tests := []struct {
+		name   string
+		expect func(mock sqlmock.Sqlmock)
+		write  func(store *data.HybridStore) error
+	}{
+		{"increment", func(mock sqlmock.Sqlmock) { expectSessionUpdate(mock, syscall.ECONNRESET) }, func(store *data.HybridStore) error {
+			return store.AddChatSessionCost("session-1", 0.5)
+		}},
+		{"insert", func(mock sqlmock.Sqlmock) {
+			mock.ExpectBegin()
+			mock.ExpectExec(`INSERT INTO "chat_sessions"`).WillReturnError(syscall.ECONNRESET)
+			mock.ExpectRollback()
+		}, func(store *data.HybridStore) error {
+			return store.CreateChatSession(&data.ChatSession{ID: "session-1", InterviewID: "interview-1", Status: "active"})
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store, mock, cleanup := newRetryTestStore(t)
+			defer cleanup()
+			tt.expect(mock)
+
+			if err := tt.write(store); err == nil {
+				t.Fatal("expected the connection reset to be returned")
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("expected a single attempt: %v", err)
+			}
+		})
+	}
+}
+
+func TestHybridStore_ReadRetriesOnce(t *testing.T) {
+	store, mock, cleanup := newRetryTestStore(t)
+	defer cleanup()
+	failure := &pgconn.PgError{Code: "08006", Message: "connection failure"}
+	mock.ExpectQuery(`SELECT \* FROM "chat_sessions"`).WillReturnError(failure)
+	mock.ExpectQuery(`SELECT \* FROM "chat_sessions"`).WillReturnError(failure)
+
+	before := storeRetryCount(t, "read")
+	if _, err := store.GetChatSession("session-1"); err == nil {
+		t.Fatal("expected the read to fail after its retry")
+	}
+	if got := storeRetryCount(t, "read") - before; got != 1 {
+		t.Errorf("expected 1 read retry counted, got %v", got)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("expected exactly two attempts: %v", err)
+	}
+}
+
+func TestHybridStore_RetriesStopWhenContextDone(t *testing.T) {
+	store, mock, cleanup := newRetryTestStore(t)
+	defer cleanup()
+	expectSessionUpdate(mock, &pgconn.PgError{Code: "40001"})
+
+	// The deadline passes during the first backoff, before any retry is attempted
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	before := storeRetryCount(t, "write")
+	if err := store.WithContext(ctx).UpdateChatSession(&data.ChatSession{ID: "session-1"}); err == nil {
+		t.Fatal("expected the serialization failure to be returned")
+	}
+	if got := storeRetryCount(t, "write") - before; got != 0 {
+		t.Errorf("expected no retries once the context is done, got %v", got)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("expected a single attempt: %v", err)
+	}
+}
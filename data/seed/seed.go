@@ -0,0 +1,341 @@
+// Package seed fills an empty store with a representative dataset for local development
+// Every record has a fixed ID, so demo links stay valid across restarts and machines.
+package seed
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/zidane0000/ai-interview-platform/ai"
+	"github.com/zidane0000/ai-interview-platform/data"
+)
+
+// DatasetDemo is the demo dataset: interviews across types, modes and languages, completed
+// chat sessions with their transcripts and evaluations
+const DatasetDemo = "demo"
+
+// IDs of demo records the frontend can link to
+const (
+	DemoInterviewID  = "demo-interview-technical-en" // Completed technical interview with a session and evaluation
+	DemoSessionID    = "demo-session-technical-en"   // DemoInterviewID's completed chat session
+	DemoEvaluationID = "demo-evaluation-technical-en"
+)
+
+// ErrStoreNotEmpty is returned when seeding a store that already holds interviews without Force
+var ErrStoreNotEmpty = errors.New("store is not empty")
+
+// Options tunes a seeding run
+type Options struct {
+	Force bool      // Seed even when the store holds interviews; records that already exist are left as they are
+	Now   time.Time // Reference time the dataset's timestamps are relative to; defaults to the current time
+}
+
+// Summary counts the records a seeding run created
+type Summary struct {
+	Interviews  int `json:"interviews"`
+	Sessions    int `json:"chat_sessions"`
+	Messages    int `json:"chat_messages"`
+	Evaluations int `json:"evaluations"`
+}
+
+// Run seeds store with the named dataset
+// It refuses with ErrStoreNotEmpty when the store holds any interview, unless opts.Force is set.
+func Run(store data.Store, dataset string, opts Options) (*Summary, error) {
+	if dataset != DatasetDemo {
+		return nil, fmt.Errorf("unknown seed dataset %q (available: %s)", dataset, DatasetDemo)
+	}
+	if !opts.Force {
+		existing, err := store.GetInterviewsWithOptions(data.ListInterviewsOptions{Limit: 1})
+		if err != nil {
+			return nil, fmt.Errorf("failed to check the store is empty: %w", err)
+		}
+		if existing.Total > 0 {
+			return nil, ErrStoreNotEmpty
+		}
+	}
+	if opts.Now.IsZero() {
+		opts.Now = time.Now()
+	}
+
+	s := &seeder{store: store, summary: &Summary{}}
+	for _, record := range demoDataset(opts.Now.UTC().Truncate(time.Second)) {
+		if err := s.write(record); err != nil {
+			return s.summary, err
+		}
+	}
+	return s.summary, nil
+}
+
+// demoRecord is one demo interview, optionally with a chat session and its evaluation
+type demoRecord struct {
+	interview  *data.Interview
+	session    *data.ChatSession
+	messages   []*data.ChatMessage
+	evaluation *data.Evaluation
+}
+
+// seeder writes records, skipping those that already exist
+type seeder struct {
+	store   data.Store
+	summary *Summary
+}
+
+// write stores a record: the interview, then its session and messages, then its evaluation
+func (s *seeder) write(record demoRecord) error {
+	created, err := s.create(record.interview.ID, func() error { return s.store.CreateInterview(record.interview) })
+	if err != nil {
+		return err
+	}
+	if created {
+		s.summary.Interviews++
+	}
+	if record.session != nil {
+		if created, err = s.create(record.session.ID, func() error { return s.store.CreateChatSession(record.session) }); err != nil {
+			return err
+		}
+		if created {
+			s.summary.Sessions++
+		}
+		for _, msg := range record.messages {
+			if created, err = s.create(msg.ID, func() error { return s.store.AddChatMessage(msg.SessionID, msg) }); err != nil {
+				return err
+			}
+			if created {
+				s.summary.Messages++
+			}
+		}
+	}
+	if record.evaluation != nil {
+		if created, err = s.create(record.evaluation.ID, func() error { return s.store.CreateEvaluation(record.evaluation) }); err != nil {
+			return err
+		}
+		if created {
+			s.summary.Evaluations++
+		}
+	}
+	return nil
+}
+
+// create runs a create call, reporting false instead of failing when the record already exists
+func (s *seeder) create(id string, fn func() error) (bool, error) {
+	err := fn()
+	switch {
+	case err == nil:
+		return true, nil
+	case errors.Is(err, data.ErrAlreadyExists):
+		return false, nil
+	default:
+		return false, fmt.Errorf("failed to seed %s: %w", id, err)
+	}
+}
+
+// bankQuestions returns the first n built-in questions for an interview type and language as bank question details
+func bankQuestions(interviewType, language string, n int, category string) data.QuestionDetailList {
+	set := data.GetDefaultQuestionSet(interviewType, language)
+	details := make(data.QuestionDetailList, 0, n)
+	for i, text := range set.Questions[:n] {
+		details = append(details, data.QuestionDetail{
+			Text:         text,
+			Category:     category,
+			Difficulty:   []string{"easy", "medium", "hard"}[i%3],
+			ExpectedTime: 3 + i,
+			Source:       data.QuestionSourceBank,
+		})
+	}
+	return details
+}
+
+// exchange is a question the AI asked and the candidate's answer
+type exchange struct {
+	question string
+	answer   string
+	minutes  int // Expected answer time of the planned question asked, if any
+}
+
+// demoSession returns a completed session with its transcript, starting at startedAt
+// The first exchange is the greeting; a closing message ends the conversation. Answers take a
+// minute or two, so answer timings have something to show.
+func demoSession(id, interviewID, language string, startedAt time.Time, exchanges []exchange, closing string) (*data.ChatSession, []*data.ChatMessage) {
+	session := &data.ChatSession{
+		ID:              id,
+		InterviewID:     interviewID,
+		SessionLanguage: language,
+		Status:          "completed",
+		StartedAt:       startedAt,
+		CreatedAt:       startedAt,
+		UpdatedAt:       startedAt,
+		Provider:        ai.ProviderMock,
+		Model:           "mock-model",
+	}
+
+	var messages []*data.ChatMessage
+	at := startedAt
+	add := func(msgType, subtype, content string, metadata data.StringMap) {
+		messages = append(messages, &data.ChatMessage{
+			ID:        fmt.Sprintf("%s-msg-%02d", id, len(messages)+1),
+			SessionID: id,
+			Type:      msgType,
+			Subtype:   subtype,
+			Content:   content,
+			Metadata:  metadata,
+			Timestamp: at,
+			CreatedAt: at,
+		})
+	}
+	for i, exchange := range exchanges {
+		subtype := data.MessageSubtypeQuestion
+		if i == 0 {
+			subtype = data.MessageSubtypeGreeting
+		}
+		var metadata data.StringMap
+		if exchange.minutes > 0 {
+			metadata = data.StringMap{data.MessageMetaExpectedTime: strconv.Itoa(exchange.minutes)}
+		}
+		session.AskedQuestions = append(session.AskedQuestions, exchange.question)
+		add("ai", subtype, exchange.question, metadata)
+		at = at.Add(time.Duration(60+30*i) * time.Second)
+		add("user", "", exchange.answer, nil)
+		at = at.Add(5 * time.Second)
+	}
+	add("ai", data.MessageSubtypeClosing, closing, nil)
+
+	session.End("completed", at)
+	session.UpdatedAt = at
+	return session, messages
+}
+
+// demoAnswers maps a transcript's answers to evaluation answer keys
+func demoAnswers(exchanges []exchange) data.StringMap {
+	answers := data.StringMap{}
+	for i, exchange := range exchanges {
+		answers[fmt.Sprintf("question_%d", i)] = exchange.answer
+	}
+	return answers
+}
+
+// demoDataset builds the demo records with timestamps in the days before now
+func demoDataset(now time.Time) []demoRecord {
+	day := 24 * time.Hour
+
+	technical := bankQuestions(data.InterviewTypeTechnical, data.LanguageEnglish, 3, "experience")
+	technicalExchanges := []exchange{
+		{"Welcome, Alex! Let's begin. " + technical[0].Text + ".",
+			"I've been a backend engineer for six years, mostly Go and PostgreSQL. At my current company I own the billing service, which handles about two million invoices a month.", technical[0].ExpectedTime},
+		{technical[1].Text + ".",
+			"Our invoice export started timing out at month end. I profiled it, found an N+1 query in the line-item loader, batched the reads and added a covering index. Export time dropped from twelve minutes to forty seconds.", technical[1].ExpectedTime},
+		{technical[2].Text,
+			"I start by reproducing the issue with the smallest input I can, then read the logs and traces around it. If that isn't enough I add targeted logging or attach a debugger, and I write a regression test before fixing.", technical[2].ExpectedTime},
+	}
+	technicalStarted := now.Add(-3 * day)
+	technicalSession, technicalMessages := demoSession(DemoSessionID, DemoInterviewID, data.LanguageEnglish, technicalStarted,
+		technicalExchanges, "Thank you, Alex. That's all the questions I have; we'll be in touch soon.")
+
+	general := bankQuestions(data.InterviewTypeGeneral, data.LanguageEnglish, 2, "motivation")
+	generalExchanges := []exchange{
+		{"Hello Jordan, thanks for joining. " + general[0].Text + ".",
+			"I studied design and moved into product support two years ago. I like helping people.", general[0].ExpectedTime},
+		{general[1].Text,
+			"I'm patient, I guess.", general[1].ExpectedTime},
+	}
+	generalStarted := now.Add(-2 * day)
+	generalSession, generalMessages := demoSession("demo-session-general-en", "demo-interview-general-en", data.LanguageEnglish, generalStarted,
+		generalExchanges, "Thanks for your time, Jordan.")
+
+	behavioral := bankQuestions(data.InterviewTypeBehavioral, data.LanguageTraditionalChinese, 3, "teamwork")
+
+	return []demoRecord{
+		{
+			interview: &data.Interview{
+				ID:                DemoInterviewID,
+				CandidateName:     "Alex Chen",
+				Questions:         technical.Texts(),
+				QuestionDetails:   technical,
+				InterviewLanguage: data.LanguageEnglish,
+				Status:            data.InterviewStatusCompleted,
+				InterviewType:     data.InterviewTypeTechnical,
+				InterviewMode:     data.InterviewModeStructured,
+				JobDescription:    "Senior Backend Engineer: design and operate Go services on PostgreSQL for a payments platform.",
+				CreatedAt:         technicalStarted.Add(-day),
+				UpdatedAt:         technicalSession.UpdatedAt,
+			},
+			session:  technicalSession,
+			messages: technicalMessages,
+			evaluation: &data.Evaluation{
+				ID:                DemoEvaluationID,
+				InterviewID:       DemoInterviewID,
+				Answers:           demoAnswers(technicalExchanges),
+				QuestionsSnapshot: technical.Texts(),
+				Score:             0.84,
+				Feedback:          "Strong, concrete answers backed by measurable results. Clear debugging method with an emphasis on regression tests. Could say more about trade-offs considered before optimizing.",
+				Status:            data.EvaluationStatusCompleted,
+				Provider:          ai.ProviderMock,
+				Model:             "mock-model",
+				Decision:          ai.DecisionHire,
+				NextSteps:         data.StringArray{"Schedule a system design interview", "Ask about on-call experience"},
+				CreatedAt:         technicalSession.UpdatedAt.Add(time.Minute),
+				UpdatedAt:         technicalSession.UpdatedAt.Add(time.Minute),
+			},
+		},
+		{
+			interview: &data.Interview{
+				ID:                "demo-interview-general-en",
+				CandidateName:     "Jordan Lee",
+				Questions:         general.Texts(),
+				QuestionDetails:   general,
+				InterviewLanguage: data.LanguageEnglish,
+				Status:            data.InterviewStatusCompleted,
+				InterviewType:     data.InterviewTypeGeneral,
+				InterviewMode:     data.InterviewModeStructured,
+				CreatedAt:         generalStarted.Add(-day),
+				UpdatedAt:         generalSession.UpdatedAt,
+			},
+			session:  generalSession,
+			messages: generalMessages,
+			evaluation: &data.Evaluation{
+				ID:                "demo-evaluation-general-en",
+				InterviewID:       "demo-interview-general-en",
+				Answers:           demoAnswers(generalExchanges),
+				QuestionsSnapshot: general.Texts(),
+				Score:             0.46,
+				Feedback:          "Friendly but brief answers with few examples. The candidate's strengths were asserted rather than shown.",
+				Status:            data.EvaluationStatusCompleted,
+				Provider:          ai.ProviderMock,
+				Model:             "mock-model",
+				Decision:          ai.DecisionMoreDataNeeded,
+				NextSteps:         data.StringArray{"Follow up with a structured behavioral interview"},
+				CreatedAt:         generalSession.UpdatedAt.Add(time.Minute),
+				UpdatedAt:         generalSession.UpdatedAt.Add(time.Minute),
+			},
+		},
+		{
+			interview: &data.Interview{
+				ID:                "demo-interview-behavioral-zh",
+				CandidateName:     "王小明",
+				Questions:         behavioral.Texts(),
+				QuestionDetails:   behavioral,
+				InterviewLanguage: data.LanguageTraditionalChinese,
+				Status:            data.InterviewStatusDraft,
+				InterviewType:     data.InterviewTypeBehavioral,
+				InterviewMode:     data.InterviewModeStructured,
+				CreatedAt:         now.Add(-day),
+				UpdatedAt:         now.Add(-day),
+			},
+		},
+		{
+			interview: &data.Interview{
+				ID:                "demo-interview-conversational-en",
+				CandidateName:     "Sam Rivera",
+				InterviewLanguage: data.LanguageEnglish,
+				Status:            data.InterviewStatusDraft,
+				InterviewType:     data.InterviewTypeTechnical,
+				InterviewMode:     data.InterviewModeConversational,
+				JobDescription:    "Frontend Engineer: build accessible React interfaces and work closely with designers.",
+				CompanyContext:    "A 40-person startup building hiring tools.",
+				CreatedAt:         now.Add(-time.Hour),
+				UpdatedAt:         now.Add(-time.Hour),
+			},
+		},
+	}
+}
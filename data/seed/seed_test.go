@@ -0,0 +1,123 @@
+package seed_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/zidane0000/ai-interview-platform/data"
+	"github.com/zidane0000/ai-interview-platform/data/seed"
+)
+
+// seeded is everything a demo seed wrote, as read back from the store
+type seeded struct {
+	interviews  []*data.Interview
+	sessions    []*data.ChatSession
+	messages    [][]*data.ChatMessage
+	evaluations []*data.Evaluation
+}
+
+// readSeeded reads back every interview with its sessions, messages and latest evaluation
+func readSeeded(t *testing.T, store data.Store) seeded {
+	t.Helper()
+	list, err := store.GetInterviewsWithOptions(data.ListInterviewsOptions{Limit: 100, SortBy: "name", SortOrder: "asc"})
+	if err != nil {
+		t.Fatalf("GetInterviewsWithOptions failed: %v", err)
+	}
+	result := seeded{interviews: list.Interviews}
+	for _, interview := range list.Interviews {
+		sessions, err := store.GetChatSessionsByInterview(interview.ID)
+		if err != nil {
+			t.Fatalf("GetChatSessionsByInterview failed: %v", err)
+		}
+		for _, session := range sessions {
+			messages, err := store.GetChatMessages(session.ID)
+			if err != nil {
+				t.Fatalf("GetChatMessages failed: %v", err)
+			}
+			result.sessions = append(result.sessions, session)
+			result.messages = append(result.messages, messages)
+		}
+		if evaluation, err := store.GetLatestEvaluationByInterview(interview.ID); err == nil {
+			result.evaluations = append(result.evaluations, evaluation)
+		}
+	}
+	return result
+}
+
+// newMemoryStore returns a store over a fresh memory backend
+func newMemoryStore() data.Store {
+	return data.NewHybridStoreWithMemory(data.NewMemoryStore())
+}
+
+func TestRun_Demo(t *testing.T) {
+	now := time.Date(2026, 6, 1, 9, 30, 0, 0, time.UTC)
+	store := newMemoryStore()
+	summary, err := seed.Run(store, seed.DatasetDemo, seed.Options{Now: now})
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	expected := seed.Summary{Interviews: 4, Sessions: 2, Messages: 12, Evaluations: 2}
+	if *summary != expected {
+		t.Errorf("expected %+v, got %+v", expected, *summary)
+	}
+
+	first := readSeeded(t, store)
+	if len(first.interviews) != 4 || len(first.sessions) != 2 || len(first.evaluations) != 2 {
+		t.Fatalf("expected 4 interviews, 2 sessions and 2 evaluations, got %d, %d and %d",
+			len(first.interviews), len(first.sessions), len(first.evaluations))
+	}
+	if _, err := store.GetEvaluation(seed.DemoEvaluationID); err != nil {
+		t.Errorf("expected the demo evaluation %s: %v", seed.DemoEvaluationID, err)
+	}
+	session, err := store.GetChatSession(seed.DemoSessionID)
+	if err != nil || session.InterviewID != seed.DemoInterviewID || session.Status != "completed" || session.EndedAt == nil {
+		t.Errorf("expected the demo session to be completed for %s, got %+v (%v)", seed.DemoInterviewID, session, err)
+	}
+	languages := map[string]bool{}
+	for _, interview := range first.interviews {
+		languages[interview.InterviewLanguage] = true
+	}
+	if !languages[data.LanguageEnglish] || !languages[data.LanguageTraditionalChinese] {
+		t.Errorf("expected interviews in both languages, got %v", languages)
+	}
+
+	// A second run on a fresh store writes exactly the same data
+	again := newMemoryStore()
+	if _, err := seed.Run(again, seed.DatasetDemo, seed.Options{Now: now}); err != nil {
+		t.Fatalf("second Run failed: %v", err)
+	}
+	if second := readSeeded(t, again); !reflect.DeepEqual(first, second) {
+		t.Errorf("expected identical data across runs")
+	}
+}
+
+func TestRun_RefusesNonEmptyStore(t *testing.T) {
+	store := newMemoryStore()
+	if err := store.CreateInterview(&data.Interview{ID: "existing", CandidateName: "Existing", InterviewType: data.InterviewTypeGeneral}); err != nil {
+		t.Fatalf("CreateInterview failed: %v", err)
+	}
+	if _, err := seed.Run(store, seed.DatasetDemo, seed.Options{}); !errors.Is(err, seed.ErrStoreNotEmpty) {
+		t.Fatalf("expected ErrStoreNotEmpty, got %v", err)
+	}
+	if _, err := store.GetInterview(seed.DemoInterviewID); err == nil {
+		t.Error("expected nothing seeded into a non-empty store")
+	}
+
+	// Forced, the dataset is added; forcing again leaves the existing demo records alone
+	summary, err := seed.Run(store, seed.DatasetDemo, seed.Options{Force: true})
+	if err != nil || summary.Interviews != 4 {
+		t.Fatalf("expected 4 interviews seeded, got %+v (%v)", summary, err)
+	}
+	summary, err = seed.Run(store, seed.DatasetDemo, seed.Options{Force: true})
+	if err != nil || *summary != (seed.Summary{}) {
+		t.Errorf("expected nothing created by a repeated forced run, got %+v (%v)", summary, err)
+	}
+}
+
+func TestRun_UnknownDataset(t *testing.T) {
+	if _, err := seed.Run(newMemoryStore(), "production", seed.Options{}); err == nil {
+		t.Error("expected an error for an unknown dataset")
+	}
+}
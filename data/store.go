@@ -0,0 +1,79 @@
+package data
+
+import (
+	"context"
+	"time"
+)
+
+// Store is the storage the API handlers work against; HybridStore implements it over memory or
+// PostgreSQL. Handlers are given a Store rather than reaching for GlobalStore, so tests and
+// tenants can each use their own.
+type Store interface {
+	// WithContext returns a store whose database queries and retries are bounded by ctx
+	WithContext(ctx context.Context) Store
+	// WithPrimaryReads returns a store whose reads skip the replica
+	WithPrimaryReads() Store
+
+	CreateInterview(interview *Interview) error
+	CreateInterviewWithSession(interview *Interview, session *ChatSession) error
+	GetInterview(id string) (*Interview, error)
+	UpdateInterview(interview *Interview) error
+	SetInterviewJobDescriptionSummary(id, summary string) error
+	SetInterviewQuestionsStatus(id, status string, questions QuestionDetailList, failure string) error
+	RecordInterviewOutcome(id, outcome, note string) error
+	GetInterviewsWithOptions(options ListInterviewsOptions) (*ListInterviewsResult, error)
+	GetInterviewsGroupedByCandidate(options CandidateGroupOptions) (*CandidateGroupsResult, error)
+	GetInterviewEstimatedCost(interviewID string) (float64, error)
+
+	CreateEvaluation(evaluation *Evaluation) error
+	GetEvaluation(id string) (*Evaluation, error)
+	GetLatestEvaluationByInterview(interviewID string) (*Evaluation, error)
+	GetLatestEvaluationsByInterviews(interviewIDs []string) (map[string]*Evaluation, error)
+	SupersedeEvaluation(id string) error
+	GetEvaluationScoresByModel() ([]*ModelScoreStats, error)
+	GetEvaluationDecisionCounts() ([]*DecisionCount, error)
+	GetEvaluationScoresByOutcome() ([]*OutcomeScoreStats, error)
+	GetScoreDistribution(interviewType, model string, since time.Time) ([]float64, error)
+	CreateEvaluationTrace(trace *EvaluationTrace) error
+	GetEvaluationTrace(evaluationID string) (*EvaluationTrace, error)
+
+	CreateChatSession(session *ChatSession) error
+	GetChatSession(id string) (*ChatSession, error)
+	UpdateChatSession(session *ChatSession) error
+	AppendAskedQuestion(sessionID, question string) error
+	AppendDifficultyLevel(sessionID string, level int) error
+	PinChatSessionProvider(sessionID, provider, model string) error
+	GetChatSessionsByInterview(interviewID string) ([]*ChatSession, error)
+	GetSessionDurationsByInterviewType() ([]*SessionDurationStats, error)
+	AddChatSessionCost(sessionID string, amount float64) error
+	GetCompletedSessionsWithoutEvaluation(limit int) ([]*ChatSession, error)
+	GetIdleChatSessions(cutoff time.Time, limit int) ([]*ChatSession, error)
+	AbandonIdleChatSession(sessionID string, cutoff, at time.Time) (bool, error)
+	RecordChatSessionHeartbeat(sessionID string, at time.Time, minInterval time.Duration) (bool, error)
+	RecordChatSessionAIAttempt(sessionID string, maxAttempts int) (bool, error)
+	RecordChatSessionAbuse(sessionID, reason string) error
+	ReopenChatSession(sessionID string, endedAfter time.Time, evaluationID string) (bool, error)
+
+	AddChatMessage(sessionID string, message *ChatMessage) error
+	AddChatMessageWithLimit(sessionID string, message *ChatMessage, maxMessages int) error
+	GetChatMessages(sessionID string) ([]*ChatMessage, error)
+	GetChatMessagesWithOptions(sessionID string, options ListMessagesOptions) (*ListMessagesResult, error)
+	GetChatMessageByClientID(sessionID, clientMessageID string) (*ChatMessage, error)
+	PurgeMessagesOlderThan(cutoff time.Time) (int, error)
+	CountMessagesOlderThan(cutoff time.Time) (int, error)
+
+	CreateNotification(notification *Notification) error
+	GetDueNotifications(now time.Time, limit int) ([]*Notification, error)
+	UpdateNotification(notification *Notification) error
+	GetNotificationStats() (*NotificationStats, error)
+
+	CreateCalibrationRun(run *CalibrationRun) error
+	GetCalibrationRun(id string) (*CalibrationRun, error)
+
+	// Health checks the backend's connectivity
+	Health() error
+	// Maintenance reclaims the space of deleted and updated records and reports the size of the store
+	Maintenance(ctx context.Context) (*MaintenanceReport, error)
+}
+
+var _ Store = (*HybridStore)(nil)
@@ -0,0 +1,60 @@
+// Per-operation store metrics
+package data
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// Operation outcomes, used as the metric label
+const (
+	storeResultOK    = "ok"
+	storeResultError = "error"
+)
+
+// storeOperations counts store operations by name, backend and outcome
+var storeOperations = promauto.NewCounterVec(prometheus.CounterOpts{
+	Namespace: "ai_interview",
+	Name:      "store_operations_total",
+	Help:      "Store operations, by operation, backend (memory, database) and result (ok, error).",
+}, []string{"operation", "backend", "result"})
+
+// storeOperationDuration records how long store operations take, retries included
+var storeOperationDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+	Namespace: "ai_interview",
+	Name:      "store_operation_duration_seconds",
+	Help:      "Time spent in each store operation, by operation and backend (memory, database).",
+	Buckets:   []float64{0.0001, 0.0005, 0.001, 0.005, 0.01, 0.05, 0.1, 0.25, 0.5, 1, 2.5},
+}, []string{"operation", "backend"})
+
+// WithInstrumentation returns a store that records a counter and a latency histogram for each
+// operation. Stores derived from it (WithContext, WithPrimaryReads) stay instrumented.
+// Errors are returned unchanged; a store not built with WithInstrumentation records nothing.
+func WithInstrumentation(store *HybridStore) *HybridStore {
+	bound := *store
+	bound.instrumented = true
+	return &bound
+}
+
+// untracked is returned by track for stores without instrumentation
+func untracked(*error) {}
+
+// track starts timing operation; the returned function records it with the final error
+// Store operations call it as `defer h.track("Name")(&err)`
+func (h *HybridStore) track(operation string) func(*error) {
+	if !h.instrumented {
+		return untracked
+	}
+	start := time.Now()
+	return func(err *error) {
+		backend := string(h.backend)
+		result := storeResultOK
+		if *err != nil {
+			result = storeResultError
+		}
+		storeOperationDuration.WithLabelValues(operation, backend).Observe(time.Since(start).Seconds())
+		storeOperations.WithLabelValues(operation, backend, result).Inc()
+	}
+}
@@ -0,0 +1,120 @@
+package data_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/zidane0000/ai-interview-platform/data"
+)
+
+// storeOperationCount reads the store operation counter for one label set from the default registry
+func storeOperationCount(t *testing.T, operation, backend, result string) float64 {
+	t.Helper()
+	families, err := prometheus.DefaultGatherer.Gather()
+	if err != nil {
+		t.Fatalf("failed to gather metrics: %v", err)
+	}
+	want := map[string]string{"operation": operation, "backend": backend, "result": result}
+	for _, family := range families {
+		if family.GetName() != "ai_interview_store_operations_total" {
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			matched := 0
+			for _, label := range metric.GetLabel() {
+				if want[label.GetName()] == label.GetValue() {
+					matched++
+				}
+			}
+			if matched == len(want) {
+				return metric.GetCounter().GetValue()
+			}
+		}
+	}
+	return 0
+}
+
+func TestWithInstrumentation_CountsOperations(t *testing.T) {
+	plain, err := data.NewHybridStore(data.BackendMemory, "")
+	if err != nil {
+		t.Fatalf("NewHybridStore failed: %v", err)
+	}
+	store := data.WithInstrumentation(plain)
+	created := storeOperationCount(t, "CreateInterview", "memory", "ok")
+	failed := storeOperationCount(t, "CreateInterview", "memory", "error")
+
+	interview := &data.Interview{ID: "interview-metrics", CandidateName: "Metrics Candidate", Questions: data.StringArray{"Q1"}}
+	if err := store.CreateInterview(interview); err != nil {
+		t.Fatalf("CreateInterview failed: %v", err)
+	}
+	// Errors pass through unchanged
+	if err := store.CreateInterview(interview); !errors.Is(err, data.ErrAlreadyExists) {
+		t.Errorf("expected ErrAlreadyExists, got %v", err)
+	}
+	if got := storeOperationCount(t, "CreateInterview", "memory", "ok"); got != created+1 {
+		t.Errorf("expected 1 more successful CreateInterview, got %v", got-created)
+	}
+	if got := storeOperationCount(t, "CreateInterview", "memory", "error"); got != failed+1 {
+		t.Errorf("expected 1 more failed CreateInterview, got %v", got-failed)
+	}
+
+	// Stores derived from an instrumented store stay instrumented
+	reads := storeOperationCount(t, "GetInterview", "memory", "ok")
+	if _, err := store.WithPrimaryReads().GetInterview("interview-metrics"); err != nil {
+		t.Fatalf("GetInterview failed: %v", err)
+	}
+	if got := storeOperationCount(t, "GetInterview", "memory", "ok"); got != reads+1 {
+		t.Errorf("expected 1 more GetInterview, got %v", got-reads)
+	}
+
+	// The store it wraps records nothing
+	if _, err := plain.GetInterview("interview-metrics"); err != nil {
+		t.Fatalf("GetInterview failed: %v", err)
+	}
+	if got := storeOperationCount(t, "GetInterview", "memory", "ok"); got != reads+1 {
+		t.Errorf("expected the uninstrumented store not to record, got %v more", got-reads-1)
+	}
+}
+
+func TestWithInstrumentation_TransparentErrors(t *testing.T) {
+	gormDB, mock, cleanup := newMockGormDB(t)
+	defer cleanup()
+	plain := data.NewHybridStoreWithDatabase(data.NewDatabaseService(gormDB))
+	store := data.WithInstrumentation(plain)
+	failed := storeOperationCount(t, "GetChatSession", "database", "error")
+
+	for range 2 {
+		mock.ExpectQuery(`SELECT \* FROM "chat_sessions" WHERE id = \$1`).
+			WithArgs("missing", 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	}
+	_, plainErr := plain.GetChatSession("missing")
+	_, err := store.GetChatSession("missing")
+	if err == nil || plainErr == nil || err.Error() != plainErr.Error() {
+		t.Errorf("expected the not-found error unchanged, got %v and %v", err, plainErr)
+	}
+	if got := storeOperationCount(t, "GetChatSession", "database", "error"); got != failed+1 {
+		t.Errorf("expected 1 more failed GetChatSession on the database backend, got %v", got-failed)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unexpected queries: %v", err)
+	}
+}
+
+func TestInitGlobalStore_Instrumented(t *testing.T) {
+	original := data.GlobalStore
+	defer func() { data.GlobalStore = original }()
+
+	if err := data.InitGlobalStore("", ""); err != nil {
+		t.Fatalf("InitGlobalStore failed: %v", err)
+	}
+	before := storeOperationCount(t, "GetChatSession", "memory", "error")
+	if _, err := data.GlobalStore.GetChatSession("missing"); err == nil {
+		t.Fatal("expected an error for an unknown session")
+	}
+	if got := storeOperationCount(t, "GetChatSession", "memory", "error"); got != before+1 {
+		t.Errorf("expected the global store to record GetChatSession, got %v more", got-before)
+	}
+}
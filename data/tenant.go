@@ -0,0 +1,49 @@
+// Tenant scoping of stored data
+package data
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
+
+// DefaultTenantID owns records created outside any tenant, including those stored before tenants existed
+const DefaultTenantID = "default"
+
+// tenantContextKey is the context key of the tenant a request is scoped to
+type tenantContextKey struct{}
+
+// WithTenant returns a context that scopes the store operations it is bound to to tenantID
+// (see HybridStore.WithContext): reads only see the tenant's records and creates are stamped with it
+func WithTenant(ctx context.Context, tenantID string) context.Context {
+	return context.WithValue(ctx, tenantContextKey{}, tenantID)
+}
+
+// TenantFromContext returns the tenant ctx is scoped to, or "" when it is not scoped
+func TenantFromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	tenantID, _ := ctx.Value(tenantContextKey{}).(string)
+	return tenantID
+}
+
+// stampTenant assigns a record about to be created to the tenant the store is scoped to
+// Unscoped stores keep the record's own tenant, defaulting to DefaultTenantID
+func stampTenant(tenantID string, recordTenantID *string) {
+	switch {
+	case tenantID != "":
+		*recordTenantID = tenantID
+	case *recordTenantID == "":
+		*recordTenantID = DefaultTenantID
+	}
+}
+
+// tenantScope limits query to the rows of tenantID, whose tenant is held in column
+// An empty tenantID leaves the query unscoped
+func tenantScope(query *gorm.DB, column, tenantID string) *gorm.DB {
+	if tenantID == "" {
+		return query
+	}
+	return query.Where(column+" = ?", tenantID)
+}
@@ -1,6 +1,8 @@
 package data
 
 import (
+	"time"
+
 	"github.com/google/uuid"
 )
 
@@ -8,3 +10,24 @@ import (
 func GenerateID() string {
 	return uuid.New().String()
 }
+
+// stampCreated fills in the unset timestamps of a record about to be created; a new record's
+// UpdatedAt defaults to its CreatedAt
+func stampCreated(now time.Time, createdAt, updatedAt *time.Time) {
+	if createdAt.IsZero() {
+		*createdAt = now
+	}
+	if updatedAt != nil && updatedAt.IsZero() {
+		*updatedAt = *createdAt
+	}
+}
+
+// toUTC converts the given timestamps to UTC in place, so both backends store and return the same
+// representation; nil pointers and zero times are left alone
+func toUTC(times ...*time.Time) {
+	for _, t := range times {
+		if t != nil && !t.IsZero() {
+			*t = t.UTC()
+		}
+	}
+}
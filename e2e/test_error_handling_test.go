@@ -141,7 +141,7 @@ func TestErrorHandling(t *testing.T) {
 		}
 		defer resp.Body.Close()
 
-		AssertErrorResponse(t, resp, http.StatusBadRequest, "Chat session is not active")
+		AssertErrorResponse(t, resp, http.StatusConflict, "Chat session is not active")
 	})
 }
 
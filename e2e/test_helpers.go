@@ -9,6 +9,7 @@ import (
 	"testing"
 
 	"github.com/zidane0000/ai-interview-platform/api"
+	"github.com/zidane0000/ai-interview-platform/data"
 )
 
 // Use API DTOs directly instead of mirroring them
@@ -250,34 +251,17 @@ func AssertErrorResponse(t *testing.T, resp *http.Response, expectedStatus int,
 	}
 }
 
-// Sample test data generators
+// Sample test data generators, from the built-in question sets
 func GetSampleQuestions() []string {
-	return []string{
-		"Tell me about yourself",
-		"What are your strengths?",
-		"Describe a challenging project you worked on",
-		"Where do you see yourself in 5 years?",
-	}
+	return data.GetDefaultQuestionSet(data.InterviewTypeGeneral, data.LanguageEnglish).Questions
 }
 
 func GetSampleTechnicalQuestions() []string {
-	return []string{
-		"Tell me about your technical background and experience",
-		"Describe a challenging technical problem you solved recently",
-		"How do you approach debugging and troubleshooting?",
-		"What technologies are you most excited about learning?",
-		"Walk me through your development process for a new feature",
-	}
+	return data.GetDefaultQuestionSet(data.InterviewTypeTechnical, data.LanguageEnglish).Questions
 }
 
 func GetSampleBehavioralQuestions() []string {
-	return []string{
-		"Tell me about a time when you had to work under pressure",
-		"Describe a situation where you had to resolve a conflict with a colleague",
-		"Give me an example of when you showed leadership",
-		"Tell me about a time you failed and what you learned from it",
-		"How do you handle feedback and criticism?",
-	}
+	return data.GetDefaultQuestionSet(data.InterviewTypeBehavioral, data.LanguageEnglish).Questions
 }
 
 func GetSampleJobDescription() string {
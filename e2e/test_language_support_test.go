@@ -8,6 +8,8 @@ import (
 	"fmt"
 	"net/http"
 	"testing"
+
+	"github.com/zidane0000/ai-interview-platform/utils"
 )
 
 // Helper function to make JSON requests with proper error handling
@@ -58,17 +60,6 @@ func makeJSONRequest(t *testing.T, method, endpoint string, payload interface{})
 	return resp, responseBody
 }
 
-// Helper function to count Chinese characters in text
-func countChineseCharacters(text string) int {
-	count := 0
-	for _, r := range text {
-		if r >= 0x4e00 && r <= 0x9fff { // CJK Unified Ideographs range
-			count++
-		}
-	}
-	return count
-}
-
 // TestCreateInterviewWithLanguage tests creating interviews with language preference
 func TestCreateInterviewWithLanguage(t *testing.T) {
 	tests := []struct {
@@ -255,11 +246,11 @@ func TestAIQuestionGenerationLanguage(t *testing.T) {
 			// Check if initial AI message language matches expectation
 			if len(chatSession.Messages) > 0 {
 				firstMessage := chatSession.Messages[0]
-				actualHasChinese := countChineseCharacters(firstMessage.Content) > 0
+				actualHasChinese := utils.CountCJKCharacters(firstMessage.Content) > 0
 
 				// DEBUG: Log message details
 				t.Logf("AI Message: %s", firstMessage.Content)
-				t.Logf("Chinese character count: %d", countChineseCharacters(firstMessage.Content))
+				t.Logf("Chinese character count: %d", utils.CountCJKCharacters(firstMessage.Content))
 
 				if actualHasChinese != tt.shouldHaveChinese {
 					t.Errorf("Language mismatch - Expected Chinese: %v, Has Chinese: %v, Message: %s",
@@ -321,7 +312,7 @@ func TestChatSessionLanguagePersistence(t *testing.T) {
 	}
 
 	aiContent := msgResponse.AIResponse.Content
-	if countChineseCharacters(aiContent) == 0 {
+	if utils.CountCJKCharacters(aiContent) == 0 {
 		t.Errorf("AI response should contain Chinese characters for zh-TW language")
 		t.Logf("AI Response: %s", aiContent)
 	}
@@ -349,7 +340,7 @@ func TestEvaluationLanguage(t *testing.T) {
 	}
 
 	// Validate evaluation is in Traditional Chinese
-	if countChineseCharacters(evaluation.Feedback) == 0 {
+	if utils.CountCJKCharacters(evaluation.Feedback) == 0 {
 		t.Errorf("Evaluation feedback should be in Traditional Chinese for zh-TW interview")
 		t.Logf("Actual feedback: %s", evaluation.Feedback)
 	}
@@ -396,7 +387,7 @@ func TestCompleteLanguageWorkflow(t *testing.T) {
 		t.Errorf("Evaluation feedback should not be empty")
 	}
 	// CRITICAL: Validate evaluation is in Traditional Chinese
-	if countChineseCharacters(evaluation.Feedback) == 0 {
+	if utils.CountCJKCharacters(evaluation.Feedback) == 0 {
 		t.Errorf("Evaluation feedback should be in Traditional Chinese for zh-TW interview")
 		t.Logf("Actual feedback: %s", evaluation.Feedback)
 	}
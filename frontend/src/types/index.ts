@@ -6,6 +6,9 @@ export interface Interview {
   interview_type: string; // Required: "general", "technical", or "behavioral"
   interview_language?: 'en' | 'zh-TW';
   job_description?: string; // Optional: Job description text
+  status?: 'draft' | 'scheduled' | 'active' | 'completed';
+  scheduled_start?: string;
+  scheduled_end?: string;
   created_at: string;
 }
 
@@ -15,6 +18,8 @@ export interface CreateInterviewRequest {
   interview_type: string; // Required: "general", "technical", or "behavioral"
   interview_language?: 'en' | 'zh-TW';
   job_description?: string; // Optional: Job description text
+  scheduled_start?: string; // Optional: sessions cannot start before this time
+  scheduled_end?: string; // Optional: sessions cannot start after this time (plus grace)
 }
 
 export interface SubmitEvaluationRequest {
@@ -22,30 +27,99 @@ export interface SubmitEvaluationRequest {
   answers: Record<string, string>;
 }
 
+export interface EvaluationAnswer {
+  question: string;
+  answer: string;
+}
+
 export interface Evaluation {
   id: string;
   interview_id: string;
   answers: Record<string, string>;
+  answers_v2?: EvaluationAnswer[];
+  questions_snapshot?: string[];
   score: number;
   feedback: string;
+  status?: 'completed' | 'no_answers';
+  provider?: string;
+  model?: string;
+  estimated_cost_usd?: number;
+  supersedes_id?: string;
+  percentile?: number; // With ?include=percentile
+  cohort_size?: number;
   created_at: string;
 }
 
+export type ApiErrorCode =
+  | 'invalid_json'
+  | 'validation_failed'
+  | 'not_found'
+  | 'conflict'
+  | 'rate_limited'
+  | 'ai_unavailable'
+  | 'internal';
+
 export interface ApiError {
   error: string;
+  code: ApiErrorCode;
   details?: string;
+  existing_evaluation_id?: string; // Set on evaluation submission conflicts
+}
+
+// Non-fatal issue reported alongside a successful response; codes are listed in api/warnings.go
+export interface ApiWarning {
+  code: string;
+  message: string;
+  field?: string;
 }
 
 export interface ListInterviewsResponse {
   interviews: Interview[];
   total: number;
+  limit?: number;
+  offset?: number;
+  page?: number;
+  total_pages?: number;
+  warnings?: ApiWarning[];
+}
+
+export interface InterviewSummary {
+  id: string;
+  candidate_name: string;
+  interview_type: string;
+  interview_language: string;
+  status: string;
+  created_at: string;
+}
+
+export interface CandidateGroup {
+  candidate_name: string;
+  interview_count: number;
+  latest_created_at: string;
+  latest_score: number | null;
+  interviews: InterviewSummary[];
+}
+
+export interface ListCandidateGroupsResponse {
+  candidates: CandidateGroup[];
+  total: number;
+  limit: number;
+  offset: number;
+  page: number;
+  total_pages: number;
+  warnings?: ApiWarning[];
 }
 
 // Chat-based interview types
 export interface ChatMessage {
   id: string;
-  type: 'ai' | 'user';
+  client_message_id?: string;
+  type: 'ai' | 'user' | 'system';
   content: string;
+  subtype?: 'greeting' | 'question' | 'follow_up' | 'acknowledgement' | 'closing';
+  provider?: string;
+  model?: string;
+  metadata?: { language_mismatch?: 'true'; expected_time_minutes?: string };
   timestamp: string;
 }
 
@@ -55,19 +129,42 @@ export interface ChatInterviewSession {
   session_language?: 'en' | 'zh-TW';
   messages: ChatMessage[];
   status: 'active' | 'completed';
+  provider?: string;
+  model?: string;
+  estimated_cost_usd?: number;
   created_at: string;
+  progress?: InterviewProgress;
+}
+
+export interface InterviewProgress {
+  questions_total: number;
+  questions_asked: number;
+  user_messages: number;
+  percent_complete: number;
+  will_end_after_next: boolean;
+  current_question_expected_time?: number; // Minutes suggested for the current question
 }
 
 export interface SendMessageRequest {
   message: string;
+  client_message_id?: string;
 }
 
 export interface StartChatSessionRequest {
   session_language?: 'en' | 'zh-TW';
 }
 
+export interface MessageTimings {
+  queue_ms: number;
+  provider_ms: number;
+  store_ms: number;
+  total_ms: number;
+}
+
 export interface SendMessageResponse {
   message: ChatMessage;
   ai_response?: ChatMessage;
   session_status: string;
+  timings?: MessageTimings;
+  progress?: InterviewProgress;
 }
@@ -0,0 +1,291 @@
+// Package testsupport builds interviews, chat sessions and transcripts for tests
+// Builders fill every required field with a default, so tests only set what they care about
+// and new model fields need a default here rather than at every call site.
+package testsupport
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/zidane0000/ai-interview-platform/data"
+)
+
+// InterviewBuilder builds a data.Interview; start one with NewInterviewBuilder
+type InterviewBuilder struct {
+	interview data.Interview
+}
+
+// NewInterviewBuilder returns a builder for a structured, general, English interview with one question
+func NewInterviewBuilder() *InterviewBuilder {
+	return &InterviewBuilder{interview: data.Interview{
+		ID:                data.GenerateID(),
+		CandidateName:     "Test Candidate",
+		Questions:         []string{"Q1"},
+		InterviewLanguage: "en",
+		Status:            data.InterviewStatusDraft,
+		InterviewType:     "general",
+		InterviewMode:     data.InterviewModeStructured,
+	}}
+}
+
+// WithID sets the interview ID (only used when writing to a store)
+func (b *InterviewBuilder) WithID(id string) *InterviewBuilder {
+	b.interview.ID = id
+	return b
+}
+
+// WithCandidate sets the candidate name
+func (b *InterviewBuilder) WithCandidate(name string) *InterviewBuilder {
+	b.interview.CandidateName = name
+	return b
+}
+
+// WithQuestions sets n numbered questions: "Q1" to "Qn"
+func (b *InterviewBuilder) WithQuestions(n int) *InterviewBuilder {
+	questions := make([]string, n)
+	for i := range questions {
+		questions[i] = fmt.Sprintf("Q%d", i+1)
+	}
+	b.interview.Questions = questions
+	return b
+}
+
+// WithQuestionTexts sets the questions verbatim; no arguments leaves the interview without questions
+func (b *InterviewBuilder) WithQuestionTexts(questions ...string) *InterviewBuilder {
+	b.interview.Questions = append([]string{}, questions...)
+	return b
+}
+
+// WithQuestionDetails sets structured questions; the questions are their texts
+func (b *InterviewBuilder) WithQuestionDetails(details ...data.QuestionDetail) *InterviewBuilder {
+	b.interview.QuestionDetails = append(data.QuestionDetailList{}, details...)
+	b.interview.Questions = make([]string, len(details))
+	for i, detail := range details {
+		b.interview.Questions[i] = detail.Text
+	}
+	return b
+}
+
+// WithLanguage sets the interview language ("en" or "zh-TW")
+func (b *InterviewBuilder) WithLanguage(language string) *InterviewBuilder {
+	b.interview.InterviewLanguage = language
+	return b
+}
+
+// WithType sets the interview type ("general", "technical" or "behavioral")
+func (b *InterviewBuilder) WithType(interviewType string) *InterviewBuilder {
+	b.interview.InterviewType = interviewType
+	return b
+}
+
+// WithMode sets the interview mode, see data.InterviewMode* constants
+func (b *InterviewBuilder) WithMode(mode string) *InterviewBuilder {
+	b.interview.InterviewMode = mode
+	return b
+}
+
+// WithStatus sets the interview status (only used when writing to a store)
+func (b *InterviewBuilder) WithStatus(status string) *InterviewBuilder {
+	b.interview.Status = status
+	return b
+}
+
+// WithJobDescription sets the job description
+func (b *InterviewBuilder) WithJobDescription(jobDescription string) *InterviewBuilder {
+	b.interview.JobDescription = jobDescription
+	return b
+}
+
+// WithResume sets the candidate's resume text
+func (b *InterviewBuilder) WithResume(resume string) *InterviewBuilder {
+	b.interview.ResumeContent = resume
+	return b
+}
+
+// WithCompanyContext sets the company context
+func (b *InterviewBuilder) WithCompanyContext(companyContext string) *InterviewBuilder {
+	b.interview.CompanyContext = companyContext
+	return b
+}
+
+// WithSchedule sets the scheduling window; either end may be nil
+func (b *InterviewBuilder) WithSchedule(start, end *time.Time) *InterviewBuilder {
+	b.interview.ScheduledStart = start
+	b.interview.ScheduledEnd = end
+	return b
+}
+
+// WithWebhook subscribes the interview's own webhook endpoint to events (all when none are given)
+func (b *InterviewBuilder) WithWebhook(url, secret string, events ...string) *InterviewBuilder {
+	b.interview.NotifyWebhookURL = url
+	b.interview.NotifySecret = secret
+	b.interview.NotifyEvents = events
+	return b
+}
+
+// WithAdaptive sets whether question difficulty adapts to the candidate's answers
+func (b *InterviewBuilder) WithAdaptive(adaptive bool) *InterviewBuilder {
+	b.interview.AdaptiveDisabled = !adaptive
+	return b
+}
+
+// WithQuestionStrategy sets which questions each session asks, in which order ("shuffled", "sample:N")
+func (b *InterviewBuilder) WithQuestionStrategy(strategy string) *InterviewBuilder {
+	b.interview.QuestionStrategy = strategy
+	return b
+}
+
+// WithCreatedAt sets the creation time (only used when writing to a store)
+func (b *InterviewBuilder) WithCreatedAt(createdAt time.Time) *InterviewBuilder {
+	b.interview.CreatedAt = createdAt
+	b.interview.UpdatedAt = createdAt
+	return b
+}
+
+// Build returns a new copy of the interview
+func (b *InterviewBuilder) Build() *data.Interview {
+	interview := b.interview
+	if b.interview.Questions != nil {
+		interview.Questions = append(data.StringArray{}, b.interview.Questions...)
+	}
+	return &interview
+}
+
+// Create writes the interview to store, failing the test on error
+func (b *InterviewBuilder) Create(t testing.TB, store data.Store) *data.Interview {
+	t.Helper()
+	interview := b.Build()
+	if err := store.CreateInterview(interview); err != nil {
+		t.Fatalf("failed to create interview: %v", err)
+	}
+	return interview
+}
+
+// QA is one question the AI asked and the candidate's answer
+type QA struct {
+	Question string
+	Answer   string
+}
+
+// Pair returns a transcript entry for WithTranscript
+func Pair(question, answer string) QA {
+	return QA{Question: question, Answer: answer}
+}
+
+// SessionBuilder builds a data.ChatSession and its transcript; start one with NewSessionBuilder
+type SessionBuilder struct {
+	session    data.ChatSession
+	transcript []QA
+}
+
+// NewSessionBuilder returns a builder for an active session without messages
+// Set its interview with ForInterview or ForInterviewID.
+func NewSessionBuilder() *SessionBuilder {
+	return &SessionBuilder{session: data.ChatSession{
+		ID:     data.GenerateID(),
+		Status: "active",
+	}}
+}
+
+// ForInterview sets the session's interview; the session uses the interview's language
+func (b *SessionBuilder) ForInterview(interview *data.Interview) *SessionBuilder {
+	b.session.InterviewID = interview.ID
+	if b.session.SessionLanguage == "" {
+		b.session.SessionLanguage = interview.InterviewLanguage
+	}
+	return b
+}
+
+// ForInterviewID sets the session's interview by ID, e.g. for an interview created through the API
+func (b *SessionBuilder) ForInterviewID(interviewID string) *SessionBuilder {
+	b.session.InterviewID = interviewID
+	return b
+}
+
+// WithID sets the session ID (only used when writing to a store)
+func (b *SessionBuilder) WithID(id string) *SessionBuilder {
+	b.session.ID = id
+	return b
+}
+
+// WithLanguage overrides the session language
+func (b *SessionBuilder) WithLanguage(language string) *SessionBuilder {
+	b.session.SessionLanguage = language
+	return b
+}
+
+// WithStatus sets the session status ("active", "completed" or "abandoned")
+func (b *SessionBuilder) WithStatus(status string) *SessionBuilder {
+	b.session.Status = status
+	return b
+}
+
+// WithStartedAt sets when the session started; transcript messages follow one second apart
+func (b *SessionBuilder) WithStartedAt(startedAt time.Time) *SessionBuilder {
+	b.session.StartedAt = startedAt
+	b.session.CreatedAt = startedAt
+	return b
+}
+
+// WithTranscript appends question and answer pairs to the conversation
+func (b *SessionBuilder) WithTranscript(pairs ...QA) *SessionBuilder {
+	b.transcript = append(b.transcript, pairs...)
+	return b
+}
+
+// Build returns a new copy of the session and its transcript as chat messages
+// Each pair is an AI message (the greeting first, then questions) followed by the user's answer.
+// SessionLanguage stays empty unless set, so starting the session through the API keeps the
+// interview's language.
+func (b *SessionBuilder) Build() (*data.ChatSession, []*data.ChatMessage) {
+	session := b.session
+	if session.StartedAt.IsZero() {
+		session.StartedAt = time.Now()
+		session.CreatedAt = session.StartedAt
+	}
+
+	messages := make([]*data.ChatMessage, 0, 2*len(b.transcript))
+	for i, pair := range b.transcript {
+		subtype := data.MessageSubtypeQuestion
+		if i == 0 {
+			subtype = data.MessageSubtypeGreeting
+		}
+		session.AskedQuestions = append(session.AskedQuestions, pair.Question)
+		messages = append(messages,
+			&data.ChatMessage{ID: data.GenerateID(), SessionID: session.ID, Type: "ai", Subtype: subtype, Content: pair.Question},
+			&data.ChatMessage{ID: data.GenerateID(), SessionID: session.ID, Type: "user", Content: pair.Answer},
+		)
+	}
+	for i, msg := range messages {
+		msg.Timestamp = session.StartedAt.Add(time.Duration(i) * time.Second)
+	}
+	return &session, messages
+}
+
+// Answers returns the candidate's answers in the transcript, in order
+func (b *SessionBuilder) Answers() []string {
+	answers := make([]string, len(b.transcript))
+	for i, pair := range b.transcript {
+		answers[i] = pair.Answer
+	}
+	return answers
+}
+
+// Create writes the session and its transcript to store, failing the test on error
+func (b *SessionBuilder) Create(t testing.TB, store data.Store) *data.ChatSession {
+	t.Helper()
+	session, messages := b.Build()
+	if session.SessionLanguage == "" {
+		session.SessionLanguage = "en"
+	}
+	if err := store.CreateChatSession(session); err != nil {
+		t.Fatalf("failed to create chat session: %v", err)
+	}
+	for _, msg := range messages {
+		if err := store.AddChatMessage(session.ID, msg); err != nil {
+			t.Fatalf("failed to add chat message: %v", err)
+		}
+	}
+	return session
+}
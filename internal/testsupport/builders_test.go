@@ -0,0 +1,129 @@
+package testsupport
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/zidane0000/ai-interview-platform/data"
+)
+
+func newMemoryStore(t *testing.T) *data.HybridStore {
+	t.Helper()
+	store, err := data.NewHybridStore(data.BackendMemory, "")
+	if err != nil {
+		t.Fatalf("failed to create store: %v", err)
+	}
+	return store
+}
+
+func TestInterviewBuilder_Defaults(t *testing.T) {
+	interview := NewInterviewBuilder().Build()
+	if interview.ID == "" || interview.CandidateName == "" || interview.InterviewType != "general" {
+		t.Errorf("expected required fields to be filled, got %+v", interview)
+	}
+	if interview.InterviewLanguage != "en" || interview.InterviewMode != data.InterviewModeStructured || !interview.IsAdaptive() {
+		t.Errorf("expected a structured, adaptive English interview, got %+v", interview)
+	}
+	if !reflect.DeepEqual([]string(interview.Questions), []string{"Q1"}) {
+		t.Errorf("expected one question, got %v", interview.Questions)
+	}
+	if other := NewInterviewBuilder().Build(); other.ID == interview.ID {
+		t.Error("expected each builder to get its own ID")
+	}
+}
+
+func TestInterviewBuilder_Create(t *testing.T) {
+	store := newMemoryStore(t)
+	start := time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC)
+	builder := NewInterviewBuilder().
+		WithID("built").
+		WithCandidate("Ada").
+		WithLanguage("zh-TW").
+		WithQuestions(3).
+		WithType("technical").
+		WithSchedule(&start, nil).
+		WithWebhook("https://hooks.example.com/a", "s3cret").
+		WithAdaptive(false)
+	builder.Create(t, store)
+
+	stored, err := store.GetInterview("built")
+	if err != nil {
+		t.Fatalf("expected the interview to be stored: %v", err)
+	}
+	if stored.CandidateName != "Ada" || stored.InterviewLanguage != "zh-TW" || stored.InterviewType != "technical" {
+		t.Errorf("unexpected interview %+v", stored)
+	}
+	if !reflect.DeepEqual([]string(stored.Questions), []string{"Q1", "Q2", "Q3"}) {
+		t.Errorf("expected numbered questions, got %v", stored.Questions)
+	}
+	if stored.ScheduledStart == nil || !stored.ScheduledStart.Equal(start) || stored.NotifyWebhookURL == "" || stored.IsAdaptive() {
+		t.Errorf("expected schedule, webhook and fixed difficulty, got %+v", stored)
+	}
+
+	// Built copies don't share state with the builder
+	copied := builder.Build()
+	copied.Questions[0] = "Changed"
+	if builder.Build().Questions[0] != "Q1" {
+		t.Error("expected Build to return an independent copy")
+	}
+}
+
+func TestSessionBuilder_Create(t *testing.T) {
+	store := newMemoryStore(t)
+	interview := NewInterviewBuilder().WithLanguage("zh-TW").Create(t, store)
+	startedAt := time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC)
+
+	session := NewSessionBuilder().
+		ForInterview(interview).
+		WithStartedAt(startedAt).
+		WithStatus("completed").
+		WithTranscript(Pair("Tell me about yourself?", "I build APIs"), Pair("Why Go?", "Simplicity")).
+		Create(t, store)
+
+	if session.InterviewID != interview.ID || session.SessionLanguage != "zh-TW" || session.Status != "completed" {
+		t.Errorf("unexpected session %+v", session)
+	}
+	if !reflect.DeepEqual([]string(session.AskedQuestions), []string{"Tell me about yourself?", "Why Go?"}) {
+		t.Errorf("expected the transcript questions to be asked, got %v", session.AskedQuestions)
+	}
+
+	messages, err := store.GetChatMessages(session.ID)
+	if err != nil {
+		t.Fatalf("failed to get messages: %v", err)
+	}
+	expected := []struct{ msgType, subtype, content string }{
+		{"ai", data.MessageSubtypeGreeting, "Tell me about yourself?"},
+		{"user", "", "I build APIs"},
+		{"ai", data.MessageSubtypeQuestion, "Why Go?"},
+		{"user", "", "Simplicity"},
+	}
+	if len(messages) != len(expected) {
+		t.Fatalf("expected %d messages, got %d", len(expected), len(messages))
+	}
+	for i, want := range expected {
+		msg := messages[i]
+		if msg.Type != want.msgType || msg.Subtype != want.subtype || msg.Content != want.content {
+			t.Errorf("message %d: expected %+v, got %+v", i, want, msg)
+		}
+		if !msg.Timestamp.Equal(startedAt.Add(time.Duration(i) * time.Second)) {
+			t.Errorf("message %d: expected messages one second apart, got %v", i, msg.Timestamp)
+		}
+	}
+}
+
+func TestSessionBuilder_Defaults(t *testing.T) {
+	store := newMemoryStore(t)
+	builder := NewSessionBuilder().ForInterviewID("api-interview")
+
+	// The language is left to the interview until the session is stored
+	if session, messages := builder.Build(); session.SessionLanguage != "" || len(messages) != 0 || session.Status != "active" {
+		t.Errorf("unexpected built session %+v with %d messages", session, len(messages))
+	}
+	if session := builder.Create(t, store); session.SessionLanguage != "en" || session.StartedAt.IsZero() {
+		t.Errorf("expected stored sessions to default to English and now, got %+v", session)
+	}
+	if answers := NewSessionBuilder().WithTranscript(Pair("Q1", "A1"), Pair("Q2", "A2")).Answers(); !reflect.DeepEqual(answers, []string{"A1", "A2"}) {
+		t.Errorf("expected the answers in order, got %v", answers)
+	}
+}
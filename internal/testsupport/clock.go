@@ -0,0 +1,20 @@
+package testsupport
+
+import (
+	"sync"
+	"time"
+)
+
+// SteppingClock returns a clock reading start on its first call and step later on every call
+// after, so records stamped with it get distinct, ordered timestamps without sleeping
+func SteppingClock(start time.Time, step time.Duration) func() time.Time {
+	var mu sync.Mutex
+	next := start
+	return func() time.Time {
+		mu.Lock()
+		defer mu.Unlock()
+		now := next
+		next = next.Add(step)
+		return now
+	}
+}
@@ -0,0 +1,16 @@
+package testsupport
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSteppingClock(t *testing.T) {
+	start := time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC)
+	clock := SteppingClock(start, time.Second)
+	for i := 0; i < 3; i++ {
+		if got, want := clock(), start.Add(time.Duration(i)*time.Second); !got.Equal(want) {
+			t.Errorf("reading %d: expected %v, got %v", i, want, got)
+		}
+	}
+}
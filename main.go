@@ -5,6 +5,9 @@ package main
 import (
 	"context"
 	"embed"
+	"errors"
+	"flag"
+	"io"
 	"io/fs"
 	"net/http"
 	"os"
@@ -16,7 +19,9 @@ import (
 	"github.com/zidane0000/ai-interview-platform/api"
 	"github.com/zidane0000/ai-interview-platform/config"
 	"github.com/zidane0000/ai-interview-platform/data"
+	"github.com/zidane0000/ai-interview-platform/data/seed"
 	"github.com/zidane0000/ai-interview-platform/utils"
+	"github.com/zidane0000/ai-interview-platform/version"
 )
 
 //go:embed frontend/dist
@@ -52,7 +57,8 @@ func spaHandler() http.Handler {
 }
 
 // gracefulShutdown handles graceful shutdown of the application
-func gracefulShutdown(server *http.Server, timeout time.Duration) {
+// stopWorkers stops background workers once the server has stopped taking requests, before the store closes
+func gracefulShutdown(server *http.Server, timeout time.Duration, stopWorkers func()) {
 	// Create a channel to receive OS signals
 	quit := make(chan os.Signal, 1)
 
@@ -73,6 +79,7 @@ func gracefulShutdown(server *http.Server, timeout time.Duration) {
 
 	// Additional cleanup operations
 	utils.Infof("Performing cleanup operations...")
+	stopWorkers()
 	// Close database connections if available
 	if data.GlobalStore != nil {
 		if err := data.GlobalStore.Close(); err != nil {
@@ -84,13 +91,64 @@ func gracefulShutdown(server *http.Server, timeout time.Duration) {
 	utils.Infof("Graceful shutdown completed successfully")
 }
 
+// reloadReadOnlyOnSIGHUP re-reads READ_ONLY (see config.ReloadReadOnly) on every SIGHUP, so
+// maintenance mode can be switched without a restart or the admin token
+func reloadReadOnlyOnSIGHUP(deps *api.HandlerDependencies) {
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	go func() {
+		for range hup {
+			deps.SetReadOnly(config.ReloadReadOnly())
+		}
+	}()
+}
+
+// seedStore seeds the global store with dataset; a store that already has data is left alone unless force is set
+// Returns false when seeding failed.
+func seedStore(dataset string, force bool) bool {
+	summary, err := seed.Run(data.GlobalStore, dataset, seed.Options{Force: force})
+	switch {
+	case errors.Is(err, seed.ErrStoreNotEmpty):
+		utils.Infof("Store already has data; skipping the %s seed (use --seed-force to seed anyway)", dataset)
+	case err != nil:
+		utils.Errorf("failed to seed %s data: %v", dataset, err)
+		return false
+	default:
+		utils.Infof("Seeded %s data: %d interviews, %d chat sessions, %d messages, %d evaluations",
+			dataset, summary.Interviews, summary.Sessions, summary.Messages, summary.Evaluations)
+	}
+	return true
+}
+
 func main() {
+	os.Exit(runCLI(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+// serve runs the HTTP server until it is shut down; it is the default command, so running the
+// binary without arguments (or with only the flags below) behaves as it always has
+func serve(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet(os.Args[0], flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	checkMode := flags.Bool("check", utils.GetEnvBool("CHECK_MODE", false), "check config, store and AI client, print a JSON report and exit without serving")
+	checkAI := flags.Bool("check-ai", false, "with --check, also validate the AI provider credentials")
+	seedDataset := flags.String("seed", os.Getenv("SEED_ON_START"), "seed the store with a dataset (\"demo\") on startup when it is empty")
+	seedForce := flags.Bool("seed-force", false, "with --seed, seed even when the store is not empty")
+	if code, ok := parseFlags(flags, args); !ok {
+		return code
+	}
+	if *checkMode {
+		// stdout carries only the report; progress logs go to stderr
+		utils.InfoWriter = stderr
+		return runCheckCommand(stdout, *checkAI)
+	}
+	utils.Infof("AI Interview Backend %s", version.Get())
+
 	// Load configuration
 	utils.Infof("Loading configuration...")
 	cfg, err := config.LoadConfig()
 	if err != nil {
 		utils.Errorf("failed to load config: %v", err)
-		os.Exit(1)
+		return exitFailure
 	}
 
 	// TODO: Initialize logging with proper configuration
@@ -99,10 +157,10 @@ func main() {
 
 	// Initialize hybrid store (auto-detects memory vs database backend)
 	utils.Infof("Initializing data store...")
-	err = data.InitGlobalStore()
+	err = data.InitGlobalStore(cfg.DatabaseURL, cfg.DatabaseReplicaURL)
 	if err != nil {
 		utils.Errorf("failed to initialize store: %v", err)
-		os.Exit(1)
+		return exitFailure
 	}
 
 	// Log the backend being used
@@ -111,14 +169,20 @@ func main() {
 	} else {
 		utils.Infof("Using in-memory store backend (set DATABASE_URL for database mode)")
 	}
+	// Seed development data (--seed / SEED_ON_START) before serving
+	if *seedDataset != "" && !seedStore(*seedDataset, *seedForce) {
+		return exitFailure
+	}
 	// TODO: Add store health checks
 	// if err := data.GlobalStore.Health(); err != nil {
 	//     utils.Errorf("store health check failed: %v", err)
 	// }
 
-	// Set up router with injected config (includes API routes and frontend serving)
+	// Set up router with injected config and the global store (includes API routes and frontend serving)
+	// The janitor and webhook worker below share these dependencies
+	deps := api.NewHandlerDependencies(cfg, data.GlobalStore)
 	frontendHandler := spaHandler()
-	router := api.SetupRouter(cfg, frontendHandler)
+	router := api.SetupRouter(cfg, deps, frontendHandler)
 	// TODO: Add HTTPS support with TLS configuration
 	// TODO: Add health check endpoints
 	// TODO: Add metrics and monitoring endpoints
@@ -139,9 +203,29 @@ func main() {
 			os.Exit(1)
 		}
 	}()
+	// Switch read-only maintenance mode on SIGHUP
+	reloadReadOnlyOnSIGHUP(deps)
+	// Abandon chat sessions left idle (CHAT_SESSION_IDLE_TIMEOUT); stopped when main returns
+	janitorCtx, stopJanitor := context.WithCancel(context.Background())
+	defer stopJanitor()
+	api.StartSessionJanitor(janitorCtx, cfg, deps)
+	// Delete chat transcripts past TRANSCRIPT_RETENTION_DAYS; stopped with the janitor
+	api.StartRetentionWorker(janitorCtx, cfg, deps)
+	// Vacuum the hot tables every DB_MAINTENANCE_INTERVAL; stopped with the janitor
+	api.StartMaintenanceWorker(janitorCtx, cfg, deps)
+	// Deliver queued webhook notifications, including those left over from a previous run
+	webhookCtx, stopWebhooks := context.WithCancel(context.Background())
+	webhookWorker := api.StartWebhookWorker(webhookCtx, deps)
+
 	utils.Infof("Server successfully started on port %s", cfg.Port)
 	utils.Infof("Frontend can now connect to: http://localhost:%s", cfg.Port)
 
 	// Start graceful shutdown handler (this will block until shutdown signal)
-	gracefulShutdown(server, cfg.ShutdownTimeout)
+	gracefulShutdown(server, cfg.ShutdownTimeout, func() {
+		stopJanitor()
+		stopWebhooks()
+		webhookWorker.Wait()
+		deps.Workers.Stop()
+	})
+	return exitOK
 }
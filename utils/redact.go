@@ -0,0 +1,68 @@
+// Redaction of secrets and personal data before text leaves our infrastructure or is retained for debugging
+package utils
+
+import "regexp"
+
+// RedactedPlaceholder replaces every value masked by Redact
+const RedactedPlaceholder = "[REDACTED]"
+
+// Built-in types of personal data, used in typed placeholders such as [EMAIL_1]
+const (
+	RedactTypeEmail      = "EMAIL"
+	RedactTypePhone      = "PHONE"
+	RedactTypeNationalID = "NATIONAL_ID"
+)
+
+// RedactPattern detects one type of sensitive value; Type names the placeholder and should be upper case
+type RedactPattern struct {
+	Type    string
+	Pattern *regexp.Regexp
+}
+
+// secretPatterns match API keys and bearer tokens
+var secretPatterns = []RedactPattern{
+	{Type: "API_KEY", Pattern: regexp.MustCompile(`sk-[A-Za-z0-9_\-]{16,}`)},                  // OpenAI-style secret keys
+	{Type: "API_KEY", Pattern: regexp.MustCompile(`AIza[0-9A-Za-z_\-]{30,}`)},                 // Google API keys
+	{Type: "BEARER_TOKEN", Pattern: regexp.MustCompile(`(?i)bearer\s+[A-Za-z0-9._~+/\-]+=*`)}, // Authorization header values
+}
+
+// piiPatterns match email addresses, national IDs and phone numbers
+// National IDs run before phone numbers so their digit groups are not taken for phone numbers
+var piiPatterns = []RedactPattern{
+	{Type: RedactTypeEmail, Pattern: regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,}`)},
+	{Type: RedactTypeNationalID, Pattern: regexp.MustCompile(`\b(?:\d{3}-\d{2}-\d{4}|[A-Z][12]\d{8})\b`)}, // US SSN, Taiwan ID
+	{Type: RedactTypePhone, Pattern: regexp.MustCompile(`(?:\+\d{1,3}[\s\-]?)?(?:\(\d{2,4}\)[\s\-]?|\b\d{2,4}[\s\-])\d{3,4}[\s\-]?\d{3,4}\b|\b09\d{8}\b`)},
+}
+
+// TypedPlaceholderPattern matches placeholders produced by RedactTyped, such as [EMAIL_1]
+var TypedPlaceholderPattern = regexp.MustCompile(`\[([A-Z][A-Z0-9_]*)_(\d+)\]`)
+
+// Redact masks API keys, bearer tokens, email addresses, national IDs and phone numbers in text
+// Free-form personal data such as names cannot be detected and is left as is
+func Redact(text string) string {
+	mask := func(string, string) string { return RedactedPlaceholder }
+	return RedactTyped(redactWith(text, secretPatterns, mask), nil, mask)
+}
+
+// RedactTyped replaces email addresses, national IDs, phone numbers and matches of custom with
+// the placeholder returned for each value's type. Custom patterns are applied first so
+// organization-specific formats win over the built-ins. Typed placeholders already in text are kept.
+func RedactTyped(text string, custom []RedactPattern, placeholder func(redactType, value string) string) string {
+	return redactWith(redactWith(text, custom, placeholder), piiPatterns, placeholder)
+}
+
+// redactWith applies patterns in order, skipping invalid ones and values that are typed placeholders
+func redactWith(text string, patterns []RedactPattern, placeholder func(redactType, value string) string) string {
+	for _, p := range patterns {
+		if p.Type == "" || p.Pattern == nil {
+			continue
+		}
+		text = p.Pattern.ReplaceAllStringFunc(text, func(value string) string {
+			if TypedPlaceholderPattern.MatchString(value) {
+				return value
+			}
+			return placeholder(p.Type, value)
+		})
+	}
+	return text
+}
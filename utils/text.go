@@ -0,0 +1,166 @@
+// Text analysis utilities
+package utils
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// IsCJK reports whether r is a Chinese, Japanese or Korean script character
+func IsCJK(r rune) bool {
+	return unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul)
+}
+
+// CountCJKCharacters counts the CJK script characters in text
+func CountCJKCharacters(text string) int {
+	count := 0
+	for _, r := range text {
+		if IsCJK(r) {
+			count++
+		}
+	}
+	return count
+}
+
+// CJKRatio returns the fraction of letters in text that are CJK characters
+// Digits, punctuation and whitespace are ignored; text without letters yields 0
+func CJKRatio(text string) float64 {
+	letters, cjk := 0, 0
+	for _, r := range text {
+		if !unicode.IsLetter(r) {
+			continue
+		}
+		letters++
+		if IsCJK(r) {
+			cjk++
+		}
+	}
+	if letters == 0 {
+		return 0
+	}
+	return float64(cjk) / float64(letters)
+}
+
+// LatinRatio returns the fraction of letters in text that are Latin script characters
+// Digits, punctuation and whitespace are ignored; text without letters yields 0
+func LatinRatio(text string) float64 {
+	letters, latin := 0, 0
+	for _, r := range text {
+		if !unicode.IsLetter(r) {
+			continue
+		}
+		letters++
+		if unicode.Is(unicode.Latin, r) {
+			latin++
+		}
+	}
+	if letters == 0 {
+		return 0
+	}
+	return float64(latin) / float64(letters)
+}
+
+// CountWords counts the words in text
+// CJK text has no spaces between words, so each CJK character counts as one word
+func CountWords(text string) int {
+	return len(wordEnds(text))
+}
+
+// wordEnds returns the byte offset just past each word of text
+// Punctuation belongs to the word it follows but never starts one
+func wordEnds(text string) []int {
+	var ends []int
+	inWord := false
+	for i, r := range text {
+		switch {
+		case unicode.IsSpace(r):
+			if inWord {
+				ends = append(ends, i)
+			}
+			inWord = false
+		case IsCJK(r):
+			if inWord {
+				ends = append(ends, i)
+			}
+			ends = append(ends, i+utf8.RuneLen(r))
+			inWord = false
+		case inWord, unicode.IsLetter(r), unicode.IsDigit(r):
+			inWord = true
+		}
+	}
+	if inWord {
+		ends = append(ends, len(text))
+	}
+	return ends
+}
+
+// TruncateWords shortens text to at most maxWords words (see CountWords), reporting whether it cut
+// The cut falls at the end of the last complete sentence that fits, or after the last word that
+// fits when no sentence does, and is marked with an ellipsis. maxWords <= 0 means no limit.
+func TruncateWords(text string, maxWords int) (string, bool) {
+	ends := wordEnds(text)
+	if maxWords <= 0 || len(ends) <= maxWords {
+		return text, false
+	}
+	cut := text[:ends[maxWords-1]]
+	if end := lastSentenceEnd(cut); end > 0 {
+		cut = cut[:end]
+	}
+	cut = strings.TrimSpace(cut)
+	if last, _ := utf8.DecodeLastRuneInString(cut); last < utf8.RuneSelf {
+		return cut + " …", true
+	}
+	return cut + "…", true
+}
+
+// lastSentenceEnd returns the byte offset just past the last sentence-ending punctuation in text,
+// or 0 when there is none; ".", "!" and "?" only end a sentence before whitespace or the end of text
+func lastSentenceEnd(text string) int {
+	end := 0
+	for i, r := range text {
+		next := i + utf8.RuneLen(r)
+		switch r {
+		case '。', '！', '？':
+			end = next
+		case '.', '!', '?':
+			if following, _ := utf8.DecodeRuneInString(text[next:]); next == len(text) || unicode.IsSpace(following) {
+				end = next
+			}
+		}
+	}
+	return end
+}
+
+// SimilarityRatio returns how alike a and b are, from 0 (nothing in common) to 1 (identical)
+// It is one minus their Levenshtein distance in characters over the longer length, compared
+// case-insensitively with whitespace runs collapsed, so re-spaced or re-cased copies score 1.
+func SimilarityRatio(a, b string) float64 {
+	x := []rune(strings.ToLower(strings.Join(strings.Fields(a), " ")))
+	y := []rune(strings.ToLower(strings.Join(strings.Fields(b), " ")))
+	longest := max(len(x), len(y))
+	if longest == 0 {
+		return 1
+	}
+	return 1 - float64(levenshtein(x, y))/float64(longest)
+}
+
+// levenshtein returns the edit distance between a and b, keeping one row of the table
+func levenshtein(a, b []rune) int {
+	row := make([]int, len(b)+1)
+	for j := range row {
+		row[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		diagonal := row[0]
+		row[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			diagonal, row[j] = row[j], min(row[j]+1, row[j-1]+1, diagonal+cost)
+		}
+	}
+	return row[len(b)]
+}
@@ -1,7 +1,9 @@
 package utils_test
 
 import (
+	"fmt"
 	"os"
+	"regexp"
 	"testing"
 	"time"
 
@@ -163,3 +165,177 @@ func TestGetEnvDuration(t *testing.T) {
 		})
 	}
 }
+
+func TestCountCJKCharacters(t *testing.T) {
+	tests := []struct {
+		text     string
+		expected int
+	}{
+		{"", 0},
+		{"Hello, world", 0},
+		{"你好，世界", 4},
+		{"Go 語言 API", 2},
+		{"こんにちは", 5},
+		{"안녕하세요", 5},
+	}
+
+	for _, tt := range tests {
+		if got := utils.CountCJKCharacters(tt.text); got != tt.expected {
+			t.Errorf("CountCJKCharacters(%q) = %d, expected %d", tt.text, got, tt.expected)
+		}
+	}
+}
+
+func TestCJKRatio(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		expected float64
+	}{
+		{"empty", "", 0},
+		{"no letters", "123 !?", 0},
+		{"english", "Tell me about yourself.", 0},
+		{"chinese with punctuation", "請介紹一下你自己。", 1},
+		{"mixed", "ab你好", 0.5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := utils.CJKRatio(tt.text); got != tt.expected {
+				t.Errorf("CJKRatio(%q) = %v, expected %v", tt.text, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestLatinRatio(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		expected float64
+	}{
+		{"empty", "", 0},
+		{"english", "Tell me about yourself.", 1},
+		{"chinese", "請介紹一下你自己。", 0},
+		{"mixed", "ab你好", 0.5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := utils.LatinRatio(tt.text); got != tt.expected {
+				t.Errorf("LatinRatio(%q) = %v, expected %v", tt.text, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestCountWords(t *testing.T) {
+	tests := []struct {
+		text     string
+		expected int
+	}{
+		{"", 0},
+		{"Tell me about yourself.", 4},
+		{"  spaced   out  ", 2},
+		{"請介紹你自己。", 6},
+		{"Go 語言", 3},
+		{"— well, ok", 2},
+	}
+	for _, tt := range tests {
+		if got := utils.CountWords(tt.text); got != tt.expected {
+			t.Errorf("CountWords(%q) = %d, expected %d", tt.text, got, tt.expected)
+		}
+	}
+}
+
+func TestSimilarityRatio(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		expected float64
+	}{
+		{"", "", 1},
+		{"hello", "", 0},
+		{"I like Go", "I like Go", 1},
+		{"I  like\tGO ", "i like go", 1},
+		{"kitten", "sitting", 1 - 3.0/7},
+		{"abc", "xyz", 0},
+		{"我喜歡寫程式", "我喜歡寫程序", 1 - 1.0/6},
+	}
+	for _, tt := range tests {
+		if got := utils.SimilarityRatio(tt.a, tt.b); got < tt.expected-1e-9 || got > tt.expected+1e-9 {
+			t.Errorf("SimilarityRatio(%q, %q) = %v, expected %v", tt.a, tt.b, got, tt.expected)
+		}
+		if got, reverse := utils.SimilarityRatio(tt.a, tt.b), utils.SimilarityRatio(tt.b, tt.a); got != reverse {
+			t.Errorf("expected SimilarityRatio to be symmetric for %q and %q, got %v and %v", tt.a, tt.b, got, reverse)
+		}
+	}
+}
+
+func TestTruncateWords(t *testing.T) {
+	tests := []struct {
+		name      string
+		text      string
+		maxWords  int
+		expected  string
+		truncated bool
+	}{
+		{"short text untouched", "Good answers overall.", 10, "Good answers overall.", false},
+		{"exactly at the limit", "Good answers overall.", 3, "Good answers overall.", false},
+		{"no limit", "Good answers overall.", 0, "Good answers overall.", false},
+		{"cut at the last sentence that fits", "Strong start. Clear examples! Then it went on and on.", 6, "Strong start. Clear examples! …", true},
+		{"no sentence fits", "A single very long sentence without an end", 4, "A single very long …", true},
+		{"decimal point is not a boundary", "Scored 3.5 overall and more words here", 4, "Scored 3.5 overall and …", true},
+		{"chinese", "表現很好。但是細節不足，需要加強。", 8, "表現很好。…", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, truncated := utils.TruncateWords(tt.text, tt.maxWords)
+			if got != tt.expected || truncated != tt.truncated {
+				t.Errorf("TruncateWords(%q, %d) = %q, %v; expected %q, %v", tt.text, tt.maxWords, got, truncated, tt.expected, tt.truncated)
+			}
+		})
+	}
+}
+
+func TestRedact(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"openai key", `{"key":"sk-abcdefghijklmnopqrstuvwx"}`, `{"key":"[REDACTED]"}`},
+		{"google key", "key=AIzaSyA1234567890abcdefghijklmnopqrstu", "key=[REDACTED]"},
+		{"bearer token", "Authorization: Bearer abc.def-123", "Authorization: [REDACTED]"},
+		{"email", "reach me at jane.doe@example.com please", "reach me at [REDACTED] please"},
+		{"phone", "call +1 555-123-4567", "call [REDACTED]"},
+		{"local phone", "call (02) 2345-6789", "call [REDACTED]"},
+		{"national id", "ID A123456789", "ID [REDACTED]"},
+		{"plain text", "I led a team of 5 engineers", "I led a team of 5 engineers"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := utils.Redact(tt.input); got != tt.expected {
+				t.Errorf("Redact(%q) = %q, want %q", tt.input, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestRedactTyped(t *testing.T) {
+	custom := []utils.RedactPattern{
+		{Type: "EMPLOYEE_ID", Pattern: regexp.MustCompile(`EMP-\d{6}`)},
+		{Type: "", Pattern: regexp.MustCompile(`ignored`)},
+	}
+	counts := map[string]int{}
+	placeholder := func(redactType, value string) string {
+		counts[redactType]++
+		return fmt.Sprintf("[%s_%d]", redactType, counts[redactType])
+	}
+
+	got := utils.RedactTyped("EMP-004211 mailed jane@example.com from 0912345678; ignored [EMAIL_7]", custom, placeholder)
+	expected := "[EMPLOYEE_ID_1] mailed [EMAIL_1] from [PHONE_1]; ignored [EMAIL_7]"
+	if got != expected {
+		t.Errorf("RedactTyped() = %q, want %q", got, expected)
+	}
+}
@@ -0,0 +1,38 @@
+// Build information of the running binary, injected at link time
+package version
+
+import (
+	"fmt"
+	"runtime"
+)
+
+// Set when building, e.g.
+//
+//	go build -ldflags "-X github.com/zidane0000/ai-interview-platform/version.Version=v1.4.0 \
+//	  -X github.com/zidane0000/ai-interview-platform/version.Commit=$(git rev-parse --short HEAD) \
+//	  -X github.com/zidane0000/ai-interview-platform/version.BuildDate=$(date -u +%Y-%m-%dT%H:%M:%SZ)"
+//
+// Binaries built without the flags (go run, go test) report the defaults.
+var (
+	Version   = "dev"
+	Commit    = "unknown"
+	BuildDate = "unknown"
+)
+
+// Info is the build information of the running binary
+type Info struct {
+	Version   string
+	Commit    string
+	BuildDate string
+	GoVersion string
+}
+
+// Get returns the build information, including the Go runtime version
+func Get() Info {
+	return Info{Version: Version, Commit: Commit, BuildDate: BuildDate, GoVersion: runtime.Version()}
+}
+
+// String formats the build information for logs
+func (i Info) String() string {
+	return fmt.Sprintf("version %s (commit %s, built %s, %s)", i.Version, i.Commit, i.BuildDate, i.GoVersion)
+}